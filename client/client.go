@@ -18,6 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/opensds/opensds/pkg/utils/constants"
@@ -25,6 +27,10 @@ import (
 
 const (
 	OpensdsEndpoint = "OPENSDS_ENDPOINT"
+
+	// The number of resources requested in each page when all the resources
+	// are listed page by page.
+	defaultPageSize = 100
 )
 
 var (
@@ -39,6 +45,10 @@ type Client struct {
 	*VolumeMgr
 	*VersionMgr
 	*ReplicationMgr
+	*QuotaMgr
+	*EventMgr
+	*FileShareMgr
+	*JobMgr
 
 	cfg *Config
 }
@@ -88,6 +98,10 @@ func NewClient(c *Config) *Client {
 		VolumeMgr:      NewVolumeMgr(r, c.Endpoint, t),
 		VersionMgr:     NewVersionMgr(r, c.Endpoint, t),
 		ReplicationMgr: NewReplicationMgr(r, c.Endpoint, t),
+		QuotaMgr:       NewQuotaMgr(r, c.Endpoint, t),
+		EventMgr:       NewEventMgr(r, c.Endpoint, t),
+		FileShareMgr:   NewFileShareMgr(r, c.Endpoint, t),
+		JobMgr:         NewJobMgr(r, c.Endpoint, t),
 	}
 }
 
@@ -124,3 +138,42 @@ func processListParam(args []interface{}) (string, error) {
 
 	return u, nil
 }
+
+// listAllPages lists the resources page by page following the markers of
+// next page, and appends all of them to items which must be a pointer to
+// slice. If the caller asks for a specific page with limit, offset or
+// marker, only that page is requested.
+func listAllPages(r Receiver, urlStr string, args []interface{}, items interface{}) error {
+	var filter = map[string]string{}
+	if len(args) > 0 {
+		if len(args) > 1 {
+			return errors.New("only support one parameter that must be map[string]string")
+		}
+		for k, v := range args[0].(map[string]string) {
+			filter[k] = v
+		}
+	}
+	if filter["limit"] != "" || filter["offset"] != "" || filter["marker"] != "" {
+		param, _ := processListParam([]interface{}{filter})
+		if param != "" {
+			urlStr += "?" + param
+		}
+		return r.Recv(urlStr, "GET", nil, items)
+	}
+
+	all := reflect.ValueOf(items).Elem()
+	all.Set(reflect.MakeSlice(all.Type(), 0, 0))
+	filter["limit"] = strconv.Itoa(defaultPageSize)
+	for {
+		param, _ := processListParam([]interface{}{filter})
+		page := &Page{Items: reflect.New(all.Type()).Interface()}
+		if err := r.Recv(urlStr+"?"+param, "GET", nil, page); err != nil {
+			return err
+		}
+		all.Set(reflect.AppendSlice(all, reflect.ValueOf(page.Items).Elem()))
+		if page.Next == "" {
+			return nil
+		}
+		filter["marker"] = url.QueryEscape(page.Next)
+	}
+}
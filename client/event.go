@@ -0,0 +1,145 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/urls"
+)
+
+// NewEventMgr
+func NewEventMgr(r Receiver, edp string, tenantId string) *EventMgr {
+	return &EventMgr{
+		Receiver: r,
+		Endpoint: edp,
+		TenantId: tenantId,
+	}
+}
+
+// EventMgr
+type EventMgr struct {
+	Receiver
+	Endpoint string
+	TenantId string
+}
+
+// ListEvents lists the status transitions of resources, which can be
+// filtered by resourceType, resourceId, newStatus and since.
+func (e *EventMgr) ListEvents(args ...interface{}) ([]*model.EventSpec, error) {
+	return e.listEvents(urls.GenerateEventURL(urls.Client, e.TenantId), args)
+}
+
+// WatchEvents waits for the events after the since parameter, which is the
+// resourceVersion of the last event the caller has received. It accepts the
+// same filter as ListEvents, and the timeout parameter in seconds.
+func (e *EventMgr) WatchEvents(args ...interface{}) ([]*model.EventSpec, error) {
+	return e.listEvents(urls.GenerateEventURL(urls.Client, e.TenantId, "watch"), args)
+}
+
+func (e *EventMgr) listEvents(path string, args []interface{}) ([]*model.EventSpec, error) {
+	url := strings.Join([]string{e.Endpoint, path}, "/")
+
+	param, err := processListParam(args)
+	if err != nil {
+		return nil, err
+	}
+	if param != "" {
+		url += "?" + param
+	}
+
+	var res []*model.EventSpec
+	if err := e.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// unanchoredWatchTimeout bounds the watch of the resource which has no event
+// yet. Such a watch only returns the events from the time the server receives
+// it, so the events are listed again after it to catch the transitions which
+// happen before that.
+const unanchoredWatchTimeout = time.Second
+
+// WaitForStatus waits until the resource transits to the status, and returns
+// the event of that transition. An error is returned if the resource transits
+// to an error status instead, or nothing happens before timeout. If the last
+// transition of the resource has been to the status, it returns immediately.
+func (e *EventMgr) WaitForStatus(resourceType, resourceId, status string, timeout time.Duration) (*model.EventSpec, error) {
+	filter := map[string]string{
+		"resourceType": resourceType,
+		"resourceId":   resourceId,
+	}
+
+	var since int64
+	deadline := time.Now().Add(timeout)
+	for {
+		if since == 0 {
+			evts, err := e.ListEvents(filter)
+			if err != nil {
+				return nil, err
+			}
+			if len(evts) > 0 {
+				last := evts[len(evts)-1]
+				if done, err := reachStatus(last, status); done {
+					return last, err
+				}
+				since = last.ResourceVersion
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("timeout waiting for %s %s to be %s", resourceType, resourceId, status)
+		}
+		if since == 0 && remaining > unanchoredWatchTimeout {
+			remaining = unanchoredWatchTimeout
+		}
+		evts, err := e.WatchEvents(map[string]string{
+			"resourceType": resourceType,
+			"resourceId":   resourceId,
+			"since":        strconv.FormatInt(since, 10),
+			"timeout":      strconv.Itoa(int(math.Ceil(remaining.Seconds()))),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, evt := range evts {
+			if done, err := reachStatus(evt, status); done {
+				return evt, err
+			}
+			if evt.ResourceVersion > since {
+				since = evt.ResourceVersion
+			}
+		}
+	}
+}
+
+// reachStatus reports whether the waiting for status ends at the event.
+func reachStatus(evt *model.EventSpec, status string) (bool, error) {
+	if evt.NewStatus == status {
+		return true, nil
+	}
+	if model.IsErrorStatus(evt.NewStatus) {
+		return true, fmt.Errorf("%s %s transits to %s instead of %s",
+			evt.ResourceType, evt.ResourceId, evt.NewStatus, status)
+	}
+	return false, nil
+}
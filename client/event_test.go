@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+)
+
+var fe = &EventMgr{
+	Receiver: NewFakeEventReceiver(),
+}
+
+func TestListEvents(t *testing.T) {
+	expected := []*model.EventSpec{&SampleEvents[0]}
+
+	evts, err := fe.ListEvents(map[string]string{"resourceType": "volume"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(evts, expected) {
+		t.Errorf("Expected %v, got %v", expected, evts)
+		return
+	}
+}
+
+// watchReceiver returns no event in the list request, and returns the
+// events one by one in the watch requests.
+type watchReceiver struct {
+	events []*model.EventSpec
+	urls   []string
+}
+
+func (w *watchReceiver) Recv(url string, method string, in interface{}, out interface{}) error {
+	w.urls = append(w.urls, url)
+	res := out.(*[]*model.EventSpec)
+	if !strings.Contains(url, "/events/watch") || len(w.events) == 0 {
+		*res = []*model.EventSpec{}
+		return nil
+	}
+	*res, w.events = w.events[:1], w.events[1:]
+	return nil
+}
+
+func newWatchEvent(version int64, status string) *model.EventSpec {
+	return &model.EventSpec{
+		BaseModel:    &model.BaseModel{ResourceVersion: version},
+		ResourceType: model.EventResourceVolume,
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		NewStatus:    status,
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	r := &watchReceiver{events: []*model.EventSpec{
+		newWatchEvent(5, model.VolumeExtending),
+		newWatchEvent(6, model.VolumeAvailable),
+	}}
+	em := &EventMgr{Receiver: r}
+
+	evt, err := em.WaitForStatus(model.EventResourceVolume,
+		"bd5b12a8-a101-11e7-941e-d77981b584d8", model.VolumeAvailable, time.Minute)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if evt.ResourceVersion != 6 {
+		t.Errorf("Expected the event of version 6, got %v", evt)
+	}
+	if len(r.urls) != 3 || !strings.Contains(r.urls[2], "since=5") {
+		t.Errorf("Unexpected requests %v", r.urls)
+	}
+}
+
+func TestWaitForStatusWithError(t *testing.T) {
+	r := &watchReceiver{events: []*model.EventSpec{
+		newWatchEvent(5, model.VolumeError),
+	}}
+	em := &EventMgr{Receiver: r}
+
+	if _, err := em.WaitForStatus(model.EventResourceVolume,
+		"bd5b12a8-a101-11e7-941e-d77981b584d8", model.VolumeAvailable, time.Minute); err == nil {
+		t.Error("Expected error when volume transits to error status")
+	}
+	if _, err := em.WaitForStatus(model.EventResourceVolume,
+		"bd5b12a8-a101-11e7-941e-d77981b584d8", model.VolumeAvailable, time.Millisecond); err == nil {
+		t.Error("Expected error when waiting times out")
+	}
+}
+
+// lateReceiver returns the event in the second list request, as if the
+// volume transits before the server starts watching.
+type lateReceiver struct {
+	event *model.EventSpec
+	lists int
+}
+
+func (l *lateReceiver) Recv(url string, method string, in interface{}, out interface{}) error {
+	res := out.(*[]*model.EventSpec)
+	*res = []*model.EventSpec{}
+	if !strings.Contains(url, "/events/watch") {
+		if l.lists++; l.lists > 1 {
+			*res = []*model.EventSpec{l.event}
+		}
+	}
+	return nil
+}
+
+func TestWaitForStatusBeforeWatch(t *testing.T) {
+	r := &lateReceiver{event: newWatchEvent(5, model.VolumeAvailable)}
+	em := &EventMgr{Receiver: r}
+
+	evt, err := em.WaitForStatus(model.EventResourceVolume,
+		"bd5b12a8-a101-11e7-941e-d77981b584d8", model.VolumeAvailable, time.Minute)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if evt.ResourceVersion != 5 || r.lists != 2 {
+		t.Errorf("Expected the event of version 5 in the second list, got %v after %d lists", evt, r.lists)
+	}
+}
@@ -59,6 +59,22 @@ func NewFakeClient(config *Config) *Client {
 				Receiver: NewFakeVersionReceiver(),
 				Endpoint: config.Endpoint,
 			},
+			QuotaMgr: &QuotaMgr{
+				Receiver: NewFakeQuotaReceiver(),
+				Endpoint: config.Endpoint,
+			},
+			EventMgr: &EventMgr{
+				Receiver: NewFakeEventReceiver(),
+				Endpoint: config.Endpoint,
+			},
+			FileShareMgr: &FileShareMgr{
+				Receiver: NewFakeFileShareReceiver(),
+				Endpoint: config.Endpoint,
+			},
+			JobMgr: &JobMgr{
+				Receiver: NewFakeJobReceiver(),
+				Endpoint: config.Endpoint,
+			},
 		}
 	})
 	return fakeClient
@@ -178,6 +194,11 @@ func (*fakeProfileReceiver) Recv(
 				return err
 			}
 			break
+		case *[]*model.PolicySpec:
+			if err := json.Unmarshal([]byte(BytePolicies), out); err != nil {
+				return err
+			}
+			break
 		default:
 			return errors.New("output format not supported")
 		}
@@ -214,9 +235,15 @@ func (*fakeVolumeReceiver) Recv(
 	in interface{},
 	out interface{},
 ) error {
+	// All the resources are returned in one page.
+	if page, ok := out.(*Page); ok {
+		out = page.Items
+	}
 	switch strings.ToUpper(method) {
 	case "POST", "PUT":
 		switch out.(type) {
+		case nil:
+			break
 		case *model.VolumeSpec:
 			if err := json.Unmarshal([]byte(ByteVolume), out); err != nil {
 				return err
@@ -237,6 +264,11 @@ func (*fakeVolumeReceiver) Recv(
 				return err
 			}
 			break
+		case *model.GroupSnapshotSpec:
+			if err := json.Unmarshal([]byte(ByteGroupSnapshot), out); err != nil {
+				return err
+			}
+			break
 		default:
 			return errors.New("output format not supported")
 		}
@@ -253,6 +285,11 @@ func (*fakeVolumeReceiver) Recv(
 				return err
 			}
 			break
+		case *[]*model.PolicySpec:
+			if err := json.Unmarshal([]byte(BytePolicies), out); err != nil {
+				return err
+			}
+			break
 		case *model.VolumeAttachmentSpec:
 			if err := json.Unmarshal([]byte(ByteAttachment), out); err != nil {
 				return err
@@ -283,6 +320,16 @@ func (*fakeVolumeReceiver) Recv(
 				return err
 			}
 			break
+		case *model.GroupSnapshotSpec:
+			if err := json.Unmarshal([]byte(ByteGroupSnapshot), out); err != nil {
+				return err
+			}
+			break
+		case *[]*model.GroupSnapshotSpec:
+			if err := json.Unmarshal([]byte(ByteGroupSnapshots), out); err != nil {
+				return err
+			}
+			break
 		default:
 			return errors.New("output format not supported")
 		}
@@ -368,3 +415,109 @@ func (*fakeVersionReceiver) Recv(
 
 	return nil
 }
+
+func NewFakeQuotaReceiver() Receiver {
+	return &fakeQuotaReceiver{}
+}
+
+type fakeQuotaReceiver struct{}
+
+func (*fakeQuotaReceiver) Recv(
+	string,
+	method string,
+	in interface{},
+	out interface{},
+) error {
+	switch strings.ToUpper(method) {
+	case "GET", "PUT":
+		switch out.(type) {
+		case *model.QuotaSpec:
+			return json.Unmarshal([]byte(ByteQuota), out)
+		default:
+			return errors.New("output format not supported")
+		}
+	}
+	return errors.New("input method format not supported")
+}
+
+func NewFakeEventReceiver() Receiver {
+	return &fakeEventReceiver{}
+}
+
+type fakeEventReceiver struct{}
+
+func (*fakeEventReceiver) Recv(
+	string,
+	method string,
+	in interface{},
+	out interface{},
+) error {
+	switch strings.ToUpper(method) {
+	case "GET":
+		switch out.(type) {
+		case *[]*model.EventSpec:
+			return json.Unmarshal([]byte(ByteEvents), out)
+		default:
+			return errors.New("output format not supported")
+		}
+	}
+	return errors.New("input method format not supported")
+}
+
+func NewFakeJobReceiver() Receiver {
+	return &fakeJobReceiver{}
+}
+
+type fakeJobReceiver struct{}
+
+func (*fakeJobReceiver) Recv(
+	string,
+	method string,
+	in interface{},
+	out interface{},
+) error {
+	switch strings.ToUpper(method) {
+	case "GET":
+		switch out.(type) {
+		case *model.JobSpec:
+			return json.Unmarshal([]byte(ByteJob), out)
+		case *[]*model.JobSpec:
+			return json.Unmarshal([]byte(ByteJobs), out)
+		default:
+			return errors.New("output format not supported")
+		}
+	}
+	return errors.New("input method format not supported")
+}
+
+func NewFakeFileShareReceiver() Receiver {
+	return &fakeFileShareReceiver{}
+}
+
+type fakeFileShareReceiver struct{}
+
+func (*fakeFileShareReceiver) Recv(
+	string,
+	method string,
+	in interface{},
+	out interface{},
+) error {
+	switch strings.ToUpper(method) {
+	case "POST", "PUT", "GET":
+		switch out.(type) {
+		case *model.FileShareSpec:
+			return json.Unmarshal([]byte(ByteFileShare), out)
+		case *[]*model.FileShareSpec:
+			return json.Unmarshal([]byte(ByteFileShares), out)
+		case *model.FileShareAclSpec:
+			return json.Unmarshal([]byte(ByteFileShareAcl), out)
+		case *[]*model.FileShareAclSpec:
+			return json.Unmarshal([]byte(ByteFileShareAcls), out)
+		default:
+			return errors.New("output format not supported")
+		}
+	case "DELETE":
+		return nil
+	}
+	return errors.New("input method format not supported")
+}
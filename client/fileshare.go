@@ -0,0 +1,199 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strings"
+
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/urls"
+)
+
+// FileShareBuilder contains request body of handling a file share request.
+// Currently it's assigned as the pointer of FileShareSpec struct, but it
+// could be discussed if it's better to define an interface.
+type FileShareBuilder *model.FileShareSpec
+
+// ExtendFileShareBuilder contains request body of handling a file share
+// extension request.
+type ExtendFileShareBuilder *model.ExtendFileShareSpec
+
+// FileShareAclBuilder contains request body of handling a file share access
+// rule request.
+type FileShareAclBuilder *model.FileShareAclSpec
+
+// NewFileShareMgr method creates a FileShareMgr instance.
+func NewFileShareMgr(r Receiver, edp string, tenantId string) *FileShareMgr {
+	return &FileShareMgr{
+		Receiver: r,
+		Endpoint: edp,
+		TenantId: tenantId,
+	}
+}
+
+// FileShareMgr implementation
+type FileShareMgr struct {
+	Receiver
+	Endpoint string
+	TenantId string
+}
+
+// CreateFileShare implementation
+func (v *FileShareMgr) CreateFileShare(body FileShareBuilder) (*model.FileShareSpec, error) {
+	var res model.FileShareSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareURL(urls.Client, v.TenantId)}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetFileShare implementation
+func (v *FileShareMgr) GetFileShare(shareID string) (*model.FileShareSpec, error) {
+	var res model.FileShareSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareURL(urls.Client, v.TenantId, shareID)}, "/")
+
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListFileShares implementation
+func (v *FileShareMgr) ListFileShares(args ...interface{}) ([]*model.FileShareSpec, error) {
+	var res []*model.FileShareSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareURL(urls.Client, v.TenantId)}, "/")
+
+	param, err := processListParam(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if param != "" {
+		url += "?" + param
+	}
+
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateFileShare implementation
+func (v *FileShareMgr) UpdateFileShare(shareID string, body FileShareBuilder) (*model.FileShareSpec, error) {
+	var res model.FileShareSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareURL(urls.Client, v.TenantId, shareID)}, "/")
+
+	if err := v.Recv(url, "PUT", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ExtendFileShare implementation
+func (v *FileShareMgr) ExtendFileShare(shareID string, body ExtendFileShareBuilder) (*model.FileShareSpec, error) {
+	var res model.FileShareSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareURL(urls.Client, v.TenantId, shareID, "resize")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteFileShare implementation
+func (v *FileShareMgr) DeleteFileShare(shareID string) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareURL(urls.Client, v.TenantId, shareID)}, "/")
+
+	return v.Recv(url, "DELETE", nil, nil)
+}
+
+// CreateFileShareAcl implementation
+func (v *FileShareMgr) CreateFileShareAcl(body FileShareAclBuilder) (*model.FileShareAclSpec, error) {
+	var res model.FileShareAclSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareAclURL(urls.Client, v.TenantId)}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetFileShareAcl implementation
+func (v *FileShareMgr) GetFileShareAcl(aclID string) (*model.FileShareAclSpec, error) {
+	var res model.FileShareAclSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareAclURL(urls.Client, v.TenantId, aclID)}, "/")
+
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListFileShareAcls implementation
+func (v *FileShareMgr) ListFileShareAcls(args ...interface{}) ([]*model.FileShareAclSpec, error) {
+	var res []*model.FileShareAclSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareAclURL(urls.Client, v.TenantId)}, "/")
+
+	param, err := processListParam(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if param != "" {
+		url += "?" + param
+	}
+
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteFileShareAcl implementation
+func (v *FileShareMgr) DeleteFileShareAcl(aclID string) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateFileShareAclURL(urls.Client, v.TenantId, aclID)}, "/")
+
+	return v.Recv(url, "DELETE", nil, nil)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+)
+
+var fs = &FileShareMgr{
+	Receiver: NewFakeFileShareReceiver(),
+}
+
+func TestCreateFileShare(t *testing.T) {
+	expected := &SampleFileShares[0]
+
+	share, err := fs.CreateFileShare(&model.FileShareSpec{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(share, expected) {
+		t.Errorf("Expected %v, got %v", expected, share)
+		return
+	}
+}
+
+func TestListFileShares(t *testing.T) {
+	expected := []*model.FileShareSpec{&SampleFileShares[0]}
+
+	shares, err := fs.ListFileShares(map[string]string{"limit": "1"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(shares[:1], expected) {
+		t.Errorf("Expected %v, got %v", expected, shares)
+		return
+	}
+}
+
+func TestExtendFileShare(t *testing.T) {
+	share, err := fs.ExtendFileShare(SampleFileShares[0].Id, &model.ExtendFileShareSpec{NewSize: 2})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if share.Id != SampleFileShares[0].Id {
+		t.Errorf("Expected %v, got %v", SampleFileShares[0].Id, share.Id)
+	}
+}
+
+func TestDeleteFileShare(t *testing.T) {
+	if err := fs.DeleteFileShare(SampleFileShares[0].Id); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateFileShareAcl(t *testing.T) {
+	expected := &SampleFileShareAcls[0]
+
+	acl, err := fs.CreateFileShareAcl(&model.FileShareAclSpec{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(acl, expected) {
+		t.Errorf("Expected %v, got %v", expected, acl)
+		return
+	}
+}
+
+func TestListFileShareAcls(t *testing.T) {
+	acls, err := fs.ListFileShareAcls()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(acls) == 0 || !reflect.DeepEqual(acls[0], &SampleFileShareAcls[0]) {
+		t.Errorf("Expected %v, got %v", &SampleFileShareAcls[0], acls)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strings"
+
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/urls"
+)
+
+// NewJobMgr
+func NewJobMgr(r Receiver, edp string, tenantId string) *JobMgr {
+	return &JobMgr{
+		Receiver: r,
+		Endpoint: edp,
+		TenantId: tenantId,
+	}
+}
+
+// JobMgr
+type JobMgr struct {
+	Receiver
+	Endpoint string
+	TenantId string
+}
+
+// GetJob returns the job which tracks the progress of a background operation.
+func (j *JobMgr) GetJob(jobID string) (*model.JobSpec, error) {
+	var res model.JobSpec
+	url := strings.Join([]string{
+		j.Endpoint,
+		urls.GenerateJobURL(urls.Client, j.TenantId, jobID)}, "/")
+
+	if err := j.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListJobs lists the jobs, which can be filtered by type, resourceId and
+// status.
+func (j *JobMgr) ListJobs(args ...interface{}) ([]*model.JobSpec, error) {
+	var res []*model.JobSpec
+
+	url := strings.Join([]string{
+		j.Endpoint,
+		urls.GenerateJobURL(urls.Client, j.TenantId)}, "/")
+
+	param, err := processListParam(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if param != "" {
+		url += "?" + param
+	}
+
+	if err := j.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+)
+
+var fj = &JobMgr{
+	Receiver: NewFakeJobReceiver(),
+}
+
+func TestGetJob(t *testing.T) {
+	expected := &SampleJobs[0]
+
+	job, err := fj.GetJob("0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(job, expected) {
+		t.Errorf("Expected %v, got %v", expected, job)
+		return
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	expected := []*model.JobSpec{&SampleJobs[0]}
+
+	jobs, err := fj.ListJobs(map[string]string{"status": "succeeded"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(jobs, expected) {
+		t.Errorf("Expected %v, got %v", expected, jobs)
+		return
+	}
+}
@@ -122,6 +122,21 @@ func (p *ProfileMgr) DeleteProfile(prfID string) error {
 	return p.Recv(url, "DELETE", nil, nil)
 }
 
+// ListPolicies lists the policies which can be specified in the custom
+// properties of profiles.
+func (p *ProfileMgr) ListPolicies() ([]*model.PolicySpec, error) {
+	var res []*model.PolicySpec
+	url := strings.Join([]string{
+		p.Endpoint,
+		urls.GeneratePolicyURL(urls.Client, p.TenantId)}, "/")
+
+	if err := p.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // AddCustomProperty
 func (p *ProfileMgr) AddCustomProperty(prfID string, body CustomBuilder) (*model.CustomPropertiesSpec, error) {
 	var res model.CustomPropertiesSpec
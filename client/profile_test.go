@@ -178,3 +178,26 @@ func TestRemoveCustomProperty(t *testing.T) {
 		return
 	}
 }
+
+func TestListPolicies(t *testing.T) {
+	expected := []*model.PolicySpec{
+		{
+			Name:        "intervalSnapshot",
+			Type:        "operation",
+			Lifecycle:   "create",
+			Description: "Take a few snapshots of the volume every interval such as 12h after it's created.",
+			Value:       "12h",
+		},
+	}
+
+	policies, err := fpr.ListPolicies()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(policies, expected) {
+		t.Errorf("Expected %v, got %v", expected, policies)
+		return
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strings"
+
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/urls"
+)
+
+// QuotaBuilder contains request body of handling a quota request.
+// Currently it's assigned as the pointer of QuotaSpec struct, but it
+// could be discussed if it's better to define an interface.
+type QuotaBuilder *model.QuotaSpec
+
+// NewQuotaMgr
+func NewQuotaMgr(r Receiver, edp string, tenantId string) *QuotaMgr {
+	return &QuotaMgr{
+		Receiver: r,
+		Endpoint: edp,
+		TenantId: tenantId,
+	}
+}
+
+// QuotaMgr
+type QuotaMgr struct {
+	Receiver
+	Endpoint string
+	TenantId string
+}
+
+// GetQuota returns the quota of the specified tenant, the quota of current
+// tenant is returned if tenantId is empty.
+func (q *QuotaMgr) GetQuota(tenantId string) (*model.QuotaSpec, error) {
+	var res model.QuotaSpec
+	url := strings.Join([]string{
+		q.Endpoint,
+		urls.GenerateQuotaURL(urls.Client, q.tenant(tenantId))}, "/")
+
+	if err := q.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateQuota changes the limits of the specified tenant, the quota of
+// current tenant is updated if tenantId is empty.
+func (q *QuotaMgr) UpdateQuota(tenantId string, body QuotaBuilder) (*model.QuotaSpec, error) {
+	var res model.QuotaSpec
+	url := strings.Join([]string{
+		q.Endpoint,
+		urls.GenerateQuotaURL(urls.Client, q.tenant(tenantId))}, "/")
+
+	if err := q.Recv(url, "PUT", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (q *QuotaMgr) tenant(tenantId string) string {
+	if tenantId == "" {
+		return q.TenantId
+	}
+	return tenantId
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+)
+
+var fq = &QuotaMgr{
+	Receiver: NewFakeQuotaReceiver(),
+}
+
+func TestGetQuota(t *testing.T) {
+	expected := &SampleQuotas[0]
+
+	quota, err := fq.GetQuota("ef305038-cd12-4f3b-90bd-0612f83e14ee")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(quota, expected) {
+		t.Errorf("Expected %v, got %v", expected, quota)
+		return
+	}
+}
+
+func TestUpdateQuota(t *testing.T) {
+	body := &model.QuotaSpec{
+		Volumes:      10,
+		Gigabytes:    100,
+		Snapshots:    model.QuotaUnlimited,
+		Replications: model.QuotaUnlimited,
+	}
+	expected := &SampleQuotas[0]
+
+	quota, err := fq.UpdateQuota("ef305038-cd12-4f3b-90bd-0612f83e14ee", body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(quota, expected) {
+		t.Errorf("Expected %v, got %v", expected, quota)
+		return
+	}
+}
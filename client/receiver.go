@@ -23,6 +23,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -60,6 +61,15 @@ func (e *HttpError) Error() string {
 // ParamOption
 type HeaderOption map[string]string
 
+// Page is the output of paginated list request. The resources in the page
+// are unmarshaled into Items, and the paging information is read from the
+// response headers.
+type Page struct {
+	Items interface{}
+	Next  string
+	Total int
+}
+
 // Receiver
 type Receiver interface {
 	Recv(url string, method string, input interface{}, output interface{}) error
@@ -142,6 +152,11 @@ func request(urlStr string, method string, headers HeaderOption, input interface
 	if output == nil {
 		return nil
 	}
+	if page, ok := output.(*Page); ok {
+		page.Next = resp.Header.Get(constants.NextMarkerHeader)
+		page.Total, _ = strconv.Atoi(resp.Header.Get(constants.TotalCountHeader))
+		output = page.Items
+	}
 	if err = json.Unmarshal(rbody, output); err != nil {
 		return fmt.Errorf("failed to unmarshal result message: %v", err)
 	}
@@ -136,3 +136,25 @@ func (v *ReplicationMgr) FailoverReplication(replicaId string, body FailoverRepl
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId, "failover")}, "/")
 	return v.Recv(url, "POST", body, nil)
 }
+
+// ResetReplicationStatus sets the status of the replication, which is only
+// allowed to admin.
+func (v *ReplicationMgr) ResetReplicationStatus(replicaId string, body ResetStatusBuilder) (*model.ReplicationSpec, error) {
+	var res model.ReplicationSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId, "reset-status")}, "/")
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ForceDeleteReplication deletes the replication whatever status it is in,
+// which is only allowed to admin.
+func (v *ReplicationMgr) ForceDeleteReplication(replicaId string) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId, "force-delete")}, "/")
+	return v.Recv(url, "POST", nil, nil)
+}
@@ -31,6 +31,26 @@ type VolumeBuilder *model.VolumeSpec
 // could be discussed if it's better to define an interface.
 type ExtendVolumeBuilder *model.ExtendVolumeSpec
 
+// RevertVolumeBuilder contains request body of handling a revert volume
+// request. Currently it's assigned as the pointer of RevertVolumeSpec struct,
+// but it could be discussed if it's better to define an interface.
+type RevertVolumeBuilder *model.RevertVolumeSpec
+
+// MigrateVolumeBuilder contains request body of handling a migrate volume
+// request. Currently it's assigned as the pointer of MigrateVolumeSpec struct,
+// but it could be discussed if it's better to define an interface.
+type MigrateVolumeBuilder *model.MigrateVolumeSpec
+
+// RetypeVolumeBuilder contains request body of handling a retype volume
+// request. Currently it's assigned as the pointer of RetypeVolumeSpec struct,
+// but it could be discussed if it's better to define an interface.
+type RetypeVolumeBuilder *model.RetypeVolumeSpec
+
+// ResetStatusBuilder contains request body of handling a reset status
+// request. Currently it's assigned as the pointer of ResetStatusSpec struct,
+// but it could be discussed if it's better to define an interface.
+type ResetStatusBuilder *model.ResetStatusSpec
+
 // VolumeAttachmentBuilder contains request body of handling a volume request.
 // Currently it's assigned as the pointer of VolumeSpec struct, but it
 // could be discussed if it's better to define an interface.
@@ -46,6 +66,11 @@ type VolumeSnapshotBuilder *model.VolumeSnapshotSpec
 // struct, but it could be discussed if it's better to define an interface.
 type VolumeGroupBuilder *model.VolumeGroupSpec
 
+// GroupSnapshotBuilder contains request body of handling a group snapshot
+// request. Currently it's assigned as the pointer of GroupSnapshotSpec
+// struct, but it could be discussed if it's better to define an interface.
+type GroupSnapshotBuilder *model.GroupSnapshotSpec
+
 // NewVolumeMgr
 func NewVolumeMgr(r Receiver, edp string, tenantId string) *VolumeMgr {
 	return &VolumeMgr{
@@ -90,23 +115,28 @@ func (v *VolumeMgr) GetVolume(volID string) (*model.VolumeSpec, error) {
 	return &res, nil
 }
 
-// ListVolumes
-func (v *VolumeMgr) ListVolumes(args ...interface{}) ([]*model.VolumeSpec, error) {
+// ListVolumePolicies lists the policies specified in the profile of volume.
+func (v *VolumeMgr) ListVolumePolicies(volID string) ([]*model.PolicySpec, error) {
+	var res []*model.PolicySpec
 	url := strings.Join([]string{
 		v.Endpoint,
-		urls.GenerateVolumeURL(urls.Client, v.TenantId)}, "/")
+		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "policies")}, "/")
 
-	param, err := processListParam(args)
-	if err != nil {
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
-	if param != "" {
-		url += "?" + param
-	}
+	return res, nil
+}
+
+// ListVolumes
+func (v *VolumeMgr) ListVolumes(args ...interface{}) ([]*model.VolumeSpec, error) {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateVolumeURL(urls.Client, v.TenantId)}, "/")
 
 	var res []*model.VolumeSpec
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := listAllPages(v.Receiver, url, args, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -149,6 +179,73 @@ func (v *VolumeMgr) ExtendVolume(volID string, body ExtendVolumeBuilder) (*model
 	return &res, nil
 }
 
+// RevertVolume ...
+func (v *VolumeMgr) RevertVolume(volID string, body RevertVolumeBuilder) (*model.VolumeSpec, error) {
+	var res model.VolumeSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "revert")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// MigrateVolume ...
+func (v *VolumeMgr) MigrateVolume(volID string, body MigrateVolumeBuilder) (*model.VolumeSpec, error) {
+	var res model.VolumeSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "migrate")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RetypeVolume ...
+func (v *VolumeMgr) RetypeVolume(volID string, body RetypeVolumeBuilder) (*model.VolumeSpec, error) {
+	var res model.VolumeSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "retype")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ResetVolumeStatus sets the status of the volume, which is only allowed to
+// admin.
+func (v *VolumeMgr) ResetVolumeStatus(volID string, body ResetStatusBuilder) (*model.VolumeSpec, error) {
+	var res model.VolumeSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "reset-status")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ForceDeleteVolume deletes the volume whatever status it is in, which is
+// only allowed to admin.
+func (v *VolumeMgr) ForceDeleteVolume(volID string) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "force-delete")}, "/")
+
+	return v.Recv(url, "POST", nil, nil)
+}
+
 // CreateVolumeAttachment
 func (v *VolumeMgr) CreateVolumeAttachment(body VolumeAttachmentBuilder) (*model.VolumeAttachmentSpec, error) {
 	var res model.VolumeAttachmentSpec
@@ -221,6 +318,31 @@ func (v *VolumeMgr) DeleteVolumeAttachment(atcID string, body VolumeAttachmentBu
 	return v.Recv(url, "DELETE", body, nil)
 }
 
+// ResetVolumeAttachmentStatus sets the status of the volume attachment, which
+// is only allowed to admin.
+func (v *VolumeMgr) ResetVolumeAttachmentStatus(atcID string, body ResetStatusBuilder) (*model.VolumeAttachmentSpec, error) {
+	var res model.VolumeAttachmentSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateAttachmentURL(urls.Client, v.TenantId, atcID, "reset-status")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ForceDeleteVolumeAttachment deletes the volume attachment without
+// terminating the connection, which is only allowed to admin.
+func (v *VolumeMgr) ForceDeleteVolumeAttachment(atcID string) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateAttachmentURL(urls.Client, v.TenantId, atcID, "force-delete")}, "/")
+
+	return v.Recv(url, "POST", nil, nil)
+}
+
 // CreateVolumeSnapshot
 func (v *VolumeMgr) CreateVolumeSnapshot(body VolumeSnapshotBuilder) (*model.VolumeSnapshotSpec, error) {
 	var res model.VolumeSnapshotSpec
@@ -257,16 +379,7 @@ func (v *VolumeMgr) ListVolumeSnapshots(args ...interface{}) ([]*model.VolumeSna
 		v.Endpoint,
 		urls.GenerateSnapshotURL(urls.Client, v.TenantId)}, "/")
 
-	param, err := processListParam(args)
-	if err != nil {
-		return nil, err
-	}
-
-	if param != "" {
-		url += "?" + param
-	}
-
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := listAllPages(v.Receiver, url, args, &res); err != nil {
 		return nil, err
 	}
 
@@ -296,6 +409,31 @@ func (v *VolumeMgr) UpdateVolumeSnapshot(snpID string, body VolumeSnapshotBuilde
 	return &res, nil
 }
 
+// ResetVolumeSnapshotStatus sets the status of the volume snapshot, which is
+// only allowed to admin.
+func (v *VolumeMgr) ResetVolumeSnapshotStatus(snpID string, body ResetStatusBuilder) (*model.VolumeSnapshotSpec, error) {
+	var res model.VolumeSnapshotSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateSnapshotURL(urls.Client, v.TenantId, snpID, "reset-status")}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ForceDeleteVolumeSnapshot deletes the volume snapshot whatever status it is
+// in, which is only allowed to admin.
+func (v *VolumeMgr) ForceDeleteVolumeSnapshot(snpID string) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateSnapshotURL(urls.Client, v.TenantId, snpID, "force-delete")}, "/")
+
+	return v.Recv(url, "POST", nil, nil)
+}
+
 // CreateVolumeGroup
 func (v *VolumeMgr) CreateVolumeGroup(body VolumeGroupBuilder) (*model.VolumeGroupSpec, error) {
 	var res model.VolumeGroupSpec
@@ -369,3 +507,63 @@ func (v *VolumeMgr) UpdateVolumeGroup(vgId string, body VolumeGroupBuilder) (*mo
 
 	return &res, nil
 }
+
+// CreateGroupSnapshot
+func (v *VolumeMgr) CreateGroupSnapshot(body GroupSnapshotBuilder) (*model.GroupSnapshotSpec, error) {
+	var res model.GroupSnapshotSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateGroupSnapshotURL(urls.Client, v.TenantId)}, "/")
+
+	if err := v.Recv(url, "POST", body, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetGroupSnapshot
+func (v *VolumeMgr) GetGroupSnapshot(gsId string) (*model.GroupSnapshotSpec, error) {
+	var res model.GroupSnapshotSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateGroupSnapshotURL(urls.Client, v.TenantId, gsId)}, "/")
+
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListGroupSnapshots
+func (v *VolumeMgr) ListGroupSnapshots(args ...interface{}) ([]*model.GroupSnapshotSpec, error) {
+	var res []*model.GroupSnapshotSpec
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateGroupSnapshotURL(urls.Client, v.TenantId)}, "/")
+
+	param, err := processListParam(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if param != "" {
+		url += "?" + param
+	}
+
+	if err := v.Recv(url, "GET", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteGroupSnapshot
+func (v *VolumeMgr) DeleteGroupSnapshot(gsId string, body GroupSnapshotBuilder) error {
+	url := strings.Join([]string{
+		v.Endpoint,
+		urls.GenerateGroupSnapshotURL(urls.Client, v.TenantId, gsId)}, "/")
+
+	return v.Recv(url, "DELETE", body, nil)
+}
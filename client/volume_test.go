@@ -16,6 +16,7 @@ package client
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/opensds/opensds/pkg/model"
@@ -76,6 +77,29 @@ func TestGetVolume(t *testing.T) {
 	}
 }
 
+func TestListVolumePolicies(t *testing.T) {
+	expected := []*model.PolicySpec{
+		{
+			Name:        "intervalSnapshot",
+			Type:        "operation",
+			Lifecycle:   "create",
+			Description: "Take a few snapshots of the volume every interval such as 12h after it's created.",
+			Value:       "12h",
+		},
+	}
+
+	policies, err := fv.ListVolumePolicies("bd5b12a8-a101-11e7-941e-d77981b584d8")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(policies, expected) {
+		t.Errorf("Expected %v, got %v", expected, policies)
+		return
+	}
+}
+
 func TestListVolumes(t *testing.T) {
 	expected := []*model.VolumeSpec{
 		{
@@ -103,6 +127,42 @@ func TestListVolumes(t *testing.T) {
 	}
 }
 
+// pagedVolumeReceiver returns one volume in each page, and records the
+// requested urls.
+type pagedVolumeReceiver struct {
+	urls []string
+}
+
+func (p *pagedVolumeReceiver) Recv(url string, method string, in interface{}, out interface{}) error {
+	p.urls = append(p.urls, url)
+	page := out.(*Page)
+	vols := page.Items.(*[]*model.VolumeSpec)
+	if strings.Contains(url, "marker=page2") {
+		*vols = []*model.VolumeSpec{{BaseModel: &model.BaseModel{Id: "vol2"}}}
+		return nil
+	}
+	*vols = []*model.VolumeSpec{{BaseModel: &model.BaseModel{Id: "vol1"}}}
+	page.Next, page.Total = "page2", 2
+	return nil
+}
+
+func TestListVolumesFollowsMarker(t *testing.T) {
+	r := &pagedVolumeReceiver{}
+	mgr := &VolumeMgr{Receiver: r, Endpoint: "http://localhost:50040"}
+
+	vols, err := mgr.ListVolumes()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(vols) != 2 || vols[0].Id != "vol1" || vols[1].Id != "vol2" {
+		t.Errorf("Expected volumes vol1 and vol2, got %v", vols)
+	}
+	if len(r.urls) != 2 || !strings.Contains(r.urls[0], "limit=100") {
+		t.Errorf("Unexpected requests %v", r.urls)
+	}
+}
+
 func TestDeleteVolume(t *testing.T) {
 	var volID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
 
@@ -173,6 +233,115 @@ func TestExtendVolume(t *testing.T) {
 	}
 }
 
+func TestResetVolumeStatus(t *testing.T) {
+	var volID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
+
+	result, err := fv.ResetVolumeStatus(volID, &model.ResetStatusSpec{Status: "available"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Id != volID || result.Status != "available" {
+		t.Errorf("Unexpected volume returned: %+v", result)
+	}
+}
+
+func TestForceDeleteVolume(t *testing.T) {
+	if err := fv.ForceDeleteVolume("bd5b12a8-a101-11e7-941e-d77981b584d8"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRevertVolume(t *testing.T) {
+	var volID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
+	body := model.RevertVolumeSpec{
+		SnapshotId: "3769855c-a102-11e7-b772-17b880d2f537",
+	}
+
+	result, err := fv.RevertVolume(volID, &body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Name:        "sample-volume",
+		Description: "This is a sample volume for testing",
+		Size:        int64(1),
+		Status:      "available",
+		PoolId:      "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		ProfileId:   "1106b972-66ef-11e7-b172-db03f3689c9c",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+		return
+	}
+}
+
+func TestMigrateVolume(t *testing.T) {
+	var volID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
+	body := model.MigrateVolumeSpec{
+		PoolId: "a594b8ac-a103-11e7-985f-d723bcf01b5f",
+	}
+
+	result, err := fv.MigrateVolume(volID, &body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Name:        "sample-volume",
+		Description: "This is a sample volume for testing",
+		Size:        int64(1),
+		Status:      "available",
+		PoolId:      "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		ProfileId:   "1106b972-66ef-11e7-b172-db03f3689c9c",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+		return
+	}
+}
+
+func TestRetypeVolume(t *testing.T) {
+	var volID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
+	body := model.RetypeVolumeSpec{
+		ProfileId: "2f9c0a04-66ef-11e7-ade2-43158893e017",
+	}
+
+	result, err := fv.RetypeVolume(volID, &body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Name:        "sample-volume",
+		Description: "This is a sample volume for testing",
+		Size:        int64(1),
+		Status:      "available",
+		PoolId:      "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		ProfileId:   "1106b972-66ef-11e7-b172-db03f3689c9c",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+		return
+	}
+}
+
 func TestCreateVolumeAttachment(t *testing.T) {
 	var volID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
 	expected := &model.VolumeAttachmentSpec{
@@ -319,6 +488,12 @@ func TestDeleteVolumeAttachment(t *testing.T) {
 	}
 }
 
+func TestForceDeleteVolumeAttachment(t *testing.T) {
+	if err := fv.ForceDeleteVolumeAttachment("f2dda3d2-bf79-11e7-8665-f750b088f63e"); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestCreateVolumeSnapshot(t *testing.T) {
 	expected := &model.VolumeSnapshotSpec{
 		BaseModel: &model.BaseModel{
@@ -417,6 +592,19 @@ func TestDeleteVolumeSnapshot(t *testing.T) {
 	}
 }
 
+func TestResetVolumeSnapshotStatus(t *testing.T) {
+	var snpID = "3769855c-a102-11e7-b772-17b880d2f537"
+
+	result, err := fv.ResetVolumeSnapshotStatus(snpID, &model.ResetStatusSpec{Status: "available"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Id != snpID {
+		t.Errorf("Unexpected volume snapshot returned: %+v", result)
+	}
+}
+
 func TestUpdateVolumeSnapshot(t *testing.T) {
 	var snpID = "bd5b12a8-a101-11e7-941e-d77981b584d8"
 	snp := model.VolumeSnapshotSpec{
@@ -554,3 +742,70 @@ func TestUpdateVolumeGroup(t *testing.T) {
 		return
 	}
 }
+
+func TestCreateGroupSnapshot(t *testing.T) {
+	expected := &model.GroupSnapshotSpec{
+		BaseModel: &model.BaseModel{
+			Id: "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50",
+		},
+		Name:        "sample-group-snapshot-01",
+		Description: "This is the first sample group snapshot for testing",
+		Status:      "available",
+		GroupId:     "3769855c-a102-11e7-b772-17b880d2f555",
+		Snapshots: []string{
+			"3769855c-a102-11e7-b772-17b880d2f537",
+			"3bfaf2cc-a102-11e7-8ecb-63aea739d755",
+		},
+	}
+
+	gs, err := fv.CreateGroupSnapshot(&model.GroupSnapshotSpec{
+		GroupId: "3769855c-a102-11e7-b772-17b880d2f555",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(gs, expected) {
+		t.Errorf("Expected %v, got %v", expected, gs)
+		return
+	}
+}
+
+func TestListGroupSnapshots(t *testing.T) {
+	expected := []*model.GroupSnapshotSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50",
+			},
+			Name:        "sample-group-snapshot-01",
+			Description: "This is the first sample group snapshot for testing",
+			Status:      "available",
+			GroupId:     "3769855c-a102-11e7-b772-17b880d2f555",
+			Snapshots: []string{
+				"3769855c-a102-11e7-b772-17b880d2f537",
+				"3bfaf2cc-a102-11e7-8ecb-63aea739d755",
+			},
+		},
+	}
+
+	gss, err := fv.ListGroupSnapshots()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(gss, expected) {
+		t.Errorf("Expected %v, got %v", expected, gss)
+		return
+	}
+}
+
+func TestDeleteGroupSnapshot(t *testing.T) {
+	var gsId = "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50"
+
+	if err := fv.DeleteGroupSnapshot(gsId, nil); err != nil {
+		t.Error(err)
+		return
+	}
+}
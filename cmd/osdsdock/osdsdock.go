@@ -52,6 +52,7 @@ func main() {
 
 	// Automatically discover dock and pool resources from backends.
 	dock.Brain = dock.NewDockHub(CONF.OsdsDock.DockType)
+	defer dock.Brain.Close()
 	if err := dock.Brain.TriggerDiscovery(); err != nil {
 		panic(err)
 	}
@@ -52,6 +52,22 @@ func main() {
 	// Initialize Controller object.
 	c.Brain = c.NewController()
 
+	// Mark the docks which stop sending heartbeats and their pools as
+	// unavailable.
+	go c.TrackDocks(CONF.OsdsLet.DockCheckInterval, make(chan struct{}))
+
+	// Take the snapshots and prune the expired ones according to the snapshot
+	// schedules of volumes.
+	go c.ScheduleSnapshots(CONF.OsdsLet.SnapshotScheduleInterval, make(chan struct{}))
+
+	// Run the jobs of background operations, including the ones interrupted
+	// by the last restart.
+	go c.RunJobs(CONF.OsdsLet.JobWorkers, CONF.OsdsLet.JobCheckInterval, make(chan struct{}))
+
+	// Resolve the resources stuck in transient statuses, for example when
+	// the dock crashed in the middle of an operation.
+	go c.ReconcileStuckResources(CONF.OsdsLet.StuckResourceTimeout, CONF.OsdsLet.StuckCheckInterval, make(chan struct{}))
+
 	// Start OpenSDS northbound REST service.
 	api.Run(CONF.OsdsLet)
 }
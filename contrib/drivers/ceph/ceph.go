@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ceph/go-ceph/rados"
 	"github.com/ceph/go-ceph/rbd"
@@ -31,6 +33,7 @@ import (
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
 	"github.com/opensds/opensds/pkg/utils/config"
+	"github.com/opensds/opensds/pkg/utils/exec"
 	"github.com/satori/go.uuid"
 )
 
@@ -42,8 +45,15 @@ const (
 )
 
 const (
-	KPoolName  = "CephPoolName"
-	KImageName = "CephImageName"
+	KPoolName      = "CephPoolName"
+	KImageName     = "CephImageName"
+	KGroupName     = "CephGroupName"
+	KGroupSnapName = "CephGroupSnapName"
+)
+
+const (
+	qosIopsLimitKey = "conf_rbd_qos_iops_limit"
+	qosBpsLimitKey  = "conf_rbd_qos_bps_limit"
 )
 
 type CephConfig struct {
@@ -140,6 +150,13 @@ func (s *SrcMgr) destroy() {
 
 type Driver struct {
 	conf *CephConfig
+	cli  exec.Executer
+}
+
+// NewDriver returns a driver which runs the rbd commands by the executer, it
+// is mainly used for testing.
+func NewDriver(conf *CephConfig, cli exec.Executer) *Driver {
+	return &Driver{conf: conf, cli: cli}
 }
 
 func (d *Driver) Setup() error {
@@ -148,8 +165,13 @@ func (d *Driver) Setup() error {
 	if "" == p {
 		p = defaultConfPath
 	}
-	_, err := Parse(d.conf, p)
-	return err
+	if _, err := Parse(d.conf, p); err != nil {
+		return err
+	}
+	if d.cli == nil {
+		d.cli = exec.NewRootExecuter()
+	}
+	return nil
 }
 
 func (d *Driver) Unset() error { return nil }
@@ -160,6 +182,29 @@ func (d *Driver) createVolumeFromSnapshot(opt *pb.CreateVolumeOpts) (*model.Volu
 	srcImgName := opt.GetMetadata()[KImageName]
 	destImgName := EncodeName(opt.GetId())
 
+	// The member snapshots of rbd group snapshot are not visible by name.
+	if groupSnapName := opt.GetMetadata()[KGroupSnapName]; groupSnapName != "" {
+		if err := d.cloneFromGroupSnapshot(poolName, srcImgName, groupSnapName, destImgName); err != nil {
+			log.Errorf("create volume (%s) from snapshot (%s) failed, %v",
+				opt.GetId(), opt.GetSnapshotId(), err)
+			return nil, err
+		}
+		log.Infof("create volume (%s) from snapshot (%s) success",
+			opt.GetId(), opt.GetSnapshotId())
+		return &model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id: opt.GetId(),
+			},
+			Name:             opt.GetName(),
+			Size:             opt.GetSize(),
+			Description:      opt.GetDescription(),
+			AvailabilityZone: opt.GetAvailabilityZone(),
+			Metadata: map[string]string{
+				KPoolName: opt.GetPoolName(),
+			},
+		}, nil
+	}
+
 	mgr := NewSrcMgr(d.conf)
 	defer mgr.destroy()
 
@@ -235,11 +280,144 @@ func (d *Driver) createVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, erro
 }
 
 func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	var vol *model.VolumeSpec
+	var err error
 	// create a volume from snapshot
 	if opt.GetSnapshotId() != "" {
-		return d.createVolumeFromSnapshot(opt)
+		vol, err = d.createVolumeFromSnapshot(opt)
+	} else {
+		vol, err = d.createVolume(opt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if vol.Qos, err = d.setImageQos(opt.GetPoolName(), opt.GetId(), opt.GetQos()); err != nil {
+		d.DeleteVolume(&pb.DeleteVolumeOpts{Id: opt.GetId(), Metadata: vol.Metadata})
+		return nil, err
 	}
-	return d.createVolume(opt)
+	return vol, nil
+}
+
+// setImageQos stores the qos limits in the image metadata, which will be
+// enforced by librbd when the image is opened. A zero limit means no limit.
+func (d *Driver) setImageQos(poolName, id string, qos *pb.Qos) (*model.QosSpec, error) {
+	if qos == nil {
+		return nil, nil
+	}
+	var spec = poolName + "/" + EncodeName(id)
+	for _, meta := range [][]string{
+		{qosIopsLimitKey, strconv.FormatInt(qos.GetMaxIOPS(), 10)},
+		{qosBpsLimitKey, strconv.FormatInt(qos.GetMaxBWS()<<20, 10)},
+	} {
+		if _, err := d.rbd("image-meta", "set", spec, meta[0], meta[1]); err != nil {
+			log.Errorf("set qos (%s) of volume (%s) failed, %v", meta[0], id, err)
+			return nil, err
+		}
+	}
+	log.Infof("set qos of volume (%s) to %d IOPS and %d MB/s success", id,
+		qos.GetMaxIOPS(), qos.GetMaxBWS())
+	return &model.QosSpec{MaxIOPS: qos.GetMaxIOPS(), MaxBWS: qos.GetMaxBWS()}, nil
+}
+
+// rbd runs the rbd command with the ceph config file of the driver, it is used
+// for the features which are not provided by go-ceph.
+func (d *Driver) rbd(args ...string) (string, error) {
+	if d.conf.ConfigFile != "" {
+		args = append([]string{"-c", d.conf.ConfigFile}, args...)
+	}
+	return d.cli.Run("rbd", args...)
+}
+
+// CloneVolume creates a temporary snapshot of the source image, clones a new
+// image from it and flattens the clone so that the temporary snapshot can be
+// removed afterwards.
+func (d *Driver) CloneVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	poolName := opt.GetPoolName()
+	srcImgName := EncodeName(opt.GetSourceVolumeId())
+	destImgName := EncodeName(opt.GetId())
+	tmpSnapName := EncodeName("clone-" + opt.GetId())
+
+	mgr := NewSrcMgr(d.conf)
+	defer mgr.destroy()
+
+	ioctx, err := mgr.GetIoctx(poolName)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := mgr.GetImage(poolName, srcImgName)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := img.CreateSnapshot(tmpSnapName)
+	if err != nil {
+		log.Errorf("create temporary snapshot of volume (%s) failed, %v",
+			opt.GetSourceVolumeId(), err)
+		return nil, err
+	}
+	defer snap.Remove()
+	if err := snap.Protect(); err != nil {
+		log.Errorf("protect failed, %v", err)
+		return nil, err
+	}
+	defer snap.Unprotect()
+
+	dest, err := img.Clone(tmpSnapName, ioctx, destImgName, rbd.RbdFeatureLayering, 20)
+	if err != nil {
+		log.Errorf("clone volume (%s) from volume (%s) failed, %v",
+			opt.GetId(), opt.GetSourceVolumeId(), err)
+		return nil, err
+	}
+	if err := d.flattenImage(dest, opt.GetSize()); err != nil {
+		log.Errorf("flatten volume (%s) failed, %v", opt.GetId(), err)
+		dest.Remove()
+		return nil, err
+	}
+
+	qos, err := d.setImageQos(poolName, opt.GetId(), opt.GetQos())
+	if err != nil {
+		dest.Remove()
+		return nil, err
+	}
+
+	log.Infof("clone volume (%s) from volume (%s) success",
+		opt.GetId(), opt.GetSourceVolumeId())
+	return &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Name:             opt.GetName(),
+		Size:             opt.GetSize(),
+		Description:      opt.GetDescription(),
+		AvailabilityZone: opt.GetAvailabilityZone(),
+		Metadata: map[string]string{
+			KPoolName: opt.GetPoolName(),
+		},
+		Qos: qos,
+	}, nil
+}
+
+// flattenImage detaches the cloned image from its parent snapshot and resizes
+// it to the requested size.
+func (d *Driver) flattenImage(img *rbd.Image, size int64) error {
+	if err := img.Open(); err != nil {
+		log.Error("When open image:", err)
+		return err
+	}
+	defer img.Close()
+
+	if err := img.Flatten(); err != nil {
+		return err
+	}
+	imgSize, err := img.GetSize()
+	if err != nil {
+		return err
+	}
+	if newSize := uint64(size) << sizeShiftBit; newSize > imgSize {
+		return img.Resize(newSize)
+	}
+	return nil
 }
 
 // ExtendVolume ...
@@ -269,9 +447,176 @@ func (d *Driver) ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, erro
 	}, nil
 }
 
+// RevertToSnapshot rolls back the image of volume to the specified snapshot.
+func (d *Driver) RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error {
+	mgr := NewSrcMgr(d.conf)
+	defer mgr.destroy()
+
+	poolName, ok := opt.GetMetadata()[KPoolName]
+	if !ok {
+		poolName = opt.GetPoolName()
+	}
+	img, err := mgr.GetImage(poolName, EncodeName(opt.GetId()))
+	if err != nil {
+		return err
+	}
+
+	if err := img.GetSnapshot(EncodeName(opt.GetSnapshotId())).Rollback(); err != nil {
+		log.Errorf("rollback volume (%s) to snapshot (%s) failed, %v",
+			opt.GetId(), opt.GetSnapshotId(), err)
+		return err
+	}
+	log.Infof("revert volume (%s) to snapshot (%s) success", opt.GetId(), opt.GetSnapshotId())
+	return nil
+}
+
+// MigrateVolume copies the image of volume to the target pool and removes the
+// source image after the copy is finished.
+func (d *Driver) MigrateVolume(opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	mgr := NewSrcMgr(d.conf)
+	defer mgr.destroy()
+
+	poolName, ok := opt.GetMetadata()[KPoolName]
+	if !ok {
+		poolName = opt.GetPoolName()
+	}
+	imgName := EncodeName(opt.GetId())
+	img, err := mgr.GetImage(poolName, imgName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := mgr.GetConn()
+	if err != nil {
+		return nil, err
+	}
+	destIoctx, err := conn.OpenIOContext(opt.GetTargetPoolName())
+	if err != nil {
+		log.Error("Open IO context failed, poolName:", opt.GetTargetPoolName(), err)
+		return nil, err
+	}
+	defer destIoctx.Destroy()
+
+	if err := img.Copy(*destIoctx, imgName); err != nil {
+		log.Errorf("copy volume (%s) to pool (%s) failed, %v",
+			opt.GetId(), opt.GetTargetPoolName(), err)
+		return nil, err
+	}
+
+	// The source image must be closed before being removed.
+	img.Close()
+	mgr.img = nil
+	if err := rbd.GetImage(mgr.ioctx, imgName).Remove(); err != nil {
+		log.Warningf("remove source image of volume (%s) failed, %v", opt.GetId(), err)
+	}
+
+	qos, err := d.setImageQos(opt.GetTargetPoolName(), opt.GetId(), opt.GetQos())
+	if err != nil {
+		log.Warningf("set qos of volume (%s) in pool (%s) failed, %v",
+			opt.GetId(), opt.GetTargetPoolName(), err)
+	}
+
+	log.Infof("migrate volume (%s) from pool (%s) to pool (%s) success",
+		opt.GetId(), poolName, opt.GetTargetPoolName())
+	return &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Size: opt.GetSize(),
+		Metadata: map[string]string{
+			KPoolName: opt.GetTargetPoolName(),
+		},
+		Qos: qos,
+	}, nil
+}
+
+// RetypeVolume only changes the qos limits of the volume, because rbd images
+// are always thin provisioned and have no other properties which can be
+// changed in place.
+func (d *Driver) RetypeVolume(opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	poolName, ok := opt.GetMetadata()[KPoolName]
+	if !ok {
+		poolName = opt.GetPoolName()
+	}
+	qos, err := d.setImageQos(poolName, opt.GetId(), opt.GetQos())
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("retype volume (%s) to profile (%s) success", opt.GetId(), opt.GetProfileId())
+	return &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Size:      opt.GetSize(),
+		ProfileId: opt.GetProfileId(),
+		Metadata:  opt.GetMetadata(),
+		Qos:       qos,
+	}, nil
+}
+
+// PullVolume looks up the image of volume in the configured pools, since the
+// pool of volume is unknown here.
 func (d *Driver) PullVolume(volID string) (*model.VolumeSpec, error) {
-	// Not used, do nothing.
-	return nil, nil
+	name := EncodeName(volID)
+	for poolName := range d.conf.Pool {
+		found, err := d.imageExists(poolName, name)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		out, err := d.rbd("info", "--format", "json", poolName+"/"+name)
+		if err != nil {
+			log.Errorf("get info of volume (%s) failed, %v", volID, err)
+			return nil, err
+		}
+		var info struct {
+			Size uint64 `json:"size"`
+		}
+		if err := json.Unmarshal([]byte(out), &info); err != nil {
+			return nil, err
+		}
+		return &model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id: volID,
+			},
+			Size: int64(info.Size >> sizeShiftBit),
+			Metadata: map[string]string{
+				KPoolName: poolName,
+			},
+		}, nil
+	}
+	return nil, model.NewNotFoundError(fmt.Sprintf("image of volume (%s) is not found in any pool", volID))
+}
+
+// imageExists reports whether the image is in the pool, which tells the
+// missing image from the other errors of rbd command.
+func (d *Driver) imageExists(poolName, imgName string) (bool, error) {
+	names, err := d.listImages(poolName)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range names {
+		if name == imgName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// listImages lists the names of images in the pool.
+func (d *Driver) listImages(poolName string) ([]string, error) {
+	out, err := d.rbd("ls", "--format", "json", poolName)
+	if err != nil {
+		log.Errorf("list images of pool (%s) failed, %v", poolName, err)
+		return nil, err
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(out), &names); err != nil {
+		return nil, err
+	}
+	return names, nil
 }
 
 func (d *Driver) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
@@ -301,6 +646,11 @@ func (d *Driver) InitializeConnection(opt *pb.CreateAttachmentOpts) (*model.Conn
 		log.Error(err)
 		return nil, err
 	}
+	// Make sure the qos limits are in the image metadata before it is opened
+	// by the host.
+	if _, err := d.setImageQos(poolName, opt.GetVolumeId(), opt.GetQos()); err != nil {
+		return nil, err
+	}
 	return &model.ConnectionInfo{
 		DriverVolumeType: RBDProtocol,
 		ConnectionData: map[string]interface{}{
@@ -351,8 +701,60 @@ func (d *Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.Volume
 
 }
 
-func (d *Driver) PullSnapshot(snapID string) (*model.VolumeSnapshotSpec, error) {
-	return nil, fmt.Errorf("Ceph PullSnapshot has not implemented yet.")
+// PullSnapshot looks up the snapshot in the image of its volume, which is
+// found by the pool and image name in the metadata of the snapshot and volume.
+// The member snapshot of rbd group snapshot is found by the group snapshot.
+func (d *Driver) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	meta := opt.GetMetadata()
+	poolName := meta[KPoolName]
+	if poolName == "" {
+		return nil, fmt.Errorf("pool of snapshot (%s) is not found in metadata", opt.GetId())
+	}
+	imgName := meta[KImageName]
+	if imgName == "" {
+		imgName = EncodeName(opt.GetVolumeId())
+	}
+
+	found, err := d.imageExists(poolName, imgName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, model.NewNotFoundError(fmt.Sprintf("image (%s) of snapshot (%s) is not found in pool (%s)",
+			imgName, opt.GetId(), poolName))
+	}
+	snaps, err := d.listImageSnaps(poolName + "/" + imgName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapName, groupSnapName := EncodeName(opt.GetId()), meta[KGroupSnapName]
+	for _, snap := range snaps {
+		if groupSnapName != "" {
+			if snap.Namespace.Type != "group" || snap.Namespace.GroupSnap != groupSnapName {
+				continue
+			}
+		} else if snap.Name != snapName {
+			continue
+		}
+		metadata := map[string]string{
+			KPoolName:  poolName,
+			KImageName: imgName,
+		}
+		if groupSnapName != "" {
+			metadata[KGroupName] = meta[KGroupName]
+			metadata[KGroupSnapName] = groupSnapName
+		}
+		return &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{
+				Id: opt.GetId(),
+			},
+			VolumeId: strings.TrimPrefix(imgName, opensdsPrefix),
+			Size:     int64(snap.Size >> sizeShiftBit),
+			Metadata: metadata,
+		}, nil
+	}
+	return nil, model.NewNotFoundError(fmt.Sprintf("snapshot (%s) is not found in image (%s)", opt.GetId(), imgName))
 }
 
 func (d *Driver) DeleteSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error {
@@ -436,20 +838,58 @@ func (d *Driver) ListPools() ([]*model.StoragePoolSpec, error) {
 			BaseModel: &model.BaseModel{
 				Id: uuid.NewV5(uuid.NamespaceOID, p.Name).String(),
 			},
-			Name:             p.Name,
-			TotalCapacity:    (p.Stats.BytesUsed + p.Stats.MaxAvail) >> sizeShiftBit,
-			FreeCapacity:     p.Stats.MaxAvail >> sizeShiftBit,
-			StorageType:      d.conf.Pool[p.Name].StorageType,
-			AvailabilityZone: d.conf.Pool[p.Name].AvailabilityZone,
+			Name:              p.Name,
+			TotalCapacity:     (p.Stats.BytesUsed + p.Stats.MaxAvail) >> sizeShiftBit,
+			FreeCapacity:      p.Stats.MaxAvail >> sizeShiftBit,
+			AllocatedCapacity: p.Stats.BytesUsed >> sizeShiftBit,
+			StorageType:       d.conf.Pool[p.Name].StorageType,
+			Extras:            d.conf.Pool[p.Name].Extras,
+			AvailabilityZone:  d.conf.Pool[p.Name].AvailabilityZone,
 		}
 		if pol.AvailabilityZone == "" {
 			pol.AvailabilityZone = defaultAZ
 		}
+		if pol.ProvisionedCapacity, err = provisionedCapacity(conn, p.Name); err != nil {
+			return nil, err
+		}
 		pols = append(pols, pol)
 	}
 	return pols, nil
 }
 
+// provisionedCapacity sums up the sizes of the rbd images in the pool, which
+// are thin provisioned and don't consume the space until data is written.
+func provisionedCapacity(conn *rados.Conn, poolName string) (int64, error) {
+	ioctx, err := conn.OpenIOContext(poolName)
+	if err != nil {
+		log.Error("Open IO context failed, poolName:", poolName, err)
+		return 0, err
+	}
+	defer ioctx.Destroy()
+
+	names, err := rbd.GetImageNames(ioctx)
+	if err != nil {
+		log.Error("When get image names of pool:", poolName, err)
+		return 0, err
+	}
+	var provisioned uint64
+	for _, name := range names {
+		img := rbd.GetImage(ioctx, name)
+		if err := img.Open(true); err != nil {
+			log.Error("When open image:", name, err)
+			return 0, err
+		}
+		size, err := img.GetSize()
+		img.Close()
+		if err != nil {
+			log.Error("When get size of image:", name, err)
+			return 0, err
+		}
+		provisioned += size
+	}
+	return int64(provisioned >> sizeShiftBit), nil
+}
+
 func (d *Driver) InitializeSnapshotConnection(opt *pb.CreateSnapshotAttachmentOpts) (*model.ConnectionInfo, error) {
 	poolName, ok := opt.GetMetadata()[KPoolName]
 	if !ok {
@@ -474,15 +914,3 @@ func (d *Driver) InitializeSnapshotConnection(opt *pb.CreateSnapshotAttachmentOp
 func (d *Driver) TerminateSnapshotConnection(opt *pb.DeleteSnapshotAttachmentOpts) error {
 	return nil
 }
-
-func (d *Driver) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts, vg *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
-	return nil, &model.NotImplementError{"Method CreateVolumeGroup has not been implemented yet"}
-}
-
-func (d *Driver) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts, vg *model.VolumeGroupSpec, addVolumesRef []*model.VolumeSpec, removeVolumesRef []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, []*model.VolumeSpec, error) {
-	return nil, nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup has not been implemented yet"}
-}
-
-func (d *Driver) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error) {
-	return nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup has not been implemented yet"}
-}
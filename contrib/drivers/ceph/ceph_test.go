@@ -0,0 +1,165 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package ceph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/opensds/opensds/contrib/drivers/utils/config"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+// fakeExecuter records the commands and fails the ones starting with any of
+// the failed prefixes, the output of a command is the one of its prefix.
+type fakeExecuter struct {
+	cmds    []string
+	failed  []string
+	outputs map[string]string
+}
+
+func (f *fakeExecuter) Run(name string, arg ...string) (string, error) {
+	cmd := strings.Join(append([]string{name}, arg...), " ")
+	f.cmds = append(f.cmds, cmd)
+	for _, prefix := range f.failed {
+		if strings.HasPrefix(cmd, prefix) {
+			return "", fmt.Errorf("command %s failed", cmd)
+		}
+	}
+	for prefix, out := range f.outputs {
+		if strings.HasPrefix(cmd, prefix) {
+			return out, nil
+		}
+	}
+	return "", nil
+}
+
+var sampleConf = &CephConfig{
+	Pool: map[string]PoolProperties{"rbd": {}},
+}
+
+func TestPullVolume(t *testing.T) {
+	cli := &fakeExecuter{outputs: map[string]string{
+		"rbd ls":   `["opensds-volume01","image01"]`,
+		"rbd info": `{"name":"opensds-volume01","size":2147483648}`,
+	}}
+	d := NewDriver(sampleConf, cli)
+
+	vol, err := d.PullVolume("volume01")
+	if err != nil {
+		t.Fatal("Failed to pull volume:", err)
+	}
+	var expected = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "volume01"},
+		Size:      2,
+		Metadata:  map[string]string{KPoolName: "rbd"},
+	}
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, vol)
+	}
+	var expectedCmds = []string{
+		"rbd ls --format json rbd",
+		"rbd info --format json rbd/opensds-volume01",
+	}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+
+	if _, err = d.PullVolume("volume02"); err == nil {
+		t.Error("Expected error of pulling the missing volume")
+	} else if _, ok := err.(*model.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+
+	// The volume can't be told missing if the pool can't be listed.
+	d = NewDriver(sampleConf, &fakeExecuter{failed: []string{"rbd ls"}})
+	if _, err = d.PullVolume("volume01"); err == nil {
+		t.Error("Expected error when the pool can't be listed")
+	} else if _, ok := err.(*model.NotFoundError); ok {
+		t.Errorf("Expected error other than NotFoundError, got %v", err)
+	}
+}
+
+func TestPullSnapshot(t *testing.T) {
+	cli := &fakeExecuter{outputs: map[string]string{
+		"rbd ls": `["opensds-volume01"]`,
+		"rbd snap ls": `[{"id":4,"name":"opensds-snapshot01","size":1073741824,"namespace":{"type":"user"}},
+			{"id":5,"name":".group.2_1f3d","size":2147483648,"namespace":{"type":"group","group snap":"opensds-groupsnap01"}}]`,
+	}}
+	d := NewDriver(sampleConf, cli)
+	var meta = map[string]string{KPoolName: "rbd", KImageName: "opensds-volume01"}
+
+	snap, err := d.PullSnapshot(&pb.PullVolumeSnapshotOpts{
+		Id:       "snapshot01",
+		VolumeId: "volume01",
+		Metadata: meta,
+	})
+	if err != nil {
+		t.Fatal("Failed to pull snapshot:", err)
+	}
+	var expected = &model.VolumeSnapshotSpec{
+		BaseModel: &model.BaseModel{Id: "snapshot01"},
+		VolumeId:  "volume01",
+		Size:      1,
+		Metadata:  meta,
+	}
+	if !reflect.DeepEqual(snap, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, snap)
+	}
+	// Only the image of volume is looked up.
+	var expectedCmds = []string{
+		"rbd ls --format json rbd",
+		"rbd snap ls --all --format json rbd/opensds-volume01",
+	}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+
+	// The member snapshot of group snapshot is found by the group snapshot.
+	snap, err = d.PullSnapshot(&pb.PullVolumeSnapshotOpts{
+		Id:       "snapshot02",
+		VolumeId: "volume01",
+		Metadata: map[string]string{
+			KPoolName:      "rbd",
+			KGroupName:     "opensds-group01",
+			KGroupSnapName: "opensds-groupsnap01",
+		},
+	})
+	if err != nil {
+		t.Fatal("Failed to pull member snapshot of group snapshot:", err)
+	}
+	if snap.Id != "snapshot02" || snap.Size != 2 || snap.Metadata[KImageName] != "opensds-volume01" ||
+		snap.Metadata[KGroupSnapName] != "opensds-groupsnap01" {
+		t.Errorf("Unexpected snapshot %+v", snap)
+	}
+
+	for _, opt := range []*pb.PullVolumeSnapshotOpts{
+		{Id: "snapshot03", VolumeId: "volume01", Metadata: meta},
+		{Id: "snapshot01", VolumeId: "volume02", Metadata: map[string]string{KPoolName: "rbd"}},
+	} {
+		if _, err = d.PullSnapshot(opt); err == nil {
+			t.Errorf("Expected error of pulling the missing snapshot %+v", opt)
+		} else if _, ok := err.(*model.NotFoundError); !ok {
+			t.Errorf("Expected NotFoundError, got %v", err)
+		}
+	}
+
+	if _, err = d.PullSnapshot(&pb.PullVolumeSnapshotOpts{Id: "snapshot01"}); err == nil {
+		t.Error("Expected error when the pool of snapshot is unknown")
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+/*
+This module implements the volume groups of ceph driver. The groups are built
+on rbd groups, which are not provided by go-ceph, so the rbd command is used.
+If rbd groups are not supported by the cluster, the membership of group is
+recorded in the metadata of images instead, and the snapshots of the group
+are taken one by one by the dock without consistency.
+*/
+
+package ceph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	log "github.com/golang/glog"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/satori/go.uuid"
+)
+
+// groupMetaKey is the image metadata which records the group of image when
+// rbd groups are not supported.
+const groupMetaKey = "opensds_group_id"
+
+// poolNameOf returns the name of the configured pool with the id, which is
+// generated from the pool name in ListPools.
+func (d *Driver) poolNameOf(poolId string) (string, error) {
+	for name := range d.conf.Pool {
+		if uuid.NewV5(uuid.NamespaceOID, name).String() == poolId {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("pool (%s) is not found in ceph config", poolId)
+}
+
+// groupSupported reports whether rbd groups are supported in the pool.
+func (d *Driver) groupSupported(poolName string) bool {
+	if _, err := d.rbd("group", "list", poolName); err != nil {
+		log.Warningf("rbd group is not supported in pool (%s), use image metadata instead: %v", poolName, err)
+		return false
+	}
+	return true
+}
+
+func groupSpec(poolName, groupId string) string {
+	return poolName + "/" + EncodeName(groupId)
+}
+
+// poolOf returns the pool of volume, which is the pool of its group if the
+// pool is not found in metadata.
+func poolOf(vol *model.VolumeSpec, poolName string) string {
+	if p, ok := vol.Metadata[KPoolName]; ok {
+		return p
+	}
+	return poolName
+}
+
+func imageSpec(vol *model.VolumeSpec, poolName string) string {
+	return poolOf(vol, poolName) + "/" + EncodeName(vol.Id)
+}
+
+// CreateVolumeGroup creates the rbd group in the pool of volume group, and
+// does nothing if rbd groups are not supported.
+func (d *Driver) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts, vg *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
+	poolName, err := d.poolNameOf(opt.GetPoolId())
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if d.groupSupported(poolName) {
+		if _, err := d.rbd("group", "create", groupSpec(poolName, opt.GetId())); err != nil {
+			log.Errorf("create rbd group of volume group (%s) failed, %v", opt.GetId(), err)
+			return nil, err
+		}
+	}
+	log.Infof("create volume group (%s) success", opt.GetId())
+	return &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Status: model.VolumeGroupAvailable,
+	}, nil
+}
+
+// UpdateVolumeGroup adds the images of volumes to the rbd group and removes
+// the ones from it, or records the membership in image metadata.
+func (d *Driver) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts, vg *model.VolumeGroupSpec, addVolumesRef []*model.VolumeSpec, removeVolumesRef []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, []*model.VolumeSpec, error) {
+	poolName, err := d.poolNameOf(vg.PoolId)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, nil, err
+	}
+	supported := d.groupSupported(poolName)
+	group := groupSpec(poolName, opt.GetId())
+
+	for _, vol := range addVolumesRef {
+		img := imageSpec(vol, poolName)
+		if supported {
+			_, err = d.rbd("group", "image", "add", group, img)
+		} else {
+			_, err = d.rbd("image-meta", "set", img, groupMetaKey, opt.GetId())
+		}
+		if err != nil {
+			log.Errorf("add volume (%s) to group (%s) failed, %v", vol.Id, opt.GetId(), err)
+			return nil, nil, nil, err
+		}
+	}
+	for _, vol := range removeVolumesRef {
+		img := imageSpec(vol, poolName)
+		if supported {
+			_, err = d.rbd("group", "image", "remove", group, img)
+		} else {
+			_, err = d.rbd("image-meta", "remove", img, groupMetaKey)
+		}
+		if err != nil {
+			log.Errorf("remove volume (%s) from group (%s) failed, %v", vol.Id, opt.GetId(), err)
+			return nil, nil, nil, err
+		}
+	}
+	log.Infof("update volume group (%s) success", opt.GetId())
+
+	return &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Status: model.VolumeGroupAvailable,
+	}, nil, nil, nil
+}
+
+// DeleteVolumeGroup deletes the volumes in the group along with the rbd group.
+// The volumes which fail to be deleted are returned with error status, and
+// the rbd group is kept in that case.
+func (d *Driver) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error) {
+	poolName, err := d.poolNameOf(vg.PoolId)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+	supported := d.groupSupported(poolName)
+	group := groupSpec(poolName, opt.GetId())
+
+	var vgUpdate = &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Status: model.VolumeGroupDeleting,
+	}
+	var volumesUpdate []*model.VolumeSpec
+	for _, vol := range volumes {
+		v := &model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id: vol.Id,
+			},
+			Status: model.VolumeDeleting,
+		}
+		// The image in a rbd group can't be removed.
+		if supported {
+			if _, err := d.rbd("group", "image", "remove", group, imageSpec(vol, poolName)); err != nil {
+				log.Warningf("remove volume (%s) from group (%s) failed, %v", vol.Id, opt.GetId(), err)
+			}
+		}
+		if err := d.DeleteVolume(&pb.DeleteVolumeOpts{
+			Id:       vol.Id,
+			Metadata: map[string]string{KPoolName: poolOf(vol, poolName)},
+		}); err != nil {
+			log.Errorf("delete volume (%s) of group (%s) failed, %v", vol.Id, opt.GetId(), err)
+			v.Status = model.VolumeErrorDeleting
+			vgUpdate.Status = model.VolumeGroupErrorDeleting
+		}
+		volumesUpdate = append(volumesUpdate, v)
+	}
+
+	if supported && vgUpdate.Status != model.VolumeGroupErrorDeleting {
+		if _, err := d.rbd("group", "remove", group); err != nil {
+			log.Errorf("remove rbd group of volume group (%s) failed, %v", opt.GetId(), err)
+			vgUpdate.Status = model.VolumeGroupErrorDeleting
+		}
+	}
+	return vgUpdate, volumesUpdate, nil
+}
+
+type groupImage struct {
+	Image string `json:"image"`
+	Pool  string `json:"pool"`
+}
+
+// CreateGroupSnapshot takes a snapshot of the rbd group, in which the images
+// are snapshotted at the same point. NotImplementError is returned if rbd
+// groups are not supported, so that the snapshots are taken one by one.
+func (d *Driver) CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	opts := opt.GetSnapshots()
+	if len(opts) == 0 {
+		return nil, fmt.Errorf("no snapshot of group (%s) is requested", opt.GetGroupId())
+	}
+	poolName := opts[0].GetMetadata()[KPoolName]
+	if !d.groupSupported(poolName) {
+		return nil, &model.NotImplementError{S: "rbd group is not supported in pool " + poolName}
+	}
+	group := groupSpec(poolName, opt.GetGroupId())
+
+	// Make sure every image of the group is snapshotted as requested.
+	out, err := d.rbd("group", "image", "list", group, "--format", "json")
+	if err != nil {
+		log.Errorf("list images of group (%s) failed, %v", opt.GetGroupId(), err)
+		return nil, err
+	}
+	var imgs []groupImage
+	if err := json.Unmarshal([]byte(out), &imgs); err != nil {
+		return nil, err
+	}
+	var members = make(map[string]bool)
+	for _, img := range imgs {
+		members[img.Pool+"/"+img.Image] = true
+	}
+	for _, snap := range opts {
+		img := snap.GetMetadata()[KPoolName] + "/" + EncodeName(snap.GetVolumeId())
+		if !members[img] {
+			return nil, fmt.Errorf("image (%s) is not in group (%s)", img, opt.GetGroupId())
+		}
+	}
+	if len(members) != len(opts) {
+		return nil, fmt.Errorf("group (%s) has %d images, but %d snapshots are requested",
+			opt.GetGroupId(), len(members), len(opts))
+	}
+
+	snapName := EncodeName(opt.GetId())
+	if _, err := d.rbd("group", "snap", "create", group+"@"+snapName); err != nil {
+		log.Errorf("create snapshot of group (%s) failed, %v", opt.GetGroupId(), err)
+		return nil, err
+	}
+
+	var snaps []*model.VolumeSnapshotSpec
+	for _, snap := range opts {
+		snaps = append(snaps, &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{
+				Id: snap.GetId(),
+			},
+			Name:        snap.GetName(),
+			Description: snap.GetDescription(),
+			VolumeId:    snap.GetVolumeId(),
+			Size:        snap.GetSize(),
+			Metadata: map[string]string{
+				KPoolName:      snap.GetMetadata()[KPoolName],
+				KImageName:     EncodeName(snap.GetVolumeId()),
+				KGroupName:     EncodeName(opt.GetGroupId()),
+				KGroupSnapName: snapName,
+			},
+		})
+	}
+	log.Infof("create snapshot (%s) of group (%s) success", opt.GetId(), opt.GetGroupId())
+	return snaps, nil
+}
+
+// DeleteGroupSnapshot removes the snapshot of rbd group. NotImplementError is
+// returned if the snapshots were taken one by one, so that they are deleted
+// in the same way.
+func (d *Driver) DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error {
+	opts := opt.GetSnapshots()
+	if len(opts) == 0 || opts[0].GetMetadata()[KGroupSnapName] == "" {
+		return &model.NotImplementError{S: "group snapshot " + opt.GetId() + " is not a snapshot of rbd group"}
+	}
+	meta := opts[0].GetMetadata()
+	snap := meta[KPoolName] + "/" + meta[KGroupName] + "@" + meta[KGroupSnapName]
+	if _, err := d.rbd("group", "snap", "remove", snap); err != nil {
+		log.Errorf("remove snapshot of group (%s) failed, %v", opt.GetGroupId(), err)
+		return err
+	}
+	log.Infof("delete snapshot (%s) of group (%s) success", opt.GetId(), opt.GetGroupId())
+	return nil
+}
+
+type imageSnap struct {
+	Id        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Size      uint64 `json:"size"`
+	Namespace struct {
+		Type      string `json:"type"`
+		GroupSnap string `json:"group snap"`
+	} `json:"namespace"`
+}
+
+// cloneFromGroupSnapshot clones the image from its snapshot taken by the rbd
+// group snapshot. The snapshot is in the group namespace of image, so it is
+// found by the name of group snapshot and cloned by id, which requires clone
+// v2 of rbd.
+func (d *Driver) cloneFromGroupSnapshot(poolName, imgName, groupSnapName, destImgName string) error {
+	src := poolName + "/" + imgName
+	snaps, err := d.listImageSnaps(src)
+	if err != nil {
+		return err
+	}
+	for _, snap := range snaps {
+		if snap.Namespace.Type != "group" || snap.Namespace.GroupSnap != groupSnapName {
+			continue
+		}
+		_, err := d.rbd("clone", "--snap-id", strconv.FormatUint(snap.Id, 10),
+			src, poolName+"/"+destImgName)
+		return err
+	}
+	return fmt.Errorf("snapshot of group snapshot (%s) is not found in image (%s)", groupSnapName, src)
+}
+
+// listImageSnaps lists the snapshots of image in all namespaces, including the
+// ones taken by rbd group snapshots.
+func (d *Driver) listImageSnaps(img string) ([]imageSnap, error) {
+	out, err := d.rbd("snap", "ls", "--all", "--format", "json", img)
+	if err != nil {
+		log.Errorf("list snapshots of image (%s) failed, %v", img, err)
+		return nil, err
+	}
+	var snaps []imageSnap
+	if err := json.Unmarshal([]byte(out), &snaps); err != nil {
+		return nil, err
+	}
+	return snaps, nil
+}
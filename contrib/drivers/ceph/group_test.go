@@ -0,0 +1,227 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package ceph
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/satori/go.uuid"
+)
+
+var samplePoolId = uuid.NewV5(uuid.NamespaceOID, "rbd").String()
+
+var sampleGroup = &model.VolumeGroupSpec{
+	BaseModel: &model.BaseModel{Id: "group01"},
+	PoolId:    samplePoolId,
+}
+
+var sampleGroupVolumes = []*model.VolumeSpec{
+	{
+		BaseModel: &model.BaseModel{Id: "volume01"},
+		Metadata:  map[string]string{KPoolName: "rbd"},
+	},
+	{
+		BaseModel: &model.BaseModel{Id: "volume02"},
+	},
+}
+
+func TestCreateVolumeGroup(t *testing.T) {
+	cli := &fakeExecuter{}
+	d := NewDriver(sampleConf, cli)
+
+	vg, err := d.CreateVolumeGroup(&pb.CreateVolumeGroupOpts{Id: "group01", PoolId: samplePoolId}, nil)
+	if err != nil {
+		t.Fatal("Failed to create volume group:", err)
+	}
+	if vg.Id != "group01" || vg.Status != model.VolumeGroupAvailable {
+		t.Errorf("Unexpected volume group %+v", vg)
+	}
+	var expectedCmds = []string{
+		"rbd group list rbd",
+		"rbd group create rbd/opensds-group01",
+	}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+
+	// Nothing is created if rbd groups are not supported.
+	cli = &fakeExecuter{failed: []string{"rbd group list"}}
+	d = NewDriver(sampleConf, cli)
+	if _, err = d.CreateVolumeGroup(&pb.CreateVolumeGroupOpts{Id: "group01", PoolId: samplePoolId}, nil); err != nil {
+		t.Fatal("Failed to create volume group:", err)
+	}
+	if len(cli.cmds) != 1 {
+		t.Errorf("Unexpected commands %v", cli.cmds)
+	}
+
+	if _, err = d.CreateVolumeGroup(&pb.CreateVolumeGroupOpts{Id: "group01", PoolId: "unknown"}, nil); err == nil {
+		t.Error("Expected error of creating volume group in unknown pool")
+	}
+}
+
+func TestUpdateVolumeGroup(t *testing.T) {
+	cli := &fakeExecuter{}
+	d := NewDriver(sampleConf, cli)
+
+	if _, _, _, err := d.UpdateVolumeGroup(&pb.UpdateVolumeGroupOpts{Id: "group01"}, sampleGroup,
+		sampleGroupVolumes[:1], sampleGroupVolumes[1:]); err != nil {
+		t.Fatal("Failed to update volume group:", err)
+	}
+	var expectedCmds = []string{
+		"rbd group list rbd",
+		"rbd group image add rbd/opensds-group01 rbd/opensds-volume01",
+		"rbd group image remove rbd/opensds-group01 rbd/opensds-volume02",
+	}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+}
+
+func TestUpdateVolumeGroupWithImageMetadata(t *testing.T) {
+	cli := &fakeExecuter{failed: []string{"rbd group list"}}
+	d := NewDriver(sampleConf, cli)
+
+	if _, _, _, err := d.UpdateVolumeGroup(&pb.UpdateVolumeGroupOpts{Id: "group01"}, sampleGroup,
+		sampleGroupVolumes[:1], sampleGroupVolumes[1:]); err != nil {
+		t.Fatal("Failed to update volume group:", err)
+	}
+	var expectedCmds = []string{
+		"rbd group list rbd",
+		"rbd image-meta set rbd/opensds-volume01 " + groupMetaKey + " group01",
+		"rbd image-meta remove rbd/opensds-volume02 " + groupMetaKey,
+	}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+
+	cli = &fakeExecuter{failed: []string{"rbd group list", "rbd image-meta set"}}
+	d = NewDriver(sampleConf, cli)
+	if _, _, _, err := d.UpdateVolumeGroup(&pb.UpdateVolumeGroupOpts{Id: "group01"}, sampleGroup,
+		sampleGroupVolumes[:1], nil); err == nil {
+		t.Error("Expected error when the membership can't be recorded")
+	}
+}
+
+func TestDeleteVolumeGroup(t *testing.T) {
+	cli := &fakeExecuter{}
+	d := NewDriver(sampleConf, cli)
+
+	vg, _, err := d.DeleteVolumeGroup(&pb.DeleteVolumeGroupOpts{Id: "group01"}, sampleGroup, nil)
+	if err != nil {
+		t.Fatal("Failed to delete volume group:", err)
+	}
+	if vg.Status != model.VolumeGroupDeleting {
+		t.Errorf("Unexpected volume group %+v", vg)
+	}
+	var expectedCmds = []string{
+		"rbd group list rbd",
+		"rbd group remove rbd/opensds-group01",
+	}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+
+	cli = &fakeExecuter{failed: []string{"rbd group remove"}}
+	d = NewDriver(sampleConf, cli)
+	if vg, _, err = d.DeleteVolumeGroup(&pb.DeleteVolumeGroupOpts{Id: "group01"}, sampleGroup, nil); err != nil {
+		t.Fatal("Failed to delete volume group:", err)
+	}
+	if vg.Status != model.VolumeGroupErrorDeleting {
+		t.Errorf("Expected status %s, got %s", model.VolumeGroupErrorDeleting, vg.Status)
+	}
+}
+
+func newGroupSnapshotOpts() *pb.CreateGroupSnapshotOpts {
+	return &pb.CreateGroupSnapshotOpts{
+		Id:      "groupsnap01",
+		GroupId: "group01",
+		Snapshots: []*pb.CreateVolumeSnapshotOpts{
+			{Id: "snapshot01", VolumeId: "volume01", Size: 1, Metadata: map[string]string{KPoolName: "rbd"}},
+			{Id: "snapshot02", VolumeId: "volume02", Size: 2, Metadata: map[string]string{KPoolName: "rbd"}},
+		},
+	}
+}
+
+func TestCreateGroupSnapshot(t *testing.T) {
+	cli := &fakeExecuter{outputs: map[string]string{
+		"rbd group image list": `[{"image":"opensds-volume01","pool":"rbd"},{"image":"opensds-volume02","pool":"rbd"}]`,
+	}}
+	d := NewDriver(sampleConf, cli)
+
+	snaps, err := d.CreateGroupSnapshot(newGroupSnapshotOpts())
+	if err != nil {
+		t.Fatal("Failed to create group snapshot:", err)
+	}
+	if len(snaps) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snaps))
+	}
+	for i, snap := range snaps {
+		if snap.Id != newGroupSnapshotOpts().Snapshots[i].Id ||
+			snap.Metadata[KGroupName] != "opensds-group01" ||
+			snap.Metadata[KGroupSnapName] != "opensds-groupsnap01" {
+			t.Errorf("Unexpected snapshot %+v", snap)
+		}
+	}
+	if last := cli.cmds[len(cli.cmds)-1]; last != "rbd group snap create rbd/opensds-group01@opensds-groupsnap01" {
+		t.Errorf("Unexpected command %s", last)
+	}
+
+	// Every image of the group must be snapshotted.
+	cli.outputs["rbd group image list"] = `[{"image":"opensds-volume01","pool":"rbd"},{"image":"opensds-volume03","pool":"rbd"}]`
+	if _, err = d.CreateGroupSnapshot(newGroupSnapshotOpts()); err == nil {
+		t.Error("Expected error when the images of group don't match the snapshots")
+	}
+
+	d = NewDriver(sampleConf, &fakeExecuter{failed: []string{"rbd group list"}})
+	if _, err = d.CreateGroupSnapshot(newGroupSnapshotOpts()); err == nil {
+		t.Error("Expected error when rbd groups are not supported")
+	} else if _, ok := err.(*model.NotImplementError); !ok {
+		t.Errorf("Expected NotImplementError, got %v", err)
+	}
+}
+
+func TestDeleteGroupSnapshot(t *testing.T) {
+	cli := &fakeExecuter{}
+	d := NewDriver(sampleConf, cli)
+
+	var meta = map[string]string{
+		KPoolName:      "rbd",
+		KGroupName:     "opensds-group01",
+		KGroupSnapName: "opensds-groupsnap01",
+	}
+	if err := d.DeleteGroupSnapshot(&pb.DeleteGroupSnapshotOpts{
+		Id:        "groupsnap01",
+		GroupId:   "group01",
+		Snapshots: []*pb.DeleteVolumeSnapshotOpts{{Id: "snapshot01", Metadata: meta}},
+	}); err != nil {
+		t.Fatal("Failed to delete group snapshot:", err)
+	}
+	var expectedCmds = []string{"rbd group snap remove rbd/opensds-group01@opensds-groupsnap01"}
+	if !reflect.DeepEqual(cli.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, cli.cmds)
+	}
+
+	// The snapshots taken one by one are not deleted as a group.
+	err := d.DeleteGroupSnapshot(&pb.DeleteGroupSnapshotOpts{
+		Id:        "groupsnap02",
+		Snapshots: []*pb.DeleteVolumeSnapshotOpts{{Id: "snapshot02", Metadata: map[string]string{KPoolName: "rbd"}}},
+	})
+	if _, ok := err.(*model.NotImplementError); !ok {
+		t.Errorf("Expected NotImplementError, got %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+/*
+This module implements the replication of ceph volumes by rbd mirroring. The
+rbd-mirror daemon on the secondary cluster replays the primary image into an
+image with the same pool and image name, so the primary and secondary backends
+should have a pool with the same name and both of the clusters should have
+been peered with each other before replications are created.
+
+*/
+
+package ceph
+
+import (
+	"fmt"
+	"strconv"
+
+	log "github.com/golang/glog"
+	. "github.com/opensds/opensds/contrib/drivers/utils/config"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/config"
+	"github.com/opensds/opensds/pkg/utils/exec"
+)
+
+const (
+	KMirrorMode = "CephMirrorMode"
+)
+
+const (
+	mirrorModeJournal  = "journal"
+	mirrorModeSnapshot = "snapshot"
+)
+
+// ReplicationDriver
+type ReplicationDriver struct {
+	conf *CephConfig
+	cli  exec.Executer
+}
+
+// NewReplicationDriver returns a replication driver which runs the rbd
+// commands by the executer, it is mainly used for testing.
+func NewReplicationDriver(conf *CephConfig, cli exec.Executer) *ReplicationDriver {
+	return &ReplicationDriver{conf: conf, cli: cli}
+}
+
+// Setup
+func (r *ReplicationDriver) Setup() error {
+	r.conf = &CephConfig{ConfigFile: "/etc/ceph/ceph.conf"}
+	p := config.CONF.OsdsDock.Backends.Ceph.ConfigPath
+	if "" == p {
+		p = defaultConfPath
+	}
+	if _, err := Parse(r.conf, p); err != nil {
+		return err
+	}
+	if r.cli == nil {
+		r.cli = exec.NewRootExecuter()
+	}
+	return nil
+}
+
+// Unset
+func (r *ReplicationDriver) Unset() error { return nil }
+
+func (r *ReplicationDriver) rbd(args ...string) (string, error) {
+	if r.conf.ConfigFile != "" {
+		args = append([]string{"-c", r.conf.ConfigFile}, args...)
+	}
+	return r.cli.Run("rbd", args...)
+}
+
+// replicaImage returns the pool and image name of the volume on this side, the
+// image is named after the volume id if it is not found in the driver data.
+func replicaImage(data map[string]string, poolName, volId string) (string, string) {
+	if p := data[KPoolName]; p != "" {
+		poolName = p
+	}
+	imgName := data[KImageName]
+	if imgName == "" {
+		imgName = EncodeName(volId)
+	}
+	return poolName, imgName
+}
+
+func imagePath(poolName, imgName string) string {
+	return poolName + "/" + imgName
+}
+
+// CreateReplication enables the mirroring of the primary image, in journal
+// mode by default, or in snapshot mode if the replication period is set.
+// Rbd mirroring is asynchronous, so the synchronous replication is rejected.
+func (r *ReplicationDriver) CreateReplication(opt *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
+	if opt.GetReplicationMode() == model.ReplicationModeSync {
+		return nil, fmt.Errorf("ceph replication doesn't support the %s mode", model.ReplicationModeSync)
+	}
+	mode := mirrorModeJournal
+	if opt.GetReplicationPeriod() > 0 {
+		mode = mirrorModeSnapshot
+	}
+
+	// The image on the secondary cluster is created by rbd-mirror after the
+	// name of the primary image, so there is nothing to do on this side.
+	if !opt.GetIsPrimary() {
+		poolName, imgName := replicaImage(opt.GetPrimaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId())
+		return &model.ReplicationSpec{
+			BaseModel: &model.BaseModel{
+				Id: opt.GetId(),
+			},
+			SecondaryReplicationDriverData: map[string]string{
+				KPoolName:  poolName,
+				KImageName: imgName,
+			},
+		}, nil
+	}
+
+	poolName, imgName := replicaImage(opt.GetPrimaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId())
+	pImg := imagePath(poolName, imgName)
+	if _, err := r.rbd("mirror", "pool", "enable", poolName, "image"); err != nil {
+		log.Errorf("Enable mirroring of pool %s failed: %v", poolName, err)
+		return nil, err
+	}
+	if mode == mirrorModeJournal {
+		if _, err := r.rbd("feature", "enable", pImg, "journaling"); err != nil {
+			log.Errorf("Enable journaling of image %s failed: %v", pImg, err)
+			return nil, err
+		}
+	}
+	if _, err := r.rbd("mirror", "image", "enable", pImg, mode); err != nil {
+		log.Errorf("Enable mirroring of image %s failed: %v", pImg, err)
+		return nil, err
+	}
+	if mode == mirrorModeSnapshot {
+		interval := strconv.FormatInt(opt.GetReplicationPeriod(), 10) + "m"
+		if _, err := r.rbd("mirror", "snapshot", "schedule", "add", "--image", pImg, interval); err != nil {
+			log.Errorf("Add mirror snapshot schedule of image %s failed: %v", pImg, err)
+			return nil, err
+		}
+	}
+	log.Infof("Enable %s mirroring of image %s success", mode, pImg)
+
+	return &model.ReplicationSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		PrimaryReplicationDriverData: map[string]string{
+			KPoolName:  poolName,
+			KImageName: imgName,
+		},
+		Metadata: map[string]string{
+			KMirrorMode: mode,
+		},
+	}, nil
+}
+
+// DeleteReplication disables the mirroring of the primary image, and the
+// image on the secondary cluster is removed by rbd-mirror.
+func (r *ReplicationDriver) DeleteReplication(opt *pb.DeleteReplicationOpts) error {
+	if !opt.GetIsPrimary() {
+		return nil
+	}
+	pImg := imagePath(replicaImage(opt.GetPrimaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId()))
+	if _, err := r.rbd("mirror", "image", "disable", pImg); err != nil {
+		log.Errorf("Disable mirroring of image %s failed: %v", pImg, err)
+		return err
+	}
+	return nil
+}
+
+// EnableReplication enables the mirroring of the primary image again, note
+// that the secondary image is fully resynchronized after it is re-enabled.
+func (r *ReplicationDriver) EnableReplication(opt *pb.EnableReplicationOpts) error {
+	if !opt.GetIsPrimary() {
+		return nil
+	}
+	mode := opt.GetMetadata()[KMirrorMode]
+	if mode == "" {
+		mode = mirrorModeJournal
+	}
+	pImg := imagePath(replicaImage(opt.GetPrimaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId()))
+	if _, err := r.rbd("mirror", "image", "enable", pImg, mode); err != nil {
+		log.Errorf("Enable mirroring of image %s failed: %v", pImg, err)
+		return err
+	}
+	return nil
+}
+
+// DisableReplication disables the mirroring of the primary image.
+func (r *ReplicationDriver) DisableReplication(opt *pb.DisableReplicationOpts) error {
+	if !opt.GetIsPrimary() {
+		return nil
+	}
+	pImg := imagePath(replicaImage(opt.GetPrimaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId()))
+	if _, err := r.rbd("mirror", "image", "disable", pImg); err != nil {
+		log.Errorf("Disable mirroring of image %s failed: %v", pImg, err)
+		return err
+	}
+	return nil
+}
+
+// FailoverReplication demotes the primary image and promotes the secondary
+// one when failing over to the secondary backend, and does the opposite when
+// failing back.
+func (r *ReplicationDriver) FailoverReplication(opt *pb.FailoverReplicationOpts) error {
+	failover := opt.GetSecondaryBackendId() == model.ReplicationDefaultBackendId
+	if opt.GetIsPrimary() {
+		pImg := imagePath(replicaImage(opt.GetPrimaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId()))
+		if failover {
+			// The primary cluster may be down in a disaster, and the secondary
+			// image will be promoted forcibly then.
+			if _, err := r.rbd("mirror", "image", "demote", pImg); err != nil {
+				log.Warningf("Demote image %s failed: %v", pImg, err)
+			}
+			return nil
+		}
+		if _, err := r.rbd("mirror", "image", "promote", "--force", pImg); err != nil {
+			log.Errorf("Promote image %s failed: %v", pImg, err)
+			return err
+		}
+		return nil
+	}
+
+	sImg := imagePath(replicaImage(opt.GetSecondaryReplicationDriverData(), opt.GetPoolName(), opt.GetPrimaryVolumeId()))
+	if failover {
+		if _, err := r.rbd("mirror", "image", "promote", sImg); err != nil {
+			log.Warningf("Promote image %s failed, try to promote it forcibly: %v", sImg, err)
+			if _, err := r.rbd("mirror", "image", "promote", "--force", sImg); err != nil {
+				log.Errorf("Promote image %s forcibly failed: %v", sImg, err)
+				return err
+			}
+		}
+		return nil
+	}
+	if _, err := r.rbd("mirror", "image", "demote", sImg); err != nil {
+		log.Errorf("Demote image %s failed: %v", sImg, err)
+		return err
+	}
+	// The primary image has been promoted forcibly, so the secondary one has
+	// to be resynchronized from it.
+	if _, err := r.rbd("mirror", "image", "resync", sImg); err != nil {
+		log.Errorf("Resync image %s failed: %v", sImg, err)
+		return err
+	}
+	return nil
+}
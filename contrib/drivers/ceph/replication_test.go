@@ -0,0 +1,188 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package ceph
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+var replicaDriverData = map[string]string{
+	KPoolName:  "rbd",
+	KImageName: "opensds-primary",
+}
+
+func TestCreateReplication(t *testing.T) {
+	cli := &fakeExecuter{}
+	r := NewReplicationDriver(&CephConfig{}, cli)
+
+	rep, err := r.CreateReplication(&pb.CreateReplicationOpts{
+		Id:                           "replica",
+		IsPrimary:                    true,
+		PrimaryReplicationDriverData: replicaDriverData,
+		ReplicationMode:              model.ReplicationModeAsync,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"rbd mirror pool enable rbd image",
+		"rbd feature enable rbd/opensds-primary journaling",
+		"rbd mirror image enable rbd/opensds-primary journal",
+	}
+	if !reflect.DeepEqual(cli.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, cli.cmds)
+	}
+	if rep.Metadata[KMirrorMode] != mirrorModeJournal {
+		t.Errorf("Unexpected metadata %v", rep.Metadata)
+	}
+
+	cli = &fakeExecuter{}
+	r = NewReplicationDriver(&CephConfig{ConfigFile: "/etc/ceph/ceph.conf"}, cli)
+	if _, err = r.CreateReplication(&pb.CreateReplicationOpts{
+		IsPrimary:         true,
+		PrimaryVolumeId:   "primary",
+		PoolName:          "rbd",
+		ReplicationMode:   model.ReplicationModeAsync,
+		ReplicationPeriod: 10,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{
+		"rbd -c /etc/ceph/ceph.conf mirror pool enable rbd image",
+		"rbd -c /etc/ceph/ceph.conf mirror image enable rbd/opensds-primary snapshot",
+		"rbd -c /etc/ceph/ceph.conf mirror snapshot schedule add --image rbd/opensds-primary 10m",
+	}
+	if !reflect.DeepEqual(cli.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, cli.cmds)
+	}
+
+	// The secondary image is named after the primary one.
+	cli = &fakeExecuter{}
+	r = NewReplicationDriver(&CephConfig{}, cli)
+	rep, err = r.CreateReplication(&pb.CreateReplicationOpts{
+		PrimaryReplicationDriverData: replicaDriverData,
+		ReplicationMode:              model.ReplicationModeAsync,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cli.cmds) != 0 || !reflect.DeepEqual(rep.SecondaryReplicationDriverData, replicaDriverData) {
+		t.Errorf("Unexpected commands %v and driver data %v", cli.cmds, rep.SecondaryReplicationDriverData)
+	}
+
+	if _, err = r.CreateReplication(&pb.CreateReplicationOpts{
+		IsPrimary:       true,
+		ReplicationMode: model.ReplicationModeSync,
+	}); err == nil {
+		t.Error("Expected error when creating sync replication")
+	}
+}
+
+func TestDeleteReplication(t *testing.T) {
+	cli := &fakeExecuter{}
+	r := NewReplicationDriver(&CephConfig{}, cli)
+
+	if err := r.DeleteReplication(&pb.DeleteReplicationOpts{
+		IsPrimary:                    true,
+		PrimaryReplicationDriverData: replicaDriverData,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DeleteReplication(&pb.DeleteReplicationOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"rbd mirror image disable rbd/opensds-primary"}
+	if !reflect.DeepEqual(cli.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, cli.cmds)
+	}
+}
+
+func TestEnableAndDisableReplication(t *testing.T) {
+	cli := &fakeExecuter{}
+	r := NewReplicationDriver(&CephConfig{}, cli)
+
+	if err := r.DisableReplication(&pb.DisableReplicationOpts{
+		IsPrimary:                    true,
+		PrimaryReplicationDriverData: replicaDriverData,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.EnableReplication(&pb.EnableReplicationOpts{
+		IsPrimary:                    true,
+		PrimaryReplicationDriverData: replicaDriverData,
+		Metadata:                     map[string]string{KMirrorMode: mirrorModeSnapshot},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"rbd mirror image disable rbd/opensds-primary",
+		"rbd mirror image enable rbd/opensds-primary snapshot",
+	}
+	if !reflect.DeepEqual(cli.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, cli.cmds)
+	}
+}
+
+func TestFailoverReplication(t *testing.T) {
+	// The primary cluster is down, so the secondary image is promoted
+	// forcibly.
+	cli := &fakeExecuter{failed: []string{
+		"rbd mirror image demote", "rbd mirror image promote rbd",
+	}}
+	r := NewReplicationDriver(&CephConfig{}, cli)
+	for _, isPrimary := range []bool{true, false} {
+		if err := r.FailoverReplication(&pb.FailoverReplicationOpts{
+			IsPrimary:                      isPrimary,
+			PrimaryReplicationDriverData:   replicaDriverData,
+			SecondaryReplicationDriverData: replicaDriverData,
+			SecondaryBackendId:             model.ReplicationDefaultBackendId,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{
+		"rbd mirror image demote rbd/opensds-primary",
+		"rbd mirror image promote rbd/opensds-primary",
+		"rbd mirror image promote --force rbd/opensds-primary",
+	}
+	if !reflect.DeepEqual(cli.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, cli.cmds)
+	}
+
+	cli = &fakeExecuter{}
+	r = NewReplicationDriver(&CephConfig{}, cli)
+	for _, isPrimary := range []bool{true, false} {
+		if err := r.FailoverReplication(&pb.FailoverReplicationOpts{
+			IsPrimary:                      isPrimary,
+			PrimaryReplicationDriverData:   replicaDriverData,
+			SecondaryReplicationDriverData: replicaDriverData,
+			SecondaryBackendId:             "primary-backend",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected = []string{
+		"rbd mirror image promote --force rbd/opensds-primary",
+		"rbd mirror image demote rbd/opensds-primary",
+		"rbd mirror image resync rbd/opensds-primary",
+	}
+	if !reflect.DeepEqual(cli.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, cli.cmds)
+	}
+}
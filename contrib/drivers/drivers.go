@@ -46,17 +46,35 @@ type VolumeDriver interface {
 
 	PullVolume(volIdentifier string) (*model.VolumeSpec, error)
 
+	// NOTE Parameter opt contains the uuid, size and metadata of the source
+	// volume, driver should copy all data of source volume to the new one.
+	CloneVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error)
+
 	DeleteVolume(opt *pb.DeleteVolumeOpts) error
 
 	ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error)
 
+	// NOTE Driver should roll back the data of volume to the specified
+	// snapshot in place, the uuid of volume will not be changed.
+	RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error
+
+	// NOTE Driver should move the volume to the target pool which belongs to
+	// the same backend, the uuid of volume will not be changed.
+	MigrateVolume(opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error)
+
+	// NOTE Driver should apply the properties defined in the new profile, such
+	// as QoS and provisioning policy, to the volume without moving its data.
+	RetypeVolume(opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error)
+
 	InitializeConnection(opt *pb.CreateAttachmentOpts) (*model.ConnectionInfo, error)
 
 	TerminateConnection(opt *pb.DeleteAttachmentOpts) error
 
 	CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error)
 
-	PullSnapshot(snapIdentifier string) (*model.VolumeSnapshotSpec, error)
+	// NOTE Parameter opt contains the uuid of volume and the metadata of both
+	// the snapshot and its volume, driver may use them to locate the snapshot.
+	PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error)
 
 	DeleteSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error
 
@@ -78,11 +96,17 @@ type VolumeDriver interface {
 	// their status.
 	DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error)
 
+	// NOTE Driver should take the snapshots of all volumes in the group at the
+	// same point in time, and return them in the order of opt.Snapshots.
+	CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error)
+
+	DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error
+
 	ListPools() ([]*model.StoragePoolSpec, error)
 }
 
 // Init
-func Init(resourceType string) VolumeDriver {
+func Init(resourceType string) (VolumeDriver, error) {
 	var d VolumeDriver
 	switch resourceType {
 	case config.CinderDriverType:
@@ -103,8 +127,8 @@ func Init(resourceType string) VolumeDriver {
 		d = &sample.Driver{}
 		break
 	}
-	d.Setup()
-	return d
+	err := d.Setup()
+	return d, err
 }
 
 // Clean
@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module defines an standard table of file share driver. The default file
+share driver is sample driver used for testing.
+
+*/
+
+package drivers
+
+import (
+	"github.com/opensds/opensds/contrib/drivers/nfs"
+	"github.com/opensds/opensds/contrib/drivers/utils/config"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/testutils/driver"
+)
+
+// FileShareDriver is an interface for exposing some operations of different
+// file share drivers, currently support sample and nfs.
+type FileShareDriver interface {
+	// Any initialization the file share driver does while starting.
+	Setup() error
+	// Any operation the file share driver does while stopping.
+	Unset() error
+
+	CreateFileShare(opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error)
+
+	DeleteFileShare(opt *pb.DeleteFileShareOpts) error
+
+	ExtendFileShare(opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error)
+
+	// NOTE Driver should replace the existing access rule of the same clients,
+	// so that the access level of them can be changed.
+	CreateFileShareAcl(opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error)
+
+	DeleteFileShareAcl(opt *pb.DeleteFileShareAclOpts) error
+
+	ListPools() ([]*model.StoragePoolSpec, error)
+}
+
+// IsFileShareDriver returns true if the backend of the driver provides file
+// shares instead of volumes.
+func IsFileShareDriver(resourceType string) bool {
+	switch resourceType {
+	case config.NFSDriverType:
+		return true
+	default:
+		return false
+	}
+}
+
+// InitFileShareDriver
+func InitFileShareDriver(resourceType string) FileShareDriver {
+	var d FileShareDriver
+	switch resourceType {
+	case config.NFSDriverType:
+		d = &nfs.Driver{}
+		break
+	default:
+		d = &sample.FileShareDriver{}
+		break
+	}
+	d.Setup()
+	return d
+}
+
+// CleanFileShareDriver
+func CleanFileShareDriver(d FileShareDriver) FileShareDriver {
+	// Execute different clean operations according to the FileShareDriver type.
+	switch d.(type) {
+	case *nfs.Driver:
+		break
+	default:
+		break
+	}
+	d.Unset()
+	d = nil
+
+	return d
+}
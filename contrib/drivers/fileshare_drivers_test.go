@@ -0,0 +1,51 @@
+// Copyright (c) 2017 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensds/opensds/contrib/drivers/nfs"
+	sample "github.com/opensds/opensds/testutils/driver"
+)
+
+func TestIsFileShareDriver(t *testing.T) {
+	if !IsFileShareDriver("nfs") {
+		t.Error("Expected nfs to be a file share driver")
+	}
+	if IsFileShareDriver("lvm") {
+		t.Error("Expected lvm not to be a file share driver")
+	}
+}
+
+func TestInitFileShareDriver(t *testing.T) {
+	if d := InitFileShareDriver("others"); !reflect.DeepEqual(d, &sample.FileShareDriver{}) {
+		t.Errorf("Expected %v, got %v\n", &sample.FileShareDriver{}, d)
+	}
+}
+
+func TestCleanFileShareDriver(t *testing.T) {
+	var driverList = []FileShareDriver{
+		&nfs.Driver{},
+		&sample.FileShareDriver{},
+	}
+
+	for _, driver := range driverList {
+		if d := CleanFileShareDriver(driver); !reflect.DeepEqual(d, nil) {
+			t.Errorf("Expected %v, got %v\n", nil, d)
+		}
+	}
+}
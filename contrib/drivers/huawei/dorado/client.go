@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/astaxie/beego/httplib"
@@ -312,6 +313,48 @@ func (c *DoradoClient) CheckLunExist(id, wwn string) bool {
 	return true
 }
 
+// CreateQosPolicy creates a SmartQoS policy which limits the io of the lun
+// all day long, zero limit will not be set to the policy.
+func (c *DoradoClient) CreateQosPolicy(name, lunId string, maxIOPS, maxBWS int64) (*QosPolicy, error) {
+	data := map[string]interface{}{
+		"NAME":              name,
+		"DESCRIPTION":       "Created by OpenSDS",
+		"LUNLIST":           []string{lunId},
+		"CLASSTYPE":         "1",
+		"SCHEDULEPOLICY":    "2",
+		"SCHEDULESTARTTIME": "1410969600",
+		"STARTTIME":         "08:00",
+		"DURATION":          "86400",
+		"CYCLESET":          "[1,2,3,4,5,6,0]",
+	}
+	if maxIOPS > 0 {
+		data["MAXIOPS"] = strconv.FormatInt(maxIOPS, 10)
+	}
+	if maxBWS > 0 {
+		data["MAXBANDWIDTH"] = strconv.FormatInt(maxBWS, 10)
+	}
+	qos := &QosPolicyResp{}
+	err := c.request("POST", "/ioclass", data, qos)
+	return &qos.Data, err
+}
+
+func (c *DoradoClient) ActivateQosPolicy(id string, enable bool) error {
+	data := map[string]interface{}{
+		"ID":           id,
+		"ENABLESTATUS": strconv.FormatBool(enable),
+	}
+	return c.request("PUT", "/ioclass/active/"+id, data, nil)
+}
+
+// DeleteQosPolicy deactivates the SmartQoS policy and deletes it.
+func (c *DoradoClient) DeleteQosPolicy(id string) error {
+	if err := c.ActivateQosPolicy(id, false); err != nil {
+		log.Errorf("Deactivate qos policy %s failed, %v", id, err)
+		return err
+	}
+	return c.request("DELETE", "/ioclass/"+id, nil, nil)
+}
+
 func (c *DoradoClient) CreateSnapshot(lunId, name, desc string) (*Snapshot, error) {
 	data := map[string]interface{}{
 		"PARENTTYPE":  11,
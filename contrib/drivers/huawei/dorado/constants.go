@@ -39,6 +39,7 @@ const (
 const (
 	KLunId  = "huaweiLunId"
 	KSnapId = "huaweiSnapId"
+	KQosId  = "huaweiQosId"
 )
 
 const (
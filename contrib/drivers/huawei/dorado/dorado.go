@@ -157,9 +157,24 @@ func (d *Driver) copyVolume(opt *pb.CreateVolumeOpts, srcid, tgtid string) error
 }
 
 func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	var vol *model.VolumeSpec
+	var err error
 	if opt.GetSnapshotId() != "" {
-		return d.createVolumeFromSnapshot(opt)
+		vol, err = d.createVolumeFromSnapshot(opt)
+	} else {
+		vol, err = d.createVolume(opt)
+	}
+	if err != nil {
+		return nil, err
 	}
+	if err = d.setLunQos(vol, opt.GetQos()); err != nil {
+		d.client.DeleteVolume(vol.Metadata[KLunId])
+		return nil, err
+	}
+	return vol, nil
+}
+
+func (d *Driver) createVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
 	name := EncodeName(opt.GetId())
 	desc := TruncateDescription(opt.GetDescription())
 	poolId, err := d.client.GetPoolIdByName(opt.GetPoolName())
@@ -186,6 +201,111 @@ func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, erro
 	}, nil
 }
 
+func (d *Driver) CloneVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	srcLunId := opt.GetSourceVolumeMetadata()[KLunId]
+	if srcLunId == "" {
+		srcLun, err := d.client.GetVolumeByName(EncodeName(opt.GetSourceVolumeId()))
+		if err != nil {
+			log.Error("Get source volume failed:", err)
+			return nil, err
+		}
+		srcLunId = srcLun.Id
+	}
+	volumeDesc := TruncateDescription(opt.GetDescription())
+	poolId, err := d.client.GetPoolIdByName(opt.GetPoolName())
+	if err != nil {
+		return nil, err
+	}
+
+	lun, err := d.client.CreateVolume(EncodeName(opt.GetId()), opt.GetSize(),
+		volumeDesc, poolId)
+	if err != nil {
+		log.Error("Create Volume Failed:", err)
+		return nil, err
+	}
+
+	log.Infof("Clone Volume, source_lun_id : %s , target_lun_id : %s", srcLunId, lun.Id)
+	err = WaitForCondition(func() (bool, error) {
+		getVolumeResult, getVolumeErr := d.client.GetVolume(lun.Id)
+		if nil == getVolumeErr {
+			if getVolumeResult.HealthStatus == StatusHealth && getVolumeResult.RunningStatus == StatusVolumeReady {
+				return true, nil
+			}
+			log.V(5).Infof("Current lun HealthStatus : %s , RunningStatus : %s",
+				getVolumeResult.HealthStatus, getVolumeResult.RunningStatus)
+			return false, nil
+		}
+		return false, getVolumeErr
+
+	}, LunReadyWaitInterval, LunReadyWaitTimeout)
+
+	if err != nil {
+		log.Error(err)
+		d.client.DeleteVolume(lun.Id)
+		return nil, err
+	}
+	err = d.copyVolume(opt, srcLunId, lun.Id)
+	if err != nil {
+		d.client.DeleteVolume(lun.Id)
+		return nil, err
+	}
+	vol := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Name:             opt.GetName(),
+		Size:             Sector2Gb(lun.Capacity),
+		Description:      volumeDesc,
+		AvailabilityZone: opt.GetAvailabilityZone(),
+		Metadata: map[string]string{
+			KLunId: lun.Id,
+		},
+	}
+	if err = d.setLunQos(vol, opt.GetQos()); err != nil {
+		d.client.DeleteVolume(lun.Id)
+		return nil, err
+	}
+	return vol, nil
+}
+
+// setLunQos replaces the SmartQoS policy of the lun with a new one built from
+// the qos limits, and the id of the new policy is saved in the metadata of
+// volume. A qos with zero limits only removes the existing policy.
+func (d *Driver) setLunQos(vol *model.VolumeSpec, qos *pb.Qos) error {
+	if qos == nil {
+		return nil
+	}
+	// The id is reset instead of being deleted from metadata, so that it can
+	// be overwritten when the metadata is merged.
+	if qosId := vol.Metadata[KQosId]; qosId != "" {
+		if err := d.client.DeleteQosPolicy(qosId); err != nil {
+			log.Errorf("Delete qos policy %s failed, %v", qosId, err)
+			return err
+		}
+		vol.Metadata[KQosId] = ""
+	}
+
+	vol.Qos = &model.QosSpec{MaxIOPS: qos.GetMaxIOPS(), MaxBWS: qos.GetMaxBWS()}
+	if qos.GetMaxIOPS() == 0 && qos.GetMaxBWS() == 0 {
+		return nil
+	}
+	lunId := vol.Metadata[KLunId]
+	policy, err := d.client.CreateQosPolicy(EncodeName("qos-"+vol.Id), lunId,
+		qos.GetMaxIOPS(), qos.GetMaxBWS())
+	if err != nil {
+		log.Errorf("Create qos policy of lun %s failed, %v", lunId, err)
+		return err
+	}
+	if err = d.client.ActivateQosPolicy(policy.Id, true); err != nil {
+		log.Errorf("Activate qos policy %s failed, %v", policy.Id, err)
+		d.client.DeleteQosPolicy(policy.Id)
+		return err
+	}
+	vol.Metadata[KQosId] = policy.Id
+	log.Infof("Set qos policy %s of lun %s success.", policy.Id, lunId)
+	return nil
+}
+
 func (d *Driver) PullVolume(volID string) (*model.VolumeSpec, error) {
 	name := EncodeName(volID)
 	lun, err := d.client.GetVolumeByName(name)
@@ -204,6 +324,12 @@ func (d *Driver) PullVolume(volID string) (*model.VolumeSpec, error) {
 }
 
 func (d *Driver) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
+	if qosId := opt.GetMetadata()[KQosId]; qosId != "" {
+		if err := d.client.DeleteQosPolicy(qosId); err != nil {
+			log.Errorf("Delete qos policy failed, volume id =%s , Error:%s", opt.GetId(), err)
+			return err
+		}
+	}
 	lunId := opt.GetMetadata()[KLunId]
 	err := d.client.DeleteVolume(lunId)
 	if err != nil {
@@ -254,6 +380,36 @@ func (d *Driver) getTargetInfo() (string, string, error) {
 	return "", "", errors.New(msg)
 }
 
+func (d *Driver) RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error {
+	return &model.NotImplementError{S: "Method RevertToSnapshot has not been implemented yet"}
+}
+
+func (d *Driver) MigrateVolume(opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return nil, &model.NotImplementError{S: "Method MigrateVolume has not been implemented yet"}
+}
+
+// RetypeVolume only changes the SmartQoS policy of the lun, other properties
+// of the lun can not be changed in place.
+func (d *Driver) RetypeVolume(opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	vol := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Size:      opt.GetSize(),
+		ProfileId: opt.GetProfileId(),
+		Metadata:  map[string]string{},
+	}
+	for k, v := range opt.GetMetadata() {
+		vol.Metadata[k] = v
+	}
+	if err := d.setLunQos(vol, opt.GetQos()); err != nil {
+		log.Error("Retype Volume Failed:", err)
+		return nil, err
+	}
+	log.Infof("Retype volume %s to profile %s success.", opt.GetId(), opt.GetProfileId())
+	return vol, nil
+}
+
 func (d *Driver) InitializeConnection(opt *pb.CreateAttachmentOpts) (*model.ConnectionInfo, error) {
 	if opt.GetAccessProtocol() == ISCSIProtocol {
 		return d.InitializeConnectionIscsi(opt)
@@ -377,7 +533,8 @@ func (d *Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.Volume
 	}, nil
 }
 
-func (d *Driver) PullSnapshot(id string) (*model.VolumeSnapshotSpec, error) {
+func (d *Driver) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	id := opt.GetId()
 	name := EncodeName(id)
 	snap, err := d.client.GetSnapshotByName(name)
 	if err != nil {
@@ -385,12 +542,15 @@ func (d *Driver) PullSnapshot(id string) (*model.VolumeSnapshotSpec, error) {
 	}
 	return &model.VolumeSnapshotSpec{
 		BaseModel: &model.BaseModel{
-			Id: snap.Id,
+			Id: id,
 		},
 		Name:        snap.Name,
 		Description: snap.Description,
 		Size:        0,
 		VolumeId:    snap.ParentId,
+		Metadata: map[string]string{
+			KSnapId: snap.Id,
+		},
 	}, nil
 }
 
@@ -422,12 +582,14 @@ func (d *Driver) ListPools() ([]*model.StoragePoolSpec, error) {
 			BaseModel: &model.BaseModel{
 				Id: uuid.NewV5(uuid.NamespaceOID, name).String(),
 			},
-			Name:             p.Name,
-			TotalCapacity:    Sector2Gb(p.UserTotalCapacity),
-			FreeCapacity:     Sector2Gb(p.UserFreeCapacity),
-			StorageType:      c.Pool[p.Name].StorageType,
-			Extras:           c.Pool[p.Name].Extras,
-			AvailabilityZone: c.Pool[p.Name].AvailabilityZone,
+			Name:                p.Name,
+			TotalCapacity:       Sector2Gb(p.UserTotalCapacity),
+			FreeCapacity:        Sector2Gb(p.UserFreeCapacity),
+			ProvisionedCapacity: Sector2Gb(p.LunConfigedCapacity),
+			AllocatedCapacity:   Sector2Gb(p.UserConsumedCapacity),
+			StorageType:         c.Pool[p.Name].StorageType,
+			Extras:              c.Pool[p.Name].Extras,
+			AvailabilityZone:    c.Pool[p.Name].AvailabilityZone,
 		}
 		if pol.AvailabilityZone == "" {
 			pol.AvailabilityZone = defaultAZ
@@ -745,3 +907,11 @@ func (d *Driver) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts, vg *model.Volu
 func (d *Driver) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error) {
 	return nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup did not implement."}
 }
+
+func (d *Driver) CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	return nil, &model.NotImplementError{S: "Method CreateGroupSnapshot did not implement."}
+}
+
+func (d *Driver) DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error {
+	return &model.NotImplementError{S: "Method DeleteGroupSnapshot did not implement."}
+}
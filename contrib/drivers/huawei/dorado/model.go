@@ -43,11 +43,13 @@ type AuthResp struct {
 }
 
 type StoragePool struct {
-	Description       string `json:"DESCRIPTION"`
-	Id                string `json:"ID"`
-	Name              string `json:"NAME"`
-	UserFreeCapacity  string `json:"USERFREECAPACITY"`
-	UserTotalCapacity string `json:"USERTOTALCAPACITY"`
+	Description          string `json:"DESCRIPTION"`
+	Id                   string `json:"ID"`
+	Name                 string `json:"NAME"`
+	UserFreeCapacity     string `json:"USERFREECAPACITY"`
+	UserTotalCapacity    string `json:"USERTOTALCAPACITY"`
+	UserConsumedCapacity string `json:"USERCONSUMEDCAPACITY"`
+	LunConfigedCapacity  string `json:"LUNCONFIGEDCAPACITY"`
 }
 type StoragePoolsResp struct {
 	Data  []StoragePool `json:"data"`
@@ -108,6 +110,21 @@ type LunsResp struct {
 	Error Error `json:"error"`
 }
 
+type QosPolicy struct {
+	Id            string `json:"ID"`
+	Name          string `json:"NAME"`
+	LunList       string `json:"LUNLIST"`
+	EnableStatus  string `json:"ENABLESTATUS"`
+	RunningStatus string `json:"RUNNINGSTATUS"`
+	MaxIops       string `json:"MAXIOPS"`
+	MaxBandwidth  string `json:"MAXBANDWIDTH"`
+}
+
+type QosPolicyResp struct {
+	Data  QosPolicy `json:"data"`
+	Error Error     `json:"error"`
+}
+
 type Snapshot struct {
 	CascadedLevel         string `json:"CASCADEDLEVEL"`
 	CascadedNum           string `json:"CASCADEDNUM"`
@@ -124,8 +124,11 @@ func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (*VolumeSpec, error) {
 }
 
 func (d *Driver) PullVolume(volIdentifier string) (*VolumeSpec, error) {
-	// Not used , do nothing
-	return nil, nil
+	return nil, &NotImplementError{S: "Method PullVolume has not been implemented yet."}
+}
+
+func (d *Driver) CloneVolume(opt *pb.CreateVolumeOpts) (*VolumeSpec, error) {
+	return nil, &NotImplementError{S: "Method CloneVolume has not been implemented yet."}
 }
 
 func (d *Driver) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
@@ -157,6 +160,18 @@ func (d *Driver) ExtendVolume(opt *pb.ExtendVolumeOpts) (*VolumeSpec, error) {
 	}, nil
 }
 
+func (d *Driver) RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error {
+	return &NotImplementError{S: "Method RevertToSnapshot has not been implemented yet."}
+}
+
+func (d *Driver) MigrateVolume(opt *pb.MigrateVolumeOpts) (*VolumeSpec, error) {
+	return nil, &NotImplementError{S: "Method MigrateVolume has not been implemented yet."}
+}
+
+func (d *Driver) RetypeVolume(opt *pb.RetypeVolumeOpts) (*VolumeSpec, error) {
+	return nil, &NotImplementError{S: "Method RetypeVolume has not been implemented yet."}
+}
+
 func (d *Driver) InitializeConnection(opt *pb.CreateAttachmentOpts) (*ConnectionInfo, error) {
 	connInfo := &ConnectionInfo{
 
@@ -194,8 +209,8 @@ func (d *Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*VolumeSnapsh
 		Size:        opt.GetSize(),
 	}, nil
 }
-func (d *Driver) PullSnapshot(snapIdentifier string) (*VolumeSnapshotSpec, error) {
-	return nil, nil
+func (d *Driver) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*VolumeSnapshotSpec, error) {
+	return nil, &NotImplementError{S: "Method PullSnapshot has not been implemented yet."}
 }
 
 func (d *Driver) DeleteSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error {
@@ -267,3 +282,11 @@ func (d *Driver) DeleteVolumeGroup(
 	volumes []*VolumeSpec) (*VolumeGroupSpec, []*VolumeSpec, error) {
 	return nil, nil, &NotImplementError{S: "Method DeleteVolumeGroup has not been implemented yet."}
 }
+
+func (d *Driver) CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*VolumeSnapshotSpec, error) {
+	return nil, &NotImplementError{S: "Method CreateGroupSnapshot has not been implemented yet."}
+}
+
+func (d *Driver) DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error {
+	return &NotImplementError{S: "Method DeleteGroupSnapshot has not been implemented yet."}
+}
@@ -17,6 +17,7 @@ package lvm
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path"
@@ -42,6 +43,7 @@ const (
 	defaultConfPath   = "/etc/opensds/driver/lvm.yaml"
 	volumePrefix      = "volume-"
 	snapshotPrefix    = "_snapshot-"
+	groupTagPrefix    = "opensds-group-"
 	blocksize         = 4096
 	sizeShiftBit      = 30
 
@@ -88,22 +90,67 @@ func (d *Driver) Setup() error {
 func (*Driver) Unset() error { return nil }
 
 func (d *Driver) copySnapshotToVolume(opt *pb.CreateVolumeOpts, lvPath string) error {
-	var snapSize = uint64(opt.GetSnapshotSize())
-	var count = (snapSize << sizeShiftBit) / blocksize
 	var snapName = snapshotPrefix + opt.GetSnapshotId()
 	var snapPath = path.Join("/dev", opt.GetPoolName(), snapName)
+	return d.copyVolume(snapPath, lvPath, opt.GetSnapshotSize())
+}
+
+// copyVolume copies size GB data from the source device to the target one.
+func (d *Driver) copyVolume(srcPath, destPath string, size int64) error {
+	var count = (uint64(size) << sizeShiftBit) / blocksize
 	if _, err := d.handler("dd", []string{
-		"if=" + snapPath,
-		"of=" + lvPath,
+		"if=" + srcPath,
+		"of=" + destPath,
 		"count=" + fmt.Sprint(count),
 		"bs=" + fmt.Sprint(blocksize),
 	}); err != nil {
-		log.Error("Failed to create logic volume:", err)
+		log.Error("Failed to copy logic volume:", err)
 		return err
 	}
 	return nil
 }
 
+// setVolumeQos limits the io of logic volume through the blkio throttle of
+// root cgroup, which the tgt daemon exporting the volume belongs to. The
+// throttle rules are lost once the host reboots, so they are applied again
+// whenever the volume is attached. A zero limit removes the existing rule.
+func (d *Driver) setVolumeQos(lvPath string, qos *pb.Qos) (*model.QosSpec, error) {
+	if qos == nil {
+		return nil, nil
+	}
+	info, err := d.handler("lvs", []string{
+		"--noheadings",
+		"-o", "lv_kernel_major,lv_kernel_minor",
+		lvPath,
+	})
+	if err != nil {
+		log.Error("Failed to get device number of logic volume:", err)
+		return nil, err
+	}
+	fields := strings.Fields(info)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid device number %q of logic volume %s", info, lvPath)
+	}
+
+	var dev = fields[0] + ":" + fields[1]
+	var bps = qos.GetMaxBWS() << 20
+	for _, rule := range []string{
+		fmt.Sprintf("blkio.throttle.read_iops_device=%s %d", dev, qos.GetMaxIOPS()),
+		fmt.Sprintf("blkio.throttle.write_iops_device=%s %d", dev, qos.GetMaxIOPS()),
+		fmt.Sprintf("blkio.throttle.read_bps_device=%s %d", dev, bps),
+		fmt.Sprintf("blkio.throttle.write_bps_device=%s %d", dev, bps),
+	} {
+		if _, err := d.handler("cgset", []string{"-r", rule, "/"}); err != nil {
+			log.Errorf("Failed to set blkio throttle %s: %v", rule, err)
+			return nil, err
+		}
+	}
+	log.Infof("Set qos of logic volume %s to %d IOPS and %d MB/s.", lvPath,
+		qos.GetMaxIOPS(), qos.GetMaxBWS())
+
+	return &model.QosSpec{MaxIOPS: qos.GetMaxIOPS(), MaxBWS: qos.GetMaxBWS()}, nil
+}
+
 func (d *Driver) downloadSnapshot(bucket, backupId, dest string) error {
 	mc, err := backup.NewBackup("multi-cloud")
 	if err != nil {
@@ -143,19 +190,46 @@ func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (vol *model.VolumeSpec,
 	var id = opt.GetId()
 	var name = volumePrefix + id
 
-	if _, err := d.handler("lvcreate", []string{
+	thinPool, err := d.getThinPool(polName)
+	if err != nil {
+		log.Error("Failed to get thin pool of volume group:", err)
+		return nil, err
+	}
+	var args = []string{
 		"-Z", "n",
 		"-n", name, // use uuid instead of name.
 		"-L", size,
 		polName,
-	}); err != nil {
+	}
+	// The thin volume only allocates space from the thin pool when data is
+	// written into it.
+	if thinPool != "" {
+		args = []string{
+			"-n", name,
+			"-V", size,
+			"--thinpool", path.Join(polName, thinPool),
+		}
+	}
+	if _, err := d.handler("lvcreate", args); err != nil {
 		log.Error("Failed to create logic volume:", err)
 		return nil, err
 	}
 
 	var lvPath, lvStatus string
-	// Display and parse some metadata in logic volume returned.
 	lvPath = path.Join("/dev", polName, name)
+	// Remove the created logic volume if any of the following steps, such
+	// as setting its qos, fails.
+	defer func() {
+		// using return value as the error flag
+		if vol == nil {
+			_, err := d.handler("lvremove", []string{"-f", lvPath})
+			if err != nil {
+				log.Error("Failed to remove logic volume:", err)
+			}
+		}
+	}()
+
+	// Display and parse some metadata in logic volume returned.
 	lv, err := d.handler("lvdisplay", []string{lvPath})
 	if err != nil {
 		log.Error("Failed to display logic volume:", err)
@@ -171,17 +245,6 @@ func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (vol *model.VolumeSpec,
 		}
 	}
 
-	// remove created volume if got error
-	defer func() {
-		// using return value as the error flag
-		if vol == nil {
-			_, err := d.handler("lvremove", []string{"-f", lvPath})
-			if err != nil {
-				log.Error("Failed to remove logic volume:", err)
-			}
-		}
-	}()
-
 	// Create volume from snapshot
 	if opt.GetSnapshotId() != "" {
 		if opt.SnapshotFromCloud {
@@ -209,6 +272,12 @@ func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (vol *model.VolumeSpec,
 		}
 	}
 
+	qos, err := d.setVolumeQos(lvPath, opt.GetQos())
+	if err != nil {
+		log.Errorf("Set qos of volume failed, %v", err)
+		return nil, err
+	}
+
 	return &model.VolumeSpec{
 		BaseModel: &model.BaseModel{
 			Id: opt.GetId(),
@@ -220,25 +289,178 @@ func (d *Driver) CreateVolume(opt *pb.CreateVolumeOpts) (vol *model.VolumeSpec,
 		Metadata: map[string]string{
 			"lvPath": lvPath,
 		},
+		Qos: qos,
 	}, nil
 }
 
+func (d *Driver) CloneVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	var srcPath = path.Join("/dev", opt.GetPoolName(), volumePrefix+opt.GetSourceVolumeId())
+	if lvPath, ok := opt.GetSourceVolumeMetadata()["lvPath"]; ok {
+		srcPath = lvPath
+	}
+	if opt.GetSourceVolumeSize() > opt.GetSize() {
+		return nil, fmt.Errorf("size of volume (%d GB) is smaller than source volume (%d GB)",
+			opt.GetSize(), opt.GetSourceVolumeSize())
+	}
+
+	vol, err := d.CreateVolume(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var lvPath = vol.Metadata["lvPath"]
+	if err := d.copyVolume(srcPath, lvPath, opt.GetSourceVolumeSize()); err != nil {
+		log.Errorf("Copy volume %s to %s failed, %v", srcPath, lvPath, err)
+		if _, err := d.handler("lvremove", []string{"-f", lvPath}); err != nil {
+			log.Error("Failed to remove logic volume:", err)
+		}
+		return nil, err
+	}
+	log.Infof("Clone volume %s from %s success.", opt.GetId(), opt.GetSourceVolumeId())
+
+	return vol, nil
+}
+
+// PullVolume displays the logic volume, which is identified by either its
+// path or the uuid of volume. If the uuid is given, the logic volume is looked
+// up in the configured pools, and NotFoundError is returned if it is missing.
 func (d *Driver) PullVolume(volIdentifier string) (*model.VolumeSpec, error) {
+	var vol = &model.VolumeSpec{}
+	if !path.IsAbs(volIdentifier) {
+		lv, err := d.findLv(volumePrefix + volIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		volIdentifier = path.Join("/dev", lv.Vg, lv.Name)
+		vol.BaseModel = &model.BaseModel{Id: strings.TrimPrefix(lv.Name, volumePrefix)}
+		vol.Size = lv.Size
+		vol.Metadata = map[string]string{"lvPath": volIdentifier}
+	}
+
 	// Display and parse some metadata in logic volume returned.
 	lv, err := d.handler("lvdisplay", []string{volIdentifier})
 	if err != nil {
 		log.Error("Failed to display logic volume:", err)
 		return nil, err
 	}
-	var lvStatus string
 	for _, line := range strings.Split(lv, "\n") {
 		if strings.Contains(line, "LV Status") {
-			lvStatus = strings.Fields(line)[2]
+			vol.Status = strings.Fields(line)[2]
 		}
 	}
+	return vol, nil
+}
+
+// RevertToSnapshot merges the snapshot into its origin logic volume. Since the
+// snapshot will be consumed by merging, it is recreated afterwards. If the
+// origin is open, the merging is deferred until it's activated next time, and
+// the revert is left pending without recreating the snapshot.
+func (d *Driver) RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error {
+	lvPath, ok := opt.GetMetadata()["lvPath"]
+	if !ok {
+		err := errors.New("Failed to find logic volume path in volume metadata!")
+		log.Error(err)
+		return err
+	}
+	var snapName = snapshotPrefix + opt.GetSnapshotId()
+	lvsPath, ok := opt.GetSnapshotMetadata()["lvsPath"]
+	if !ok {
+		lvsPath = path.Join(path.Dir(lvPath), snapName)
+	}
+
+	if _, err := d.handler("lvconvert", []string{"--merge", lvsPath}); err != nil {
+		log.Error("Failed to merge logic volume snapshot:", err)
+		return err
+	}
+	// If the origin volume is open, merging will be deferred until the volume
+	// is activated next time, so try to refresh it here.
+	if _, err := d.handler("lvchange", []string{"-an", lvPath}); err != nil {
+		log.Warning("Failed to deactivate logic volume:", err)
+		merging, err := d.isMerging(lvsPath)
+		if err != nil {
+			return err
+		}
+		// The snapshot is still in use by the merging, which completes when
+		// the volume is activated next time, so it can't be recreated now.
+		if merging {
+			log.Warningf("Revert volume %s to snapshot %s is pending, merging is deferred until the volume is activated next time.",
+				opt.GetId(), opt.GetSnapshotId())
+			return nil
+		}
+	} else if _, err := d.handler("lvchange", []string{"-ay", lvPath}); err != nil {
+		log.Error("Failed to activate logic volume:", err)
+		return err
+	}
+
+	if err := d.createSnapshotLV(lvPath, snapName, opt.GetSize()); err != nil {
+		log.Error("Failed to recreate logic volume snapshot:", err)
+		return err
+	}
+	log.Infof("Revert volume %s to snapshot %s success.", opt.GetId(), opt.GetSnapshotId())
+	return nil
+}
+
+// MigrateVolume moves the logic volume to the volume group of target pool.
+// Logic volumes can not be moved across volume groups directly, so a new one
+// is created in the target volume group and the data is copied into it.
+func (d *Driver) MigrateVolume(opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	var name = volumePrefix + opt.GetId()
+	var srcPath = path.Join("/dev", opt.GetPoolName(), name)
+	if lvPath, ok := opt.GetMetadata()["lvPath"]; ok {
+		srcPath = lvPath
+	}
+
+	vol, err := d.CreateVolume(&pb.CreateVolumeOpts{
+		Id:       opt.GetId(),
+		Size:     opt.GetSize(),
+		PoolName: opt.GetTargetPoolName(),
+		Qos:      opt.GetQos(),
+	})
+	if err != nil {
+		log.Error("Failed to create logic volume in target pool:", err)
+		return nil, err
+	}
+
+	var lvPath = vol.Metadata["lvPath"]
+	if err := d.copyVolume(srcPath, lvPath, opt.GetSize()); err != nil {
+		log.Errorf("Copy volume %s to %s failed, %v", srcPath, lvPath, err)
+		if _, err := d.handler("lvremove", []string{"-f", lvPath}); err != nil {
+			log.Error("Failed to remove logic volume:", err)
+		}
+		return nil, err
+	}
+
+	if _, err := d.handler("lvremove", []string{"-f", srcPath}); err != nil {
+		log.Warning("Failed to remove source logic volume:", err)
+	}
+	log.Infof("Migrate volume %s from %s to %s success.", opt.GetId(),
+		opt.GetPoolName(), opt.GetTargetPoolName())
+
+	return vol, nil
+}
+
+// RetypeVolume only changes the qos limits of the volume, because logic
+// volumes have no other properties which can be changed in place.
+func (d *Driver) RetypeVolume(opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	var lvPath = path.Join("/dev", opt.GetPoolName(), volumePrefix+opt.GetId())
+	if p, ok := opt.GetMetadata()["lvPath"]; ok {
+		lvPath = p
+	}
+	qos, err := d.setVolumeQos(lvPath, opt.GetQos())
+	if err != nil {
+		log.Errorf("Set qos of volume failed, %v", err)
+		return nil, err
+	}
+	log.Infof("Retype volume %s to profile %s success.", opt.GetId(), opt.GetProfileId())
 
 	return &model.VolumeSpec{
-		Status: lvStatus,
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Size:      opt.GetSize(),
+		ProfileId: opt.GetProfileId(),
+		Metadata:  opt.GetMetadata(),
+		Qos:       qos,
 	}, nil
 }
 
@@ -255,17 +477,33 @@ func (d *Driver) geLvInfos() ([]*LvInfo, error) {
 			continue
 		}
 		words := strings.Fields(line)
-		size, _ := strconv.ParseInt(words[2], 10, 64)
+		// The size is printed with decimals, such as 1.00.
+		size, _ := strconv.ParseFloat(words[2], 64)
 		lv := &LvInfo{
 			Vg:   words[0],
 			Name: words[1],
-			Size: size,
+			Size: int64(math.Ceil(size)),
 		}
 		lvList = append(lvList, lv)
 	}
 	return lvList, nil
 }
 
+// findLv looks up the logic volume with the name in the volume groups of the
+// configured pools.
+func (d *Driver) findLv(name string) (*LvInfo, error) {
+	lvList, err := d.geLvInfos()
+	if err != nil {
+		return nil, err
+	}
+	for _, lv := range lvList {
+		if _, ok := d.conf.Pool[lv.Vg]; ok && lv.Name == name {
+			return lv, nil
+		}
+	}
+	return nil, model.NewNotFoundError(fmt.Sprintf("logic volume %s is not found", name))
+}
+
 func (d *Driver) volumeExists(id string) bool {
 	lvList, _ := d.geLvInfos()
 	name := volumePrefix + id
@@ -432,11 +670,16 @@ func (d *Driver) InitializeConnection(opt *pb.CreateAttachmentOpts) (*model.Conn
 		log.Error(err)
 		return nil, err
 	}
+	if _, err := d.setVolumeQos(lvPath, opt.GetQos()); err != nil {
+		log.Error("Failed to set qos of logic volume:", err)
+		return nil, err
+	}
 	var chapAuth []string
 	if d.conf.EnableChapAuth {
 		chapAuth = []string{utils.RandSeqWithAlnum(20), utils.RandSeqWithAlnum(16)}
 	}
-	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir)
+	protocol := accessProtocol(opt.GetAccessProtocol())
+	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir, protocol)
 	expt, err := t.CreateExport(opt.GetVolumeId(), lvPath, hostIP, initiator, chapAuth)
 	if err != nil {
 		log.Error("Failed to initialize connection of logic volume:", err)
@@ -444,13 +687,13 @@ func (d *Driver) InitializeConnection(opt *pb.CreateAttachmentOpts) (*model.Conn
 	}
 
 	return &model.ConnectionInfo{
-		DriverVolumeType: ISCSIProtocol,
+		DriverVolumeType: protocol,
 		ConnectionData:   expt,
 	}, nil
 }
 
 func (d *Driver) TerminateConnection(opt *pb.DeleteAttachmentOpts) error {
-	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir)
+	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir, accessProtocol(opt.GetAccessProtocol()))
 	if err := t.RemoveExport(opt.GetVolumeId()); err != nil {
 		log.Error("Failed to initialize connection of logic volume:", err)
 		return err
@@ -572,8 +815,40 @@ func (d *Driver) deleteUploadedSnapshot(backupId string, bucket string) error {
 	return nil
 }
 
+// createSnapshotLV creates a read-only snapshot of the logic volume. The
+// snapshot of a thin volume is a thin snapshot which shares the thin pool with
+// its origin and needs no preallocated size, otherwise a COW snapshot of size
+// GB is created.
+func (d *Driver) createSnapshotLV(lvPath, snapName string, size int64) error {
+	thin, err := d.isThinVolume(lvPath)
+	if err != nil {
+		return err
+	}
+	_, err = d.handler("lvcreate", snapshotArgs(lvPath, snapName, size, thin))
+	return err
+}
+
+// snapshotArgs returns the arguments of lvcreate to create the snapshot.
+func snapshotArgs(lvPath, snapName string, size int64, thin bool) []string {
+	if thin {
+		// Thin snapshots are skipped in activation by default, so the flag is
+		// cleared to make them accessible like COW snapshots.
+		return []string{
+			"-n", snapName,
+			"-kn",
+			"-p", "r",
+			"-s", lvPath,
+		}
+	}
+	return []string{
+		"-n", snapName,
+		"-L", fmt.Sprint(size) + "G",
+		"-p", "r",
+		"-s", lvPath,
+	}
+}
+
 func (d *Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (snap *model.VolumeSnapshotSpec, err error) {
-	var size = fmt.Sprint(opt.GetSize()) + "G"
 	var id = opt.GetId()
 	var snapName = snapshotPrefix + id
 
@@ -584,12 +859,7 @@ func (d *Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (snap *model.V
 		return nil, err
 	}
 
-	if _, err := d.handler("lvcreate", []string{
-		"-n", snapName,
-		"-L", size,
-		"-p", "r",
-		"-s", lvPath,
-	}); err != nil {
+	if err := d.createSnapshotLV(lvPath, snapName, opt.GetSize()); err != nil {
 		log.Error("Failed to create logic volume snapshot:", err)
 		return nil, err
 	}
@@ -649,23 +919,34 @@ func (d *Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (snap *model.V
 	}, nil
 }
 
-func (d *Driver) PullSnapshot(snapIdentifier string) (*model.VolumeSnapshotSpec, error) {
+// PullSnapshot displays the logic volume snapshot, which is identified by
+// either its path or the uuid of snapshot like PullVolume.
+func (d *Driver) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	var snapIdentifier = opt.GetId()
+	var snap = &model.VolumeSnapshotSpec{}
+	if !path.IsAbs(snapIdentifier) {
+		lv, err := d.findLv(snapshotPrefix + snapIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		snapIdentifier = path.Join("/dev", lv.Vg, lv.Name)
+		snap.BaseModel = &model.BaseModel{Id: strings.TrimPrefix(lv.Name, snapshotPrefix)}
+		snap.Size = lv.Size
+		snap.Metadata = map[string]string{"lvsPath": snapIdentifier}
+	}
+
 	// Display and parse some metadata in logic volume snapshot returned.
 	lv, err := d.handler("lvdisplay", []string{snapIdentifier})
 	if err != nil {
 		log.Error("Failed to display logic volume snapshot:", err)
 		return nil, err
 	}
-	var lvStatus string
 	for _, line := range strings.Split(lv, "\n") {
 		if strings.Contains(line, "LV Status") {
-			lvStatus = strings.Fields(line)[2]
+			snap.Status = strings.Fields(line)[2]
 		}
 	}
-
-	return &model.VolumeSnapshotSpec{
-		Status: lvStatus,
-	}, nil
+	return snap, nil
 }
 
 func (d *Driver) DeleteSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error {
@@ -698,6 +979,112 @@ type VolumeGroup struct {
 	TotalCapacity int64
 	FreeCapacity  int64
 	UUID          string
+	// The capacity of volumes created in the volume group and the capacity
+	// actually consumed by them, which differ only if it's thin provisioned.
+	ProvisionedCapacity int64
+	AllocatedCapacity   int64
+	// The thin pool which volumes are created in, it's empty if the volume
+	// group is thick provisioned.
+	ThinPool string
+}
+
+// ThinPool is the thin pool logic volume along with the usage of it.
+type ThinPool struct {
+	Name string
+	Vg   string
+	// The size of thin pool in GB.
+	Size int64
+	// The sum of the virtual sizes of thin volumes in the thin pool.
+	ProvisionedCapacity int64
+	// The capacity consumed by the data written into the thin volumes.
+	AllocatedCapacity int64
+}
+
+// getThinPools returns the thin pools keyed by the name of volume groups. Only
+// the first thin pool of a volume group is used if there are several ones.
+func (d *Driver) getThinPools() (map[string]*ThinPool, error) {
+	info, err := d.handler("lvs", []string{
+		"--noheadings", "--nosuffix",
+		"--unit=g",
+		"--separator", ",",
+		"-o", "vg_name,lv_name,lv_attr,lv_size,data_percent,pool_lv",
+	})
+	if err != nil {
+		log.Error("Failed to list logic volumes:", err)
+		return nil, err
+	}
+
+	var pools = make(map[string]*ThinPool)
+	var provisioned = make(map[string]float64)
+	for _, line := range strings.Split(info, "\n") {
+		val := strings.Split(strings.TrimSpace(line), ",")
+		if len(val) != 6 || val[2] == "" {
+			continue
+		}
+		size, _ := strconv.ParseFloat(val[3], 64)
+		switch val[2][0] {
+		case 't':
+			if _, ok := pools[val[0]]; ok {
+				continue
+			}
+			percent, _ := strconv.ParseFloat(val[4], 64)
+			pools[val[0]] = &ThinPool{
+				Name:              val[1],
+				Vg:                val[0],
+				Size:              int64(size),
+				AllocatedCapacity: int64(size * percent / 100),
+			}
+		case 'V':
+			provisioned[path.Join(val[0], val[5])] += size
+		}
+	}
+	for _, pool := range pools {
+		pool.ProvisionedCapacity = int64(provisioned[path.Join(pool.Vg, pool.Name)])
+	}
+	return pools, nil
+}
+
+// getThinPool returns the thin pool of the volume group, or an empty string if
+// the volume group is thick provisioned.
+func (d *Driver) getThinPool(vg string) (string, error) {
+	pools, err := d.getThinPools()
+	if err != nil {
+		return "", err
+	}
+	if pool, ok := pools[vg]; ok {
+		return pool.Name, nil
+	}
+	return "", nil
+}
+
+// isMerging reports whether the logic volume snapshot is being merged into
+// its origin.
+func (d *Driver) isMerging(lvsPath string) (bool, error) {
+	info, err := d.handler("lvs", []string{
+		"--noheadings",
+		"-o", "lv_merging",
+		lvsPath,
+	})
+	if err != nil {
+		log.Error("Failed to get merging state of logic volume snapshot:", err)
+		return false, err
+	}
+	return strings.TrimSpace(info) == "merging", nil
+}
+
+// isThinVolume reports whether the logic volume is a thin volume.
+func (d *Driver) isThinVolume(lvPath string) (bool, error) {
+	info, err := d.handler("lvs", []string{
+		"--noheadings",
+		"-o", "lv_attr",
+		lvPath,
+	})
+	if err != nil {
+		log.Error("Failed to get attributes of logic volume:", err)
+		return false, err
+	}
+	attr := strings.TrimSpace(info)
+	return len(attr) > 0 && attr[0] == 'V', nil
 }
 
 func (d *Driver) getVGList() (*[]VolumeGroup, error) {
@@ -710,6 +1097,11 @@ func (d *Driver) getVGList() (*[]VolumeGroup, error) {
 		return nil, err
 	}
 
+	pools, err := d.getThinPools()
+	if err != nil {
+		return nil, err
+	}
+
 	lines := strings.Split(info, "\n")
 	var vgs []VolumeGroup
 	for _, line := range lines {
@@ -728,6 +1120,18 @@ func (d *Driver) getVGList() (*[]VolumeGroup, error) {
 			TotalCapacity: total,
 			FreeCapacity:  free,
 			UUID:          val[3],
+			// The logic volumes are fully allocated in the volume group.
+			ProvisionedCapacity: total - free,
+			AllocatedCapacity:   total - free,
+		}
+		// The capacity of a volume group with thin pool is the capacity of the
+		// thin pool, since volumes are created in it.
+		if pool, ok := pools[vg.Name]; ok {
+			vg.ThinPool = pool.Name
+			vg.TotalCapacity = pool.Size
+			vg.FreeCapacity = pool.Size - pool.AllocatedCapacity
+			vg.ProvisionedCapacity = pool.ProvisionedCapacity
+			vg.AllocatedCapacity = pool.AllocatedCapacity
 		}
 		vgs = append(vgs, vg)
 	}
@@ -751,16 +1155,26 @@ func (d *Driver) ListPools() ([]*model.StoragePoolSpec, error) {
 			BaseModel: &model.BaseModel{
 				Id: uuid.NewV5(uuid.NamespaceOID, vg.UUID).String(),
 			},
-			Name:             vg.Name,
-			TotalCapacity:    vg.TotalCapacity,
-			FreeCapacity:     vg.FreeCapacity,
-			StorageType:      d.conf.Pool[vg.Name].StorageType,
-			Extras:           d.conf.Pool[vg.Name].Extras,
-			AvailabilityZone: d.conf.Pool[vg.Name].AvailabilityZone,
+			Name:                vg.Name,
+			TotalCapacity:       vg.TotalCapacity,
+			FreeCapacity:        vg.FreeCapacity,
+			ProvisionedCapacity: vg.ProvisionedCapacity,
+			AllocatedCapacity:   vg.AllocatedCapacity,
+			StorageType:         d.conf.Pool[vg.Name].StorageType,
+			Extras:              d.conf.Pool[vg.Name].Extras,
+			AvailabilityZone:    d.conf.Pool[vg.Name].AvailabilityZone,
 		}
 		if pol.AvailabilityZone == "" {
 			pol.AvailabilityZone = "default"
 		}
+		if vg.ThinPool != "" {
+			pol.Extras.DataStorage.ProvisioningPolicy = model.ProvisioningPolicyThin
+		} else {
+			// The thick volume group can't be over-subscribed, whatever the
+			// provisioning policy configured for it is.
+			pol.Extras.DataStorage.ProvisioningPolicy = model.ProvisioningPolicyFixed
+			pol.MaxOverSubscriptionRatio = 1
+		}
 		pols = append(pols, pol)
 	}
 	return pols, nil
@@ -788,7 +1202,8 @@ func (d *Driver) InitializeSnapshotConnection(opt *pb.CreateSnapshotAttachmentOp
 		chapAuth = []string{utils.RandSeqWithAlnum(20), utils.RandSeqWithAlnum(16)}
 	}
 
-	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir)
+	protocol := accessProtocol(opt.GetAccessProtocol())
+	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir, protocol)
 	data, err := t.CreateExport(opt.GetSnapshotId(), lvsPath, hostIP, initiator, chapAuth)
 	if err != nil {
 		log.Error("Failed to initialize snapshot connection of logic volume:", err)
@@ -796,13 +1211,13 @@ func (d *Driver) InitializeSnapshotConnection(opt *pb.CreateSnapshotAttachmentOp
 	}
 
 	return &model.ConnectionInfo{
-		DriverVolumeType: ISCSIProtocol,
+		DriverVolumeType: protocol,
 		ConnectionData:   data,
 	}, nil
 }
 
 func (d *Driver) TerminateSnapshotConnection(opt *pb.DeleteSnapshotAttachmentOpts) error {
-	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir)
+	t := targets.NewTarget(d.conf.TgtBindIp, d.conf.TgtConfDir, accessProtocol(opt.GetAccessProtocol()))
 	if err := t.RemoveExport(opt.GetSnapshotId()); err != nil {
 		log.Error("Failed to terminate snapshot connection of logic volume:", err)
 		return err
@@ -811,16 +1226,203 @@ func (d *Driver) TerminateSnapshotConnection(opt *pb.DeleteSnapshotAttachmentOpt
 
 }
 
+// accessProtocol returns the protocol through which the logic volume is
+// exported, only iscsi and nvmeof are supported and iscsi is the default one.
+func accessProtocol(protocol string) string {
+	if protocol == NVMEOFProtocol {
+		return NVMEOFProtocol
+	}
+	return ISCSIProtocol
+}
+
+// groupTag returns the tag of logic volumes which belong to the volume group.
+func groupTag(groupId string) string {
+	return groupTagPrefix + groupId
+}
+
+// lvPathOf returns the path of logic volume recorded in volume metadata.
+func lvPathOf(vol *model.VolumeSpec) (string, error) {
+	lvPath, ok := vol.Metadata["lvPath"]
+	if !ok {
+		return "", fmt.Errorf("failed to find logic volume path in metadata of volume %s", vol.Id)
+	}
+	return lvPath, nil
+}
+
+// getGroupMembers returns the paths of logic volumes tagged with the group.
+func (d *Driver) getGroupMembers(groupId string) ([]string, error) {
+	info, err := d.handler("lvs", []string{
+		"--noheadings",
+		"-o", "lv_path",
+		"@" + groupTag(groupId),
+	})
+	if err != nil {
+		log.Error("Failed to list logic volumes of group:", err)
+		return nil, err
+	}
+	var members []string
+	for _, line := range strings.Split(info, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			members = append(members, line)
+		}
+	}
+	return members, nil
+}
+
+// CreateVolumeGroup has nothing to do on the storage, since the membership of
+// volume group is recorded by the tags of logic volumes.
 func (d *Driver) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts, vg *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
-	return nil, &model.NotImplementError{"Method CreateVolumeGroup did not implement."}
+	log.Infof("Create volume group %s success.", opt.GetId())
+	return &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Status: model.VolumeGroupAvailable,
+	}, nil
 }
 
+// UpdateVolumeGroup tags the logic volumes added to the group and untags the
+// ones removed from it.
 func (d *Driver) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts, vg *model.VolumeGroupSpec, addVolumesRef []*model.VolumeSpec, removeVolumesRef []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, []*model.VolumeSpec, error) {
-	return nil, nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup did not implement."}
+	var tag = groupTag(opt.GetId())
+	for _, vol := range addVolumesRef {
+		lvPath, err := lvPathOf(vol)
+		if err != nil {
+			log.Error(err)
+			return nil, nil, nil, err
+		}
+		if _, err := d.handler("lvchange", []string{"--addtag", tag, lvPath}); err != nil {
+			log.Errorf("Failed to add volume %s to group %s: %v", vol.Id, opt.GetId(), err)
+			return nil, nil, nil, err
+		}
+	}
+	for _, vol := range removeVolumesRef {
+		lvPath, err := lvPathOf(vol)
+		if err != nil {
+			log.Error(err)
+			return nil, nil, nil, err
+		}
+		if _, err := d.handler("lvchange", []string{"--deltag", tag, lvPath}); err != nil {
+			log.Errorf("Failed to remove volume %s from group %s: %v", vol.Id, opt.GetId(), err)
+			return nil, nil, nil, err
+		}
+	}
+	log.Infof("Update volume group %s success.", opt.GetId())
+
+	return &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Status: model.VolumeGroupAvailable,
+	}, nil, nil, nil
 }
 
+// DeleteVolumeGroup deletes the logic volumes in the group. The volumes which
+// fail to be deleted are returned with error status.
 func (d *Driver) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error) {
-	return nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup did not implement."}
+	var vgUpdate = &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Status: model.VolumeGroupDeleting,
+	}
+	var volumesUpdate []*model.VolumeSpec
+	for _, vol := range volumes {
+		v := &model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id: vol.Id,
+			},
+			Status: model.VolumeDeleting,
+		}
+		if err := d.DeleteVolume(&pb.DeleteVolumeOpts{
+			Id:       vol.Id,
+			Metadata: vol.Metadata,
+		}); err != nil {
+			log.Errorf("Failed to delete volume %s of group %s: %v", vol.Id, opt.GetId(), err)
+			v.Status = model.VolumeErrorDeleting
+			vgUpdate.Status = model.VolumeGroupErrorDeleting
+		}
+		volumesUpdate = append(volumesUpdate, v)
+	}
+
+	return vgUpdate, volumesUpdate, nil
+}
+
+// CreateGroupSnapshot snapshots all the logic volumes of the volume group one
+// by one. The io of the member volumes isn't held while the snapshots are
+// created, so the snapshots are NOT crash-consistent across the volumes, and
+// the applications should be quiesced by the user if needed. Each of the
+// snapshot options should specify the lvPath of one member volume in metadata.
+func (d *Driver) CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	groupId, opts := opt.GetGroupId(), opt.GetSnapshots()
+	members, err := d.getGroupMembers(groupId)
+	if err != nil {
+		return nil, err
+	}
+	// The logic volumes are inspected in advance, so that no snapshot is
+	// created if any of them is invalid.
+	var thin = make(map[string]bool)
+	for _, opt := range opts {
+		lvPath, ok := opt.GetMetadata()["lvPath"]
+		if !ok {
+			return nil, fmt.Errorf("failed to find logic volume path in metadata of snapshot %s", opt.GetId())
+		}
+		if thin[lvPath], err = d.isThinVolume(lvPath); err != nil {
+			return nil, err
+		}
+	}
+	for _, member := range members {
+		if _, ok := thin[member]; !ok {
+			return nil, fmt.Errorf("logic volume %s of group %s is not snapshotted", member, groupId)
+		}
+	}
+	if len(members) != len(opts) {
+		return nil, fmt.Errorf("group %s has %d volumes, but %d snapshots are requested",
+			groupId, len(members), len(opts))
+	}
+
+	var snaps []*model.VolumeSnapshotSpec
+	for _, opt := range opts {
+		var lvPath = opt.GetMetadata()["lvPath"]
+		var snapName = snapshotPrefix + opt.GetId()
+		var lvsPath = path.Join(path.Dir(lvPath), snapName)
+		args := snapshotArgs(lvPath, snapName, opt.GetSize(), thin[lvPath])
+		if _, err := d.handler("lvcreate", args); err != nil {
+			log.Errorf("Failed to create snapshot of %s in group %s: %v", lvPath, groupId, err)
+			for _, snap := range snaps {
+				if _, err := d.handler("lvremove", []string{"-f", snap.Metadata["lvsPath"]}); err != nil {
+					log.Error("Failed to remove logic volume snapshot:", err)
+				}
+			}
+			return nil, err
+		}
+		snaps = append(snaps, &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{
+				Id: opt.GetId(),
+			},
+			Name:        opt.GetName(),
+			Size:        opt.GetSize(),
+			Description: opt.GetDescription(),
+			Status:      model.VolumeSnapAvailable,
+			VolumeId:    opt.GetVolumeId(),
+			Metadata:    map[string]string{"lvsPath": lvsPath},
+		})
+	}
+	log.Infof("Create snapshots of group %s success.", groupId)
+
+	return snaps, nil
+}
+
+// DeleteGroupSnapshot removes the snapshots of the group one by one, since
+// there is no need to keep consistency when deleting them.
+func (d *Driver) DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error {
+	for _, snap := range opt.GetSnapshots() {
+		if err := d.DeleteSnapshot(snap); err != nil {
+			log.Errorf("Failed to delete snapshot %s of group %s: %v", snap.GetId(), opt.GetGroupId(), err)
+			return err
+		}
+	}
+	return nil
 }
 
 func execCmd(script string, cmd []string) (string, error) {
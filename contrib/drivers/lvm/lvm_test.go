@@ -15,8 +15,10 @@
 package lvm
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	. "github.com/opensds/opensds/contrib/drivers/utils/config"
@@ -85,6 +87,8 @@ func fakeHandler(script string, cmd []string) (string, error) {
 		return "", nil
 	case "lvchange":
 		return "", nil
+	case "lvconvert":
+		return "", nil
 	case "lvdisplay":
 		args := []string{"--noheading", "-C", "-o", "Attr", "/dev/vg001/test001"}
 		isForLvHasSnapshotFun := true
@@ -118,6 +122,19 @@ func fakeHandler(script string, cmd []string) (string, error) {
 		return string(sampleVGS), nil
 	case "dd":
 		return "", nil
+	case "lvs":
+		if len(cmd) > 2 && cmd[2] == "lv_kernel_major,lv_kernel_minor" {
+			return "  253   3\n", nil
+		}
+		if len(cmd) > 2 && cmd[2] == "lv_attr" {
+			return "  -wi-a-----\n", nil
+		}
+		if len(cmd) > 6 && cmd[6] == "vg_name,lv_name,lv_attr,lv_size,data_percent,pool_lv" {
+			return string(sampleLVSList), nil
+		}
+		break
+	case "cgset":
+		return "", nil
 	default:
 		break
 	}
@@ -152,6 +169,65 @@ func TestCreateVolume(t *testing.T) {
 	}
 }
 
+func TestCreateVolumeWithQos(t *testing.T) {
+	opt := &pb.CreateVolumeOpts{
+		Name:        "test001",
+		Description: "volume for testing",
+		Size:        int64(1),
+		PoolName:    "vg001",
+		Qos:         &pb.Qos{MaxIOPS: 1000, MaxBWS: 100},
+	}
+	var expected = &model.VolumeSpec{
+		BaseModel:   &model.BaseModel{},
+		Name:        "test001",
+		Description: "volume for testing",
+		Size:        int64(1),
+		Status:      "available",
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+		Qos: &model.QosSpec{MaxIOPS: 1000, MaxBWS: 100},
+	}
+	vol, err := fd.CreateVolume(opt)
+	if err != nil {
+		t.Error("Failed to create volume:", err)
+	}
+	vol.Id = ""
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, vol)
+	}
+}
+
+func TestCreateVolumeWithQosFailed(t *testing.T) {
+	var removed []string
+	var d = &Driver{
+		conf: fd.conf,
+		handler: func(script string, cmd []string) (string, error) {
+			switch script {
+			case "cgset":
+				return "", errors.New("cgroup blkio is not mounted")
+			case "lvremove":
+				removed = append(removed, cmd[len(cmd)-1])
+			}
+			return fakeHandler(script, cmd)
+		},
+	}
+	opt := &pb.CreateVolumeOpts{
+		Name:     "test001",
+		Size:     int64(1),
+		PoolName: "vg001",
+		Qos:      &pb.Qos{MaxIOPS: 1000, MaxBWS: 100},
+	}
+
+	if _, err := d.CreateVolume(opt); err == nil {
+		t.Error("Expected error of creating volume when qos failed to be set")
+	}
+	var expected = []string{"/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71"}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("Expected %v removed, got %v", expected, removed)
+	}
+}
+
 func TestCreateVolumeFromSnapshot(t *testing.T) {
 	opt := &pb.CreateVolumeOpts{
 		Name:         "test001",
@@ -181,6 +257,136 @@ func TestCreateVolumeFromSnapshot(t *testing.T) {
 	}
 }
 
+func TestCloneVolume(t *testing.T) {
+	opt := &pb.CreateVolumeOpts{
+		Name:             "test001",
+		Description:      "volume for testing",
+		Size:             int64(2),
+		PoolName:         "vg001",
+		SourceVolumeId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		SourceVolumeSize: int64(1),
+	}
+	var expected = &model.VolumeSpec{
+		BaseModel:   &model.BaseModel{},
+		Name:        "test001",
+		Description: "volume for testing",
+		Size:        int64(2),
+		Status:      "available",
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+	}
+	vol, err := fd.CloneVolume(opt)
+	if err != nil {
+		t.Error("Failed to clone volume:", err)
+	}
+	vol.Id = ""
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, vol)
+	}
+
+	opt.Size = int64(0)
+	if _, err := fd.CloneVolume(opt); err == nil {
+		t.Error("Expected an error when volume is smaller than source volume")
+	}
+}
+
+func TestMigrateVolume(t *testing.T) {
+	opt := &pb.MigrateVolumeOpts{
+		Id:             "e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		Size:           int64(1),
+		PoolName:       "vg000",
+		TargetPoolName: "vg001",
+	}
+	var expected = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+		Size:   int64(1),
+		Status: "available",
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+	}
+	vol, err := fd.MigrateVolume(opt)
+	if err != nil {
+		t.Error("Failed to migrate volume:", err)
+	}
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, vol)
+	}
+}
+
+func TestRevertToSnapshot(t *testing.T) {
+	opt := &pb.RevertToSnapshotOpts{
+		Id:         "e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		SnapshotId: "3769855c-a102-11e7-b772-17b880d2f537",
+		Size:       int64(1),
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+	}
+	if err := fd.RevertToSnapshot(opt); err != nil {
+		t.Error("Failed to revert volume to snapshot:", err)
+	}
+
+	opt.Metadata = nil
+	if err := fd.RevertToSnapshot(opt); err == nil {
+		t.Error("Expected an error when logic volume path is not specified")
+	}
+}
+
+// openHandler simulates a logic volume which is open, so it can't be
+// deactivated, and records the arguments of lvcreate.
+type openHandler struct {
+	merging  string
+	lvcreate [][]string
+}
+
+func (h *openHandler) handle(script string, cmd []string) (string, error) {
+	switch {
+	case script == "lvchange" && len(cmd) > 0 && cmd[0] == "-an":
+		return "", errors.New("logical volume in use")
+	case script == "lvs" && len(cmd) > 2 && cmd[2] == "lv_merging":
+		return h.merging, nil
+	case script == "lvcreate":
+		h.lvcreate = append(h.lvcreate, cmd)
+		return "", nil
+	}
+	return fakeHandler(script, cmd)
+}
+
+func TestRevertToSnapshotDeferred(t *testing.T) {
+	opt := &pb.RevertToSnapshotOpts{
+		Id:         "e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		SnapshotId: "3769855c-a102-11e7-b772-17b880d2f537",
+		Size:       int64(1),
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+	}
+
+	// The merging is deferred, so the snapshot is left to it.
+	h := &openHandler{merging: "  merging\n"}
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: h.handle}
+	if err := d.RevertToSnapshot(opt); err != nil {
+		t.Error("Failed to revert volume to snapshot:", err)
+	}
+	if len(h.lvcreate) != 0 {
+		t.Errorf("Expected the merging snapshot not to be recreated, got %v", h.lvcreate)
+	}
+
+	// The merging has completed, so the snapshot is recreated.
+	h = &openHandler{merging: "  \n"}
+	d = &Driver{conf: &LVMConfig{Pool: fp}, handler: h.handle}
+	if err := d.RevertToSnapshot(opt); err != nil {
+		t.Error("Failed to revert volume to snapshot:", err)
+	}
+	if len(h.lvcreate) != 1 {
+		t.Errorf("Expected the snapshot to be recreated, got %v", h.lvcreate)
+	}
+}
+
 func TestPullVolume(t *testing.T) {
 	volIdentifier := "/dev/vg001/test001"
 	var expected = &model.VolumeSpec{
@@ -195,6 +401,42 @@ func TestPullVolume(t *testing.T) {
 	}
 }
 
+// lvsHandler simulates the logic volumes listed by lvs, which include ones in
+// the volume group not configured as pool.
+func lvsHandler(script string, cmd []string) (string, error) {
+	if script == "lvs" && len(cmd) > 3 && cmd[3] == "vg_name,name,size" {
+		return "  vg001 volume-4a0b5ea6-cc1e-4a35-9bc4-5b6a1d7e1c48 1.00\n" +
+			"  vg001 _snapshot-9b12d4f4-cd6a-4c0f-a3a8-4a2a3a4bd3b2 1.00\n" +
+			"  vg009 volume-62e9f5b4-8c61-4c2c-b4c8-5f7ab7ab8d34 2.00\n", nil
+	}
+	return fakeHandler(script, cmd)
+}
+
+func TestPullVolumeById(t *testing.T) {
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: lvsHandler}
+	var expected = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "4a0b5ea6-cc1e-4a35-9bc4-5b6a1d7e1c48"},
+		Size:      1,
+		Status:    "available",
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-4a0b5ea6-cc1e-4a35-9bc4-5b6a1d7e1c48",
+		},
+	}
+	vol, err := d.PullVolume("4a0b5ea6-cc1e-4a35-9bc4-5b6a1d7e1c48")
+	if err != nil {
+		t.Fatal("Failed to pull volume:", err)
+	}
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, vol)
+	}
+
+	// The volume in the volume group which isn't configured is ignored.
+	_, err = d.PullVolume("62e9f5b4-8c61-4c2c-b4c8-5f7ab7ab8d34")
+	if _, ok := err.(*model.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
 func TestDeleteVolume(t *testing.T) {
 	opt := &pb.DeleteVolumeOpts{
 		Metadata: map[string]string{
@@ -270,7 +512,7 @@ func TestPullSnapshot(t *testing.T) {
 	var expected = &model.VolumeSnapshotSpec{
 		Status: "available",
 	}
-	snp, err := fd.PullSnapshot(snpIdentifier)
+	snp, err := fd.PullSnapshot(&pb.PullVolumeSnapshotOpts{Id: snpIdentifier})
 	if err != nil {
 		t.Error("Failed to pull volume snapshot:", err)
 	}
@@ -279,6 +521,23 @@ func TestPullSnapshot(t *testing.T) {
 	}
 }
 
+func TestPullSnapshotById(t *testing.T) {
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: lvsHandler}
+	snp, err := d.PullSnapshot(&pb.PullVolumeSnapshotOpts{Id: "9b12d4f4-cd6a-4c0f-a3a8-4a2a3a4bd3b2"})
+	if err != nil {
+		t.Fatal("Failed to pull volume snapshot:", err)
+	}
+	if snp.Id != "9b12d4f4-cd6a-4c0f-a3a8-4a2a3a4bd3b2" || snp.Status != "available" ||
+		snp.Metadata["lvsPath"] != "/dev/vg001/_snapshot-9b12d4f4-cd6a-4c0f-a3a8-4a2a3a4bd3b2" {
+		t.Errorf("Unexpected volume snapshot %+v", snp)
+	}
+
+	_, err = d.PullSnapshot(&pb.PullVolumeSnapshotOpts{Id: "4a0b5ea6-cc1e-4a35-9bc4-5b6a1d7e1c48"})
+	if _, ok := err.(*model.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
 func TestDeleteSnapshot(t *testing.T) {
 	opt := &pb.DeleteVolumeSnapshotOpts{
 		Metadata: map[string]string{
@@ -290,6 +549,172 @@ func TestDeleteSnapshot(t *testing.T) {
 	}
 }
 
+// thinHandler simulates a volume group vg001 with thin pool, and records the
+// arguments of lvcreate.
+type thinHandler struct {
+	lvcreate [][]string
+}
+
+func (h *thinHandler) handle(script string, cmd []string) (string, error) {
+	switch {
+	case script == "lvcreate":
+		h.lvcreate = append(h.lvcreate, cmd)
+		return "", nil
+	case script == "lvs" && len(cmd) > 2 && cmd[2] == "lv_attr":
+		return "  Vwi-a-tz--\n", nil
+	case script == "lvs" && len(cmd) > 6 && cmd[6] == "vg_name,lv_name,lv_attr,lv_size,data_percent,pool_lv":
+		return string(sampleThinLVSList), nil
+	}
+	return fakeHandler(script, cmd)
+}
+
+func TestCreateThinVolumeAndSnapshot(t *testing.T) {
+	h := &thinHandler{}
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: h.handle}
+
+	if _, err := d.CreateVolume(&pb.CreateVolumeOpts{
+		Id:       "e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		Size:     int64(1),
+		PoolName: "vg001",
+	}); err != nil {
+		t.Error("Failed to create thin volume:", err)
+	}
+	if _, err := d.CreateSnapshot(&pb.CreateVolumeSnapshotOpts{
+		Id:   "d1916c49-3088-4a40-b6fb-0fda18d074c3",
+		Size: int64(1),
+		Metadata: map[string]string{
+			"lvPath": "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71",
+		},
+	}); err != nil {
+		t.Error("Failed to create thin snapshot:", err)
+	}
+
+	var expected = [][]string{
+		{"-n", "volume-e1bb066c-5ce7-46eb-9336-25508cee9f71", "-V", "1G", "--thinpool", "vg001/thinpool"},
+		{"-n", "_snapshot-d1916c49-3088-4a40-b6fb-0fda18d074c3", "-kn", "-p", "r",
+			"-s", "/dev/vg001/volume-e1bb066c-5ce7-46eb-9336-25508cee9f71"},
+	}
+	if !reflect.DeepEqual(h.lvcreate, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, h.lvcreate)
+	}
+}
+
+func TestListThinPools(t *testing.T) {
+	h := &thinHandler{}
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: h.handle}
+
+	pols, err := d.ListPools()
+	if err != nil {
+		t.Fatal("Failed to list pools:", err)
+	}
+	if len(pols) != 1 {
+		t.Fatalf("Expected 1 pool, got %d", len(pols))
+	}
+	pol := pols[0]
+	if pol.TotalCapacity != 10 || pol.FreeCapacity != 8 || pol.AllocatedCapacity != 2 ||
+		pol.ProvisionedCapacity != 15 || pol.MaxOverSubscriptionRatio != 0 {
+		t.Errorf("Unexpected capacity of thin pool %+v", pol)
+	}
+	if pol.Extras.DataStorage.ProvisioningPolicy != model.ProvisioningPolicyThin {
+		t.Errorf("Expected thin provisioning policy, got %s", pol.Extras.DataStorage.ProvisioningPolicy)
+	}
+}
+
+// groupHandler simulates a volume group with two member logic volumes, and
+// records the commands.
+type groupHandler struct {
+	cmds []string
+}
+
+func (h *groupHandler) handle(script string, cmd []string) (string, error) {
+	h.cmds = append(h.cmds, script+" "+strings.Join(cmd, " "))
+	if script == "lvs" && len(cmd) > 3 && cmd[3] == "@opensds-group-3769855c-a102-11e7-b772-17b880d2f537" {
+		return "  /dev/vg001/volume-a\n  /dev/vg001/volume-b\n", nil
+	}
+	return fakeHandler(script, cmd)
+}
+
+func TestUpdateVolumeGroup(t *testing.T) {
+	h := &groupHandler{}
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: h.handle}
+	opt := &pb.UpdateVolumeGroupOpts{Id: "3769855c-a102-11e7-b772-17b880d2f537"}
+	add := []*model.VolumeSpec{{
+		BaseModel: &model.BaseModel{Id: "a"},
+		Metadata:  map[string]string{"lvPath": "/dev/vg001/volume-a"},
+	}}
+	remove := []*model.VolumeSpec{{
+		BaseModel: &model.BaseModel{Id: "b"},
+		Metadata:  map[string]string{"lvPath": "/dev/vg001/volume-b"},
+	}}
+
+	vg, _, _, err := d.UpdateVolumeGroup(opt, nil, add, remove)
+	if err != nil {
+		t.Fatal("Failed to update volume group:", err)
+	}
+	if vg.Status != model.VolumeGroupAvailable {
+		t.Errorf("Expected %s, got %s", model.VolumeGroupAvailable, vg.Status)
+	}
+	var expected = []string{
+		"lvchange --addtag opensds-group-3769855c-a102-11e7-b772-17b880d2f537 /dev/vg001/volume-a",
+		"lvchange --deltag opensds-group-3769855c-a102-11e7-b772-17b880d2f537 /dev/vg001/volume-b",
+	}
+	if !reflect.DeepEqual(h.cmds, expected) {
+		t.Errorf("Expected %v, got %v", expected, h.cmds)
+	}
+
+	add[0].Metadata = nil
+	if _, _, _, err := d.UpdateVolumeGroup(opt, nil, add, nil); err == nil {
+		t.Error("Expected an error when logic volume path is not specified")
+	}
+}
+
+func TestDeleteVolumeGroup(t *testing.T) {
+	vols := []*model.VolumeSpec{{
+		BaseModel: &model.BaseModel{Id: "e1bb066c-5ce7-46eb-9336-25508cee9f71"},
+		Metadata:  map[string]string{"lvPath": "/dev/vg001/test001"},
+	}}
+	vg, volsUpdate, err := fd.DeleteVolumeGroup(
+		&pb.DeleteVolumeGroupOpts{Id: "3769855c-a102-11e7-b772-17b880d2f537"}, nil, vols)
+	if err != nil {
+		t.Fatal("Failed to delete volume group:", err)
+	}
+	if vg.Status != model.VolumeGroupDeleting || len(volsUpdate) != 1 ||
+		volsUpdate[0].Status != model.VolumeDeleting {
+		t.Errorf("Unexpected status of group %+v and volumes %+v", vg, volsUpdate[0])
+	}
+}
+
+func TestCreateGroupSnapshot(t *testing.T) {
+	h := &groupHandler{}
+	d := &Driver{conf: &LVMConfig{Pool: fp}, handler: h.handle}
+	opts := []*pb.CreateVolumeSnapshotOpts{
+		{Id: "1", Size: 1, Metadata: map[string]string{"lvPath": "/dev/vg001/volume-a"}},
+		{Id: "2", Size: 2, Metadata: map[string]string{"lvPath": "/dev/vg001/volume-b"}},
+	}
+
+	opt := &pb.CreateGroupSnapshotOpts{GroupId: "3769855c-a102-11e7-b772-17b880d2f537", Snapshots: opts}
+	snaps, err := d.CreateGroupSnapshot(opt)
+	if err != nil {
+		t.Fatal("Failed to create group snapshot:", err)
+	}
+	if len(snaps) != 2 || snaps[1].Metadata["lvsPath"] != "/dev/vg001/_snapshot-2" {
+		t.Errorf("Unexpected snapshots %+v", snaps)
+	}
+	var expected = []string{
+		"lvcreate -n _snapshot-1 -L 1G -p r -s /dev/vg001/volume-a",
+		"lvcreate -n _snapshot-2 -L 2G -p r -s /dev/vg001/volume-b",
+	}
+	if !reflect.DeepEqual(h.cmds[len(h.cmds)-len(expected):], expected) {
+		t.Errorf("Expected %v, got %v", expected, h.cmds)
+	}
+
+	// All the volumes in the group must be snapshotted together.
+	opt.Snapshots = opts[:1]
+	if _, err := d.CreateGroupSnapshot(opt); err == nil {
+		t.Error("Expected an error when a volume of group is not snapshotted")
+	}
+}
+
 func TestListPools(t *testing.T) {
 	var expected = []*model.StoragePoolSpec{
 		{
@@ -299,9 +724,11 @@ func TestListPools(t *testing.T) {
 			FreeCapacity:     int64(18),
 			AvailabilityZone: "default",
 			StorageType:      "block",
+			// The thick volume group can't be over-subscribed.
+			MaxOverSubscriptionRatio: 1,
 			Extras: model.StoragePoolExtraSpec{
 				DataStorage: model.DataStorageLoS{
-					ProvisioningPolicy: "Thin",
+					ProvisioningPolicy: "Fixed",
 					IsSpaceEfficient:   false,
 				},
 				IOConnectivity: model.IOConnectivityLoS{
@@ -469,6 +896,16 @@ var (
   - currently set to     256
   Block device           253:3
 	`
+	sampleLVSList = `
+  ubuntu-vg,root,-wi-ao----,120.00,,
+  ubuntu-vg,swap_1,-wi-ao----,7.49,,
+`
+	sampleThinLVSList = `
+  vg001,thinpool,twi-aotz--,10.00,20.00,
+  vg001,volume-bd5b12a8-a101-11e7-941e-d77981b584d8,Vwi-a-tz--,10.00,16.00,thinpool
+  vg001,volume-e1bb066c-5ce7-46eb-9336-25508cee9f71,Vwi-a-tz--,5.00,8.00,thinpool
+  ubuntu-vg,root,-wi-ao----,120.00,,
+`
 	sampleVGS = `
   vg001      18.62  18.62 6fBbT0-MrAT-eLfh-cySE-Guqf-YLkw-Vyfcrb
   ubuntu-vg  127.52  0.03 fQbqtg-3vDQ-vk3U-gfsT-50kJ-30pq-OZVSJH
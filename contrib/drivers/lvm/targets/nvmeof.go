@@ -0,0 +1,240 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package targets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/pkg/utils"
+)
+
+const (
+	defaultNvmetConfigfsDir = "/sys/kernel/config/nvmet"
+	nvmeofTcpPort           = "4420"
+	nvmeofNamespaceId       = "1"
+)
+
+// NVMeoFTarget is an interface for exposing the block device to the hosts
+// through the NVMe over TCP target of kernel.
+type NVMeoFTarget interface {
+	CreateNVMeoFTarget(volId, tgtNqn, path, hostNqn string) error
+	RemoveNVMeoFTarget(volId, tgtNqn string) error
+	GetPort() (string, error)
+}
+
+// NewNVMeoFTarget method creates a nvmet target which is configured through
+// the configfs of kernel mounted at configfsDir.
+func NewNVMeoFTarget(bip, configfsDir string) NVMeoFTarget {
+	return &nvmetTarget{
+		BindIp:      bip,
+		ConfigfsDir: configfsDir,
+	}
+}
+
+// nvmetTarget manages the subsystems, namespaces and ports in the nvmet
+// configfs, the layout of which is:
+//
+//	subsystems/<nqn>/attr_allow_any_host
+//	subsystems/<nqn>/allowed_hosts/<host nqn> -> hosts/<host nqn>
+//	subsystems/<nqn>/namespaces/<nsid>/{device_path,enable}
+//	ports/<port id>/{addr_trtype,addr_adrfam,addr_traddr,addr_trsvcid}
+//	ports/<port id>/subsystems/<nqn> -> subsystems/<nqn>
+type nvmetTarget struct {
+	BindIp      string
+	ConfigfsDir string
+}
+
+func (t *nvmetTarget) subsystemDir(nqn string) string {
+	return filepath.Join(t.ConfigfsDir, "subsystems", nqn)
+}
+
+func (t *nvmetTarget) CreateNVMeoFTarget(volId, tgtNqn, path, hostNqn string) error {
+	if exist, _ := utils.PathExists(t.ConfigfsDir); !exist {
+		return fmt.Errorf("nvmet configfs %s does not exist, please make sure "+
+			"that nvmet and nvmet-tcp modules are loaded", t.ConfigfsDir)
+	}
+
+	subDir := t.subsystemDir(tgtNqn)
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		log.Errorf("Failed to create nvmet subsystem of volume %s: %v", volId, err)
+		return err
+	}
+	// The subsystem is only accessible to the specified host if the nqn of
+	// host is provided, otherwise any host is allowed.
+	if hostNqn == "" {
+		if err := writeAttr(subDir, "attr_allow_any_host", "1"); err != nil {
+			return err
+		}
+	} else {
+		if err := writeAttr(subDir, "attr_allow_any_host", "0"); err != nil {
+			return err
+		}
+		hostDir := filepath.Join(t.ConfigfsDir, "hosts", hostNqn)
+		if err := os.MkdirAll(hostDir, 0755); err != nil {
+			log.Errorf("Failed to create nvmet host %s: %v", hostNqn, err)
+			return err
+		}
+		if err := link(hostDir, filepath.Join(subDir, "allowed_hosts", hostNqn)); err != nil {
+			return err
+		}
+	}
+
+	nsDir := filepath.Join(subDir, "namespaces", nvmeofNamespaceId)
+	if err := os.MkdirAll(nsDir, 0755); err != nil {
+		log.Errorf("Failed to create nvmet namespace of volume %s: %v", volId, err)
+		return err
+	}
+	if err := writeAttr(nsDir, "device_path", path); err != nil {
+		return err
+	}
+	if err := writeAttr(nsDir, "enable", "1"); err != nil {
+		return err
+	}
+
+	portId, err := t.GetPort()
+	if err != nil {
+		return err
+	}
+	portDir := filepath.Join(t.ConfigfsDir, "ports", portId)
+	return link(subDir, filepath.Join(portDir, "subsystems", tgtNqn))
+}
+
+func (t *nvmetTarget) RemoveNVMeoFTarget(volId, tgtNqn string) error {
+	subDir := t.subsystemDir(tgtNqn)
+	if exist, _ := utils.PathExists(subDir); !exist {
+		log.Warningf("Nvmet subsystem %s does not exist, nothing to remove.", subDir)
+		return nil
+	}
+
+	// The subsystem must be unlinked from all the ports and hosts before the
+	// namespaces and itself are removed.
+	ports, _ := ioutil.ReadDir(filepath.Join(t.ConfigfsDir, "ports"))
+	for _, p := range ports {
+		l := filepath.Join(t.ConfigfsDir, "ports", p.Name(), "subsystems", tgtNqn)
+		if err := os.Remove(l); err != nil && !os.IsNotExist(err) {
+			log.Errorf("Failed to unlink nvmet subsystem %s from port %s: %v", tgtNqn, p.Name(), err)
+			return err
+		}
+	}
+	hosts, _ := ioutil.ReadDir(filepath.Join(subDir, "allowed_hosts"))
+	for _, h := range hosts {
+		if err := os.Remove(filepath.Join(subDir, "allowed_hosts", h.Name())); err != nil {
+			log.Errorf("Failed to unlink nvmet host %s from subsystem %s: %v", h.Name(), tgtNqn, err)
+			return err
+		}
+	}
+
+	nsDir := filepath.Join(subDir, "namespaces", nvmeofNamespaceId)
+	if exist, _ := utils.PathExists(nsDir); exist {
+		writeAttr(nsDir, "enable", "0")
+		if err := removeDir(nsDir); err != nil {
+			log.Errorf("Failed to remove nvmet namespace of volume %s: %v", volId, err)
+			return err
+		}
+	}
+	if err := removeDir(subDir); err != nil {
+		log.Errorf("Failed to remove nvmet subsystem of volume %s: %v", volId, err)
+		return err
+	}
+	return nil
+}
+
+// GetPort returns the id of the tcp port listening on the bind ip, which is
+// shared by all the subsystems. The port is created if it doesn't exist.
+func (t *nvmetTarget) GetPort() (string, error) {
+	portsDir := filepath.Join(t.ConfigfsDir, "ports")
+	ports, _ := ioutil.ReadDir(portsDir)
+
+	var maxId int
+	for _, p := range ports {
+		id, err := strconv.Atoi(p.Name())
+		if err != nil {
+			continue
+		}
+		if id > maxId {
+			maxId = id
+		}
+		dir := filepath.Join(portsDir, p.Name())
+		if readAttr(dir, "addr_trtype") == "tcp" &&
+			readAttr(dir, "addr_traddr") == t.BindIp &&
+			readAttr(dir, "addr_trsvcid") == nvmeofTcpPort {
+			return p.Name(), nil
+		}
+	}
+
+	portId := strconv.Itoa(maxId + 1)
+	portDir := filepath.Join(portsDir, portId)
+	if err := os.MkdirAll(filepath.Join(portDir, "subsystems"), 0755); err != nil {
+		log.Errorf("Failed to create nvmet port %s: %v", portId, err)
+		return "", err
+	}
+	for _, attr := range [][2]string{
+		{"addr_trtype", "tcp"},
+		{"addr_adrfam", "ipv4"},
+		{"addr_traddr", t.BindIp},
+		{"addr_trsvcid", nvmeofTcpPort},
+	} {
+		if err := writeAttr(portDir, attr[0], attr[1]); err != nil {
+			return "", err
+		}
+	}
+	return portId, nil
+}
+
+func writeAttr(dir, name, value string) error {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+		log.Errorf("Failed to write %s to nvmet attribute %s/%s: %v", value, dir, name, err)
+		return err
+	}
+	return nil
+}
+
+func readAttr(dir, name string) string {
+	value, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
+
+func link(target, name string) error {
+	if _, err := os.Lstat(name); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	if err := os.Symlink(target, name); err != nil {
+		log.Errorf("Failed to link %s to %s: %v", name, target, err)
+		return err
+	}
+	return nil
+}
+
+// removeDir removes the directory of nvmet configfs. The attributes of the
+// directory are dropped along with it by configfs, so they are removed here
+// only when the directory is not empty, which happens outside of configfs.
+func removeDir(dir string) error {
+	if err := os.Remove(dir); err == nil || os.IsNotExist(err) {
+		return nil
+	}
+	return os.RemoveAll(dir)
+}
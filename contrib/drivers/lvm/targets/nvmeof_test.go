@@ -0,0 +1,160 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package targets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTestNVMeoFTarget(t *testing.T) (*nvmeofTarget, string) {
+	dir, err := ioutil.TempDir("", "nvmet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &nvmeofTarget{NVMeoFTarget: NewNVMeoFTarget("192.168.56.105", dir)}, dir
+}
+
+func assertAttr(t *testing.T, dir, name, expected string) {
+	if value := readAttr(dir, name); value != expected {
+		t.Errorf("Expected %s of %s is %s, got %s", name, dir, expected, value)
+	}
+}
+
+func TestNVMeoFCreateExport(t *testing.T) {
+	tgt, dir := newTestNVMeoFTarget(t)
+	defer os.RemoveAll(dir)
+
+	var volId = "bd5b12a8-a101-11e7-941e-d77981b584d8"
+	var lvPath = "/dev/vg001/volume-" + volId
+	conn, err := tgt.CreateExport(volId, lvPath, "ALL", "ALL", nil)
+	if err != nil {
+		t.Fatal("Failed to create nvmeof export:", err)
+	}
+	var expected = map[string]interface{}{
+		"targetNQN":        "nqn.2017-10.io.opensds:" + volId,
+		"transportType":    "tcp",
+		"transportAddress": "192.168.56.105",
+		"transportPort":    "4420",
+		"discard":          false,
+	}
+	if !reflect.DeepEqual(conn, expected) {
+		t.Errorf("Expected %v, got %v", expected, conn)
+	}
+
+	var subDir = filepath.Join(dir, "subsystems", "nqn.2017-10.io.opensds:"+volId)
+	assertAttr(t, subDir, "attr_allow_any_host", "1")
+	assertAttr(t, filepath.Join(subDir, "namespaces", "1"), "device_path", lvPath)
+	assertAttr(t, filepath.Join(subDir, "namespaces", "1"), "enable", "1")
+
+	var portDir = filepath.Join(dir, "ports", "1")
+	assertAttr(t, portDir, "addr_trtype", "tcp")
+	assertAttr(t, portDir, "addr_adrfam", "ipv4")
+	assertAttr(t, portDir, "addr_traddr", "192.168.56.105")
+	assertAttr(t, portDir, "addr_trsvcid", "4420")
+	if target, err := os.Readlink(filepath.Join(portDir, "subsystems", "nqn.2017-10.io.opensds:"+volId)); err != nil || target != subDir {
+		t.Errorf("Expected subsystem is linked to port, got %s, %v", target, err)
+	}
+}
+
+func TestNVMeoFCreateExportWithHostNqn(t *testing.T) {
+	tgt, dir := newTestNVMeoFTarget(t)
+	defer os.RemoveAll(dir)
+
+	var volId = "bd5b12a8-a101-11e7-941e-d77981b584d8"
+	var hostNqn = "nqn.2014-08.org.nvmexpress:uuid:0c4a4b2e-3e1a-4a51-9e7c-1c8f3c0d6b1a"
+	if _, err := tgt.CreateExport(volId, "/dev/vg001/volume-"+volId, "ALL", hostNqn, nil); err != nil {
+		t.Fatal("Failed to create nvmeof export:", err)
+	}
+
+	var subDir = filepath.Join(dir, "subsystems", "nqn.2017-10.io.opensds:"+volId)
+	assertAttr(t, subDir, "attr_allow_any_host", "0")
+	if _, err := os.Stat(filepath.Join(dir, "hosts", hostNqn)); err != nil {
+		t.Error("Expected nvmet host is created:", err)
+	}
+	if _, err := os.Lstat(filepath.Join(subDir, "allowed_hosts", hostNqn)); err != nil {
+		t.Error("Expected host is allowed to access subsystem:", err)
+	}
+}
+
+func TestNVMeoFPortShared(t *testing.T) {
+	tgt, dir := newTestNVMeoFTarget(t)
+	defer os.RemoveAll(dir)
+
+	// The port listening on other address is not reused.
+	var otherPort = filepath.Join(dir, "ports", "3")
+	os.MkdirAll(otherPort, 0755)
+	writeAttr(otherPort, "addr_trtype", "tcp")
+	writeAttr(otherPort, "addr_traddr", "192.168.56.106")
+	writeAttr(otherPort, "addr_trsvcid", "4420")
+
+	for _, volId := range []string{"vol-1", "vol-2"} {
+		if _, err := tgt.CreateExport(volId, "/dev/vg001/volume-"+volId, "ALL", "ALL", nil); err != nil {
+			t.Fatal("Failed to create nvmeof export:", err)
+		}
+	}
+	ports, _ := ioutil.ReadDir(filepath.Join(dir, "ports"))
+	if len(ports) != 2 {
+		t.Errorf("Expected 2 ports, got %d", len(ports))
+	}
+	links, _ := ioutil.ReadDir(filepath.Join(dir, "ports", "4", "subsystems"))
+	if len(links) != 2 {
+		t.Errorf("Expected 2 subsystems linked to port 4, got %d", len(links))
+	}
+}
+
+func TestNVMeoFRemoveExport(t *testing.T) {
+	tgt, dir := newTestNVMeoFTarget(t)
+	defer os.RemoveAll(dir)
+
+	var hostNqn = "nqn.2014-08.org.nvmexpress:uuid:0c4a4b2e-3e1a-4a51-9e7c-1c8f3c0d6b1a"
+	for _, volId := range []string{"vol-1", "vol-2"} {
+		if _, err := tgt.CreateExport(volId, "/dev/vg001/volume-"+volId, "ALL", hostNqn, nil); err != nil {
+			t.Fatal("Failed to create nvmeof export:", err)
+		}
+	}
+	if err := tgt.RemoveExport("vol-1"); err != nil {
+		t.Fatal("Failed to remove nvmeof export:", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "subsystems", "nqn.2017-10.io.opensds:vol-1")); !os.IsNotExist(err) {
+		t.Error("Expected subsystem is removed, got", err)
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "ports", "1", "subsystems", "nqn.2017-10.io.opensds:vol-1")); !os.IsNotExist(err) {
+		t.Error("Expected subsystem is unlinked from port, got", err)
+	}
+	// The port and host are still used by the other subsystem.
+	if _, err := os.Lstat(filepath.Join(dir, "ports", "1", "subsystems", "nqn.2017-10.io.opensds:vol-2")); err != nil {
+		t.Error("Expected the other subsystem is still linked to port:", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "hosts", hostNqn)); err != nil {
+		t.Error("Expected nvmet host is kept:", err)
+	}
+
+	// Removing the export which doesn't exist is not an error.
+	if err := tgt.RemoveExport("vol-1"); err != nil {
+		t.Error("Expected no error of removing export twice, got", err)
+	}
+}
+
+func TestNVMeoFWithoutConfigfs(t *testing.T) {
+	tgt := &nvmeofTarget{NVMeoFTarget: NewNVMeoFTarget("192.168.56.105", "/path/not/exist/nvmet")}
+	if _, err := tgt.CreateExport("vol-1", "/dev/vg001/volume-vol-1", "ALL", "ALL", nil); err == nil {
+		t.Error("Expected error when nvmet configfs doesn't exist")
+	}
+}
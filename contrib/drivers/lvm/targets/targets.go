@@ -14,20 +14,34 @@
 
 package targets
 
+import (
+	"strings"
+
+	. "github.com/opensds/opensds/contrib/drivers/utils/config"
+)
+
 const (
-	iscsiTgtPrefix = "iqn.2017-10.io.opensds:"
+	iscsiTgtPrefix  = "iqn.2017-10.io.opensds:"
+	nvmeofTgtPrefix = "nqn.2017-10.io.opensds:"
+	nvmeofNqnPrefix = "nqn."
 )
 
 // Target is an interface for exposing some operations of different targets,
-// currently support iscsiTarget.
+// currently support iscsiTarget and nvmeofTarget.
 type Target interface {
 	CreateExport(volId, path, hostIp, initiator string, chapAuth []string) (map[string]interface{}, error)
 
 	RemoveExport(volId string) error
 }
 
-// NewTarget method creates a new iscsi target.
-func NewTarget(bip string, tgtConfDir string) Target {
+// NewTarget method creates a new target of the access protocol, the iscsi
+// target is created if the access protocol is not specified.
+func NewTarget(bip string, tgtConfDir string, accessProtocol string) Target {
+	if accessProtocol == NVMEOFProtocol {
+		return &nvmeofTarget{
+			NVMeoFTarget: NewNVMeoFTarget(bip, defaultNvmetConfigfsDir),
+		}
+	}
 	return &iscsiTarget{
 		ISCSITarget: NewISCSITarget(bip, tgtConfDir),
 	}
@@ -62,3 +76,33 @@ func (t *iscsiTarget) RemoveExport(volId string) error {
 	tgtIqn := iscsiTgtPrefix + volId
 	return t.RemoveISCSITarget(volId, tgtIqn)
 }
+
+type nvmeofTarget struct {
+	NVMeoFTarget
+}
+
+// CreateExport exports the volume through the NVMe over TCP target. Only the
+// initiator in the format of nqn is used to restrict the access to the
+// target, and the chap authentication is not supported.
+func (t *nvmeofTarget) CreateExport(volId, path, hostIp, initiator string, chapAuth []string) (map[string]interface{}, error) {
+	tgtNqn := nvmeofTgtPrefix + volId
+	var hostNqn string
+	if strings.HasPrefix(initiator, nvmeofNqnPrefix) {
+		hostNqn = initiator
+	}
+	if err := t.CreateNVMeoFTarget(volId, tgtNqn, path, hostNqn); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"targetNQN":        tgtNqn,
+		"transportType":    "tcp",
+		"transportAddress": t.NVMeoFTarget.(*nvmetTarget).BindIp,
+		"transportPort":    nvmeofTcpPort,
+		"discard":          false,
+	}, nil
+}
+
+func (t *nvmeofTarget) RemoveExport(volId string) error {
+	tgtNqn := nvmeofTgtPrefix + volId
+	return t.RemoveNVMeoFTarget(volId, tgtNqn)
+}
@@ -0,0 +1,552 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+/*
+This module implements a reference file share driver, which creates an ext4
+filesystem on a logic volume of the configured volume groups for each file
+share, mounts it on the local host and exports the directory through kernel
+NFS server or NFS-Ganesha. The file shares are mounted again when the driver
+is set up, since the mounts don't survive the reboot of host. The access
+rules of a file share are kept in an export config file of the share, which
+is rewritten whenever a rule is added or removed and then reloaded by the NFS
+server.
+
+*/
+
+package nfs
+
+import (
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	. "github.com/opensds/opensds/contrib/drivers/utils/config"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/config"
+	"github.com/satori/go.uuid"
+)
+
+const (
+	defaultConfPath  = "/etc/opensds/driver/nfs.yaml"
+	defaultTgtBindIp = "127.0.0.1"
+	defaultMountRoot = "/var/lib/opensds/shares"
+	sharePrefix      = "opensds-"
+
+	KLvPath    = "lvPath"
+	KMountPath = "mountPath"
+)
+
+// The NFS servers which export the file shares.
+const (
+	ExportTypeKernel  = "kernel"
+	ExportTypeGanesha = "ganesha"
+
+	defaultKernelExportConfDir  = "/etc/exports.d"
+	defaultGaneshaExportConfDir = "/etc/ganesha/export.d"
+)
+
+type NFSConfig struct {
+	TgtBindIp string `yaml:"tgtBindIp"`
+	// One of: "kernel" or "ganesha".
+	ExportType string `yaml:"exportType"`
+	// The directory under which the file shares are mounted.
+	MountRoot string `yaml:"mountRoot"`
+	// The directory where the export config files of file shares are put,
+	// it should be included by the config of NFS-Ganesha if it's used.
+	ExportConfDir string                    `yaml:"exportConfDir"`
+	Pool          map[string]PoolProperties `yaml:"pool,flow"`
+}
+
+type Driver struct {
+	conf *NFSConfig
+
+	handler func(script string, cmd []string) (string, error)
+}
+
+func (d *Driver) Setup() error {
+	// Read nfs config file
+	d.conf = &NFSConfig{
+		TgtBindIp:  defaultTgtBindIp,
+		ExportType: ExportTypeKernel,
+		MountRoot:  defaultMountRoot,
+	}
+	p := config.CONF.OsdsDock.Backends.NFS.ConfigPath
+	if "" == p {
+		p = defaultConfPath
+	}
+	if _, err := Parse(d.conf, p); err != nil {
+		return err
+	}
+	if d.conf.ExportType != ExportTypeKernel && d.conf.ExportType != ExportTypeGanesha {
+		return fmt.Errorf("unsupported export type %s of nfs driver", d.conf.ExportType)
+	}
+	if d.conf.ExportConfDir == "" {
+		d.conf.ExportConfDir = defaultKernelExportConfDir
+		if d.conf.ExportType == ExportTypeGanesha {
+			d.conf.ExportConfDir = defaultGaneshaExportConfDir
+		}
+	}
+	if d.handler == nil {
+		d.handler = execCmd
+	}
+
+	return d.remountFileShares()
+}
+
+// remountFileShares mounts the file shares found in the configured volume
+// groups which are not mounted, and reloads the NFS server so that they are
+// exported again.
+func (d *Driver) remountFileShares() error {
+	info, err := d.handler("lvs", []string{
+		"--noheadings",
+		"-o", "vg_name,lv_name",
+	})
+	if err != nil {
+		log.Error("Failed to list logic volumes of file shares:", err)
+		return err
+	}
+
+	var remounted bool
+	for _, line := range strings.Split(info, "\n") {
+		val := strings.Fields(line)
+		if len(val) != 2 || !strings.HasPrefix(val[1], sharePrefix) {
+			continue
+		}
+		if _, ok := d.conf.Pool[val[0]]; !ok {
+			continue
+		}
+		var lvPath = path.Join("/dev", val[0], val[1])
+		var mountPath = d.mountPath(strings.TrimPrefix(val[1], sharePrefix))
+		if _, err := d.handler("mountpoint", []string{"-q", mountPath}); err == nil {
+			continue
+		}
+		if _, err := d.handler("mkdir", []string{"-p", mountPath}); err != nil {
+			log.Errorf("Failed to make mount point of file share %s: %v", mountPath, err)
+			continue
+		}
+		if _, err := d.handler("mount", []string{lvPath, mountPath}); err != nil {
+			log.Errorf("Failed to remount file share %s: %v", mountPath, err)
+			continue
+		}
+		log.Infof("Remount file share %s on %s success.", lvPath, mountPath)
+		remounted = true
+	}
+	if !remounted {
+		return nil
+	}
+	return d.reloadExports()
+}
+
+func (*Driver) Unset() error { return nil }
+
+func (d *Driver) mountPath(id string) string {
+	return path.Join(d.conf.MountRoot, sharePrefix+id)
+}
+
+func (d *Driver) CreateFileShare(opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error) {
+	if proto := opt.GetProtocol(); proto != "" && proto != NFSProtocol {
+		return nil, fmt.Errorf("nfs driver doesn't support the %s protocol", proto)
+	}
+	var id = opt.GetId()
+	var name = sharePrefix + id
+	var lvPath = path.Join("/dev", opt.GetPoolName(), name)
+	var mountPath = d.mountPath(id)
+
+	if _, err := d.handler("lvcreate", []string{
+		"-Z", "n",
+		"-n", name,
+		"-L", fmt.Sprint(opt.GetSize()) + "G",
+		opt.GetPoolName(),
+	}); err != nil {
+		log.Error("Failed to create logic volume of file share:", err)
+		return nil, err
+	}
+	// Remove the logic volume if the file share can't be made available.
+	if err := d.mountFileShare(lvPath, mountPath); err != nil {
+		if _, e := d.handler("lvremove", []string{"-f", lvPath}); e != nil {
+			log.Errorf("Failed to remove logic volume %s: %v", lvPath, e)
+		}
+		return nil, err
+	}
+	log.Infof("Create file share %s on %s success.", id, mountPath)
+
+	return &model.FileShareSpec{
+		BaseModel: &model.BaseModel{
+			Id: id,
+		},
+		Name:             opt.GetName(),
+		Size:             opt.GetSize(),
+		Description:      opt.GetDescription(),
+		AvailabilityZone: opt.GetAvailabilityZone(),
+		Protocol:         NFSProtocol,
+		ExportLocations:  []string{d.conf.TgtBindIp + ":" + mountPath},
+		Metadata: map[string]string{
+			KLvPath:    lvPath,
+			KMountPath: mountPath,
+		},
+	}, nil
+}
+
+func (d *Driver) mountFileShare(lvPath, mountPath string) error {
+	if _, err := d.handler("mkfs.ext4", []string{"-q", lvPath}); err != nil {
+		log.Error("Failed to make filesystem of file share:", err)
+		return err
+	}
+	if _, err := d.handler("mkdir", []string{"-p", mountPath}); err != nil {
+		log.Error("Failed to make mount point of file share:", err)
+		return err
+	}
+	if _, err := d.handler("mount", []string{lvPath, mountPath}); err != nil {
+		log.Error("Failed to mount file share:", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Driver) DeleteFileShare(opt *pb.DeleteFileShareOpts) error {
+	var id = opt.GetId()
+	var lvPath = opt.GetMetadata()[KLvPath]
+	var mountPath = opt.GetMetadata()[KMountPath]
+	if lvPath == "" {
+		return fmt.Errorf("can't find the logic volume of file share %s", id)
+	}
+	if mountPath == "" {
+		mountPath = d.mountPath(id)
+	}
+
+	// The file share is no longer exported once its export config is removed.
+	if err := d.writeRules(id, mountPath, nil); err != nil {
+		return err
+	}
+	if _, err := d.handler("umount", []string{mountPath}); err != nil {
+		// It may have been unmounted by the previous attempt.
+		log.Warningf("Failed to umount file share %s: %v", mountPath, err)
+	}
+	if _, err := d.handler("rmdir", []string{mountPath}); err != nil {
+		log.Warningf("Failed to remove mount point of file share %s: %v", mountPath, err)
+	}
+	if _, err := d.handler("lvremove", []string{"-f", lvPath}); err != nil {
+		log.Error("Failed to remove logic volume of file share:", err)
+		return err
+	}
+	log.Infof("Remove file share %s success.", id)
+	return nil
+}
+
+// ExtendFileShare extends the logic volume and then grows the filesystem on
+// it online, the file share stays mounted and exported meanwhile.
+func (d *Driver) ExtendFileShare(opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error) {
+	var lvPath = opt.GetMetadata()[KLvPath]
+	if lvPath == "" {
+		return nil, fmt.Errorf("can't find the logic volume of file share %s", opt.GetId())
+	}
+	if _, err := d.handler("lvextend", []string{
+		"-L", fmt.Sprint(opt.GetSize()) + "G",
+		lvPath,
+	}); err != nil {
+		log.Error("Failed to extend logic volume of file share:", err)
+		return nil, err
+	}
+	if _, err := d.handler("resize2fs", []string{lvPath}); err != nil {
+		log.Error("Failed to resize filesystem of file share:", err)
+		return nil, err
+	}
+
+	return &model.FileShareSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		Size: opt.GetSize(),
+	}, nil
+}
+
+// exportRule allows the clients to access the file share with the level.
+type exportRule struct {
+	client string
+	level  string
+}
+
+func validateAcl(accessType, accessTo string) error {
+	if accessType != model.FileShareAccessTypeIP {
+		return fmt.Errorf("nfs driver doesn't support the %s access type", accessType)
+	}
+	if net.ParseIP(accessTo) == nil {
+		if _, _, err := net.ParseCIDR(accessTo); err != nil {
+			return fmt.Errorf("invalid ip or cidr %s", accessTo)
+		}
+	}
+	return nil
+}
+
+func (d *Driver) CreateFileShareAcl(opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error) {
+	if err := validateAcl(opt.GetType(), opt.GetAccessTo()); err != nil {
+		return nil, err
+	}
+	var level = opt.GetAccessLevel()
+	if level != model.FileShareAccessLevelRO && level != model.FileShareAccessLevelRW {
+		return nil, fmt.Errorf("invalid access level %s", level)
+	}
+
+	var shareId = opt.GetFileShareId()
+	rules, err := d.readRules(shareId)
+	if err != nil {
+		return nil, err
+	}
+	// The rule of the same clients is replaced.
+	var found bool
+	for _, r := range rules {
+		if r.client == opt.GetAccessTo() {
+			r.level, found = level, true
+		}
+	}
+	if !found {
+		rules = append(rules, &exportRule{client: opt.GetAccessTo(), level: level})
+	}
+	if err := d.writeRules(shareId, d.mountPathOf(shareId, opt.GetMetadata()), rules); err != nil {
+		return nil, err
+	}
+	log.Infof("Grant %s access of file share %s to %s success.", level, shareId, opt.GetAccessTo())
+
+	return &model.FileShareAclSpec{
+		BaseModel: &model.BaseModel{
+			Id: opt.GetId(),
+		},
+		FileShareId: shareId,
+		Type:        opt.GetType(),
+		AccessTo:    opt.GetAccessTo(),
+		AccessLevel: level,
+	}, nil
+}
+
+func (d *Driver) DeleteFileShareAcl(opt *pb.DeleteFileShareAclOpts) error {
+	var shareId = opt.GetFileShareId()
+	rules, err := d.readRules(shareId)
+	if err != nil {
+		return err
+	}
+	var remained []*exportRule
+	for _, r := range rules {
+		if r.client != opt.GetAccessTo() {
+			remained = append(remained, r)
+		}
+	}
+	if len(remained) == len(rules) {
+		log.Warningf("Access of file share %s to %s is not found.", shareId, opt.GetAccessTo())
+		return nil
+	}
+	if err := d.writeRules(shareId, d.mountPathOf(shareId, opt.GetMetadata()), remained); err != nil {
+		return err
+	}
+	log.Infof("Revoke access of file share %s to %s success.", shareId, opt.GetAccessTo())
+	return nil
+}
+
+func (d *Driver) mountPathOf(id string, metadata map[string]string) string {
+	if p := metadata[KMountPath]; p != "" {
+		return p
+	}
+	return d.mountPath(id)
+}
+
+func (d *Driver) exportConfPath(id string) string {
+	if d.conf.ExportType == ExportTypeGanesha {
+		return path.Join(d.conf.ExportConfDir, sharePrefix+id+".conf")
+	}
+	return path.Join(d.conf.ExportConfDir, sharePrefix+id+".exports")
+}
+
+var ganeshaClientRegexp = regexp.MustCompile(`CLIENT\s*{\s*Clients\s*=\s*([^;]+);\s*Access_Type\s*=\s*(\w+);\s*}`)
+
+// readRules parses the rules from the export config file of the file share,
+// there is no rule if the file doesn't exist.
+func (d *Driver) readRules(id string) ([]*exportRule, error) {
+	body, err := ioutil.ReadFile(d.exportConfPath(id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error("Failed to read export config of file share:", err)
+		return nil, err
+	}
+
+	var rules []*exportRule
+	if d.conf.ExportType == ExportTypeGanesha {
+		for _, m := range ganeshaClientRegexp.FindAllStringSubmatch(string(body), -1) {
+			rules = append(rules, &exportRule{
+				client: strings.TrimSpace(m[1]),
+				level:  strings.ToLower(m[2]),
+			})
+		}
+		return rules, nil
+	}
+	// Each line is in the format of "path client(options)".
+	for _, line := range strings.Split(string(body), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		i := strings.Index(fields[1], "(")
+		if i < 0 {
+			continue
+		}
+		rules = append(rules, &exportRule{
+			client: fields[1][:i],
+			level:  strings.SplitN(fields[1][i+1:], ",", 2)[0],
+		})
+	}
+	return rules, nil
+}
+
+// writeRules rewrites the export config file of the file share with the rules
+// and reloads the NFS server, the file is removed if there is no rule.
+func (d *Driver) writeRules(id, mountPath string, rules []*exportRule) error {
+	var p = d.exportConfPath(id)
+	if len(rules) == 0 {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			log.Error("Failed to remove export config of file share:", err)
+			return err
+		}
+	} else {
+		if err := os.MkdirAll(d.conf.ExportConfDir, 0755); err != nil {
+			log.Error("Failed to make export config directory:", err)
+			return err
+		}
+		if err := ioutil.WriteFile(p, []byte(d.renderRules(id, mountPath, rules)), 0644); err != nil {
+			log.Error("Failed to write export config of file share:", err)
+			return err
+		}
+	}
+	return d.reloadExports()
+}
+
+func (d *Driver) renderRules(id, mountPath string, rules []*exportRule) string {
+	var lines []string
+	if d.conf.ExportType == ExportTypeGanesha {
+		lines = append(lines,
+			"EXPORT {",
+			"    Export_Id = "+strconv.FormatUint(uint64(exportId(id)), 10)+";",
+			"    Path = "+mountPath+";",
+			"    Pseudo = "+mountPath+";",
+			"    Protocols = 3, 4;",
+			"    Access_Type = None;",
+			"    Squash = No_Root_Squash;",
+			"    FSAL { Name = VFS; }",
+		)
+		for _, r := range rules {
+			lines = append(lines, fmt.Sprintf("    CLIENT { Clients = %s; Access_Type = %s; }",
+				r.client, strings.ToUpper(r.level)))
+		}
+		lines = append(lines, "}")
+	} else {
+		for _, r := range rules {
+			lines = append(lines, fmt.Sprintf("%s %s(%s,sync,no_subtree_check,no_root_squash)",
+				mountPath, r.client, r.level))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// exportId derives the export id required by NFS-Ganesha from the uuid of
+// file share, the ids 0 and 1 are reserved by NFS-Ganesha.
+func exportId(id string) uint32 {
+	return crc32.ChecksumIEEE([]byte(id))%65000 + 2
+}
+
+func (d *Driver) reloadExports() error {
+	var err error
+	if d.conf.ExportType == ExportTypeGanesha {
+		_, err = d.handler("systemctl", []string{"reload", "nfs-ganesha"})
+	} else {
+		_, err = d.handler("exportfs", []string{"-ra"})
+	}
+	if err != nil {
+		log.Error("Failed to reload exports of nfs server:", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Driver) ListPools() ([]*model.StoragePoolSpec, error) {
+	info, err := d.handler("vgs", []string{
+		"--noheadings", "--nosuffix",
+		"--unit=g",
+		"-o", "name,size,free,uuid",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pols []*model.StoragePoolSpec
+	for _, line := range strings.Split(info, "\n") {
+		val := strings.Fields(line)
+		if len(val) != 4 {
+			continue
+		}
+		prop, ok := d.conf.Pool[val[0]]
+		if !ok {
+			continue
+		}
+		total, _ := strconv.ParseFloat(val[1], 64)
+		free, _ := strconv.ParseFloat(val[2], 64)
+
+		pol := &model.StoragePoolSpec{
+			BaseModel: &model.BaseModel{
+				// Differ from the pool id given by lvm driver, in case the
+				// volume group is configured in both of the backends.
+				Id: uuid.NewV5(uuid.NamespaceOID, NFSDriverType+":"+val[3]).String(),
+			},
+			Name:                val[0],
+			TotalCapacity:       int64(total),
+			FreeCapacity:        int64(free),
+			ProvisionedCapacity: int64(total) - int64(free),
+			AllocatedCapacity:   int64(total) - int64(free),
+			// The logic volumes are fully allocated in the volume group.
+			MaxOverSubscriptionRatio: 1,
+			StorageType:              prop.StorageType,
+			Extras:                   prop.Extras,
+			AvailabilityZone:         prop.AvailabilityZone,
+		}
+		if pol.StorageType == "" {
+			pol.StorageType = model.StorageTypeFile
+		}
+		if pol.AvailabilityZone == "" {
+			pol.AvailabilityZone = "default"
+		}
+		if pol.Extras.IOConnectivity.AccessProtocol == "" {
+			pol.Extras.IOConnectivity.AccessProtocol = NFSProtocol
+		}
+		pols = append(pols, pol)
+	}
+	return pols, nil
+}
+
+func execCmd(script string, cmd []string) (string, error) {
+	log.Infof("Command: %s %s", script, strings.Join(cmd, " "))
+	info, err := exec.Command(script, cmd...).Output()
+	if err != nil {
+		log.Error(info, err.Error())
+		return "", err
+	}
+	log.V(8).Infof("Command Result:\n%s", string(info))
+	return string(info), nil
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package nfs
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/opensds/opensds/contrib/drivers/utils/config"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/config"
+)
+
+var fp = map[string]PoolProperties{
+	"vg002": {
+		StorageType:      "file",
+		AvailabilityZone: "default",
+		Extras: model.StoragePoolExtraSpec{
+			DataStorage: model.DataStorageLoS{
+				ProvisioningPolicy: "Thick",
+				IsSpaceEfficient:   false,
+			},
+			IOConnectivity: model.IOConnectivityLoS{
+				AccessProtocol: "nfs",
+				MaxIOPS:        7000000,
+				MaxBWS:         600,
+			},
+			Advanced: map[string]interface{}{
+				"diskType": "SSD",
+				"latency":  "5ms",
+			},
+		},
+	},
+}
+
+func TestSetup(t *testing.T) {
+	var r = &recorder{outputs: map[string]string{}}
+	var d = &Driver{handler: r.handle}
+	config.CONF.OsdsDock.Backends.NFS.ConfigPath = "testdata/nfs.yaml"
+	var expected = &NFSConfig{
+		TgtBindIp:     "192.168.56.105",
+		ExportType:    ExportTypeGanesha,
+		MountRoot:     "/var/lib/opensds/shares",
+		ExportConfDir: defaultGaneshaExportConfDir,
+		Pool:          fp,
+	}
+
+	if err := d.Setup(); err != nil {
+		t.Errorf("Setup nfs driver failed: %+v\n", err)
+	}
+	if !reflect.DeepEqual(d.conf, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, d.conf)
+	}
+}
+
+// recorder records the commands and returns the output or error of them.
+type recorder struct {
+	cmds    []string
+	outputs map[string]string
+	errs    map[string]error
+}
+
+func (r *recorder) handle(script string, cmd []string) (string, error) {
+	r.cmds = append(r.cmds, strings.Join(append([]string{script}, cmd...), " "))
+	return r.outputs[script], r.errs[script]
+}
+
+func newFakeDriver(t *testing.T, exportType string) (*Driver, *recorder, func()) {
+	dir, err := ioutil.TempDir("", "nfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &recorder{outputs: map[string]string{}}
+	d := &Driver{
+		conf: &NFSConfig{
+			TgtBindIp:     "192.168.56.105",
+			ExportType:    exportType,
+			MountRoot:     "/var/lib/opensds/shares",
+			ExportConfDir: dir,
+			Pool:          fp,
+		},
+		handler: r.handle,
+	}
+	return d, r, func() { os.RemoveAll(dir) }
+}
+
+func TestCreateFileShare(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeKernel)
+	defer clean()
+
+	share, err := d.CreateFileShare(&pb.CreateFileShareOpts{
+		Id:       "d2975ebe-d82c-430f-b28e-f373746a71ca",
+		Name:     "test001",
+		Size:     1,
+		PoolName: "vg002",
+		Protocol: "nfs",
+	})
+	if err != nil {
+		t.Fatal("Create file share failed:", err)
+	}
+	var mountPath = "/var/lib/opensds/shares/opensds-d2975ebe-d82c-430f-b28e-f373746a71ca"
+	var lvPath = "/dev/vg002/opensds-d2975ebe-d82c-430f-b28e-f373746a71ca"
+	var expectedCmds = []string{
+		"lvcreate -Z n -n opensds-d2975ebe-d82c-430f-b28e-f373746a71ca -L 1G vg002",
+		"mkfs.ext4 -q " + lvPath,
+		"mkdir -p " + mountPath,
+		"mount " + lvPath + " " + mountPath,
+	}
+	if !reflect.DeepEqual(r.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, r.cmds)
+	}
+	if !reflect.DeepEqual(share.ExportLocations, []string{"192.168.56.105:" + mountPath}) {
+		t.Errorf("Unexpected export locations %v", share.ExportLocations)
+	}
+	if share.Metadata[KLvPath] != lvPath || share.Metadata[KMountPath] != mountPath {
+		t.Errorf("Unexpected metadata %v", share.Metadata)
+	}
+
+	if _, err := d.CreateFileShare(&pb.CreateFileShareOpts{Protocol: "cifs"}); err == nil {
+		t.Error("Expected error of unsupported protocol")
+	}
+}
+
+func TestExtendFileShare(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeKernel)
+	defer clean()
+
+	share, err := d.ExtendFileShare(&pb.ExtendFileShareOpts{
+		Id:       "d2975ebe-d82c-430f-b28e-f373746a71ca",
+		Size:     2,
+		Metadata: map[string]string{KLvPath: "/dev/vg002/opensds-xxx"},
+	})
+	if err != nil {
+		t.Fatal("Extend file share failed:", err)
+	}
+	var expectedCmds = []string{
+		"lvextend -L 2G /dev/vg002/opensds-xxx",
+		"resize2fs /dev/vg002/opensds-xxx",
+	}
+	if !reflect.DeepEqual(r.cmds, expectedCmds) || share.Size != 2 {
+		t.Errorf("Unexpected commands %v or size %d", r.cmds, share.Size)
+	}
+}
+
+func TestKernelFileShareAcl(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeKernel)
+	defer clean()
+
+	var shareId = "d2975ebe-d82c-430f-b28e-f373746a71ca"
+	var meta = map[string]string{KMountPath: "/var/lib/opensds/shares/opensds-" + shareId}
+	for _, acl := range []*pb.CreateFileShareAclOpts{
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.56.0/24", AccessLevel: "rw", Metadata: meta},
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.57.10", AccessLevel: "ro", Metadata: meta},
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.56.0/24", AccessLevel: "ro", Metadata: meta},
+	} {
+		if _, err := d.CreateFileShareAcl(acl); err != nil {
+			t.Fatal("Create file share acl failed:", err)
+		}
+	}
+	var confPath = path.Join(d.conf.ExportConfDir, "opensds-"+shareId+".exports")
+	body, _ := ioutil.ReadFile(confPath)
+	var expected = meta[KMountPath] + " 192.168.56.0/24(ro,sync,no_subtree_check,no_root_squash)\n" +
+		meta[KMountPath] + " 192.168.57.10(ro,sync,no_subtree_check,no_root_squash)\n"
+	if string(body) != expected {
+		t.Errorf("Expected export config %q, got %q", expected, string(body))
+	}
+	if len(r.cmds) != 3 || r.cmds[0] != "exportfs -ra" {
+		t.Errorf("Unexpected commands %v", r.cmds)
+	}
+
+	for _, accessTo := range []string{"192.168.56.0/24", "192.168.57.10"} {
+		if err := d.DeleteFileShareAcl(&pb.DeleteFileShareAclOpts{
+			FileShareId: shareId, Type: "ip", AccessTo: accessTo, Metadata: meta,
+		}); err != nil {
+			t.Fatal("Delete file share acl failed:", err)
+		}
+	}
+	// The export config is removed along with the last rule.
+	if _, err := os.Stat(confPath); !os.IsNotExist(err) {
+		t.Error("Expected export config to be removed")
+	}
+
+	for _, acl := range []*pb.CreateFileShareAclOpts{
+		{FileShareId: shareId, Type: "user", AccessTo: "admin", AccessLevel: "rw"},
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.56.300", AccessLevel: "rw"},
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.56.0/24", AccessLevel: "all"},
+	} {
+		if _, err := d.CreateFileShareAcl(acl); err == nil {
+			t.Errorf("Expected error of invalid acl %v", acl)
+		}
+	}
+}
+
+func TestGaneshaFileShareAcl(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeGanesha)
+	defer clean()
+
+	var shareId = "d2975ebe-d82c-430f-b28e-f373746a71ca"
+	for _, acl := range []*pb.CreateFileShareAclOpts{
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.56.0/24", AccessLevel: "rw"},
+		{FileShareId: shareId, Type: "ip", AccessTo: "192.168.57.10", AccessLevel: "ro"},
+	} {
+		if _, err := d.CreateFileShareAcl(acl); err != nil {
+			t.Fatal("Create file share acl failed:", err)
+		}
+	}
+	rules, err := d.readRules(shareId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = []*exportRule{
+		{client: "192.168.56.0/24", level: "rw"},
+		{client: "192.168.57.10", level: "ro"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, rules)
+	}
+	body, _ := ioutil.ReadFile(d.exportConfPath(shareId))
+	if !strings.Contains(string(body), "Path = /var/lib/opensds/shares/opensds-"+shareId+";") ||
+		!strings.Contains(string(body), "CLIENT { Clients = 192.168.56.0/24; Access_Type = RW; }") {
+		t.Errorf("Unexpected export config %s", string(body))
+	}
+	if r.cmds[0] != "systemctl reload nfs-ganesha" {
+		t.Errorf("Unexpected commands %v", r.cmds)
+	}
+}
+
+func TestDeleteFileShare(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeKernel)
+	defer clean()
+
+	var shareId = "d2975ebe-d82c-430f-b28e-f373746a71ca"
+	if _, err := d.CreateFileShareAcl(&pb.CreateFileShareAclOpts{
+		FileShareId: shareId, Type: "ip", AccessTo: "192.168.56.0/24", AccessLevel: "rw",
+	}); err != nil {
+		t.Fatal("Create file share acl failed:", err)
+	}
+	r.cmds = nil
+
+	if err := d.DeleteFileShare(&pb.DeleteFileShareOpts{
+		Id:       shareId,
+		Metadata: map[string]string{KLvPath: "/dev/vg002/opensds-" + shareId},
+	}); err != nil {
+		t.Fatal("Delete file share failed:", err)
+	}
+	var mountPath = "/var/lib/opensds/shares/opensds-" + shareId
+	var expectedCmds = []string{
+		"exportfs -ra",
+		"umount " + mountPath,
+		"rmdir " + mountPath,
+		"lvremove -f /dev/vg002/opensds-" + shareId,
+	}
+	if !reflect.DeepEqual(r.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, r.cmds)
+	}
+	if _, err := os.Stat(d.exportConfPath(shareId)); !os.IsNotExist(err) {
+		t.Error("Expected export config to be removed")
+	}
+}
+
+func TestRemountFileShares(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeKernel)
+	defer clean()
+	var shareId = "d2975ebe-d82c-430f-b28e-f373746a71ca"
+	r.outputs["lvs"] = `  vg001 opensds-9b1c7b3e-2a4f-4d3e-8f6a-6c1d2e3f4a5b
+  vg002 opensds-` + shareId + `
+  vg002 volume-bd5b12a8-a101-11e7-941e-d77981b584d8
+`
+	r.errs = map[string]error{"mountpoint": errors.New("exit status 1")}
+
+	if err := d.remountFileShares(); err != nil {
+		t.Fatal("Remount file shares failed:", err)
+	}
+	var lvPath = "/dev/vg002/opensds-" + shareId
+	var mountPath = "/var/lib/opensds/shares/opensds-" + shareId
+	var expectedCmds = []string{
+		"lvs --noheadings -o vg_name,lv_name",
+		"mountpoint -q " + mountPath,
+		"mkdir -p " + mountPath,
+		"mount " + lvPath + " " + mountPath,
+		"exportfs -ra",
+	}
+	if !reflect.DeepEqual(r.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, r.cmds)
+	}
+
+	// The file shares which are still mounted are left alone.
+	r.cmds, r.errs = nil, nil
+	if err := d.remountFileShares(); err != nil {
+		t.Fatal("Remount file shares failed:", err)
+	}
+	expectedCmds = []string{
+		"lvs --noheadings -o vg_name,lv_name",
+		"mountpoint -q " + mountPath,
+	}
+	if !reflect.DeepEqual(r.cmds, expectedCmds) {
+		t.Errorf("Expected %v, got %v", expectedCmds, r.cmds)
+	}
+}
+
+func TestListPools(t *testing.T) {
+	d, r, clean := newFakeDriver(t, ExportTypeKernel)
+	defer clean()
+	r.outputs["vgs"] = `  vg001   18.00   18.00 ahF0kx-zfBH-Y8EM-zBi3-pzTO-Ngnk-Td3vmv
+  vg002   20.00   15.00 Qq6rLp-zfBH-Y8EM-zBi3-pzTO-Ngnk-Td3vmv
+`
+
+	pols, err := d.ListPools()
+	if err != nil {
+		t.Fatal("List pools failed:", err)
+	}
+	if len(pols) != 1 {
+		t.Fatalf("Expected 1 pool, got %d", len(pols))
+	}
+	pol := pols[0]
+	if pol.Name != "vg002" || pol.StorageType != model.StorageTypeFile ||
+		pol.TotalCapacity != 20 || pol.FreeCapacity != 15 || pol.ProvisionedCapacity != 5 ||
+		pol.MaxOverSubscriptionRatio != 1 {
+		t.Errorf("Unexpected pool %+v", pol)
+	}
+}
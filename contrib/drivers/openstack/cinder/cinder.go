@@ -244,6 +244,11 @@ func (d *Driver) PullVolume(volID string) (*model.VolumeSpec, error) {
 	}, nil
 }
 
+// CloneVolume
+func (d *Driver) CloneVolume(req *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	return nil, &model.NotImplementError{S: "Method CloneVolume has not been implemented yet"}
+}
+
 // DeleteVolume
 func (d *Driver) DeleteVolume(req *pb.DeleteVolumeOpts) error {
 	cinderVolId := req.Metadata[KCinderVolumeId]
@@ -279,6 +284,21 @@ func (d *Driver) ExtendVolume(req *pb.ExtendVolumeOpts) (*model.VolumeSpec, erro
 	}, nil
 }
 
+// RevertToSnapshot
+func (d *Driver) RevertToSnapshot(req *pb.RevertToSnapshotOpts) error {
+	return &model.NotImplementError{S: "Method RevertToSnapshot has not been implemented yet"}
+}
+
+// MigrateVolume
+func (d *Driver) MigrateVolume(req *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return nil, &model.NotImplementError{S: "Method MigrateVolume has not been implemented yet"}
+}
+
+// RetypeVolume
+func (d *Driver) RetypeVolume(req *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	return nil, &model.NotImplementError{S: "Method RetypeVolume has not been implemented yet"}
+}
+
 // InitializeConnection
 func (d *Driver) InitializeConnection(req *pb.CreateAttachmentOpts) (*model.ConnectionInfo, error) {
 	opts := &volumeactions.InitializeConnectionOpts{
@@ -358,7 +378,7 @@ func (d *Driver) CreateSnapshot(req *pb.CreateVolumeSnapshotOpts) (*model.Volume
 		for {
 			select {
 			case <-ticker.C:
-				tmpSnp, err := d.PullSnapshot(snp.ID)
+				tmpSnp, err := d.pullSnapshot(snp.ID)
 				if err != nil {
 					continue
 				}
@@ -390,7 +410,11 @@ func (d *Driver) CreateSnapshot(req *pb.CreateVolumeSnapshotOpts) (*model.Volume
 }
 
 // PullSnapshot
-func (d *Driver) PullSnapshot(snapID string) (*model.VolumeSnapshotSpec, error) {
+func (d *Driver) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	return d.pullSnapshot(opt.GetId())
+}
+
+func (d *Driver) pullSnapshot(snapID string) (*model.VolumeSnapshotSpec, error) {
 	snp, err := snapshotsv2.Get(d.blockStoragev2, snapID).Extract()
 	if err != nil {
 		log.Error("Cannot get snapshot:", err)
@@ -483,3 +507,11 @@ func (d *Driver) UpdateVolumeGroup(req *pb.UpdateVolumeGroupOpts, vg *model.Volu
 func (d *Driver) DeleteVolumeGroup(req *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error) {
 	return nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup has not been implemented"}
 }
+
+func (d *Driver) CreateGroupSnapshot(req *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	return nil, &model.NotImplementError{S: "Method CreateGroupSnapshot has not been implemented"}
+}
+
+func (d *Driver) DeleteGroupSnapshot(req *pb.DeleteGroupSnapshotOpts) error {
+	return &model.NotImplementError{S: "Method DeleteGroupSnapshot has not been implemented"}
+}
@@ -24,6 +24,7 @@ package drivers
 import (
 	"reflect"
 
+	"github.com/opensds/opensds/contrib/drivers/ceph"
 	"github.com/opensds/opensds/contrib/drivers/drbd"
 	"github.com/opensds/opensds/contrib/drivers/huawei/dorado"
 	driversConfig "github.com/opensds/opensds/contrib/drivers/utils/config"
@@ -34,7 +35,7 @@ import (
 )
 
 // ReplicationDriver is an interface for exposing some operations of different
-// replication drivers, currently supporting DRBD.
+// replication drivers, currently supporting DRBD, Huawei Dorado and Ceph.
 type ReplicationDriver interface {
 	// Any initialization the replication driver does while starting.
 	Setup() error
@@ -71,6 +72,9 @@ func InitReplicationDriver(resourceType string) (ReplicationDriver, error) {
 	case driversConfig.HuaweiDoradoDriverType:
 		d = &dorado.ReplicationDriver{}
 		break
+	case driversConfig.CephDriverType:
+		d = &ceph.ReplicationDriver{}
+		break
 	default:
 		d = &replication_sample.ReplicationDriver{}
 		break
@@ -87,6 +91,8 @@ func CleanReplicationDriver(d ReplicationDriver) ReplicationDriver {
 		break
 	case *dorado.ReplicationDriver:
 		d = &dorado.ReplicationDriver{}
+	case *ceph.ReplicationDriver:
+		break
 	default:
 		break
 	}
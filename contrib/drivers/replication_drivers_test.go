@@ -0,0 +1,40 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drivers
+
+import (
+	"testing"
+
+	"github.com/opensds/opensds/contrib/drivers/ceph"
+	"github.com/opensds/opensds/pkg/utils/config"
+)
+
+func TestIsSupportHostBasedReplication(t *testing.T) {
+	config.CONF.OsdsDock.Backends.Ceph.SupportReplication = true
+	defer func() { config.CONF.OsdsDock.Backends.Ceph.SupportReplication = false }()
+
+	if !IsSupportHostBasedReplication("ceph") {
+		t.Error("Expected ceph to support array based replication")
+	}
+	if IsSupportHostBasedReplication("lvm") {
+		t.Error("Expected lvm not to support array based replication")
+	}
+}
+
+func TestCleanReplicationDriver(t *testing.T) {
+	if d := CleanReplicationDriver(&ceph.ReplicationDriver{}); d != nil {
+		t.Errorf("Expected %v, got %v\n", nil, d)
+	}
+}
@@ -29,14 +29,18 @@ const (
 	HuaweiFusionStorageDriverType = "huawei_fusionstorage"
 
 	DRBDDriverType = "drbd"
+
+	NFSDriverType = "nfs"
 )
 
 // These constants below represent the access protocol type of all storage
 // drivers which can be supported by now. Please NOTICE that currently these
 // constants can NOT be used by all methods except InitializeConnection().
 const (
-	ISCSIProtocol = "iscsi"
-	DSWARE        = "DSWARE"
-	RBDProtocol   = "rbd"
-	FCProtocol    = "fibre_channel"
+	ISCSIProtocol  = "iscsi"
+	DSWARE         = "DSWARE"
+	RBDProtocol    = "rbd"
+	FCProtocol     = "fibre_channel"
+	NFSProtocol    = "nfs"
+	NVMEOFProtocol = "nvmeof"
 )
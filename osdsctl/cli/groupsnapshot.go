@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var groupSnapshotCommand = &cobra.Command{
+	Use:   "snapshot",
+	Short: "manage group snapshots of volume groups in the cluster",
+	Run:   groupSnapshotAction,
+}
+
+var groupSnapshotCreateCommand = &cobra.Command{
+	Use:   "create <group id>",
+	Short: "create a snapshot of all volumes in the volume group at the same point in time",
+	Run:   groupSnapshotCreateAction,
+}
+
+var groupSnapshotShowCommand = &cobra.Command{
+	Use:   "show <id>",
+	Short: "show a group snapshot in the cluster",
+	Run:   groupSnapshotShowAction,
+}
+
+var groupSnapshotListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "list all group snapshots in the cluster",
+	Run:   groupSnapshotListAction,
+}
+
+var groupSnapshotDeleteCommand = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "delete a group snapshot along with its member snapshots",
+	Run:   groupSnapshotDeleteAction,
+}
+
+var (
+	gsLimit   string
+	gsOffset  string
+	gsSortDir string
+	gsSortKey string
+	gsId      string
+	gsGroupId string
+	gsStatus  string
+
+	gsName string
+	gsDesp string
+)
+
+func init() {
+	groupSnapshotListCommand.Flags().StringVarP(&gsLimit, "limit", "", "50", "the number of ertries displayed per page")
+	groupSnapshotListCommand.Flags().StringVarP(&gsOffset, "offset", "", "0", "all requested data offsets")
+	groupSnapshotListCommand.Flags().StringVarP(&gsSortDir, "sortDir", "", "desc", "the sort direction of all requested data. supports asc or desc(default)")
+	groupSnapshotListCommand.Flags().StringVarP(&gsSortKey, "sortKey", "", "id",
+		"the sort key of all requested data. supports id(default), name, status, groupid")
+	groupSnapshotListCommand.Flags().StringVarP(&gsId, "id", "", "", "list group snapshot by id")
+	groupSnapshotListCommand.Flags().StringVarP(&gsGroupId, "groupId", "", "", "list group snapshot by volume group id")
+	groupSnapshotListCommand.Flags().StringVarP(&gsStatus, "status", "", "", "list group snapshot by status")
+	groupSnapshotListCommand.Flags().StringVarP(&gsName, "name", "", "", "list group snapshot by name")
+
+	groupSnapshotCommand.AddCommand(groupSnapshotCreateCommand)
+	groupSnapshotCreateCommand.Flags().StringVarP(&gsName, "name", "n", "", "the name of created group snapshot")
+	groupSnapshotCreateCommand.Flags().StringVarP(&gsDesp, "description", "d", "", "the description of created group snapshot")
+	groupSnapshotCommand.AddCommand(groupSnapshotShowCommand)
+	groupSnapshotCommand.AddCommand(groupSnapshotListCommand)
+	groupSnapshotCommand.AddCommand(groupSnapshotDeleteCommand)
+}
+
+func groupSnapshotAction(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+	os.Exit(1)
+}
+
+func groupSnapshotCreateAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	gs := &model.GroupSnapshotSpec{
+		Name:        gsName,
+		Description: gsDesp,
+		GroupId:     args[0],
+	}
+
+	resp, err := client.CreateGroupSnapshot(gs)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Status", "GroupId", "Snapshots"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func groupSnapshotShowAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	resp, err := client.GetGroupSnapshot(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Status", "GroupId", "Snapshots"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func groupSnapshotListAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 0)
+
+	var opts = map[string]string{"limit": gsLimit, "offset": gsOffset, "sortDir": gsSortDir,
+		"sortKey": gsSortKey, "Id": gsId, "Name": gsName, "GroupId": gsGroupId,
+		"Status": gsStatus}
+
+	resp, err := client.ListGroupSnapshots(opts)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "Name", "Description", "Status", "GroupId", "Snapshots"}
+	PrintList(resp, keys, FormatterList{})
+}
+
+func groupSnapshotDeleteAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	err := client.DeleteGroupSnapshot(args[0], nil)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	fmt.Printf("Delete group snapshot(%s) success.\n", args[0])
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package cli
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var jobCommand = &cobra.Command{
+	Use:   "job",
+	Short: "manage OpenSDS jobs of background operations",
+	Run:   jobAction,
+}
+
+var jobShowCommand = &cobra.Command{
+	Use:   "show <job id>",
+	Short: "show information of specified job",
+	Run:   jobShowAction,
+}
+
+var jobListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "get all jobs",
+	Run:   jobListAction,
+}
+
+var (
+	jobLimit      string
+	jobOffset     string
+	jobSortDir    string
+	jobSortKey    string
+	jobType       string
+	jobResourceId string
+	jobStatus     string
+)
+
+func init() {
+	jobListCommand.Flags().StringVarP(&jobLimit, "limit", "", "50", "the number of ertries displayed per page")
+	jobListCommand.Flags().StringVarP(&jobOffset, "offset", "", "0", "all requested data offsets")
+	jobListCommand.Flags().StringVarP(&jobSortDir, "sortDir", "", "desc", "the sort direction of all requested data. supports asc or desc(default)")
+	jobListCommand.Flags().StringVarP(&jobSortKey, "sortKey", "", "createdAt", "the sort key of all requested data. supports id, createdAt(default), type, status, resourceId")
+	jobListCommand.Flags().StringVarP(&jobType, "type", "", "", "list jobs by type")
+	jobListCommand.Flags().StringVarP(&jobResourceId, "resourceId", "", "", "list jobs by resource id")
+	jobListCommand.Flags().StringVarP(&jobStatus, "status", "", "", "list jobs by status")
+
+	jobCommand.AddCommand(jobShowCommand)
+	jobCommand.AddCommand(jobListCommand)
+}
+
+func jobAction(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+	os.Exit(1)
+}
+
+func jobShowAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	resp, err := client.GetJob(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Type", "ResourceId", "Parameters", "Status",
+		"Attempts", "MaxAttempts", "NextRunAt", "Error"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func jobListAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 0)
+	var opts = map[string]string{"limit": jobLimit, "offset": jobOffset, "sortDir": jobSortDir,
+		"sortKey": jobSortKey, "Type": jobType, "ResourceId": jobResourceId, "Status": jobStatus}
+
+	resp, err := client.ListJobs(opts)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "Type", "ResourceId", "Status", "Attempts"}
+	PrintList(resp, keys, FormatterList{})
+}
@@ -56,6 +56,12 @@ var profileDeleteCommand = &cobra.Command{
 	Run:   profileDeleteAction,
 }
 
+var profilePolicyListCommand = &cobra.Command{
+	Use:   "policies",
+	Short: "get all policies which can be specified in the custom properties of profile",
+	Run:   profilePolicyListAction,
+}
+
 var (
 	profLimit       string
 	profOffset      string
@@ -81,6 +87,7 @@ func init() {
 	profileCommand.AddCommand(profileShowCommand)
 	profileCommand.AddCommand(profileListCommand)
 	profileCommand.AddCommand(profileDeleteCommand)
+	profileCommand.AddCommand(profilePolicyListCommand)
 }
 
 func profileAction(cmd *cobra.Command, args []string) {
@@ -138,3 +145,13 @@ func profileDeleteAction(cmd *cobra.Command, args []string) {
 		Fatalln(HttpErrStrip(err))
 	}
 }
+
+func profilePolicyListAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 0)
+	resp, err := client.ListPolicies()
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Name", "Type", "Lifecycle", "Description"}
+	PrintList(resp, keys, FormatterList{})
+}
@@ -66,6 +66,11 @@ func TestProfileListAction(t *testing.T) {
 	profileListAction(profileListCommand, args)
 }
 
+func TestProfilePolicyListAction(t *testing.T) {
+	var args []string
+	profilePolicyListAction(profilePolicyListCommand, args)
+}
+
 func TestProfileDeleteAction(t *testing.T) {
 	var args []string
 	args = append(args, "1106b972-66ef-11e7-b172-db03f3689c9c")
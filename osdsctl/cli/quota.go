@@ -0,0 +1,108 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package cli
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var quotaCommand = &cobra.Command{
+	Use:   "quota",
+	Short: "manage OpenSDS quota of tenants",
+	Run:   quotaAction,
+}
+
+var quotaShowCommand = &cobra.Command{
+	Use:   "show <tenant id>",
+	Short: "show quota and usage of specified tenant",
+	Run:   quotaShowAction,
+}
+
+var quotaUpdateCommand = &cobra.Command{
+	Use:   "update <tenant id>",
+	Short: "update quota of specified tenant",
+	Run:   quotaUpdateAction,
+}
+
+var (
+	quotaVolumes      int64
+	quotaGigabytes    int64
+	quotaSnapshots    int64
+	quotaReplications int64
+)
+
+func init() {
+	flags := quotaUpdateCommand.Flags()
+	flags.Int64VarP(&quotaVolumes, "volumes", "", 0, "the maximum number of volumes, -1 means unlimited")
+	flags.Int64VarP(&quotaGigabytes, "gigabytes", "", 0, "the maximum total size(GB) of volumes, -1 means unlimited")
+	flags.Int64VarP(&quotaSnapshots, "snapshots", "", 0, "the maximum number of snapshots, -1 means unlimited")
+	flags.Int64VarP(&quotaReplications, "replications", "", 0, "the maximum number of replications, -1 means unlimited")
+
+	quotaCommand.AddCommand(quotaShowCommand)
+	quotaCommand.AddCommand(quotaUpdateCommand)
+}
+
+func quotaAction(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+	os.Exit(1)
+}
+
+var quotaKeys = KeyList{"TenantId", "CreatedAt", "UpdatedAt", "Volumes", "Gigabytes",
+	"Snapshots", "Replications", "Usage"}
+
+func quotaShowAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	resp, err := client.GetQuota(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	PrintDict(resp, quotaKeys, FormatterList{"Usage": JsonFormatter})
+}
+
+func quotaUpdateAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	// Only the limits specified in flags are changed, so fetch the current
+	// quota first.
+	quota, err := client.GetQuota(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("volumes") {
+		quota.Volumes = quotaVolumes
+	}
+	if flags.Changed("gigabytes") {
+		quota.Gigabytes = quotaGigabytes
+	}
+	if flags.Changed("snapshots") {
+		quota.Snapshots = quotaSnapshots
+	}
+	if flags.Changed("replications") {
+		quota.Replications = quotaReplications
+	}
+
+	resp, err := client.UpdateQuota(args[0], quota)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	PrintDict(resp, quotaKeys, FormatterList{"Usage": JsonFormatter})
+}
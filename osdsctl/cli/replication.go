@@ -83,6 +83,18 @@ var replicationFailoverCommand = &cobra.Command{
 	Run:   replicationFailoverAction,
 }
 
+var replicationResetStatusCommand = &cobra.Command{
+	Use:   "reset-status <replication id> <status>",
+	Short: "reset the status of a replication in the cluster, which is only allowed to admin",
+	Run:   replicationResetStatusAction,
+}
+
+var replicationForceDeleteCommand = &cobra.Command{
+	Use:   "force-delete <replication id>",
+	Short: "delete a replication in any status in the cluster, which is only allowed to admin",
+	Run:   replicationForceDeleteAction,
+}
+
 var (
 	replicationName                string
 	replicationDesp                string
@@ -138,6 +150,8 @@ func init() {
 	replicationCommand.AddCommand(replicationEnableCommand)
 	replicationCommand.AddCommand(replicationDisableCommand)
 	replicationCommand.AddCommand(replicationFailoverCommand)
+	replicationCommand.AddCommand(replicationResetStatusCommand)
+	replicationCommand.AddCommand(replicationForceDeleteCommand)
 }
 
 func replicationAction(cmd *cobra.Command, args []string) {
@@ -289,3 +303,27 @@ func replicationFailoverAction(cmd *cobra.Command, args []string) {
 		Fatalln(HttpErrStrip(err))
 	}
 }
+
+func replicationResetStatusAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 2)
+	body := &model.ResetStatusSpec{
+		Status: args[1],
+	}
+
+	resp, err := client.ResetReplicationStatus(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "AvailabilityZone",
+		"PrimaryVolumeId", "SecondaryVolumeId", "PrimaryReplicationDriverData", "SecondaryReplicationDriverData",
+		"ReplicationStatus", "ReplicationMode", "ReplicationPeriod", "ProfileId"}
+	PrintDict(resp, keys, replicationFormatters)
+}
+
+func replicationForceDeleteAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	err := client.ForceDeleteReplication(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+}
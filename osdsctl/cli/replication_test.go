@@ -86,3 +86,13 @@ func TestReplicationFailoverAction(t *testing.T) {
 	var args = []string{"f2dda3d2-bf79-11e7-8665-f750b088f63e"}
 	replicationFailoverAction(replicationFailoverCommand, args)
 }
+
+func TestReplicationResetStatusAction(t *testing.T) {
+	var args = []string{"f2dda3d2-bf79-11e7-8665-f750b088f63e", "error"}
+	replicationResetStatusAction(replicationResetStatusCommand, args)
+}
+
+func TestReplicationForceDeleteAction(t *testing.T) {
+	var args = []string{"f2dda3d2-bf79-11e7-8665-f750b088f63e"}
+	replicationForceDeleteAction(replicationForceDeleteCommand, args)
+}
@@ -69,12 +69,50 @@ var volumeExtendCommand = &cobra.Command{
 	Run:   volumeExtendAction,
 }
 
+var volumeRevertCommand = &cobra.Command{
+	Use:   "revert <id> <snapshot id>",
+	Short: "revert a volume to one of its snapshots in the cluster",
+	Run:   volumeRevertAction,
+}
+
+var volumeMigrateCommand = &cobra.Command{
+	Use:   "migrate <id>",
+	Short: "migrate a volume to another pool in the cluster",
+	Run:   volumeMigrateAction,
+}
+
+var volumeRetypeCommand = &cobra.Command{
+	Use:   "retype <id> <profile id>",
+	Short: "change the profile of a volume in the cluster",
+	Run:   volumeRetypeAction,
+}
+
+var volumePolicyListCommand = &cobra.Command{
+	Use:   "policies <id>",
+	Short: "get the policies of a volume specified in its profile",
+	Run:   volumePolicyListAction,
+}
+
+var volumeResetStatusCommand = &cobra.Command{
+	Use:   "reset-status <id> <status>",
+	Short: "reset the status of a volume in the cluster, which is only allowed to admin",
+	Run:   volumeResetStatusAction,
+}
+
+var volumeForceDeleteCommand = &cobra.Command{
+	Use:   "force-delete <id>",
+	Short: "delete a volume in any status in the cluster, which is only allowed to admin",
+	Run:   volumeForceDeleteAction,
+}
+
 var (
 	profileId string
 	volName   string
 	volDesp   string
 	volAz     string
 	volSnap   string
+	volSrc    string
+	volPool   string
 )
 
 var (
@@ -117,6 +155,7 @@ func init() {
 	volumeCreateCommand.Flags().StringVarP(&volAz, "az", "a", "", "the availability zone of created volume")
 	volumeCreateCommand.Flags().StringVarP(&volSnap, "snapshot", "s", "", "the snapshot to create volume")
 	volumeCreateCommand.Flags().BoolVarP(&snapshotFromCloud, "snapshotFromCloud", "c", false, "download snapshot from cloud")
+	volumeCreateCommand.Flags().StringVarP(&volSrc, "sourceVolume", "", "", "the source volume to clone volume")
 	volumeCommand.AddCommand(volumeShowCommand)
 	volumeCommand.AddCommand(volumeListCommand)
 	volumeCommand.AddCommand(volumeDeleteCommand)
@@ -124,6 +163,13 @@ func init() {
 	volumeUpdateCommand.Flags().StringVarP(&volName, "name", "n", "", "the name of updated volume")
 	volumeUpdateCommand.Flags().StringVarP(&volDesp, "description", "d", "", "the description of updated volume")
 	volumeCommand.AddCommand(volumeExtendCommand)
+	volumeCommand.AddCommand(volumeRevertCommand)
+	volumeCommand.AddCommand(volumeMigrateCommand)
+	volumeMigrateCommand.Flags().StringVarP(&volPool, "pool", "", "", "the target pool of migrated volume, selected automatically if not specified")
+	volumeCommand.AddCommand(volumeRetypeCommand)
+	volumeCommand.AddCommand(volumePolicyListCommand)
+	volumeCommand.AddCommand(volumeResetStatusCommand)
+	volumeCommand.AddCommand(volumeForceDeleteCommand)
 
 	volumeCommand.AddCommand(volumeSnapshotCommand)
 	volumeCommand.AddCommand(volumeAttachmentCommand)
@@ -150,6 +196,7 @@ func volumeCreateAction(cmd *cobra.Command, args []string) {
 		ProfileId:         profileId,
 		SnapshotId:        volSnap,
 		SnapshotFromCloud: snapshotFromCloud,
+		SourceVolumeId:    volSrc,
 	}
 
 	resp, err := client.CreateVolume(vol)
@@ -169,10 +216,21 @@ func volumeShowAction(cmd *cobra.Command, args []string) {
 		Fatalln(HttpErrStrip(err))
 	}
 	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size",
-		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId", "SnapshotId"}
+		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId", "SnapshotId",
+		"PolicyStatus"}
 	PrintDict(resp, keys, FormatterList{})
 }
 
+func volumePolicyListAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	resp, err := client.ListVolumePolicies(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Name", "Type", "Lifecycle", "Value"}
+	PrintList(resp, keys, FormatterList{})
+}
+
 func volumeListAction(cmd *cobra.Command, args []string) {
 	ArgsNumCheck(cmd, args, 0)
 
@@ -236,3 +294,72 @@ func volumeExtendAction(cmd *cobra.Command, args []string) {
 		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId"}
 	PrintDict(resp, keys, FormatterList{})
 }
+
+func volumeRevertAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 2)
+	body := &model.RevertVolumeSpec{
+		SnapshotId: args[1],
+	}
+
+	resp, err := client.RevertVolume(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size",
+		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func volumeMigrateAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	body := &model.MigrateVolumeSpec{
+		PoolId: volPool,
+	}
+
+	resp, err := client.MigrateVolume(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size",
+		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId",
+		"MigrationStatus", "MigrationProgress"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func volumeRetypeAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 2)
+	body := &model.RetypeVolumeSpec{
+		ProfileId: args[1],
+	}
+
+	resp, err := client.RetypeVolume(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size",
+		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func volumeResetStatusAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 2)
+	body := &model.ResetStatusSpec{
+		Status: args[1],
+	}
+
+	resp, err := client.ResetVolumeStatus(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size",
+		"AvailabilityZone", "Status", "PoolId", "ProfileId", "Metadata", "GroupId"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func volumeForceDeleteAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	err := client.ForceDeleteVolume(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+}
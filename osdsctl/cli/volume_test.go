@@ -60,6 +60,12 @@ func TestVolumeShowAction(t *testing.T) {
 	volumeShowAction(volumeShowCommand, args)
 }
 
+func TestVolumePolicyListAction(t *testing.T) {
+	var args []string
+	args = append(args, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	volumePolicyListAction(volumePolicyListCommand, args)
+}
+
 func TestVolumeListAction(t *testing.T) {
 	var args []string
 	volumeListAction(volumeListCommand, args)
@@ -82,3 +88,36 @@ func TestVolumeExtendAction(t *testing.T) {
 	args = append(args, "5")
 	volumeExtendAction(volumeExtendCommand, args)
 }
+
+func TestVolumeRevertAction(t *testing.T) {
+	var args []string
+	args = append(args, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	args = append(args, "3769855c-a102-11e7-b772-17b880d2f537")
+	volumeRevertAction(volumeRevertCommand, args)
+}
+
+func TestVolumeMigrateAction(t *testing.T) {
+	var args []string
+	args = append(args, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	volumeMigrateAction(volumeMigrateCommand, args)
+}
+
+func TestVolumeRetypeAction(t *testing.T) {
+	var args []string
+	args = append(args, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	args = append(args, "2f9c0a04-66ef-11e7-ade2-43158893e017")
+	volumeRetypeAction(volumeRetypeCommand, args)
+}
+
+func TestVolumeResetStatusAction(t *testing.T) {
+	var args []string
+	args = append(args, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	args = append(args, "available")
+	volumeResetStatusAction(volumeResetStatusCommand, args)
+}
+
+func TestVolumeForceDeleteAction(t *testing.T) {
+	var args []string
+	args = append(args, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	volumeForceDeleteAction(volumeForceDeleteCommand, args)
+}
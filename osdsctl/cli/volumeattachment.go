@@ -63,6 +63,18 @@ var volumeAttachmentUpdateCommand = &cobra.Command{
 	Run:   volumeAttachmentUpdateAction,
 }
 
+var volumeAttachmentResetStatusCommand = &cobra.Command{
+	Use:   "reset-status <attachment id> <status>",
+	Short: "reset the status of a volume attachment in the cluster, which is only allowed to admin",
+	Run:   volumeAttachmentResetStatusAction,
+}
+
+var volumeAttachmentForceDeleteCommand = &cobra.Command{
+	Use:   "force-delete <attachment id>",
+	Short: "delete a volume attachment without terminating its connection, which is only allowed to admin",
+	Run:   volumeAttachmentForceDeleteAction,
+}
+
 var (
 	volAtmLimit      string
 	volAtmOffset     string
@@ -92,6 +104,8 @@ func init() {
 	volumeAttachmentCommand.AddCommand(volumeAttachmentListCommand)
 	volumeAttachmentCommand.AddCommand(volumeAttachmentDeleteCommand)
 	volumeAttachmentCommand.AddCommand(volumeAttachmentUpdateCommand)
+	volumeAttachmentCommand.AddCommand(volumeAttachmentResetStatusCommand)
+	volumeAttachmentCommand.AddCommand(volumeAttachmentForceDeleteCommand)
 }
 
 func volumeAttachmentAction(cmd *cobra.Command, args []string) {
@@ -171,3 +185,26 @@ func volumeAttachmentUpdateAction(cmd *cobra.Command, args []string) {
 		"Mountpoint", "Status", "VolumeId"}
 	PrintDict(resp, keys, attachmentFormatters)
 }
+
+func volumeAttachmentResetStatusAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 2)
+	body := &model.ResetStatusSpec{
+		Status: args[1],
+	}
+
+	resp, err := client.ResetVolumeAttachmentStatus(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "HostInfo", "ConnectionInfo",
+		"Mountpoint", "Status", "VolumeId", "AccessProtocol"}
+	PrintDict(resp, keys, attachmentFormatters)
+}
+
+func volumeAttachmentForceDeleteAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	err := client.ForceDeleteVolumeAttachment(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+}
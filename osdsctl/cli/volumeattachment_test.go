@@ -78,3 +78,16 @@ func TestVolumeAttachmentUpdateAction(t *testing.T) {
 	args = append(args, ByteAttachment)
 	volumeAttachmentUpdateAction(volumeAttachmentDeleteCommand, args)
 }
+
+func TestVolumeAttachmentResetStatusAction(t *testing.T) {
+	var args []string
+	args = append(args, "f2dda3d2-bf79-11e7-8665-f750b088f63e")
+	args = append(args, "available")
+	volumeAttachmentResetStatusAction(volumeAttachmentResetStatusCommand, args)
+}
+
+func TestVolumeAttachmentForceDeleteAction(t *testing.T) {
+	var args []string
+	args = append(args, "f2dda3d2-bf79-11e7-8665-f750b088f63e")
+	volumeAttachmentForceDeleteAction(volumeAttachmentForceDeleteCommand, args)
+}
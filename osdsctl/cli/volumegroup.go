@@ -79,6 +79,7 @@ var (
 	vgprofiles    *[]string
 	vgStatus      string
 	vgPoolId      string
+	vgSnapshotId  string
 )
 
 func init() {
@@ -101,10 +102,12 @@ func init() {
 	volumeGroupCreateCommand.Flags().StringVarP(&vgDesp, "description", "d", "", "the description of created volume group")
 	volumeGroupCreateCommand.Flags().StringVarP(&vgAZ, "availabilityZone", "a", "", "the availabilityZone of created volume group")
 	vgprofiles = volumeGroupCreateCommand.Flags().StringSliceP("profiles", "", nil, "the profiles of created volume group")
+	volumeGroupCreateCommand.Flags().StringVarP(&vgSnapshotId, "groupSnapshotId", "", "", "the group snapshot which the volume group is created from")
 	volumeGroupCommand.AddCommand(volumeGroupShowCommand)
 	volumeGroupCommand.AddCommand(volumeGroupListCommand)
 	volumeGroupCommand.AddCommand(volumeGroupDeleteCommand)
 	volumeGroupCommand.AddCommand(volumeGroupUpdateCommand)
+	volumeGroupCommand.AddCommand(groupSnapshotCommand)
 	volumeGroupUpdateCommand.Flags().StringVarP(&vgName, "name", "n", "", "the name of updated volume group")
 	volumeGroupUpdateCommand.Flags().StringVarP(&vgDesp, "description", "d", "", "the description of updated volume group")
 	addVolumes = volumeGroupUpdateCommand.Flags().StringSliceP("addVolumes", "a", nil, "the addVolumes of updated volume group")
@@ -123,6 +126,7 @@ func volumeGroupCreateAction(cmd *cobra.Command, args []string) {
 		Description:      vgDesp,
 		AvailabilityZone: vgAZ,
 		Profiles:         *vgprofiles,
+		GroupSnapshotId:  vgSnapshotId,
 	}
 
 	resp, err := client.CreateVolumeGroup(vg)
@@ -62,6 +62,18 @@ var volumeSnapshotUpdateCommand = &cobra.Command{
 	Run:   volumeSnapshotUpdateAction,
 }
 
+var volumeSnapshotResetStatusCommand = &cobra.Command{
+	Use:   "reset-status <snapshot id> <status>",
+	Short: "reset the status of a volume snapshot in the cluster, which is only allowed to admin",
+	Run:   volumeSnapshotResetStatusAction,
+}
+
+var volumeSnapshotForceDeleteCommand = &cobra.Command{
+	Use:   "force-delete <snapshot id>",
+	Short: "delete a volume snapshot in any status in the cluster, which is only allowed to admin",
+	Run:   volumeSnapshotForceDeleteAction,
+}
+
 var (
 	volSnapshotName string
 	volSnapshotDesp string
@@ -102,6 +114,8 @@ func init() {
 	volumeSnapshotCommand.AddCommand(volumeSnapshotUpdateCommand)
 	volumeSnapshotUpdateCommand.Flags().StringVarP(&volSnapshotName, "name", "n", "", "the name of updated volume snapshot")
 	volumeSnapshotUpdateCommand.Flags().StringVarP(&volSnapshotDesp, "description", "d", "", "the description of updated volume snapshot")
+	volumeSnapshotCommand.AddCommand(volumeSnapshotResetStatusCommand)
+	volumeSnapshotCommand.AddCommand(volumeSnapshotForceDeleteCommand)
 }
 
 func volumeSnapshotAction(cmd *cobra.Command, args []string) {
@@ -175,3 +189,25 @@ func volumeSnapshotUpdateAction(cmd *cobra.Command, args []string) {
 	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size", "Status", "VolumeId"}
 	PrintDict(resp, keys, FormatterList{})
 }
+
+func volumeSnapshotResetStatusAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 2)
+	body := &model.ResetStatusSpec{
+		Status: args[1],
+	}
+
+	resp, err := client.ResetVolumeSnapshotStatus(args[0], body)
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+	keys := KeyList{"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Size", "Status", "VolumeId"}
+	PrintDict(resp, keys, FormatterList{})
+}
+
+func volumeSnapshotForceDeleteAction(cmd *cobra.Command, args []string) {
+	ArgsNumCheck(cmd, args, 1)
+	err := client.ForceDeleteVolumeSnapshot(args[0])
+	if err != nil {
+		Fatalln(HttpErrStrip(err))
+	}
+}
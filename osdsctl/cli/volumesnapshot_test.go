@@ -76,3 +76,16 @@ func TestVolumeSnapshotUpdateAction(t *testing.T) {
 	args = append(args, "3769855c-a102-11e7-b772-17b880d2f537")
 	volumeSnapshotUpdateAction(volumeSnapshotDeleteCommand, args)
 }
+
+func TestVolumeSnapshotResetStatusAction(t *testing.T) {
+	var args []string
+	args = append(args, "3769855c-a102-11e7-b772-17b880d2f537")
+	args = append(args, "available")
+	volumeSnapshotResetStatusAction(volumeSnapshotResetStatusCommand, args)
+}
+
+func TestVolumeSnapshotForceDeleteAction(t *testing.T) {
+	var args []string
+	args = append(args, "3769855c-a102-11e7-b772-17b880d2f537")
+	volumeSnapshotForceDeleteAction(volumeSnapshotForceDeleteCommand, args)
+}
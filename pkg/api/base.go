@@ -18,10 +18,13 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/astaxie/beego"
 	log "github.com/golang/glog"
 	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
 )
 
 type BasePortal struct {
@@ -40,6 +43,15 @@ func (b *BasePortal) GetParameters() (map[string][]string, error) {
 	return m, nil
 }
 
+// setPageHeaders tells the total number of resources and the marker of next
+// page in response headers, so that the body of list response is unchanged.
+func (b *BasePortal) setPageHeaders(page *model.PageSpec) {
+	b.Ctx.Output.Header(constants.TotalCountHeader, strconv.Itoa(page.Total))
+	if page.Next != "" {
+		b.Ctx.Output.Header(constants.NextMarkerHeader, page.Next)
+	}
+}
+
 // Filter some items in spec that no need to transfer to users.
 func (b *BasePortal) outputFilter(resp interface{}, whiteList []string) interface{} {
 	v := reflect.ValueOf(resp)
@@ -80,3 +92,34 @@ func (b *BasePortal) SuccessHandle(status int, body []byte) {
 		b.Ctx.Output.Body(body)
 	}
 }
+
+// parseIfMatch sets the resource version in If-Match header as the precondition
+// of update, so that the update fails if the resource has been modified since
+// that version. Both quoted and bare versions are accepted, and the header
+// overrides the resource version in request body.
+func (b *BasePortal) parseIfMatch(m *model.BaseModel) error {
+	v := strings.TrimSpace(b.Ctx.Input.Header("If-Match"))
+	if v == "" || v == "*" {
+		return nil
+	}
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(v, "W/"), `"`), 10, 64)
+	if err != nil || version <= 0 {
+		return fmt.Errorf("invalid If-Match header: %s", v)
+	}
+	m.ResourceVersion = version
+	return nil
+}
+
+// updateFailed responds the failure of update request, and the conflict of
+// resource version is reported as 409 so that the client could get the
+// resource again and retry.
+func (b *BasePortal) updateFailed(reason string, err error) {
+	if _, ok := err.(*model.ConflictError); ok {
+		b.Ctx.Output.SetStatus(model.ErrorConflict)
+		b.Ctx.Output.Body(model.ErrorConflictStatus(reason))
+	} else {
+		b.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		b.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+	}
+	log.Error(reason)
+}
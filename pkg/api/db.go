@@ -21,12 +21,14 @@ package api
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	log "github.com/golang/glog"
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/controller"
+	"github.com/opensds/opensds/pkg/controller/selector"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
 	"github.com/opensds/opensds/pkg/utils"
@@ -35,6 +37,12 @@ import (
 )
 
 func CreateVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) (*model.VolumeSpec, error) {
+	return createVolumeDBEntry(ctx, in, "")
+}
+
+// createVolumeDBEntry creates the volume entry in the group of groupId, which
+// is only set for the volumes created along with their group.
+func createVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec, groupId string) (*model.VolumeSpec, error) {
 	if in.Id == "" {
 		in.Id = uuid.NewV4().String()
 	}
@@ -60,6 +68,28 @@ func CreateVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) (*model.VolumeSpe
 			return nil, errors.New(errMsg)
 		}
 	}
+	if in.SourceVolumeId != "" {
+		if in.SnapshotId != "" {
+			var errMsg = "Source volume and snapshot can not be specified at the same time"
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+		srcVol, err := db.C.GetVolume(ctx, in.SourceVolumeId)
+		if err != nil {
+			log.Error("Get source volume failed in create volume method: ", err)
+			return nil, err
+		}
+		if srcVol.Status != model.VolumeAvailable {
+			var errMsg = "Only if the source volume is available, the volume can be cloned"
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+		if srcVol.Size > in.Size {
+			var errMsg = "Size of volume must be equal to or bigger than size of the source volume"
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+	}
 	if in.AvailabilityZone == "" {
 		log.Warning("Use default availability zone when user doesn't specify availabilityZone.")
 		in.AvailabilityZone = "default"
@@ -81,17 +111,25 @@ func CreateVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) (*model.VolumeSpe
 		Status:            model.VolumeCreating,
 		SnapshotId:        in.SnapshotId,
 		SnapshotFromCloud: in.SnapshotFromCloud,
+		SourceVolumeId:    in.SourceVolumeId,
+		GroupId:           groupId,
+	}
+	var usage = &model.QuotaUsageSpec{Volumes: 1, Gigabytes: in.Size}
+	if err := db.C.ReserveQuota(ctx, ctx.TenantId, usage); err != nil {
+		log.Error("When reserve quota of volume:", err)
+		return nil, err
 	}
 	result, err := db.C.CreateVolume(ctx, vol)
 	if err != nil {
 		log.Error("When add volume to db:", err)
+		db.C.ReleaseQuota(ctx, ctx.TenantId, usage)
 		return nil, err
 	}
 
 	return result, nil
 }
 
-func ExtendVolumeDBEntry(ctx *c.Context, volID string) (*model.VolumeSpec, error) {
+func ExtendVolumeDBEntry(ctx *c.Context, volID string, newSize int64) (*model.VolumeSpec, error) {
 	volume, err := db.C.GetVolume(ctx, volID)
 	if err != nil {
 		log.Error("Get volume failed in extend volume method: ", err)
@@ -103,11 +141,157 @@ func ExtendVolumeDBEntry(ctx *c.Context, volID string) (*model.VolumeSpec, error
 		log.Error(errMsg)
 		return nil, errors.New(errMsg)
 	}
+	// The new size is validated by controller, which also releases the
+	// reserved gigabytes if the extension fails.
+	var usage = &model.QuotaUsageSpec{}
+	if newSize > volume.Size {
+		usage.Gigabytes = newSize - volume.Size
+		if err := db.C.ReserveQuota(ctx, volume.TenantId, usage); err != nil {
+			log.Error("When reserve quota of volume:", err)
+			return nil, err
+		}
+	}
 	volume.Status = model.VolumeExtending
 	// Store the volume data into database.
 	result, err := db.C.ExtendVolume(ctx, volume)
 	if err != nil {
 		log.Error("When extend volume in db module:", err)
+		if usage.Gigabytes > 0 {
+			db.C.ReleaseQuota(ctx, volume.TenantId, usage)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func RevertVolumeDBEntry(ctx *c.Context, volID string, snapID string) (*model.VolumeSpec, error) {
+	volume, err := db.C.GetVolume(ctx, volID)
+	if err != nil {
+		log.Error("Get volume failed in revert volume method: ", err)
+		return nil, err
+	}
+	if volume.Status != model.VolumeAvailable {
+		errMsg := "The status of the volume to be reverted must be available"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+
+	snap, err := db.C.GetVolumeSnapshot(ctx, snapID)
+	if err != nil {
+		log.Error("Get snapshot failed in revert volume method: ", err)
+		return nil, err
+	}
+	if snap.VolumeId != volume.Id {
+		errMsg := fmt.Sprintf("Snapshot %s does not belong to volume %s", snapID, volID)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if snap.Status != model.VolumeSnapAvailable {
+		errMsg := "Only if the snapshot is available, the volume can be reverted"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+
+	volume.Status = model.VolumeReverting
+	result, err := db.C.UpdateVolume(ctx, volume)
+	if err != nil {
+		log.Error("When revert volume in db module:", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func MigrateVolumeDBEntry(ctx *c.Context, volID string, poolID string) (*model.VolumeSpec, error) {
+	volume, err := db.C.GetVolume(ctx, volID)
+	if err != nil {
+		log.Error("Get volume failed in migrate volume method: ", err)
+		return nil, err
+	}
+	if volume.Status != model.VolumeAvailable {
+		errMsg := "The status of the volume to be migrated must be available"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	// The snapshots are left in the source pool by migration.
+	snaps, err := db.C.ListSnapshotsByVolumeId(ctx, volID)
+	if err != nil {
+		log.Error("List snapshots failed in migrate volume method: ", err)
+		return nil, err
+	}
+	if len(snaps) > 0 {
+		errMsg := fmt.Sprintf("Volume %s can't be migrated since it has snapshots", volID)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+
+	if poolID != "" {
+		if poolID == volume.PoolId {
+			errMsg := fmt.Sprintf("Volume %s is already located in pool %s", volID, poolID)
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+		pool, err := db.C.GetPool(ctx, poolID)
+		if err != nil {
+			log.Error("Get target pool failed in migrate volume method: ", err)
+			return nil, err
+		}
+		var prf *model.ProfileSpec
+		if volume.ProfileId == "" {
+			prf, err = db.C.GetDefaultProfile(ctx)
+		} else {
+			prf, err = db.C.GetProfile(ctx, volume.ProfileId)
+		}
+		if err != nil {
+			log.Error("Get profile failed in migrate volume method: ", err)
+			return nil, err
+		}
+		ok, err := selector.IsAvailablePool(selector.NewVolumeFilterRequest(prf, volume), pool)
+		if err != nil {
+			log.Error("Check target pool failed in migrate volume method: ", err)
+			return nil, err
+		}
+		if !ok {
+			errMsg := fmt.Sprintf("Pool %s doesn't satisfy the profile, availability zone or size of volume %s", poolID, volID)
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+	}
+
+	volume.Status = model.VolumeMigrating
+	volume.MigrationStatus, volume.MigrationProgress = model.VolumeMigrationStarting, 0
+	result, err := db.C.UpdateVolume(ctx, volume)
+	if err != nil {
+		log.Error("When migrate volume in db module:", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func RetypeVolumeDBEntry(ctx *c.Context, volID string, prfID string) (*model.VolumeSpec, error) {
+	volume, err := db.C.GetVolume(ctx, volID)
+	if err != nil {
+		log.Error("Get volume failed in retype volume method: ", err)
+		return nil, err
+	}
+	if volume.Status != model.VolumeAvailable {
+		errMsg := "The status of the volume to be retyped must be available"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if prfID == "" || prfID == volume.ProfileId {
+		errMsg := fmt.Sprintf("The new profile of volume %s must be different from the current one", volID)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if _, err := db.C.GetProfile(ctx, prfID); err != nil {
+		log.Error("Get profile failed in retype volume method: ", err)
+		return nil, err
+	}
+
+	volume.Status = model.VolumeRetyping
+	result, err := db.C.UpdateVolume(ctx, volume)
+	if err != nil {
+		log.Error("When retype volume in db module:", err)
 		return nil, err
 	}
 	return result, nil
@@ -197,18 +381,39 @@ func CreateVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec) (
 		Status:      model.VolumeSnapCreating,
 	}
 
+	var usage = &model.QuotaUsageSpec{Snapshots: 1}
+	if err := db.C.ReserveQuota(ctx, ctx.TenantId, usage); err != nil {
+		log.Error("When reserve quota of volume snapshot:", err)
+		return nil, err
+	}
 	result, err := db.C.CreateVolumeSnapshot(ctx, snap)
 	if err != nil {
 		log.Error("Error occurred in dock module when create volume snapshot in db:", err)
+		db.C.ReleaseQuota(ctx, ctx.TenantId, usage)
 		return nil, err
 	}
 	return result, nil
 }
 
 func DeleteVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec) error {
+	return deleteVolumeSnapshotDBEntry(ctx, in, false)
+}
+
+// ForceDeleteVolumeSnapshotDBEntry marks the volume snapshot as deleting
+// whatever status it is in.
+func ForceDeleteVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec) error {
+	return deleteVolumeSnapshotDBEntry(ctx, in, true)
+}
+
+func deleteVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec, force bool) error {
+	if in.GroupSnapshotId != "" {
+		errMsg := fmt.Sprintf("Volume snapshot %s belongs to group snapshot %s, it can only be deleted along with the group snapshot", in.Id, in.GroupSnapshotId)
+		log.Error(errMsg)
+		return errors.New(errMsg)
+	}
 	validStatus := []string{model.VolumeSnapAvailable, model.VolumeSnapError,
 		model.VolumeSnapErrorDeleting}
-	if !utils.Contained(in.Status, validStatus) {
+	if !force && !utils.Contained(in.Status, validStatus) {
 		errMsg := fmt.Sprintf("Only the volume snapshot with the status available, error, error_deleting can be deleted, the volume status is %s", in.Status)
 		log.Error(errMsg)
 		return errors.New(errMsg)
@@ -222,6 +427,7 @@ func DeleteVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec) e
 			log.Error("when delete volume snapshot in db:", err)
 			return err
 		}
+		db.C.ReleaseQuota(ctx, in.TenantId, &model.QuotaUsageSpec{Snapshots: 1})
 		return nil
 	}
 
@@ -235,9 +441,19 @@ func DeleteVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec) e
 
 //Just modify the state of the volume to be deleted in the DB, the real deletion in another thread
 func DeleteVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) error {
+	return deleteVolumeDBEntry(ctx, in, false)
+}
+
+// ForceDeleteVolumeDBEntry marks the volume as deleting whatever status it is
+// in, the volume still can't be deleted if it has snapshots or attachments.
+func ForceDeleteVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) error {
+	return deleteVolumeDBEntry(ctx, in, true)
+}
+
+func deleteVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec, force bool) error {
 	validStatus := []string{model.VolumeAvailable, model.VolumeError,
 		model.VolumeErrorDeleting, model.VolumeErrorExtending}
-	if !utils.Contained(in.Status, validStatus) {
+	if !force && !utils.Contained(in.Status, validStatus) {
 		errMsg := fmt.Sprintf("Only the volume with the status available, error, error_deleting, error_extending can be deleted, the volume status is %s", in.Status)
 		log.Error(errMsg)
 		return errors.New(errMsg)
@@ -250,6 +466,7 @@ func DeleteVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) error {
 			log.Error("when delete volume in db:", err)
 			return err
 		}
+		db.C.ReleaseQuota(ctx, in.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: in.Size})
 		return nil
 	}
 
@@ -297,6 +514,14 @@ func DeleteReplicationDBEntry(ctx *c.Context, in *model.ReplicationSpec) error {
 	return nil
 }
 
+// ForceDeleteReplicationDBEntry marks the replication as deleting whatever
+// status it is in.
+func ForceDeleteReplicationDBEntry(ctx *c.Context, in *model.ReplicationSpec) error {
+	in.ReplicationStatus = model.ReplicationDeleting
+	_, err := db.C.UpdateReplication(ctx, in.Id, in)
+	return err
+}
+
 func EnableReplicationDBEntry(ctx *c.Context, in *model.ReplicationSpec) error {
 	invalidStatus := []string{model.ReplicationCreating, model.ReplicationDeleting, model.ReplicationEnabling,
 		model.ReplicationDisabling, model.ReplicationFailingOver, model.ReplicationFailingBack}
@@ -353,6 +578,31 @@ func FailoverReplicationDBEntry(ctx *c.Context, in *model.ReplicationSpec, secon
 }
 
 func CreateVolumeGroupDBEntry(ctx *c.Context, in *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
+	var gs *model.GroupSnapshotSpec
+	var srcGroup *model.VolumeGroupSpec
+	if in.GroupSnapshotId != "" {
+		var err error
+		if gs, err = db.C.GetGroupSnapshot(ctx, in.GroupSnapshotId); err != nil {
+			log.Error("Get group snapshot failed in create volume group method: ", err)
+			return nil, err
+		}
+		if gs.Status != model.GroupSnapAvailable {
+			var errMsg = "Only if the group snapshot is available, the volume group can be created"
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+		if srcGroup, err = db.C.GetVolumeGroup(ctx, gs.GroupId); err != nil {
+			log.Error("Get source volume group failed in create volume group method: ", err)
+			return nil, err
+		}
+		// The group is created in the same pool with its source group.
+		if len(in.Profiles) == 0 {
+			in.Profiles = srcGroup.Profiles
+		}
+		if in.AvailabilityZone == "" {
+			in.AvailabilityZone = srcGroup.AvailabilityZone
+		}
+	}
 	if len(in.Profiles) == 0 {
 		msg := fmt.Sprintf("Profiles must be provided to create volume group.")
 		log.Error(msg)
@@ -377,12 +627,22 @@ func CreateVolumeGroupDBEntry(ctx *c.Context, in *model.VolumeGroupSpec) (*model
 		AvailabilityZone: in.AvailabilityZone,
 		Status:           model.VolumeGroupCreating,
 		Profiles:         in.Profiles,
+		GroupSnapshotId:  in.GroupSnapshotId,
 	}
 	result, err := db.C.CreateVolumeGroup(ctx, vg)
 	if err != nil {
 		log.Error("When add volume to db:", err)
 		return nil, err
 	}
+	// The group created from group snapshot is created by a job, which is
+	// submitted after the entries of its volumes are created.
+	if gs != nil {
+		if err := createGroupVolumesDBEntry(ctx, vg, gs); err != nil {
+			db.C.DeleteVolumeGroup(ctx, vg.Id)
+			return nil, err
+		}
+		return result, nil
+	}
 	// TODO:Rpc call to create group.
 	// Create volume group request is sent to the Dock. Dock will update volume status to "available"
 	// after volume group creation is completed.
@@ -390,6 +650,45 @@ func CreateVolumeGroupDBEntry(ctx *c.Context, in *model.VolumeGroupSpec) (*model
 	return result, nil
 }
 
+// createGroupVolumesDBEntry creates the entries of volumes in the group which
+// are created from the member snapshots of the group snapshot. The entries
+// created and their quota are rolled back if any of them fails.
+func createGroupVolumesDBEntry(ctx *c.Context, vg *model.VolumeGroupSpec, gs *model.GroupSnapshotSpec) error {
+	var vols []*model.VolumeSpec
+	var cleanup = func() {
+		for _, vol := range vols {
+			db.C.DeleteVolume(ctx, vol.Id)
+			db.C.ReleaseQuota(ctx, ctx.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: vol.Size})
+		}
+	}
+	for _, snpId := range gs.Snapshots {
+		snp, err := db.C.GetVolumeSnapshot(ctx, snpId)
+		if err != nil {
+			log.Error("Get volume snapshot failed in create volume group method: ", err)
+			cleanup()
+			return err
+		}
+		var name, prfId string
+		if srcVol, err := db.C.GetVolume(ctx, snp.VolumeId); err == nil {
+			name, prfId = srcVol.Name, srcVol.ProfileId
+		}
+		vol, err := createVolumeDBEntry(ctx, &model.VolumeSpec{
+			BaseModel:        &model.BaseModel{},
+			Name:             name,
+			ProfileId:        prfId,
+			Size:             snp.Size,
+			AvailabilityZone: vg.AvailabilityZone,
+			SnapshotId:       snp.Id,
+		}, vg.Id)
+		if err != nil {
+			cleanup()
+			return err
+		}
+		vols = append(vols, vol)
+	}
+	return nil
+}
+
 func UpdateVolumeGroupDBEntry(ctx *c.Context, vgUpdate *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
 	vg, err := db.C.GetVolumeGroup(ctx, vgUpdate.Id)
 	if err != nil {
@@ -636,3 +935,385 @@ func DeleteVolumeGroupDBEntry(ctx *c.Context, volumeGroupId string) error {
 
 	return nil
 }
+
+// CreateGroupSnapshotDBEntry creates the group snapshot entry along with the
+// entries of its member snapshots, one for each volume in the group.
+func CreateGroupSnapshotDBEntry(ctx *c.Context, in *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	vg, err := db.C.GetVolumeGroup(ctx, in.GroupId)
+	if err != nil {
+		log.Error("Get volume group failed in create group snapshot method: ", err)
+		return nil, err
+	}
+	if vg.Status != model.VolumeGroupAvailable {
+		var errMsg = "Only the status of volume group is available, the group snapshot can be created"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	vols, err := db.C.ListVolumesByGroupId(ctx, vg.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(vols) == 0 {
+		errMsg := fmt.Sprintf("Volume group %s has no volumes to take snapshots of", vg.Id)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	for _, vol := range vols {
+		if vol.Status != model.VolumeAvailable && vol.Status != model.VolumeInUse {
+			errMsg := fmt.Sprintf("Only the status of volumes in group is available or in-use, the group snapshot can be created, the status of volume %s is %s", vol.Id, vol.Status)
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+	}
+
+	if in.Id == "" {
+		in.Id = uuid.NewV4().String()
+	}
+	if in.CreatedAt == "" {
+		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+	}
+
+	var usage = &model.QuotaUsageSpec{Snapshots: int64(len(vols))}
+	if err := db.C.ReserveQuota(ctx, ctx.TenantId, usage); err != nil {
+		log.Error("When reserve quota of group snapshot:", err)
+		return nil, err
+	}
+	var snpIds []string
+	var cleanup = func() {
+		for _, id := range snpIds {
+			db.C.DeleteVolumeSnapshot(ctx, id)
+		}
+		db.C.ReleaseQuota(ctx, ctx.TenantId, usage)
+	}
+	for _, vol := range vols {
+		snp := &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{
+				Id:        uuid.NewV4().String(),
+				CreatedAt: in.CreatedAt,
+			},
+			Name:            in.Name,
+			Description:     in.Description,
+			VolumeId:        vol.Id,
+			Size:            vol.Size,
+			Metadata:        vol.Metadata,
+			Status:          model.VolumeSnapCreating,
+			GroupSnapshotId: in.Id,
+		}
+		if _, err := db.C.CreateVolumeSnapshot(ctx, snp); err != nil {
+			log.Error("When add volume snapshot of group snapshot to db:", err)
+			cleanup()
+			return nil, err
+		}
+		snpIds = append(snpIds, snp.Id)
+	}
+
+	var gs = &model.GroupSnapshotSpec{
+		BaseModel: &model.BaseModel{
+			Id:        in.Id,
+			CreatedAt: in.CreatedAt,
+		},
+		UserId:      ctx.UserId,
+		Name:        in.Name,
+		Description: in.Description,
+		Status:      model.GroupSnapCreating,
+		GroupId:     vg.Id,
+		Snapshots:   snpIds,
+	}
+	result, err := db.C.CreateGroupSnapshot(ctx, gs)
+	if err != nil {
+		log.Error("When add group snapshot to db:", err)
+		cleanup()
+		return nil, err
+	}
+	// The group can not be deleted until its group snapshots are deleted.
+	vg.GroupSnapshots = append(vg.GroupSnapshots, gs.Id)
+	if _, err := db.C.UpdateVolumeGroup(ctx, vg); err != nil {
+		log.Error("When add group snapshot to volume group:", err)
+		db.C.DeleteGroupSnapshot(ctx, gs.Id)
+		cleanup()
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteGroupSnapshotDBEntry just modifies the status of the group snapshot
+// and its member snapshots to be deleted, the real deletion is done in
+// another thread.
+func DeleteGroupSnapshotDBEntry(ctx *c.Context, in *model.GroupSnapshotSpec) error {
+	validStatus := []string{model.GroupSnapAvailable, model.GroupSnapError,
+		model.GroupSnapErrorDeleting}
+	if !utils.Contained(in.Status, validStatus) {
+		errMsg := fmt.Sprintf("Only the group snapshot with the status available, error, errorDeleting can be deleted, the group snapshot status is %s", in.Status)
+		log.Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	for _, snpId := range in.Snapshots {
+		snp, err := db.C.GetVolumeSnapshot(ctx, snpId)
+		if err != nil {
+			return err
+		}
+		if err := db.C.UpdateStatus(ctx, snp, model.VolumeSnapDeleting); err != nil {
+			return err
+		}
+	}
+	return db.C.UpdateStatus(ctx, in, model.GroupSnapDeleting)
+}
+
+// CreateFileShareDBEntry creates the file share entry with the status of
+// "creating", only the nfs protocol is supported by now.
+func CreateFileShareDBEntry(ctx *c.Context, in *model.FileShareSpec) (*model.FileShareSpec, error) {
+	if in.Size <= 0 {
+		errMsg := fmt.Sprintf("Invalid file share size: %d", in.Size)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if in.Protocol == "" {
+		in.Protocol = "nfs"
+	}
+	if in.Protocol != "nfs" {
+		errMsg := fmt.Sprintf("File sharing protocol %s is not supported", in.Protocol)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if in.AvailabilityZone == "" {
+		log.Warning("Use default availability zone when user doesn't specify availabilityZone.")
+		in.AvailabilityZone = "default"
+	}
+	if in.Id == "" {
+		in.Id = uuid.NewV4().String()
+	}
+	if in.CreatedAt == "" {
+		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+	}
+
+	var share = &model.FileShareSpec{
+		BaseModel: &model.BaseModel{
+			Id:        in.Id,
+			CreatedAt: in.CreatedAt,
+		},
+		UserId:           ctx.UserId,
+		Name:             in.Name,
+		Description:      in.Description,
+		Size:             in.Size,
+		AvailabilityZone: in.AvailabilityZone,
+		ProfileId:        in.ProfileId,
+		Protocol:         in.Protocol,
+		Metadata:         in.Metadata,
+		Status:           model.FileShareCreating,
+	}
+	result, err := db.C.CreateFileShare(ctx, share)
+	if err != nil {
+		log.Error("When add file share to db:", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExtendFileShareDBEntry modifies the status of the file share to be extended,
+// the real extension is done in another thread.
+func ExtendFileShareDBEntry(ctx *c.Context, shareId string, newSize int64) (*model.FileShareSpec, error) {
+	share, err := db.C.GetFileShare(ctx, shareId)
+	if err != nil {
+		log.Error("Get file share failed in extend file share method: ", err)
+		return nil, err
+	}
+	// The extension which failed in the middle can be retried.
+	if share.Status != model.FileShareAvailable && share.Status != model.FileShareErrorExtending {
+		errMsg := "The status of the file share to be extended must be available or errorExtending"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if newSize <= share.Size {
+		errMsg := fmt.Sprintf("New size for extend must be greater than current size."+
+			"(current: %d GB, extended: %d GB).", share.Size, newSize)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if err := db.C.UpdateStatus(ctx, share, model.FileShareExtending); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// DeleteFileShareDBEntry just modifies the status of the file share to be
+// deleted, the real deletion is done in another thread.
+func DeleteFileShareDBEntry(ctx *c.Context, in *model.FileShareSpec) error {
+	validStatus := []string{model.FileShareAvailable, model.FileShareError,
+		model.FileShareErrorDeleting, model.FileShareErrorExtending}
+	if !utils.Contained(in.Status, validStatus) {
+		errMsg := fmt.Sprintf("Only the file share with the status available, error, errorDeleting, errorExtending can be deleted, the file share status is %s", in.Status)
+		log.Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	// The access rules being applied or revoked would be left over on the
+	// storage, so the file share can't be deleted until they are done.
+	acls, err := db.C.ListFileShareAcls(ctx)
+	if err != nil {
+		return err
+	}
+	for _, acl := range acls {
+		if acl.FileShareId != in.Id {
+			continue
+		}
+		if acl.Status == model.FileShareAclApplying || acl.Status == model.FileShareAclDeleting {
+			errMsg := fmt.Sprintf("Access rule %s of file share %s is in the status of %s", acl.Id, in.Id, acl.Status)
+			log.Error(errMsg)
+			return errors.New(errMsg)
+		}
+	}
+	return db.C.UpdateStatus(ctx, in, model.FileShareDeleting)
+}
+
+// CreateFileShareAclDBEntry creates the access rule entry of file share with
+// the status of "applying", only one rule is allowed for the same clients.
+func CreateFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	if in.Type != model.FileShareAccessTypeIP {
+		errMsg := fmt.Sprintf("Access type %s is not supported", in.Type)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if net.ParseIP(in.AccessTo) == nil {
+		if _, _, err := net.ParseCIDR(in.AccessTo); err != nil {
+			errMsg := fmt.Sprintf("Invalid ip or cidr: %s", in.AccessTo)
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+	}
+	if in.AccessLevel != model.FileShareAccessLevelRO && in.AccessLevel != model.FileShareAccessLevelRW {
+		errMsg := fmt.Sprintf("Invalid access level: %s", in.AccessLevel)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+
+	share, err := db.C.GetFileShare(ctx, in.FileShareId)
+	if err != nil {
+		log.Error("Get file share failed in create file share acl method: ", err)
+		return nil, err
+	}
+	if share.Status != model.FileShareAvailable {
+		errMsg := "Only if the file share is available, the access rule can be created"
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	acls, err := db.C.ListFileShareAcls(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, acl := range acls {
+		if acl.FileShareId == in.FileShareId && acl.AccessTo == in.AccessTo {
+			errMsg := fmt.Sprintf("Access rule %s of file share %s for %s already exists", acl.Id, in.FileShareId, in.AccessTo)
+			log.Error(errMsg)
+			return nil, errors.New(errMsg)
+		}
+	}
+
+	if in.Id == "" {
+		in.Id = uuid.NewV4().String()
+	}
+	if in.CreatedAt == "" {
+		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+	}
+	var acl = &model.FileShareAclSpec{
+		BaseModel: &model.BaseModel{
+			Id:        in.Id,
+			CreatedAt: in.CreatedAt,
+		},
+		UserId:      ctx.UserId,
+		FileShareId: in.FileShareId,
+		Type:        in.Type,
+		AccessTo:    in.AccessTo,
+		AccessLevel: in.AccessLevel,
+		Description: in.Description,
+		Metadata:    in.Metadata,
+		Status:      model.FileShareAclApplying,
+	}
+	result, err := db.C.CreateFileShareAcl(ctx, acl)
+	if err != nil {
+		log.Error("When add file share acl to db:", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteFileShareAclDBEntry just modifies the status of the access rule to be
+// deleted, the real revocation is done in another thread.
+func DeleteFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) error {
+	validStatus := []string{model.FileShareAclAvailable, model.FileShareAclError,
+		model.FileShareAclErrorDeleting}
+	if !utils.Contained(in.Status, validStatus) {
+		errMsg := fmt.Sprintf("Only the file share acl with the status available, error, errorDeleting can be deleted, the file share acl status is %s", in.Status)
+		log.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return db.C.UpdateStatus(ctx, in, model.FileShareAclDeleting)
+}
+
+// The statuses which the resources can be reset to by admin, the transient
+// statuses are excluded since no operation would move the resources out of
+// them.
+var (
+	volumeResetStatus = []string{model.VolumeAvailable, model.VolumeInUse, model.VolumeError,
+		model.VolumeErrorDeleting, model.VolumeErrorExtending, model.VolumeErrorReverting,
+		model.VolumeErrorMigrating, model.VolumeErrorRetyping}
+	volumeSnapshotResetStatus = []string{model.VolumeSnapAvailable, model.VolumeSnapError,
+		model.VolumeSnapErrorDeleting}
+	volumeAttachmentResetStatus = []string{model.VolumeAttachAvailable, model.VolumeAttachError,
+		model.VolumeAttachErrorDeleting}
+	replicationResetStatus = []string{model.ReplicationAvailable, model.ReplicationEnabled,
+		model.ReplicationDisabled, model.ReplicationFailover, model.ReplicationError,
+		model.ReplicationErrorDeleting, model.ReplicationErrorEnabling, model.ReplicationErrorDisabling,
+		model.ReplicationErrorFailover, model.ReplicationErrorFailback}
+)
+
+func validateResetStatus(status string, validStatus []string) error {
+	if !utils.Contained(status, validStatus) {
+		errMsg := fmt.Sprintf("Status %s is invalid, it should be one of %s", status, strings.Join(validStatus, ", "))
+		log.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+// ResetVolumeStatusDBEntry sets the status of the volume without touching the
+// backend.
+func ResetVolumeStatusDBEntry(ctx *c.Context, in *model.VolumeSpec, status string) error {
+	if err := validateResetStatus(status, volumeResetStatus); err != nil {
+		return err
+	}
+	log.Warningf("Reset status of volume %s from %s to %s", in.Id, in.Status, status)
+	return db.C.UpdateStatus(ctx, in, status)
+}
+
+// ResetVolumeSnapshotStatusDBEntry sets the status of the volume snapshot
+// without touching the backend.
+func ResetVolumeSnapshotStatusDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec, status string) error {
+	if err := validateResetStatus(status, volumeSnapshotResetStatus); err != nil {
+		return err
+	}
+	log.Warningf("Reset status of volume snapshot %s from %s to %s", in.Id, in.Status, status)
+	return db.C.UpdateStatus(ctx, in, status)
+}
+
+// ResetVolumeAttachmentStatusDBEntry sets the status of the volume attachment
+// without touching the backend.
+func ResetVolumeAttachmentStatusDBEntry(ctx *c.Context, in *model.VolumeAttachmentSpec, status string) error {
+	if err := validateResetStatus(status, volumeAttachmentResetStatus); err != nil {
+		return err
+	}
+	log.Warningf("Reset status of volume attachment %s from %s to %s", in.Id, in.Status, status)
+	return db.C.UpdateStatus(ctx, in, status)
+}
+
+// ResetReplicationStatusDBEntry sets the status of the replication without
+// touching the backend.
+func ResetReplicationStatusDBEntry(ctx *c.Context, in *model.ReplicationSpec, status string) error {
+	if err := validateResetStatus(status, replicationResetStatus); err != nil {
+		return err
+	}
+	log.Warningf("Reset status of replication %s from %s to %s", in.Id, in.ReplicationStatus, status)
+	in.ReplicationStatus = status
+	_, err := db.C.UpdateReplication(ctx, in.Id, in)
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/opensds/opensds/pkg/model"
 	. "github.com/opensds/opensds/testutils/collection"
 	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateVolumeDBEntry(t *testing.T) {
@@ -37,6 +38,7 @@ func TestCreateVolumeDBEntry(t *testing.T) {
 
 	mockClient := new(dbtest.Client)
 	mockClient.On("CreateVolume", context.NewAdminContext(), req).Return(&SampleVolumes[0], nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
 	db.C = mockClient
 
 	var expected = &SampleVolumes[0]
@@ -60,8 +62,18 @@ func TestCreateVolumeDBEntry(t *testing.T) {
 
 	mockClient = new(dbtest.Client)
 	mockClient.On("CreateVolume", context.NewAdminContext(), req).Return(nil, errors.New("not find the volume"))
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
 	db.C = mockClient
 	result, err = CreateVolumeDBEntry(context.NewAdminContext(), req)
+
+	mockClient = new(dbtest.Client)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(
+		model.NewQuotaExceededError("quota of volumes exceeded"))
+	db.C = mockClient
+	if _, err = CreateVolumeDBEntry(context.NewAdminContext(), req); err == nil {
+		t.Errorf("Expected an error when quota is exceeded\n")
+	}
 }
 
 func TestCreateVolumeFromSnapshotDBEntry(t *testing.T) {
@@ -83,6 +95,7 @@ func TestCreateVolumeFromSnapshotDBEntry(t *testing.T) {
 
 	mockClient := new(dbtest.Client)
 	mockClient.On("CreateVolume", context.NewAdminContext(), req).Return(&SampleVolumes[1], nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
 	mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f537").Return(snap, nil)
 	db.C = mockClient
 
@@ -97,6 +110,50 @@ func TestCreateVolumeFromSnapshotDBEntry(t *testing.T) {
 	}
 }
 
+func TestCloneVolumeDBEntry(t *testing.T) {
+	var req = &model.VolumeSpec{
+		BaseModel:      &model.BaseModel{},
+		Name:           "volume sample",
+		Description:    "This is a sample volume for testing",
+		Size:           int64(1),
+		Status:         "creating",
+		SourceVolumeId: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+	}
+	var srcVol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Size:   int64(1),
+		Status: "available",
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("CreateVolume", context.NewAdminContext(), req).Return(&SampleVolumes[1], nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
+	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(srcVol, nil)
+	db.C = mockClient
+
+	var expected = &SampleVolumes[1]
+	result, err := CreateVolumeDBEntry(context.NewAdminContext(), req)
+
+	if err != nil {
+		t.Errorf("Failed to clone volume, err is %v\n", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+
+	srcVol.Size = int64(2)
+	if _, err = CreateVolumeDBEntry(context.NewAdminContext(), req); err == nil {
+		t.Errorf("Expected an error when size of volume is smaller than source volume\n")
+	}
+
+	srcVol.Size, srcVol.Status = int64(1), "in-use"
+	if _, err = CreateVolumeDBEntry(context.NewAdminContext(), req); err == nil {
+		t.Errorf("Expected an error when source volume is not available\n")
+	}
+}
+
 func TestDeleteVolumeDBEntry(t *testing.T) {
 	var vol = &model.VolumeSpec{
 		BaseModel: &model.BaseModel{},
@@ -118,6 +175,61 @@ func TestDeleteVolumeDBEntry(t *testing.T) {
 	}
 }
 
+func TestForceDeleteVolumeDBEntry(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Status:    model.VolumeCreating,
+		ProfileId: "3769855c-a102-11e7-b772-17b880d2f537",
+		PoolId:    "3762355c-a102-11e7-b772-17b880d2f537",
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(nil, nil)
+	mockClient.On("ListSnapshotsByVolumeId", context.NewAdminContext(), vol.Id).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", context.NewAdminContext(), vol.Id).Return(nil, nil)
+	db.C = mockClient
+
+	if err := DeleteVolumeDBEntry(context.NewAdminContext(), vol); err == nil {
+		t.Error("Expected an error when delete volume in creating")
+	}
+	if err := ForceDeleteVolumeDBEntry(context.NewAdminContext(), vol); err != nil {
+		t.Errorf("Failed to force delete volume, err is %v\n", err)
+	}
+	if vol.Status != model.VolumeDeleting {
+		t.Errorf("Expected %s, actual %s", model.VolumeDeleting, vol.Status)
+	}
+
+	// The volume with snapshots can't be deleted even by force.
+	vol.Status = model.VolumeCreating
+	mockClient = new(dbtest.Client)
+	mockClient.On("ListSnapshotsByVolumeId", context.NewAdminContext(), vol.Id).Return(
+		[]*model.VolumeSnapshotSpec{&SampleSnapshots[0]}, nil)
+	db.C = mockClient
+	if err := ForceDeleteVolumeDBEntry(context.NewAdminContext(), vol); err == nil {
+		t.Error("Expected an error when force delete volume with snapshots")
+	}
+}
+
+func TestResetVolumeStatusDBEntry(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Status:    model.VolumeExtending,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, model.VolumeErrorExtending).Return(nil)
+	db.C = mockClient
+
+	if err := ResetVolumeStatusDBEntry(context.NewAdminContext(), vol, model.VolumeErrorExtending); err != nil {
+		t.Errorf("Failed to reset volume status, err is %v\n", err)
+	}
+	for _, status := range []string{model.VolumeCreating, model.VolumeDeleting, "unknown"} {
+		if err := ResetVolumeStatusDBEntry(context.NewAdminContext(), vol, status); err == nil {
+			t.Errorf("Expected an error when reset volume status to %s", status)
+		}
+	}
+}
+
 func TestExtendVolumeDBEntry(t *testing.T) {
 	var vol = &model.VolumeSpec{
 		BaseModel: &model.BaseModel{
@@ -130,9 +242,10 @@ func TestExtendVolumeDBEntry(t *testing.T) {
 	mockClient := new(dbtest.Client)
 	mockClient.On("ExtendVolume", context.NewAdminContext(), vol).Return(nil, nil)
 	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(vol, nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Gigabytes: 1}).Return(nil)
 	db.C = mockClient
 
-	_, err := ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id)
+	_, err := ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id, 3)
 	if err != nil {
 		t.Errorf("Failed to delete volume, err is %v\n", err)
 	}
@@ -140,7 +253,7 @@ func TestExtendVolumeDBEntry(t *testing.T) {
 	mockClient = new(dbtest.Client)
 	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(nil, errors.New("error occurs when get volume"))
 	db.C = mockClient
-	_, err = ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id)
+	_, err = ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id, 3)
 
 	var vol2 = &model.VolumeSpec{
 		BaseModel: &model.BaseModel{
@@ -152,7 +265,177 @@ func TestExtendVolumeDBEntry(t *testing.T) {
 	mockClient = new(dbtest.Client)
 	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(vol2, nil)
 	db.C = mockClient
-	_, err = ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id)
+	_, err = ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id, 3)
+}
+
+func TestRevertVolumeDBEntry(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Status: "available",
+		Size:   1,
+	}
+	var snap = &model.VolumeSnapshotSpec{
+		BaseModel: &model.BaseModel{
+			Id: "3769855c-a102-11e7-b772-17b880d2f537",
+		},
+		VolumeId: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Status:   "available",
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), snap.Id).Return(snap, nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(vol, nil)
+	db.C = mockClient
+
+	result, err := RevertVolumeDBEntry(context.NewAdminContext(), vol.Id, snap.Id)
+	if err != nil {
+		t.Errorf("Failed to revert volume, err is %v\n", err)
+	}
+	if result.Status != model.VolumeReverting {
+		t.Errorf("Expected %v, got %v\n", model.VolumeReverting, result.Status)
+	}
+
+	vol.Status, snap.VolumeId = "available", "65ee2a8a-a101-11e7-941e-d77981b584d8"
+	if _, err = RevertVolumeDBEntry(context.NewAdminContext(), vol.Id, snap.Id); err == nil {
+		t.Errorf("Expected an error when snapshot does not belong to volume\n")
+	}
+
+	vol.Status = "inUse"
+	if _, err = RevertVolumeDBEntry(context.NewAdminContext(), vol.Id, snap.Id); err == nil {
+		t.Errorf("Expected an error when volume is not available\n")
+	}
+}
+
+func TestMigrateVolumeDBEntry(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Status: "available",
+		PoolId: "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:   1,
+	}
+	var poolID = "a594b8ac-a103-11e7-985f-d723bcf01b5f"
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("ListSnapshotsByVolumeId", context.NewAdminContext(), vol.Id).Return(nil, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), poolID).Return(&SamplePools[1], nil)
+	mockClient.On("GetDefaultProfile", context.NewAdminContext()).Return(&SampleProfiles[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(vol, nil)
+	db.C = mockClient
+
+	result, err := MigrateVolumeDBEntry(context.NewAdminContext(), vol.Id, poolID)
+	if err != nil {
+		t.Errorf("Failed to migrate volume, err is %v\n", err)
+	}
+	if result.Status != model.VolumeMigrating {
+		t.Errorf("Expected %v, got %v\n", model.VolumeMigrating, result.Status)
+	}
+
+	vol.Status = "available"
+	if _, err = MigrateVolumeDBEntry(context.NewAdminContext(), vol.Id, vol.PoolId); err == nil {
+		t.Errorf("Expected an error when target pool is the same with source pool\n")
+	}
+
+	vol.Status = "inUse"
+	if _, err = MigrateVolumeDBEntry(context.NewAdminContext(), vol.Id, poolID); err == nil {
+		t.Errorf("Expected an error when volume is not available\n")
+	}
+}
+
+func TestMigrateVolumeDBEntryWithSnapshots(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Status: "available",
+		PoolId: "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:   1,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("ListSnapshotsByVolumeId", context.NewAdminContext(), vol.Id).Return(
+		[]*model.VolumeSnapshotSpec{&SampleSnapshots[0]}, nil)
+	db.C = mockClient
+
+	if _, err := MigrateVolumeDBEntry(context.NewAdminContext(), vol.Id, ""); err == nil {
+		t.Error("Expected an error when volume has snapshots")
+	}
+	mockClient.AssertNotCalled(t, "UpdateVolume", mock.Anything, mock.Anything)
+}
+
+func TestMigrateVolumeDBEntryWithUnsupportedPool(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Status:    "available",
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		ProfileId: SampleProfiles[1].Id,
+		Size:      1,
+	}
+	var poolID = "a594b8ac-a103-11e7-985f-d723bcf01b5f"
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("ListSnapshotsByVolumeId", context.NewAdminContext(), vol.Id).Return(nil, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), poolID).Return(&SamplePools[1], nil)
+	mockClient.On("GetProfile", context.NewAdminContext(), vol.ProfileId).Return(&SampleProfiles[1], nil)
+	db.C = mockClient
+
+	// The pool doesn't provide the iops required by profile.
+	if _, err := MigrateVolumeDBEntry(context.NewAdminContext(), vol.Id, poolID); err == nil {
+		t.Error("Expected an error when target pool doesn't satisfy the profile")
+	}
+
+	// The pool is located in another availability zone.
+	vol.ProfileId, vol.AvailabilityZone = "", "az2"
+	mockClient.On("GetDefaultProfile", context.NewAdminContext()).Return(&SampleProfiles[0], nil)
+	if _, err := MigrateVolumeDBEntry(context.NewAdminContext(), vol.Id, poolID); err == nil {
+		t.Error("Expected an error when target pool is in another availability zone")
+	}
+	mockClient.AssertNotCalled(t, "UpdateVolume", mock.Anything, mock.Anything)
+}
+
+func TestRetypeVolumeDBEntry(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Status:    "available",
+		ProfileId: "1106b972-66ef-11e7-b172-db03f3689c9c",
+		Size:      1,
+	}
+	var prfID = "2f9c0a04-66ef-11e7-ade2-43158893e017"
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetProfile", context.NewAdminContext(), prfID).Return(&SampleProfiles[1], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(vol, nil)
+	db.C = mockClient
+
+	result, err := RetypeVolumeDBEntry(context.NewAdminContext(), vol.Id, prfID)
+	if err != nil {
+		t.Errorf("Failed to retype volume, err is %v\n", err)
+	}
+	if result.Status != model.VolumeRetyping {
+		t.Errorf("Expected %v, got %v\n", model.VolumeRetyping, result.Status)
+	}
+
+	vol.Status = "available"
+	if _, err = RetypeVolumeDBEntry(context.NewAdminContext(), vol.Id, vol.ProfileId); err == nil {
+		t.Errorf("Expected an error when new profile is the same with current one\n")
+	}
+
+	vol.Status = "inUse"
+	if _, err = RetypeVolumeDBEntry(context.NewAdminContext(), vol.Id, prfID); err == nil {
+		t.Errorf("Expected an error when volume is not available\n")
+	}
 }
 
 func TestCreateVolumeAttachmentDBEntry(t *testing.T) {
@@ -209,6 +492,7 @@ func TestCreateVolumeSnapshotDBEntry(t *testing.T) {
 	mockClient := new(dbtest.Client)
 	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(vol, nil)
 	mockClient.On("CreateVolumeSnapshot", context.NewAdminContext(), req).Return(&SampleSnapshots[0], nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Snapshots: 1}).Return(nil)
 	db.C = mockClient
 
 	var expected = &SampleSnapshots[0]
@@ -242,3 +526,256 @@ func TestDeleteVolumeSnapshotDBEntry(t *testing.T) {
 		t.Errorf("Failed to delete volume snapshot, err is %v\n", err)
 	}
 }
+
+func TestCreateGroupSnapshotDBEntry(t *testing.T) {
+	var vg = &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{Id: "3769855c-a102-11e7-b772-17b880d2f555"},
+		Status:    model.VolumeGroupAvailable,
+	}
+	var vols = []*model.VolumeSpec{&SampleVolumes[0], &SampleVolumes[1]}
+	var req = &model.GroupSnapshotSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "sample-group-snapshot-01",
+		GroupId:   vg.Id,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolumeGroup", context.NewAdminContext(), vg.Id).Return(vg, nil)
+	mockClient.On("ListVolumesByGroupId", context.NewAdminContext(), vg.Id).Return(vols, nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Snapshots: 2}).Return(nil)
+	mockClient.On("CreateVolumeSnapshot", context.NewAdminContext(), mock.AnythingOfType("*model.VolumeSnapshotSpec")).Return(nil, nil)
+	mockClient.On("CreateGroupSnapshot", context.NewAdminContext(), mock.AnythingOfType("*model.GroupSnapshotSpec")).Return(&SampleGroupSnapshots[0], nil)
+	mockClient.On("UpdateVolumeGroup", context.NewAdminContext(), vg).Return(vg, nil)
+	db.C = mockClient
+
+	result, err := CreateGroupSnapshotDBEntry(context.NewAdminContext(), req)
+	if err != nil {
+		t.Fatalf("Failed to create group snapshot, err is %v\n", err)
+	}
+	if !reflect.DeepEqual(result, &SampleGroupSnapshots[0]) {
+		t.Errorf("Expected %v, got %v\n", &SampleGroupSnapshots[0], result)
+	}
+	mockClient.AssertNumberOfCalls(t, "CreateVolumeSnapshot", 2)
+	for _, call := range mockClient.Calls {
+		if call.Method != "CreateVolumeSnapshot" {
+			continue
+		}
+		snp := call.Arguments.Get(1).(*model.VolumeSnapshotSpec)
+		if snp.GroupSnapshotId != req.Id || snp.Status != model.VolumeSnapCreating {
+			t.Errorf("Unexpected member snapshot %+v\n", snp)
+		}
+	}
+	if !reflect.DeepEqual(vg.GroupSnapshots, []string{req.Id}) {
+		t.Errorf("Expected group snapshots %v, got %v\n", []string{req.Id}, vg.GroupSnapshots)
+	}
+}
+
+func TestCreateGroupSnapshotDBEntryWithUnavailableGroup(t *testing.T) {
+	var vg = &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{Id: "3769855c-a102-11e7-b772-17b880d2f555"},
+		Status:    model.VolumeGroupUpdating,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolumeGroup", context.NewAdminContext(), vg.Id).Return(vg, nil)
+	db.C = mockClient
+
+	_, err := CreateGroupSnapshotDBEntry(context.NewAdminContext(), &model.GroupSnapshotSpec{
+		BaseModel: &model.BaseModel{},
+		GroupId:   vg.Id,
+	})
+	if err == nil {
+		t.Error("Expected error when the volume group is not available")
+	}
+}
+
+func TestDeleteGroupSnapshotDBEntry(t *testing.T) {
+	var gs = SampleGroupSnapshots[0]
+
+	mockClient := new(dbtest.Client)
+	for i := range SampleSnapshots {
+		snp := &SampleSnapshots[i]
+		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), snp.Id).Return(snp, nil)
+		mockClient.On("UpdateStatus", context.NewAdminContext(), snp, model.VolumeSnapDeleting).Return(nil)
+	}
+	mockClient.On("UpdateStatus", context.NewAdminContext(), &gs, model.GroupSnapDeleting).Return(nil)
+	db.C = mockClient
+
+	if err := DeleteGroupSnapshotDBEntry(context.NewAdminContext(), &gs); err != nil {
+		t.Errorf("Failed to delete group snapshot, err is %v\n", err)
+	}
+	mockClient.AssertNumberOfCalls(t, "UpdateStatus", 3)
+
+	gs.Status = model.GroupSnapCreating
+	if err := DeleteGroupSnapshotDBEntry(context.NewAdminContext(), &gs); err == nil {
+		t.Error("Expected error when the group snapshot is creating")
+	}
+}
+
+func newGroupFromSnapshotMock(gs *model.GroupSnapshotSpec, srcGroup *model.VolumeGroupSpec) *dbtest.Client {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetGroupSnapshot", context.NewAdminContext(), gs.Id).Return(gs, nil)
+	mockClient.On("GetVolumeGroup", context.NewAdminContext(), gs.GroupId).Return(srcGroup, nil)
+	mockClient.On("CreateVolumeGroup", context.NewAdminContext(), mock.AnythingOfType("*model.VolumeGroupSpec")).Return(srcGroup, nil)
+	for i := range SampleSnapshots {
+		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), SampleSnapshots[i].Id).Return(&SampleSnapshots[i], nil)
+	}
+	mockClient.On("GetVolume", context.NewAdminContext(), SampleSnapshots[0].VolumeId).Return(&SampleVolumes[0], nil)
+	mockClient.On("ReserveQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
+	return mockClient
+}
+
+func TestCreateVolumeGroupFromGroupSnapshotDBEntry(t *testing.T) {
+	var gs = &SampleGroupSnapshots[0]
+	var srcGroup = &model.VolumeGroupSpec{
+		BaseModel:        &model.BaseModel{Id: gs.GroupId},
+		AvailabilityZone: "default",
+		Profiles:         []string{"1106b972-66ef-11e7-b172-db03f3689c9c"},
+	}
+	var req = &model.VolumeGroupSpec{
+		BaseModel:       &model.BaseModel{},
+		Name:            "sample-group-02",
+		GroupSnapshotId: gs.Id,
+	}
+
+	mockClient := newGroupFromSnapshotMock(gs, srcGroup)
+	mockClient.On("CreateVolume", context.NewAdminContext(), mock.AnythingOfType("*model.VolumeSpec")).Return(nil, nil)
+	db.C = mockClient
+
+	if _, err := CreateVolumeGroupDBEntry(context.NewAdminContext(), req); err != nil {
+		t.Fatalf("Failed to create volume group from group snapshot, err is %v\n", err)
+	}
+	// The volumes are created in the group by the job submitted later.
+	mockClient.AssertNumberOfCalls(t, "CreateVolume", 2)
+	for _, call := range mockClient.Calls {
+		if call.Method != "CreateVolume" {
+			continue
+		}
+		vol := call.Arguments.Get(1).(*model.VolumeSpec)
+		if vol.GroupId != req.Id || vol.Status != model.VolumeCreating {
+			t.Errorf("Unexpected volume of group %+v\n", vol)
+		}
+	}
+}
+
+func TestCreateVolumeGroupFromGroupSnapshotDBEntryRollback(t *testing.T) {
+	var gs = &SampleGroupSnapshots[0]
+	var srcGroup = &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{Id: gs.GroupId},
+		Profiles:  []string{"1106b972-66ef-11e7-b172-db03f3689c9c"},
+	}
+	var req = &model.VolumeGroupSpec{
+		BaseModel:       &model.BaseModel{Id: "f4a5e666-c669-4c64-a2a1-8f9ecd560c78"},
+		GroupSnapshotId: gs.Id,
+	}
+
+	mockClient := newGroupFromSnapshotMock(gs, srcGroup)
+	mockClient.On("CreateVolume", context.NewAdminContext(), mock.AnythingOfType("*model.VolumeSpec")).Return(&SampleVolumes[1], nil).Once()
+	mockClient.On("CreateVolume", context.NewAdminContext(), mock.AnythingOfType("*model.VolumeSpec")).Return(nil, errors.New("db is down"))
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}).Return(nil)
+	mockClient.On("DeleteVolume", context.NewAdminContext(), SampleVolumes[1].Id).Return(nil)
+	mockClient.On("DeleteVolumeGroup", context.NewAdminContext(), req.Id).Return(nil)
+	db.C = mockClient
+
+	if _, err := CreateVolumeGroupDBEntry(context.NewAdminContext(), req); err == nil {
+		t.Fatal("Expected error when the volume entry of group can't be created")
+	}
+	// Both the volume created and the group are removed, and the quota of
+	// both volumes is released.
+	mockClient.AssertCalled(t, "DeleteVolume", context.NewAdminContext(), SampleVolumes[1].Id)
+	mockClient.AssertCalled(t, "DeleteVolumeGroup", context.NewAdminContext(), req.Id)
+	mockClient.AssertNumberOfCalls(t, "ReleaseQuota", 2)
+}
+
+func TestDeleteMemberSnapshotOfGroupSnapshot(t *testing.T) {
+	var req = &model.VolumeSnapshotSpec{
+		BaseModel: &model.BaseModel{
+			Id: "3769855c-a102-11e7-b772-17b880d2f537",
+		},
+		VolumeId:        "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Status:          "available",
+		GroupSnapshotId: "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50",
+	}
+	db.C = new(dbtest.Client)
+
+	if err := DeleteVolumeSnapshotDBEntry(context.NewAdminContext(), req); err == nil {
+		t.Error("Expected error when delete a member snapshot of group snapshot")
+	}
+}
+
+func TestCreateFileShareDBEntry(t *testing.T) {
+	var req = &model.FileShareSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "sample-fileshare-01",
+		Size:      int64(1),
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("CreateFileShare", context.NewAdminContext(), mock.AnythingOfType("*model.FileShareSpec")).Return(&SampleFileShares[0], nil)
+	db.C = mockClient
+
+	if _, err := CreateFileShareDBEntry(context.NewAdminContext(), req); err != nil {
+		t.Fatalf("Failed to create file share, err is %v\n", err)
+	}
+	share := mockClient.Calls[0].Arguments.Get(1).(*model.FileShareSpec)
+	if share.Id == "" || share.Status != model.FileShareCreating || share.Protocol != "nfs" ||
+		share.AvailabilityZone != "default" {
+		t.Errorf("Unexpected file share %+v\n", share)
+	}
+
+	for _, req := range []*model.FileShareSpec{
+		{BaseModel: &model.BaseModel{}, Size: 0},
+		{BaseModel: &model.BaseModel{}, Size: 1, Protocol: "cifs"},
+	} {
+		if _, err := CreateFileShareDBEntry(context.NewAdminContext(), req); err == nil {
+			t.Errorf("Expected error of invalid file share %+v\n", req)
+		}
+	}
+}
+
+func TestDeleteFileShareDBEntryWithPendingAcl(t *testing.T) {
+	var share = SampleFileShares[0]
+	var acl = SampleFileShareAcls[0]
+	acl.Status = model.FileShareAclApplying
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListFileShareAcls", context.NewAdminContext()).Return([]*model.FileShareAclSpec{&acl}, nil)
+	db.C = mockClient
+
+	if err := DeleteFileShareDBEntry(context.NewAdminContext(), &share); err == nil {
+		t.Error("Expected error when the access rule of file share is being applied")
+	}
+}
+
+func TestCreateFileShareAclDBEntry(t *testing.T) {
+	var req = &model.FileShareAclSpec{
+		BaseModel:   &model.BaseModel{},
+		FileShareId: SampleFileShares[0].Id,
+		Type:        "ip",
+		AccessTo:    "192.168.57.10",
+		AccessLevel: "ro",
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShare", context.NewAdminContext(), SampleFileShares[0].Id).Return(&SampleFileShares[0], nil)
+	mockClient.On("ListFileShareAcls", context.NewAdminContext()).Return([]*model.FileShareAclSpec{&SampleFileShareAcls[0]}, nil)
+	mockClient.On("CreateFileShareAcl", context.NewAdminContext(), mock.AnythingOfType("*model.FileShareAclSpec")).Return(&SampleFileShareAcls[0], nil)
+	db.C = mockClient
+
+	if _, err := CreateFileShareAclDBEntry(context.NewAdminContext(), req); err != nil {
+		t.Fatalf("Failed to create file share acl, err is %v\n", err)
+	}
+	mockClient.AssertNumberOfCalls(t, "CreateFileShareAcl", 1)
+
+	// The clients which already have an access rule are rejected.
+	req = &model.FileShareAclSpec{
+		BaseModel:   &model.BaseModel{},
+		FileShareId: SampleFileShares[0].Id,
+		Type:        "ip",
+		AccessTo:    SampleFileShareAcls[0].AccessTo,
+		AccessLevel: "ro",
+	}
+	if _, err := CreateFileShareAclDBEntry(context.NewAdminContext(), req); err == nil {
+		t.Error("Expected error when the access rule already exists")
+	}
+}
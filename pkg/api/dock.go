@@ -49,7 +49,7 @@ func (d *DockPortal) ListDocks() {
 		log.Error(reason)
 		return
 	}
-	result, err := db.C.ListDocksWithFilter(c.GetContext(d.Ctx), m)
+	result, page, err := db.C.ListDocksWithPage(c.GetContext(d.Ctx), m)
 	if err != nil {
 		reason := fmt.Sprintf("List docks failed: %s", err.Error())
 		d.Ctx.Output.SetStatus(model.ErrorBadRequest)
@@ -68,6 +68,7 @@ func (d *DockPortal) ListDocks() {
 		return
 	}
 
+	d.setPageHeaders(page)
 	d.Ctx.Output.SetStatus(StatusOK)
 	d.Ctx.Output.Body(body)
 	return
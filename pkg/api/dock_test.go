@@ -59,7 +59,7 @@ func TestListDocks(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListDocksWithFilter", c.NewAdminContext(), m).Return(fakeDocks, nil)
+	mockClient.On("ListDocksWithPage", c.NewAdminContext(), m).Return(fakeDocks, &model.PageSpec{Total: len(fakeDocks)}, nil)
 
 	db.C = mockClient
 
@@ -109,7 +109,7 @@ func TestListDocksWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListDocksWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListDocksWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/docks?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -0,0 +1,125 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS northbound service.
+
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/pkg/api/policy"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
+)
+
+const (
+	defaultWatchTimeout = 30
+	// The watch request must return before the server closes the connection.
+	maxWatchTimeout = constants.BeegoServerTimeOut - 10
+)
+
+type EventPortal struct {
+	BasePortal
+}
+
+func (e *EventPortal) ListEvents() {
+	if !policy.Authorize(e.Ctx, "event:list") {
+		return
+	}
+	m, err := e.GetParameters()
+	if err != nil {
+		reason := fmt.Sprintf("List events failed: %s", err.Error())
+		e.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		e.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	result, err := db.C.ListEventsWithFilter(c.GetContext(e.Ctx), m)
+	if err != nil {
+		reason := fmt.Sprintf("List events failed: %s", err.Error())
+		e.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		e.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+	e.outputEvents(result)
+}
+
+// WatchEvents is a long-poll request, it returns the events which happen
+// after the since parameter as soon as there is any of them, or an empty list
+// after timeout seconds. The client should send the resourceVersion of the
+// last event as since parameter in the next request.
+func (e *EventPortal) WatchEvents() {
+	if !policy.Authorize(e.Ctx, "event:watch") {
+		return
+	}
+	m, err := e.GetParameters()
+	if err != nil {
+		reason := fmt.Sprintf("Watch events failed: %s", err.Error())
+		e.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		e.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	timeout := defaultWatchTimeout
+	if v := e.Ctx.Input.Query("timeout"); v != "" {
+		if timeout, err = strconv.Atoi(v); err != nil || timeout < 0 {
+			reason := fmt.Sprintf("Watch events failed: invalid timeout %s", v)
+			e.Ctx.Output.SetStatus(model.ErrorBadRequest)
+			e.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+			log.Error(reason)
+			return
+		}
+	}
+	if timeout > maxWatchTimeout {
+		timeout = maxWatchTimeout
+	}
+
+	result, err := db.C.WatchEvents(c.GetContext(e.Ctx), m, time.Duration(timeout)*time.Second)
+	if err != nil {
+		reason := fmt.Sprintf("Watch events failed: %s", err.Error())
+		e.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		e.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+	e.outputEvents(result)
+}
+
+func (e *EventPortal) outputEvents(result []*model.EventSpec) {
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("Marshal events listed result failed: %s", err.Error())
+		e.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		e.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	e.Ctx.Output.SetStatus(StatusOK)
+	e.Ctx.Output.Body(body)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+func init() {
+	var eventPortal EventPortal
+	beego.Router("/v1beta/:tenantId/events", &eventPortal, "get:ListEvents")
+	beego.Router("/v1beta/:tenantId/events/watch", &eventPortal, "get:WatchEvents")
+}
+
+var fakeEvents = []*model.EventSpec{
+	{
+		BaseModel: &model.BaseModel{
+			Id:              "e3f6a5d4-0c1b-4f3e-8c6b-5b0b3b2f1a01",
+			CreatedAt:       "2018-08-10T14:36:58",
+			ResourceVersion: 7,
+		},
+		ResourceType: model.EventResourceVolume,
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		OldStatus:    model.VolumeCreating,
+		NewStatus:    model.VolumeAvailable,
+	},
+}
+
+func TestListEvents(t *testing.T) {
+	m := map[string][]string{
+		"resourceId": {"bd5b12a8-a101-11e7-941e-d77981b584d8"},
+	}
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListEventsWithFilter", c.NewAdminContext(), m).Return(fakeEvents, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/admin/events?resourceId=bd5b12a8-a101-11e7-941e-d77981b584d8", nil)
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.EventSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(fakeEvents, output) {
+		t.Errorf("Expected %v, actual %v", fakeEvents, output)
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	m := map[string][]string{
+		"since":   {"6"},
+		"timeout": {"100"},
+	}
+	mockClient := new(dbtest.Client)
+	mockClient.On("WatchEvents", c.NewAdminContext(), m, time.Duration(maxWatchTimeout)*time.Second).
+		Return(fakeEvents, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/admin/events/watch?since=6&timeout=100", nil)
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.EventSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(fakeEvents, output) {
+		t.Errorf("Expected %v, actual %v", fakeEvents, output)
+	}
+}
+
+func TestWatchEventsWithBadRequest(t *testing.T) {
+	m := map[string][]string{
+		"since": {"invalid"},
+	}
+	mockClient := new(dbtest.Client)
+	mockClient.On("WatchEvents", c.NewAdminContext(), m, time.Duration(defaultWatchTimeout)*time.Second).
+		Return(nil, errors.New("invalid since: invalid"))
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/admin/events/watch?since=invalid", nil)
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+
+	r, _ = http.NewRequest("GET", "/v1beta/admin/events/watch?timeout=-1", nil)
+	w = httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS northbound service of file
+shares and their access rules.
+
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/opensds/opensds/pkg/api/policy"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/controller"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+type FileSharePortal struct {
+	BasePortal
+}
+
+func (v *FileSharePortal) CreateFileShare() {
+	if !policy.Authorize(v.Ctx, "fileshare:create") {
+		return
+	}
+
+	var share = model.FileShareSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	// Unmarshal the request body
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&share); err != nil {
+		v.ErrorHandle("Parse file share request body failed", model.ErrorBadRequest, err)
+		return
+	}
+	// NOTE:It will create a file share entry into the database and initialize
+	// its status as "creating". It will not wait for the real file share
+	// creation to complete and will return result immediately.
+	result, err := CreateFileShareDBEntry(c.GetContext(v.Ctx), &share)
+	if err != nil {
+		v.ErrorHandle("Create file share failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share created result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	// NOTE:The real file share creation process.
+	// File share creation request is sent to the Dock. Dock will update the
+	// status of file share to "available" after creation complete.
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateFileShare, result.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, body)
+	return
+}
+
+func (v *FileSharePortal) ListFileShares() {
+	if !policy.Authorize(v.Ctx, "fileshare:get") {
+		return
+	}
+
+	m, err := v.GetParameters()
+	if err != nil {
+		v.ErrorHandle("List file shares failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	result, page, err := db.C.ListFileSharesWithPage(c.GetContext(v.Ctx), m)
+	if err != nil {
+		v.ErrorHandle("List file shares failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file shares listed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.setPageHeaders(page)
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *FileSharePortal) GetFileShare() {
+	if !policy.Authorize(v.Ctx, "fileshare:get") {
+		return
+	}
+
+	result, err := db.C.GetFileShare(c.GetContext(v.Ctx), v.Ctx.Input.Param(":fileshareId"))
+	if err != nil {
+		v.ErrorHandle("Get file share failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share showed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *FileSharePortal) UpdateFileShare() {
+	if !policy.Authorize(v.Ctx, "fileshare:update") {
+		return
+	}
+
+	var share = model.FileShareSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&share); err != nil {
+		v.ErrorHandle("Parse file share request body failed", model.ErrorBadRequest, err)
+		return
+	}
+	if err := v.parseIfMatch(share.BaseModel); err != nil {
+		v.ErrorHandle("Parse file share request header failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Only the name and description of file share can be updated by users,
+	// the others are maintained by the system.
+	var update = &model.FileShareSpec{
+		BaseModel: &model.BaseModel{
+			Id:              v.Ctx.Input.Param(":fileshareId"),
+			ResourceVersion: share.ResourceVersion,
+		},
+		Name:        share.Name,
+		Description: share.Description,
+	}
+	result, err := db.C.UpdateFileShare(c.GetContext(v.Ctx), update)
+	if err != nil {
+		v.updateFailed(fmt.Sprintf("Update file share failed: %s", err.Error()), err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share updated result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *FileSharePortal) ExtendFileShare() {
+	if !policy.Authorize(v.Ctx, "fileshare:extend") {
+		return
+	}
+
+	var extendRequestBody = model.ExtendFileShareSpec{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&extendRequestBody); err != nil {
+		v.ErrorHandle("Parse file share request body failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	// NOTE:It will update the the status of the file share waiting for
+	// expansion in the database to "extending" and return the result
+	// immediately.
+	result, err := ExtendFileShareDBEntry(ctx, v.Ctx.Input.Param(":fileshareId"), extendRequestBody.NewSize)
+	if err != nil {
+		v.ErrorHandle("Extend file share failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share extended result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	// NOTE:The real file share extension process.
+	// File share extension request is sent to the Dock. Dock will update the
+	// status of file share to "available" after extension complete.
+	params := map[string]string{controller.JobNewSizeKey: strconv.FormatInt(extendRequestBody.NewSize, 10)}
+	if _, err := controller.SubmitJob(ctx, model.JobExtendFileShare, result.Id, params); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, body)
+	return
+}
+
+func (v *FileSharePortal) DeleteFileShare() {
+	if !policy.Authorize(v.Ctx, "fileshare:delete") {
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	share, err := db.C.GetFileShare(ctx, v.Ctx.Input.Param(":fileshareId"))
+	if err != nil {
+		v.ErrorHandle("Get file share failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// NOTE:It will update the the status of the file share waiting for
+	// deletion in the database to "deleting" and return the result
+	// immediately.
+	if err = DeleteFileShareDBEntry(ctx, share); err != nil {
+		v.ErrorHandle("Delete file share failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// NOTE:The real file share deletion process.
+	// File share deletion request is sent to the Dock. Dock will delete the
+	// file share from driver and database or update its status to
+	// "errorDeleting" if deletion from driver failed.
+	if _, err := controller.SubmitJob(ctx, model.JobDeleteFileShare, share.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, nil)
+	return
+}
+
+type FileShareAclPortal struct {
+	BasePortal
+}
+
+func (v *FileShareAclPortal) CreateFileShareAcl() {
+	if !policy.Authorize(v.Ctx, "fileshare_acl:create") {
+		return
+	}
+
+	var acl = model.FileShareAclSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	// Unmarshal the request body
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&acl); err != nil {
+		v.ErrorHandle("Parse file share acl request body failed", model.ErrorBadRequest, err)
+		return
+	}
+	// NOTE:It will create a file share acl entry into the database and
+	// initialize its status as "applying". It will not wait for the access
+	// rule to be applied and will return result immediately.
+	result, err := CreateFileShareAclDBEntry(c.GetContext(v.Ctx), &acl)
+	if err != nil {
+		v.ErrorHandle("Create file share acl failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share acl created result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	// NOTE:The access rule is applied by the Dock, which will update the
+	// status of file share acl to "available" after it is applied.
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateFileShareAcl, result.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, body)
+	return
+}
+
+func (v *FileShareAclPortal) ListFileShareAcls() {
+	if !policy.Authorize(v.Ctx, "fileshare_acl:get") {
+		return
+	}
+
+	m, err := v.GetParameters()
+	if err != nil {
+		v.ErrorHandle("List file share acls failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	result, err := db.C.ListFileShareAclsWithFilter(c.GetContext(v.Ctx), m)
+	if err != nil {
+		v.ErrorHandle("List file share acls failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share acls listed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *FileShareAclPortal) GetFileShareAcl() {
+	if !policy.Authorize(v.Ctx, "fileshare_acl:get") {
+		return
+	}
+
+	result, err := db.C.GetFileShareAcl(c.GetContext(v.Ctx), v.Ctx.Input.Param(":aclId"))
+	if err != nil {
+		v.ErrorHandle("Get file share acl failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal file share acl showed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *FileShareAclPortal) DeleteFileShareAcl() {
+	if !policy.Authorize(v.Ctx, "fileshare_acl:delete") {
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	acl, err := db.C.GetFileShareAcl(ctx, v.Ctx.Input.Param(":aclId"))
+	if err != nil {
+		v.ErrorHandle("Get file share acl failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// NOTE:It will update the the status of the file share acl waiting for
+	// deletion in the database to "deleting" and return the result
+	// immediately.
+	if err = DeleteFileShareAclDBEntry(ctx, acl); err != nil {
+		v.ErrorHandle("Delete file share acl failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// NOTE:The access rule is revoked by the Dock, which will delete the file
+	// share acl from database or update its status to "errorDeleting" if it
+	// can't be revoked.
+	if _, err := controller.SubmitJob(ctx, model.JobDeleteFileShareAcl, acl.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, nil)
+	return
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/astaxie/beego"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/file/shares", &FileSharePortal{}, "post:CreateFileShare;get:ListFileShares")
+	beego.Router("/v1beta/file/shares/:fileshareId", &FileSharePortal{}, "get:GetFileShare;put:UpdateFileShare;delete:DeleteFileShare")
+	beego.Router("/v1beta/file/shares/:fileshareId/resize", &FileSharePortal{}, "post:ExtendFileShare")
+	beego.Router("/v1beta/file/acls", &FileShareAclPortal{}, "post:CreateFileShareAcl;get:ListFileShareAcls")
+	beego.Router("/v1beta/file/acls/:aclId", &FileShareAclPortal{}, "get:GetFileShareAcl;delete:DeleteFileShareAcl")
+}
+
+func TestListFileShares(t *testing.T) {
+	var sampleFileShares = []*model.FileShareSpec{&SampleFileShares[0]}
+	mockClient := new(dbtest.Client)
+	m := map[string][]string{
+		"offset":  {"0"},
+		"limit":   {"1"},
+		"sortDir": {"asc"},
+		"sortKey": {"name"},
+	}
+	mockClient.On("ListFileSharesWithPage", c.NewAdminContext(), m).Return(sampleFileShares, &model.PageSpec{Total: len(sampleFileShares)}, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/file/shares?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.FileShareSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(output, sampleFileShares) {
+		t.Errorf("Expected %v, actual %v", sampleFileShares, output)
+	}
+}
+
+func TestGetFileShare(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&SampleFileShares[0], nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.FileShareSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(&output, &SampleFileShares[0]) {
+		t.Errorf("Expected %v, actual %v", &SampleFileShares[0], &output)
+	}
+}
+
+func TestUpdateFileShare(t *testing.T) {
+	var update = &model.FileShareSpec{
+		BaseModel:   &model.BaseModel{Id: "d2975ebe-d82c-430f-b28e-f373746a71ca"},
+		Name:        "fileshare-updated",
+		Description: "The status can't be updated",
+	}
+	var expected = SampleFileShares[0]
+	expected.Name, expected.Description = update.Name, update.Description
+	mockClient := new(dbtest.Client)
+	mockClient.On("UpdateFileShare", c.NewAdminContext(), update).Return(&expected, nil)
+	db.C = mockClient
+
+	body := []byte(`{"name":"fileshare-updated","description":"The status can't be updated","status":"error","size":100}`)
+	r, _ := http.NewRequest("PUT", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.FileShareSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(&output, &expected) {
+		t.Errorf("Expected %v, actual %v", &expected, &output)
+	}
+}
+
+func TestDeleteFileShareWithBadRequest(t *testing.T) {
+	var share = SampleFileShares[0]
+	share.Status = model.FileShareCreating
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShare", c.NewAdminContext(), share.Id).Return(&share, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("DELETE", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
+func TestExtendFileShareWithBadRequest(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&SampleFileShares[0], nil)
+	db.C = mockClient
+
+	body := []byte(`{"newSize":1}`)
+	r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/resize", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
+func TestListFileShareAcls(t *testing.T) {
+	var sampleAcls = []*model.FileShareAclSpec{&SampleFileShareAcls[0]}
+	mockClient := new(dbtest.Client)
+	m := map[string][]string{
+		"fileShareId": {"d2975ebe-d82c-430f-b28e-f373746a71ca"},
+	}
+	mockClient.On("ListFileShareAclsWithFilter", c.NewAdminContext(), m).Return(sampleAcls, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/file/acls?fileShareId=d2975ebe-d82c-430f-b28e-f373746a71ca", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.FileShareAclSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(output, sampleAcls) {
+		t.Errorf("Expected %v, actual %v", sampleAcls, output)
+	}
+}
+
+func TestGetFileShareAcl(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShareAcl", c.NewAdminContext(), "6ad25d59-a160-45b2-8920-211be282e2df").Return(&SampleFileShareAcls[0], nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/file/acls/6ad25d59-a160-45b2-8920-211be282e2df", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.FileShareAclSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(&output, &SampleFileShareAcls[0]) {
+		t.Errorf("Expected %v, actual %v", &SampleFileShareAcls[0], &output)
+	}
+}
+
+func TestCreateFileShareAclWithBadRequest(t *testing.T) {
+	db.C = new(dbtest.Client)
+
+	body := []byte(`{"fileShareId":"d2975ebe-d82c-430f-b28e-f373746a71ca","type":"ip","accessTo":"192.168.56.0/33","accessLevel":"rw"}`)
+	r, _ := http.NewRequest("POST", "/v1beta/file/acls", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/opensds/opensds/pkg/api/policy"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/controller"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+type GroupSnapshotPortal struct {
+	BasePortal
+}
+
+func (v *GroupSnapshotPortal) CreateGroupSnapshot() {
+	if !policy.Authorize(v.Ctx, "group_snapshot:create") {
+		return
+	}
+
+	var gs = model.GroupSnapshotSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	// Unmarshal the request body
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&gs); err != nil {
+		v.ErrorHandle("Parse group snapshot request body failed", model.ErrorBadRequest, err)
+		return
+	}
+	// NOTE:It will create a group snapshot entry along with the entries of its member
+	// snapshots into the database and initialize their status as "creating". It will
+	// not wait for the real group snapshot creation to complete and will return result
+	// immediately.
+	result, err := CreateGroupSnapshotDBEntry(c.GetContext(v.Ctx), &gs)
+	if err != nil {
+		v.ErrorHandle("Create group snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal group snapshot created result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	// NOTE:The real group snapshot creation process.
+	// Group snapshot creation request is sent to the Dock. Dock will update the status of
+	// group snapshot and its member snapshots to "available" after creation complete.
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateGroupSnapshot, result.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, body)
+	return
+}
+
+func (v *GroupSnapshotPortal) ListGroupSnapshots() {
+	if !policy.Authorize(v.Ctx, "group_snapshot:get") {
+		return
+	}
+
+	m, err := v.GetParameters()
+	if err != nil {
+		v.ErrorHandle("List group snapshots failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	result, err := db.C.ListGroupSnapshotsWithFilter(c.GetContext(v.Ctx), m)
+	if err != nil {
+		v.ErrorHandle("List group snapshots failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal group snapshots listed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *GroupSnapshotPortal) GetGroupSnapshot() {
+	if !policy.Authorize(v.Ctx, "group_snapshot:get") {
+		return
+	}
+
+	result, err := db.C.GetGroupSnapshot(c.GetContext(v.Ctx), v.Ctx.Input.Param(":groupSnapshotId"))
+	if err != nil {
+		v.ErrorHandle("Get group snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal group snapshot showed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *GroupSnapshotPortal) DeleteGroupSnapshot() {
+	if !policy.Authorize(v.Ctx, "group_snapshot:delete") {
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	gs, err := db.C.GetGroupSnapshot(ctx, v.Ctx.Input.Param(":groupSnapshotId"))
+	if err != nil {
+		v.ErrorHandle("Get group snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// NOTE:It will update the the status of the group snapshot and its member snapshots
+	// waiting for deletion in the database to "deleting" and return the result immediately.
+	if err = DeleteGroupSnapshotDBEntry(ctx, gs); err != nil {
+		v.ErrorHandle("Delete group snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// NOTE:The real group snapshot deletion process.
+	// Group snapshot deletion request is sent to the Dock. Dock will delete the member
+	// snapshots from driver and database or update their status to "errorDeleting" if
+	// deletion from driver failed.
+	if _, err := controller.SubmitJob(ctx, model.JobDeleteGroupSnapshot, gs.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, nil)
+	return
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/astaxie/beego"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/block/groupSnapshots", &GroupSnapshotPortal{}, "post:CreateGroupSnapshot;get:ListGroupSnapshots")
+	beego.Router("/v1beta/block/groupSnapshots/:groupSnapshotId", &GroupSnapshotPortal{}, "get:GetGroupSnapshot;delete:DeleteGroupSnapshot")
+}
+
+func TestListGroupSnapshots(t *testing.T) {
+	var sampleGroupSnapshots = []*model.GroupSnapshotSpec{&SampleGroupSnapshots[0]}
+	mockClient := new(dbtest.Client)
+	m := map[string][]string{
+		"offset":  {"0"},
+		"limit":   {"1"},
+		"sortDir": {"asc"},
+		"sortKey": {"name"},
+	}
+	mockClient.On("ListGroupSnapshotsWithFilter", c.NewAdminContext(), m).Return(sampleGroupSnapshots, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/block/groupSnapshots?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.GroupSnapshotSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(output, sampleGroupSnapshots) {
+		t.Errorf("Expected %v, actual %v", sampleGroupSnapshots, output)
+	}
+}
+
+func TestGetGroupSnapshot(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetGroupSnapshot", c.NewAdminContext(), "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50").Return(&SampleGroupSnapshots[0], nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/block/groupSnapshots/8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.GroupSnapshotSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(&output, &SampleGroupSnapshots[0]) {
+		t.Errorf("Expected %v, actual %v", &SampleGroupSnapshots[0], &output)
+	}
+}
+
+func TestDeleteGroupSnapshotWithBadRequest(t *testing.T) {
+	var gs = SampleGroupSnapshots[0]
+	gs.Status = model.GroupSnapCreating
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetGroupSnapshot", c.NewAdminContext(), gs.Id).Return(&gs, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("DELETE", "/v1beta/block/groupSnapshots/8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS northbound service of jobs,
+which track the progress of the operations running in the background.
+
+*/
+
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/opensds/opensds/pkg/api/policy"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+type JobPortal struct {
+	BasePortal
+}
+
+func (v *JobPortal) ListJobs() {
+	if !policy.Authorize(v.Ctx, "job:list") {
+		return
+	}
+
+	m, err := v.GetParameters()
+	if err != nil {
+		v.ErrorHandle("List jobs failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	result, err := db.C.ListJobsWithFilter(c.GetContext(v.Ctx), m)
+	if err != nil {
+		v.ErrorHandle("List jobs failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal jobs listed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (v *JobPortal) GetJob() {
+	if !policy.Authorize(v.Ctx, "job:get") {
+		return
+	}
+
+	result, err := db.C.GetJob(c.GetContext(v.Ctx), v.Ctx.Input.Param(":jobId"))
+	if err != nil {
+		v.ErrorHandle("Get job failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		v.ErrorHandle("Marshal job showed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
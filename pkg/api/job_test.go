@@ -0,0 +1,94 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/astaxie/beego"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/:tenantId/jobs", &JobPortal{}, "get:ListJobs")
+	beego.Router("/v1beta/:tenantId/jobs/:jobId", &JobPortal{}, "get:GetJob")
+}
+
+func TestListJobs(t *testing.T) {
+	var sampleJobs = []*model.JobSpec{&SampleJobs[0]}
+	m := map[string][]string{
+		"status": {model.JobSucceeded},
+	}
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListJobsWithFilter", c.NewAdminContext(), m).Return(sampleJobs, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/admin/jobs?status=succeeded", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.JobSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(output, sampleJobs) {
+		t.Errorf("Expected %v, actual %v", sampleJobs, output)
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetJob", c.NewAdminContext(), SampleJobs[0].Id).Return(&SampleJobs[0], nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/admin/jobs/"+SampleJobs[0].Id, nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.JobSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(&output, &SampleJobs[0]) {
+		t.Errorf("Expected %v, actual %v", &SampleJobs[0], &output)
+	}
+}
+
+func TestGetJobWithBadRequest(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetJob", c.NewAdminContext(), "not-exist").Return(nil, errors.New("db error"))
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/admin/jobs/not-exist", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
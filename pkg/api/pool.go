@@ -75,7 +75,7 @@ func (p *PoolPortal) ListPools() {
 		return
 	}
 
-	result, err := db.C.ListPoolsWithFilter(c.GetContext(p.Ctx), m)
+	result, page, err := db.C.ListPoolsWithPage(c.GetContext(p.Ctx), m)
 	if err != nil {
 		reason := fmt.Sprintf("List pools failed: %s", err.Error())
 		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
@@ -94,6 +94,7 @@ func (p *PoolPortal) ListPools() {
 		return
 	}
 
+	p.setPageHeaders(page)
 	p.Ctx.Output.SetStatus(StatusOK)
 	p.Ctx.Output.Body(body)
 	return
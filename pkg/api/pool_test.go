@@ -27,6 +27,7 @@ import (
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
 	dbtest "github.com/opensds/opensds/testutils/db/testing"
 )
 
@@ -93,7 +94,7 @@ func TestListPools(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListPoolsWithFilter", c.NewAdminContext(), m).Return(fakePools, nil)
+	mockClient.On("ListPoolsWithPage", c.NewAdminContext(), m).Return(fakePools, &model.PageSpec{Next: "next", Total: 2}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/pools?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -142,6 +143,12 @@ func TestListPools(t *testing.T) {
 	if !reflect.DeepEqual(expected, output) {
 		t.Errorf("Expected %v, actual %v", expected, output)
 	}
+	if total := w.Header().Get(constants.TotalCountHeader); total != "2" {
+		t.Errorf("Expected total count 2, actual %v", total)
+	}
+	if next := w.Header().Get(constants.NextMarkerHeader); next != "next" {
+		t.Errorf("Expected next marker next, actual %v", next)
+	}
 }
 
 func TestListPoolsWithBadRequest(t *testing.T) {
@@ -153,7 +160,7 @@ func TestListPoolsWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListPoolsWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListPoolsWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/pools?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -28,6 +28,7 @@ import (
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/schedule"
 )
 
 type ProfilePortal struct {
@@ -52,6 +53,16 @@ func (p *ProfilePortal) CreateProfile() {
 		return
 	}
 
+	if sched := profile.SnapshotProperties.Schedule; sched.Occurrence != "" {
+		if _, err := schedule.Parse(sched.Occurrence, sched.Datetime); err != nil {
+			reason := fmt.Sprintf("Invalid snapshot schedule of profile: %v", err)
+			p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+			p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+			log.Error(reason)
+			return
+		}
+	}
+
 	// Call db api module to handle create profile request.
 	result, err := db.C.CreateProfile(c.GetContext(p.Ctx), &profile)
 	if err != nil {
@@ -91,7 +102,7 @@ func (p *ProfilePortal) ListProfiles() {
 		return
 	}
 
-	result, err := db.C.ListProfilesWithFilter(c.GetContext(p.Ctx), m)
+	result, page, err := db.C.ListProfilesWithPage(c.GetContext(p.Ctx), m)
 	if err != nil {
 		reason := fmt.Sprintf("List profiles failed: %v", err)
 		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
@@ -110,6 +121,7 @@ func (p *ProfilePortal) ListProfiles() {
 		return
 	}
 
+	p.setPageHeaders(page)
 	p.Ctx.Output.SetStatus(StatusOK)
 	p.Ctx.Output.Body(body)
 	return
@@ -162,15 +174,20 @@ func (p *ProfilePortal) UpdateProfile() {
 		return
 	}
 
-	result, err := db.C.UpdateProfile(c.GetContext(p.Ctx), id, &profile)
-	if err != nil {
-		reason := fmt.Sprintf("Update profiles failed: %v", err)
+	if err := p.parseIfMatch(profile.BaseModel); err != nil {
+		reason := fmt.Sprintf("Parse profile request header failed: %v", err)
 		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
 		p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
 		log.Error(reason)
 		return
 	}
 
+	result, err := db.C.UpdateProfile(c.GetContext(p.Ctx), id, &profile)
+	if err != nil {
+		p.updateFailed(fmt.Sprintf("Update profiles failed: %v", err), err)
+		return
+	}
+
 	// Marshal the result.
 	body, err := json.Marshal(result)
 	if err != nil {
@@ -184,7 +184,7 @@ func TestListProfiles(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListProfilesWithFilter", c.NewAdminContext(), m).Return(fakeProfiles, nil)
+	mockClient.On("ListProfilesWithPage", c.NewAdminContext(), m).Return(fakeProfiles, &model.PageSpec{Total: len(fakeProfiles)}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/profiles?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -236,7 +236,7 @@ func TestListProfilesWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListProfilesWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListProfilesWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/profiles?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -0,0 +1,122 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS northbound service.
+
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/pkg/api/policy"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+type QuotaPortal struct {
+	BasePortal
+}
+
+func (p *QuotaPortal) GetQuota() {
+	if !policy.Authorize(p.Ctx, "quota:get") {
+		return
+	}
+	tenantId := p.Ctx.Input.Param(":tenantId")
+
+	result, err := db.C.GetQuota(c.GetContext(p.Ctx), tenantId)
+	if err != nil {
+		reason := fmt.Sprintf("Get quota failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("Marshal quota got result failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		p.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	p.Ctx.Output.SetStatus(StatusOK)
+	p.Ctx.Output.Body(body)
+	return
+}
+
+func (p *QuotaPortal) UpdateQuota() {
+	if !policy.Authorize(p.Ctx, "quota:update") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	tenantId := p.Ctx.Input.Param(":tenantId")
+
+	// Limits which are not specified in the request body are kept as they are.
+	quota, err := db.C.GetQuota(ctx, tenantId)
+	if err != nil {
+		reason := fmt.Sprintf("Get quota failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(quota); err != nil {
+		reason := fmt.Sprintf("Parse quota request body failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+	quota.TenantId = tenantId
+	if err := quota.Validate(); err != nil {
+		reason := fmt.Sprintf("Update quota failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	result, err := db.C.UpdateQuota(ctx, quota)
+	if err != nil {
+		reason := fmt.Sprintf("Update quota failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		p.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("Marshal quota updated result failed: %v", err)
+		p.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		p.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	p.Ctx.Output.SetStatus(StatusOK)
+	p.Ctx.Output.Body(body)
+	return
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+func init() {
+	var quotaPortal QuotaPortal
+	beego.Router("/v1beta/:tenantId/quotas", &quotaPortal, "get:GetQuota;put:UpdateQuota")
+}
+
+var fakeQuota = &model.QuotaSpec{
+	BaseModel: &model.BaseModel{
+		Id:        "a7d8ec2e-4b4a-4c5f-9d39-5b8a2c6e7c1e",
+		CreatedAt: "2018-07-24T15:04:05",
+	},
+	TenantId:     "a7d8ec2e-4b4a-4c5f-9d39-5b8a2c6e7c1e",
+	Volumes:      10,
+	Gigabytes:    100,
+	Snapshots:    model.QuotaUnlimited,
+	Replications: model.QuotaUnlimited,
+	Usage: model.QuotaUsageSpec{
+		Volumes:   2,
+		Gigabytes: 3,
+	},
+}
+
+func TestGetQuota(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetQuota", c.NewAdminContext(), fakeQuota.TenantId).Return(fakeQuota, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/a7d8ec2e-4b4a-4c5f-9d39-5b8a2c6e7c1e/quotas", nil)
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.QuotaSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(fakeQuota, &output) {
+		t.Errorf("Expected %v, actual %v", fakeQuota, &output)
+	}
+}
+
+func TestUpdateQuota(t *testing.T) {
+	var current = *fakeQuota
+	var updated = *fakeQuota
+	updated.Gigabytes = 200
+	updated.Snapshots = 20
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetQuota", c.NewAdminContext(), fakeQuota.TenantId).Return(&current, nil)
+	mockClient.On("UpdateQuota", c.NewAdminContext(), &updated).Return(&updated, nil)
+	db.C = mockClient
+
+	var fakeBody = `{"gigabytes": 200, "snapshots": 20}`
+	r, _ := http.NewRequest("PUT", "/v1beta/a7d8ec2e-4b4a-4c5f-9d39-5b8a2c6e7c1e/quotas", strings.NewReader(fakeBody))
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.QuotaSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	if !reflect.DeepEqual(&updated, &output) {
+		t.Errorf("Expected %v, actual %v", &updated, &output)
+	}
+}
+
+func TestUpdateQuotaWithBadRequest(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetQuota", c.NewAdminContext(), fakeQuota.TenantId).Return(nil, errors.New("db error"))
+	db.C = mockClient
+
+	r, _ := http.NewRequest("PUT", "/v1beta/a7d8ec2e-4b4a-4c5f-9d39-5b8a2c6e7c1e/quotas", strings.NewReader(`{"volumes": 1}`))
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
+func TestUpdateQuotaWithNegativeLimit(t *testing.T) {
+	var current = *fakeQuota
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetQuota", c.NewAdminContext(), fakeQuota.TenantId).Return(&current, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("PUT", "/v1beta/a7d8ec2e-4b4a-4c5f-9d39-5b8a2c6e7c1e/quotas", strings.NewReader(`{"gigabytes": -2}`))
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+	mockClient.AssertNotCalled(t, "UpdateQuota", c.NewAdminContext(), &current)
+}
@@ -16,6 +16,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/opensds/opensds/pkg/api/policy"
@@ -101,9 +102,16 @@ func (r *ReplicationPortal) CreateReplication() {
 		return
 	}
 
+	var usage = &model.QuotaUsageSpec{Replications: 1}
+	if err := db.C.ReserveQuota(ctx, ctx.TenantId, usage); err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest,
+			"reserve quota of replication failed: %s", err.Error())
+		return
+	}
 	replication.ReplicationStatus = model.ReplicationCreating
 	replication, err = db.C.CreateReplication(ctx, replication)
 	if err != nil {
+		db.C.ReleaseQuota(ctx, ctx.TenantId, usage)
 		model.HttpError(r.Ctx, http.StatusInternalServerError,
 			"create replication in db failed")
 		return
@@ -141,7 +149,7 @@ func (r *ReplicationPortal) ListReplications() {
 		return
 	}
 
-	result, err := db.C.ListReplicationWithFilter(c.GetContext(r.Ctx), params)
+	result, page, err := db.C.ListReplicationWithPage(c.GetContext(r.Ctx), params)
 	if err != nil {
 		model.HttpError(r.Ctx, http.StatusBadRequest,
 			"list replications failed: %s", err.Error())
@@ -156,6 +164,7 @@ func (r *ReplicationPortal) ListReplications() {
 		return
 	}
 
+	r.setPageHeaders(page)
 	r.Ctx.Output.SetStatus(StatusOK)
 	r.Ctx.Output.Body(body)
 	return
@@ -175,7 +184,7 @@ func (r *ReplicationPortal) ListReplicationsDetail() {
 		return
 	}
 
-	result, err := db.C.ListReplicationWithFilter(c.GetContext(r.Ctx), params)
+	result, page, err := db.C.ListReplicationWithPage(c.GetContext(r.Ctx), params)
 	if err != nil {
 		model.HttpError(r.Ctx, http.StatusBadRequest,
 			"list replications detail failed: %s", err.Error())
@@ -190,6 +199,7 @@ func (r *ReplicationPortal) ListReplicationsDetail() {
 		return
 	}
 
+	r.setPageHeaders(page)
 	r.Ctx.Output.SetStatus(StatusOK)
 	r.Ctx.Output.Body(body)
 	return
@@ -235,6 +245,11 @@ func (r *ReplicationPortal) UpdateReplication() {
 			"parse replication request body failed: %s", err.Error())
 		return
 	}
+	if err := r.parseIfMatch(mr.BaseModel); err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest,
+			"parse replication request header failed: %s", err.Error())
+		return
+	}
 
 	if mr.ProfileId != "" {
 		_, err := db.C.GetProfile(c.GetContext(r.Ctx), mr.ProfileId)
@@ -248,8 +263,7 @@ func (r *ReplicationPortal) UpdateReplication() {
 
 	result, err := db.C.UpdateReplication(c.GetContext(r.Ctx), id, &mr)
 	if err != nil {
-		model.HttpError(r.Ctx, http.StatusBadRequest,
-			"update replication failed: %s", err.Error())
+		r.updateFailed(fmt.Sprintf("update replication failed: %s", err.Error()), err)
 		return
 	}
 
@@ -279,7 +293,7 @@ func (r *ReplicationPortal) DeleteReplication() {
 	}
 
 	if err := DeleteReplicationDBEntry(c.GetContext(r.Ctx), rep); err != nil {
-		model.HttpError(r.Ctx, http.StatusBadRequest, err.Error())
+		model.HttpError(r.Ctx, http.StatusBadRequest, "%s", err.Error())
 		return
 	}
 	// Call global controller variable to handle delete replication request.
@@ -293,6 +307,74 @@ func (r *ReplicationPortal) DeleteReplication() {
 	r.Ctx.Output.SetStatus(StatusAccepted)
 }
 
+// ForceDeleteReplication deletes the replication whatever status it is in,
+// which is used by admin to clean up the replication stuck in a transient
+// status.
+func (r *ReplicationPortal) ForceDeleteReplication() {
+	if !policy.Authorize(r.Ctx, "replication:force_delete") {
+		return
+	}
+
+	id := r.Ctx.Input.Param(":replicationId")
+	rep, err := db.C.GetReplication(c.GetContext(r.Ctx), id)
+	if err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest,
+			"get replication failed: %s", err.Error())
+		return
+	}
+
+	if err := ForceDeleteReplicationDBEntry(c.GetContext(r.Ctx), rep); err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+	err = controller.Brain.DeleteReplication(c.GetContext(r.Ctx), rep)
+	if err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest,
+			"delete replication failed: %v", err.Error())
+		return
+	}
+
+	r.Ctx.Output.SetStatus(StatusAccepted)
+}
+
+// ResetReplicationStatus sets the status of the replication in database
+// without touching the backend.
+func (r *ReplicationPortal) ResetReplicationStatus() {
+	if !policy.Authorize(r.Ctx, "replication:reset_status") {
+		return
+	}
+
+	var resetRequestBody = model.ResetStatusSpec{}
+	if err := json.NewDecoder(r.Ctx.Request.Body).Decode(&resetRequestBody); err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest,
+			"parse replication reset status request body failed: %s", err.Error())
+		return
+	}
+
+	id := r.Ctx.Input.Param(":replicationId")
+	rep, err := db.C.GetReplication(c.GetContext(r.Ctx), id)
+	if err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest,
+			"get replication failed: %s", err.Error())
+		return
+	}
+
+	if err := ResetReplicationStatusDBEntry(c.GetContext(r.Ctx), rep, resetRequestBody.Status); err != nil {
+		model.HttpError(r.Ctx, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	body, err := json.Marshal(rep)
+	if err != nil {
+		model.HttpError(r.Ctx, http.StatusInternalServerError,
+			"marshal replication reset status result failed: %s", err.Error())
+		return
+	}
+
+	r.Ctx.Output.SetStatus(StatusOK)
+	r.Ctx.Output.Body(body)
+}
+
 func (r *ReplicationPortal) EnableReplication() {
 	ctx := r.Ctx
 	if !policy.Authorize(ctx, "replication:enable") {
@@ -308,7 +390,7 @@ func (r *ReplicationPortal) EnableReplication() {
 	}
 
 	if err := EnableReplicationDBEntry(c.GetContext(ctx), rep); err != nil {
-		model.HttpError(ctx, http.StatusBadRequest, err.Error())
+		model.HttpError(ctx, http.StatusBadRequest, "%s", err.Error())
 		return
 	}
 	// Call global controller variable to handle delete replication request.
@@ -337,7 +419,7 @@ func (r *ReplicationPortal) DisableReplication() {
 	}
 
 	if err := DisableReplicationDBEntry(c.GetContext(ctx), rep); err != nil {
-		model.HttpError(ctx, http.StatusBadRequest, err.Error())
+		model.HttpError(ctx, http.StatusBadRequest, "%s", err.Error())
 		return
 	}
 	// Call global controller variable to handle delete r request.
@@ -373,7 +455,7 @@ func (r *ReplicationPortal) FailoverReplication() {
 	}
 
 	if err := FailoverReplicationDBEntry(c.GetContext(ctx), rep, failover.SecondaryBackendId); err != nil {
-		model.HttpError(ctx, http.StatusBadRequest, err.Error())
+		model.HttpError(ctx, http.StatusBadRequest, "%s", err.Error())
 		return
 	}
 
@@ -38,6 +38,8 @@ func init() {
 		"get:ListReplicationsDetail")
 	beego.Router("/v1beta/block/replications/:replicationId", NewReplicationPortal(),
 		"get:GetReplication;put:UpdateReplication;delete:DeleteReplication")
+	beego.Router("/v1beta/block/replications/:replicationId/reset-status", NewReplicationPortal(),
+		"post:ResetReplicationStatus")
 }
 
 var (
@@ -67,7 +69,7 @@ func TestListReplicationsDetail(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListReplicationWithFilter", c.NewAdminContext(), m).Return(fakeReplications, nil)
+	mockClient.On("ListReplicationWithPage", c.NewAdminContext(), m).Return(fakeReplications, &model.PageSpec{Total: len(fakeReplications)}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/replications/detail?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -114,7 +116,7 @@ func TestListReplications(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListReplicationWithFilter", c.NewAdminContext(), m).Return(fakeReplications, nil)
+	mockClient.On("ListReplicationWithPage", c.NewAdminContext(), m).Return(fakeReplications, &model.PageSpec{Total: len(fakeReplications)}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/replications?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -154,7 +156,7 @@ func TestListReplicationsWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListReplicationWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListReplicationWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/replications?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -320,3 +322,35 @@ func TestUpdateReplicationWithBadRequest(t *testing.T) {
 		t.Errorf("Expected 400, actual %v", w.Code)
 	}
 }
+
+func TestResetReplicationStatus(t *testing.T) {
+	var jsonStr = []byte(`{"status": "error_enabling"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/replications/c299a978-4f3e-11e8-8a5c-977218a83359/reset-status", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	rep := &model.ReplicationSpec{
+		BaseModel:         &model.BaseModel{Id: "c299a978-4f3e-11e8-8a5c-977218a83359"},
+		ReplicationStatus: model.ReplicationEnabling,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetReplication", c.NewAdminContext(), rep.Id).Return(rep, nil)
+	mockClient.On("UpdateReplication", c.NewAdminContext(), rep.Id, rep).Return(rep, nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.ReplicationSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+	if w.Code != StatusOK {
+		t.Errorf("Expected %v, actual %v", StatusOK, w.Code)
+	}
+	if output.ReplicationStatus != model.ReplicationErrorEnabling {
+		t.Errorf("Expected %s, actual %s", model.ReplicationErrorEnabling, output.ReplicationStatus)
+	}
+}
@@ -71,6 +71,10 @@ func Run(osdsletCfg cfg.OsdsLet) {
 			beego.NSRouter("/:tenantId/profiles/:profileId/customProperties", &ProfilePortal{}, "post:AddCustomProperty;get:ListCustomProperties"),
 			beego.NSRouter("/:tenantId/profiles/:profileId/customProperties/:customKey", &ProfilePortal{}, "delete:RemoveCustomProperty"),
 
+			// Policy is a kind of custom property of profile which is executed at the lifecycle points of volume.
+			// ListPolicies lists the policies supported by the system, which is used for both admin and users.
+			beego.NSRouter("/:tenantId/policies", &PolicyPortal{}, "get:ListPolicies"),
+
 			// Pool is the virtual description of backend storage, usually divided into block, file and object,
 			// and every pool is atomic, which means every pool contains a specific set of features.
 			// ListPools and GetPool are used for checking the status of backend pool, admin only
@@ -78,6 +82,20 @@ func Run(osdsletCfg cfg.OsdsLet) {
 			beego.NSRouter("/:tenantId/pools/:poolId", &PoolPortal{}, "get:GetPool"),
 			beego.NSRouter("/:tenantId/availabilityZones", &PoolPortal{}, "get:ListAvailabilityZones"),
 
+			// Quota limits the amount of resources which can be consumed by a tenant.
+			// GetQuota is used for both admin and users, UpdateQuota is used for admin only
+			beego.NSRouter("/:tenantId/quotas", &QuotaPortal{}, "get:GetQuota;put:UpdateQuota"),
+
+			// Event is a record of the status transition of a resource, which is made by the system.
+			// WatchEvents waits for the events after the specified one instead of polling the resources.
+			beego.NSRouter("/:tenantId/events", &EventPortal{}, "get:ListEvents"),
+			beego.NSRouter("/:tenantId/events/watch", &EventPortal{}, "get:WatchEvents"),
+
+			// Job tracks the progress of an operation running in the background, which is
+			// retried when it fails and resumed when the controller restarts in the middle of it.
+			beego.NSRouter("/:tenantId/jobs", &JobPortal{}, "get:ListJobs"),
+			beego.NSRouter("/:tenantId/jobs/:jobId", &JobPortal{}, "get:GetJob"),
+
 			beego.NSNamespace("/:tenantId/block",
 
 				// Volume is the logical description of a piece of storage, which can be directly used by users.
@@ -86,15 +104,28 @@ func Run(osdsletCfg cfg.OsdsLet) {
 				beego.NSRouter("/volumes/:volumeId", &VolumePortal{}, "get:GetVolume;put:UpdateVolume;delete:DeleteVolume"),
 				// Extend Volume
 				beego.NSRouter("/volumes/:volumeId/resize", &VolumePortal{}, "post:ExtendVolume"),
+				// Revert Volume to snapshot
+				beego.NSRouter("/volumes/:volumeId/revert", &VolumePortal{}, "post:RevertVolume"),
+				beego.NSRouter("/volumes/:volumeId/migrate", &VolumePortal{}, "post:MigrateVolume"),
+				beego.NSRouter("/volumes/:volumeId/retype", &VolumePortal{}, "post:RetypeVolume"),
+				// List the policies of volume specified in its profile.
+				beego.NSRouter("/volumes/:volumeId/policies", &VolumePortal{}, "get:ListVolumePolicies"),
+				// Reset the status of volume or delete it whatever status it is in, which are only allowed to admin.
+				beego.NSRouter("/volumes/:volumeId/reset-status", &VolumePortal{}, "post:ResetVolumeStatus"),
+				beego.NSRouter("/volumes/:volumeId/force-delete", &VolumePortal{}, "post:ForceDeleteVolume"),
 
 				// Creates, shows, lists, unpdates and deletes attachment.
 				beego.NSRouter("/attachments", &VolumeAttachmentPortal{}, "post:CreateVolumeAttachment;get:ListVolumeAttachments"),
 				beego.NSRouter("/attachments/:attachmentId", &VolumeAttachmentPortal{}, "get:GetVolumeAttachment;put:UpdateVolumeAttachment;delete:DeleteVolumeAttachment"),
+				beego.NSRouter("/attachments/:attachmentId/reset-status", &VolumeAttachmentPortal{}, "post:ResetVolumeAttachmentStatus"),
+				beego.NSRouter("/attachments/:attachmentId/force-delete", &VolumeAttachmentPortal{}, "post:ForceDeleteVolumeAttachment"),
 
 				// Snapshot is a point-in-time copy of the data that a volume contains.
 				// Creates, shows, lists, unpdates and deletes snapshot.
 				beego.NSRouter("/snapshots", &VolumeSnapshotPortal{}, "post:CreateVolumeSnapshot;get:ListVolumeSnapshots"),
 				beego.NSRouter("/snapshots/:snapshotId", &VolumeSnapshotPortal{}, "get:GetVolumeSnapshot;put:UpdateVolumeSnapshot;delete:DeleteVolumeSnapshot"),
+				beego.NSRouter("/snapshots/:snapshotId/reset-status", &VolumeSnapshotPortal{}, "post:ResetVolumeSnapshotStatus"),
+				beego.NSRouter("/snapshots/:snapshotId/force-delete", &VolumeSnapshotPortal{}, "post:ForceDeleteVolumeSnapshot"),
 
 				// Creates, shows, lists, unpdates and deletes replication.
 				beego.NSRouter("/replications", NewReplicationPortal(), "post:CreateReplication;get:ListReplications"),
@@ -103,9 +134,27 @@ func Run(osdsletCfg cfg.OsdsLet) {
 				beego.NSRouter("/replications/:replicationId/enable", NewReplicationPortal(), "post:EnableReplication"),
 				beego.NSRouter("/replications/:replicationId/disable", NewReplicationPortal(), "post:DisableReplication"),
 				beego.NSRouter("/replications/:replicationId/failover", NewReplicationPortal(), "post:FailoverReplication"),
+				beego.NSRouter("/replications/:replicationId/reset-status", NewReplicationPortal(), "post:ResetReplicationStatus"),
+				beego.NSRouter("/replications/:replicationId/force-delete", NewReplicationPortal(), "post:ForceDeleteReplication"),
 				// Volume group contains a list of volumes that are used in the same application.
 				beego.NSRouter("/volumeGroups", &VolumeGroupPortal{}, "post:CreateVolumeGroup;get:ListVolumeGroups"),
 				beego.NSRouter("/volumeGroups/:groupId", &VolumeGroupPortal{}, "put:UpdateVolumeGroup;get:GetVolumeGroup;delete:DeleteVolumeGroup"),
+				// Group snapshot takes the snapshots of all volumes in a group at the same point in time.
+				beego.NSRouter("/groupSnapshots", &GroupSnapshotPortal{}, "post:CreateGroupSnapshot;get:ListGroupSnapshots"),
+				beego.NSRouter("/groupSnapshots/:groupSnapshotId", &GroupSnapshotPortal{}, "get:GetGroupSnapshot;delete:DeleteGroupSnapshot"),
+			),
+
+			beego.NSNamespace("/:tenantId/file",
+
+				// File share is a file system exported to the clients through the file sharing protocol such as NFS.
+				// All operations of file share can be used for both admin and users.
+				beego.NSRouter("/shares", &FileSharePortal{}, "post:CreateFileShare;get:ListFileShares"),
+				beego.NSRouter("/shares/:fileshareId", &FileSharePortal{}, "get:GetFileShare;put:UpdateFileShare;delete:DeleteFileShare"),
+				beego.NSRouter("/shares/:fileshareId/resize", &FileSharePortal{}, "post:ExtendFileShare"),
+
+				// Access rule allows the clients matching it to mount the file share.
+				beego.NSRouter("/acls", &FileShareAclPortal{}, "post:CreateFileShareAcl;get:ListFileShareAcls"),
+				beego.NSRouter("/acls/:aclId", &FileShareAclPortal{}, "get:GetFileShareAcl;delete:DeleteFileShareAcl"),
 			),
 		)
 	pattern := fmt.Sprintf("/%s/*", constants.APIVersion)
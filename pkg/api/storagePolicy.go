@@ -0,0 +1,51 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS northbound service of the
+policies which can be specified in profiles.
+
+*/
+
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/opensds/opensds/pkg/api/policy"
+	storagepolicy "github.com/opensds/opensds/pkg/controller/policy"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+type PolicyPortal struct {
+	BasePortal
+}
+
+// ListPolicies lists the policies supported by the system, the admin can
+// specify them in the custom properties of profiles.
+func (p *PolicyPortal) ListPolicies() {
+	if !policy.Authorize(p.Ctx, "policy:list") {
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(storagepolicy.SupportedPolicies())
+	if err != nil {
+		p.ErrorHandle("Marshal policies listed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	p.SuccessHandle(StatusOK, body)
+	return
+}
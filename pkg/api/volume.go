@@ -22,11 +22,13 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	log "github.com/golang/glog"
 	"github.com/opensds/opensds/pkg/api/policy"
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/controller"
+	storagepolicy "github.com/opensds/opensds/pkg/controller/policy"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
 )
@@ -73,20 +75,19 @@ func (v *VolumePortal) CreateVolume() {
 		return
 	}
 
-	v.Ctx.Output.SetStatus(StatusAccepted)
-	v.Ctx.Output.Body(body)
-
 	// NOTE:The real volume creation process.
 	// CreateVolume request is sent to the Dock. Dock will update volume status to "available"
 	// after volume creation is completed.
-	var errchan = make(chan error, 1)
-	defer close(errchan)
-	go controller.Brain.CreateVolume(c.GetContext(v.Ctx), result, errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Marshal volume created result failed: %s", err.Error())
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateVolume, result.Id, nil); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
 		return
 	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
 	return
 }
 
@@ -104,7 +105,7 @@ func (v *VolumePortal) ListVolumes() {
 		return
 	}
 
-	result, err := db.C.ListVolumesWithFilter(c.GetContext(v.Ctx), m)
+	result, page, err := db.C.ListVolumesWithPage(c.GetContext(v.Ctx), m)
 	if err != nil {
 		reason := fmt.Sprintf("List volumes failed: %s", err.Error())
 		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
@@ -123,6 +124,7 @@ func (v *VolumePortal) ListVolumes() {
 		return
 	}
 
+	v.setPageHeaders(page)
 	v.Ctx.Output.SetStatus(StatusOK)
 	v.Ctx.Output.Body(body)
 	return
@@ -176,14 +178,19 @@ func (v *VolumePortal) UpdateVolume() {
 		return
 	}
 
+	if err := v.parseIfMatch(volume.BaseModel); err != nil {
+		reason := fmt.Sprintf("Parse volume request header failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
 	volume.Id = id
 	result, err := db.C.UpdateVolume(c.GetContext(v.Ctx), &volume)
 
 	if err != nil {
-		reason := fmt.Sprintf("Update volume failed: %s", err.Error())
-		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
-		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
-		log.Error(reason)
+		v.updateFailed(fmt.Sprintf("Update volume failed: %s", err.Error()), err)
 		return
 	}
 
@@ -199,7 +206,6 @@ func (v *VolumePortal) UpdateVolume() {
 
 	v.Ctx.Output.SetStatus(StatusOK)
 	v.Ctx.Output.Body(body)
-
 	return
 }
 
@@ -221,7 +227,7 @@ func (v *VolumePortal) ExtendVolume() {
 	id := v.Ctx.Input.Param(":volumeId")
 	// NOTE:It will update the the status of the volume waiting for expansion in
 	// the database to "extending" and return the result immediately.
-	result, err := ExtendVolumeDBEntry(c.GetContext(v.Ctx), id)
+	result, err := ExtendVolumeDBEntry(c.GetContext(v.Ctx), id, extendRequestBody.NewSize)
 	if err != nil {
 		reason := fmt.Sprintf("Extend volume failed: %s", err.Error())
 		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
@@ -240,20 +246,181 @@ func (v *VolumePortal) ExtendVolume() {
 		return
 	}
 
-	v.Ctx.Output.SetStatus(StatusAccepted)
-	v.Ctx.Output.Body(body)
-
 	// NOTE:The real volume extension process.
 	// Volume extension request is sent to the Dock. Dock will update volume status to "available"
 	// after volume extension is completed.
-	var errchan = make(chan error, 1)
-	defer close(errchan)
-	go controller.Brain.ExtendVolume(c.GetContext(v.Ctx), id, extendRequestBody.NewSize, errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Extend volume failed: %s", err.Error())
+	params := map[string]string{controller.JobNewSizeKey: strconv.FormatInt(extendRequestBody.NewSize, 10)}
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobExtendVolume, id, params); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
+	return
+}
+
+// RevertVolume ...
+func (v *VolumePortal) RevertVolume() {
+	if !policy.Authorize(v.Ctx, "volume:revert") {
+		return
+	}
+	var revertRequestBody = model.RevertVolumeSpec{}
+
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&revertRequestBody); err != nil {
+		reason := fmt.Sprintf("Parse volume request body failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	id := v.Ctx.Input.Param(":volumeId")
+	// NOTE:It will update the the status of the volume waiting for reverting in
+	// the database to "reverting" and return the result immediately.
+	result, err := RevertVolumeDBEntry(c.GetContext(v.Ctx), id, revertRequestBody.SnapshotId)
+	if err != nil {
+		reason := fmt.Sprintf("Revert volume failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("Marshal volume reverted result failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// NOTE:The real volume reverting process.
+	// Volume reverting request is sent to the Dock. Dock will update volume status to "available"
+	// after volume reverting is completed.
+	params := map[string]string{controller.JobSnapshotIdKey: revertRequestBody.SnapshotId}
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobRevertVolume, id, params); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
+	return
+}
+
+func (v *VolumePortal) MigrateVolume() {
+	if !policy.Authorize(v.Ctx, "volume:migrate") {
+		return
+	}
+	var migrateRequestBody = model.MigrateVolumeSpec{}
+
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&migrateRequestBody); err != nil {
+		reason := fmt.Sprintf("Parse volume request body failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	id := v.Ctx.Input.Param(":volumeId")
+	// NOTE:It will update the the status of the volume waiting for migration in
+	// the database to "migrating" and return the result immediately.
+	result, err := MigrateVolumeDBEntry(c.GetContext(v.Ctx), id, migrateRequestBody.PoolId)
+	if err != nil {
+		reason := fmt.Sprintf("Migrate volume failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("Marshal volume migrated result failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
 		return
 	}
+
+	// NOTE:The real volume migration process.
+	// The migration status and progress of volume will be updated during the
+	// migration, and volume status will be "available" after it is completed.
+	params := map[string]string{controller.JobPoolIdKey: migrateRequestBody.PoolId}
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobMigrateVolume, id, params); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
+	return
+}
+
+func (v *VolumePortal) RetypeVolume() {
+	if !policy.Authorize(v.Ctx, "volume:retype") {
+		return
+	}
+	var retypeRequestBody = model.RetypeVolumeSpec{}
+
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&retypeRequestBody); err != nil {
+		reason := fmt.Sprintf("Parse volume request body failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	id := v.Ctx.Input.Param(":volumeId")
+	// NOTE:It will update the the status of the volume waiting for retyping in
+	// the database to "retyping" and return the result immediately.
+	result, err := RetypeVolumeDBEntry(c.GetContext(v.Ctx), id, retypeRequestBody.ProfileId)
+	if err != nil {
+		reason := fmt.Sprintf("Retype volume failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("Marshal volume retyped result failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	// NOTE:The real volume retyping process.
+	// If the current pool doesn't satisfy the new profile, the volume will be
+	// migrated to another pool. Volume status will be "available" after
+	// retyping is completed.
+	params := map[string]string{controller.JobProfileIdKey: retypeRequestBody.ProfileId}
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobRetypeVolume, id, params); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
+		log.Error(reason)
+		return
+	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
 	return
 }
 
@@ -282,18 +449,107 @@ func (v *VolumePortal) DeleteVolume() {
 		log.Error(reason)
 		return
 	}
-	v.Ctx.Output.SetStatus(StatusAccepted)
 	// NOTE:The real volume deletion process.
 	// Volume deletion request is sent to the Dock. Dock will delete volume from driver
 	// and database or update volume status to "errorDeleting" if deletion from driver faild.
-	var errchan = make(chan error, 1)
-	go controller.Brain.DeleteVolume(c.GetContext(v.Ctx), volume, errchan)
-	defer close(errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Delete volume failed: %v", err.Error())
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobDeleteVolume, volume.Id, nil); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
 		return
 	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	return
+}
+
+// ResetVolumeStatus sets the status of the volume in database without touching
+// the backend, which is used by admin to recover the volume stuck in a
+// transient status.
+func (v *VolumePortal) ResetVolumeStatus() {
+	if !policy.Authorize(v.Ctx, "volume:reset_status") {
+		return
+	}
+	var resetRequestBody = model.ResetStatusSpec{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&resetRequestBody); err != nil {
+		v.ErrorHandle("Parse volume reset status request body failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	vol, err := db.C.GetVolume(ctx, v.Ctx.Input.Param(":volumeId"))
+	if err != nil {
+		v.ErrorHandle("Get volume failed", model.ErrorBadRequest, err)
+		return
+	}
+	if err := ResetVolumeStatusDBEntry(ctx, vol, resetRequestBody.Status); err != nil {
+		v.ErrorHandle("Reset volume status failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(vol)
+	if err != nil {
+		v.ErrorHandle("Marshal volume reset status result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+// ForceDeleteVolume deletes the volume whatever status it is in, which is
+// used by admin to clean up the volume stuck in a transient status.
+func (v *VolumePortal) ForceDeleteVolume() {
+	if !policy.Authorize(v.Ctx, "volume:force_delete") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	vol, err := db.C.GetVolume(ctx, v.Ctx.Input.Param(":volumeId"))
+	if err != nil {
+		v.ErrorHandle("Get volume failed", model.ErrorBadRequest, err)
+		return
+	}
+	if err := ForceDeleteVolumeDBEntry(ctx, vol); err != nil {
+		v.ErrorHandle("Force delete volume failed", model.ErrorBadRequest, err)
+		return
+	}
+	if _, err := controller.SubmitJob(ctx, model.JobDeleteVolume, vol.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, nil)
+	return
+}
+
+// ListVolumePolicies lists the policies specified in the profile of volume,
+// which are executed at the lifecycle points of the volume.
+func (v *VolumePortal) ListVolumePolicies() {
+	if !policy.Authorize(v.Ctx, "volume:get") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	vol, err := db.C.GetVolume(ctx, v.Ctx.Input.Param(":volumeId"))
+	if err != nil {
+		v.ErrorHandle("Get volume failed", model.ErrorBadRequest, err)
+		return
+	}
+	prf, err := db.C.GetProfile(ctx, vol.ProfileId)
+	if err != nil {
+		v.ErrorHandle("Get profile of volume failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(storagepolicy.ActivePolicies(prf))
+	if err != nil {
+		v.ErrorHandle("Marshal volume policies listed result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
 	return
 }
 
@@ -338,19 +594,19 @@ func (v *VolumeAttachmentPortal) CreateVolumeAttachment() {
 		return
 	}
 
-	v.Ctx.Output.SetStatus(StatusAccepted)
-	v.Ctx.Output.Body(body)
 	// NOTE:The real volume attachment creation process.
 	// Volume attachment creation request is sent to the Dock. Dock will update volume attachment status to "available"
 	// after volume attachment creation is completed.
-	errchan := make(chan error, 1)
-	defer close(errchan)
-	go controller.Brain.CreateVolumeAttachment(c.GetContext(v.Ctx), result, errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Create volume attachment failed: %s", err.Error())
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateAttachment, result.Id, nil); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
 		return
 	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
 	return
 }
 
@@ -438,14 +694,18 @@ func (v *VolumeAttachmentPortal) UpdateVolumeAttachment() {
 		log.Error(reason)
 		return
 	}
+	if err := v.parseIfMatch(attachment.BaseModel); err != nil {
+		reason := fmt.Sprintf("Parse volume attachment request header failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
 	attachment.Id = id
 
 	result, err := db.C.UpdateVolumeAttachment(c.GetContext(v.Ctx), id, &attachment)
 	if err != nil {
-		reason := fmt.Sprintf("Update volume attachment failed: %s", err.Error())
-		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
-		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
-		log.Error(reason)
+		v.updateFailed(fmt.Sprintf("Update volume attachment failed: %s", err.Error()), err)
 		return
 	}
 
@@ -477,21 +737,77 @@ func (v *VolumeAttachmentPortal) DeleteVolumeAttachment() {
 		log.Error(reason)
 		return
 	}
-	// NOTE:It will not wait for the real volume attachment deletion to complete
-	// and will return ok immediately.
-	v.Ctx.Output.SetStatus(StatusAccepted)
-
 	// NOTE:The real volume attachment deletion process.
 	// Volume attachment deletion request is sent to the Dock. Dock will delete volume attachment from database
 	// or update its status to "errorDeleting" if volume connection termination failed.
-	var errchan = make(chan error, 1)
-	go controller.Brain.DeleteVolumeAttachment(c.GetContext(v.Ctx), attachment, errchan)
-	defer close(errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Delete volume attachment failed: %v", err.Error())
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobDeleteAttachment, attachment.Id, nil); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
 		return
 	}
+
+	// NOTE:It will not wait for the real volume attachment deletion to complete
+	// and will return ok immediately.
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	return
+}
+
+// ResetVolumeAttachmentStatus sets the status of the volume attachment in
+// database without touching the backend.
+func (v *VolumeAttachmentPortal) ResetVolumeAttachmentStatus() {
+	if !policy.Authorize(v.Ctx, "volume:reset_attachment_status") {
+		return
+	}
+	var resetRequestBody = model.ResetStatusSpec{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&resetRequestBody); err != nil {
+		v.ErrorHandle("Parse volume attachment reset status request body failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	atc, err := db.C.GetVolumeAttachment(ctx, v.Ctx.Input.Param(":attachmentId"))
+	if err != nil {
+		v.ErrorHandle("Get volume attachment failed", model.ErrorBadRequest, err)
+		return
+	}
+	if err := ResetVolumeAttachmentStatusDBEntry(ctx, atc, resetRequestBody.Status); err != nil {
+		v.ErrorHandle("Reset volume attachment status failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(atc)
+	if err != nil {
+		v.ErrorHandle("Marshal volume attachment reset status result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+// ForceDeleteVolumeAttachment deletes the volume attachment from database
+// without terminating the connection on the backend, which is used by admin
+// when the connection is known to be gone.
+func (v *VolumeAttachmentPortal) ForceDeleteVolumeAttachment() {
+	if !policy.Authorize(v.Ctx, "volume:force_delete_attachment") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	atc, err := db.C.GetVolumeAttachment(ctx, v.Ctx.Input.Param(":attachmentId"))
+	if err != nil {
+		v.ErrorHandle("Get volume attachment failed", model.ErrorBadRequest, err)
+		return
+	}
+	log.Warningf("Force delete volume attachment %s in %s", atc.Id, atc.Status)
+	if err := db.C.DeleteVolumeAttachment(ctx, atc.Id); err != nil {
+		v.ErrorHandle("Force delete volume attachment failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, nil)
 	return
 }
 
@@ -536,18 +852,19 @@ func (v *VolumeSnapshotPortal) CreateVolumeSnapshot() {
 		return
 	}
 
-	v.Ctx.Output.SetStatus(StatusAccepted)
-	v.Ctx.Output.Body(body)
 	// NOTE:The real volume snapshot creation process.
 	// Volume snapshot creation request is sent to the Dock. Dock will update volume snapshot status to "available"
 	// after volume snapshot creation complete.
-	var errchan = make(chan error, 1)
-	defer close(errchan)
-	go controller.Brain.CreateVolumeSnapshot(c.GetContext(v.Ctx), &snapshot, errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Create volume snapshot failed: %s", err.Error())
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateSnapshot, result.Id, nil); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
+		return
 	}
+
+	v.Ctx.Output.SetStatus(StatusAccepted)
+	v.Ctx.Output.Body(body)
 	return
 }
 
@@ -564,7 +881,7 @@ func (v *VolumeSnapshotPortal) ListVolumeSnapshots() {
 		return
 	}
 
-	result, err := db.C.ListVolumeSnapshotsWithFilter(c.GetContext(v.Ctx), m)
+	result, page, err := db.C.ListVolumeSnapshotsWithPage(c.GetContext(v.Ctx), m)
 	if err != nil {
 		reason := fmt.Sprintf("List volume snapshots failed: %s", err.Error())
 		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
@@ -583,6 +900,7 @@ func (v *VolumeSnapshotPortal) ListVolumeSnapshots() {
 		return
 	}
 
+	v.setPageHeaders(page)
 	v.Ctx.Output.SetStatus(StatusOK)
 	v.Ctx.Output.Body(body)
 	return
@@ -635,14 +953,18 @@ func (v *VolumeSnapshotPortal) UpdateVolumeSnapshot() {
 		log.Error(reason)
 		return
 	}
+	if err := v.parseIfMatch(snapshot.BaseModel); err != nil {
+		reason := fmt.Sprintf("Parse volume snapshot request header failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
+		log.Error(reason)
+		return
+	}
 	snapshot.Id = id
 
 	result, err := db.C.UpdateVolumeSnapshot(c.GetContext(v.Ctx), id, &snapshot)
 	if err != nil {
-		reason := fmt.Sprintf("Update volume snapshot failed: %s", err.Error())
-		v.Ctx.Output.SetStatus(model.ErrorBadRequest)
-		v.Ctx.Output.Body(model.ErrorBadRequestStatus(reason))
-		log.Error(reason)
+		v.updateFailed(fmt.Sprintf("Update volume snapshot failed: %s", err.Error()), err)
 		return
 	}
 
@@ -690,14 +1012,73 @@ func (v *VolumeSnapshotPortal) DeleteVolumeSnapshot() {
 	// NOTE:The real volume snapshot deletion process.
 	// Volume snapshot deletion request is sent to the Dock. Dock will delete volume snapshot from driver and
 	// database or update its status to "errorDeleting" if volume snapshot deletion from driver failed.
-	var errchan = make(chan error, 1)
-	defer close(errchan)
-	go controller.Brain.DeleteVolumeSnapshot(c.GetContext(v.Ctx), snapshot, errchan)
-	if err := <-errchan; err != nil {
-		reason := fmt.Sprintf("Delete volume snapshot failed: %v", err.Error())
+	if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobDeleteSnapshot, snapshot.Id, nil); err != nil {
+		reason := fmt.Sprintf("Submit job failed: %s", err.Error())
+		v.Ctx.Output.SetStatus(model.ErrorInternalServer)
+		v.Ctx.Output.Body(model.ErrorInternalServerStatus(reason))
 		log.Error(reason)
+		return
 	}
-
 	v.Ctx.Output.SetStatus(StatusAccepted)
 	return
 }
+
+// ResetVolumeSnapshotStatus sets the status of the volume snapshot in database
+// without touching the backend.
+func (v *VolumeSnapshotPortal) ResetVolumeSnapshotStatus() {
+	if !policy.Authorize(v.Ctx, "snapshot:reset_status") {
+		return
+	}
+	var resetRequestBody = model.ResetStatusSpec{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&resetRequestBody); err != nil {
+		v.ErrorHandle("Parse volume snapshot reset status request body failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	ctx := c.GetContext(v.Ctx)
+	snap, err := db.C.GetVolumeSnapshot(ctx, v.Ctx.Input.Param(":snapshotId"))
+	if err != nil {
+		v.ErrorHandle("Get volume snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+	if err := ResetVolumeSnapshotStatusDBEntry(ctx, snap, resetRequestBody.Status); err != nil {
+		v.ErrorHandle("Reset volume snapshot status failed", model.ErrorBadRequest, err)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(snap)
+	if err != nil {
+		v.ErrorHandle("Marshal volume snapshot reset status result failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusOK, body)
+	return
+}
+
+// ForceDeleteVolumeSnapshot deletes the volume snapshot whatever status it is
+// in, which is used by admin to clean up the snapshot stuck in a transient
+// status.
+func (v *VolumeSnapshotPortal) ForceDeleteVolumeSnapshot() {
+	if !policy.Authorize(v.Ctx, "snapshot:force_delete") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	snap, err := db.C.GetVolumeSnapshot(ctx, v.Ctx.Input.Param(":snapshotId"))
+	if err != nil {
+		v.ErrorHandle("Get volume snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+	if err := ForceDeleteVolumeSnapshotDBEntry(ctx, snap); err != nil {
+		v.ErrorHandle("Force delete volume snapshot failed", model.ErrorBadRequest, err)
+		return
+	}
+	if _, err := controller.SubmitJob(ctx, model.JobDeleteSnapshot, snap.Id, nil); err != nil {
+		v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+		return
+	}
+
+	v.SuccessHandle(StatusAccepted, nil)
+	return
+}
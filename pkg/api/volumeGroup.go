@@ -17,9 +17,9 @@ package api
 import (
 	"encoding/json"
 
-	c "github.com/opensds/opensds/pkg/context"
-	//"github.com/opensds/opensds/pkg/controller"
 	"github.com/opensds/opensds/pkg/api/policy"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/controller"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
 )
@@ -58,6 +58,15 @@ func (v *VolumeGroupPortal) CreateVolumeGroup() {
 		return
 	}
 
+	// The group created from group snapshot is created in the background
+	// along with its volumes.
+	if result.GroupSnapshotId != "" {
+		if _, err := controller.SubmitJob(c.GetContext(v.Ctx), model.JobCreateVolumeGroup, result.Id, nil); err != nil {
+			v.ErrorHandle("Submit job failed", model.ErrorInternalServer, err)
+			return
+		}
+	}
+
 	v.SuccessHandle(StatusOK, body)
 	return
 }
@@ -142,7 +151,7 @@ func (v *VolumeGroupPortal) ListVolumeGroups() {
 		return
 	}
 
-	result, err := db.C.ListVolumeGroupsWithFilter(c.GetContext(v.Ctx), m)
+	result, page, err := db.C.ListVolumeGroupsWithPage(c.GetContext(v.Ctx), m)
 	if err != nil {
 		v.ErrorHandle("List volume groups failed", model.ErrorBadRequest, err)
 		return
@@ -155,6 +164,7 @@ func (v *VolumeGroupPortal) ListVolumeGroups() {
 		return
 	}
 
+	v.setPageHeaders(page)
 	v.SuccessHandle(StatusOK, body)
 	return
 }
@@ -59,7 +59,7 @@ func TestListVolumeGroups(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListVolumeGroupsWithFilter", c.NewAdminContext(), m).Return(fakeVolumeGroups, nil)
+	mockClient.On("ListVolumeGroupsWithPage", c.NewAdminContext(), m).Return(fakeVolumeGroups, &model.PageSpec{Total: len(fakeVolumeGroups)}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/volumeGroups?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -100,7 +100,7 @@ func TestListVolumeGroupsWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListVolumeGroupsWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListVolumeGroupsWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/volumeGroups?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
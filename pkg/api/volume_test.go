@@ -28,8 +28,10 @@ import (
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
 	. "github.com/opensds/opensds/testutils/collection"
 	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
 )
 
 func init() {
@@ -40,16 +42,32 @@ func init() {
 
 	beego.Router("/v1beta/block/volumes/:volumeId/resize", &VolumePortal{},
 		"post:ExtendVolume")
+	beego.Router("/v1beta/block/volumes/:volumeId/revert", &VolumePortal{},
+		"post:RevertVolume")
+	beego.Router("/v1beta/block/volumes/:volumeId/migrate", &VolumePortal{},
+		"post:MigrateVolume")
+	beego.Router("/v1beta/block/volumes/:volumeId/retype", &VolumePortal{},
+		"post:RetypeVolume")
+	beego.Router("/v1beta/block/volumes/:volumeId/policies", &VolumePortal{},
+		"get:ListVolumePolicies")
+	beego.Router("/v1beta/block/volumes/:volumeId/reset-status", &VolumePortal{},
+		"post:ResetVolumeStatus")
+	beego.Router("/v1beta/block/volumes/:volumeId/force-delete", &VolumePortal{},
+		"post:ForceDeleteVolume")
 
 	beego.Router("/v1beta/block/attachments", &VolumeAttachmentPortal{},
 		"post:CreateVolumeAttachment;get:ListVolumeAttachments")
 	beego.Router("/v1beta/block/attachments/:attachmentId", &VolumeAttachmentPortal{},
 		"get:GetVolumeAttachment;put:UpdateVolumeAttachment;delete:DeleteVolumeAttachment")
+	beego.Router("/v1beta/block/attachments/:attachmentId/force-delete", &VolumeAttachmentPortal{},
+		"post:ForceDeleteVolumeAttachment")
 
 	beego.Router("/v1beta/block/snapshots", &VolumeSnapshotPortal{},
 		"post:CreateVolumeSnapshot;get:ListVolumeSnapshots")
 	beego.Router("/v1beta/block/snapshots/:snapshotId", &VolumeSnapshotPortal{},
 		"get:GetVolumeSnapshot;put:UpdateVolumeSnapshot;delete:DeleteVolumeSnapshot")
+	beego.Router("/v1beta/block/snapshots/:snapshotId/reset-status", &VolumeSnapshotPortal{},
+		"post:ResetVolumeSnapshotStatus")
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -82,7 +100,7 @@ func TestListVolumes(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListVolumesWithFilter", c.NewAdminContext(), m).Return(fakeVolumes, nil)
+	mockClient.On("ListVolumesWithPage", c.NewAdminContext(), m).Return(fakeVolumes, &model.PageSpec{Next: "next", Total: 2}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/volumes?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -117,6 +135,12 @@ func TestListVolumes(t *testing.T) {
 	if !reflect.DeepEqual(expected, output) {
 		t.Errorf("Expected %v, actual %v", expected, output)
 	}
+	if total := w.Header().Get(constants.TotalCountHeader); total != "2" {
+		t.Errorf("Expected total count 2, actual %v", total)
+	}
+	if next := w.Header().Get(constants.NextMarkerHeader); next != "next" {
+		t.Errorf("Expected next marker next, actual %v", next)
+	}
 }
 
 func TestListVolumesWithBadRequest(t *testing.T) {
@@ -128,7 +152,7 @@ func TestListVolumesWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListVolumesWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListVolumesWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/volumes?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -183,6 +207,38 @@ func TestGetVolume(t *testing.T) {
 	}
 }
 
+func TestListVolumePolicies(t *testing.T) {
+	var prf = &model.ProfileSpec{
+		BaseModel: &model.BaseModel{Id: fakeVolume.ProfileId},
+		CustomProperties: model.CustomPropertiesSpec{
+			"intervalSnapshot": "12h",
+			"key1":             "value1",
+		},
+	}
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), fakeVolume.Id).Return(fakeVolume, nil)
+	mockClient.On("GetProfile", c.NewAdminContext(), fakeVolume.ProfileId).Return(prf, nil)
+	db.C = mockClient
+
+	r, _ := http.NewRequest("GET", "/v1beta/block/volumes/f4a5e666-c669-4c64-a2a1-8f9ecd560c78/policies", nil)
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output []*model.PolicySpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+
+	if w.Code != 200 {
+		t.Errorf("Expected 200, actual %v", w.Code)
+	}
+	// The custom properties which are not policies are left out.
+	if len(output) != 1 || output[0].Name != "intervalSnapshot" || output[0].Value != "12h" {
+		t.Errorf("Unexpected volume policies %v", output)
+	}
+}
+
 func TestGetVolumeWithBadRequest(t *testing.T) {
 
 	mockClient := new(dbtest.Client)
@@ -249,6 +305,46 @@ func TestUpdateVolume(t *testing.T) {
 	}
 }
 
+func TestUpdateVolumeWithConflict(t *testing.T) {
+	var jsonStr = []byte(`{"name":"fake Vol","description":"fake Vol"}`)
+	r, _ := http.NewRequest("PUT",
+		"/v1beta/block/volumes/f4a5e666-c669-4c64-a2a1-8f9ecd560c78", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+	r.Header.Set("If-Match", `"5"`)
+
+	var volume = model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	json.NewDecoder(bytes.NewBuffer(jsonStr)).Decode(&volume)
+	volume.Id = "f4a5e666-c669-4c64-a2a1-8f9ecd560c78"
+	volume.ResourceVersion = 5
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("UpdateVolume", c.NewAdminContext(), &volume).
+		Return(nil, model.NewConflictError("The resource has been modified by others!"))
+	db.C = mockClient
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 409 {
+		t.Errorf("Expected 409, actual %v", w.Code)
+	}
+
+	r, _ = http.NewRequest("PUT",
+		"/v1beta/block/volumes/f4a5e666-c669-4c64-a2a1-8f9ecd560c78", bytes.NewBuffer(jsonStr))
+	w = httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+	r.Header.Set("If-Match", "invalid")
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
 func TestUpdateVolumeWithBadRequest(t *testing.T) {
 	var jsonStr = []byte(``)
 	r, _ := http.NewRequest("PUT",
@@ -318,7 +414,7 @@ func TestListVolumeSnapshots(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListVolumeSnapshotsWithFilter", c.NewAdminContext(), m).Return(fakeSnapshots, nil)
+	mockClient.On("ListVolumeSnapshotsWithPage", c.NewAdminContext(), m).Return(fakeSnapshots, &model.PageSpec{Total: 1}, nil)
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/snapshots?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -351,6 +447,9 @@ func TestListVolumeSnapshots(t *testing.T) {
 	if !reflect.DeepEqual(expected, output) {
 		t.Errorf("Expected %v, actual %v", expected, output)
 	}
+	if next := w.Header().Get(constants.NextMarkerHeader); next != "" {
+		t.Errorf("Expected no next marker, actual %v", next)
+	}
 }
 
 func TestListVolumeSnapshotsWithBadRequest(t *testing.T) {
@@ -362,7 +461,7 @@ func TestListVolumeSnapshotsWithBadRequest(t *testing.T) {
 		"sortDir": {"asc"},
 		"sortKey": {"name"},
 	}
-	mockClient.On("ListVolumeSnapshotsWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+	mockClient.On("ListVolumeSnapshotsWithPage", c.NewAdminContext(), m).Return(nil, nil, errors.New("db error"))
 	db.C = mockClient
 
 	r, _ := http.NewRequest("GET", "/v1beta/block/snapshots?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
@@ -755,6 +854,43 @@ func TestExtendVolume(t *testing.T) {
 	mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(volume, nil)
 	mockClient.On("UpdateVolume", c.NewAdminContext(), volume).Return(volume, nil)
 	mockClient.On("GetPool", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SamplePools[0], nil)
+	mockClient.On("CreateJob", c.NewAdminContext(), mock.Anything).Return(&SampleJobs[0], nil)
+
+	db.C = mockClient
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != StatusAccepted {
+		t.Errorf("Expected %v, actual %v", StatusAccepted, w.Code)
+	}
+}
+
+func TestExtendVolumeWithQuota(t *testing.T) {
+	var jsonStr = []byte(`{"newSize": 200}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/resize", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	volume := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Status:    "available",
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:      1,
+	}
+
+	// The gigabytes grown by the extension are reserved from the quota of
+	// tenant.
+	mockClient := new(dbtest.Client)
+	mockClient.On("ExtendVolume", c.NewAdminContext(), volume).Return(volume, nil)
+	mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(volume, nil)
+	mockClient.On("UpdateVolume", c.NewAdminContext(), volume).Return(volume, nil)
+	mockClient.On("GetPool", c.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").Return(&SamplePools[0], nil)
+	mockClient.On("ReserveQuota", c.NewAdminContext(), "", &model.QuotaUsageSpec{Gigabytes: 199}).Return(nil)
+	mockClient.On("ReleaseQuota", c.NewAdminContext(), "", &model.QuotaUsageSpec{Gigabytes: 199}).Return(nil)
+	mockClient.On("CreateJob", c.NewAdminContext(), mock.Anything).Return(&SampleJobs[0], nil)
 
 	db.C = mockClient
 	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
@@ -805,3 +941,238 @@ func TestExtendVolumeWithBadRequest(t *testing.T) {
 		t.Errorf("Expected 400, actual %v", w.Code)
 	}
 }
+
+func TestRevertVolumeWithBadRequest(t *testing.T) {
+	var jsonStr = []byte(`{"snapshotId": "3769855c-a102-11e7-b772-17b880d2f537"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/revert", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	volumeExtending := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Status:    model.VolumeExtending,
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:      1,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(volumeExtending, nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
+func TestMigrateVolumeWithBadRequest(t *testing.T) {
+	var jsonStr = []byte(`{"poolId": "a594b8ac-a103-11e7-985f-d723bcf01b5f"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/migrate", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	volumeInUse := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Status:    model.VolumeInUse,
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:      1,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(volumeInUse, nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
+func TestRetypeVolumeWithBadRequest(t *testing.T) {
+	var jsonStr = []byte(`{"profileId": "2f9c0a04-66ef-11e7-ade2-43158893e017"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/retype", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	volumeInUse := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Status:    model.VolumeInUse,
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:      1,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(volumeInUse, nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+}
+
+func TestResetVolumeStatus(t *testing.T) {
+	var jsonStr = []byte(`{"status": "error"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/reset-status", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	volume := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Status:    model.VolumeExtending,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), volume.Id).Return(volume, nil)
+	mockClient.On("UpdateStatus", c.NewAdminContext(), volume, model.VolumeError).Return(
+		func(ctx *c.Context, in interface{}, status string) error {
+			in.(*model.VolumeSpec).Status = status
+			return nil
+		})
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var output model.VolumeSpec
+	json.Unmarshal(w.Body.Bytes(), &output)
+	if w.Code != StatusOK {
+		t.Errorf("Expected %v, actual %v", StatusOK, w.Code)
+	}
+	if output.Status != model.VolumeError {
+		t.Errorf("Expected %s, actual %s", model.VolumeError, output.Status)
+	}
+}
+
+func TestResetVolumeStatusWithBadRequest(t *testing.T) {
+	// Volume can't be reset to a transient status.
+	var jsonStr = []byte(`{"status": "creating"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/reset-status", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	volume := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Status:    model.VolumeError,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), volume.Id).Return(volume, nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != 400 {
+		t.Errorf("Expected 400, actual %v", w.Code)
+	}
+	mockClient.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestForceDeleteVolume(t *testing.T) {
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/force-delete", nil)
+	w := httptest.NewRecorder()
+
+	volume := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Status:    model.VolumeExtending,
+		ProfileId: "1106b972-66ef-11e7-b172-db03f3689c9c",
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", c.NewAdminContext(), volume.Id).Return(volume, nil)
+	mockClient.On("ListSnapshotsByVolumeId", c.NewAdminContext(), volume.Id).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", c.NewAdminContext(), volume.Id).Return(nil, nil)
+	mockClient.On("UpdateVolume", c.NewAdminContext(), volume).Return(volume, nil)
+	mockClient.On("CreateJob", c.NewAdminContext(), mock.Anything).Return(&SampleJobs[0], nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != StatusAccepted {
+		t.Errorf("Expected %v, actual %v", StatusAccepted, w.Code)
+	}
+	if volume.Status != model.VolumeDeleting {
+		t.Errorf("Expected %s, actual %s", model.VolumeDeleting, volume.Status)
+	}
+}
+
+func TestResetVolumeSnapshotStatus(t *testing.T) {
+	var jsonStr = []byte(`{"status": "available"}`)
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/snapshots/3769855c-a102-11e7-b772-17b880d2f537/reset-status", bytes.NewBuffer(jsonStr))
+	w := httptest.NewRecorder()
+	r.Header.Set("Content-Type", "application/JSON")
+
+	snapshot := &model.VolumeSnapshotSpec{
+		BaseModel: &model.BaseModel{Id: "3769855c-a102-11e7-b772-17b880d2f537"},
+		Status:    model.VolumeSnapCreating,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolumeSnapshot", c.NewAdminContext(), snapshot.Id).Return(snapshot, nil)
+	mockClient.On("UpdateStatus", c.NewAdminContext(), snapshot, model.VolumeSnapAvailable).Return(nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != StatusOK {
+		t.Errorf("Expected %v, actual %v", StatusOK, w.Code)
+	}
+	mockClient.AssertCalled(t, "UpdateStatus", c.NewAdminContext(), snapshot, model.VolumeSnapAvailable)
+}
+
+func TestForceDeleteVolumeAttachment(t *testing.T) {
+	r, _ := http.NewRequest("POST",
+		"/v1beta/block/attachments/f2dda3d2-bf79-11e7-8665-f750b088f63e/force-delete", nil)
+	w := httptest.NewRecorder()
+
+	attachment := &model.VolumeAttachmentSpec{
+		BaseModel: &model.BaseModel{Id: "f2dda3d2-bf79-11e7-8665-f750b088f63e"},
+		Status:    model.VolumeAttachCreating,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolumeAttachment", c.NewAdminContext(), attachment.Id).Return(attachment, nil)
+	mockClient.On("DeleteVolumeAttachment", c.NewAdminContext(), attachment.Id).Return(nil)
+	db.C = mockClient
+
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	if w.Code != StatusAccepted {
+		t.Errorf("Expected %v, actual %v", StatusAccepted, w.Code)
+	}
+	mockClient.AssertCalled(t, "DeleteVolumeAttachment", c.NewAdminContext(), attachment.Id)
+}
@@ -19,17 +19,18 @@ This module implements a entry into the OpenSDS northbound service.
 package controller
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	log "github.com/golang/glog"
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/controller/dr"
+	"github.com/opensds/opensds/pkg/controller/fileshare"
 	"github.com/opensds/opensds/pkg/controller/policy"
 	"github.com/opensds/opensds/pkg/controller/selector"
 	"github.com/opensds/opensds/pkg/controller/volume"
 	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/dock/client"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
 	"github.com/opensds/opensds/pkg/utils"
@@ -46,19 +47,23 @@ const (
 var Brain *Controller
 
 func NewController() *Controller {
-	volCtrl := volume.NewController()
+	// The dock clients are shared by all the requests, the dock of each
+	// request is passed to the volume and file share controllers explicitly.
+	pool := client.NewPool(client.NewClient)
+	volCtrl := volume.NewController(pool)
 	return &Controller{
-		selector:         selector.NewSelector(),
-		volumeController: volCtrl,
-		drController:     dr.NewController(volCtrl),
+		selector:            selector.NewSelector(),
+		volumeController:    volCtrl,
+		fileShareController: fileshare.NewController(pool),
+		drController:        dr.NewController(volCtrl),
 	}
 }
 
 type Controller struct {
-	selector         selector.Selector
-	volumeController volume.Controller
-	drController     dr.Controller
-	policyController policy.Controller
+	selector            selector.Selector
+	volumeController    volume.Controller
+	fileShareController fileshare.Controller
+	drController        dr.Controller
 }
 
 func (c *Controller) CreateVolume(ctx *c.Context, in *model.VolumeSpec, errchanVolume chan error) {
@@ -67,6 +72,7 @@ func (c *Controller) CreateVolume(ctx *c.Context, in *model.VolumeSpec, errchanV
 	var snap *model.VolumeSnapshotSpec
 	var snapVol *model.VolumeSpec
 	var snapSize int64
+	var srcVol = &model.VolumeSpec{}
 
 	if in.ProfileId == "" {
 		log.Warning("Use default profile when user doesn't specify profile.")
@@ -108,8 +114,22 @@ func (c *Controller) CreateVolume(ctx *c.Context, in *model.VolumeSpec, errchanV
 		in.PoolId = snapVol.PoolId
 		in.Metadata = utils.MergeStringMaps(in.Metadata, snap.Metadata)
 	}
+	if in.SourceVolumeId != "" {
+		srcVol, err = db.C.GetVolume(ctx, in.SourceVolumeId)
+		if err != nil {
+			log.Error("Get source volume failed in create volume method: ", err)
+			if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeError); errUpdate != nil {
+				errchanVolume <- errUpdate
+				return
+			}
+			errchanVolume <- err
+			return
+		}
+		// The cloned volume must be located in the same pool with its source.
+		in.PoolId = srcVol.PoolId
+	}
 
-	polInfo, err := c.selector.SelectSupportedPoolForVolume(in)
+	sel, err := c.selector.SelectPoolForVolume(in)
 	if err != nil {
 		if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeError); errUpdate != nil {
 			errchanVolume <- errUpdate
@@ -118,6 +138,7 @@ func (c *Controller) CreateVolume(ctx *c.Context, in *model.VolumeSpec, errchanV
 		errchanVolume <- err
 		return
 	}
+	polInfo := sel.Pool
 
 	dockInfo, err := db.C.GetDock(ctx, polInfo.DockId)
 	if err != nil {
@@ -129,25 +150,47 @@ func (c *Controller) CreateVolume(ctx *c.Context, in *model.VolumeSpec, errchanV
 		errchanVolume <- err
 		return
 	}
-	c.volumeController.SetDock(dockInfo)
+	// The pool is recorded before the volume is created in the backend, so
+	// that the volume can be pulled from the dock if the controller restarts
+	// in the middle of the creation. The summary of selection is kept along
+	// with it for debugging.
+	if _, err = db.C.UpdateVolume(ctx, &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: in.Id},
+		PoolId:    polInfo.Id,
+		Metadata:  utils.MergeStringMaps(in.Metadata, map[string]string{selector.SelectionKey: sel.Summary()}),
+	}); err != nil {
+		log.Error("When record the pool of volume:", err)
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeError); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+	in.PoolId = polInfo.Id
+
 	opt := &pb.CreateVolumeOpts{
-		Id:                in.Id,
-		Name:              in.Name,
-		Description:       in.Description,
-		Size:              in.Size,
-		AvailabilityZone:  in.AvailabilityZone,
-		PoolId:            polInfo.Id,
-		ProfileId:         prf.Id,
-		SnapshotId:        in.SnapshotId,
-		SnapshotSize:      snapSize,
-		PoolName:          polInfo.Name,
-		DriverName:        dockInfo.DriverName,
-		Context:           ctx.ToJson(),
-		Metadata:          in.Metadata,
-		SnapshotFromCloud: in.SnapshotFromCloud,
-	}
-
-	result, err := c.volumeController.CreateVolume(opt)
+		Id:                   in.Id,
+		Name:                 in.Name,
+		Description:          in.Description,
+		Size:                 in.Size,
+		AvailabilityZone:     in.AvailabilityZone,
+		PoolId:               polInfo.Id,
+		ProfileId:            prf.Id,
+		SnapshotId:           in.SnapshotId,
+		SnapshotSize:         snapSize,
+		PoolName:             polInfo.Name,
+		DriverName:           dockInfo.DriverName,
+		Context:              ctx.ToJson(),
+		Metadata:             in.Metadata,
+		SnapshotFromCloud:    in.SnapshotFromCloud,
+		SourceVolumeId:       in.SourceVolumeId,
+		SourceVolumeSize:     srcVol.Size,
+		SourceVolumeMetadata: srcVol.Metadata,
+		Qos:                  newQos(profileQos(prf)),
+	}
+
+	result, err := c.volumeController.CreateVolume(dockInfo, opt)
 	if err != nil {
 		//Change the status of the volume to error when the creation faild
 		if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeError); errUpdate != nil {
@@ -165,20 +208,32 @@ func (c *Controller) CreateVolume(ctx *c.Context, in *model.VolumeSpec, errchanV
 		errchanVolume <- err
 		return
 	}
+	if err = createSnapshotSchedule(ctx, prf, in); err != nil {
+		log.Error("When create snapshot schedule of volume:", err)
+	}
 
 	// Select the storage tag according to the lifecycle flag.
-	c.policyController = policy.NewController(prf)
-	c.policyController.Setup(CREATE_LIFECIRCLE_FLAG)
-	c.policyController.SetDock(dockInfo)
+	pc := policy.NewController(prf)
+	pc.Setup(CREATE_LIFECIRCLE_FLAG)
+	pc.SetDock(dockInfo)
 
 	var errChanPolicy = make(chan error, 1)
 	defer close(errChanPolicy)
-	volBody, _ := json.Marshal(result)
-	go c.policyController.ExecuteAsyncPolicy(opt, string(volBody), errChanPolicy)
+	in.PoolId, in.ProfileId = result.PoolId, result.ProfileId
+	go pc.ExecuteAsyncPolicy(ctx, in, errChanPolicy)
+	// The volume is available even if its policies fail to be applied, so the
+	// failure is recorded in the policy status of volume instead of failing
+	// the creation.
+	in.PolicyStatus = model.VolumePolicyApplied
 	if err := <-errChanPolicy; err != nil {
 		log.Error("When execute async policy:", err)
-		errchanVolume <- err
-		return
+		in.PolicyStatus = model.VolumePolicyError
+	}
+	if _, err = db.C.UpdateVolume(ctx, &model.VolumeSpec{
+		BaseModel:    &model.BaseModel{Id: in.Id},
+		PolicyStatus: in.PolicyStatus,
+	}); err != nil {
+		log.Error("When record the policy status of volume:", err)
 	}
 	errchanVolume <- nil
 }
@@ -197,8 +252,8 @@ func (c *Controller) DeleteVolume(ctx *c.Context, in *model.VolumeSpec, errchanv
 	}
 
 	// Select the storage tag according to the lifecycle flag.
-	c.policyController = policy.NewController(prf)
-	c.policyController.Setup(DELETE_LIFECIRCLE_FLAG)
+	pc := policy.NewController(prf)
+	pc.Setup(DELETE_LIFECIRCLE_FLAG)
 
 	dockInfo, err := db.C.GetDockByPoolId(ctx, in.PoolId)
 	if err != nil {
@@ -211,8 +266,7 @@ func (c *Controller) DeleteVolume(ctx *c.Context, in *model.VolumeSpec, errchanv
 		errchanvol <- err
 		return
 	}
-	c.policyController.SetDock(dockInfo)
-	c.volumeController.SetDock(dockInfo)
+	pc.SetDock(dockInfo)
 
 	opt := &pb.DeleteVolumeOpts{
 		Id:         in.Id,
@@ -223,7 +277,7 @@ func (c *Controller) DeleteVolume(ctx *c.Context, in *model.VolumeSpec, errchanv
 
 	var errChan = make(chan error, 1)
 	defer close(errChan)
-	go c.policyController.ExecuteAsyncPolicy(opt, "", errChan)
+	go pc.ExecuteAsyncPolicy(ctx, in, errChan)
 
 	if err := <-errChan; err != nil {
 		log.Error("When execute async policy:", err)
@@ -236,7 +290,7 @@ func (c *Controller) DeleteVolume(ctx *c.Context, in *model.VolumeSpec, errchanv
 		return
 	}
 
-	err = c.volumeController.DeleteVolume(opt)
+	err = c.volumeController.DeleteVolume(dockInfo, opt)
 	if err != nil {
 		if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeErrorDeleting); errUpdate != nil {
 			errchanvol <- errUpdate
@@ -250,6 +304,12 @@ func (c *Controller) DeleteVolume(ctx *c.Context, in *model.VolumeSpec, errchanv
 		errchanvol <- err
 		return
 	}
+	if err = deleteSnapshotSchedules(ctx, in.Id); err != nil {
+		log.Warning("Delete snapshot schedules of volume failed:", err)
+	}
+	if err = db.C.ReleaseQuota(ctx, in.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: in.Size}); err != nil {
+		log.Warning("Release quota of volume failed:", err)
+	}
 	errchanvol <- nil
 }
 
@@ -267,9 +327,13 @@ func (c *Controller) ExtendVolume(ctx *c.Context, volID string, newSize int64, e
 	defer func() {
 		if rollBack {
 			vol.Status = model.VolumeAvailable
-			if errUpdate, _ := db.C.UpdateVolume(ctx, vol); errUpdate != nil {
+			if _, errUpdate := db.C.UpdateVolume(ctx, vol); errUpdate != nil {
 				log.Errorf("update volume failed: %v", errUpdate)
 			}
+			// Release the gigabytes reserved when the request is accepted.
+			if newSize > vol.Size {
+				db.C.ReleaseQuota(ctx, vol.TenantId, &model.QuotaUsageSpec{Gigabytes: newSize - vol.Size})
+			}
 		}
 	}()
 
@@ -307,8 +371,8 @@ func (c *Controller) ExtendVolume(ctx *c.Context, volID string, newSize int64, e
 	}
 
 	// Select the storage tag according to the lifecycle flag.
-	c.policyController = policy.NewController(prf)
-	c.policyController.Setup(EXTEND_LIFECIRCLE_FLAG)
+	pc := policy.NewController(prf)
+	pc.Setup(EXTEND_LIFECIRCLE_FLAG)
 
 	dockInfo, err := db.C.GetDockByPoolId(ctx, vol.PoolId)
 	if err != nil {
@@ -318,8 +382,7 @@ func (c *Controller) ExtendVolume(ctx *c.Context, volID string, newSize int64, e
 		return
 
 	}
-	c.policyController.SetDock(dockInfo)
-	c.volumeController.SetDock(dockInfo)
+	pc.SetDock(dockInfo)
 
 	opt := &pb.ExtendVolumeOpts{
 		Id:         vol.Id,
@@ -331,8 +394,7 @@ func (c *Controller) ExtendVolume(ctx *c.Context, volID string, newSize int64, e
 		Context:    ctx.ToJson(),
 	}
 
-	result, err := c.volumeController.ExtendVolume(opt)
-	if err != nil {
+	if _, err = c.volumeController.ExtendVolume(dockInfo, opt); err != nil {
 		log.Error("extend volume failed: ", err.Error())
 		errchanVolume <- err
 		rollBack = true
@@ -342,17 +404,15 @@ func (c *Controller) ExtendVolume(ctx *c.Context, volID string, newSize int64, e
 	// Update the volume data in database.
 	vol.Size = newSize
 	vol.Status = model.VolumeAvailable
-	if errUpdate, _ := db.C.UpdateVolume(ctx, vol); errUpdate != nil {
+	if _, errUpdate := db.C.UpdateVolume(ctx, vol); errUpdate != nil {
 		log.Errorf("update volume failed: %v", errUpdate)
-		errchanVolume <- err
+		errchanVolume <- errUpdate
 		return
 	}
 
-	result.PoolId, result.ProfileId = opt.GetPoolId(), opt.GetProfileId()
-	volBody, _ := json.Marshal(result)
 	var errChan = make(chan error, 1)
 	defer close(errChan)
-	go c.policyController.ExecuteAsyncPolicy(opt, string(volBody), errChan)
+	go pc.ExecuteAsyncPolicy(ctx, vol, errChan)
 
 	if err := <-errChan; err != nil {
 		log.Error("When execute async policy:", err.Error())
@@ -363,6 +423,72 @@ func (c *Controller) ExtendVolume(ctx *c.Context, volID string, newSize int64, e
 	errchanVolume <- nil
 }
 
+// RevertToSnapshot rolls back the data of volume to the specified snapshot.
+func (c *Controller) RevertToSnapshot(ctx *c.Context, volID string, snapID string, errchanVolume chan error) {
+	vol, err := db.C.GetVolume(ctx, volID)
+	if err != nil {
+		log.Error("Get volume failed in revert volume method: ", err)
+		errchanVolume <- err
+		return
+	}
+	snap, err := db.C.GetVolumeSnapshot(ctx, snapID)
+	if err != nil {
+		log.Error("Get snapshot failed in revert volume method: ", err)
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeErrorReverting); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+	pool, err := db.C.GetPool(ctx, vol.PoolId)
+	if err != nil {
+		log.Error("Get pool failed in revert volume method: ", err)
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeErrorReverting); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, vol.PoolId)
+	if err != nil {
+		log.Error("When search dock in db by pool id: ", err)
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeErrorReverting); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+
+	opt := &pb.RevertToSnapshotOpts{
+		Id:               vol.Id,
+		SnapshotId:       snap.Id,
+		Size:             vol.Size,
+		PoolName:         pool.Name,
+		Metadata:         vol.Metadata,
+		SnapshotMetadata: snap.Metadata,
+		DriverName:       dockInfo.DriverName,
+		Context:          ctx.ToJson(),
+	}
+	if err = c.volumeController.RevertToSnapshot(dockInfo, opt); err != nil {
+		log.Error("Revert volume to snapshot failed: ", err)
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeErrorReverting); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+
+	if err = db.C.UpdateStatus(ctx, vol, model.VolumeAvailable); err != nil {
+		errchanVolume <- err
+		return
+	}
+	errchanVolume <- nil
+}
+
 func (c *Controller) CreateVolumeAttachment(ctx *c.Context, in *model.VolumeAttachmentSpec, errchanVolAtm chan error) {
 	vol, err := db.C.GetVolume(ctx, in.VolumeId)
 	if err != nil {
@@ -376,7 +502,6 @@ func (c *Controller) CreateVolumeAttachment(ctx *c.Context, in *model.VolumeAtta
 		errchanVolAtm <- err
 		return
 	}
-	c.volumeController.SetDock(dockInfo)
 
 	pol, err := db.C.GetPool(ctx, vol.PoolId)
 	if err != nil {
@@ -405,8 +530,9 @@ func (c *Controller) CreateVolumeAttachment(ctx *c.Context, in *model.VolumeAtta
 		Metadata:       utils.MergeStringMaps(in.Metadata, vol.Metadata),
 		DriverName:     dockInfo.DriverName,
 		Context:        ctx.ToJson(),
+		Qos:            newQos(vol.Qos),
 	}
-	result, err := c.volumeController.CreateVolumeAttachment(atm)
+	result, err := c.volumeController.CreateVolumeAttachment(dockInfo, atm)
 	if err != nil {
 		if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeAttachError); errUpdate != nil {
 			errchanVolAtm <- errUpdate
@@ -445,9 +571,8 @@ func (c *Controller) DeleteVolumeAttachment(ctx *c.Context, in *model.VolumeAtta
 		errchan <- err
 		return
 	}
-	c.volumeController.SetDock(dockInfo)
 
-	err = c.volumeController.DeleteVolumeAttachment(
+	err = c.volumeController.DeleteVolumeAttachment(dockInfo,
 		&pb.DeleteAttachmentOpts{
 			Id:       in.Id,
 			VolumeId: in.VolumeId,
@@ -496,7 +621,6 @@ func (c *Controller) CreateVolumeSnapshot(ctx *c.Context, in *model.VolumeSnapsh
 		errchan <- err
 		return
 	}
-	c.volumeController.SetDock(dockInfo)
 
 	if in.Metadata == nil {
 		in.Metadata = map[string]string{}
@@ -515,7 +639,7 @@ func (c *Controller) CreateVolumeSnapshot(ctx *c.Context, in *model.VolumeSnapsh
 		}
 	}
 
-	snp, err := c.volumeController.CreateVolumeSnapshot(
+	snp, err := c.volumeController.CreateVolumeSnapshot(dockInfo,
 		&pb.CreateVolumeSnapshotOpts{
 			Id:          in.Id,
 			Name:        in.Name,
@@ -555,9 +679,8 @@ func (c *Controller) DeleteVolumeSnapshot(ctx *c.Context, in *model.VolumeSnapsh
 		errchan <- err
 		return
 	}
-	c.volumeController.SetDock(dockInfo)
 
-	err = c.volumeController.DeleteVolumeSnapshot(
+	err = c.volumeController.DeleteVolumeSnapshot(dockInfo,
 		&pb.DeleteVolumeSnapshotOpts{
 			Id:         in.Id,
 			VolumeId:   in.VolumeId,
@@ -580,6 +703,9 @@ func (c *Controller) DeleteVolumeSnapshot(ctx *c.Context, in *model.VolumeSnapsh
 		errchan <- err
 		return
 	}
+	if err = db.C.ReleaseQuota(ctx, in.TenantId, &model.QuotaUsageSpec{Snapshots: 1}); err != nil {
+		log.Warning("Release quota of volume snapshot failed:", err)
+	}
 	errchan <- nil
 }
 
@@ -593,6 +719,74 @@ func (c *Controller) CreateVolumeGroup(ctx *c.Context, in *model.VolumeGroupSpec
 		}
 		return errors.New(msg)
 	}
+	return c.createVolumeGroup(ctx, in, polInfo)
+}
+
+// CreateVolumeGroupFromSnapshot creates the group in the pool of the group
+// which the snapshot is taken from, then creates the volumes from the member
+// snapshots and adds them to the group. The volume entries have been created
+// along with the group entry.
+func (c *Controller) CreateVolumeGroupFromSnapshot(ctx *c.Context, in *model.VolumeGroupSpec, vols []*model.VolumeSpec) error {
+	polInfo, err := c.groupSnapshotPool(ctx, in.GroupSnapshotId)
+	if err != nil {
+		log.Error("When get the pool of group snapshot:", err)
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.VolumeGroupError); errUpdate != nil {
+			return errUpdate
+		}
+		for _, vol := range vols {
+			db.C.UpdateStatus(ctx, vol, model.VolumeError)
+		}
+		return err
+	}
+	if err = c.createVolumeGroup(ctx, in, polInfo); err != nil {
+		for _, vol := range vols {
+			db.C.UpdateStatus(ctx, vol, model.VolumeError)
+		}
+		return err
+	}
+	in.PoolId = polInfo.Id
+
+	var created []string
+	var errCreate error
+	for _, vol := range vols {
+		var errchan = make(chan error, 1)
+		c.CreateVolume(ctx, vol, errchan)
+		if err := <-errchan; err != nil {
+			log.Errorf("When create volume %s of group %s from snapshot: %v", vol.Id, in.Id, err)
+			errCreate = err
+			continue
+		}
+		created = append(created, vol.Id)
+	}
+	if len(created) > 0 {
+		if err = c.UpdateVolumeGroup(ctx, in, created, nil); err != nil {
+			return err
+		}
+	}
+	if errCreate != nil {
+		if err = db.C.UpdateStatus(ctx, in, model.VolumeGroupError); err != nil {
+			return err
+		}
+		return errCreate
+	}
+	return nil
+}
+
+// groupSnapshotPool returns the pool of the group which the group snapshot
+// is taken from.
+func (c *Controller) groupSnapshotPool(ctx *c.Context, gsId string) (*model.StoragePoolSpec, error) {
+	gs, err := db.C.GetGroupSnapshot(ctx, gsId)
+	if err != nil {
+		return nil, err
+	}
+	vg, err := db.C.GetVolumeGroup(ctx, gs.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	return db.C.GetPool(ctx, vg.PoolId)
+}
+
+func (c *Controller) createVolumeGroup(ctx *c.Context, in *model.VolumeGroupSpec, polInfo *model.StoragePoolSpec) error {
 	dockInfo, err := db.C.GetDock(ctx, polInfo.DockId)
 	if err != nil {
 		msg := "No valid dock find for group"
@@ -603,8 +797,6 @@ func (c *Controller) CreateVolumeGroup(ctx *c.Context, in *model.VolumeGroupSpec
 		return errors.New(msg)
 	}
 
-	c.volumeController.SetDock(dockInfo)
-
 	opt := &pb.CreateVolumeGroupOpts{
 		Id:               in.Id,
 		Name:             in.Name,
@@ -615,7 +807,7 @@ func (c *Controller) CreateVolumeGroup(ctx *c.Context, in *model.VolumeGroupSpec
 		Context:          ctx.ToJson(),
 	}
 
-	_, err = c.volumeController.CreateVolumeGroup(opt)
+	_, err = c.volumeController.CreateVolumeGroup(dockInfo, opt)
 	if err != nil {
 		return err
 	}
@@ -764,8 +956,6 @@ func (c *Controller) UpdateVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec
 		return err
 	}
 
-	c.volumeController.SetDock(dock)
-
 	opt := &pb.UpdateVolumeGroupOpts{
 		Id:            vg.Id,
 		DriverName:    dock.DriverName,
@@ -774,7 +964,7 @@ func (c *Controller) UpdateVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec
 		Context:       ctx.ToJson(),
 	}
 
-	err = c.volumeController.UpdateVolumeGroup(opt)
+	err = c.volumeController.UpdateVolumeGroup(dock, opt)
 	if err != nil {
 		log.Error("When create volume group:", err)
 		return err
@@ -804,15 +994,13 @@ func (c *Controller) DeleteVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec
 		return err
 	}
 
-	c.volumeController.SetDock(dock)
-
 	opt := &pb.DeleteVolumeGroupOpts{
 		Id:         vg.Id,
 		DriverName: dock.DriverName,
 		Context:    ctx.ToJson(),
 	}
 
-	err = c.volumeController.DeleteVolumeGroup(opt)
+	err = c.volumeController.DeleteVolumeGroup(dock, opt)
 	if err != nil {
 		log.Error("When delete volume group:", err)
 		return err
@@ -820,3 +1008,203 @@ func (c *Controller) DeleteVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec
 
 	return nil
 }
+
+// CreateGroupSnapshot takes the snapshots of all volumes in the group at the
+// same point in time. The entries of the member snapshots have been created
+// along with the group snapshot entry.
+func (c *Controller) CreateGroupSnapshot(ctx *c.Context, in *model.GroupSnapshotSpec, errchan chan error) {
+	var snps []*model.VolumeSnapshotSpec
+	var fail = func(err error) {
+		for _, snp := range snps {
+			db.C.UpdateStatus(ctx, snp, model.VolumeSnapError)
+		}
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.GroupSnapError); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	vg, err := db.C.GetVolumeGroup(ctx, in.GroupId)
+	if err != nil {
+		log.Error("Get volume group failed in create group snapshot method: ", err)
+		fail(err)
+		return
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, vg.PoolId)
+	if err != nil {
+		log.Error("When search supported dock resource:", err)
+		fail(err)
+		return
+	}
+
+	var opt = &pb.CreateGroupSnapshotOpts{
+		Id:          in.Id,
+		Name:        in.Name,
+		Description: in.Description,
+		GroupId:     in.GroupId,
+		DriverName:  dockInfo.DriverName,
+		Context:     ctx.ToJson(),
+	}
+	for _, snpId := range in.Snapshots {
+		snp, err := db.C.GetVolumeSnapshot(ctx, snpId)
+		if err != nil {
+			log.Error("Get volume snapshot failed in create group snapshot method: ", err)
+			fail(err)
+			return
+		}
+		snps = append(snps, snp)
+		vol, err := db.C.GetVolume(ctx, snp.VolumeId)
+		if err != nil {
+			log.Error("Get volume failed in create group snapshot method: ", err)
+			fail(err)
+			return
+		}
+		opt.Snapshots = append(opt.Snapshots, &pb.CreateVolumeSnapshotOpts{
+			Id:          snp.Id,
+			Name:        snp.Name,
+			Description: snp.Description,
+			VolumeId:    snp.VolumeId,
+			Size:        vol.Size,
+			Metadata:    utils.MergeStringMaps(snp.Metadata, vol.Metadata),
+			DriverName:  dockInfo.DriverName,
+			Context:     ctx.ToJson(),
+		})
+	}
+
+	result, err := c.volumeController.CreateGroupSnapshot(dockInfo, opt)
+	if err != nil {
+		log.Error("When create group snapshot:", err)
+		fail(err)
+		return
+	}
+	// The snapshots are returned in the same order as requested.
+	for i, snp := range snps {
+		snp.Metadata = utils.MergeStringMaps(snp.Metadata, result[i].Metadata)
+		if errUpdate := db.C.UpdateStatus(ctx, snp, model.VolumeSnapAvailable); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+	}
+	if errUpdate := db.C.UpdateStatus(ctx, in, model.GroupSnapAvailable); errUpdate != nil {
+		errchan <- errUpdate
+		return
+	}
+	errchan <- nil
+}
+
+// DeleteGroupSnapshot deletes the member snapshots on the storage along with
+// their entries, and removes the group snapshot from its group.
+func (c *Controller) DeleteGroupSnapshot(ctx *c.Context, in *model.GroupSnapshotSpec, errchan chan error) {
+	var snps []*model.VolumeSnapshotSpec
+	var fail = func(err error) {
+		for _, snp := range snps {
+			db.C.UpdateStatus(ctx, snp, model.VolumeSnapErrorDeleting)
+		}
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.GroupSnapErrorDeleting); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	vg, err := db.C.GetVolumeGroup(ctx, in.GroupId)
+	if err != nil {
+		log.Error("Get volume group failed in delete group snapshot method: ", err)
+		fail(err)
+		return
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, vg.PoolId)
+	if err != nil {
+		log.Error("When search supported dock resource:", err)
+		fail(err)
+		return
+	}
+
+	var opt = &pb.DeleteGroupSnapshotOpts{
+		Id:         in.Id,
+		GroupId:    in.GroupId,
+		DriverName: dockInfo.DriverName,
+		Context:    ctx.ToJson(),
+	}
+	for _, snpId := range in.Snapshots {
+		snp, err := db.C.GetVolumeSnapshot(ctx, snpId)
+		if err != nil {
+			log.Error("Get volume snapshot failed in delete group snapshot method: ", err)
+			fail(err)
+			return
+		}
+		snps = append(snps, snp)
+		vol, err := db.C.GetVolume(ctx, snp.VolumeId)
+		if err != nil {
+			log.Error("Get volume failed in delete group snapshot method: ", err)
+			fail(err)
+			return
+		}
+		opt.Snapshots = append(opt.Snapshots, &pb.DeleteVolumeSnapshotOpts{
+			Id:         snp.Id,
+			VolumeId:   snp.VolumeId,
+			Metadata:   utils.MergeStringMaps(snp.Metadata, vol.Metadata),
+			DriverName: dockInfo.DriverName,
+			Context:    ctx.ToJson(),
+		})
+	}
+
+	if err = c.volumeController.DeleteGroupSnapshot(dockInfo, opt); err != nil {
+		log.Error("When delete group snapshot:", err)
+		fail(err)
+		return
+	}
+	for _, snp := range snps {
+		if err = db.C.DeleteVolumeSnapshot(ctx, snp.Id); err != nil {
+			log.Error("When delete volume snapshot of group snapshot in db:", err)
+			errchan <- err
+			return
+		}
+	}
+	if err = db.C.ReleaseQuota(ctx, in.TenantId, &model.QuotaUsageSpec{Snapshots: int64(len(snps))}); err != nil {
+		log.Warning("Release quota of group snapshot failed:", err)
+	}
+
+	// Read the group again, since it may have been updated during deletion.
+	if vg, err = db.C.GetVolumeGroup(ctx, in.GroupId); err != nil {
+		errchan <- err
+		return
+	}
+	var groupSnapshots = []string{}
+	for _, id := range vg.GroupSnapshots {
+		if id != in.Id {
+			groupSnapshots = append(groupSnapshots, id)
+		}
+	}
+	vg.GroupSnapshots = groupSnapshots
+	if _, err = db.C.UpdateVolumeGroup(ctx, vg); err != nil {
+		log.Error("When remove group snapshot from volume group:", err)
+		errchan <- err
+		return
+	}
+	if err = db.C.DeleteGroupSnapshot(ctx, in.Id); err != nil {
+		log.Error("When delete group snapshot in db:", err)
+		errchan <- err
+		return
+	}
+	errchan <- nil
+}
+
+// profileQos returns the qos limits required by the io connectivity of the
+// profile, nil will be returned if no limit is specified.
+func profileQos(prf *model.ProfileSpec) *model.QosSpec {
+	ic := prf.ProvisioningProperties.IOConnectivity
+	if ic.MaxIOPS == 0 && ic.MaxBWS == 0 {
+		return nil
+	}
+	return &model.QosSpec{MaxIOPS: ic.MaxIOPS, MaxBWS: ic.MaxBWS}
+}
+
+// newQos converts the qos limits of volume to the one sent to dock.
+func newQos(qos *model.QosSpec) *pb.Qos {
+	if qos == nil {
+		return nil
+	}
+	return &pb.Qos{MaxIOPS: qos.MaxIOPS, MaxBWS: qos.MaxBWS}
+}
@@ -15,17 +15,21 @@
 package controller
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/controller/dr"
+	"github.com/opensds/opensds/pkg/controller/policy/executor"
+	"github.com/opensds/opensds/pkg/controller/selector"
 	"github.com/opensds/opensds/pkg/controller/volume"
 	"github.com/opensds/opensds/pkg/db"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
 	. "github.com/opensds/opensds/testutils/collection"
 	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
 )
 
 type fakeSelector struct {
@@ -33,6 +37,13 @@ type fakeSelector struct {
 	err error
 }
 
+func (s *fakeSelector) SelectPoolForVolume(vol *model.VolumeSpec) (*selector.Selection, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &selector.Selection{Pool: s.res}, nil
+}
+
 func (s *fakeSelector) SelectSupportedPoolForVolume(vol *model.VolumeSpec) (*model.StoragePoolSpec, error) {
 	if s.err != nil {
 		return nil, s.err
@@ -47,6 +58,20 @@ func (s *fakeSelector) SelectSupportedPoolForVG(vg *model.VolumeGroupSpec) (*mod
 	return s.res, nil
 }
 
+func (s *fakeSelector) SelectSupportedPoolForMigration(vol *model.VolumeSpec) (*model.StoragePoolSpec, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.res, nil
+}
+
+func (s *fakeSelector) SelectSupportedPoolForFileShare(share *model.FileShareSpec) (*model.StoragePoolSpec, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.res, nil
+}
+
 // NewController method creates a controller structure and expose its pointer.
 func NewFakeDrController() dr.Controller {
 	return &fakeDrController{}
@@ -87,74 +112,112 @@ func NewFakeVolumeController() volume.Controller {
 type fakeVolumeController struct {
 }
 
-func (fvc *fakeVolumeController) CreateVolume(*pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+func (fvc *fakeVolumeController) CreateVolume(*model.DockSpec, *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
 	return &SampleVolumes[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolume(*pb.DeleteVolumeOpts) error {
+func (fvc *fakeVolumeController) DeleteVolume(*model.DockSpec, *pb.DeleteVolumeOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) ExtendVolume(*pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
+func (fvc *fakeVolumeController) ExtendVolume(*model.DockSpec, *pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
 	return &SampleVolumes[0], nil
 }
 
-func (fvc *fakeVolumeController) CreateVolumeAttachment(*pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
+func (fvc *fakeVolumeController) RevertToSnapshot(*model.DockSpec, *pb.RevertToSnapshotOpts) error {
+	return nil
+}
+
+func (fvc *fakeVolumeController) MigrateVolume(*model.DockSpec, *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+func (fvc *fakeVolumeController) RetypeVolume(*model.DockSpec, *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+func (fvc *fakeVolumeController) CreateVolumeAttachment(*model.DockSpec, *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
 	return &SampleAttachments[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolumeAttachment(*pb.DeleteAttachmentOpts) error {
+func (fvc *fakeVolumeController) DeleteVolumeAttachment(*model.DockSpec, *pb.DeleteAttachmentOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) CreateVolumeSnapshot(*pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+func (fvc *fakeVolumeController) CreateVolumeSnapshot(*model.DockSpec, *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
 	return &SampleSnapshots[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolumeSnapshot(*pb.DeleteVolumeSnapshotOpts) error {
+func (fvc *fakeVolumeController) DeleteVolumeSnapshot(*model.DockSpec, *pb.DeleteVolumeSnapshotOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) AttachVolume(*pb.AttachVolumeOpts) (string, error) {
+func (fvc *fakeVolumeController) PullVolume(*model.DockSpec, *pb.PullVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+func (fvc *fakeVolumeController) PullVolumeSnapshot(*model.DockSpec, *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	return &SampleSnapshots[0], nil
+}
+
+func (fvc *fakeVolumeController) AttachVolume(*model.DockSpec, *pb.AttachVolumeOpts) (string, error) {
 	return "", nil
 }
 
-func (fvc *fakeVolumeController) DetachVolume(*pb.DetachVolumeOpts) error {
+func (fvc *fakeVolumeController) DetachVolume(*model.DockSpec, *pb.DetachVolumeOpts) error {
+	return nil
+}
+
+func (fvc *fakeVolumeController) CopyVolume(*model.DockSpec, *pb.CopyVolumeOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) CreateReplication(opts *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
+func (fvc *fakeVolumeController) CreateReplication(dock *model.DockSpec, opts *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
 	return &SampleReplications[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteReplication(opt *pb.DeleteReplicationOpts) error {
+func (fvc *fakeVolumeController) DeleteReplication(dock *model.DockSpec, opt *pb.DeleteReplicationOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) EnableReplication(opt *pb.EnableReplicationOpts) error {
+func (fvc *fakeVolumeController) EnableReplication(dock *model.DockSpec, opt *pb.EnableReplicationOpts) error {
 	return nil
 
 }
 
-func (fvc *fakeVolumeController) DisableReplication(opt *pb.DisableReplicationOpts) error {
+func (fvc *fakeVolumeController) DisableReplication(dock *model.DockSpec, opt *pb.DisableReplicationOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) FailoverReplication(opt *pb.FailoverReplicationOpts) error {
+func (fvc *fakeVolumeController) FailoverReplication(dock *model.DockSpec, opt *pb.FailoverReplicationOpts) error {
 	return nil
 }
-func (fvc *fakeVolumeController) CreateVolumeGroup(*pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
+func (fvc *fakeVolumeController) CreateVolumeGroup(*model.DockSpec, *pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
 	return nil, nil
 }
 
-func (fvc *fakeVolumeController) UpdateVolumeGroup(*pb.UpdateVolumeGroupOpts) error {
+func (fvc *fakeVolumeController) UpdateVolumeGroup(*model.DockSpec, *pb.UpdateVolumeGroupOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolumeGroup(*pb.DeleteVolumeGroupOpts) error {
+func (fvc *fakeVolumeController) DeleteVolumeGroup(*model.DockSpec, *pb.DeleteVolumeGroupOpts) error {
+	return nil
+}
+
+func (fvc *fakeVolumeController) CreateGroupSnapshot(dock *model.DockSpec, opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	var snps []*model.VolumeSnapshotSpec
+	for _, snp := range opt.GetSnapshots() {
+		snps = append(snps, &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{Id: snp.GetId()},
+			Metadata:  map[string]string{"lvsPath": "/dev/vg001/_snapshot-" + snp.GetId()},
+		})
+	}
+	return snps, nil
+}
+
+func (fvc *fakeVolumeController) DeleteGroupSnapshot(*model.DockSpec, *pb.DeleteGroupSnapshotOpts) error {
 	return nil
 }
-func (fvc *fakeVolumeController) SetDock(dockInfo *model.DockSpec) { return }
 
 func TestCreateVolume(t *testing.T) {
 	var req = &model.VolumeSpec{
@@ -167,6 +230,7 @@ func TestCreateVolume(t *testing.T) {
 	var vol = &SampleVolumes[0]
 	mockClient := new(dbtest.Client)
 	mockClient.On("GetDock", context.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(nil, nil)
 	mockClient.On("GetDefaultProfile", context.NewAdminContext()).Return(&SampleProfiles[0], nil)
 	mockClient.On("GetProfile", context.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").Return(&SampleProfiles[0], nil)
 	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, vol.Status).Return(nil)
@@ -185,6 +249,10 @@ func TestCreateVolume(t *testing.T) {
 	if err := <-errchan; err != nil {
 		t.Errorf("Failed to create volume, err is %v\n", err)
 	}
+	// The summary of pool selection is kept in the metadata of volume.
+	mockClient.AssertCalled(t, "UpdateVolume", context.NewAdminContext(), mock.MatchedBy(func(vol *model.VolumeSpec) bool {
+		return vol.Metadata[selector.SelectionKey] != ""
+	}))
 }
 
 func TestCreateVolumeFromSnapshot(t *testing.T) {
@@ -199,6 +267,7 @@ func TestCreateVolumeFromSnapshot(t *testing.T) {
 	var vol = &SampleVolumes[0]
 	mockClient := new(dbtest.Client)
 	mockClient.On("GetDock", context.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(nil, nil)
 	mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f537").Return(&SampleSnapshots[0], nil)
 	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
 	mockClient.On("GetProfile", context.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").Return(&SampleProfiles[0], nil)
@@ -220,6 +289,42 @@ func TestCreateVolumeFromSnapshot(t *testing.T) {
 	}
 }
 
+func TestCloneVolume(t *testing.T) {
+	var req = &model.VolumeSpec{
+		BaseModel:      &model.BaseModel{},
+		Name:           "sample-volume",
+		Description:    "This is a sample volume for testing",
+		Size:           int64(1),
+		ProfileId:      "1106b972-66ef-11e7-b172-db03f3689c9c",
+		SourceVolumeId: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+	}
+	var vol = &SampleVolumes[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDock", context.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(nil, nil)
+	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
+	mockClient.On("GetProfile", context.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").Return(&SampleProfiles[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, vol.Status).Return(nil)
+	db.C = mockClient
+
+	var ctrl = &Controller{
+		selector: &fakeSelector{
+			res: &model.StoragePoolSpec{BaseModel: &model.BaseModel{}, DockId: "b7602e18-771e-11e7-8f38-dbd6d291f4e0"},
+			err: nil,
+		},
+		volumeController: NewFakeVolumeController(),
+	}
+
+	var errchan = make(chan error, 1)
+	ctrl.CreateVolume(context.NewAdminContext(), req, errchan)
+	if err := <-errchan; err != nil {
+		t.Errorf("Failed to clone volume, err is %v\n", err)
+	}
+	if req.PoolId != SampleVolumes[0].PoolId {
+		t.Errorf("Expected pool %s, got %s\n", SampleVolumes[0].PoolId, req.PoolId)
+	}
+}
+
 func TestDeleteVolume(t *testing.T) {
 
 	var req = &model.VolumeSpec{
@@ -234,6 +339,8 @@ func TestDeleteVolume(t *testing.T) {
 	mockClient.On("GetProfile", context.NewAdminContext(), req.ProfileId).Return(&SampleProfiles[0], nil)
 	mockClient.On("GetDockByPoolId", context.NewAdminContext(), req.PoolId).Return(&SampleDocks[0], nil)
 	mockClient.On("DeleteVolume", context.NewAdminContext(), req.Id).Return(nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), req.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: req.Size}).Return(nil)
+	mockClient.On("ListSnapshotSchedules", context.NewAdminContext()).Return([]*model.SnapshotScheduleSpec{}, nil)
 	db.C = mockClient
 
 	var c = &Controller{
@@ -269,6 +376,7 @@ func TestExtendVolume(t *testing.T) {
 	mockClient.On("GetProfile", context.NewAdminContext(), vol.ProfileId).Return(&SampleProfiles[0], nil)
 	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vol.PoolId).Return(&SampleDocks[0], nil)
 	mockClient.On("UpdateStatus", context.NewAdminContext(), vol2, vol2.Status).Return(nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), vol.TenantId, &model.QuotaUsageSpec{Gigabytes: 91}).Return(nil)
 	db.C = mockClient
 
 	var c = &Controller{
@@ -314,6 +422,328 @@ func TestExtendVolume(t *testing.T) {
 	}
 }
 
+type fakePolicyExecutor struct {
+	req *executor.Request
+	err error
+}
+
+func (f *fakePolicyExecutor) Asynchronized() error {
+	return f.err
+}
+
+func TestCreateVolumePolicyFailed(t *testing.T) {
+	var req = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "sample-volume",
+		Size:      int64(1),
+		ProfileId: "1106b972-66ef-11e7-b172-db03f3689c9c",
+	}
+	var prf = SampleProfiles[0]
+	prf.CustomProperties = model.CustomPropertiesSpec{"fakeCreatePolicy": "true"}
+	var fe = &fakePolicyExecutor{err: errors.New("policy failed")}
+	executor.RegisterPolicy(&executor.Policy{
+		Name:      "fakeCreatePolicy",
+		Type:      executor.OperationPolicy,
+		Lifecycle: executor.CreateLifecycle,
+		NewExecutor: func(value string, req *executor.Request) (executor.AsynchronizedExecutor, error) {
+			fe.req = req
+			return fe, nil
+		},
+	})
+	defer executor.UnregisterPolicy("fakeCreatePolicy")
+
+	var vol = &SampleVolumes[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDock", context.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(nil, nil)
+	mockClient.On("GetProfile", context.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").Return(&prf, nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, vol.Status).Return(nil)
+	db.C = mockClient
+
+	var ctrl = &Controller{
+		selector: &fakeSelector{
+			res: &model.StoragePoolSpec{BaseModel: &model.BaseModel{}, DockId: "b7602e18-771e-11e7-8f38-dbd6d291f4e0"},
+		},
+		volumeController: NewFakeVolumeController(),
+	}
+
+	var errchan = make(chan error, 1)
+	ctrl.CreateVolume(context.NewAdminContext(), req, errchan)
+	// The volume is created even if its policies fail to be applied.
+	if err := <-errchan; err != nil {
+		t.Errorf("Failed to create volume, err is %v\n", err)
+	}
+	if fe.req == nil {
+		t.Fatal("Expected the create policy to be executed")
+	}
+	mockClient.AssertCalled(t, "UpdateVolume", context.NewAdminContext(), mock.MatchedBy(func(vol *model.VolumeSpec) bool {
+		return vol.PolicyStatus == model.VolumePolicyError
+	}))
+}
+
+func TestExtendVolumeExecutesPolicy(t *testing.T) {
+	var vol = SampleVolumes[0]
+	vol.Size = 1
+	var prf = SampleProfiles[0]
+	prf.CustomProperties = model.CustomPropertiesSpec{"fakeExtendPolicy": "true"}
+	var fe = &fakePolicyExecutor{}
+	executor.RegisterPolicy(&executor.Policy{
+		Name:      "fakeExtendPolicy",
+		Type:      executor.OperationPolicy,
+		Lifecycle: executor.ExtendLifecycle,
+		NewExecutor: func(value string, req *executor.Request) (executor.AsynchronizedExecutor, error) {
+			fe.req = req
+			return fe, nil
+		},
+	})
+	defer executor.UnregisterPolicy("fakeExtendPolicy")
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(&vol, nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), &vol).Return(&vol, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), vol.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetProfile", context.NewAdminContext(), vol.ProfileId).Return(&prf, nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vol.PoolId).Return(&SampleDocks[0], nil)
+	db.C = mockClient
+
+	var c = &Controller{volumeController: NewFakeVolumeController()}
+	var errchan = make(chan error, 1)
+	c.ExtendVolume(context.NewAdminContext(), vol.Id, 2, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to extend volume:", err)
+	}
+	if fe.req == nil || fe.req.Volume.Id != vol.Id || fe.req.Volume.Size != 2 ||
+		fe.req.DockInfo.Id != SampleDocks[0].Id {
+		t.Errorf("Unexpected policy request %+v", fe.req)
+	}
+}
+
+func TestRevertToSnapshot(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		PoolId: "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:   int64(1),
+		Status: "reverting",
+	}
+	var snapID = "3769855c-a102-11e7-b772-17b880d2f537"
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), snapID).Return(&SampleSnapshots[0], nil)
+	mockClient.On("GetPool", context.NewAdminContext(), vol.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vol.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, model.VolumeAvailable).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		volumeController: NewFakeVolumeController(),
+	}
+	var errchan = make(chan error, 1)
+	c.RevertToSnapshot(context.NewAdminContext(), vol.Id, snapID, errchan)
+	if err := <-errchan; err != nil {
+		t.Errorf("Failed to revert volume to snapshot, err is %v\n", err)
+	}
+}
+
+func TestMigrateVolume(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		PoolId: "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:   int64(1),
+		Status: "migrating",
+	}
+	var dstPool = &SamplePools[1]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), vol.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vol.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), dstPool.Id).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(vol, nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, model.VolumeAvailable).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		selector: &fakeSelector{
+			res: dstPool,
+			err: nil,
+		},
+		volumeController: NewFakeVolumeController(),
+	}
+	var errchan = make(chan error, 1)
+	c.MigrateVolume(context.NewAdminContext(), vol.Id, "", errchan)
+	if err := <-errchan; err != nil {
+		t.Errorf("Failed to migrate volume, err is %v\n", err)
+	}
+	if vol.PoolId != dstPool.Id {
+		t.Errorf("Expected %v, got %v\n", dstPool.Id, vol.PoolId)
+	}
+	if vol.MigrationStatus != model.VolumeMigrationSuccess {
+		t.Errorf("Expected %v, got %v\n", model.VolumeMigrationSuccess, vol.MigrationStatus)
+	}
+}
+
+// hostMigrationController migrates volumes by host, and fails to delete the
+// source volume.
+type hostMigrationController struct {
+	volume.Controller
+}
+
+func (*hostMigrationController) MigrateVolume(*model.DockSpec, *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return nil, &model.NotImplementError{S: "driver-native migration is not supported"}
+}
+
+func (*hostMigrationController) DeleteVolume(*model.DockSpec, *pb.DeleteVolumeOpts) error {
+	return errors.New("volume is busy")
+}
+
+func TestMigrateVolumeDeleteSourceFailed(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		PoolId: "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:   int64(1),
+		Status: "migrating",
+	}
+	var dstPool = &SamplePools[1]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), vol.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), mock.Anything).Return(&SampleDocks[0], nil)
+	mockClient.On("GetDock", context.NewAdminContext(), mock.Anything).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(vol, nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, model.VolumeAvailable).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		selector:         &fakeSelector{res: dstPool},
+		volumeController: &hostMigrationController{Controller: NewFakeVolumeController()},
+	}
+	var errchan = make(chan error, 1)
+	c.MigrateVolume(context.NewAdminContext(), vol.Id, "", errchan)
+	if err := <-errchan; err == nil {
+		t.Error("Expected error when the source volume fails to be deleted")
+	}
+	// The data has been moved to the target pool.
+	if vol.PoolId != dstPool.Id {
+		t.Errorf("Expected %v, got %v\n", dstPool.Id, vol.PoolId)
+	}
+	if vol.MigrationStatus != model.VolumeMigrationPartial {
+		t.Errorf("Expected %v, got %v\n", model.VolumeMigrationPartial, vol.MigrationStatus)
+	}
+	// The source volume left behind is recorded.
+	if vol.Metadata[MigrationSourcePoolKey] != SamplePools[0].Id {
+		t.Errorf("Expected %v, got %v\n", SamplePools[0].Id, vol.Metadata[MigrationSourcePoolKey])
+	}
+	if _, ok := vol.Metadata[MigrationSourceMetadataKey]; !ok {
+		t.Errorf("Expected %s in volume metadata\n", MigrationSourceMetadataKey)
+	}
+	mockClient.AssertCalled(t, "UpdateStatus", context.NewAdminContext(), vol, model.VolumeAvailable)
+	mockClient.AssertNotCalled(t, "UpdateStatus", context.NewAdminContext(), vol, model.VolumeErrorMigrating)
+}
+
+// nativeMigrationFailedController fails the driver-native migration.
+type nativeMigrationFailedController struct {
+	volume.Controller
+}
+
+func (*nativeMigrationFailedController) MigrateVolume(*model.DockSpec, *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return nil, errors.New("target pool is out of space")
+}
+
+func TestMigrateVolumeNativeFailed(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		PoolId: "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Size:   int64(1),
+		Status: "migrating",
+	}
+	var dstPool = &SamplePools[1]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), vol.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), mock.Anything).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), vol).Return(vol, nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, model.VolumeErrorMigrating).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		selector:         &fakeSelector{res: dstPool},
+		volumeController: &nativeMigrationFailedController{Controller: NewFakeVolumeController()},
+	}
+	var errchan = make(chan error, 1)
+	c.MigrateVolume(context.NewAdminContext(), vol.Id, "", errchan)
+	if err := <-errchan; err == nil {
+		t.Error("Expected error when the driver-native migration fails")
+	}
+	// The host-side copy is not tried.
+	if vol.PoolId != SamplePools[0].Id {
+		t.Errorf("Expected %v, got %v\n", SamplePools[0].Id, vol.PoolId)
+	}
+	if vol.MigrationStatus != model.VolumeMigrationError {
+		t.Errorf("Expected %v, got %v\n", model.VolumeMigrationError, vol.MigrationStatus)
+	}
+	mockClient.AssertNotCalled(t, "GetDock", mock.Anything, mock.Anything)
+}
+
+func TestRetypeVolume(t *testing.T) {
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		ProfileId: "2f9c0a04-66ef-11e7-ade2-43158893e017",
+		Size:      int64(1),
+		Status:    "retyping",
+	}
+	var prf = &SampleProfiles[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("GetProfile", context.NewAdminContext(), prf.Id).Return(prf, nil)
+	mockClient.On("GetPool", context.NewAdminContext(), vol.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vol.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), vol, model.VolumeAvailable).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		volumeController: NewFakeVolumeController(),
+	}
+	var errchan = make(chan error, 1)
+	c.RetypeVolume(context.NewAdminContext(), vol.Id, prf.Id, errchan)
+	if err := <-errchan; err != nil {
+		t.Errorf("Failed to retype volume, err is %v\n", err)
+	}
+	if vol.ProfileId != prf.Id {
+		t.Errorf("Expected %v, got %v\n", prf.Id, vol.ProfileId)
+	}
+	if vol.PoolId != "084bf71e-a102-11e7-88a8-e31fe6d52248" {
+		t.Errorf("Expected volume not to be migrated, got pool %v\n", vol.PoolId)
+	}
+}
+
+func TestProfileQos(t *testing.T) {
+	var prf = &model.ProfileSpec{
+		ProvisioningProperties: model.ProvisioningPropertiesSpec{
+			IOConnectivity: model.IOConnectivityLoS{
+				MaxIOPS: 1000,
+				MaxBWS:  100,
+			},
+		},
+	}
+	var expected = &pb.Qos{MaxIOPS: 1000, MaxBWS: 100}
+	if qos := newQos(profileQos(prf)); !reflect.DeepEqual(qos, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, qos)
+	}
+	if qos := newQos(profileQos(&model.ProfileSpec{})); qos != nil {
+		t.Errorf("Expected nil, got %v\n", qos)
+	}
+}
+
 func TestCreateVolumeAttachment(t *testing.T) {
 	var req = &model.VolumeAttachmentSpec{
 		BaseModel: &model.BaseModel{},
@@ -414,6 +844,7 @@ func TestDeleteVolumeSnapshot(t *testing.T) {
 	mockClient.On("GetVolume", context.NewAdminContext(), req.VolumeId).Return(vol, nil)
 	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vol.PoolId).Return(&SampleDocks[0], nil)
 	mockClient.On("DeleteVolumeSnapshot", context.NewAdminContext(), req.Id).Return(nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), req.TenantId, &model.QuotaUsageSpec{Snapshots: 1}).Return(nil)
 
 	db.C = mockClient
 
@@ -428,6 +859,84 @@ func TestDeleteVolumeSnapshot(t *testing.T) {
 	}
 }
 
+func TestCreateGroupSnapshot(t *testing.T) {
+	var gs = SampleGroupSnapshots[0]
+	var vg = &model.VolumeGroupSpec{
+		BaseModel: &model.BaseModel{Id: gs.GroupId},
+		PoolId:    SamplePools[0].Id,
+	}
+	var vol = &SampleVolumes[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolumeGroup", context.NewAdminContext(), gs.GroupId).Return(vg, nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vg.PoolId).Return(&SampleDocks[0], nil)
+	for i := range SampleSnapshots {
+		snp := SampleSnapshots[i]
+		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), snp.Id).Return(&snp, nil)
+	}
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), mock.Anything, mock.Anything).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		volumeController: NewFakeVolumeController(),
+	}
+	var errchan = make(chan error, 1)
+
+	c.CreateGroupSnapshot(context.NewAdminContext(), &gs, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to create group snapshot:", err)
+	}
+	mockClient.AssertCalled(t, "UpdateStatus", context.NewAdminContext(), &gs, model.GroupSnapAvailable)
+	// The metadata returned by driver is saved along with the snapshots.
+	for _, call := range mockClient.Calls {
+		snp, ok := call.Arguments.Get(1).(*model.VolumeSnapshotSpec)
+		if !ok || call.Method != "UpdateStatus" {
+			continue
+		}
+		if snp.Status != model.VolumeSnapAvailable || snp.Metadata["lvsPath"] != "/dev/vg001/_snapshot-"+snp.Id {
+			t.Errorf("Unexpected snapshot %+v", snp)
+		}
+	}
+}
+
+func TestDeleteGroupSnapshot(t *testing.T) {
+	var gs = SampleGroupSnapshots[0]
+	var vg = &model.VolumeGroupSpec{
+		BaseModel:      &model.BaseModel{Id: gs.GroupId},
+		PoolId:         SamplePools[0].Id,
+		GroupSnapshots: []string{gs.Id},
+	}
+	var vol = &SampleVolumes[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetVolumeGroup", context.NewAdminContext(), gs.GroupId).Return(vg, nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), vg.PoolId).Return(&SampleDocks[0], nil)
+	for i := range SampleSnapshots {
+		snp := SampleSnapshots[i]
+		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), snp.Id).Return(&snp, nil)
+		mockClient.On("DeleteVolumeSnapshot", context.NewAdminContext(), snp.Id).Return(nil)
+	}
+	mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), gs.TenantId, &model.QuotaUsageSpec{Snapshots: 2}).Return(nil)
+	mockClient.On("UpdateVolumeGroup", context.NewAdminContext(), vg).Return(vg, nil)
+	mockClient.On("DeleteGroupSnapshot", context.NewAdminContext(), gs.Id).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		volumeController: NewFakeVolumeController(),
+	}
+	var errchan = make(chan error, 1)
+
+	c.DeleteGroupSnapshot(context.NewAdminContext(), &gs, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to delete group snapshot:", err)
+	}
+	if len(vg.GroupSnapshots) != 0 {
+		t.Errorf("Expected group snapshot removed from group, got %v", vg.GroupSnapshots)
+	}
+	mockClient.AssertNumberOfCalls(t, "DeleteVolumeSnapshot", 2)
+	mockClient.AssertCalled(t, "DeleteGroupSnapshot", context.NewAdminContext(), gs.Id)
+}
+
 func TestCreateReplication(t *testing.T) {
 
 	var req = &model.ReplicationSpec{
@@ -448,6 +957,7 @@ func TestCreateReplication(t *testing.T) {
 	mockClient.On("GetDefaultProfile", context.NewAdminContext()).Return(&SampleProfiles[0], nil)
 	mockClient.On("GetProfile", context.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").Return(&SampleProfiles[0], nil)
 	mockClient.On("GetDock", context.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(nil, nil)
 	mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
 	mockClient.On("UpdateReplication", context.NewAdminContext(), "c299a978-4f3e-11e8-8a5c-977218a83359", req).Return(&SampleReplications[0], nil)
 	db.C = mockClient
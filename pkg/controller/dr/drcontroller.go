@@ -186,7 +186,13 @@ func (d *DrController) DeleteReplication(ctx *c.Context, replica *ReplicationSpe
 	db.C.UpdateVolume(ctx, primaryVol)
 	secondaryVol.ReplicationDriverData = make(map[string]string)
 	db.C.UpdateVolume(ctx, secondaryVol)
-	return db.C.DeleteReplication(ctx, replica.Id)
+	if err := db.C.DeleteReplication(ctx, replica.Id); err != nil {
+		return err
+	}
+	if err := db.C.ReleaseQuota(ctx, replica.TenantId, &QuotaUsageSpec{Replications: 1}); err != nil {
+		log.Warning("Release quota of replication failed:", err)
+	}
+	return nil
 }
 
 func (d *DrController) EnableReplication(ctx *c.Context, replica *ReplicationSpec, primaryVol, secondaryVol *VolumeSpec) error {
@@ -276,7 +282,6 @@ func (p *PairOperator) getAttacherDockByProvisioner(ctx *c.Context, provisionerD
 
 func (p *PairOperator) doAttach(ctx *c.Context, vol *VolumeSpec, provisionerDock *DockSpec) (*VolumeAttachmentSpec, error) {
 	attacherDock, err := p.getAttacherDockByProvisioner(ctx, provisionerDock)
-	p.volumeController.SetDock(provisionerDock)
 	attachmentId := uuid.NewV4().String()
 	// Default protocol is iscsi
 	protocol := config.ISCSIProtocol
@@ -305,7 +310,7 @@ func (p *PairOperator) doAttach(ctx *c.Context, vol *VolumeSpec, provisionerDock
 		Context:        ctx.ToJson(),
 	}
 
-	atm, err := p.volumeController.CreateVolumeAttachment(createAttachOpt)
+	atm, err := p.volumeController.CreateVolumeAttachment(provisionerDock, createAttachOpt)
 	if err != nil {
 		log.Errorf("create attachment failed, %v", err)
 		return nil, err
@@ -334,13 +339,11 @@ func (p *PairOperator) doAttach(ctx *c.Context, vol *VolumeSpec, provisionerDock
 				DriverName: provisionerDock.DriverName,
 				Context:    ctx.ToJson(),
 			}
-			p.volumeController.SetDock(provisionerDock)
-			p.volumeController.DeleteVolumeAttachment(opt)
+			p.volumeController.DeleteVolumeAttachment(provisionerDock, opt)
 			db.C.DeleteVolumeAttachment(ctx, atm.Id)
 		}
 	}()
 
-	p.volumeController.SetDock(attacherDock)
 	connData, _ := json.Marshal(atm.ConnectionData)
 	var attachOpt = &pb.AttachVolumeOpts{
 		AccessProtocol: atm.DriverVolumeType,
@@ -348,7 +351,7 @@ func (p *PairOperator) doAttach(ctx *c.Context, vol *VolumeSpec, provisionerDock
 		Metadata:       map[string]string{},
 		Context:        ctx.ToJson(),
 	}
-	mountPoint, err := p.volumeController.AttachVolume(attachOpt)
+	mountPoint, err := p.volumeController.AttachVolume(attacherDock, attachOpt)
 	if err != nil {
 		rollback = true
 		log.Errorf("attach volume failed, %v", err)
@@ -386,8 +389,7 @@ func (p *PairOperator) doDetach(ctx *c.Context, attachmentId string, vol *Volume
 		Metadata:       atm.Metadata,
 		Context:        ctx.ToJson(),
 	}
-	p.volumeController.SetDock(attacherDock)
-	if err := p.volumeController.DetachVolume(detachOpt); err != nil {
+	if err := p.volumeController.DetachVolume(attacherDock, detachOpt); err != nil {
 		log.Error("deatach failed,", err)
 		return err
 	}
@@ -408,8 +410,7 @@ func (p *PairOperator) doDetach(ctx *c.Context, attachmentId string, vol *Volume
 		Context:        ctx.ToJson(),
 	}
 
-	p.volumeController.SetDock(provisionerDock)
-	if err := p.volumeController.DeleteVolumeAttachment(opt); err != nil {
+	if err := p.volumeController.DeleteVolumeAttachment(provisionerDock, opt); err != nil {
 		log.Error("delete volume attachment failed, ", err)
 		return err
 	}
@@ -471,8 +472,7 @@ func (p *PairOperator) Create(ctx *c.Context, replica *ReplicationSpec, vol *Vol
 		VolumeDataList:                 replica.VolumeDataList,
 		Metadata:                       replica.Metadata,
 	}
-	p.volumeController.SetDock(p.provisionDock)
-	return p.volumeController.CreateReplication(opt)
+	return p.volumeController.CreateReplication(p.provisionDock, opt)
 }
 
 func (p *PairOperator) Delete(ctx *c.Context, replica *ReplicationSpec, vol *VolumeSpec) error {
@@ -492,8 +492,7 @@ func (p *PairOperator) Delete(ctx *c.Context, replica *ReplicationSpec, vol *Vol
 		Metadata:                       replica.Metadata,
 		IsPrimary:                      p.isPrimary,
 	}
-	p.volumeController.SetDock(p.provisionDock)
-	return p.volumeController.DeleteReplication(opt)
+	return p.volumeController.DeleteReplication(p.provisionDock, opt)
 }
 
 func (p *PairOperator) Enable(ctx *c.Context, replica *ReplicationSpec, vol *VolumeSpec) error {
@@ -512,8 +511,7 @@ func (p *PairOperator) Enable(ctx *c.Context, replica *ReplicationSpec, vol *Vol
 		Metadata:                       replica.Metadata,
 		IsPrimary:                      p.isPrimary,
 	}
-	p.volumeController.SetDock(p.provisionDock)
-	return p.volumeController.EnableReplication(opt)
+	return p.volumeController.EnableReplication(p.provisionDock, opt)
 }
 
 func (p *PairOperator) Disable(ctx *c.Context, replica *ReplicationSpec, vol *VolumeSpec) error {
@@ -532,8 +530,7 @@ func (p *PairOperator) Disable(ctx *c.Context, replica *ReplicationSpec, vol *Vo
 		Metadata:                       replica.Metadata,
 		IsPrimary:                      p.isPrimary,
 	}
-	p.volumeController.SetDock(p.provisionDock)
-	return p.volumeController.DisableReplication(opt)
+	return p.volumeController.DisableReplication(p.provisionDock, opt)
 }
 
 func (p *PairOperator) Failover(ctx *c.Context, replica *ReplicationSpec, failover *FailoverReplicationSpec, vol *VolumeSpec) error {
@@ -554,6 +551,5 @@ func (p *PairOperator) Failover(ctx *c.Context, replica *ReplicationSpec, failov
 		SecondaryBackendId:             failover.SecondaryBackendId,
 		IsPrimary:                      p.isPrimary,
 	}
-	p.volumeController.SetDock(p.provisionDock)
-	return p.volumeController.FailoverReplication(opt)
+	return p.volumeController.FailoverReplication(p.provisionDock, opt)
 }
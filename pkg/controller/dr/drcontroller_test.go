@@ -35,74 +35,105 @@ func NewFakeVolumeController() volume.Controller {
 type fakeVolumeController struct {
 }
 
-func (fvc *fakeVolumeController) CreateVolume(*pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+func (fvc *fakeVolumeController) CreateVolume(*model.DockSpec, *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
 	return &SampleVolumes[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolume(*pb.DeleteVolumeOpts) error {
+func (fvc *fakeVolumeController) DeleteVolume(*model.DockSpec, *pb.DeleteVolumeOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) ExtendVolume(*pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
+func (fvc *fakeVolumeController) ExtendVolume(*model.DockSpec, *pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
 	return &SampleVolumes[0], nil
 }
 
-func (fvc *fakeVolumeController) CreateVolumeAttachment(*pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
+func (fvc *fakeVolumeController) RevertToSnapshot(*model.DockSpec, *pb.RevertToSnapshotOpts) error {
+	return nil
+}
+
+func (fvc *fakeVolumeController) MigrateVolume(*model.DockSpec, *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+func (fvc *fakeVolumeController) RetypeVolume(*model.DockSpec, *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+func (fvc *fakeVolumeController) CreateVolumeAttachment(*model.DockSpec, *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
 	return &SampleAttachments[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolumeAttachment(*pb.DeleteAttachmentOpts) error {
+func (fvc *fakeVolumeController) DeleteVolumeAttachment(*model.DockSpec, *pb.DeleteAttachmentOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) CreateVolumeSnapshot(*pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+func (fvc *fakeVolumeController) CreateVolumeSnapshot(*model.DockSpec, *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
 	return &SampleSnapshots[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolumeSnapshot(*pb.DeleteVolumeSnapshotOpts) error {
+func (fvc *fakeVolumeController) DeleteVolumeSnapshot(*model.DockSpec, *pb.DeleteVolumeSnapshotOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) AttachVolume(*pb.AttachVolumeOpts) (string, error) {
+func (fvc *fakeVolumeController) PullVolume(*model.DockSpec, *pb.PullVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+func (fvc *fakeVolumeController) PullVolumeSnapshot(*model.DockSpec, *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	return &SampleSnapshots[0], nil
+}
+
+func (fvc *fakeVolumeController) AttachVolume(*model.DockSpec, *pb.AttachVolumeOpts) (string, error) {
 	return "/dev/disk/by-path/ip-192.168.56.100:3260-iscsi-iqn.2017-10.io.opensds:baec258b-8f79-4bbc-bf97-28addfa903d3-lun-1", nil
 }
 
-func (fvc *fakeVolumeController) DetachVolume(*pb.DetachVolumeOpts) error {
+func (fvc *fakeVolumeController) DetachVolume(*model.DockSpec, *pb.DetachVolumeOpts) error {
+	return nil
+}
+
+func (fvc *fakeVolumeController) CopyVolume(*model.DockSpec, *pb.CopyVolumeOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) CreateReplication(opts *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
+func (fvc *fakeVolumeController) CreateReplication(dock *model.DockSpec, opts *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
 	return &SampleReplications[0], nil
 }
 
-func (fvc *fakeVolumeController) DeleteReplication(opt *pb.DeleteReplicationOpts) error {
+func (fvc *fakeVolumeController) DeleteReplication(dock *model.DockSpec, opt *pb.DeleteReplicationOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) EnableReplication(opt *pb.EnableReplicationOpts) error {
+func (fvc *fakeVolumeController) EnableReplication(dock *model.DockSpec, opt *pb.EnableReplicationOpts) error {
 	return nil
 
 }
 
-func (fvc *fakeVolumeController) DisableReplication(opt *pb.DisableReplicationOpts) error {
+func (fvc *fakeVolumeController) DisableReplication(dock *model.DockSpec, opt *pb.DisableReplicationOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) FailoverReplication(opt *pb.FailoverReplicationOpts) error {
+func (fvc *fakeVolumeController) FailoverReplication(dock *model.DockSpec, opt *pb.FailoverReplicationOpts) error {
 	return nil
 }
-func (fvc *fakeVolumeController) CreateVolumeGroup(*pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
+func (fvc *fakeVolumeController) CreateVolumeGroup(*model.DockSpec, *pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
 	return nil, nil
 }
 
-func (fvc *fakeVolumeController) UpdateVolumeGroup(*pb.UpdateVolumeGroupOpts) error {
+func (fvc *fakeVolumeController) UpdateVolumeGroup(*model.DockSpec, *pb.UpdateVolumeGroupOpts) error {
 	return nil
 }
 
-func (fvc *fakeVolumeController) DeleteVolumeGroup(*pb.DeleteVolumeGroupOpts) error {
+func (fvc *fakeVolumeController) DeleteVolumeGroup(*model.DockSpec, *pb.DeleteVolumeGroupOpts) error {
+	return nil
+}
+
+func (fvc *fakeVolumeController) CreateGroupSnapshot(*model.DockSpec, *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	return nil, nil
+}
+
+func (fvc *fakeVolumeController) DeleteGroupSnapshot(*model.DockSpec, *pb.DeleteGroupSnapshotOpts) error {
 	return nil
 }
-func (fvc *fakeVolumeController) SetDock(dockInfo *model.DockSpec) { return }
 
 var (
 	pool = model.StoragePoolSpec{
@@ -270,6 +301,7 @@ func TestArrayBasedDeleteReplication(t *testing.T) {
 	mockClient.On("GetDock", context.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
 	mockClient.On("GetDockByPoolId", context.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").Return(&SampleDocks[0], nil)
 	mockClient.On("DeleteReplication", context.NewAdminContext(), "c299a978-4f3e-11e8-8a5c-977218a83359").Return(nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Replications: 1}).Return(nil)
 	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(&SampleVolumes[0], nil)
 	db.C = mockClient
 
@@ -299,6 +331,7 @@ func TestHostBasedDeleteReplication(t *testing.T) {
 	mockClient.On("GetDock", context.NewAdminContext(), mock.Anything).Return(&SampleDocks[0], nil)
 	mockClient.On("GetDockByPoolId", context.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").Return(&SampleDocks[0], nil)
 	mockClient.On("DeleteReplication", context.NewAdminContext(), "c299a978-4f3e-11e8-8a5c-977218a83359").Return(nil)
+	mockClient.On("ReleaseQuota", context.NewAdminContext(), "", &model.QuotaUsageSpec{Replications: 1}).Return(nil)
 	mockClient.On("GetVolumeAttachment", context.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").Return(&SampleAttachments[0], nil)
 	mockClient.On("DeleteVolumeAttachment", context.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").Return(nil)
 	mockClient.On("UpdateVolume", context.NewAdminContext(), mock.Anything).Return(&SampleVolumes[0], nil)
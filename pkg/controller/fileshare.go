@@ -0,0 +1,304 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the management of file shares and their access rules
+in the OpenSDS northbound service.
+
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+)
+
+// CreateFileShare selects a pool of file storage type for the file share and
+// creates it on the dock which the pool belongs to.
+func (c *Controller) CreateFileShare(ctx *c.Context, in *model.FileShareSpec, errchan chan error) {
+	var fail = func(err error) {
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareError); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	var prf *model.ProfileSpec
+	var err error
+	if in.ProfileId == "" {
+		log.Warning("Use default profile when user doesn't specify profile.")
+		prf, err = db.C.GetDefaultProfile(ctx)
+	} else {
+		prf, err = db.C.GetProfile(ctx, in.ProfileId)
+	}
+	if err != nil {
+		log.Error("Get profile failed: ", err)
+		fail(err)
+		return
+	}
+	in.ProfileId = prf.Id
+
+	polInfo, err := c.selector.SelectSupportedPoolForFileShare(in)
+	if err != nil {
+		log.Error("When select pool for file share:", err)
+		fail(err)
+		return
+	}
+	dockInfo, err := db.C.GetDock(ctx, polInfo.DockId)
+	if err != nil {
+		log.Error("When search supported dock resource:", err)
+		fail(err)
+		return
+	}
+
+	opt := &pb.CreateFileShareOpts{
+		Id:               in.Id,
+		Name:             in.Name,
+		Description:      in.Description,
+		Size:             in.Size,
+		AvailabilityZone: in.AvailabilityZone,
+		PoolId:           polInfo.Id,
+		PoolName:         polInfo.Name,
+		ProfileId:        prf.Id,
+		Protocol:         in.Protocol,
+		Metadata:         in.Metadata,
+		DriverName:       dockInfo.DriverName,
+		Context:          ctx.ToJson(),
+	}
+	result, err := c.fileShareController.CreateFileShare(dockInfo, opt)
+	if err != nil {
+		log.Error("When create file share:", err)
+		fail(err)
+		return
+	}
+	result.PoolId, result.ProfileId = opt.GetPoolId(), opt.GetProfileId()
+	if errUpdate := db.C.UpdateStatus(ctx, result, model.FileShareAvailable); errUpdate != nil {
+		errchan <- errUpdate
+		return
+	}
+	errchan <- nil
+}
+
+// DeleteFileShare deletes the file share on the storage, and the access rules
+// of it are removed along with the file share.
+func (c *Controller) DeleteFileShare(ctx *c.Context, in *model.FileShareSpec, errchan chan error) {
+	var fail = func(err error) {
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareErrorDeleting); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	dockInfo, err := db.C.GetDockByPoolId(ctx, in.PoolId)
+	if err != nil {
+		log.Error("When search dock in db by pool id: ", err)
+		fail(err)
+		return
+	}
+
+	if err = c.fileShareController.DeleteFileShare(dockInfo, &pb.DeleteFileShareOpts{
+		Id:         in.Id,
+		Metadata:   in.Metadata,
+		DriverName: dockInfo.DriverName,
+		Context:    ctx.ToJson(),
+	}); err != nil {
+		log.Error("When delete file share:", err)
+		fail(err)
+		return
+	}
+
+	acls, err := db.C.ListFileShareAcls(ctx)
+	if err != nil {
+		log.Error("When list file share acls in db:", err)
+		errchan <- err
+		return
+	}
+	for _, acl := range acls {
+		if acl.FileShareId != in.Id {
+			continue
+		}
+		if err = db.C.DeleteFileShareAcl(ctx, acl.Id); err != nil {
+			log.Error("When delete file share acl in db:", err)
+			errchan <- err
+			return
+		}
+	}
+	if err = db.C.DeleteFileShare(ctx, in.Id); err != nil {
+		log.Error("When delete file share in db:", err)
+		errchan <- err
+		return
+	}
+	errchan <- nil
+}
+
+// ExtendFileShare extends the file share to the new size. The status of it is
+// restored to available if the extension is rejected before reaching the
+// backend, since the file share is still usable with the original size, and
+// is set to errorExtending if the backend fails in the middle of it.
+func (c *Controller) ExtendFileShare(ctx *c.Context, in *model.FileShareSpec, newSize int64, errchan chan error) {
+	var fail = func(err error) {
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareAvailable); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	if newSize <= in.Size {
+		fail(fmt.Errorf("new size for extend must be greater than current size "+
+			"(current: %d GB, extended: %d GB)", in.Size, newSize))
+		return
+	}
+	pool, err := db.C.GetPool(ctx, in.PoolId)
+	if err != nil {
+		log.Error("Get pool failed in extend file share method: ", err)
+		fail(err)
+		return
+	}
+	if pool.FreeCapacity < newSize-in.Size {
+		fail(fmt.Errorf("pool free capacity(%d) < new size(%d) - old size(%d)",
+			pool.FreeCapacity, newSize, in.Size))
+		return
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, in.PoolId)
+	if err != nil {
+		log.Error("When search dock in db by pool id: ", err)
+		fail(err)
+		return
+	}
+
+	if _, err = c.fileShareController.ExtendFileShare(dockInfo, &pb.ExtendFileShareOpts{
+		Id:         in.Id,
+		Size:       newSize,
+		PoolName:   pool.Name,
+		Metadata:   in.Metadata,
+		DriverName: dockInfo.DriverName,
+		Context:    ctx.ToJson(),
+	}); err != nil {
+		log.Error("When extend file share:", err)
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareErrorExtending); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+		return
+	}
+	in.Size = newSize
+	if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareAvailable); errUpdate != nil {
+		errchan <- errUpdate
+		return
+	}
+	errchan <- nil
+}
+
+// CreateFileShareAcl grants the access to the file share to the clients
+// specified in the access rule.
+func (c *Controller) CreateFileShareAcl(ctx *c.Context, in *model.FileShareAclSpec, errchan chan error) {
+	var fail = func(err error) {
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareAclError); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	share, err := db.C.GetFileShare(ctx, in.FileShareId)
+	if err != nil {
+		log.Error("Get file share failed in create file share acl method: ", err)
+		fail(err)
+		return
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, share.PoolId)
+	if err != nil {
+		log.Error("When search dock in db by pool id: ", err)
+		fail(err)
+		return
+	}
+
+	result, err := c.fileShareController.CreateFileShareAcl(dockInfo, &pb.CreateFileShareAclOpts{
+		Id:          in.Id,
+		FileShareId: in.FileShareId,
+		Type:        in.Type,
+		AccessTo:    in.AccessTo,
+		AccessLevel: in.AccessLevel,
+		Metadata:    utils.MergeStringMaps(in.Metadata, share.Metadata),
+		DriverName:  dockInfo.DriverName,
+		Context:     ctx.ToJson(),
+	})
+	if err != nil {
+		log.Error("When create file share acl:", err)
+		fail(err)
+		return
+	}
+	in.Metadata = utils.MergeStringMaps(in.Metadata, result.Metadata)
+	if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareAclAvailable); errUpdate != nil {
+		errchan <- errUpdate
+		return
+	}
+	errchan <- nil
+}
+
+// DeleteFileShareAcl revokes the access to the file share from the clients
+// specified in the access rule.
+func (c *Controller) DeleteFileShareAcl(ctx *c.Context, in *model.FileShareAclSpec, errchan chan error) {
+	var fail = func(err error) {
+		if errUpdate := db.C.UpdateStatus(ctx, in, model.FileShareAclErrorDeleting); errUpdate != nil {
+			errchan <- errUpdate
+			return
+		}
+		errchan <- err
+	}
+
+	share, err := db.C.GetFileShare(ctx, in.FileShareId)
+	if err != nil {
+		log.Error("Get file share failed in delete file share acl method: ", err)
+		fail(err)
+		return
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, share.PoolId)
+	if err != nil {
+		log.Error("When search dock in db by pool id: ", err)
+		fail(err)
+		return
+	}
+
+	if err = c.fileShareController.DeleteFileShareAcl(dockInfo, &pb.DeleteFileShareAclOpts{
+		Id:          in.Id,
+		FileShareId: in.FileShareId,
+		Type:        in.Type,
+		AccessTo:    in.AccessTo,
+		Metadata:    utils.MergeStringMaps(in.Metadata, share.Metadata),
+		DriverName:  dockInfo.DriverName,
+		Context:     ctx.ToJson(),
+	}); err != nil {
+		log.Error("When delete file share acl:", err)
+		fail(err)
+		return
+	}
+	if err = db.C.DeleteFileShareAcl(ctx, in.Id); err != nil {
+		log.Error("When delete file share acl in db:", err)
+		errchan <- err
+		return
+	}
+	errchan <- nil
+}
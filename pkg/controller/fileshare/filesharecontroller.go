@@ -0,0 +1,184 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS file share controller service.
+
+*/
+
+package fileshare
+
+import (
+	"encoding/json"
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/pkg/dock/client"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"golang.org/x/net/context"
+)
+
+// Controller is an interface for exposing some operations of different file
+// share controllers.
+type Controller interface {
+	CreateFileShare(dock *model.DockSpec, opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error)
+
+	DeleteFileShare(dock *model.DockSpec, opt *pb.DeleteFileShareOpts) error
+
+	ExtendFileShare(dock *model.DockSpec, opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error)
+
+	CreateFileShareAcl(dock *model.DockSpec, opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error)
+
+	DeleteFileShareAcl(dock *model.DockSpec, opt *pb.DeleteFileShareAclOpts) error
+}
+
+// NewController method creates a controller structure and expose its pointer.
+// The requests are sent to the docks with the clients from pool, which is
+// safe to be shared by concurrent requests.
+func NewController(pool *client.Pool) Controller {
+	return &controller{
+		pool: pool,
+	}
+}
+
+type controller struct {
+	pool *client.Pool
+}
+
+func (c *controller) CreateFileShare(dock *model.DockSpec, opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.CreateFileShare(context.Background(), opt)
+	if err != nil {
+		log.Error("Create file share failed in file share controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return nil,
+			fmt.Errorf("failed to create file share in file share controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var share = &model.FileShareSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), share); err != nil {
+		log.Error("Create file share failed in file share controller:", err)
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (c *controller) DeleteFileShare(dock *model.DockSpec, opt *pb.DeleteFileShareOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return err
+	}
+
+	response, err := cli.DeleteFileShare(context.Background(), opt)
+	if err != nil {
+		log.Error("Delete file share failed in file share controller:", err)
+		return err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return fmt.Errorf("failed to delete file share in file share controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	return nil
+}
+
+func (c *controller) ExtendFileShare(dock *model.DockSpec, opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.ExtendFileShare(context.Background(), opt)
+	if err != nil {
+		log.Error("Extend file share failed in file share controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return nil,
+			fmt.Errorf("failed to extend file share in file share controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var share = &model.FileShareSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), share); err != nil {
+		log.Error("Extend file share failed in file share controller:", err)
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (c *controller) CreateFileShareAcl(dock *model.DockSpec, opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.CreateFileShareAcl(context.Background(), opt)
+	if err != nil {
+		log.Error("Create file share acl failed in file share controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return nil,
+			fmt.Errorf("failed to create file share acl in file share controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var acl = &model.FileShareAclSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), acl); err != nil {
+		log.Error("Create file share acl failed in file share controller:", err)
+		return nil, err
+	}
+
+	return acl, nil
+}
+
+func (c *controller) DeleteFileShareAcl(dock *model.DockSpec, opt *pb.DeleteFileShareAclOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return err
+	}
+
+	response, err := cli.DeleteFileShareAcl(context.Background(), opt)
+	if err != nil {
+		log.Error("Delete file share acl failed in file share controller:", err)
+		return err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return fmt.Errorf("failed to delete file share acl in file share controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	return nil
+}
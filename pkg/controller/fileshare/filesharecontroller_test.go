@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileshare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensds/opensds/pkg/dock/client"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dockclient "github.com/opensds/opensds/testutils/dock/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+func resultOf(msg string) *pb.GenericResponse {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: msg,
+			},
+		},
+	}
+}
+
+var fakeDock = &model.DockSpec{Endpoint: "localhost:50050"}
+
+func newFakeController(method, msg string) (Controller, *dockclient.Client) {
+	cli := new(dockclient.Client)
+	cli.On("Connect", fakeDock.Endpoint).Return(nil)
+	cli.On(method, mock.Anything, mock.Anything).Return(resultOf(msg), nil)
+	return &controller{
+		pool: client.NewPool(func() client.Client { return cli }),
+	}, cli
+}
+
+func TestCreateFileShare(t *testing.T) {
+	fc, cli := newFakeController("CreateFileShare", ByteFileShare)
+	var expected = &SampleFileShares[0]
+
+	result, err := fc.CreateFileShare(fakeDock, &pb.CreateFileShareOpts{})
+	if err != nil {
+		t.Errorf("Failed to create file share, err is %v\n", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+	cli.AssertCalled(t, "Connect", fakeDock.Endpoint)
+	// The client is kept in the pool for the later requests to the dock.
+	cli.AssertNotCalled(t, "Close")
+}
+
+func TestDeleteFileShare(t *testing.T) {
+	fc, _ := newFakeController("DeleteFileShare", "")
+
+	if err := fc.DeleteFileShare(fakeDock, &pb.DeleteFileShareOpts{}); err != nil {
+		t.Errorf("Expected %v, got %v\n", nil, err)
+	}
+}
+
+func TestExtendFileShare(t *testing.T) {
+	fc, _ := newFakeController("ExtendFileShare", ByteFileShare)
+	var expected = &SampleFileShares[0]
+
+	result, err := fc.ExtendFileShare(fakeDock, &pb.ExtendFileShareOpts{})
+	if err != nil {
+		t.Errorf("Failed to extend file share, err is %v\n", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+}
+
+func TestCreateFileShareAcl(t *testing.T) {
+	fc, _ := newFakeController("CreateFileShareAcl", ByteFileShareAcl)
+	var expected = &SampleFileShareAcls[0]
+
+	result, err := fc.CreateFileShareAcl(fakeDock, &pb.CreateFileShareAclOpts{})
+	if err != nil {
+		t.Errorf("Failed to create file share acl, err is %v\n", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+}
+
+func TestDeleteFileShareAcl(t *testing.T) {
+	fc, _ := newFakeController("DeleteFileShareAcl", "")
+
+	if err := fc.DeleteFileShareAcl(fakeDock, &pb.DeleteFileShareAclOpts{}); err != nil {
+		t.Errorf("Expected %v, got %v\n", nil, err)
+	}
+}
+
+func TestCreateFileShareWithError(t *testing.T) {
+	cli := new(dockclient.Client)
+	cli.On("Connect", fakeDock.Endpoint).Return(nil)
+	cli.On("CreateFileShare", mock.Anything, mock.Anything).Return(&pb.GenericResponse{
+		Reply: &pb.GenericResponse_Error_{
+			Error: &pb.GenericResponse_Error{Code: "400", Description: "no space left"},
+		},
+	}, nil)
+	fc := &controller{pool: client.NewPool(func() client.Client { return cli })}
+
+	if _, err := fc.CreateFileShare(fakeDock, &pb.CreateFileShareOpts{}); err == nil {
+		t.Error("Expected error of creating file share")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeFileShareController struct {
+	extended  int64
+	extendErr error
+}
+
+func (f *fakeFileShareController) CreateFileShare(dock *model.DockSpec, opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error) {
+	share := SampleFileShares[0]
+	return &share, nil
+}
+
+func (f *fakeFileShareController) DeleteFileShare(dock *model.DockSpec, opt *pb.DeleteFileShareOpts) error {
+	return nil
+}
+
+func (f *fakeFileShareController) ExtendFileShare(dock *model.DockSpec, opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error) {
+	if f.extendErr != nil {
+		return nil, f.extendErr
+	}
+	f.extended = opt.GetSize()
+	share := SampleFileShares[0]
+	return &share, nil
+}
+
+func (f *fakeFileShareController) CreateFileShareAcl(dock *model.DockSpec, opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error) {
+	acl := SampleFileShareAcls[0]
+	return &acl, nil
+}
+
+func (f *fakeFileShareController) DeleteFileShareAcl(dock *model.DockSpec, opt *pb.DeleteFileShareAclOpts) error {
+	return nil
+}
+
+func TestCreateFileShare(t *testing.T) {
+	var share = SampleFileShares[0]
+	share.PoolId = ""
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetProfile", context.NewAdminContext(), share.ProfileId).Return(&SampleProfiles[0], nil)
+	mockClient.On("GetDock", context.NewAdminContext(), SamplePools[0].DockId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), mock.Anything, mock.Anything).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{
+		selector:            &fakeSelector{res: &SamplePools[0]},
+		fileShareController: &fakeFileShareController{},
+	}
+	var errchan = make(chan error, 1)
+	c.CreateFileShare(context.NewAdminContext(), &share, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to create file share:", err)
+	}
+	var result = mockClient.Calls[len(mockClient.Calls)-1].Arguments.Get(1).(*model.FileShareSpec)
+	if result.Status != model.FileShareAvailable || result.PoolId != SamplePools[0].Id ||
+		len(result.ExportLocations) != 1 {
+		t.Errorf("Unexpected file share %+v", result)
+	}
+}
+
+func TestDeleteFileShare(t *testing.T) {
+	var share = SampleFileShares[0]
+	var acls = []*model.FileShareAclSpec{
+		&SampleFileShareAcls[0],
+		{BaseModel: &model.BaseModel{Id: "another-acl"}, FileShareId: "another-share"},
+	}
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), share.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("ListFileShareAcls", context.NewAdminContext()).Return(acls, nil)
+	mockClient.On("DeleteFileShareAcl", context.NewAdminContext(), SampleFileShareAcls[0].Id).Return(nil)
+	mockClient.On("DeleteFileShare", context.NewAdminContext(), share.Id).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{fileShareController: &fakeFileShareController{}}
+	var errchan = make(chan error, 1)
+	c.DeleteFileShare(context.NewAdminContext(), &share, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to delete file share:", err)
+	}
+	// Only the access rules of the file share are removed.
+	mockClient.AssertNumberOfCalls(t, "DeleteFileShareAcl", 1)
+	mockClient.AssertCalled(t, "DeleteFileShare", context.NewAdminContext(), share.Id)
+}
+
+func TestExtendFileShare(t *testing.T) {
+	var share = SampleFileShares[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetPool", context.NewAdminContext(), share.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), share.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), mock.Anything, mock.Anything).Return(nil)
+	db.C = mockClient
+
+	var fc = &fakeFileShareController{}
+	var c = &Controller{fileShareController: fc}
+	var errchan = make(chan error, 1)
+
+	c.ExtendFileShare(context.NewAdminContext(), &share, 2, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to extend file share:", err)
+	}
+	if fc.extended != 2 || share.Size != 2 || share.Status != model.FileShareAvailable {
+		t.Errorf("Unexpected file share %+v", share)
+	}
+
+	// The file share can't be shrunk.
+	c.ExtendFileShare(context.NewAdminContext(), &share, 1, errchan)
+	if err := <-errchan; err == nil {
+		t.Error("Expected error when the new size is smaller")
+	}
+}
+
+func TestExtendFileShareFailed(t *testing.T) {
+	var share = SampleFileShares[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetPool", context.NewAdminContext(), share.PoolId).Return(&SamplePools[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), share.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), mock.Anything, mock.Anything).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{fileShareController: &fakeFileShareController{extendErr: errors.New("resize2fs failed")}}
+	var errchan = make(chan error, 1)
+
+	c.ExtendFileShare(context.NewAdminContext(), &share, 2, errchan)
+	if err := <-errchan; err == nil {
+		t.Fatal("Expected error when the backend failed to extend file share")
+	}
+	// The file share may have been partially extended on the backend.
+	mockClient.AssertCalled(t, "UpdateStatus", context.NewAdminContext(), &share, model.FileShareErrorExtending)
+	if share.Size != SampleFileShares[0].Size {
+		t.Errorf("Expected size %d, got %d", SampleFileShares[0].Size, share.Size)
+	}
+}
+
+func TestCreateFileShareAcl(t *testing.T) {
+	var acl = SampleFileShareAcls[0]
+	acl.Status = model.FileShareAclApplying
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShare", context.NewAdminContext(), acl.FileShareId).Return(&SampleFileShares[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), SampleFileShares[0].PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", context.NewAdminContext(), &acl, model.FileShareAclAvailable).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{fileShareController: &fakeFileShareController{}}
+	var errchan = make(chan error, 1)
+	c.CreateFileShareAcl(context.NewAdminContext(), &acl, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to create file share acl:", err)
+	}
+	mockClient.AssertCalled(t, "UpdateStatus", context.NewAdminContext(), &acl, model.FileShareAclAvailable)
+}
+
+func TestDeleteFileShareAcl(t *testing.T) {
+	var acl = SampleFileShareAcls[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetFileShare", context.NewAdminContext(), acl.FileShareId).Return(&SampleFileShares[0], nil)
+	mockClient.On("GetDockByPoolId", context.NewAdminContext(), SampleFileShares[0].PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("DeleteFileShareAcl", context.NewAdminContext(), acl.Id).Return(nil)
+	db.C = mockClient
+
+	var c = &Controller{fileShareController: &fakeFileShareController{}}
+	var errchan = make(chan error, 1)
+	c.DeleteFileShareAcl(context.NewAdminContext(), &acl, errchan)
+	if err := <-errchan; err != nil {
+		t.Fatal("Failed to delete file share acl:", err)
+	}
+	mockClient.AssertCalled(t, "DeleteFileShareAcl", context.NewAdminContext(), acl.Id)
+}
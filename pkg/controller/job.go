@@ -0,0 +1,806 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the jobs of controller, which persist the operations
+running in the background so that they are retried when they fail and
+reconciled when the controller restarts in the middle of them.
+
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+	"github.com/opensds/opensds/pkg/utils/config"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/satori/go.uuid"
+)
+
+const (
+	// The keys of the parameters of jobs.
+	JobNewSizeKey    = "newSize"
+	JobSnapshotIdKey = "snapshotId"
+	JobPoolIdKey     = "poolId"
+	JobProfileIdKey  = "profileId"
+
+	jobQueueSize    = 1024
+	jobBackoffBase  = 5 * time.Second
+	jobBackoffLimit = 5 * time.Minute
+)
+
+// jobHost is the host recorded in the jobs submitted by this osdslet, which
+// only runs and recovers its own jobs.
+var jobHost, _ = os.Hostname()
+
+// jobHeartbeatId returns the id of the heartbeat which the osdslet on host
+// keeps while it runs the jobs of the host.
+func jobHeartbeatId(host string) string {
+	return "osdslet-" + host
+}
+
+// liveJobHosts returns the ids of the heartbeats which have not lapsed, the
+// hosts of which are running their jobs.
+func liveJobHosts(ctx *c.Context) (map[string]bool, error) {
+	ids, err := db.C.ListHeartbeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var alive = make(map[string]bool)
+	for _, id := range ids {
+		alive[id] = true
+	}
+	return alive, nil
+}
+
+// isOrphaned reports whether the job is left by an osdslet which has stopped,
+// that is the heartbeat of its host has lapsed and it has not been updated for
+// longer than lease by now.
+func isOrphaned(job *model.JobSpec, alive map[string]bool, now time.Time, lease time.Duration) bool {
+	return !alive[jobHeartbeatId(job.Host)] && isStale(job.BaseModel, now, lease)
+}
+
+// jobQueue holds the jobs waiting for the workers, a job is queued at most
+// once no matter how many times it is pushed.
+type jobQueue struct {
+	sync.Mutex
+	ch     chan *model.JobSpec
+	queued map[string]bool
+}
+
+var jobs = &jobQueue{
+	ch:     make(chan *model.JobSpec, jobQueueSize),
+	queued: make(map[string]bool),
+}
+
+// push queues the job without blocking, the job left out when the queue is
+// full is picked up by the next sweep.
+func (q *jobQueue) push(job *model.JobSpec) {
+	q.Lock()
+	defer q.Unlock()
+	if q.queued[job.Id] {
+		return
+	}
+	select {
+	case q.ch <- job:
+		q.queued[job.Id] = true
+	default:
+		log.Warningf("Job queue is full, job %s is deferred", job.Id)
+	}
+}
+
+func (q *jobQueue) done(id string) {
+	q.Lock()
+	defer q.Unlock()
+	delete(q.queued, id)
+}
+
+// jobHandler describes how a type of job is run. A retryable job is run again
+// after it fails, and a job with reconcile is checked against the backend
+// when the controller restarts in the middle of it, which reports whether the
+// operation has completed.
+type jobHandler struct {
+	retryable bool
+	run       func(ctx *c.Context, job *model.JobSpec) error
+	reconcile func(ctx *c.Context, job *model.JobSpec) (bool, error)
+}
+
+var jobHandlers = map[string]*jobHandler{
+	model.JobCreateVolume:        {retryable: true, run: runCreateVolume, reconcile: reconcileCreateVolume},
+	model.JobDeleteVolume:        {retryable: true, run: runDeleteVolume, reconcile: reconcileDeleteVolume},
+	model.JobExtendVolume:        {run: runExtendVolume, reconcile: reconcileExtendVolume},
+	model.JobRevertVolume:        {run: runRevertVolume},
+	model.JobMigrateVolume:       {run: runMigrateVolume},
+	model.JobRetypeVolume:        {run: runRetypeVolume},
+	model.JobCreateAttachment:    {run: runCreateAttachment},
+	model.JobDeleteAttachment:    {run: runDeleteAttachment},
+	model.JobCreateSnapshot:      {retryable: true, run: runCreateSnapshot, reconcile: reconcileCreateSnapshot},
+	model.JobDeleteSnapshot:      {retryable: true, run: runDeleteSnapshot, reconcile: reconcileDeleteSnapshot},
+	model.JobCreateGroupSnapshot: {run: runCreateGroupSnapshot},
+	model.JobDeleteGroupSnapshot: {run: runDeleteGroupSnapshot},
+	model.JobCreateVolumeGroup:   {run: runCreateVolumeGroup},
+	model.JobCreateFileShare:     {run: runCreateFileShare},
+	model.JobDeleteFileShare:     {run: runDeleteFileShare},
+	model.JobExtendFileShare:     {run: runExtendFileShare},
+	model.JobCreateFileShareAcl:  {run: runCreateFileShareAcl},
+	model.JobDeleteFileShareAcl:  {run: runDeleteFileShareAcl},
+}
+
+// SubmitJob persists a job of the operation on the resource and queues it,
+// the operation is run by the job workers in the background.
+func SubmitJob(ctx *c.Context, jobType, resourceId string, params map[string]string) (*model.JobSpec, error) {
+	handler, ok := jobHandlers[jobType]
+	if !ok {
+		return nil, fmt.Errorf("job type %s is not supported", jobType)
+	}
+	var maxAttempts int64 = 1
+	if handler.retryable && config.CONF.OsdsLet.JobMaxAttempts > 1 {
+		maxAttempts = int64(config.CONF.OsdsLet.JobMaxAttempts)
+	}
+
+	job := &model.JobSpec{
+		BaseModel: &model.BaseModel{
+			Id:        uuid.NewV4().String(),
+			CreatedAt: time.Now().Format(constants.TimeFormat),
+		},
+		TenantId:    ctx.TenantId,
+		UserId:      ctx.UserId,
+		Type:        jobType,
+		ResourceId:  resourceId,
+		Parameters:  params,
+		Status:      model.JobPending,
+		Host:        jobHost,
+		MaxAttempts: maxAttempts,
+	}
+	result, err := db.C.CreateJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	jobs.push(result)
+	return result, nil
+}
+
+// RunJobs recovers the jobs interrupted by the last restart, then runs the
+// jobs with the workers and sweeps the pending jobs which are due for retry
+// every interval until stop is closed. The heartbeat of the host is put every
+// interval as well, which tells the other osdslets that its jobs are not
+// orphaned.
+func RunJobs(workers int, interval time.Duration, stop <-chan struct{}) {
+	unreconciled, err := RecoverJobs(c.NewAdminContext(), jobHost)
+	if err != nil {
+		log.Error("When recover jobs:", err)
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				case job := <-jobs.ch:
+					runJob(job.Id)
+					jobs.done(job.Id)
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := db.C.Heartbeat(c.NewAdminContext(), jobHeartbeatId(jobHost), 3*interval); err != nil {
+			log.Error("When put the heartbeat of jobs:", err)
+		}
+		if len(unreconciled) > 0 {
+			unreconciled = reconcileJobs(c.NewAdminContext(), unreconciled)
+		}
+		if err := sweepJobs(c.NewAdminContext(), time.Now()); err != nil {
+			log.Error("When sweep jobs:", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepJobs queues the pending jobs of this host which are due by now.
+func sweepJobs(ctx *c.Context, now time.Time) error {
+	pending, err := db.C.ListJobsWithFilter(ctx, map[string][]string{
+		"Status": {model.JobPending},
+		"Host":   {jobHost},
+	})
+	if err != nil {
+		return err
+	}
+	for _, job := range pending {
+		if job.NextRunAt != "" {
+			next, err := time.Parse(time.RFC3339, job.NextRunAt)
+			if err == nil && next.After(now) {
+				continue
+			}
+		}
+		jobs.push(job)
+	}
+	return nil
+}
+
+// runJob runs the pending job and records the result. A retryable job which
+// fails is scheduled to run again with exponential backoff until it uses up
+// its attempts.
+func runJob(id string) {
+	job, err := db.C.GetJob(c.NewAdminContext(), id)
+	if err != nil {
+		log.Errorf("Get job %s failed: %v", id, err)
+		return
+	}
+	if job.Status != model.JobPending {
+		return
+	}
+	handler, ok := jobHandlers[job.Type]
+	if !ok {
+		finishJob(job, fmt.Errorf("job type %s is not supported", job.Type))
+		return
+	}
+
+	// Claim the job before running it, the update fails if another worker
+	// has claimed it in the meantime.
+	job.Status = model.JobRunning
+	job.Attempts++
+	job.NextRunAt = ""
+	if job, err = db.C.UpdateJob(c.NewAdminContext(), job); err != nil {
+		log.Errorf("Claim job %s failed: %v", id, err)
+		return
+	}
+
+	err = handler.run(c.NewInternalTenantContext(job.TenantId, job.UserId), job)
+	if err != nil && handler.retryable && job.Attempts < job.MaxAttempts {
+		log.Errorf("Job %s of %s %s failed and will be retried: %v", job.Id, job.Type, job.ResourceId, err)
+		job.Status = model.JobPending
+		job.Error = err.Error()
+		job.NextRunAt = time.Now().Add(jobBackoff(job.Attempts)).UTC().Format(time.RFC3339)
+		if _, err := db.C.UpdateJob(c.NewAdminContext(), job); err != nil {
+			log.Errorf("Update job %s failed: %v", job.Id, err)
+		}
+		return
+	}
+	finishJob(job, err)
+}
+
+// finishJob marks the job as succeeded, or failed with the error.
+func finishJob(job *model.JobSpec, err error) {
+	if err != nil {
+		log.Errorf("Job %s of %s %s failed: %v", job.Id, job.Type, job.ResourceId, err)
+		job.Status = model.JobFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = model.JobSucceeded
+		job.Error = ""
+	}
+	job.NextRunAt = ""
+	if _, err := db.C.UpdateJob(c.NewAdminContext(), job); err != nil {
+		log.Errorf("Update job %s failed: %v", job.Id, err)
+	}
+}
+
+// jobBackoff returns the delay before the next attempt after the given
+// number of attempts.
+func jobBackoff(attempts int64) time.Duration {
+	backoff := jobBackoffBase
+	for i := int64(1); i < attempts && backoff < jobBackoffLimit; i++ {
+		backoff *= 2
+	}
+	if backoff > jobBackoffLimit {
+		backoff = jobBackoffLimit
+	}
+	return backoff
+}
+
+// RecoverJobs reconciles the jobs of the host which were running when the
+// controller stopped. A job whose operation has completed on the backend is
+// marked as succeeded, one which can be checked but has not completed is run
+// again, and the others are marked as failed. The jobs which fail to be
+// reconciled are kept running and returned, they should be reconciled again
+// later.
+func RecoverJobs(ctx *c.Context, host string) ([]*model.JobSpec, error) {
+	running, err := db.C.ListJobsWithFilter(ctx, map[string][]string{
+		"Status": {model.JobRunning},
+		"Host":   {host},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reconcileJobs(ctx, running), nil
+}
+
+// AdoptOrphanedJobs claims the pending and running jobs orphaned by the
+// osdslets which have stopped for longer than lease by now, so that they are
+// run or reconciled on this host. A job is claimed by updating it based on the
+// version listed, the same as it's claimed before running, so it's adopted by
+// one osdslet only. The adopted running jobs are reconciled as the interrupted
+// ones, and the ones which fail to be reconciled are returned.
+func AdoptOrphanedJobs(ctx *c.Context, now time.Time, lease time.Duration) ([]*model.JobSpec, error) {
+	alive, err := liveJobHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var interrupted []*model.JobSpec
+	for _, status := range []string{model.JobPending, model.JobRunning} {
+		list, err := db.C.ListJobsWithFilter(ctx, map[string][]string{"Status": {status}})
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range list {
+			if job.Host == jobHost || !isOrphaned(job, alive, now, lease) {
+				continue
+			}
+			log.Warningf("Job %s of %s %s is orphaned by host %s, adopt it", job.Id, job.Type, job.ResourceId, job.Host)
+			job.Host = jobHost
+			claimed, err := db.C.UpdateJob(ctx, job)
+			if err != nil {
+				log.Errorf("Claim job %s failed: %v", job.Id, err)
+				continue
+			}
+			if claimed.Status == model.JobRunning {
+				interrupted = append(interrupted, claimed)
+			}
+		}
+	}
+	return reconcileJobs(ctx, interrupted), nil
+}
+
+// reconcileJobs reconciles the interrupted jobs, and returns the ones which
+// fail to be reconciled. Such a job is not run again, since its operation may
+// have been done on the backend, unless the backend reports that it's not.
+func reconcileJobs(ctx *c.Context, interrupted []*model.JobSpec) []*model.JobSpec {
+	var unreconciled []*model.JobSpec
+	for _, job := range interrupted {
+		handler, ok := jobHandlers[job.Type]
+		if !ok || handler.reconcile == nil {
+			finishJob(job, errors.New("interrupted by the restart of controller"))
+			continue
+		}
+		done, err := handler.reconcile(c.NewInternalTenantContext(job.TenantId, job.UserId), job)
+		// The resource which the job operates on no longer exists.
+		if isNotFound(err) {
+			finishJob(job, err)
+			continue
+		}
+		if err != nil {
+			log.Errorf("Reconcile job %s of %s %s failed and will be retried: %v", job.Id, job.Type, job.ResourceId, err)
+			unreconciled = append(unreconciled, job)
+			continue
+		}
+		if done {
+			finishJob(job, nil)
+			continue
+		}
+		job.Status = model.JobPending
+		job.NextRunAt = ""
+		if _, err := db.C.UpdateJob(ctx, job); err != nil {
+			log.Errorf("Update job %s failed: %v", job.Id, err)
+		}
+	}
+	return unreconciled
+}
+
+// waitFor runs the operation of Brain and waits for its result.
+func waitFor(op func(errchan chan error)) error {
+	var errchan = make(chan error, 1)
+	defer close(errchan)
+	go op(errchan)
+	return <-errchan
+}
+
+func jobSize(job *model.JobSpec) (int64, error) {
+	size, err := strconv.ParseInt(job.Parameters[JobNewSizeKey], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid new size of job %s: %v", job.Id, err)
+	}
+	return size, nil
+}
+
+func runCreateVolume(ctx *c.Context, job *model.JobSpec) error {
+	vol, err := db.C.GetVolume(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	switch vol.Status {
+	case model.VolumeAvailable:
+		return nil
+	case model.VolumeError:
+		if err := db.C.UpdateStatus(ctx, vol, model.VolumeCreating); err != nil {
+			return err
+		}
+	}
+	return waitFor(func(errchan chan error) { Brain.CreateVolume(ctx, vol, errchan) })
+}
+
+func runDeleteVolume(ctx *c.Context, job *model.JobSpec) error {
+	vol, err := db.C.GetVolume(ctx, job.ResourceId)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if vol.Status == model.VolumeErrorDeleting {
+		if err := db.C.UpdateStatus(ctx, vol, model.VolumeDeleting); err != nil {
+			return err
+		}
+	}
+	return waitFor(func(errchan chan error) { Brain.DeleteVolume(ctx, vol, errchan) })
+}
+
+func runExtendVolume(ctx *c.Context, job *model.JobSpec) error {
+	size, err := jobSize(job)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.ExtendVolume(ctx, job.ResourceId, size, errchan) })
+}
+
+func runRevertVolume(ctx *c.Context, job *model.JobSpec) error {
+	return waitFor(func(errchan chan error) {
+		Brain.RevertToSnapshot(ctx, job.ResourceId, job.Parameters[JobSnapshotIdKey], errchan)
+	})
+}
+
+func runMigrateVolume(ctx *c.Context, job *model.JobSpec) error {
+	return waitFor(func(errchan chan error) {
+		Brain.MigrateVolume(ctx, job.ResourceId, job.Parameters[JobPoolIdKey], errchan)
+	})
+}
+
+func runRetypeVolume(ctx *c.Context, job *model.JobSpec) error {
+	return waitFor(func(errchan chan error) {
+		Brain.RetypeVolume(ctx, job.ResourceId, job.Parameters[JobProfileIdKey], errchan)
+	})
+}
+
+func runCreateAttachment(ctx *c.Context, job *model.JobSpec) error {
+	atc, err := db.C.GetVolumeAttachment(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.CreateVolumeAttachment(ctx, atc, errchan) })
+}
+
+func runDeleteAttachment(ctx *c.Context, job *model.JobSpec) error {
+	atc, err := db.C.GetVolumeAttachment(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.DeleteVolumeAttachment(ctx, atc, errchan) })
+}
+
+func runCreateSnapshot(ctx *c.Context, job *model.JobSpec) error {
+	snap, err := db.C.GetVolumeSnapshot(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	switch snap.Status {
+	case model.VolumeSnapAvailable:
+		return nil
+	case model.VolumeSnapError:
+		if err := db.C.UpdateStatus(ctx, snap, model.VolumeSnapCreating); err != nil {
+			return err
+		}
+	}
+	return waitFor(func(errchan chan error) { Brain.CreateVolumeSnapshot(ctx, snap, errchan) })
+}
+
+func runDeleteSnapshot(ctx *c.Context, job *model.JobSpec) error {
+	snap, err := db.C.GetVolumeSnapshot(ctx, job.ResourceId)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if snap.Status == model.VolumeSnapErrorDeleting {
+		if err := db.C.UpdateStatus(ctx, snap, model.VolumeSnapDeleting); err != nil {
+			return err
+		}
+	}
+	return waitFor(func(errchan chan error) { Brain.DeleteVolumeSnapshot(ctx, snap, errchan) })
+}
+
+func runCreateGroupSnapshot(ctx *c.Context, job *model.JobSpec) error {
+	gs, err := db.C.GetGroupSnapshot(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.CreateGroupSnapshot(ctx, gs, errchan) })
+}
+
+func runDeleteGroupSnapshot(ctx *c.Context, job *model.JobSpec) error {
+	gs, err := db.C.GetGroupSnapshot(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.DeleteGroupSnapshot(ctx, gs, errchan) })
+}
+
+// runCreateVolumeGroup creates the group from its group snapshot, the
+// volumes of the group have been added to db along with the group.
+func runCreateVolumeGroup(ctx *c.Context, job *model.JobSpec) error {
+	vg, err := db.C.GetVolumeGroup(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	vols, err := db.C.ListVolumesByGroupId(ctx, vg.Id)
+	if err != nil {
+		return err
+	}
+	return Brain.CreateVolumeGroupFromSnapshot(ctx, vg, vols)
+}
+
+func runCreateFileShare(ctx *c.Context, job *model.JobSpec) error {
+	share, err := db.C.GetFileShare(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.CreateFileShare(ctx, share, errchan) })
+}
+
+func runDeleteFileShare(ctx *c.Context, job *model.JobSpec) error {
+	share, err := db.C.GetFileShare(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.DeleteFileShare(ctx, share, errchan) })
+}
+
+func runExtendFileShare(ctx *c.Context, job *model.JobSpec) error {
+	size, err := jobSize(job)
+	if err != nil {
+		return err
+	}
+	share, err := db.C.GetFileShare(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.ExtendFileShare(ctx, share, size, errchan) })
+}
+
+func runCreateFileShareAcl(ctx *c.Context, job *model.JobSpec) error {
+	acl, err := db.C.GetFileShareAcl(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.CreateFileShareAcl(ctx, acl, errchan) })
+}
+
+func runDeleteFileShareAcl(ctx *c.Context, job *model.JobSpec) error {
+	acl, err := db.C.GetFileShareAcl(ctx, job.ResourceId)
+	if err != nil {
+		return err
+	}
+	return waitFor(func(errchan chan error) { Brain.DeleteFileShareAcl(ctx, acl, errchan) })
+}
+
+// pullVolume fetches the volume from the backend of its pool. Only the
+// NotFoundError proves that the volume doesn't exist on the backend, and the
+// volume pulled is trusted only if it carries the same id, since the drivers
+// which don't support pulling may return an empty one.
+func pullVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error) {
+	dockInfo, err := db.C.GetDockByPoolId(ctx, vol.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	pulled, err := Brain.volumeController.PullVolume(dockInfo, &pb.PullVolumeOpts{
+		Id:         vol.Id,
+		Metadata:   vol.Metadata,
+		DriverName: dockInfo.DriverName,
+		Context:    ctx.ToJson(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pulled == nil || pulled.BaseModel == nil || pulled.Id != vol.Id {
+		return nil, fmt.Errorf("volume %s can't be verified on the backend", vol.Id)
+	}
+	return pulled, nil
+}
+
+// pullSnapshot fetches the snapshot from the backend of the pool of its
+// volume, the snapshot pulled is verified like pullVolume.
+func pullSnapshot(ctx *c.Context, snap *model.VolumeSnapshotSpec) (*model.VolumeSnapshotSpec, error) {
+	vol, err := db.C.GetVolume(ctx, snap.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+	dockInfo, err := db.C.GetDockByPoolId(ctx, vol.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	pulled, err := Brain.volumeController.PullVolumeSnapshot(dockInfo, &pb.PullVolumeSnapshotOpts{
+		Id:         snap.Id,
+		VolumeId:   snap.VolumeId,
+		Metadata:   utils.MergeStringMaps(snap.Metadata, vol.Metadata),
+		DriverName: dockInfo.DriverName,
+		Context:    ctx.ToJson(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pulled == nil || pulled.BaseModel == nil || pulled.Id != snap.Id {
+		return nil, fmt.Errorf("volume snapshot %s can't be verified on the backend", snap.Id)
+	}
+	return pulled, nil
+}
+
+// purgeVolume deletes the volume which no longer exists on the backend from
+// database, along with its snapshot schedules and quota usage.
+func purgeVolume(ctx *c.Context, vol *model.VolumeSpec) error {
+	if err := db.C.DeleteVolume(ctx, vol.Id); err != nil {
+		return err
+	}
+	if err := deleteSnapshotSchedules(ctx, vol.Id); err != nil {
+		log.Warning("Delete snapshot schedules of volume failed:", err)
+	}
+	if err := db.C.ReleaseQuota(ctx, vol.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: vol.Size}); err != nil {
+		log.Warning("Release quota of volume failed:", err)
+	}
+	return nil
+}
+
+// purgeSnapshot deletes the snapshot which no longer exists on the backend
+// from database, along with its quota usage.
+func purgeSnapshot(ctx *c.Context, snap *model.VolumeSnapshotSpec) error {
+	if err := db.C.DeleteVolumeSnapshot(ctx, snap.Id); err != nil {
+		return err
+	}
+	if err := db.C.ReleaseQuota(ctx, snap.TenantId, &model.QuotaUsageSpec{Snapshots: 1}); err != nil {
+		log.Warning("Release quota of volume snapshot failed:", err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	_, ok := err.(*model.NotFoundError)
+	return ok
+}
+
+func reconcileCreateVolume(ctx *c.Context, job *model.JobSpec) (bool, error) {
+	vol, err := db.C.GetVolume(ctx, job.ResourceId)
+	if err != nil {
+		return false, err
+	}
+	if vol.Status == model.VolumeAvailable {
+		return true, nil
+	}
+	// The volume is not placed on any pool until the pool is selected.
+	if vol.Status != model.VolumeCreating || vol.PoolId == "" {
+		return false, nil
+	}
+	pulled, err := pullVolume(ctx, vol)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	vol.Metadata = utils.MergeStringMaps(vol.Metadata, pulled.Metadata)
+	if err := db.C.UpdateStatus(ctx, vol, model.VolumeAvailable); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func reconcileDeleteVolume(ctx *c.Context, job *model.JobSpec) (bool, error) {
+	vol, err := db.C.GetVolume(ctx, job.ResourceId)
+	if isNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if vol.PoolId == "" {
+		return false, nil
+	}
+	_, err = pullVolume(ctx, vol)
+	if !isNotFound(err) {
+		return false, err
+	}
+	// The volume has been deleted from the backend but not from database.
+	if err := purgeVolume(ctx, vol); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func reconcileExtendVolume(ctx *c.Context, job *model.JobSpec) (bool, error) {
+	size, err := jobSize(job)
+	if err != nil {
+		return false, err
+	}
+	vol, err := db.C.GetVolume(ctx, job.ResourceId)
+	if err != nil {
+		return false, err
+	}
+	if vol.Size >= size {
+		return true, nil
+	}
+	pulled, err := pullVolume(ctx, vol)
+	if err != nil {
+		return false, err
+	}
+	if pulled.Size < size {
+		return false, nil
+	}
+	vol.Size = pulled.Size
+	vol.Status = model.VolumeAvailable
+	if _, err := db.C.UpdateVolume(ctx, vol); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func reconcileCreateSnapshot(ctx *c.Context, job *model.JobSpec) (bool, error) {
+	snap, err := db.C.GetVolumeSnapshot(ctx, job.ResourceId)
+	if err != nil {
+		return false, err
+	}
+	if snap.Status == model.VolumeSnapAvailable {
+		return true, nil
+	}
+	if snap.Status != model.VolumeSnapCreating {
+		return false, nil
+	}
+	pulled, err := pullSnapshot(ctx, snap)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	snap.Metadata = utils.MergeStringMaps(snap.Metadata, pulled.Metadata)
+	if err := db.C.UpdateStatus(ctx, snap, model.VolumeSnapAvailable); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func reconcileDeleteSnapshot(ctx *c.Context, job *model.JobSpec) (bool, error) {
+	snap, err := db.C.GetVolumeSnapshot(ctx, job.ResourceId)
+	if isNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = pullSnapshot(ctx, snap)
+	if !isNotFound(err) {
+		return false, err
+	}
+	// The snapshot has been deleted from the backend but not from database.
+	if err := purgeSnapshot(ctx, snap); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/controller/volume"
+	"github.com/opensds/opensds/pkg/db"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+// pullVolumeController returns the pulled volume or the error configured.
+type pullVolumeController struct {
+	volume.Controller
+	vol *model.VolumeSpec
+	err error
+}
+
+func (p *pullVolumeController) PullVolume(*model.DockSpec, *pb.PullVolumeOpts) (*model.VolumeSpec, error) {
+	return p.vol, p.err
+}
+
+// recordJobs records the status of the job every time it is updated.
+func recordJobs(mockClient *dbtest.Client) *[]model.JobSpec {
+	var updated []model.JobSpec
+	mockClient.On("UpdateJob", mock.Anything, mock.Anything).Return(
+		func(ctx *context.Context, job *model.JobSpec) *model.JobSpec {
+			updated = append(updated, *job)
+			return job
+		}, nil)
+	return &updated
+}
+
+func newSampleJob(jobType, status string, attempts, maxAttempts int64) *model.JobSpec {
+	return &model.JobSpec{
+		BaseModel:   &model.BaseModel{Id: "0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d"},
+		Type:        jobType,
+		ResourceId:  SampleVolumes[0].Id,
+		Status:      status,
+		Host:        jobHost,
+		Attempts:    attempts,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+func newSampleVolume(status string) *model.VolumeSpec {
+	return &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Size:      1,
+		Status:    status,
+		PoolId:    "084bf71e-a102-11e7-88a8-e31fe6d52248",
+	}
+}
+
+func TestSubmitJob(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("CreateJob", context.NewAdminContext(), mock.Anything).Return(
+		func(ctx *context.Context, job *model.JobSpec) *model.JobSpec { return job }, nil)
+	db.C = mockClient
+
+	job, err := SubmitJob(context.NewAdminContext(), model.JobRevertVolume, SampleVolumes[0].Id,
+		map[string]string{JobSnapshotIdKey: SampleSnapshots[0].Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		<-jobs.ch
+		jobs.done(job.Id)
+	}()
+	if job.Status != model.JobPending || job.Host != jobHost || job.MaxAttempts != 1 {
+		t.Errorf("Unexpected job submitted: %+v", job)
+	}
+	if !jobs.queued[job.Id] {
+		t.Errorf("Expected job %s to be queued", job.Id)
+	}
+
+	if _, err := SubmitJob(context.NewAdminContext(), "unknown", SampleVolumes[0].Id, nil); err == nil {
+		t.Error("Expected an error when submit job of unknown type")
+	}
+}
+
+func TestRunJobRetry(t *testing.T) {
+	var attempts int
+	jobHandlers["test"] = &jobHandler{
+		retryable: true,
+		run: func(ctx *context.Context, job *model.JobSpec) error {
+			attempts++
+			return errors.New("backend is busy")
+		},
+	}
+	defer delete(jobHandlers, "test")
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetJob", context.NewAdminContext(), mock.Anything).Return(newSampleJob("test", model.JobPending, 1, 3), nil)
+	updated := recordJobs(mockClient)
+	db.C = mockClient
+
+	runJob("0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d")
+	if attempts != 1 || len(*updated) != 2 {
+		t.Fatalf("Expected the job to be run and updated twice, actual %d runs, %d updates", attempts, len(*updated))
+	}
+	if job := (*updated)[0]; job.Status != model.JobRunning || job.Attempts != 2 {
+		t.Errorf("Expected the job to be claimed, actual %+v", job)
+	}
+	job := (*updated)[1]
+	if job.Status != model.JobPending || job.Error != "backend is busy" {
+		t.Errorf("Expected the job to be pending for retry, actual %+v", job)
+	}
+	next, err := time.Parse(time.RFC3339, job.NextRunAt)
+	if err != nil || next.Before(time.Now().Add(5*time.Second)) {
+		t.Errorf("Expected the job to be retried after backoff, actual %s", job.NextRunAt)
+	}
+}
+
+func TestRunJobFailed(t *testing.T) {
+	jobHandlers["test"] = &jobHandler{
+		retryable: true,
+		run: func(ctx *context.Context, job *model.JobSpec) error {
+			return errors.New("backend is busy")
+		},
+	}
+	defer delete(jobHandlers, "test")
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetJob", context.NewAdminContext(), mock.Anything).Return(newSampleJob("test", model.JobPending, 2, 3), nil)
+	updated := recordJobs(mockClient)
+	db.C = mockClient
+
+	runJob("0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d")
+	if len(*updated) != 2 {
+		t.Fatalf("Expected the job to be updated twice, actual %d", len(*updated))
+	}
+	if job := (*updated)[1]; job.Status != model.JobFailed || job.Attempts != 3 || job.NextRunAt != "" {
+		t.Errorf("Expected the job to fail after the last attempt, actual %+v", job)
+	}
+}
+
+func TestRunJobNotPending(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetJob", context.NewAdminContext(), mock.Anything).Return(newSampleJob(model.JobCreateVolume, model.JobSucceeded, 1, 3), nil)
+	db.C = mockClient
+
+	// The job which has been finished must not be run again.
+	runJob("0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d")
+	mockClient.AssertNotCalled(t, "UpdateJob", mock.Anything, mock.Anything)
+}
+
+func TestJobBackoff(t *testing.T) {
+	var expected = map[int64]time.Duration{
+		1:  5 * time.Second,
+		2:  10 * time.Second,
+		4:  40 * time.Second,
+		10: 5 * time.Minute,
+	}
+	for attempts, backoff := range expected {
+		if actual := jobBackoff(attempts); actual != backoff {
+			t.Errorf("Expected backoff %v after %d attempts, actual %v", backoff, attempts, actual)
+		}
+	}
+}
+
+func TestRecoverJobs(t *testing.T) {
+	var creating = newSampleVolume(model.VolumeCreating)
+	var running = []*model.JobSpec{
+		newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3),
+		newSampleJob(model.JobRevertVolume, model.JobRunning, 1, 1),
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobRunning},
+		"Host":   {jobHost},
+	}).Return(running, nil)
+	mockClient.On("GetVolume", mock.Anything, creating.Id).Return(creating, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, creating.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", mock.Anything, creating, model.VolumeAvailable).Return(nil)
+	updated := recordJobs(mockClient)
+	db.C = mockClient
+	Brain = &Controller{
+		volumeController: &pullVolumeController{Controller: NewFakeVolumeController(), vol: &SampleVolumes[0]},
+	}
+
+	if _, err := RecoverJobs(context.NewAdminContext(), jobHost); err != nil {
+		t.Fatal(err)
+	}
+	if len(*updated) != 2 {
+		t.Fatalf("Expected both jobs to be updated, actual %d", len(*updated))
+	}
+	// The volume has been created on the backend.
+	if job := (*updated)[0]; job.Status != model.JobSucceeded {
+		t.Errorf("Expected the job of creating volume to succeed, actual %+v", job)
+	}
+	// The reverting can't be checked on the backend.
+	if job := (*updated)[1]; job.Status != model.JobFailed {
+		t.Errorf("Expected the job of reverting volume to fail, actual %+v", job)
+	}
+}
+
+func TestAdoptOrphanedJobs(t *testing.T) {
+	var now = time.Now()
+	var stale = now.Add(-time.Hour).Format(constants.TimeFormat)
+	var creating = newSampleVolume(model.VolumeCreating)
+	var pending = newSampleJob(model.JobDeleteSnapshot, model.JobPending, 1, 3)
+	pending.Id, pending.Host, pending.UpdatedAt = "d1f2a3b4-5c6d-4e7f-8a9b-0c1d2e3f4a5b", "dead-host", stale
+	var running = newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3)
+	running.Host, running.UpdatedAt = "dead-host", stale
+	// The job which was updated lately is not orphaned yet.
+	var fresh = newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3)
+	fresh.Id, fresh.Host, fresh.UpdatedAt = "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", "dead-host", now.Format(constants.TimeFormat)
+	// The job of the live host is left to its host.
+	var live = newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3)
+	live.Id, live.Host, live.UpdatedAt = "7b8c9d0e-1f2a-4b3c-9d4e-5f6a7b8c9d0e", "live-host", stale
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", context.NewAdminContext()).Return([]string{jobHeartbeatId("live-host")}, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobPending},
+	}).Return([]*model.JobSpec{pending}, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobRunning},
+	}).Return([]*model.JobSpec{running, fresh, live}, nil)
+	mockClient.On("GetVolume", mock.Anything, creating.Id).Return(creating, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, creating.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", mock.Anything, creating, model.VolumeAvailable).Return(nil)
+	updated := recordJobs(mockClient)
+	db.C = mockClient
+	Brain = &Controller{
+		volumeController: &pullVolumeController{Controller: NewFakeVolumeController(), vol: &SampleVolumes[0]},
+	}
+
+	unreconciled, err := AdoptOrphanedJobs(context.NewAdminContext(), now, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unreconciled) != 0 {
+		t.Errorf("Expected no job left unreconciled, actual %d", len(unreconciled))
+	}
+	if len(*updated) != 3 {
+		t.Fatalf("Expected the orphaned jobs to be claimed and reconciled, actual %+v", *updated)
+	}
+	// The pending job is claimed and left to the sweep.
+	if job := (*updated)[0]; job.Id != pending.Id || job.Host != jobHost || job.Status != model.JobPending {
+		t.Errorf("Expected the pending job to be claimed, actual %+v", job)
+	}
+	if job := (*updated)[1]; job.Id != running.Id || job.Host != jobHost || job.Status != model.JobRunning {
+		t.Errorf("Expected the running job to be claimed, actual %+v", job)
+	}
+	// The volume has been created on the backend.
+	if job := (*updated)[2]; job.Id != running.Id || job.Status != model.JobSucceeded {
+		t.Errorf("Expected the running job to succeed, actual %+v", job)
+	}
+}
+
+func TestRecoverDeleteVolumeJob(t *testing.T) {
+	var deleting = newSampleVolume(model.VolumeDeleting)
+	var running = []*model.JobSpec{newSampleJob(model.JobDeleteVolume, model.JobRunning, 1, 3)}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), mock.Anything).Return(running, nil)
+	mockClient.On("GetVolume", mock.Anything, deleting.Id).Return(deleting, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, deleting.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("DeleteVolume", mock.Anything, deleting.Id).Return(nil)
+	mockClient.On("ListSnapshotSchedules", mock.Anything).Return(nil, nil)
+	mockClient.On("ReleaseQuota", mock.Anything, deleting.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: deleting.Size}).Return(nil)
+	updated := recordJobs(mockClient)
+	db.C = mockClient
+	Brain = &Controller{
+		volumeController: &pullVolumeController{
+			Controller: NewFakeVolumeController(),
+			err:        model.NewNotFoundError("volume is not found"),
+		},
+	}
+
+	if _, err := RecoverJobs(context.NewAdminContext(), jobHost); err != nil {
+		t.Fatal(err)
+	}
+	mockClient.AssertCalled(t, "DeleteVolume", mock.Anything, deleting.Id)
+	if len(*updated) != 1 || (*updated)[0].Status != model.JobSucceeded {
+		t.Errorf("Expected the job of deleting volume to succeed, actual %+v", *updated)
+	}
+}
+
+func TestRecoverJobsUnreconciled(t *testing.T) {
+	var creating = newSampleVolume(model.VolumeCreating)
+	var running = []*model.JobSpec{newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3)}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), mock.Anything).Return(running, nil)
+	mockClient.On("GetVolume", mock.Anything, creating.Id).Return(creating, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, creating.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", mock.Anything, creating, model.VolumeAvailable).Return(nil)
+	updated := recordJobs(mockClient)
+	db.C = mockClient
+	pvc := &pullVolumeController{Controller: NewFakeVolumeController(), err: errors.New("dock is down")}
+	Brain = &Controller{volumeController: pvc}
+
+	unreconciled, err := RecoverJobs(context.NewAdminContext(), jobHost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The volume may have been created, so the job is neither run again nor
+	// failed.
+	if len(*updated) != 0 || len(unreconciled) != 1 {
+		t.Fatalf("Expected the job to be kept running, actual %+v", *updated)
+	}
+
+	// The job is reconciled again once the dock is up.
+	pvc.vol, pvc.err = &SampleVolumes[0], nil
+	if unreconciled = reconcileJobs(context.NewAdminContext(), unreconciled); len(unreconciled) != 0 {
+		t.Errorf("Expected all jobs to be reconciled, actual %v", unreconciled)
+	}
+	if len(*updated) != 1 || (*updated)[0].Status != model.JobSucceeded {
+		t.Errorf("Expected the job of creating volume to succeed, actual %+v", *updated)
+	}
+}
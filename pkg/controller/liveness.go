@@ -0,0 +1,99 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the liveness tracking of docks by their heartbeats.
+
+*/
+
+package controller
+
+import (
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+// TrackDocks checks the liveness of docks every interval until stop is closed.
+func TrackDocks(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := CheckDocks(c.NewAdminContext()); err != nil {
+			log.Error("When check the liveness of docks:", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckDocks marks the docks whose heartbeat has lapsed and their pools as
+// unavailable, and marks them as available again once the heartbeat resumes.
+func CheckDocks(ctx *c.Context) error {
+	ids, err := db.C.ListHeartbeats(ctx)
+	if err != nil {
+		return err
+	}
+	dcks, err := db.C.ListDocks(ctx)
+	if err != nil {
+		return err
+	}
+	pols, err := db.C.ListPools(ctx)
+	if err != nil {
+		return err
+	}
+
+	var alive = make(map[string]bool)
+	for _, id := range ids {
+		alive[id] = true
+	}
+	var dockStatus = make(map[string]string)
+	for _, dck := range dcks {
+		status := model.DockUnavailable
+		if alive[dck.Id] {
+			status = model.DockAvailable
+		}
+		dockStatus[dck.Id] = status
+		if dck.Status == status {
+			continue
+		}
+		log.Warningf("Dock %s (%s) becomes %s.", dck.Id, dck.Endpoint, status)
+		if err := db.C.UpdateStatus(ctx, dck, status); err != nil {
+			return err
+		}
+	}
+
+	for _, pol := range pols {
+		// The pool whose dock has been removed is unavailable as well.
+		status := model.PoolUnavailable
+		if dockStatus[pol.DockId] == model.DockAvailable {
+			status = model.PoolAvailable
+		}
+		if pol.Status == status {
+			continue
+		}
+		log.Warningf("Pool %s of dock %s becomes %s.", pol.Id, pol.DockId, status)
+		if err := db.C.UpdateStatus(ctx, pol, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+func TestCheckDocks(t *testing.T) {
+	var dcks = []*model.DockSpec{
+		{BaseModel: &model.BaseModel{Id: "dock-alive"}, Status: model.DockAvailable},
+		{BaseModel: &model.BaseModel{Id: "dock-down"}, Status: model.DockAvailable},
+		{BaseModel: &model.BaseModel{Id: "dock-recovered"}, Status: model.DockUnavailable},
+	}
+	var pols = []*model.StoragePoolSpec{
+		{BaseModel: &model.BaseModel{Id: "pool-alive"}, DockId: "dock-alive", Status: model.PoolAvailable},
+		{BaseModel: &model.BaseModel{Id: "pool-down"}, DockId: "dock-down", Status: model.PoolAvailable},
+		{BaseModel: &model.BaseModel{Id: "pool-recovered"}, DockId: "dock-recovered", Status: model.PoolUnavailable},
+		{BaseModel: &model.BaseModel{Id: "pool-orphan"}, DockId: "dock-removed"},
+	}
+
+	ctx := context.NewAdminContext()
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", ctx).Return([]string{"dock-alive", "dock-recovered"}, nil)
+	mockClient.On("ListDocks", ctx).Return(dcks, nil)
+	mockClient.On("ListPools", ctx).Return(pols, nil)
+	mockClient.On("UpdateStatus", ctx, dcks[1], model.DockUnavailable).Return(nil)
+	mockClient.On("UpdateStatus", ctx, dcks[2], model.DockAvailable).Return(nil)
+	mockClient.On("UpdateStatus", ctx, pols[1], model.PoolUnavailable).Return(nil)
+	mockClient.On("UpdateStatus", ctx, pols[2], model.PoolAvailable).Return(nil)
+	mockClient.On("UpdateStatus", ctx, pols[3], model.PoolUnavailable).Return(nil)
+	db.C = mockClient
+
+	if err := CheckDocks(ctx); err != nil {
+		t.Error("Check docks failed:", err)
+	}
+	mockClient.AssertNumberOfCalls(t, "UpdateStatus", 5)
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the migration of volume between storage pools. If both
+pools are managed by the same dock, the driver-native migration is tried
+first, and the data is copied on the host of the attacher dock which the
+source volume belongs to if the driver doesn't support it or the pools are
+managed by different docks.
+
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/contrib/drivers/utils/config"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+	"github.com/satori/go.uuid"
+)
+
+const (
+	// The keys of volume metadata which record the source volume left behind
+	// by the migration, it needs to be cleaned up by the administrator.
+	MigrationSourcePoolKey     = "migrationSourcePoolId"
+	MigrationSourceMetadataKey = "migrationSourceMetadata"
+)
+
+// MigrateVolume moves the volume to the pool specified by poolID. If poolID
+// is empty, a pool which satisfies the profile of volume will be selected.
+func (c *Controller) MigrateVolume(ctx *c.Context, volID string, poolID string, errchanVolume chan error) {
+	vol, err := db.C.GetVolume(ctx, volID)
+	if err != nil {
+		log.Error("Get volume failed in migrate volume method: ", err)
+		errchanVolume <- err
+		return
+	}
+
+	if err = c.migrateVolume(ctx, vol, poolID); err != nil {
+		log.Error("Migrate volume failed: ", err)
+		// The volume which has been moved to the target pool is available.
+		if vol.MigrationStatus == model.VolumeMigrationPartial {
+			errchanVolume <- err
+			return
+		}
+		vol.MigrationStatus = model.VolumeMigrationError
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeErrorMigrating); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+	errchanVolume <- nil
+}
+
+func (c *Controller) migrateVolume(ctx *c.Context, vol *model.VolumeSpec, poolID string) error {
+	srcPool, err := db.C.GetPool(ctx, vol.PoolId)
+	if err != nil {
+		log.Error("Get source pool failed in migrate volume method: ", err)
+		return err
+	}
+	srcDock, err := db.C.GetDockByPoolId(ctx, vol.PoolId)
+	if err != nil {
+		log.Error("When search source dock in db by pool id: ", err)
+		return err
+	}
+
+	var dstPool *model.StoragePoolSpec
+	if poolID == "" {
+		dstPool, err = c.selector.SelectSupportedPoolForMigration(vol)
+	} else {
+		dstPool, err = db.C.GetPool(ctx, poolID)
+	}
+	if err != nil {
+		log.Error("Get target pool failed in migrate volume method: ", err)
+		return err
+	}
+	dstDock, err := db.C.GetDockByPoolId(ctx, dstPool.Id)
+	if err != nil {
+		log.Error("When search target dock in db by pool id: ", err)
+		return err
+	}
+	log.Infof("Start migrating volume %s from pool %s to pool %s.", vol.Id, srcPool.Id, dstPool.Id)
+	c.updateMigrationProgress(ctx, vol, model.VolumeMigrationStarting, 0)
+
+	var result *model.VolumeSpec
+	if srcDock.Id == dstDock.Id {
+		result, err = c.volumeController.MigrateVolume(srcDock, &pb.MigrateVolumeOpts{
+			Id:             vol.Id,
+			Size:           vol.Size,
+			PoolName:       srcPool.Name,
+			TargetPoolId:   dstPool.Id,
+			TargetPoolName: dstPool.Name,
+			Metadata:       vol.Metadata,
+			DriverName:     srcDock.DriverName,
+			Context:        ctx.ToJson(),
+			Qos:            newQos(vol.Qos),
+		})
+		if _, ok := err.(*model.NotImplementError); ok {
+			log.Warning("Driver-native migration is not supported, fall back to host-side copy: ", err)
+		} else if err != nil {
+			log.Error("Driver-native migration failed: ", err)
+			return err
+		}
+	}
+	if result == nil {
+		if result, err = c.migrateVolumeByHost(ctx, vol, srcPool, dstPool, srcDock, dstDock); result == nil {
+			return err
+		}
+	}
+
+	srcMetadata := vol.Metadata
+	vol.PoolId = dstPool.Id
+	vol.Metadata = result.Metadata
+	if vol.Metadata == nil {
+		vol.Metadata = map[string]string{}
+	}
+	if result.Qos != nil {
+		vol.Qos = result.Qos
+	}
+	// The volume has been moved to the target pool, but the source volume
+	// left behind is recorded, which needs to be cleaned up by the
+	// administrator.
+	if err != nil {
+		body, _ := json.Marshal(srcMetadata)
+		vol.Metadata[MigrationSourcePoolKey] = srcPool.Id
+		vol.Metadata[MigrationSourceMetadataKey] = string(body)
+		vol.MigrationStatus, vol.MigrationProgress = model.VolumeMigrationPartial, 100
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeAvailable); errUpdate != nil {
+			return errUpdate
+		}
+		return err
+	}
+	vol.MigrationStatus, vol.MigrationProgress = model.VolumeMigrationSuccess, 100
+	log.Infof("Migrate volume %s to pool %s success.", vol.Id, dstPool.Id)
+	return db.C.UpdateStatus(ctx, vol, model.VolumeAvailable)
+}
+
+// migrateVolumeByHost creates a new volume in the target pool, copies the data
+// of source volume to it on the attacher host and deletes the source volume
+// at last. If the source volume fails to be deleted, the new volume is
+// returned along with the error.
+func (c *Controller) migrateVolumeByHost(
+	ctx *c.Context,
+	vol *model.VolumeSpec,
+	srcPool, dstPool *model.StoragePoolSpec,
+	srcDock, dstDock *model.DockSpec,
+) (*model.VolumeSpec, error) {
+	dstVol, err := c.volumeController.CreateVolume(dstDock, &pb.CreateVolumeOpts{
+		Id:               vol.Id,
+		Name:             vol.Name,
+		Description:      vol.Description,
+		Size:             vol.Size,
+		AvailabilityZone: dstPool.AvailabilityZone,
+		ProfileId:        vol.ProfileId,
+		PoolId:           dstPool.Id,
+		PoolName:         dstPool.Name,
+		DriverName:       dstDock.DriverName,
+		Context:          ctx.ToJson(),
+		Qos:              newQos(vol.Qos),
+	})
+	if err != nil {
+		log.Error("Create volume in target pool failed: ", err)
+		return nil, err
+	}
+	c.updateMigrationProgress(ctx, vol, model.VolumeMigrationCopying, 10)
+
+	if err = c.copyVolumeByHost(ctx, vol, dstVol, srcPool, dstPool, srcDock, dstDock); err != nil {
+		if errDel := c.volumeController.DeleteVolume(dstDock, &pb.DeleteVolumeOpts{
+			Id:         dstVol.Id,
+			Metadata:   dstVol.Metadata,
+			DriverName: dstDock.DriverName,
+			Context:    ctx.ToJson(),
+		}); errDel != nil {
+			log.Error("Delete volume in target pool failed: ", errDel)
+		}
+		return nil, err
+	}
+	c.updateMigrationProgress(ctx, vol, model.VolumeMigrationCompleting, 90)
+
+	if err = c.volumeController.DeleteVolume(srcDock, &pb.DeleteVolumeOpts{
+		Id:         vol.Id,
+		Metadata:   vol.Metadata,
+		DriverName: srcDock.DriverName,
+		Context:    ctx.ToJson(),
+	}); err != nil {
+		log.Errorf("Delete volume %s in source pool %s failed: %v", vol.Id, srcPool.Id, err)
+		return dstVol, err
+	}
+	return dstVol, nil
+}
+
+// copyVolumeByHost attaches both the source and target volume to the host of
+// attacher dock, and copies the data between them.
+func (c *Controller) copyVolumeByHost(
+	ctx *c.Context,
+	srcVol, dstVol *model.VolumeSpec,
+	srcPool, dstPool *model.StoragePoolSpec,
+	srcDock, dstDock *model.DockSpec,
+) error {
+	attacherDock, err := getAttacherDock(ctx, srcDock)
+	if err != nil {
+		log.Error("Get attacher dock failed: ", err)
+		return err
+	}
+
+	srcAtm, err := c.attachVolumeToHost(ctx, srcVol, srcPool, srcDock, attacherDock)
+	if err != nil {
+		return err
+	}
+	defer c.detachVolumeFromHost(ctx, srcAtm, srcVol, srcDock, attacherDock)
+
+	dstAtm, err := c.attachVolumeToHost(ctx, dstVol, dstPool, dstDock, attacherDock)
+	if err != nil {
+		return err
+	}
+	defer c.detachVolumeFromHost(ctx, dstAtm, dstVol, dstDock, attacherDock)
+	c.updateMigrationProgress(ctx, srcVol, model.VolumeMigrationCopying, 30)
+
+	if err = c.volumeController.CopyVolume(attacherDock, &pb.CopyVolumeOpts{
+		SrcPath:  srcAtm.Mountpoint,
+		DestPath: dstAtm.Mountpoint,
+		Size:     srcVol.Size,
+		Context:  ctx.ToJson(),
+	}); err != nil {
+		log.Errorf("Copy volume from %s to %s failed: %v", srcAtm.Mountpoint, dstAtm.Mountpoint, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) attachVolumeToHost(
+	ctx *c.Context,
+	vol *model.VolumeSpec,
+	pool *model.StoragePoolSpec,
+	provisionerDock, attacherDock *model.DockSpec,
+) (*model.VolumeAttachmentSpec, error) {
+	// Default protocol is iscsi
+	protocol := config.ISCSIProtocol
+	if len(pool.Extras.IOConnectivity.AccessProtocol) != 0 {
+		protocol = pool.Extras.IOConnectivity.AccessProtocol
+	}
+	initiator := attacherDock.Metadata["Initiator"]
+	if protocol == config.FCProtocol {
+		initiator = attacherDock.Metadata["WWPNS"]
+	}
+
+	atm, err := c.volumeController.CreateVolumeAttachment(provisionerDock, &pb.CreateAttachmentOpts{
+		Id:       uuid.NewV4().String(),
+		VolumeId: vol.Id,
+		HostInfo: &pb.HostInfo{
+			Platform:  attacherDock.Metadata["Platform"],
+			OsType:    attacherDock.Metadata["OsType"],
+			Ip:        attacherDock.Metadata["HostIp"],
+			Host:      attacherDock.NodeId,
+			Initiator: initiator,
+		},
+		AccessProtocol: protocol,
+		Metadata:       vol.Metadata,
+		DriverName:     provisionerDock.DriverName,
+		Context:        ctx.ToJson(),
+	})
+	if err != nil {
+		log.Errorf("Create attachment of volume %s failed: %v", vol.Id, err)
+		return nil, err
+	}
+
+	connData, _ := json.Marshal(atm.ConnectionData)
+	mountPoint, err := c.volumeController.AttachVolume(attacherDock, &pb.AttachVolumeOpts{
+		AccessProtocol: atm.DriverVolumeType,
+		ConnectionData: string(connData),
+		Metadata:       map[string]string{},
+		Context:        ctx.ToJson(),
+	})
+	if err != nil {
+		log.Errorf("Attach volume %s failed: %v", vol.Id, err)
+		c.deleteVolumeAttachment(ctx, atm, vol, provisionerDock)
+		return nil, err
+	}
+	atm.Mountpoint = mountPoint
+	return atm, nil
+}
+
+func (c *Controller) detachVolumeFromHost(
+	ctx *c.Context,
+	atm *model.VolumeAttachmentSpec,
+	vol *model.VolumeSpec,
+	provisionerDock, attacherDock *model.DockSpec,
+) {
+	connData, _ := json.Marshal(atm.ConnectionData)
+	if err := c.volumeController.DetachVolume(attacherDock, &pb.DetachVolumeOpts{
+		AccessProtocol: atm.DriverVolumeType,
+		ConnectionData: string(connData),
+		Metadata:       atm.Metadata,
+		Context:        ctx.ToJson(),
+	}); err != nil {
+		log.Errorf("Detach volume %s failed: %v", vol.Id, err)
+	}
+	c.deleteVolumeAttachment(ctx, atm, vol, provisionerDock)
+}
+
+func (c *Controller) deleteVolumeAttachment(
+	ctx *c.Context,
+	atm *model.VolumeAttachmentSpec,
+	vol *model.VolumeSpec,
+	provisionerDock *model.DockSpec,
+) {
+	if err := c.volumeController.DeleteVolumeAttachment(provisionerDock, &pb.DeleteAttachmentOpts{
+		Id:       atm.Id,
+		VolumeId: atm.VolumeId,
+		HostInfo: &pb.HostInfo{
+			Platform:  atm.Platform,
+			OsType:    atm.OsType,
+			Ip:        atm.Ip,
+			Host:      atm.Host,
+			Initiator: atm.Initiator,
+		},
+		AccessProtocol: atm.AccessProtocol,
+		Metadata:       utils.MergeStringMaps(atm.Metadata, vol.Metadata),
+		DriverName:     provisionerDock.DriverName,
+		Context:        ctx.ToJson(),
+	}); err != nil {
+		log.Errorf("Delete attachment of volume %s failed: %v", vol.Id, err)
+	}
+}
+
+// updateMigrationProgress records the migration status and progress of volume
+// in db, the failure of which will not break the migration.
+func (c *Controller) updateMigrationProgress(ctx *c.Context, vol *model.VolumeSpec, status string, progress int64) {
+	vol.MigrationStatus, vol.MigrationProgress = status, progress
+	if _, err := db.C.UpdateVolume(ctx, vol); err != nil {
+		log.Warning("Update migration progress of volume failed: ", err)
+	}
+}
+
+// getAttacherDock returns the attacher dock which is located on the same
+// host with the provisioner dock.
+func getAttacherDock(ctx *c.Context, provisionerDock *model.DockSpec) (*model.DockSpec, error) {
+	segments := strings.Split(provisionerDock.Endpoint, ":")
+	endpointIp := segments[len(segments)-2]
+	// Generate the attacher UUID by nodeid and endpoint ip.
+	attacherDockId := uuid.NewV5(uuid.NamespaceOID, provisionerDock.NodeId+":"+endpointIp)
+	return db.C.GetDock(ctx, attacherDockId.String())
+}
@@ -22,56 +22,156 @@ package executor
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"sync"
 
 	log "github.com/golang/glog"
-	pb "github.com/opensds/opensds/pkg/dock/proto"
+	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/model"
 )
 
+// The types of policies. A feature is satisfied by the pool which the volume
+// is placed in, while an operation is executed by the controller.
+const (
+	FeaturePolicy   = "feature"
+	OperationPolicy = "operation"
+)
+
+// The lifecycle points of volume at which the policies take effect, which are
+// the same as the lifecycle flags of controller.
+const (
+	CreateLifecycle = 1
+	DeleteLifecycle = 4
+	ExtendLifecycle = 5
+)
+
+var lifecycleNames = map[int]string{
+	CreateLifecycle: "create",
+	DeleteLifecycle: "delete",
+	ExtendLifecycle: "extend",
+}
+
+// LifecycleName returns the name of lifecycle point, such as create.
+func LifecycleName(lifecycle int) string {
+	return lifecycleNames[lifecycle]
+}
+
+// Request is the volume which the policy is executed against, along with the
+// context of the request and the dock which the volume belongs to.
+type Request struct {
+	Context  *c.Context
+	Volume   *model.VolumeSpec
+	DockInfo *model.DockSpec
+}
+
+// Policy describes a kind of policy which can be specified in the custom
+// properties of profile, the key of which is the name of policy.
+type Policy struct {
+	Name        string
+	Type        string
+	Lifecycle   int
+	Description string
+	// NewExecutor creates the executor of an operation policy with its value
+	// in profile, a nil executor means that the policy is disabled by the
+	// value.
+	NewExecutor func(value string, req *Request) (AsynchronizedExecutor, error)
+}
+
+var (
+	policiesLock sync.RWMutex
+	policies     = make(map[string]*Policy)
+)
+
+// RegisterPolicy makes the policy available to profiles, it panics if the
+// policy is registered twice.
+func RegisterPolicy(p *Policy) {
+	policiesLock.Lock()
+	defer policiesLock.Unlock()
+
+	if _, ok := policies[p.Name]; ok {
+		panic(fmt.Sprintf("policy %s is registered twice", p.Name))
+	}
+	if p.Type == OperationPolicy && p.NewExecutor == nil {
+		panic(fmt.Sprintf("operation policy %s has no executor", p.Name))
+	}
+	policies[p.Name] = p
+}
+
+// UnregisterPolicy removes the policy, which is mainly used by tests.
+func UnregisterPolicy(name string) {
+	policiesLock.Lock()
+	defer policiesLock.Unlock()
+
+	delete(policies, name)
+}
+
+// GetPolicy returns the registered policy of the name.
+func GetPolicy(name string) (*Policy, bool) {
+	policiesLock.RLock()
+	defer policiesLock.RUnlock()
+
+	p, ok := policies[name]
+	return p, ok
+}
+
+// ListPolicies returns all the registered policies sorted by name.
+func ListPolicies() []*Policy {
+	policiesLock.RLock()
+	defer policiesLock.RUnlock()
+
+	var result []*Policy
+	for _, p := range policies {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func init() {
+	// The features are matched against the extras of pools by the selector,
+	// they're registered here so that they can be told apart from the
+	// custom properties which are not policies.
+	for name, desc := range map[string]string{
+		"diskType":         "The type of disks which the volume is placed on.",
+		"thinProvision":    "Whether the volume is thin provisioned.",
+		"highAvailability": "Whether the volume is placed in a highly available pool.",
+	} {
+		RegisterPolicy(&Policy{
+			Name:        name,
+			Type:        FeaturePolicy,
+			Lifecycle:   CreateLifecycle,
+			Description: desc,
+		})
+	}
+}
+
 // AsynchronizedExecutor
 type AsynchronizedExecutor interface {
-	Init(in string) error
 	Asynchronized() error
 }
 
 // AsynchronizedWorkflow
 type AsynchronizedWorkflow map[string]AsynchronizedExecutor
 
-// RegisterAsynchronizedWorkflow
-func RegisterAsynchronizedWorkflow(
-	req interface{},
-	tags map[string]string,
-	dockInfo *model.DockSpec,
-	in string) (
-	AsynchronizedWorkflow, error) {
-
+// RegisterAsynchronizedWorkflow creates the executors of the operation
+// policies in tags, which map the names of policies to their values.
+func RegisterAsynchronizedWorkflow(req *Request, tags map[string]string) (AsynchronizedWorkflow, error) {
 	var asynWorkflow = AsynchronizedWorkflow{}
-	for key := range tags {
-		switch key {
-		case "intervalSnapshot":
-			ise := &IntervalSnapshotExecutor{
-				Request:  req.(*pb.CreateVolumeSnapshotOpts),
-				Interval: tags[key],
-				DockInfo: dockInfo,
-			}
-
-			if err := ise.Init(in); err != nil {
-				log.Errorf("When register async policy %s: %v\n", key, err)
-				return asynWorkflow, err
-			}
-			asynWorkflow[key] = ise
-
-		case "deleteSnapshotPolicy":
-			ise := &DeleteSnapshotExecutor{
-				Request:  req.(*pb.DeleteVolumeSnapshotOpts),
-				DockInfo: dockInfo,
-			}
-
-			if err := ise.Init(in); err != nil {
-				log.Errorf("When register async policy %s: %v\n", key, err)
-				return asynWorkflow, err
-			}
-			asynWorkflow[key] = ise
+	for key, value := range tags {
+		p, ok := GetPolicy(key)
+		if !ok || p.Type != OperationPolicy {
+			return asynWorkflow, fmt.Errorf("the operation policy %s is not supported", key)
+		}
+		e, err := p.NewExecutor(value, req)
+		if err != nil {
+			log.Errorf("When register async policy %s: %v\n", key, err)
+			return asynWorkflow, err
+		}
+		if e != nil {
+			asynWorkflow[key] = e
 		}
 	}
 
@@ -79,15 +179,28 @@ func RegisterAsynchronizedWorkflow(
 	return asynWorkflow, nil
 }
 
-// ExecuteAsynchronizedWorkflow
+// ExecuteAsynchronizedWorkflow executes all the policies in the workflow in
+// the order of their names, and returns the first error if any of them fails.
 func ExecuteAsynchronizedWorkflow(asynWorkflow AsynchronizedWorkflow) error {
+	var keys []string
 	for key := range asynWorkflow {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result error
+	for _, key := range keys {
 		if asynWorkflow[key] == nil {
 			return errors.New("Could not execute the policy " + key)
 		}
-		return asynWorkflow[key].Asynchronized()
+		if err := asynWorkflow[key].Asynchronized(); err != nil {
+			log.Errorf("When execute async policy %s: %v\n", key, err)
+			if result == nil {
+				result = err
+			}
+		}
 	}
-	return nil
+	return result
 }
 
 // SynchronizedExecutor
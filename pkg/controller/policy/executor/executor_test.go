@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeExecutor struct {
+	executed *[]string
+	name     string
+	err      error
+}
+
+func (f *fakeExecutor) Asynchronized() error {
+	*f.executed = append(*f.executed, f.name)
+	return f.err
+}
+
+func registerFakePolicy(name string, executed *[]string, err error) {
+	RegisterPolicy(&Policy{
+		Name:      name,
+		Type:      OperationPolicy,
+		Lifecycle: ExtendLifecycle,
+		NewExecutor: func(value string, req *Request) (AsynchronizedExecutor, error) {
+			if value == "false" {
+				return nil, nil
+			}
+			return &fakeExecutor{executed: executed, name: name, err: err}, nil
+		},
+	})
+}
+
+func TestRegisterPolicy(t *testing.T) {
+	var executed []string
+	registerFakePolicy("fakePolicy", &executed, nil)
+	defer UnregisterPolicy("fakePolicy")
+
+	if p, ok := GetPolicy("fakePolicy"); !ok || p.Lifecycle != ExtendLifecycle {
+		t.Errorf("Unexpected policy %+v", p)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when the policy is registered twice")
+		}
+	}()
+	registerFakePolicy("fakePolicy", &executed, nil)
+}
+
+func TestExecuteAsynchronizedWorkflow(t *testing.T) {
+	var executed []string
+	registerFakePolicy("fakePolicyB", &executed, errors.New("failed"))
+	registerFakePolicy("fakePolicyA", &executed, nil)
+	registerFakePolicy("fakePolicyC", &executed, nil)
+	defer UnregisterPolicy("fakePolicyA")
+	defer UnregisterPolicy("fakePolicyB")
+	defer UnregisterPolicy("fakePolicyC")
+
+	awf, err := RegisterAsynchronizedWorkflow(&Request{}, map[string]string{
+		"fakePolicyA": "true",
+		"fakePolicyB": "true",
+		"fakePolicyC": "false",
+	})
+	if err != nil {
+		t.Fatal("Failed to register workflow:", err)
+	}
+	// All the policies are executed even if one of them fails.
+	if err = ExecuteAsynchronizedWorkflow(awf); err == nil || err.Error() != "failed" {
+		t.Errorf("Expected the error of fakePolicyB, got %v", err)
+	}
+	if !reflect.DeepEqual(executed, []string{"fakePolicyA", "fakePolicyB"}) {
+		t.Errorf("Unexpected executed policies %v", executed)
+	}
+
+	// The features can't be executed.
+	if _, err = RegisterAsynchronizedWorkflow(&Request{}, map[string]string{"thinProvision": "true"}); err == nil {
+		t.Error("Expected error when register a feature policy")
+	}
+}
+
+func TestIntervalSnapshotExecutor(t *testing.T) {
+	var vol = SampleVolumes[0]
+	mockClient := new(dbtest.Client)
+	mockClient.On("CreateSnapshotSchedule", context.NewAdminContext(), mock.Anything).Return(nil, nil)
+	db.C = mockClient
+
+	p, _ := GetPolicy("intervalSnapshot")
+	if _, err := p.NewExecutor("1x", nil); err == nil {
+		t.Error("Expected error when the interval is invalid")
+	}
+	e, err := p.NewExecutor("12h", &Request{Context: context.NewAdminContext(), Volume: &vol})
+	if err != nil {
+		t.Fatal("Failed to create executor:", err)
+	}
+	if err = e.Asynchronized(); err != nil {
+		t.Fatal("Failed to execute interval snapshot policy:", err)
+	}
+	sched := mockClient.Calls[0].Arguments.Get(1).(*model.SnapshotScheduleSpec)
+	if sched.VolumeId != vol.Id || sched.Occurrence != "R3/PT43200S" {
+		t.Errorf("Unexpected snapshot schedule %+v", sched)
+	}
+}
@@ -21,8 +21,8 @@ profiles configured by admin.
 package executor
 
 import (
-	"encoding/json"
-	"time"
+	"fmt"
+	"strconv"
 
 	log "github.com/golang/glog"
 	c "github.com/opensds/opensds/pkg/context"
@@ -30,82 +30,73 @@ import (
 	"github.com/opensds/opensds/pkg/dock/client"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
 	"golang.org/x/net/context"
 )
 
-const (
-	RETRY_INTERVAL = 5
-	MAX_RETRY_TIME = 10
-)
+func init() {
+	RegisterPolicy(&Policy{
+		Name:        "deleteSnapshotPolicy",
+		Type:        OperationPolicy,
+		Lifecycle:   DeleteLifecycle,
+		Description: "Delete the snapshots of the volume before it's deleted.",
+		NewExecutor: func(value string, req *Request) (AsynchronizedExecutor, error) {
+			if enabled, err := strconv.ParseBool(value); err == nil && !enabled {
+				return nil, nil
+			}
+			return &DeleteSnapshotExecutor{Client: client.NewClient(), Request: req}, nil
+		},
+	})
+}
 
 type DeleteSnapshotExecutor struct {
 	client.Client
 
-	VolumeId string
-	Request  *pb.DeleteVolumeSnapshotOpts
-	DockInfo *model.DockSpec
+	Request *Request
 }
 
-func (dse *DeleteSnapshotExecutor) Init(in string) (err error) {
-	var volumeResponse model.VolumeSpec
-	if err = json.Unmarshal([]byte(in), &volumeResponse); err != nil {
+// Asynchronized deletes the snapshots of the volume one by one, the deletion
+// stops at the first snapshot which can't be deleted.
+func (dse *DeleteSnapshotExecutor) Asynchronized() error {
+	ctx, vol, dockInfo := dse.Request.Context, dse.Request.Volume, dse.Request.DockInfo
+	snaps, err := db.C.ListVolumeSnapshots(c.NewAdminContext())
+	if err != nil {
+		log.Error("When list volume snapshots:", err)
 		return err
 	}
-	dse.VolumeId = volumeResponse.Id
-	dse.Client = client.NewClient()
-	dse.Client.Connect(dse.DockInfo.Endpoint)
-
-	return nil
-}
 
-func (dse *DeleteSnapshotExecutor) Asynchronized() error {
-	remainSnaps, err := findRemainingSnapshot(dse.VolumeId)
-	if err != nil {
+	if err = dse.Client.Connect(dockInfo.Endpoint); err != nil {
+		log.Error("When connecting dock client:", err)
 		return err
 	}
+	defer dse.Client.Close()
 
-	for i, snapId := range remainSnaps {
-		dse.Request.Id = snapId
-		if _, err = dse.Client.DeleteVolumeSnapshot(context.Background(), dse.Request); err != nil {
-			log.Errorf("When %dth delete volume snapshot: %v\n", i+1, err)
+	for _, snap := range snaps {
+		if snap.VolumeId != vol.Id {
+			continue
+		}
+		response, err := dse.Client.DeleteVolumeSnapshot(context.Background(), &pb.DeleteVolumeSnapshotOpts{
+			Id:         snap.Id,
+			VolumeId:   vol.Id,
+			Metadata:   utils.MergeStringMaps(snap.Metadata, vol.Metadata),
+			DriverName: dockInfo.DriverName,
+			Context:    ctx.ToJson(),
+		})
+		if err != nil {
+			log.Errorf("When delete volume snapshot %s: %v\n", snap.Id, err)
 			return err
 		}
-	}
-	// Waiting for snapshots deleted
-	for i := 0; i < MAX_RETRY_TIME; i++ {
-		if CheckSnapshotDeleted(dse.VolumeId) {
-			break
+		if errorMsg := response.GetError(); errorMsg != nil {
+			return fmt.Errorf("failed to delete volume snapshot %s, code: %v, message: %v",
+				snap.Id, errorMsg.GetCode(), errorMsg.GetDescription())
 		}
-		time.Sleep(RETRY_INTERVAL * time.Second)
-	}
-	return nil
-}
-
-func CheckSnapshotDeleted(volumeId string) bool {
-	snaps, err := findRemainingSnapshot(volumeId)
-	if err != nil {
-		return false
-	}
-
-	if len(snaps) == 0 {
-		return true
-	}
-	return false
-}
-
-func findRemainingSnapshot(volumeId string) ([]string, error) {
-	var remainingSnapshots = []string{}
-	snapshots, err := db.C.ListVolumeSnapshots(c.NewAdminContext())
-	if err != nil {
-		log.Error("When list volume snapshots:", err)
-		return remainingSnapshots, err
-	}
-
-	for _, snap := range snapshots {
-		if snap.VolumeId != volumeId {
-			continue
+		if err = db.C.DeleteVolumeSnapshot(ctx, snap.Id); err != nil {
+			log.Error("When delete volume snapshot in db:", err)
+			return err
+		}
+		if err = db.C.ReleaseQuota(ctx, snap.TenantId, &model.QuotaUsageSpec{Snapshots: 1}); err != nil {
+			log.Warning("Release quota of volume snapshot failed:", err)
 		}
-		remainingSnapshots = append(remainingSnapshots, snap.Id)
 	}
-	return remainingSnapshots, nil
+	return nil
 }
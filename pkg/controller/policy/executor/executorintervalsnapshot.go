@@ -21,72 +21,74 @@ profiles configured by admin.
 package executor
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
-	log "github.com/golang/glog"
-	"github.com/opensds/opensds/pkg/dock/client"
-	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
-	"golang.org/x/net/context"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/satori/go.uuid"
 )
 
-type IntervalSnapshotExecutor struct {
-	client.Client
+func init() {
+	RegisterPolicy(&Policy{
+		Name:        "intervalSnapshot",
+		Type:        OperationPolicy,
+		Lifecycle:   CreateLifecycle,
+		Description: "Take a few snapshots of the volume every interval such as 12h after it's created.",
+		NewExecutor: func(value string, req *Request) (AsynchronizedExecutor, error) {
+			if _, err := ParseInterval(value); err != nil {
+				return nil, err
+			}
+			return &IntervalSnapshotExecutor{Request: req, Interval: value}, nil
+		},
+	})
+}
 
-	Request  *pb.CreateVolumeSnapshotOpts
-	DockInfo *model.DockSpec
+type IntervalSnapshotExecutor struct {
+	Request  *Request
 	Interval string
 	TotalNum int
 }
 
-func (ise *IntervalSnapshotExecutor) Init(in string) (err error) {
-	var volumeResponse model.VolumeSpec
-	if err = json.Unmarshal([]byte(in), &volumeResponse); err != nil {
-		return err
-	}
-
-	ise.Request.VolumeId = volumeResponse.Id
-	ise.Request.Name = "snapshot-" + volumeResponse.Id
-	ise.Request.Size = volumeResponse.Size
-	ise.Client = client.NewClient()
-	ise.Client.Connect(ise.DockInfo.Endpoint)
-
-	return nil
-}
-
+// Asynchronized creates the snapshot schedule which takes TotalNum snapshots
+// of the volume, one every interval. The schedule is persisted and run by the
+// snapshot scheduler of controller, so that it survives the restart of
+// osdslet.
 func (ise *IntervalSnapshotExecutor) Asynchronized() error {
 	if ise.TotalNum == 0 {
 		ise.TotalNum = 3
 	}
 	num, err := ParseInterval(ise.Interval)
 	if err != nil {
-		log.Error("When parse snapshot interval:", err)
 		return err
 	}
 
-	for i := 0; i < ise.TotalNum; i++ {
-		// Sleep interval time
-		for j := 0; j < num; j++ {
-			time.Sleep(time.Second)
-		}
-		if _, err = ise.Client.CreateVolumeSnapshot(context.Background(), ise.Request); err != nil {
-			log.Errorf("When %dth create volume snapshot: %v\n", i+1, err)
-			return err
-		}
-	}
-	return nil
+	vol := ise.Request.Volume
+	_, err = db.C.CreateSnapshotSchedule(ise.Request.Context, &model.SnapshotScheduleSpec{
+		BaseModel: &model.BaseModel{
+			Id:        uuid.NewV4().String(),
+			CreatedAt: time.Now().Format(constants.TimeFormat),
+		},
+		TenantId:   vol.TenantId,
+		UserId:     vol.UserId,
+		VolumeId:   vol.Id,
+		ProfileId:  vol.ProfileId,
+		Occurrence: fmt.Sprintf("R%d/PT%dS", ise.TotalNum, num),
+		Datetime:   time.Now().Add(time.Duration(num) * time.Second).UTC().Format(time.RFC3339),
+	})
+	return err
 }
 
 func ParseInterval(interval string) (int, error) {
+	if interval == "" {
+		return 0, errors.New("interval is empty")
+	}
 	var times int
 	unit := strings.ToLower(interval[len(interval)-1:])
-	if unit != "s" && unit != "m" && unit != "h" && unit != "d" {
-		return 0, errors.New("interval unit is not correct")
-	}
 	switch unit {
 	case "s":
 		times = 1
@@ -104,5 +106,8 @@ func ParseInterval(interval string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
+	if num <= 0 {
+		return 0, errors.New("interval must be positive")
+	}
 	return num * times, nil
 }
@@ -21,6 +21,7 @@ profiles configured by admin.
 package policy
 
 import (
+	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/controller/policy/executor"
 	"github.com/opensds/opensds/pkg/model"
 )
@@ -34,7 +35,7 @@ type Controller interface {
 
 	ExecuteSyncPolicy(req interface{}) error
 
-	ExecuteAsyncPolicy(req interface{}, in string, errChan chan error)
+	ExecuteAsyncPolicy(ctx *c.Context, vol *model.VolumeSpec, errChan chan error)
 
 	SetDock(dockInfo *model.DockSpec)
 }
@@ -69,8 +70,9 @@ func (c *controller) ExecuteSyncPolicy(req interface{}) error {
 	return executor.ExecuteSynchronizedWorkflow(swf)
 }
 
-func (c *controller) ExecuteAsyncPolicy(req interface{}, in string, errChan chan error) {
-	awf, err := executor.RegisterAsynchronizedWorkflow(req, c.Tag.asyncTag, c.DockInfo, in)
+func (pc *controller) ExecuteAsyncPolicy(ctx *c.Context, vol *model.VolumeSpec, errChan chan error) {
+	req := &executor.Request{Context: ctx, Volume: vol, DockInfo: pc.DockInfo}
+	awf, err := executor.RegisterAsynchronizedWorkflow(req, pc.Tag.asyncTag)
 	if err != nil {
 		errChan <- err
 		return
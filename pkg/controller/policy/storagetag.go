@@ -21,45 +21,17 @@ profiles configured by admin.
 package policy
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 
-	log "github.com/golang/glog"
-	"github.com/opensds/opensds/pkg/utils"
+	"github.com/opensds/opensds/pkg/controller/policy/executor"
+	"github.com/opensds/opensds/pkg/model"
 )
 
-const (
-	POLICY_TYPE_MAPPING_TABLE = `{
-		"diskType": "feature",
-		"thinProvision": "feature",
-		"highAvailability": "feature",
-		"intervalSnapshot": "operation",
-		"deleteSnapshotPolicy": "operation"
-	}`
-	POLICY_LIFECIRCLE_TABLE = `{
-		"thinProvision": 1,
-		"highAvailability": 1,
-		"intervalSnapshot": 1,
-		"deleteSnapshotPolicy": 4
-	}`
-)
-
-// PolicyTypeMappingTable
-var PolicyTypeMappingTable map[string]string
-
-// PolicyLifecircleTable
-var PolicyLifecircleTable map[string]int
-
-func init() {
-	json.Unmarshal([]byte(POLICY_TYPE_MAPPING_TABLE), &PolicyTypeMappingTable)
-	json.Unmarshal([]byte(POLICY_LIFECIRCLE_TABLE), &PolicyLifecircleTable)
-}
-
 // IsStorageTagSupported
 func IsStorageTagSupported(tags map[string]string) bool {
 	for key := range tags {
-		if PolicyTypeMappingTable[key] != "operation" {
+		if p, ok := executor.GetPolicy(key); !ok || p.Type != executor.OperationPolicy {
 			return false
 		}
 	}
@@ -68,11 +40,45 @@ func IsStorageTagSupported(tags map[string]string) bool {
 
 // FindPolicyType
 func FindPolicyType(policy string) (string, error) {
-	if !utils.Contained(policy, PolicyTypeMappingTable) {
+	p, ok := executor.GetPolicy(policy)
+	if !ok {
 		return "", errors.New("The policy type of " + policy + " not supported")
 	}
 
-	return PolicyTypeMappingTable[policy], nil
+	return p.Type, nil
+}
+
+// SupportedPolicies returns all the policies which can be specified in the
+// custom properties of profile.
+func SupportedPolicies() []*model.PolicySpec {
+	var result []*model.PolicySpec
+	for _, p := range executor.ListPolicies() {
+		result = append(result, newPolicySpec(p, nil))
+	}
+	return result
+}
+
+// ActivePolicies returns the policies specified in the custom properties of
+// profile along with their values, the custom properties which are not
+// policies are ignored.
+func ActivePolicies(prf *model.ProfileSpec) []*model.PolicySpec {
+	var result []*model.PolicySpec
+	for _, p := range executor.ListPolicies() {
+		if value, ok := prf.CustomProperties[p.Name]; ok {
+			result = append(result, newPolicySpec(p, value))
+		}
+	}
+	return result
+}
+
+func newPolicySpec(p *executor.Policy, value interface{}) *model.PolicySpec {
+	return &model.PolicySpec{
+		Name:        p.Name,
+		Type:        p.Type,
+		Lifecycle:   executor.LifecycleName(p.Lifecycle),
+		Description: p.Description,
+		Value:       value,
+	}
 }
 
 // StorageTag
@@ -81,30 +87,26 @@ type StorageTag struct {
 	asyncTag map[string]string
 }
 
-// NewStorageTag
+// NewStorageTag divides the policies in tags which take effect at the
+// lifecycle flag into sync and async part, tags is left untouched.
 func NewStorageTag(tags map[string]interface{}, flag int) *StorageTag {
 	var st = &StorageTag{
 		syncTag:  make(map[string]interface{}),
 		asyncTag: make(map[string]string),
 	}
 
-	// Screen storage tags through life circle flag
-	for key := range tags {
-		if flag != PolicyLifecircleTable[key] {
-			delete(tags, key)
-		}
-	}
-	// Divide all tags into sync and async part
-	for key := range tags {
-		pType, err := FindPolicyType(key)
-		if err != nil {
-			log.Error("When parse storage tag:", err)
+	for key, value := range tags {
+		// The custom properties which are not policies are used by the
+		// selector only.
+		p, ok := executor.GetPolicy(key)
+		if !ok || p.Lifecycle != flag {
+			continue
 		}
-		switch pType {
-		case "feature":
-			st.syncTag[key] = tags[key]
-		case "operation":
-			st.asyncTag[key] = fmt.Sprint(tags[key])
+		switch p.Type {
+		case executor.FeaturePolicy:
+			st.syncTag[key] = value
+		case executor.OperationPolicy:
+			st.asyncTag[key] = fmt.Sprint(value)
 		}
 	}
 	return st
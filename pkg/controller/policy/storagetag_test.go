@@ -17,6 +17,8 @@ package policy
 import (
 	"reflect"
 	"testing"
+
+	"github.com/opensds/opensds/pkg/model"
 )
 
 func TestIsStorageTagSupported(t *testing.T) {
@@ -26,7 +28,7 @@ func TestIsStorageTagSupported(t *testing.T) {
 	}
 
 	if !IsStorageTagSupported(tags) {
-		t.Errorf("tags %v are not supported by %v\n", tags, PolicyTypeMappingTable)
+		t.Errorf("tags %v are not supported\n", tags)
 	}
 }
 
@@ -53,19 +55,54 @@ func TestNewStorageTag(t *testing.T) {
 		"intervalSnapshot":     "1d",
 		"deleteSnapshotPolicy": true,
 	}
+	// The policies taking effect at other lifecycle points and the custom
+	// properties which are not policies are screened out.
+	tags["key1"] = "value1"
 	var expectedSt = &StorageTag{
 		syncTag: map[string]interface{}{
 			"thinProvision":    true,
 			"highAvailability": false,
 		},
 		asyncTag: map[string]string{
-			"intervalSnapshot":     "1d",
-			"deleteSnapshotPolicy": "true",
+			"intervalSnapshot": "1d",
 		},
 	}
 
 	st := NewStorageTag(tags, 1)
-	if reflect.DeepEqual(st, expectedSt) {
+	if !reflect.DeepEqual(st, expectedSt) {
 		t.Errorf("Expected %v, got %v\n", expectedSt, st)
 	}
+	if len(tags) != 5 {
+		t.Errorf("Expected tags to be left untouched, got %v\n", tags)
+	}
+
+	st = NewStorageTag(tags, 4)
+	if !reflect.DeepEqual(st.GetAsyncTag(), map[string]string{"deleteSnapshotPolicy": "true"}) {
+		t.Errorf("Unexpected async tags %v\n", st.GetAsyncTag())
+	}
+}
+
+func TestActivePolicies(t *testing.T) {
+	var prf = &model.ProfileSpec{
+		CustomProperties: model.CustomPropertiesSpec{
+			"intervalSnapshot": "1d",
+			"key1":             "value1",
+		},
+	}
+	var expected = []*model.PolicySpec{
+		{
+			Name:        "intervalSnapshot",
+			Type:        "operation",
+			Lifecycle:   "create",
+			Description: "Take a few snapshots of the volume every interval such as 12h after it's created.",
+			Value:       "1d",
+		},
+	}
+
+	if result := ActivePolicies(prf); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+	if result := SupportedPolicies(); len(result) != 5 || result[0].Name != "deleteSnapshotPolicy" {
+		t.Errorf("Unexpected supported policies %v\n", result)
+	}
 }
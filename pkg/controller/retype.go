@@ -0,0 +1,128 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/controller/selector"
+	"github.com/opensds/opensds/pkg/db"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+)
+
+// RetypeVolume changes the profile of volume. If the pool where the volume is
+// located still satisfies the new profile, only the properties of volume on
+// the backend will be changed; otherwise the volume will be migrated to a pool
+// which satisfies the new profile.
+func (c *Controller) RetypeVolume(ctx *c.Context, volID string, prfID string, errchanVolume chan error) {
+	vol, err := db.C.GetVolume(ctx, volID)
+	if err != nil {
+		log.Error("Get volume failed in retype volume method: ", err)
+		errchanVolume <- err
+		return
+	}
+
+	var oldPrfID, oldQos = vol.ProfileId, vol.Qos
+	if err = c.retypeVolume(ctx, vol, prfID); err != nil {
+		log.Error("Retype volume failed: ", err)
+		// The volume has been moved to the pool which satisfies the new
+		// profile, only the source volume is left behind.
+		if vol.MigrationStatus == model.VolumeMigrationPartial {
+			errchanVolume <- err
+			return
+		}
+		vol.ProfileId, vol.Qos = oldPrfID, oldQos
+		if errUpdate := db.C.UpdateStatus(ctx, vol, model.VolumeErrorRetyping); errUpdate != nil {
+			errchanVolume <- errUpdate
+			return
+		}
+		errchanVolume <- err
+		return
+	}
+	errchanVolume <- nil
+}
+
+func (c *Controller) retypeVolume(ctx *c.Context, vol *model.VolumeSpec, prfID string) error {
+	prf, err := db.C.GetProfile(ctx, prfID)
+	if err != nil {
+		log.Error("Get profile failed in retype volume method: ", err)
+		return err
+	}
+	pool, err := db.C.GetPool(ctx, vol.PoolId)
+	if err != nil {
+		log.Error("Get pool failed in retype volume method: ", err)
+		return err
+	}
+
+	isAvailable, err := selector.IsAvailablePool(selector.NewProfileFilterRequest(prf), pool)
+	if err != nil {
+		log.Error("Check pool with new profile failed: ", err)
+		return err
+	}
+	vol.ProfileId = prf.Id
+	// The qos limits of the new profile will be enforced on the target volume
+	// if migration is needed.
+	vol.Qos = profileQos(prf)
+	if !isAvailable {
+		log.Infof("Pool %s does not satisfy profile %s, volume %s will be migrated.",
+			pool.Id, prf.Id, vol.Id)
+		if err = c.migrateVolume(ctx, vol, ""); err != nil && vol.MigrationStatus != model.VolumeMigrationPartial {
+			vol.MigrationStatus = model.VolumeMigrationError
+		}
+		return err
+	}
+
+	dockInfo, err := db.C.GetDockByPoolId(ctx, vol.PoolId)
+	if err != nil {
+		log.Error("When search dock in db by pool id: ", err)
+		return err
+	}
+
+	prfBody, _ := json.Marshal(prf)
+	result, err := c.volumeController.RetypeVolume(dockInfo, &pb.RetypeVolumeOpts{
+		Id:         vol.Id,
+		Size:       vol.Size,
+		PoolName:   pool.Name,
+		ProfileId:  prf.Id,
+		Profile:    string(prfBody),
+		Metadata:   vol.Metadata,
+		DriverName: dockInfo.DriverName,
+		Context:    ctx.ToJson(),
+		Qos:        retypeQos(vol.Qos),
+	})
+	if err != nil {
+		log.Error("Retype volume on backend failed: ", err)
+		return err
+	}
+
+	vol.Metadata = utils.MergeStringMaps(vol.Metadata, result.Metadata)
+	if result.Qos != nil {
+		vol.Qos = result.Qos
+	}
+	return db.C.UpdateStatus(ctx, vol, model.VolumeAvailable)
+}
+
+// retypeQos always returns the qos limits so that the backend is able to
+// remove the limits which are not required by the new profile any more.
+func retypeQos(qos *model.QosSpec) *pb.Qos {
+	if qos == nil {
+		return &pb.Qos{}
+	}
+	return newQos(qos)
+}
@@ -56,6 +56,8 @@ func GetPoolCapabilityMap(pool *model.StoragePoolSpec) (map[string]interface{},
 	if nil != err {
 		return nil, err
 	}
+	// The capacity which can be provisioned in the pool with over-subscription.
+	temMap["virtualFreeCapacity"] = float64(pool.VirtualFreeCapacity())
 
 	result := make(map[string]interface{})
 
@@ -338,6 +338,40 @@ func TestFreeCapacityFilter(t *testing.T) {
 	}
 }
 
+func TestVirtualFreeCapacityFilter(t *testing.T) {
+	thin, fixed := *FakePools[0], *FakePools[1]
+	thin.MaxOverSubscriptionRatio = 1.5
+	thin.ProvisionedCapacity = 120
+	fixed.MaxOverSubscriptionRatio = 1.5
+	fixed.Extras.DataStorage.ProvisioningPolicy = model.ProvisioningPolicyFixed
+	pools := []*model.StoragePoolSpec{&thin, &fixed}
+
+	testCases := []FilterCaseSpec{
+		{
+			// The virtual free capacity of the thin pool is 100*1.5-120.
+			request: map[string]interface{}{
+				"virtualFreeCapacity": "== 30",
+			},
+			expected: []*model.StoragePoolSpec{&thin},
+		},
+		{
+			// The virtual free capacity of the fixed pool is the free capacity.
+			request: map[string]interface{}{
+				"virtualFreeCapacity": ">= 170",
+			},
+			expected: []*model.StoragePoolSpec{&fixed},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, _ := SelectSupportedPools(len(pools), testCase.request, pools)
+
+		if !reflect.DeepEqual(result, testCase.expected) {
+			t.Errorf("Expected %v, get %v", testCase.expected, result)
+		}
+	}
+}
+
 func TestIsSpaceEfficientFilter(t *testing.T) {
 	testCases := []FilterCaseSpec{
 		{
@@ -28,23 +28,81 @@ import (
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/db"
 	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/pkg/utils/config"
 )
 
+// SelectionKey is the key of volume metadata where the summary of its pool
+// selection is kept for debugging.
+const SelectionKey = "poolSelection"
+
 // Selector is an interface that exposes some operation of different selectors.
 type Selector interface {
+	SelectPoolForVolume(*model.VolumeSpec) (*Selection, error)
 	SelectSupportedPoolForVolume(*model.VolumeSpec) (*model.StoragePoolSpec, error)
 	SelectSupportedPoolForVG(*model.VolumeGroupSpec) (*model.StoragePoolSpec, error)
+	SelectSupportedPoolForMigration(*model.VolumeSpec) (*model.StoragePoolSpec, error)
+	SelectSupportedPoolForFileShare(*model.FileShareSpec) (*model.StoragePoolSpec, error)
 }
 
-type selector struct{}
+type selector struct {
+	weighers []*weightedWeigher
+}
 
 // NewSelector method creates a new selector structure and return its pointer.
 func NewSelector() Selector {
-	return &selector{}
+	return &selector{
+		weighers: parseWeighers(CONF.OsdsLet.PoolWeighers),
+	}
+}
+
+// SelectPoolForVolume selects the pool for volume, and returns the decision
+// along with the scores of candidate pools.
+func (s *selector) SelectPoolForVolume(in *model.VolumeSpec) (*Selection, error) {
+	return s.selectSupportedPool(in, in.PoolId, model.StorageTypeBlock)
 }
 
 // SelectSupportedPoolForVolume
 func (s *selector) SelectSupportedPoolForVolume(in *model.VolumeSpec) (*model.StoragePoolSpec, error) {
+	sel, err := s.SelectPoolForVolume(in)
+	if err != nil {
+		return nil, err
+	}
+	return sel.Pool, nil
+}
+
+// SelectSupportedPoolForMigration selects a pool which satisfies the profile
+// of volume for migration, the pool where the volume is located is excluded.
+func (s *selector) SelectSupportedPoolForMigration(in *model.VolumeSpec) (*model.StoragePoolSpec, error) {
+	sel, err := s.selectSupportedPool(in, "s!= "+in.PoolId, model.StorageTypeBlock)
+	if err != nil {
+		return nil, err
+	}
+	return sel.Pool, nil
+}
+
+// SelectSupportedPoolForFileShare selects a pool of file storage type which
+// satisfies the profile of file share.
+func (s *selector) SelectSupportedPoolForFileShare(in *model.FileShareSpec) (*model.StoragePoolSpec, error) {
+	// The file share is weighed as a volume of the same size, so that the
+	// capacity weighers work for it as well.
+	vol := &model.VolumeSpec{
+		Name:             in.Name,
+		Size:             in.Size,
+		ProfileId:        in.ProfileId,
+		AvailabilityZone: in.AvailabilityZone,
+	}
+	sel, err := s.selectSupportedPool(vol, in.PoolId, model.StorageTypeFile)
+	if err != nil {
+		return nil, err
+	}
+	return sel.Pool, nil
+}
+
+// selectSupportedPool filters all pools of the storage type with the rules
+// defined in profile of volume, poolRule is used for filtering the pool id if
+// it is not empty. The pools which pass the filters are weighed by the
+// configured weighers.
+func (s *selector) selectSupportedPool(in *model.VolumeSpec, poolRule, storageType string) (*Selection, error) {
 	var prf *model.ProfileSpec
 	var err error
 
@@ -63,84 +121,26 @@ func (s *selector) SelectSupportedPoolForVolume(in *model.VolumeSpec) (*model.St
 		log.Error("When list pools in resources SelectSupportedPool: ", err)
 		return nil, err
 	}
+	pools = poolsOfStorageType(availablePools(pools), storageType)
 
-	// Generate filter request according to the rules defined in profile.
-	fltRequest := func(prf *model.ProfileSpec, in *model.VolumeSpec) map[string]interface{} {
-		var filterRequest map[string]interface{}
-		if !prf.CustomProperties.IsEmpty() {
-			filterRequest = prf.CustomProperties
-		} else {
-			filterRequest = make(map[string]interface{})
-		}
-		// Insert some basic rules.
-		filterRequest["freeCapacity"] = ">= " + strconv.Itoa(int(in.Size))
-		if in.AvailabilityZone != "" {
-			filterRequest["availabilityZone"] = in.AvailabilityZone
-		} else {
-			filterRequest["availabilityZone"] = "default"
-		}
-		if in.PoolId != "" {
-			filterRequest["id"] = in.PoolId
-		}
-		// Insert some rules of provisioning properties.
-		if pp := prf.ProvisioningProperties; !pp.IsEmpty() {
-			if ds := pp.DataStorage; !ds.IsEmpty() {
-				filterRequest["extras.dataStorage.isSpaceEfficient"] =
-					"<is> " + strconv.FormatBool(ds.IsSpaceEfficient)
-				if ds.ProvisioningPolicy != "" {
-					filterRequest["extras.dataStorage.provisioningPolicy"] =
-						ds.ProvisioningPolicy
-				}
-				if ds.RecoveryTimeObjective != 0 {
-					filterRequest["extras.dataStorage.recoveryTimeObjective"] =
-						"<= " + strconv.Itoa(int(ds.RecoveryTimeObjective))
-				}
-			}
-			if ic := pp.IOConnectivity; !ic.IsEmpty() {
-				if ic.AccessProtocol != "" {
-					filterRequest["extras.ioConnectivity.accessProtocol"] =
-						ic.AccessProtocol
-				}
-				if ic.MaxIOPS != 0 {
-					filterRequest["extras.ioConnectivity.maxIOPS"] =
-						">= " + strconv.Itoa(int(ic.MaxIOPS))
-				}
-				if ic.MaxBWS != 0 {
-					filterRequest["extras.ioConnectivity.maxBWS"] =
-						">= " + strconv.Itoa(int(ic.MaxBWS))
-				}
-			}
-		}
-		// Insert some rules of replication properties.
-		if rp := prf.ReplicationProperties; !rp.IsEmpty() {
-			if dp := rp.DataProtection; !dp.IsEmpty() {
-				filterRequest["extras.dataProtection.isIsolated"] =
-					"<is> " + strconv.FormatBool(dp.IsIsolated)
-				if dp.RecoveryGeographicObject != "" {
-					filterRequest["extras.dataProtection.recoveryGeographicObject"] =
-						dp.RecoveryGeographicObject
-				}
-				if dp.RecoveryTimeObjective != "" {
-					filterRequest["extras.dataProtection.recoveryTimeObjective"] =
-						dp.RecoveryTimeObjective
-				}
-				if dp.ReplicaType != "" {
-					filterRequest["extras.dataProtection.replicaType"] =
-						dp.ReplicaType
-				}
-			}
-		}
-		return filterRequest
-	}(prf, in)
+	fltRequest := NewVolumeFilterRequest(prf, in)
+	if poolRule != "" {
+		fltRequest["id"] = poolRule
+	}
 
-	supportedPools, err := SelectSupportedPools(1, fltRequest, pools)
+	supportedPools, err := SelectSupportedPools(len(pools), fltRequest, pools)
 	if err != nil {
 		log.Error("Filter supported pools failed: ", err)
 		return nil, err
 	}
-	// Now, we just return the first supported pool which will be improved in
-	// the future.
-	return supportedPools[0], nil
+
+	sel, err := weighPools(s.weighers, &WeighRequest{Volume: in, Pools: supportedPools})
+	if err != nil {
+		log.Error("Weigh supported pools failed: ", err)
+		return nil, err
+	}
+	log.Infof("Pool selection for volume %s: %s", in.Name, sel)
+	return sel, nil
 }
 
 func (s *selector) SelectSupportedPoolForVG(in *model.VolumeGroupSpec) (*model.StoragePoolSpec, error) {
@@ -157,6 +157,7 @@ func (s *selector) SelectSupportedPoolForVG(in *model.VolumeGroupSpec) (*model.S
 	if err != nil {
 		return nil, err
 	}
+	pools = poolsOfStorageType(availablePools(pools), model.StorageTypeBlock)
 
 	var filterRequest map[string]interface{}
 	for _, pool := range pools {
@@ -186,6 +187,113 @@ func (s *selector) SelectSupportedPoolForVG(in *model.VolumeGroupSpec) (*model.S
 	return nil, errors.New("No valid pool found for group.")
 }
 
+// availablePools excludes the pools which have been marked as unavailable,
+// since their docks are down.
+func availablePools(pools []*model.StoragePoolSpec) []*model.StoragePoolSpec {
+	var result []*model.StoragePoolSpec
+	for _, pool := range pools {
+		if pool.Status != model.PoolUnavailable {
+			result = append(result, pool)
+		}
+	}
+	return result
+}
+
+// poolsOfStorageType picks out the pools of the storage type, the pool whose
+// storage type is not reported is regarded as a block one.
+func poolsOfStorageType(pools []*model.StoragePoolSpec, storageType string) []*model.StoragePoolSpec {
+	var result []*model.StoragePoolSpec
+	for _, pool := range pools {
+		t := pool.StorageType
+		if t == "" {
+			t = model.StorageTypeBlock
+		}
+		if t == storageType {
+			result = append(result, pool)
+		}
+	}
+	return result
+}
+
+// NewProfileFilterRequest generates the filter request according to the rules
+// defined in profile, which can be used for checking whether a pool satisfies
+// the profile.
+func NewProfileFilterRequest(prf *model.ProfileSpec) map[string]interface{} {
+	var filterRequest = make(map[string]interface{})
+	for k, v := range prf.CustomProperties {
+		filterRequest[k] = v
+	}
+	// Insert some rules of provisioning properties.
+	if pp := prf.ProvisioningProperties; !pp.IsEmpty() {
+		if ds := pp.DataStorage; !ds.IsEmpty() {
+			filterRequest["extras.dataStorage.isSpaceEfficient"] =
+				"<is> " + strconv.FormatBool(ds.IsSpaceEfficient)
+			if ds.ProvisioningPolicy != "" {
+				filterRequest["extras.dataStorage.provisioningPolicy"] =
+					ds.ProvisioningPolicy
+			}
+			if ds.RecoveryTimeObjective != 0 {
+				filterRequest["extras.dataStorage.recoveryTimeObjective"] =
+					"<= " + strconv.Itoa(int(ds.RecoveryTimeObjective))
+			}
+		}
+		if ic := pp.IOConnectivity; !ic.IsEmpty() {
+			if ic.AccessProtocol != "" {
+				filterRequest["extras.ioConnectivity.accessProtocol"] =
+					ic.AccessProtocol
+			}
+			if ic.MaxIOPS != 0 {
+				filterRequest["extras.ioConnectivity.maxIOPS"] =
+					">= " + strconv.Itoa(int(ic.MaxIOPS))
+			}
+			if ic.MaxBWS != 0 {
+				filterRequest["extras.ioConnectivity.maxBWS"] =
+					">= " + strconv.Itoa(int(ic.MaxBWS))
+			}
+		}
+	}
+	// Insert some rules of replication properties.
+	if rp := prf.ReplicationProperties; !rp.IsEmpty() {
+		if dp := rp.DataProtection; !dp.IsEmpty() {
+			filterRequest["extras.dataProtection.isIsolated"] =
+				"<is> " + strconv.FormatBool(dp.IsIsolated)
+			if dp.RecoveryGeographicObject != "" {
+				filterRequest["extras.dataProtection.recoveryGeographicObject"] =
+					dp.RecoveryGeographicObject
+			}
+			if dp.RecoveryTimeObjective != "" {
+				filterRequest["extras.dataProtection.recoveryTimeObjective"] =
+					dp.RecoveryTimeObjective
+			}
+			if dp.ReplicaType != "" {
+				filterRequest["extras.dataProtection.replicaType"] =
+					dp.ReplicaType
+			}
+		}
+	}
+	return filterRequest
+}
+
+// NewVolumeFilterRequest generates the filter request of the pools which are
+// able to hold the volume, that is the rules defined in profile along with
+// the capacity and availability zone of volume.
+func NewVolumeFilterRequest(prf *model.ProfileSpec, in *model.VolumeSpec) map[string]interface{} {
+	filterRequest := NewProfileFilterRequest(prf)
+	// The thin provisioned volume is limited by the virtual free capacity of
+	// the pool instead of the physical one.
+	if prf.ProvisioningProperties.DataStorage.ProvisioningPolicy == model.ProvisioningPolicyThin {
+		filterRequest["virtualFreeCapacity"] = ">= " + strconv.Itoa(int(in.Size))
+	} else {
+		filterRequest["freeCapacity"] = ">= " + strconv.Itoa(int(in.Size))
+	}
+	if in.AvailabilityZone != "" {
+		filterRequest["availabilityZone"] = in.AvailabilityZone
+	} else {
+		filterRequest["availabilityZone"] = "default"
+	}
+	return filterRequest
+}
+
 // SelectSupportedPools ...
 func SelectSupportedPools(maxNum int, filterReq map[string]interface{}, pools []*model.StoragePoolSpec) ([]*model.StoragePoolSpec, error) {
 	supportedPools := []*model.StoragePoolSpec{}
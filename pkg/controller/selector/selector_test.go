@@ -72,6 +72,176 @@ func TestSelectSupportedPoolForVolume(t *testing.T) {
 	}
 }
 
+func TestSelectSupportedPoolForMigration(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDefaultProfile", c.NewAdminContext()).Return(fakeProfiles[0], nil)
+	mockClient.On("ListPools", c.NewAdminContext()).Return(fakePools, nil)
+	db.C = mockClient
+
+	testCases := []struct {
+		request  *model.VolumeSpec
+		expected *model.StoragePoolSpec
+	}{
+		{
+			request: &model.VolumeSpec{
+				Size:             40,
+				AvailabilityZone: "az1",
+				PoolId:           "f4486139-78d5-462d-a7b9-fdaf6c797e1b",
+			},
+			expected: fakePools[1],
+		},
+		{
+			request: &model.VolumeSpec{
+				Size:             6000,
+				AvailabilityZone: "az1",
+				PoolId:           "42a4c0e0-b497-11e8-a14c-3bb1bb1e8caf",
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewSelector()
+	for _, testCase := range testCases {
+		result, _ := s.SelectSupportedPoolForMigration(testCase.request)
+		if !reflect.DeepEqual(result, testCase.expected) {
+			t.Errorf("Expected %v, get %v", testCase.expected, result)
+		}
+	}
+}
+
+func TestSelectSupportedPoolSkipsUnavailablePool(t *testing.T) {
+	var pools []*model.StoragePoolSpec
+	for _, pool := range fakePools {
+		p := *pool
+		pools = append(pools, &p)
+	}
+	pools[0].Status = model.PoolUnavailable
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDefaultProfile", c.NewAdminContext()).Return(fakeProfiles[0], nil)
+	mockClient.On("ListPools", c.NewAdminContext()).Return(pools, nil)
+	db.C = mockClient
+
+	s := NewSelector()
+	result, err := s.SelectSupportedPoolForVolume(&model.VolumeSpec{
+		Size:             40,
+		AvailabilityZone: "az1",
+	})
+	if err != nil {
+		t.Fatal("Select pool failed:", err)
+	}
+	if result.Id == pools[0].Id {
+		t.Errorf("Expected the unavailable pool %s is skipped", pools[0].Id)
+	}
+}
+
+func TestSelectSupportedPoolForThinVolume(t *testing.T) {
+	var pools []*model.StoragePoolSpec
+	for _, pool := range fakePools {
+		p := *pool
+		pools = append(pools, &p)
+	}
+	pools[1].MaxOverSubscriptionRatio = 2
+	pools[1].ProvisionedCapacity = 150000
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDefaultProfile", c.NewAdminContext()).Return(fakeProfiles[0], nil)
+	mockClient.On("GetProfile", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(fakeProfiles[1], nil)
+	mockClient.On("ListPools", c.NewAdminContext()).Return(pools, nil)
+	db.C = mockClient
+
+	s := NewSelector()
+	// The thin volume fits in the virtual free capacity of pool 2 only.
+	result, err := s.SelectSupportedPoolForVolume(&model.VolumeSpec{
+		Size:             10000,
+		ProfileId:        "2f9c0a04-66ef-11e7-ade2-43158893e017",
+		AvailabilityZone: "az1",
+	})
+	if err != nil {
+		t.Fatal("Select pool failed:", err)
+	}
+	if result.Id != pools[1].Id {
+		t.Errorf("Expected %v, get %v", pools[1], result)
+	}
+	// The volume of default profile is limited by the physical free capacity.
+	if _, err := s.SelectSupportedPoolForVolume(&model.VolumeSpec{
+		Size:             10000,
+		AvailabilityZone: "az1",
+	}); err == nil {
+		t.Error("Expected error when no pool has enough free capacity")
+	}
+}
+
+func TestSelectSupportedPoolForFileShare(t *testing.T) {
+	var pools []*model.StoragePoolSpec
+	for _, pool := range fakePools {
+		p := *pool
+		pools = append(pools, &p)
+	}
+	pools[0].StorageType = model.StorageTypeFile
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDefaultProfile", c.NewAdminContext()).Return(fakeProfiles[0], nil)
+	mockClient.On("ListPools", c.NewAdminContext()).Return(pools, nil)
+	db.C = mockClient
+
+	s := NewSelector()
+	result, err := s.SelectSupportedPoolForFileShare(&model.FileShareSpec{
+		Size:             40,
+		AvailabilityZone: "az1",
+	})
+	if err != nil {
+		t.Fatal("Select pool failed:", err)
+	}
+	if result.Id != pools[0].Id {
+		t.Errorf("Expected %v, get %v", pools[0], result)
+	}
+	// The file pool is excluded from the pools of volumes.
+	result, err = s.SelectSupportedPoolForVolume(&model.VolumeSpec{
+		Size:             40,
+		AvailabilityZone: "az1",
+	})
+	if err != nil {
+		t.Fatal("Select pool failed:", err)
+	}
+	if result.Id == pools[0].Id {
+		t.Errorf("Expected the file pool %s is skipped", pools[0].Id)
+	}
+	if _, err := s.SelectSupportedPoolForFileShare(&model.FileShareSpec{
+		Size:             40,
+		AvailabilityZone: "az2",
+	}); err == nil {
+		t.Error("Expected error when no file pool is in the availability zone")
+	}
+}
+
+func TestNewProfileFilterRequest(t *testing.T) {
+	testCases := []struct {
+		pool     *model.StoragePoolSpec
+		expected bool
+	}{
+		{
+			pool:     fakePools[0],
+			expected: false,
+		},
+		{
+			pool:     fakePools[1],
+			expected: true,
+		},
+	}
+
+	fltRequest := NewProfileFilterRequest(fakeProfiles[1])
+	for _, testCase := range testCases {
+		result, err := IsAvailablePool(fltRequest, testCase.pool)
+		if err != nil {
+			t.Error(err)
+		}
+		if result != testCase.expected {
+			t.Errorf("Expected %v, get %v", testCase.expected, result)
+		}
+	}
+}
+
 var (
 	fakeProfiles = []*model.ProfileSpec{
 		{
@@ -0,0 +1,339 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the weighing of pools which pass the filters, so that
+volumes are spread over the pools instead of landing on the first one.
+
+*/
+
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+const (
+	FreeCapacityWeigher     = "free_capacity"
+	CapacityRatioWeigher    = "capacity_ratio"
+	ProvisionedRatioWeigher = "provisioned_ratio"
+	VolumeCountWeigher      = "volume_count"
+	AZSpreadWeigher         = "az_spread"
+)
+
+// Weigher gives each of the pools which pass the filters a raw score, and the
+// pool with higher score is preferred. The scores of each weigher are
+// normalized to [0, 1] and multiplied by the weight of it configured in
+// opensds.conf, then the pool with the highest sum is selected.
+type Weigher interface {
+	Weigh(req *WeighRequest) ([]float64, error)
+}
+
+// WeigherFunc is an adapter which allows a function to be used as Weigher.
+type WeigherFunc func(req *WeighRequest) ([]float64, error)
+
+// Weigh calls f(req).
+func (f WeigherFunc) Weigh(req *WeighRequest) ([]float64, error) {
+	return f(req)
+}
+
+var weighers = map[string]Weigher{}
+
+// RegisterWeigher makes the weigher available by the name in the
+// pool_weighers option of opensds.conf.
+func RegisterWeigher(name string, w Weigher) error {
+	if _, exist := weighers[name]; exist {
+		return fmt.Errorf("Weigher %s already exist", name)
+	}
+
+	weighers[name] = w
+	return nil
+}
+
+// UnregisterWeigher
+func UnregisterWeigher(name string) {
+	delete(weighers, name)
+}
+
+func init() {
+	RegisterWeigher(FreeCapacityWeigher, WeigherFunc(weighFreeCapacity))
+	RegisterWeigher(CapacityRatioWeigher, WeigherFunc(weighCapacityRatio))
+	RegisterWeigher(ProvisionedRatioWeigher, WeigherFunc(weighProvisionedRatio))
+	RegisterWeigher(VolumeCountWeigher, WeigherFunc(weighVolumeCount))
+	RegisterWeigher(AZSpreadWeigher, WeigherFunc(weighAZSpread))
+}
+
+// WeighRequest is the volume to be placed along with the candidate pools.
+type WeighRequest struct {
+	Volume *model.VolumeSpec
+	Pools  []*model.StoragePoolSpec
+
+	usage map[string]*PoolUsage
+}
+
+// PoolUsage is the amount of volumes which have been placed in a pool.
+type PoolUsage struct {
+	// The sum of volume sizes in GB.
+	Provisioned int64
+	Volumes     int64
+}
+
+// Usage returns the usage of pools keyed by pool id. Volumes are listed only
+// once for a request, and only when a weigher needs them.
+func (r *WeighRequest) Usage() (map[string]*PoolUsage, error) {
+	if r.usage != nil {
+		return r.usage, nil
+	}
+	vols, err := db.C.ListVolumes(c.NewAdminContext())
+	if err != nil {
+		log.Error("When list volumes to weigh pools:", err)
+		return nil, err
+	}
+	r.usage = make(map[string]*PoolUsage)
+	for _, vol := range vols {
+		u, ok := r.usage[vol.PoolId]
+		if !ok {
+			u = &PoolUsage{}
+			r.usage[vol.PoolId] = u
+		}
+		u.Provisioned += vol.Size
+		u.Volumes++
+	}
+	return r.usage, nil
+}
+
+func usageOf(usage map[string]*PoolUsage, poolId string) *PoolUsage {
+	if u, ok := usage[poolId]; ok {
+		return u
+	}
+	return &PoolUsage{}
+}
+
+// weighFreeCapacity prefers the pool with more free capacity.
+func weighFreeCapacity(req *WeighRequest) ([]float64, error) {
+	var scores []float64
+	for _, pool := range req.Pools {
+		scores = append(scores, float64(pool.FreeCapacity))
+	}
+	return scores, nil
+}
+
+// weighCapacityRatio prefers the pool with higher ratio of free capacity to
+// total capacity.
+func weighCapacityRatio(req *WeighRequest) ([]float64, error) {
+	var scores []float64
+	for _, pool := range req.Pools {
+		var score float64
+		if pool.TotalCapacity > 0 {
+			score = float64(pool.FreeCapacity) / float64(pool.TotalCapacity)
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
+
+// weighProvisionedRatio prefers the pool with lower ratio of provisioned
+// capacity to total capacity after the volume is placed in it.
+func weighProvisionedRatio(req *WeighRequest) ([]float64, error) {
+	usage, err := req.Usage()
+	if err != nil {
+		return nil, err
+	}
+	var scores []float64
+	for _, pool := range req.Pools {
+		var score float64
+		if pool.TotalCapacity > 0 {
+			provisioned := usageOf(usage, pool.Id).Provisioned + req.Volume.Size
+			score = -float64(provisioned) / float64(pool.TotalCapacity)
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
+
+// weighVolumeCount prefers the pool with fewer volumes.
+func weighVolumeCount(req *WeighRequest) ([]float64, error) {
+	usage, err := req.Usage()
+	if err != nil {
+		return nil, err
+	}
+	var scores []float64
+	for _, pool := range req.Pools {
+		scores = append(scores, -float64(usageOf(usage, pool.Id).Volumes))
+	}
+	return scores, nil
+}
+
+// weighAZSpread prefers the pool in the availability zone with fewer volumes,
+// it only makes a difference when the candidate pools are in several zones.
+func weighAZSpread(req *WeighRequest) ([]float64, error) {
+	usage, err := req.Usage()
+	if err != nil {
+		return nil, err
+	}
+	pools, err := db.C.ListPools(c.NewAdminContext())
+	if err != nil {
+		log.Error("When list pools to weigh pools:", err)
+		return nil, err
+	}
+	var zoneVolumes = make(map[string]int64)
+	for _, pool := range pools {
+		zoneVolumes[pool.AvailabilityZone] += usageOf(usage, pool.Id).Volumes
+	}
+
+	var scores []float64
+	for _, pool := range req.Pools {
+		scores = append(scores, -float64(zoneVolumes[pool.AvailabilityZone]))
+	}
+	return scores, nil
+}
+
+type weightedWeigher struct {
+	name   string
+	weight float64
+	Weigher
+}
+
+// parseWeighers parses the pool_weighers option, whose items are in the
+// format of name:weight. The unknown weighers and invalid weights are ignored.
+func parseWeighers(items []string) []*weightedWeigher {
+	var result []*weightedWeigher
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kv := strings.SplitN(item, ":", 2)
+		name, weight := strings.TrimSpace(kv[0]), 1.0
+		if len(kv) == 2 {
+			var err error
+			if weight, err = strconv.ParseFloat(strings.TrimSpace(kv[1]), 64); err != nil {
+				log.Errorf("Invalid weight of pool weigher %s: %s", name, kv[1])
+				continue
+			}
+		}
+		w, ok := weighers[name]
+		if !ok {
+			log.Errorf("Pool weigher %s is not registered", name)
+			continue
+		}
+		if weight == 0 {
+			continue
+		}
+		result = append(result, &weightedWeigher{name: name, weight: weight, Weigher: w})
+	}
+	return result
+}
+
+// WeighedPool is a candidate pool along with its scores.
+type WeighedPool struct {
+	PoolId string  `json:"poolId"`
+	Score  float64 `json:"score"`
+	// The weighted and normalized score given by each weigher.
+	Scores map[string]float64 `json:"scores,omitempty"`
+}
+
+// Selection is the decision of pool selection, which is logged and returned
+// for debugging.
+type Selection struct {
+	Pool *model.StoragePoolSpec `json:"-"`
+	// Candidates are the pools which pass the filters in the descending
+	// order of their scores.
+	Candidates []*WeighedPool `json:"candidates"`
+}
+
+func (s *Selection) String() string {
+	body, _ := json.Marshal(s)
+	return fmt.Sprintf("selected pool %s, %s", s.Pool.Id, string(body))
+}
+
+// Summary returns the selected pool and the scores of candidates in json,
+// which is kept in the metadata of volume.
+func (s *Selection) Summary() string {
+	body, _ := json.Marshal(struct {
+		PoolId     string         `json:"poolId"`
+		Candidates []*WeighedPool `json:"candidates"`
+	}{s.Pool.Id, s.Candidates})
+	return string(body)
+}
+
+// weighPools selects the pool with the highest score, and the first one of
+// pools wins if there is a tie.
+func weighPools(ws []*weightedWeigher, req *WeighRequest) (*Selection, error) {
+	var candidates []*WeighedPool
+	for _, pool := range req.Pools {
+		candidates = append(candidates, &WeighedPool{PoolId: pool.Id, Scores: map[string]float64{}})
+	}
+
+	for _, w := range ws {
+		scores, err := w.Weigh(req)
+		if err != nil {
+			return nil, err
+		}
+		if len(scores) != len(req.Pools) {
+			return nil, fmt.Errorf("weigher %s returns %d scores for %d pools", w.name, len(scores), len(req.Pools))
+		}
+		for i, score := range normalize(scores) {
+			candidates[i].Scores[w.name] = score * w.weight
+			candidates[i].Score += score * w.weight
+		}
+	}
+
+	var best = 0
+	for i := range candidates {
+		if candidates[i].Score > candidates[best].Score {
+			best = i
+		}
+	}
+	var sel = &Selection{Pool: req.Pools[best]}
+	sel.Candidates = append(sel.Candidates, candidates...)
+	sort.SliceStable(sel.Candidates, func(i, j int) bool {
+		return sel.Candidates[i].Score > sel.Candidates[j].Score
+	})
+	return sel, nil
+}
+
+// normalize scales the scores to [0, 1], all the scores are zero if they
+// are the same.
+func normalize(scores []float64) []float64 {
+	var result = make([]float64, len(scores))
+	if len(scores) == 0 {
+		return result
+	}
+	min, max := scores[0], scores[0]
+	for _, s := range scores {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+	if max == min {
+		return result
+	}
+	for i, s := range scores {
+		result[i] = (s - min) / (max - min)
+	}
+	return result
+}
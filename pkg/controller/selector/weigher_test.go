@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+)
+
+var weighPoolsData = []*model.StoragePoolSpec{
+	{
+		BaseModel:        &model.BaseModel{Id: "pool-1"},
+		AvailabilityZone: "az1",
+		TotalCapacity:    100,
+		FreeCapacity:     50,
+	},
+	{
+		BaseModel:        &model.BaseModel{Id: "pool-2"},
+		AvailabilityZone: "az1",
+		TotalCapacity:    1000,
+		FreeCapacity:     200,
+	},
+	{
+		BaseModel:        &model.BaseModel{Id: "pool-3"},
+		AvailabilityZone: "az2",
+		TotalCapacity:    100,
+		FreeCapacity:     90,
+	},
+}
+
+var weighVolumesData = []*model.VolumeSpec{
+	{PoolId: "pool-1", Size: 10},
+	{PoolId: "pool-2", Size: 500},
+	{PoolId: "pool-2", Size: 200},
+	{PoolId: "pool-3", Size: 60},
+}
+
+func TestParseWeighers(t *testing.T) {
+	ws := parseWeighers([]string{
+		"free_capacity:2", " volume_count ", "capacity_ratio:0", "unknown:1", "az_spread:invalid", "",
+	})
+
+	var names []string
+	var weights []float64
+	for _, w := range ws {
+		names = append(names, w.name)
+		weights = append(weights, w.weight)
+	}
+	if !reflect.DeepEqual(names, []string{FreeCapacityWeigher, VolumeCountWeigher}) ||
+		!reflect.DeepEqual(weights, []float64{2, 1}) {
+		t.Errorf("Unexpected weighers %v with weights %v", names, weights)
+	}
+}
+
+func TestWeighPools(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListVolumes", c.NewAdminContext()).Return(weighVolumesData, nil)
+	mockClient.On("ListPools", c.NewAdminContext()).Return(weighPoolsData, nil)
+	db.C = mockClient
+
+	testCases := []struct {
+		weighers []string
+		expected string
+	}{
+		{weighers: nil, expected: "pool-1"},
+		{weighers: []string{"free_capacity:1"}, expected: "pool-2"},
+		{weighers: []string{"capacity_ratio:1"}, expected: "pool-3"},
+		{weighers: []string{"provisioned_ratio:1"}, expected: "pool-1"},
+		{weighers: []string{"volume_count:1"}, expected: "pool-1"},
+		{weighers: []string{"az_spread:1"}, expected: "pool-3"},
+		{weighers: []string{"free_capacity:1", "capacity_ratio:2"}, expected: "pool-3"},
+	}
+
+	for _, testCase := range testCases {
+		req := &WeighRequest{Volume: &model.VolumeSpec{Size: 10}, Pools: weighPoolsData}
+		sel, err := weighPools(parseWeighers(testCase.weighers), req)
+		if err != nil {
+			t.Error("Weigh pools failed:", err)
+			continue
+		}
+		if sel.Pool.Id != testCase.expected {
+			t.Errorf("Expected %s with weighers %v, got %s", testCase.expected, testCase.weighers, sel)
+		}
+		if len(sel.Candidates) != len(weighPoolsData) || sel.Candidates[0].PoolId != testCase.expected {
+			t.Errorf("Unexpected candidates %s", sel)
+		}
+	}
+	// Volumes are listed once for each request whose weighers need them.
+	mockClient.AssertNumberOfCalls(t, "ListVolumes", 3)
+}
+
+func TestSelectPoolForVolume(t *testing.T) {
+	mockClient := new(dbtest.Client)
+	mockClient.On("GetDefaultProfile", c.NewAdminContext()).Return(fakeProfiles[0], nil)
+	mockClient.On("ListPools", c.NewAdminContext()).Return(weighPoolsData, nil)
+	db.C = mockClient
+
+	s := &selector{weighers: parseWeighers([]string{"capacity_ratio:1"})}
+	sel, err := s.SelectPoolForVolume(&model.VolumeSpec{
+		Size:             40,
+		AvailabilityZone: "az1",
+	})
+	if err != nil {
+		t.Fatal("Select pool failed:", err)
+	}
+	// Pool 3 is filtered out by the availability zone.
+	if sel.Pool.Id != "pool-1" || len(sel.Candidates) != 2 {
+		t.Errorf("Unexpected selection %s", sel)
+	}
+	if sel.Candidates[0].Scores[CapacityRatioWeigher] != 1 || sel.Candidates[1].Score != 0 {
+		t.Errorf("Unexpected scores %s", sel)
+	}
+
+	var summary struct {
+		PoolId     string         `json:"poolId"`
+		Candidates []*WeighedPool `json:"candidates"`
+	}
+	if err := json.Unmarshal([]byte(sel.Summary()), &summary); err != nil {
+		t.Fatal("Unmarshal selection summary failed:", err)
+	}
+	if summary.PoolId != "pool-1" || !reflect.DeepEqual(summary.Candidates, sel.Candidates) {
+		t.Errorf("Unexpected summary %s", sel.Summary())
+	}
+}
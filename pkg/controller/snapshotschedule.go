@@ -0,0 +1,267 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the snapshot schedules of volumes, which take the
+snapshots at the occurrences of schedules and prune the ones beyond retention.
+
+*/
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/opensds/opensds/pkg/utils/schedule"
+	"github.com/satori/go.uuid"
+)
+
+// snapshotSchedulerLock is the name of the lock held by the osdslet which
+// runs the snapshot schedules.
+const snapshotSchedulerLock = "snapshot-scheduler"
+
+// ScheduleSnapshots runs the snapshot schedules every interval until stop is
+// closed. Only the osdslet holding the scheduler lock runs them, the lock
+// expires after a few intervals so that another osdslet takes over if the
+// holder dies.
+func ScheduleSnapshots(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewV4().String())
+	for {
+		ctx := c.NewAdminContext()
+		leader, err := db.C.AcquireLock(ctx, snapshotSchedulerLock, holder, 3*interval)
+		if err != nil {
+			log.Error("When acquire the lock of snapshot scheduler:", err)
+		} else if leader {
+			if err := RunSnapshotSchedules(ctx, time.Now()); err != nil {
+				log.Error("When run snapshot schedules:", err)
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunSnapshotSchedules takes the snapshots whose occurrences have come by now
+// and prunes the snapshots beyond retention. The occurrences missed while no
+// osdslet was running are collapsed into one snapshot.
+func RunSnapshotSchedules(ctx *c.Context, now time.Time) error {
+	scheds, err := db.C.ListSnapshotSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	snaps, err := db.C.ListVolumeSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+
+	var taken = make(map[string][]*model.VolumeSnapshotSpec)
+	for _, snap := range snaps {
+		if id, ok := snap.Metadata[model.SnapshotScheduleIdKey]; ok {
+			taken[id] = append(taken[id], snap)
+		}
+	}
+	for _, sched := range scheds {
+		snap, err := runSnapshotSchedule(sched, now)
+		if err != nil {
+			log.Errorf("When run snapshot schedule %s of volume %s: %v", sched.Id, sched.VolumeId, err)
+		}
+		if snap != nil {
+			taken[sched.Id] = append(taken[sched.Id], snap)
+		}
+		pruneSnapshots(sched, taken[sched.Id], now)
+	}
+	return nil
+}
+
+// runSnapshotSchedule takes a snapshot if the occurrence of schedule has come,
+// and returns the snapshot taken. The next occurrence is persisted before the
+// snapshot is taken, so that an occurrence is never executed twice even if
+// the osdslet restarts or another osdslet takes over in the middle.
+func runSnapshotSchedule(sched *model.SnapshotScheduleSpec, now time.Time) (*model.VolumeSnapshotSpec, error) {
+	occ, err := schedule.Parse(sched.Occurrence, sched.Datetime)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.NewInternalTenantContext(sched.TenantId, sched.UserId)
+
+	if sched.NextRunAt == "" {
+		// The schedule is either new or exhausted.
+		next := occ.Next(now)
+		if next.IsZero() {
+			return nil, nil
+		}
+		sched.NextRunAt = next.UTC().Format(time.RFC3339)
+		_, err = db.C.UpdateSnapshotSchedule(ctx, sched)
+		return nil, err
+	}
+	nextRunAt, err := time.Parse(time.RFC3339, sched.NextRunAt)
+	if err != nil {
+		return nil, err
+	}
+	if now.Before(nextRunAt) {
+		return nil, nil
+	}
+
+	sched.LastRunAt = now.UTC().Format(time.RFC3339)
+	sched.NextRunAt = ""
+	if next := occ.Next(now); !next.IsZero() {
+		sched.NextRunAt = next.UTC().Format(time.RFC3339)
+	}
+	if _, err = db.C.UpdateSnapshotSchedule(ctx, sched); err != nil {
+		return nil, err
+	}
+
+	vol, err := db.C.GetVolume(ctx, sched.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+	if vol.Status != model.VolumeAvailable && vol.Status != model.VolumeInUse {
+		return nil, fmt.Errorf("the occurrence is skipped since the status of volume is %s", vol.Status)
+	}
+	var snap = &model.VolumeSnapshotSpec{
+		BaseModel: &model.BaseModel{
+			Id:        uuid.NewV4().String(),
+			CreatedAt: time.Now().Format(constants.TimeFormat),
+		},
+		Name:      fmt.Sprintf("snapshot-%s-%s", vol.Id, now.UTC().Format("20060102150405")),
+		ProfileId: sched.ProfileId,
+		VolumeId:  vol.Id,
+		Size:      vol.Size,
+		Metadata: utils.MergeStringMaps(map[string]string{
+			model.SnapshotScheduleIdKey: sched.Id,
+		}, vol.Metadata),
+		Status: model.VolumeSnapCreating,
+	}
+	var usage = &model.QuotaUsageSpec{Snapshots: 1}
+	if err = db.C.ReserveQuota(ctx, sched.TenantId, usage); err != nil {
+		return nil, err
+	}
+	if snap, err = db.C.CreateVolumeSnapshot(ctx, snap); err != nil {
+		db.C.ReleaseQuota(ctx, sched.TenantId, usage)
+		return nil, err
+	}
+
+	var errchan = make(chan error, 1)
+	defer close(errchan)
+	Brain.CreateVolumeSnapshot(ctx, snap, errchan)
+	if err = <-errchan; err != nil {
+		return nil, err
+	}
+	snap.Status = model.VolumeSnapAvailable
+	return snap, nil
+}
+
+// pruneSnapshots deletes the snapshots taken by the schedule which are older
+// than the retention duration, and then the oldest ones beyond the retention
+// number.
+func pruneSnapshots(sched *model.SnapshotScheduleSpec, snaps []*model.VolumeSnapshotSpec, now time.Time) {
+	// Only the available snapshots are counted, the ones being created or
+	// deleted and the failed ones are left alone.
+	var retained []*model.VolumeSnapshotSpec
+	for _, snap := range snaps {
+		if snap.Status == model.VolumeSnapAvailable {
+			retained = append(retained, snap)
+		}
+	}
+	sort.Slice(retained, func(i, j int) bool {
+		return retained[i].CreatedAt < retained[j].CreatedAt
+	})
+
+	var expired []*model.VolumeSnapshotSpec
+	if sched.RetentionDuration > 0 {
+		deadline := now.Add(-time.Duration(sched.RetentionDuration) * 24 * time.Hour)
+		for len(retained) > 0 {
+			createdAt, err := time.ParseInLocation(constants.TimeFormat, retained[0].CreatedAt, time.Local)
+			if err != nil || createdAt.After(deadline) {
+				break
+			}
+			expired, retained = append(expired, retained[0]), retained[1:]
+		}
+	}
+	if n := int(sched.RetentionNumber); n > 0 && len(retained) > n {
+		expired = append(expired, retained[:len(retained)-n]...)
+	}
+
+	for _, snap := range expired {
+		ctx := c.NewInternalTenantContext(snap.TenantId, snap.UserId)
+		if err := db.C.UpdateStatus(ctx, snap, model.VolumeSnapDeleting); err != nil {
+			log.Errorf("When prune snapshot %s of schedule %s: %v", snap.Id, sched.Id, err)
+			continue
+		}
+		var errchan = make(chan error, 1)
+		Brain.DeleteVolumeSnapshot(ctx, snap, errchan)
+		if err := <-errchan; err != nil {
+			log.Errorf("When prune snapshot %s of schedule %s: %v", snap.Id, sched.Id, err)
+		}
+		close(errchan)
+	}
+}
+
+// createSnapshotSchedule creates the snapshot schedule of volume if the
+// snapshot properties of its profile specify the occurrence.
+func createSnapshotSchedule(ctx *c.Context, prf *model.ProfileSpec, vol *model.VolumeSpec) error {
+	props := prf.SnapshotProperties
+	if props.Schedule.Occurrence == "" {
+		return nil
+	}
+	_, err := db.C.CreateSnapshotSchedule(ctx, &model.SnapshotScheduleSpec{
+		BaseModel: &model.BaseModel{
+			Id:        uuid.NewV4().String(),
+			CreatedAt: time.Now().Format(constants.TimeFormat),
+		},
+		TenantId:          vol.TenantId,
+		UserId:            vol.UserId,
+		VolumeId:          vol.Id,
+		ProfileId:         prf.Id,
+		Occurrence:        props.Schedule.Occurrence,
+		Datetime:          props.Schedule.Datetime,
+		RetentionNumber:   props.Retention.Number,
+		RetentionDuration: props.Retention.Duration,
+	})
+	return err
+}
+
+// deleteSnapshotSchedules deletes the snapshot schedules of volume, while the
+// snapshots taken by them are left to users.
+func deleteSnapshotSchedules(ctx *c.Context, volId string) error {
+	scheds, err := db.C.ListSnapshotSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sched := range scheds {
+		if sched.VolumeId != volId {
+			continue
+		}
+		if err = db.C.DeleteSnapshotSchedule(ctx, sched.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
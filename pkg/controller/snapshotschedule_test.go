@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSampleSchedule(nextRunAt string) *model.SnapshotScheduleSpec {
+	return &model.SnapshotScheduleSpec{
+		BaseModel:       &model.BaseModel{Id: "6e9ec0f8-52b5-4ebd-9a6c-1b3b1fe5ff06"},
+		TenantId:        "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+		VolumeId:        SampleVolumes[0].Id,
+		Occurrence:      "Daily",
+		Datetime:        "2018-09-15T02:00:00Z",
+		RetentionNumber: 1,
+		NextRunAt:       nextRunAt,
+	}
+}
+
+func TestRunSnapshotSchedules(t *testing.T) {
+	var now = time.Date(2018, 9, 20, 2, 30, 0, 0, time.UTC)
+	var sched = newSampleSchedule("2018-09-20T02:00:00Z")
+	var old = SampleSnapshots[1]
+	old.CreatedAt = "2018-09-19T02:00:00"
+	old.Metadata = map[string]string{model.SnapshotScheduleIdKey: sched.Id}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListSnapshotSchedules", context.NewAdminContext()).Return([]*model.SnapshotScheduleSpec{sched}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return([]*model.VolumeSnapshotSpec{&old}, nil)
+	mockClient.On("UpdateSnapshotSchedule", mock.Anything, sched).Return(sched, nil)
+	mockClient.On("GetVolume", mock.Anything, SampleVolumes[0].Id).Return(&SampleVolumes[0], nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, SampleVolumes[0].PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("ReserveQuota", mock.Anything, sched.TenantId, &model.QuotaUsageSpec{Snapshots: 1}).Return(nil)
+	mockClient.On("CreateVolumeSnapshot", mock.Anything, mock.Anything).Return(
+		func(ctx *context.Context, snap *model.VolumeSnapshotSpec) *model.VolumeSnapshotSpec { return snap }, nil)
+	mockClient.On("UpdateStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockClient.On("DeleteVolumeSnapshot", mock.Anything, old.Id).Return(nil)
+	mockClient.On("ReleaseQuota", mock.Anything, mock.Anything, &model.QuotaUsageSpec{Snapshots: 1}).Return(nil)
+	db.C = mockClient
+	Brain = &Controller{volumeController: NewFakeVolumeController()}
+
+	if err := RunSnapshotSchedules(context.NewAdminContext(), now); err != nil {
+		t.Fatal("Failed to run snapshot schedules:", err)
+	}
+	// The next occurrence is persisted before the snapshot is taken.
+	if sched.NextRunAt != "2018-09-21T02:00:00Z" || sched.LastRunAt != "2018-09-20T02:30:00Z" {
+		t.Errorf("Unexpected snapshot schedule %+v", sched)
+	}
+	var snap *model.VolumeSnapshotSpec
+	for _, call := range mockClient.Calls {
+		if call.Method == "CreateVolumeSnapshot" {
+			snap = call.Arguments.Get(1).(*model.VolumeSnapshotSpec)
+		}
+	}
+	if snap == nil || snap.Metadata[model.SnapshotScheduleIdKey] != sched.Id || snap.VolumeId != SampleVolumes[0].Id {
+		t.Errorf("Unexpected snapshot %+v", snap)
+	}
+	// Only the latest snapshot is retained.
+	mockClient.AssertCalled(t, "UpdateStatus", mock.Anything, &old, model.VolumeSnapDeleting)
+	mockClient.AssertCalled(t, "DeleteVolumeSnapshot", mock.Anything, old.Id)
+	mockClient.AssertNumberOfCalls(t, "DeleteVolumeSnapshot", 1)
+}
+
+func TestRunSnapshotSchedulesNotDue(t *testing.T) {
+	var now = time.Date(2018, 9, 20, 1, 30, 0, 0, time.UTC)
+	var pending = newSampleSchedule("2018-09-20T02:00:00Z")
+	var created = newSampleSchedule("")
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListSnapshotSchedules", context.NewAdminContext()).Return([]*model.SnapshotScheduleSpec{pending, created}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return([]*model.VolumeSnapshotSpec{}, nil)
+	mockClient.On("UpdateSnapshotSchedule", mock.Anything, created).Return(created, nil)
+	db.C = mockClient
+
+	if err := RunSnapshotSchedules(context.NewAdminContext(), now); err != nil {
+		t.Fatal("Failed to run snapshot schedules:", err)
+	}
+	// The first occurrence of new schedule is computed without taking
+	// snapshot.
+	if created.NextRunAt != "2018-09-20T02:00:00Z" || created.LastRunAt != "" {
+		t.Errorf("Unexpected snapshot schedule %+v", created)
+	}
+	mockClient.AssertNumberOfCalls(t, "UpdateSnapshotSchedule", 1)
+	mockClient.AssertNotCalled(t, "CreateVolumeSnapshot", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the reconciler of stuck resources, which resolves the
+real status of the resources staying in transient statuses for too long
+against the backend.
+
+*/
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/satori/go.uuid"
+)
+
+// stuckReconcilerLock is the name of the lock held by the osdslet which
+// reconciles the stuck resources.
+const stuckReconcilerLock = "stuck-reconciler"
+
+// stuckReplicationStatus maps the transient statuses of replications to the
+// error statuses they end up in when they get stuck, replications can't be
+// checked against the backend.
+var stuckReplicationStatus = map[string]string{
+	model.ReplicationCreating:    model.ReplicationError,
+	model.ReplicationDeleting:    model.ReplicationErrorDeleting,
+	model.ReplicationEnabling:    model.ReplicationErrorEnabling,
+	model.ReplicationDisabling:   model.ReplicationErrorDisabling,
+	model.ReplicationFailingOver: model.ReplicationErrorFailover,
+	model.ReplicationFailingBack: model.ReplicationErrorFailback,
+}
+
+// ReconcileStuckResources checks the stuck resources every interval until
+// stop is closed, a resource is stuck if it has stayed in a transient status
+// for longer than timeout. Only the osdslet holding the reconciler lock runs
+// the check, which adopts the jobs orphaned for longer than timeout first.
+func ReconcileStuckResources(timeout, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewV4().String())
+	var unreconciled []*model.JobSpec
+	for {
+		ctx := c.NewAdminContext()
+		if len(unreconciled) > 0 {
+			unreconciled = reconcileJobs(ctx, unreconciled)
+		}
+		leader, err := db.C.AcquireLock(ctx, stuckReconcilerLock, holder, 3*interval)
+		if err != nil {
+			log.Error("When acquire the lock of stuck reconciler:", err)
+		} else if leader {
+			adopted, err := AdoptOrphanedJobs(ctx, time.Now(), timeout)
+			if err != nil {
+				log.Error("When adopt orphaned jobs:", err)
+			}
+			unreconciled = append(unreconciled, adopted...)
+			if err := ReconcileStuck(ctx, time.Now(), timeout); err != nil {
+				log.Error("When reconcile stuck resources:", err)
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileStuck resolves the volumes, snapshots, attachments and
+// replications which have stayed in transient statuses for longer than
+// timeout by now. The resources with pending or running jobs are left to the
+// jobs, unless the jobs are orphaned and have not been adopted, which are
+// marked as failed.
+func ReconcileStuck(ctx *c.Context, now time.Time, timeout time.Duration) error {
+	busy, orphaned, err := busyResources(ctx, now, timeout)
+	if err != nil {
+		return err
+	}
+	for _, job := range orphaned {
+		log.Warningf("Job %s of %s %s is orphaned by host %s", job.Id, job.Type, job.ResourceId, job.Host)
+		finishJob(job, fmt.Errorf("orphaned by the controller on host %s", job.Host))
+	}
+	stuck := func(m *model.BaseModel) bool {
+		return !busy[m.Id] && isStale(m, now, timeout)
+	}
+
+	vols, err := db.C.ListVolumes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, vol := range vols {
+		if !utils.Contained(vol.Status, []string{model.VolumeCreating, model.VolumeDeleting,
+			model.VolumeExtending}) || !stuck(vol.BaseModel) {
+			continue
+		}
+		if err := reconcileStuckVolume(ctx, vol); err != nil {
+			log.Errorf("Reconcile volume %s stuck in %s failed: %v", vol.Id, vol.Status, err)
+		}
+	}
+
+	snaps, err := db.C.ListVolumeSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	for _, snap := range snaps {
+		if !utils.Contained(snap.Status, []string{model.VolumeSnapCreating, model.VolumeSnapDeleting}) ||
+			!stuck(snap.BaseModel) {
+			continue
+		}
+		if err := reconcileStuckSnapshot(ctx, snap); err != nil {
+			log.Errorf("Reconcile snapshot %s stuck in %s failed: %v", snap.Id, snap.Status, err)
+		}
+	}
+
+	atcs, err := db.C.ListVolumeAttachments(ctx, "")
+	if err != nil {
+		return err
+	}
+	for _, atc := range atcs {
+		if atc.Status != model.VolumeAttachCreating || !stuck(atc.BaseModel) {
+			continue
+		}
+		// The attachment can't be checked against the backend.
+		log.Warningf("Volume attachment %s is stuck in %s", atc.Id, atc.Status)
+		if err := db.C.UpdateStatus(ctx, atc, model.VolumeAttachError); err != nil {
+			log.Errorf("Reconcile volume attachment %s failed: %v", atc.Id, err)
+		}
+	}
+
+	reps, err := db.C.ListReplication(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rep := range reps {
+		status, ok := stuckReplicationStatus[rep.ReplicationStatus]
+		if !ok || !stuck(rep.BaseModel) {
+			continue
+		}
+		log.Warningf("Replication %s is stuck in %s", rep.Id, rep.ReplicationStatus)
+		rep.ReplicationStatus = status
+		if _, err := db.C.UpdateReplication(ctx, rep.Id, rep); err != nil {
+			log.Errorf("Reconcile replication %s failed: %v", rep.Id, err)
+		}
+	}
+	return nil
+}
+
+// busyResources returns the ids of the resources which have pending or
+// running jobs, and the jobs orphaned by the osdslets which have stopped. A
+// job is orphaned if the heartbeat of its host has lapsed and it has not been
+// updated for longer than timeout by now, its resource is not busy.
+func busyResources(ctx *c.Context, now time.Time, timeout time.Duration) (map[string]bool, []*model.JobSpec, error) {
+	alive, err := liveJobHosts(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var busy = make(map[string]bool)
+	var orphaned []*model.JobSpec
+	for _, status := range []string{model.JobPending, model.JobRunning} {
+		jobs, err := db.C.ListJobsWithFilter(ctx, map[string][]string{"Status": {status}})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, job := range jobs {
+			if isOrphaned(job, alive, now, timeout) {
+				orphaned = append(orphaned, job)
+				continue
+			}
+			busy[job.ResourceId] = true
+		}
+	}
+	return busy, orphaned, nil
+}
+
+// isStale reports whether the resource has not been updated for longer than
+// timeout by now.
+func isStale(m *model.BaseModel, now time.Time, timeout time.Duration) bool {
+	at := m.UpdatedAt
+	if at == "" {
+		at = m.CreatedAt
+	}
+	t, err := time.ParseInLocation(constants.TimeFormat, at, time.Local)
+	if err != nil {
+		return false
+	}
+	return now.Sub(t) > timeout
+}
+
+func reconcileStuckVolume(ctx *c.Context, vol *model.VolumeSpec) error {
+	log.Warningf("Volume %s is stuck in %s", vol.Id, vol.Status)
+	// The volume which is not placed on any pool never reaches the backend.
+	if vol.PoolId == "" {
+		if vol.Status == model.VolumeDeleting {
+			return purgeVolume(ctx, vol)
+		}
+		return db.C.UpdateStatus(ctx, vol, model.VolumeError)
+	}
+
+	pulled, err := pullVolume(ctx, vol)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	switch vol.Status {
+	case model.VolumeCreating:
+		if !found {
+			return db.C.UpdateStatus(ctx, vol, model.VolumeError)
+		}
+		vol.Metadata = utils.MergeStringMaps(vol.Metadata, pulled.Metadata)
+		return db.C.UpdateStatus(ctx, vol, model.VolumeAvailable)
+	case model.VolumeDeleting:
+		if !found {
+			return purgeVolume(ctx, vol)
+		}
+		return db.C.UpdateStatus(ctx, vol, model.VolumeErrorDeleting)
+	case model.VolumeExtending:
+		if !found || pulled.Size <= vol.Size {
+			releaseExtendingQuota(ctx, vol)
+			return db.C.UpdateStatus(ctx, vol, model.VolumeErrorExtending)
+		}
+		vol.Size = pulled.Size
+		return db.C.UpdateStatus(ctx, vol, model.VolumeAvailable)
+	}
+	return nil
+}
+
+// releaseExtendingQuota releases the gigabytes reserved when the extending of
+// volume is accepted, the new size of which is only kept by its latest job.
+func releaseExtendingQuota(ctx *c.Context, vol *model.VolumeSpec) {
+	jobs, err := db.C.ListJobsWithFilter(ctx, map[string][]string{
+		"ResourceId": {vol.Id},
+		"Type":       {model.JobExtendVolume},
+	})
+	if err != nil {
+		log.Warningf("List extending jobs of volume %s failed: %v", vol.Id, err)
+		return
+	}
+	var latest *model.JobSpec
+	for _, job := range jobs {
+		if latest == nil || job.CreatedAt > latest.CreatedAt {
+			latest = job
+		}
+	}
+	if latest == nil {
+		log.Warningf("No extending job of volume %s is found, its quota is not released", vol.Id)
+		return
+	}
+	size, err := jobSize(latest)
+	if err != nil {
+		log.Warning(err)
+		return
+	}
+	if size > vol.Size {
+		if err := db.C.ReleaseQuota(ctx, vol.TenantId, &model.QuotaUsageSpec{Gigabytes: size - vol.Size}); err != nil {
+			log.Warning("Release quota of volume failed:", err)
+		}
+	}
+}
+
+func reconcileStuckSnapshot(ctx *c.Context, snap *model.VolumeSnapshotSpec) error {
+	log.Warningf("Volume snapshot %s is stuck in %s", snap.Id, snap.Status)
+	pulled, err := pullSnapshot(ctx, snap)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	switch snap.Status {
+	case model.VolumeSnapCreating:
+		if !found {
+			return db.C.UpdateStatus(ctx, snap, model.VolumeSnapError)
+		}
+		snap.Metadata = utils.MergeStringMaps(snap.Metadata, pulled.Metadata)
+		return db.C.UpdateStatus(ctx, snap, model.VolumeSnapAvailable)
+	case model.VolumeSnapDeleting:
+		if !found {
+			return purgeSnapshot(ctx, snap)
+		}
+		return db.C.UpdateStatus(ctx, snap, model.VolumeSnapErrorDeleting)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	. "github.com/opensds/opensds/testutils/collection"
+	dbtest "github.com/opensds/opensds/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconcileStuck(t *testing.T) {
+	var now = time.Now()
+	var stale = now.Add(-time.Hour).Format(constants.TimeFormat)
+	var fresh = now.Add(-time.Minute).Format(constants.TimeFormat)
+
+	var creating = newSampleVolume(model.VolumeCreating)
+	creating.UpdatedAt = stale
+	var extending = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "9193c3ec-771f-11e7-8ca3-d32c0a8b2725", UpdatedAt: stale},
+		Size:      1,
+		Status:    model.VolumeExtending,
+		PoolId:    creating.PoolId,
+	}
+	var deleting = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "3769855c-a102-11e7-b772-17b880d2f537", UpdatedAt: fresh},
+		Status:    model.VolumeDeleting,
+		PoolId:    creating.PoolId,
+	}
+	var atc = &model.VolumeAttachmentSpec{
+		BaseModel: &model.BaseModel{Id: "f2dda3d2-bf79-11e7-8665-f750b088f63e", CreatedAt: stale},
+		Status:    model.VolumeAttachCreating,
+	}
+	var rep = &model.ReplicationSpec{
+		BaseModel:         &model.BaseModel{Id: "c299a978-4f3e-11e8-8a5c-977218a83359", UpdatedAt: stale},
+		ReplicationStatus: model.ReplicationEnabling,
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", context.NewAdminContext()).Return([]string{jobHeartbeatId(jobHost)}, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobPending},
+	}).Return([]*model.JobSpec{{BaseModel: &model.BaseModel{UpdatedAt: stale}, ResourceId: extending.Id, Host: jobHost}}, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobRunning},
+	}).Return(nil, nil)
+	mockClient.On("ListVolumes", context.NewAdminContext()).Return(
+		[]*model.VolumeSpec{creating, extending, deleting}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", context.NewAdminContext(), "").Return(
+		[]*model.VolumeAttachmentSpec{atc}, nil)
+	mockClient.On("ListReplication", context.NewAdminContext()).Return(
+		[]*model.ReplicationSpec{rep}, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, creating.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", mock.Anything, creating, model.VolumeAvailable).Return(nil)
+	mockClient.On("UpdateStatus", mock.Anything, atc, model.VolumeAttachError).Return(nil)
+	mockClient.On("UpdateReplication", mock.Anything, rep.Id, rep).Return(rep, nil)
+	db.C = mockClient
+	Brain = &Controller{
+		volumeController: &pullVolumeController{Controller: NewFakeVolumeController(), vol: &SampleVolumes[0]},
+	}
+
+	if err := ReconcileStuck(context.NewAdminContext(), now, 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// The volume has been created on the backend.
+	mockClient.AssertCalled(t, "UpdateStatus", mock.Anything, creating, model.VolumeAvailable)
+	// The extending volume is left to its job and the deleting one is not
+	// stuck yet.
+	mockClient.AssertNumberOfCalls(t, "UpdateStatus", 2)
+	mockClient.AssertNumberOfCalls(t, "GetDockByPoolId", 1)
+	mockClient.AssertCalled(t, "UpdateStatus", mock.Anything, atc, model.VolumeAttachError)
+	if rep.ReplicationStatus != model.ReplicationErrorEnabling {
+		t.Errorf("Expected replication to be %s, actual %s", model.ReplicationErrorEnabling, rep.ReplicationStatus)
+	}
+}
+
+func TestReconcileStuckDeletingVolume(t *testing.T) {
+	var deleting = newSampleVolume(model.VolumeDeleting)
+	deleting.UpdatedAt = time.Now().Add(-time.Hour).Format(constants.TimeFormat)
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), mock.Anything).Return(nil, nil)
+	mockClient.On("ListVolumes", context.NewAdminContext()).Return([]*model.VolumeSpec{deleting}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", context.NewAdminContext(), "").Return(nil, nil)
+	mockClient.On("ListReplication", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, deleting.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("DeleteVolume", mock.Anything, deleting.Id).Return(nil)
+	mockClient.On("ListSnapshotSchedules", mock.Anything).Return(nil, nil)
+	mockClient.On("ReleaseQuota", mock.Anything, deleting.TenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: deleting.Size}).Return(nil)
+	db.C = mockClient
+	Brain = &Controller{
+		volumeController: &pullVolumeController{
+			Controller: NewFakeVolumeController(),
+			err:        model.NewNotFoundError("volume is not found"),
+		},
+	}
+
+	if err := ReconcileStuck(context.NewAdminContext(), time.Now(), 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// The volume has been deleted from the backend.
+	mockClient.AssertCalled(t, "DeleteVolume", mock.Anything, deleting.Id)
+}
+
+func TestReconcileStuckUnverifiedVolume(t *testing.T) {
+	var creating = newSampleVolume(model.VolumeCreating)
+	creating.UpdatedAt = time.Now().Add(-time.Hour).Format(constants.TimeFormat)
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), mock.Anything).Return(nil, nil)
+	mockClient.On("ListVolumes", context.NewAdminContext()).Return([]*model.VolumeSpec{creating}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", context.NewAdminContext(), "").Return(nil, nil)
+	mockClient.On("ListReplication", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, creating.PoolId).Return(&SampleDocks[0], nil)
+	db.C = mockClient
+	// The driver which doesn't support pulling returns an empty volume.
+	Brain = &Controller{
+		volumeController: &pullVolumeController{Controller: NewFakeVolumeController(), vol: &model.VolumeSpec{}},
+	}
+
+	if err := ReconcileStuck(context.NewAdminContext(), time.Now(), 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	mockClient.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReconcileStuckExtendingVolume(t *testing.T) {
+	var extending = newSampleVolume(model.VolumeExtending)
+	extending.TenantId = "ef305038-cd12-4f3b-90bd-0612f83e14ee"
+	extending.UpdatedAt = time.Now().Add(-time.Hour).Format(constants.TimeFormat)
+	var jobs = []*model.JobSpec{
+		newSampleJob(model.JobExtendVolume, model.JobFailed, 3, 3),
+		newSampleJob(model.JobExtendVolume, model.JobSucceeded, 1, 3),
+	}
+	jobs[0].CreatedAt, jobs[0].Parameters = "2018-08-10T14:36:58", map[string]string{JobNewSizeKey: "3"}
+	jobs[1].CreatedAt, jobs[1].Parameters = "2018-08-01T14:36:58", map[string]string{JobNewSizeKey: "2"}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"ResourceId": {extending.Id},
+		"Type":       {model.JobExtendVolume},
+	}).Return(jobs, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), mock.Anything).Return(nil, nil)
+	mockClient.On("ListVolumes", context.NewAdminContext()).Return([]*model.VolumeSpec{extending}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", context.NewAdminContext(), "").Return(nil, nil)
+	mockClient.On("ListReplication", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, extending.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("ReleaseQuota", mock.Anything, extending.TenantId, &model.QuotaUsageSpec{Gigabytes: 2}).Return(nil)
+	mockClient.On("UpdateStatus", mock.Anything, extending, model.VolumeErrorExtending).Return(nil)
+	db.C = mockClient
+	// The volume on the backend has not been extended.
+	Brain = &Controller{
+		volumeController: &pullVolumeController{Controller: NewFakeVolumeController(), vol: newSampleVolume(model.VolumeAvailable)},
+	}
+
+	if err := ReconcileStuck(context.NewAdminContext(), time.Now(), 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// The gigabytes reserved by the latest extending are released.
+	mockClient.AssertCalled(t, "ReleaseQuota", mock.Anything, extending.TenantId, &model.QuotaUsageSpec{Gigabytes: 2})
+	mockClient.AssertCalled(t, "UpdateStatus", mock.Anything, extending, model.VolumeErrorExtending)
+}
+
+func TestReconcileStuckOrphanedJob(t *testing.T) {
+	var now = time.Now()
+	var stale = now.Add(-time.Hour).Format(constants.TimeFormat)
+
+	var creating = newSampleVolume(model.VolumeCreating)
+	creating.UpdatedAt = stale
+	// The job is running on a host which has stopped.
+	var job = newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3)
+	job.ResourceId, job.Host, job.UpdatedAt = creating.Id, "dead-host", stale
+	// The job on the live host is left to its host, though it's stale.
+	var live = newSampleJob(model.JobCreateVolume, model.JobRunning, 1, 3)
+	live.Id, live.ResourceId, live.UpdatedAt = "5e4b2b1c-0f6a-4d86-a7a3-6a9d0b3a1c42", "3769855c-a102-11e7-b772-17b880d2f537", stale
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("ListHeartbeats", context.NewAdminContext()).Return([]string{jobHeartbeatId(jobHost)}, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobPending},
+	}).Return(nil, nil)
+	mockClient.On("ListJobsWithFilter", context.NewAdminContext(), map[string][]string{
+		"Status": {model.JobRunning},
+	}).Return([]*model.JobSpec{job, live}, nil)
+	updated := recordJobs(mockClient)
+	mockClient.On("ListVolumes", context.NewAdminContext()).Return([]*model.VolumeSpec{creating}, nil)
+	mockClient.On("ListVolumeSnapshots", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("ListVolumeAttachments", context.NewAdminContext(), "").Return(nil, nil)
+	mockClient.On("ListReplication", context.NewAdminContext()).Return(nil, nil)
+	mockClient.On("GetDockByPoolId", mock.Anything, creating.PoolId).Return(&SampleDocks[0], nil)
+	mockClient.On("UpdateStatus", mock.Anything, creating, model.VolumeAvailable).Return(nil)
+	db.C = mockClient
+	Brain = &Controller{
+		volumeController: &pullVolumeController{Controller: NewFakeVolumeController(), vol: &SampleVolumes[0]},
+	}
+
+	if err := ReconcileStuck(context.NewAdminContext(), now, 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if len(*updated) != 1 || (*updated)[0].Id != job.Id || (*updated)[0].Status != model.JobFailed {
+		t.Errorf("Expected the orphaned job to fail, actual %+v", *updated)
+	}
+	// The volume of the orphaned job is reconciled against the backend.
+	mockClient.AssertCalled(t, "UpdateStatus", mock.Anything, creating, model.VolumeAvailable)
+}
+
+func TestIsStale(t *testing.T) {
+	var now = time.Now()
+	var testCases = []struct {
+		model    *model.BaseModel
+		expected bool
+	}{
+		{&model.BaseModel{UpdatedAt: now.Add(-time.Hour).Format(constants.TimeFormat)}, true},
+		{&model.BaseModel{UpdatedAt: now.Add(-time.Minute).Format(constants.TimeFormat)}, false},
+		{&model.BaseModel{CreatedAt: now.Add(-time.Hour).Format(constants.TimeFormat)}, true},
+		{&model.BaseModel{}, false},
+	}
+	for _, tc := range testCases {
+		if actual := isStale(tc.model, now, 30*time.Minute); actual != tc.expected {
+			t.Errorf("Expected %v for %+v, actual %v", tc.expected, tc.model, actual)
+		}
+	}
+}
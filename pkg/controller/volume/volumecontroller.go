@@ -34,67 +34,82 @@ import (
 // Controller is an interface for exposing some operations of different volume
 // controllers.
 type Controller interface {
-	CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error)
+	CreateVolume(dock *model.DockSpec, opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error)
 
-	DeleteVolume(opt *pb.DeleteVolumeOpts) error
+	DeleteVolume(dock *model.DockSpec, opt *pb.DeleteVolumeOpts) error
 
-	ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error)
+	ExtendVolume(dock *model.DockSpec, opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error)
 
-	CreateVolumeAttachment(opt *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error)
+	RevertToSnapshot(dock *model.DockSpec, opt *pb.RevertToSnapshotOpts) error
 
-	DeleteVolumeAttachment(opt *pb.DeleteAttachmentOpts) error
+	MigrateVolume(dock *model.DockSpec, opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error)
 
-	CreateVolumeSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error)
+	RetypeVolume(dock *model.DockSpec, opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error)
 
-	DeleteVolumeSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error
+	CreateVolumeAttachment(dock *model.DockSpec, opt *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error)
 
-	CreateReplication(opt *pb.CreateReplicationOpts) (*model.ReplicationSpec, error)
+	DeleteVolumeAttachment(dock *model.DockSpec, opt *pb.DeleteAttachmentOpts) error
 
-	DeleteReplication(opt *pb.DeleteReplicationOpts) error
+	CreateVolumeSnapshot(dock *model.DockSpec, opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error)
 
-	EnableReplication(opt *pb.EnableReplicationOpts) error
+	DeleteVolumeSnapshot(dock *model.DockSpec, opt *pb.DeleteVolumeSnapshotOpts) error
 
-	DisableReplication(opt *pb.DisableReplicationOpts) error
+	PullVolume(dock *model.DockSpec, opt *pb.PullVolumeOpts) (*model.VolumeSpec, error)
 
-	FailoverReplication(opt *pb.FailoverReplicationOpts) error
+	PullVolumeSnapshot(dock *model.DockSpec, opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error)
 
-	AttachVolume(opt *pb.AttachVolumeOpts) (string, error)
+	CreateReplication(dock *model.DockSpec, opt *pb.CreateReplicationOpts) (*model.ReplicationSpec, error)
 
-	DetachVolume(opt *pb.DetachVolumeOpts) error
+	DeleteReplication(dock *model.DockSpec, opt *pb.DeleteReplicationOpts) error
 
-	CreateVolumeGroup(*pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error)
+	EnableReplication(dock *model.DockSpec, opt *pb.EnableReplicationOpts) error
 
-	UpdateVolumeGroup(*pb.UpdateVolumeGroupOpts) error
+	DisableReplication(dock *model.DockSpec, opt *pb.DisableReplicationOpts) error
 
-	DeleteVolumeGroup(*pb.DeleteVolumeGroupOpts) error
+	FailoverReplication(dock *model.DockSpec, opt *pb.FailoverReplicationOpts) error
 
-	SetDock(dockInfo *model.DockSpec)
+	AttachVolume(dock *model.DockSpec, opt *pb.AttachVolumeOpts) (string, error)
+
+	DetachVolume(dock *model.DockSpec, opt *pb.DetachVolumeOpts) error
+
+	CopyVolume(dock *model.DockSpec, opt *pb.CopyVolumeOpts) error
+
+	CreateVolumeGroup(dock *model.DockSpec, opt *pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error)
+
+	UpdateVolumeGroup(dock *model.DockSpec, opt *pb.UpdateVolumeGroupOpts) error
+
+	DeleteVolumeGroup(dock *model.DockSpec, opt *pb.DeleteVolumeGroupOpts) error
+
+	CreateGroupSnapshot(dock *model.DockSpec, opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error)
+
+	DeleteGroupSnapshot(dock *model.DockSpec, opt *pb.DeleteGroupSnapshotOpts) error
 }
 
 // NewController method creates a controller structure and expose its pointer.
-func NewController() Controller {
+// The requests are sent to the docks with the clients from pool, which is
+// safe to be shared by concurrent requests.
+func NewController(pool *client.Pool) Controller {
 	return &controller{
-		Client: client.NewClient(),
+		pool: pool,
 	}
 }
 
 type controller struct {
-	client.Client
-	DockInfo *model.DockSpec
+	pool *client.Pool
 }
 
-func (c *controller) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) CreateVolume(dock *model.DockSpec, opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return nil, err
 	}
 
-	response, err := c.Client.CreateVolume(context.Background(), opt)
+	response, err := cli.CreateVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("create volume failed in volume controller:", err)
 		return nil, err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return nil,
@@ -112,18 +127,18 @@ func (c *controller) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec,
 
 }
 
-func (c *controller) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DeleteVolume(dock *model.DockSpec, opt *pb.DeleteVolumeOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.DeleteVolume(context.Background(), opt)
+	response, err := cli.DeleteVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("Delete volume failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -132,18 +147,18 @@ func (c *controller) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
 	return nil
 }
 
-func (c *controller) ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) ExtendVolume(dock *model.DockSpec, opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return nil, err
 	}
 
-	response, err := c.Client.ExtendVolume(context.Background(), opt)
+	response, err := cli.ExtendVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("extend volume failed in volume controller:", err)
 		return nil, err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return nil,
@@ -160,18 +175,97 @@ func (c *controller) ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec,
 	return vol, nil
 }
 
-func (c *controller) CreateVolumeAttachment(opt *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) RevertToSnapshot(dock *model.DockSpec, opt *pb.RevertToSnapshotOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
+		return err
+	}
+
+	response, err := cli.RevertToSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Error("Revert volume to snapshot failed in volume controller:", err)
+		return err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return errors.New(errorMsg.GetDescription())
+	}
+
+	return nil
+}
+
+func (c *controller) MigrateVolume(dock *model.DockSpec, opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.MigrateVolume(context.Background(), opt)
+	if err != nil {
+		log.Error("migrate volume failed in volume controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		if errorMsg.GetCode() == "501" {
+			return nil, &model.NotImplementError{S: errorMsg.GetDescription()}
+		}
+		return nil,
+			fmt.Errorf("failed to migrate volume in volume controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var vol = &model.VolumeSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), vol); err != nil {
+		log.Error("migrate volume failed in volume controller:", err)
 		return nil, err
 	}
 
-	response, err := c.Client.CreateAttachment(context.Background(), opt)
+	return vol, nil
+}
+
+func (c *controller) RetypeVolume(dock *model.DockSpec, opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.RetypeVolume(context.Background(), opt)
+	if err != nil {
+		log.Error("retype volume failed in volume controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return nil,
+			fmt.Errorf("failed to retype volume in volume controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var vol = &model.VolumeSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), vol); err != nil {
+		log.Error("retype volume failed in volume controller:", err)
+		return nil, err
+	}
+
+	return vol, nil
+}
+
+func (c *controller) CreateVolumeAttachment(dock *model.DockSpec, opt *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.CreateAttachment(context.Background(), opt)
 	if err != nil {
 		log.Error("Create volume attachment failed in volume controller:", err)
 		return nil, err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return nil,
@@ -188,18 +282,18 @@ func (c *controller) CreateVolumeAttachment(opt *pb.CreateAttachmentOpts) (*mode
 	return atc, nil
 }
 
-func (c *controller) DeleteVolumeAttachment(opt *pb.DeleteAttachmentOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DeleteVolumeAttachment(dock *model.DockSpec, opt *pb.DeleteAttachmentOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.DeleteAttachment(context.Background(), opt)
+	response, err := cli.DeleteAttachment(context.Background(), opt)
 	if err != nil {
 		log.Error("Delete volume attachment failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -208,18 +302,18 @@ func (c *controller) DeleteVolumeAttachment(opt *pb.DeleteAttachmentOpts) error
 	return nil
 }
 
-func (c *controller) CreateVolumeSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) CreateVolumeSnapshot(dock *model.DockSpec, opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return nil, err
 	}
 
-	response, err := c.Client.CreateVolumeSnapshot(context.Background(), opt)
+	response, err := cli.CreateVolumeSnapshot(context.Background(), opt)
 	if err != nil {
 		log.Error("Create volume snapshot failed in volume controller:", err)
 		return nil, err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return nil,
@@ -236,18 +330,18 @@ func (c *controller) CreateVolumeSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*mo
 	return snp, nil
 }
 
-func (c *controller) DeleteVolumeSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DeleteVolumeSnapshot(dock *model.DockSpec, opt *pb.DeleteVolumeSnapshotOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.DeleteVolumeSnapshot(context.Background(), opt)
+	response, err := cli.DeleteVolumeSnapshot(context.Background(), opt)
 	if err != nil {
 		log.Error("Delete volume snapshot failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -256,18 +350,84 @@ func (c *controller) DeleteVolumeSnapshot(opt *pb.DeleteVolumeSnapshotOpts) erro
 	return nil
 }
 
-func (c *controller) CreateReplication(opt *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+// PullVolume returns the volume in the backend, NotFoundError is returned if
+// it's missing there.
+func (c *controller) PullVolume(dock *model.DockSpec, opt *pb.PullVolumeOpts) (*model.VolumeSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return nil, err
 	}
 
-	response, err := c.Client.CreateReplication(context.Background(), opt)
+	response, err := cli.PullVolume(context.Background(), opt)
+	if err != nil {
+		log.Error("Pull volume failed in volume controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		if errorMsg.GetCode() == "404" {
+			return nil, model.NewNotFoundError(errorMsg.GetDescription())
+		}
+		return nil,
+			fmt.Errorf("failed to pull volume in volume controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var vol = &model.VolumeSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), vol); err != nil {
+		log.Error("Pull volume failed in volume controller:", err)
+		return nil, err
+	}
+
+	return vol, nil
+}
+
+// PullVolumeSnapshot returns the volume snapshot in the backend like
+// PullVolume.
+func (c *controller) PullVolumeSnapshot(dock *model.DockSpec, opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.PullVolumeSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Error("Pull volume snapshot failed in volume controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		if errorMsg.GetCode() == "404" {
+			return nil, model.NewNotFoundError(errorMsg.GetDescription())
+		}
+		return nil,
+			fmt.Errorf("failed to pull volume snapshot in volume controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var snp = &model.VolumeSnapshotSpec{}
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), snp); err != nil {
+		log.Error("Pull volume snapshot failed in volume controller:", err)
+		return nil, err
+	}
+
+	return snp, nil
+}
+
+func (c *controller) CreateReplication(dock *model.DockSpec, opt *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.CreateReplication(context.Background(), opt)
 	if err != nil {
 		log.Error("Create replication failed in volume controller:", err)
 		return nil, err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return nil,
@@ -284,18 +444,18 @@ func (c *controller) CreateReplication(opt *pb.CreateReplicationOpts) (*model.Re
 	return snp, nil
 }
 
-func (c *controller) DeleteReplication(opt *pb.DeleteReplicationOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DeleteReplication(dock *model.DockSpec, opt *pb.DeleteReplicationOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.DeleteReplication(context.Background(), opt)
+	response, err := cli.DeleteReplication(context.Background(), opt)
 	if err != nil {
 		log.Error("Delete replication failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -304,18 +464,18 @@ func (c *controller) DeleteReplication(opt *pb.DeleteReplicationOpts) error {
 	return nil
 }
 
-func (c *controller) EnableReplication(opt *pb.EnableReplicationOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) EnableReplication(dock *model.DockSpec, opt *pb.EnableReplicationOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.EnableReplication(context.Background(), opt)
+	response, err := cli.EnableReplication(context.Background(), opt)
 	if err != nil {
 		log.Error("Enable replication failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -324,18 +484,18 @@ func (c *controller) EnableReplication(opt *pb.EnableReplicationOpts) error {
 	return nil
 }
 
-func (c *controller) DisableReplication(opt *pb.DisableReplicationOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DisableReplication(dock *model.DockSpec, opt *pb.DisableReplicationOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.DisableReplication(context.Background(), opt)
+	response, err := cli.DisableReplication(context.Background(), opt)
 	if err != nil {
 		log.Error("Disable replication failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -344,18 +504,18 @@ func (c *controller) DisableReplication(opt *pb.DisableReplicationOpts) error {
 	return nil
 }
 
-func (c *controller) FailoverReplication(opt *pb.FailoverReplicationOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) FailoverReplication(dock *model.DockSpec, opt *pb.FailoverReplicationOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.FailoverReplication(context.Background(), opt)
+	response, err := cli.FailoverReplication(context.Background(), opt)
 	if err != nil {
 		log.Error("Failover replication failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -364,20 +524,18 @@ func (c *controller) FailoverReplication(opt *pb.FailoverReplicationOpts) error
 	return nil
 }
 
-func (c *controller) AttachVolume(opt *pb.AttachVolumeOpts) (string, error) {
-	fmt.Println(c.Client)
-	fmt.Println(c.DockInfo)
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) AttachVolume(dock *model.DockSpec, opt *pb.AttachVolumeOpts) (string, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return "", err
 	}
 
-	response, err := c.Client.AttachVolume(context.Background(), opt)
+	response, err := cli.AttachVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("Attach volume failed in volume controller:", err)
 		return "", err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return "",
@@ -388,17 +546,17 @@ func (c *controller) AttachVolume(opt *pb.AttachVolumeOpts) (string, error) {
 	return response.GetResult().GetMessage(), nil
 }
 
-func (c *controller) DetachVolume(opt *pb.DetachVolumeOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DetachVolume(dock *model.DockSpec, opt *pb.DetachVolumeOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
-	response, err := c.Client.DetachVolume(context.Background(), opt)
+	response, err := cli.DetachVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("Detach volume failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
@@ -407,18 +565,37 @@ func (c *controller) DetachVolume(opt *pb.DetachVolumeOpts) error {
 	return nil
 }
 
-func (c *controller) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) CopyVolume(dock *model.DockSpec, opt *pb.CopyVolumeOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return err
+	}
+	response, err := cli.CopyVolume(context.Background(), opt)
+	if err != nil {
+		log.Error("Copy volume failed in volume controller:", err)
+		return err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return errors.New(errorMsg.GetDescription())
+	}
+
+	return nil
+}
+
+func (c *controller) CreateVolumeGroup(dock *model.DockSpec, opt *pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return nil, err
 	}
 
-	response, err := c.Client.CreateVolumeGroup(context.Background(), opt)
+	response, err := cli.CreateVolumeGroup(context.Background(), opt)
 	if err != nil {
 		log.Error("Create volume group failed in volume controller:", err)
 		return nil, err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return nil,
@@ -435,18 +612,18 @@ func (c *controller) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts) (*model.Vo
 	return vg, nil
 }
 
-func (c *controller) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) UpdateVolumeGroup(dock *model.DockSpec, opt *pb.UpdateVolumeGroupOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.UpdateVolumeGroup(context.Background(), opt)
+	response, err := cli.UpdateVolumeGroup(context.Background(), opt)
 	if err != nil {
 		log.Error("Update volume group failed in volume controller:", err)
 		return err
 	}
-	defer c.Client.Close()
 
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return fmt.Errorf("Failed to update volume group in volume controller, code: %v, message: %v",
@@ -455,20 +632,19 @@ func (c *controller) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts) error {
 	return nil
 }
 
-func (c *controller) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts) error {
-	if err := c.Client.Connect(c.DockInfo.Endpoint); err != nil {
+func (c *controller) DeleteVolumeGroup(dock *model.DockSpec, opt *pb.DeleteVolumeGroupOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
 		log.Error("When connecting dock client:", err)
 		return err
 	}
 
-	response, err := c.Client.DeleteVolumeGroup(context.Background(), opt)
+	response, err := cli.DeleteVolumeGroup(context.Background(), opt)
 	if err != nil {
 		log.Error("Delete volume group failed in volume controller:", err)
 		return err
 	}
 
-	defer c.Client.Close()
-
 	if errorMsg := response.GetError(); errorMsg != nil {
 		return errors.New(errorMsg.GetDescription())
 	}
@@ -476,6 +652,51 @@ func (c *controller) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts) error {
 	return nil
 }
 
-func (c *controller) SetDock(dockInfo *model.DockSpec) {
-	c.DockInfo = dockInfo
+func (c *controller) CreateGroupSnapshot(dock *model.DockSpec, opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return nil, err
+	}
+
+	response, err := cli.CreateGroupSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Error("Create group snapshot failed in volume controller:", err)
+		return nil, err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return nil,
+			fmt.Errorf("failed to create group snapshot in volume controller, code: %v, message: %v",
+				errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	var snps []*model.VolumeSnapshotSpec
+	if err = json.Unmarshal([]byte(response.GetResult().GetMessage()), &snps); err != nil {
+		log.Error("create group snapshot failed in volume controller:", err)
+		return nil, err
+	}
+
+	return snps, nil
+}
+
+func (c *controller) DeleteGroupSnapshot(dock *model.DockSpec, opt *pb.DeleteGroupSnapshotOpts) error {
+	cli, err := c.pool.Get(dock.Endpoint)
+	if err != nil {
+		log.Error("When connecting dock client:", err)
+		return err
+	}
+
+	response, err := cli.DeleteGroupSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Error("Delete group snapshot failed in volume controller:", err)
+		return err
+	}
+
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return fmt.Errorf("failed to delete group snapshot in volume controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+
+	return nil
 }
@@ -15,13 +15,19 @@
 package volume
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/opensds/opensds/pkg/dock/client"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
 	. "github.com/opensds/opensds/testutils/collection"
+	dockclient "github.com/opensds/opensds/testutils/dock/testing"
+	"github.com/stretchr/testify/mock"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
@@ -71,6 +77,37 @@ func (fc *fakeClient) ExtendVolume(ctx context.Context, in *pb.ExtendVolumeOpts,
 	}, nil
 }
 
+// Revert a volume to snapshot
+func (fc *fakeClient) RevertToSnapshot(ctx context.Context, in *pb.RevertToSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{},
+		},
+	}, nil
+}
+
+// Migrate a volume
+func (fc *fakeClient) MigrateVolume(ctx context.Context, in *pb.MigrateVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteVolume,
+			},
+		},
+	}, nil
+}
+
+// Retype a volume
+func (fc *fakeClient) RetypeVolume(ctx context.Context, in *pb.RetypeVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteVolume,
+			},
+		},
+	}, nil
+}
+
 // Create a volume attachment
 func (fc *fakeClient) CreateAttachment(ctx context.Context, in *pb.CreateAttachmentOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
 	return &pb.GenericResponse{
@@ -110,6 +147,28 @@ func (fc *fakeClient) DeleteVolumeSnapshot(ctx context.Context, in *pb.DeleteVol
 	}, nil
 }
 
+// Pull a volume
+func (fc *fakeClient) PullVolume(ctx context.Context, in *pb.PullVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteVolume,
+			},
+		},
+	}, nil
+}
+
+// Pull a volume snapshot
+func (fc *fakeClient) PullVolumeSnapshot(ctx context.Context, in *pb.PullVolumeSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteSnapshot,
+			},
+		},
+	}, nil
+}
+
 // Create a volume snapshot
 func (fc *fakeClient) CreateVolumeGroup(ctx context.Context, in *pb.CreateVolumeGroupOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
 	return &pb.GenericResponse{
@@ -141,6 +200,77 @@ func (fc *fakeClient) DeleteVolumeGroup(ctx context.Context, in *pb.DeleteVolume
 	}, nil
 }
 
+// Create a group snapshot
+func (fc *fakeClient) CreateGroupSnapshot(ctx context.Context, in *pb.CreateGroupSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteSnapshots,
+			},
+		},
+	}, nil
+}
+
+// Delete a group snapshot
+func (fc *fakeClient) DeleteGroupSnapshot(ctx context.Context, in *pb.DeleteGroupSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{},
+		},
+	}, nil
+}
+
+// Create a file share
+func (fc *fakeClient) CreateFileShare(ctx context.Context, in *pb.CreateFileShareOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteFileShare,
+			},
+		},
+	}, nil
+}
+
+// Delete a file share
+func (fc *fakeClient) DeleteFileShare(ctx context.Context, in *pb.DeleteFileShareOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{},
+		},
+	}, nil
+}
+
+// Extend a file share
+func (fc *fakeClient) ExtendFileShare(ctx context.Context, in *pb.ExtendFileShareOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteFileShare,
+			},
+		},
+	}, nil
+}
+
+// Grant the access to a file share
+func (fc *fakeClient) CreateFileShareAcl(ctx context.Context, in *pb.CreateFileShareAclOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: ByteFileShareAcl,
+			},
+		},
+	}, nil
+}
+
+// Revoke the access to a file share
+func (fc *fakeClient) DeleteFileShareAcl(ctx context.Context, in *pb.DeleteFileShareAclOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{},
+		},
+	}, nil
+}
+
 // Attach a volume
 func (fc *fakeClient) AttachVolume(ctx context.Context, in *pb.AttachVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
 	return &pb.GenericResponse{
@@ -161,6 +291,14 @@ func (fc *fakeClient) DetachVolume(ctx context.Context, in *pb.DetachVolumeOpts,
 	}, nil
 }
 
+func (fc *fakeClient) CopyVolume(ctx context.Context, in *pb.CopyVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{},
+		},
+	}, nil
+}
+
 // Create a volume attachment
 func (fc *fakeClient) CreateReplication(ctx context.Context, in *pb.CreateReplicationOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
 	return &pb.GenericResponse{
@@ -208,10 +346,35 @@ func (fc *fakeClient) FailoverReplication(ctx context.Context, in *pb.FailoverRe
 	}, nil
 }
 
+// dockClient is a fake client which creates the volumes on the dock it is
+// connected to, the endpoint of the dock is returned in the volume metadata.
+type dockClient struct {
+	fakeClient
+	endpoint string
+}
+
+func (dc *dockClient) Connect(edp string) error {
+	dc.endpoint = edp
+	return nil
+}
+
+func (dc *dockClient) CreateVolume(ctx context.Context, in *pb.CreateVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	vol, _ := json.Marshal(&model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: in.GetId()},
+		Metadata:  map[string]string{"endpoint": dc.endpoint},
+	})
+	return &pb.GenericResponse{
+		Reply: &pb.GenericResponse_Result_{
+			Result: &pb.GenericResponse_Result{
+				Message: string(vol),
+			},
+		},
+	}, nil
+}
+
 func NewFakeController() Controller {
 	return &controller{
-		Client:   NewFakeClient(),
-		DockInfo: &model.DockSpec{},
+		pool: client.NewPool(NewFakeClient),
 	}
 }
 
@@ -219,7 +382,7 @@ func TestCreateVolume(t *testing.T) {
 	fc := NewFakeController()
 	var expected = &SampleVolumes[0]
 
-	result, err := fc.CreateVolume(&pb.CreateVolumeOpts{})
+	result, err := fc.CreateVolume(&model.DockSpec{}, &pb.CreateVolumeOpts{})
 	if err != nil {
 		t.Errorf("Failed to create volume, err is %v\n", err)
 	}
@@ -232,7 +395,7 @@ func TestCreateVolume(t *testing.T) {
 func TestDeleteVolume(t *testing.T) {
 	fc := NewFakeController()
 
-	result := fc.DeleteVolume(&pb.DeleteVolumeOpts{})
+	result := fc.DeleteVolume(&model.DockSpec{}, &pb.DeleteVolumeOpts{})
 	if result != nil {
 		t.Errorf("Expected %v, got %v\n", nil, result)
 	}
@@ -242,7 +405,7 @@ func TestExtendVolume(t *testing.T) {
 	fc := NewFakeController()
 	var expected = &SampleVolumes[0]
 
-	result, err := fc.ExtendVolume(&pb.ExtendVolumeOpts{})
+	result, err := fc.ExtendVolume(&model.DockSpec{}, &pb.ExtendVolumeOpts{})
 	if err != nil {
 		t.Errorf("Failed to extend volume, err is %v\n", err)
 	}
@@ -252,11 +415,64 @@ func TestExtendVolume(t *testing.T) {
 	}
 }
 
+func TestRevertToSnapshot(t *testing.T) {
+	fc := NewFakeController()
+
+	result := fc.RevertToSnapshot(&model.DockSpec{}, &pb.RevertToSnapshotOpts{})
+	if result != nil {
+		t.Errorf("Expected %v, got %v\n", nil, result)
+	}
+}
+
+func TestMigrateVolume(t *testing.T) {
+	fc := NewFakeController()
+	var expected = &SampleVolumes[0]
+
+	result, err := fc.MigrateVolume(&model.DockSpec{}, &pb.MigrateVolumeOpts{})
+	if err != nil {
+		t.Errorf("Failed to migrate volume, err is %v\n", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+}
+
+func TestMigrateVolumeNotImplemented(t *testing.T) {
+	cli := new(dockclient.Client)
+	cli.On("Connect", "").Return(nil)
+	cli.On("MigrateVolume", mock.Anything, mock.Anything).Return(&pb.GenericResponse{
+		Reply: &pb.GenericResponse_Error_{
+			Error: &pb.GenericResponse_Error{Code: "501", Description: "not implemented"},
+		},
+	}, nil)
+	fc := &controller{pool: client.NewPool(func() client.Client { return cli })}
+
+	_, err := fc.MigrateVolume(&model.DockSpec{}, &pb.MigrateVolumeOpts{})
+	if _, ok := err.(*model.NotImplementError); !ok {
+		t.Errorf("Expected NotImplementError, got %v\n", err)
+	}
+}
+
+func TestRetypeVolume(t *testing.T) {
+	fc := NewFakeController()
+	var expected = &SampleVolumes[0]
+
+	result, err := fc.RetypeVolume(&model.DockSpec{}, &pb.RetypeVolumeOpts{})
+	if err != nil {
+		t.Errorf("Failed to retype volume, err is %v\n", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+}
+
 func TestCreateVolumeAttachment(t *testing.T) {
 	fc := NewFakeController()
 	var expected = &SampleAttachments[0]
 
-	result, err := fc.CreateVolumeAttachment(&pb.CreateAttachmentOpts{})
+	result, err := fc.CreateVolumeAttachment(&model.DockSpec{}, &pb.CreateAttachmentOpts{})
 	if err != nil {
 		t.Errorf("Failed to create volume attachment, err is %v\n", err)
 	}
@@ -269,7 +485,7 @@ func TestCreateVolumeAttachment(t *testing.T) {
 func TestDeleteVolumeAttachment(t *testing.T) {
 	fc := NewFakeController()
 
-	result := fc.DeleteVolumeAttachment(&pb.DeleteAttachmentOpts{})
+	result := fc.DeleteVolumeAttachment(&model.DockSpec{}, &pb.DeleteAttachmentOpts{})
 	if result != nil {
 		t.Errorf("Expected %v, got %v\n", nil, result)
 	}
@@ -279,7 +495,7 @@ func TestCreateVolumeSnapshot(t *testing.T) {
 	fc := NewFakeController()
 	var expected = &SampleSnapshots[0]
 
-	result, err := fc.CreateVolumeSnapshot(&pb.CreateVolumeSnapshotOpts{})
+	result, err := fc.CreateVolumeSnapshot(&model.DockSpec{}, &pb.CreateVolumeSnapshotOpts{})
 	if err != nil {
 		t.Errorf("Failed to create volume snapshot, err is %v\n", err)
 	}
@@ -292,7 +508,60 @@ func TestCreateVolumeSnapshot(t *testing.T) {
 func TestDeleteVolumeSnapshot(t *testing.T) {
 	fc := NewFakeController()
 
-	result := fc.DeleteVolumeSnapshot(&pb.DeleteVolumeSnapshotOpts{})
+	result := fc.DeleteVolumeSnapshot(&model.DockSpec{}, &pb.DeleteVolumeSnapshotOpts{})
+	if result != nil {
+		t.Errorf("Expected %v, got %v\n", nil, result)
+	}
+}
+
+func TestPullVolume(t *testing.T) {
+	fc := NewFakeController()
+	var expected = &SampleVolumes[0]
+
+	result, err := fc.PullVolume(&model.DockSpec{}, &pb.PullVolumeOpts{})
+	if err != nil {
+		t.Errorf("Failed to pull volume, err is %v\n", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+}
+
+func TestPullVolumeNotFound(t *testing.T) {
+	cli := new(dockclient.Client)
+	cli.On("Connect", "").Return(nil)
+	cli.On("PullVolumeSnapshot", mock.Anything, mock.Anything).Return(&pb.GenericResponse{
+		Reply: &pb.GenericResponse_Error_{
+			Error: &pb.GenericResponse_Error{Code: "404", Description: "not found"},
+		},
+	}, nil)
+	fc := &controller{pool: client.NewPool(func() client.Client { return cli })}
+
+	_, err := fc.PullVolumeSnapshot(&model.DockSpec{}, &pb.PullVolumeSnapshotOpts{})
+	if _, ok := err.(*model.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v\n", err)
+	}
+}
+
+func TestCreateGroupSnapshot(t *testing.T) {
+	fc := NewFakeController()
+	var expected = []*model.VolumeSnapshotSpec{&SampleSnapshots[0], &SampleSnapshots[1]}
+
+	result, err := fc.CreateGroupSnapshot(&model.DockSpec{}, &pb.CreateGroupSnapshotOpts{})
+	if err != nil {
+		t.Errorf("Failed to create group snapshot, err is %v\n", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, result)
+	}
+}
+
+func TestDeleteGroupSnapshot(t *testing.T) {
+	fc := NewFakeController()
+
+	result := fc.DeleteGroupSnapshot(&model.DockSpec{}, &pb.DeleteGroupSnapshotOpts{})
 	if result != nil {
 		t.Errorf("Expected %v, got %v\n", nil, result)
 	}
@@ -302,7 +571,7 @@ func TestCreateReplication(t *testing.T) {
 	fc := NewFakeController()
 	var expected = &SampleReplications[0]
 
-	result, err := fc.CreateReplication(&pb.CreateReplicationOpts{})
+	result, err := fc.CreateReplication(&model.DockSpec{}, &pb.CreateReplicationOpts{})
 	if err != nil {
 		t.Errorf("Failed to create replication, err is %v\n", err)
 	}
@@ -315,7 +584,7 @@ func TestCreateReplication(t *testing.T) {
 func TestDeleteReplication(t *testing.T) {
 	fc := NewFakeController()
 
-	result := fc.DeleteReplication(&pb.DeleteReplicationOpts{})
+	result := fc.DeleteReplication(&model.DockSpec{}, &pb.DeleteReplicationOpts{})
 	if result != nil {
 		t.Errorf("Expected %v, got %v\n", nil, result)
 	}
@@ -324,7 +593,7 @@ func TestDeleteReplication(t *testing.T) {
 func TestEnableReplication(t *testing.T) {
 	fc := NewFakeController()
 
-	result := fc.EnableReplication(&pb.EnableReplicationOpts{})
+	result := fc.EnableReplication(&model.DockSpec{}, &pb.EnableReplicationOpts{})
 	if result != nil {
 		t.Errorf("Expected %v, got %v\n", nil, result)
 	}
@@ -333,8 +602,49 @@ func TestEnableReplication(t *testing.T) {
 func TestDisableReplication(t *testing.T) {
 	fc := NewFakeController()
 
-	result := fc.DisableReplication(&pb.DisableReplicationOpts{})
+	result := fc.DisableReplication(&model.DockSpec{}, &pb.DisableReplicationOpts{})
 	if result != nil {
 		t.Errorf("Expected %v, got %v\n", nil, result)
 	}
 }
+
+func TestCreateVolumeConcurrently(t *testing.T) {
+	var docks = []*model.DockSpec{
+		{Endpoint: "192.168.0.1:50050"},
+		{Endpoint: "192.168.0.2:50050"},
+		{Endpoint: "192.168.0.3:50050"},
+	}
+	var created int32
+	fc := &controller{pool: client.NewPool(func() client.Client {
+		atomic.AddInt32(&created, 1)
+		return &dockClient{}
+	})}
+
+	var wg sync.WaitGroup
+	var errs = make(chan error, 30)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(id string, dock *model.DockSpec) {
+			defer wg.Done()
+			vol, err := fc.CreateVolume(dock, &pb.CreateVolumeOpts{Id: id})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if vol.Id != id || vol.Metadata["endpoint"] != dock.Endpoint {
+				errs <- fmt.Errorf("expected volume %s created on dock %s, got volume %s on dock %s",
+					id, dock.Endpoint, vol.Id, vol.Metadata["endpoint"])
+			}
+		}(fmt.Sprintf("volume-%d", i), docks[i%len(docks)])
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	// Only one client is connected to each dock.
+	if created != int32(len(docks)) {
+		t.Errorf("Expected %d clients created, got %d", len(docks), created)
+	}
+}
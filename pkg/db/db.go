@@ -23,9 +23,11 @@ package db
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/db/drivers/etcd"
+	"github.com/opensds/opensds/pkg/db/drivers/mysql"
 	"github.com/opensds/opensds/pkg/model"
 	. "github.com/opensds/opensds/pkg/utils/config"
 	fakedb "github.com/opensds/opensds/testutils/db"
@@ -38,8 +40,7 @@ var C Client
 func Init(db *Database) {
 	switch db.Driver {
 	case "mysql":
-		// C = mysql.Init(db.Driver, db.Crendential)
-		fmt.Printf("mysql is not implemented right now!")
+		C = mysql.Init(db.Driver, db.Credential)
 		return
 	case "etcd":
 		C = etcd.NewClient(strings.Split(db.Endpoint, ","))
@@ -63,6 +64,8 @@ type Client interface {
 
 	ListDocksWithFilter(ctx *c.Context, m map[string][]string) ([]*model.DockSpec, error)
 
+	ListDocksWithPage(ctx *c.Context, m map[string][]string) ([]*model.DockSpec, *model.PageSpec, error)
+
 	UpdateDock(ctx *c.Context, dckID, name, desp string) (*model.DockSpec, error)
 
 	DeleteDock(ctx *c.Context, dckID string) error
@@ -79,10 +82,18 @@ type Client interface {
 
 	ListPoolsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.StoragePoolSpec, error)
 
+	ListPoolsWithPage(ctx *c.Context, m map[string][]string) ([]*model.StoragePoolSpec, *model.PageSpec, error)
+
 	UpdatePool(ctx *c.Context, polID, name, desp string, usedCapacity int64, used bool) (*model.StoragePoolSpec, error)
 
 	DeletePool(ctx *c.Context, polID string) error
 
+	Heartbeat(ctx *c.Context, dckID string, ttl time.Duration) error
+
+	ListHeartbeats(ctx *c.Context) ([]string, error)
+
+	AcquireLock(ctx *c.Context, name, holder string, ttl time.Duration) (bool, error)
+
 	CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.ProfileSpec, error)
 
 	GetProfile(ctx *c.Context, prfID string) (*model.ProfileSpec, error)
@@ -93,6 +104,8 @@ type Client interface {
 
 	ListProfilesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.ProfileSpec, error)
 
+	ListProfilesWithPage(ctx *c.Context, m map[string][]string) ([]*model.ProfileSpec, *model.PageSpec, error)
+
 	UpdateProfile(ctx *c.Context, prfID string, input *model.ProfileSpec) (*model.ProfileSpec, error)
 
 	DeleteProfile(ctx *c.Context, prfID string) error
@@ -111,6 +124,8 @@ type Client interface {
 
 	ListVolumesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeSpec, error)
 
+	ListVolumesWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSpec, *model.PageSpec, error)
+
 	UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error)
 
 	DeleteVolume(ctx *c.Context, volID string) error
@@ -137,6 +152,8 @@ type Client interface {
 
 	ListVolumeSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, error)
 
+	ListVolumeSnapshotsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, *model.PageSpec, error)
+
 	UpdateVolumeSnapshot(ctx *c.Context, snapshotID string, vs *model.VolumeSnapshotSpec) (*model.VolumeSnapshotSpec, error)
 
 	DeleteVolumeSnapshot(ctx *c.Context, snapshotID string) error
@@ -151,6 +168,8 @@ type Client interface {
 
 	ListReplicationWithFilter(ctx *c.Context, m map[string][]string) ([]*model.ReplicationSpec, error)
 
+	ListReplicationWithPage(ctx *c.Context, m map[string][]string) ([]*model.ReplicationSpec, *model.PageSpec, error)
+
 	DeleteReplication(ctx *c.Context, replicationId string) error
 
 	UpdateReplication(ctx *c.Context, replicationId string, input *model.ReplicationSpec) (*model.ReplicationSpec, error)
@@ -174,4 +193,76 @@ type Client interface {
 	VolumesToUpdate(ctx *c.Context, volumeList []*model.VolumeSpec) ([]*model.VolumeSpec, error)
 
 	ListVolumeGroupsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeGroupSpec, error)
+
+	ListVolumeGroupsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeGroupSpec, *model.PageSpec, error)
+
+	CreateGroupSnapshot(ctx *c.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error)
+
+	GetGroupSnapshot(ctx *c.Context, gsId string) (*model.GroupSnapshotSpec, error)
+
+	ListGroupSnapshots(ctx *c.Context) ([]*model.GroupSnapshotSpec, error)
+
+	ListGroupSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.GroupSnapshotSpec, error)
+
+	UpdateGroupSnapshot(ctx *c.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error)
+
+	DeleteGroupSnapshot(ctx *c.Context, gsId string) error
+
+	CreateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error)
+
+	GetFileShare(ctx *c.Context, fshareId string) (*model.FileShareSpec, error)
+
+	ListFileShares(ctx *c.Context) ([]*model.FileShareSpec, error)
+
+	ListFileSharesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, error)
+
+	ListFileSharesWithPage(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, *model.PageSpec, error)
+
+	UpdateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error)
+
+	DeleteFileShare(ctx *c.Context, fshareId string) error
+
+	CreateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error)
+
+	GetFileShareAcl(ctx *c.Context, aclId string) (*model.FileShareAclSpec, error)
+
+	ListFileShareAcls(ctx *c.Context) ([]*model.FileShareAclSpec, error)
+
+	ListFileShareAclsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareAclSpec, error)
+
+	UpdateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error)
+
+	DeleteFileShareAcl(ctx *c.Context, aclId string) error
+
+	CreateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error)
+
+	GetSnapshotSchedule(ctx *c.Context, schedId string) (*model.SnapshotScheduleSpec, error)
+
+	ListSnapshotSchedules(ctx *c.Context) ([]*model.SnapshotScheduleSpec, error)
+
+	UpdateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error)
+
+	DeleteSnapshotSchedule(ctx *c.Context, schedId string) error
+
+	CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error)
+
+	GetJob(ctx *c.Context, jobId string) (*model.JobSpec, error)
+
+	ListJobs(ctx *c.Context) ([]*model.JobSpec, error)
+
+	ListJobsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.JobSpec, error)
+
+	UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error)
+
+	GetQuota(ctx *c.Context, tenantId string) (*model.QuotaSpec, error)
+
+	UpdateQuota(ctx *c.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error)
+
+	ReserveQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error
+
+	ReleaseQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error
+
+	ListEventsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.EventSpec, error)
+
+	WatchEvents(ctx *c.Context, m map[string][]string, timeout time.Duration) ([]*model.EventSpec, error)
 }
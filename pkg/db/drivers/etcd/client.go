@@ -27,6 +27,8 @@ import (
 var (
 	timeOut  = 3 * time.Second
 	retryNum = 3
+	// Events expire between half of eventTTL and eventTTL after they are put.
+	eventTTL = 24 * time.Hour
 )
 
 // Request
@@ -34,6 +36,23 @@ type Request struct {
 	Url        string `json:"url"`
 	Content    string `json:"content"`
 	NewContent string `json:"newContent"`
+	// Revision is the expected modification revision of the key in update
+	// request, zero means that the key is updated unconditionally.
+	Revision int64 `json:"revision"`
+	// Marker, Limit and Descend are the paging parameters of list page
+	// request: at most Limit keys after the Marker key are returned in the
+	// order of keys, and zero limit means no limit.
+	Marker  string `json:"marker"`
+	Limit   int64  `json:"limit"`
+	Descend bool   `json:"descend"`
+	// Events are the keys and values put along with the update request in
+	// the same transaction, they expire after a period of time.
+	Events map[string]string `json:"events"`
+	// Timeout is how long the watch request waits for the keys to be put.
+	Timeout time.Duration `json:"timeout"`
+	// TTL is the time to live of the key put by create or compare and swap
+	// request, zero means that the key never expires.
+	TTL time.Duration `json:"ttl"`
 }
 
 // Response
@@ -41,6 +60,24 @@ type Response struct {
 	Status  string   `json:"status"`
 	Message []string `json:"message"`
 	Error   string   `json:"error"`
+	// Revisions are the modification revisions of the keys whose values are
+	// returned in message.
+	Revisions []int64 `json:"revisions"`
+	// Keys, Count and More are only returned by list page request, they are
+	// the keys of messages, the number of all the keys under the prefix and
+	// whether there are more keys after the page.
+	Keys  []string `json:"keys"`
+	Count int64    `json:"count"`
+	More  bool     `json:"more"`
+}
+
+// revision returns the modification revision of the i-th message, and zero
+// if it's unknown.
+func (r *Response) revision(i int) int64 {
+	if i < len(r.Revisions) {
+		return r.Revisions[i]
+	}
+	return 0
 }
 
 type clientInterface interface {
@@ -50,9 +87,15 @@ type clientInterface interface {
 
 	List(req *Request) *Response
 
+	ListPage(req *Request) *Response
+
+	Watch(req *Request) *Response
+
 	Update(req *Request) *Response
 
 	Delete(req *Request) *Response
+
+	CompareAndSwap(req *Request) *Response
 }
 
 // Init
@@ -75,6 +118,10 @@ func Init(edps []string) *client {
 type client struct {
 	cli  *clientv3.Client
 	lock sync.Mutex
+
+	// The lease shared by the events put before eventLeaseRenewAt.
+	eventLease        clientv3.LeaseID
+	eventLeaseRenewAt time.Time
 }
 
 func (c *client) Create(req *Request) *Response {
@@ -85,7 +132,15 @@ func (c *client) Create(req *Request) *Response {
 	defer c.lock.Unlock()
 
 	err := utils.Retry(retryNum, "Etcd put", false, func(retryIdx int, lastErr error) error {
-		_, err := c.cli.Put(ctx, req.Url, req.Content)
+		var opts []clientv3.OpOption
+		if req.TTL > 0 {
+			resp, err := c.cli.Grant(ctx, int64(req.TTL/time.Second))
+			if err != nil {
+				return err
+			}
+			opts = append(opts, clientv3.WithLease(resp.ID))
+		}
+		_, err := c.cli.Put(ctx, req.Url, req.Content, opts...)
 		return err
 	})
 
@@ -126,8 +181,9 @@ func (c *client) Get(req *Request) *Response {
 		}
 	}
 	return &Response{
-		Status:  "Success",
-		Message: []string{string(resp.Kvs[0].Value)},
+		Status:    "Success",
+		Message:   []string{string(resp.Kvs[0].Value)},
+		Revisions: []int64{resp.Kvs[0].ModRevision},
 	}
 }
 
@@ -148,15 +204,74 @@ func (c *client) List(req *Request) *Response {
 	}
 
 	var message = []string{}
+	var revisions = []int64{}
 	for _, v := range resp.Kvs {
 		message = append(message, string(v.Value))
+		revisions = append(revisions, v.ModRevision)
 	}
 	return &Response{
-		Status:  "Success",
-		Message: message,
+		Status:    "Success",
+		Message:   message,
+		Revisions: revisions,
 	}
 }
 
+// ListPage reads a page of keys under the prefix with range read, and counts
+// all the keys under the prefix in the same revision.
+func (c *client) ListPage(req *Request) *Response {
+	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	defer cancel()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// The range of ascending page is (marker, end of prefix), and the range
+	// of descending page is [prefix, marker).
+	key, end := req.Url, clientv3.GetPrefixRangeEnd(req.Url)
+	order := clientv3.SortAscend
+	if req.Descend {
+		order = clientv3.SortDescend
+		if req.Marker != "" {
+			end = req.Marker
+		}
+	} else if req.Marker != "" {
+		key = req.Marker + "\x00"
+	}
+	opts := []clientv3.OpOption{
+		clientv3.WithRange(end),
+		clientv3.WithSort(clientv3.SortByKey, order),
+		clientv3.WithLimit(req.Limit),
+	}
+
+	resp, err := c.cli.Txn(ctx).Then(
+		clientv3.OpGet(key, opts...),
+		clientv3.OpGet(req.Url, clientv3.WithPrefix(), clientv3.WithCountOnly()),
+	).Commit()
+	if err != nil {
+		log.Error("When list page db request:", err)
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+
+	page := resp.Responses[0].GetResponseRange()
+	var res = &Response{
+		Status:    "Success",
+		Message:   []string{},
+		Revisions: []int64{},
+		Keys:      []string{},
+		Count:     resp.Responses[1].GetResponseRange().Count,
+		More:      page.More,
+	}
+	for _, v := range page.Kvs {
+		res.Message = append(res.Message, string(v.Value))
+		res.Revisions = append(res.Revisions, v.ModRevision)
+		res.Keys = append(res.Keys, string(v.Key))
+	}
+	return res
+}
+
 func (c *client) Update(req *Request) *Response {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
 	defer cancel()
@@ -164,7 +279,27 @@ func (c *client) Update(req *Request) *Response {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	_, err := c.cli.Put(ctx, req.Url, req.NewContent)
+	// If the revision is specified, the new content is put only when the key
+	// hasn't been modified since that revision.
+	var txn = c.cli.Txn(ctx)
+	if req.Revision != 0 {
+		txn = txn.If(clientv3.Compare(clientv3.ModRevision(req.Url), "=", req.Revision))
+	}
+	var ops = []clientv3.Op{clientv3.OpPut(req.Url, req.NewContent)}
+	if len(req.Events) > 0 {
+		lease, err := c.grantEventLease(ctx)
+		if err != nil {
+			log.Error("When grant event lease:", err)
+			return &Response{
+				Status: "Failure",
+				Error:  err.Error(),
+			}
+		}
+		for k, v := range req.Events {
+			ops = append(ops, clientv3.OpPut(k, v, clientv3.WithLease(lease)))
+		}
+	}
+	resp, err := txn.Then(ops...).Commit()
 	if err != nil {
 		log.Error("When update db request:", err)
 		return &Response{
@@ -172,13 +307,73 @@ func (c *client) Update(req *Request) *Response {
 			Error:  err.Error(),
 		}
 	}
+	if !resp.Succeeded {
+		return &Response{
+			Status: "Conflict",
+			Error:  "The resource has been modified by others!",
+		}
+	}
 
 	return &Response{
-		Status:  "Success",
-		Message: []string{req.NewContent},
+		Status:    "Success",
+		Message:   []string{req.NewContent},
+		Revisions: []int64{resp.Header.Revision},
 	}
 }
 
+// grantEventLease returns the lease which the events are attached to. Events
+// put within half of eventTTL share the same lease, so that a lease isn't
+// granted for every event.
+func (c *client) grantEventLease(ctx context.Context) (clientv3.LeaseID, error) {
+	if c.eventLease != 0 && time.Now().Before(c.eventLeaseRenewAt) {
+		return c.eventLease, nil
+	}
+	resp, err := c.cli.Grant(ctx, int64(eventTTL/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	c.eventLease, c.eventLeaseRenewAt = resp.ID, time.Now().Add(eventTTL/2)
+	return c.eventLease, nil
+}
+
+// Watch waits for the keys put under the prefix after the revision of
+// request, and returns the first batch of them. Zero revision means that
+// only the keys put from now on are watched, and an empty message is
+// returned if no key is put before timeout.
+func (c *client) Watch(req *Request) *Response {
+	// The lock isn't held since the request may block for a long time.
+	ctx, cancel := context.WithTimeout(context.Background(), req.Timeout)
+	defer cancel()
+
+	opts := []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithFilterDelete()}
+	if req.Revision != 0 {
+		opts = append(opts, clientv3.WithRev(req.Revision+1))
+	}
+
+	var res = &Response{
+		Status:    "Success",
+		Message:   []string{},
+		Revisions: []int64{},
+	}
+	for wresp := range c.cli.Watch(clientv3.WithRequireLeader(ctx), req.Url, opts...) {
+		if err := wresp.Err(); err != nil {
+			log.Error("When watch db request:", err)
+			return &Response{
+				Status: "Failure",
+				Error:  err.Error(),
+			}
+		}
+		for _, ev := range wresp.Events {
+			res.Message = append(res.Message, string(ev.Kv.Value))
+			res.Revisions = append(res.Revisions, ev.Kv.ModRevision)
+		}
+		if len(res.Message) > 0 {
+			break
+		}
+	}
+	return res
+}
+
 func (c *client) Delete(req *Request) *Response {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
 	defer cancel()
@@ -199,3 +394,50 @@ func (c *client) Delete(req *Request) *Response {
 		Status: "Success",
 	}
 }
+
+// CompareAndSwap puts the new content only if the current content is the same
+// as the content of request, and an empty content means that the key should
+// not exist. The status "Conflict" is returned if the comparison fails.
+func (c *client) CompareAndSwap(req *Request) *Response {
+	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	defer cancel()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var cmp = clientv3.Compare(clientv3.Value(req.Url), "=", req.Content)
+	if req.Content == "" {
+		cmp = clientv3.Compare(clientv3.CreateRevision(req.Url), "=", 0)
+	}
+	var opts []clientv3.OpOption
+	if req.TTL > 0 {
+		lease, err := c.cli.Grant(ctx, int64(req.TTL/time.Second))
+		if err != nil {
+			log.Error("When grant lease of compare and swap db request:", err)
+			return &Response{
+				Status: "Failure",
+				Error:  err.Error(),
+			}
+		}
+		opts = append(opts, clientv3.WithLease(lease.ID))
+	}
+	resp, err := c.cli.Txn(ctx).If(cmp).Then(clientv3.OpPut(req.Url, req.NewContent, opts...)).Commit()
+	if err != nil {
+		log.Error("When compare and swap db request:", err)
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	if !resp.Succeeded {
+		return &Response{
+			Status: "Conflict",
+			Error:  "The resource has been modified by others!",
+		}
+	}
+
+	return &Response{
+		Status:  "Success",
+		Message: []string{req.NewContent},
+	}
+}
@@ -48,7 +48,7 @@ const (
 	defaultSortKey = "ID"
 )
 
-var validKey = []string{"limit", "offset", "sortDir", "sortKey"}
+var validKey = []string{"limit", "offset", "sortDir", "sortKey", "marker"}
 
 func IsAdminContext(ctx *c.Context) bool {
 	return ctx.IsAdmin
@@ -58,6 +58,25 @@ func AuthorizeProjectContext(ctx *c.Context, tenantId string) bool {
 	return ctx.TenantId == tenantId
 }
 
+// expectedRevision returns the revision which the update is based on. The
+// resource version specified by caller takes precedence over the current one,
+// so that the update fails if the caller's copy of resource is stale.
+func expectedRevision(specified *model.BaseModel, current int64) int64 {
+	if specified != nil && specified.ResourceVersion != 0 {
+		return specified.ResourceVersion
+	}
+	return current
+}
+
+// updateError converts the failure response of update request to error, and
+// the conflict of revision is reported as ConflictError.
+func updateError(dbRes *Response) error {
+	if dbRes.Status == "Conflict" {
+		return model.NewConflictError(dbRes.Error)
+	}
+	return errors.New(dbRes.Error)
+}
+
 // NewClient
 func NewClient(edps []string) *Client {
 	return &Client{
@@ -234,6 +253,7 @@ func (c *Client) GetDock(ctx *c.Context, dckID string) (*model.DockSpec, error)
 		log.Error("When parsing dock in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	dck.ResourceVersion = dbRes.revision(0)
 	return dck, nil
 }
 
@@ -273,12 +293,13 @@ func (c *Client) ListDocks(ctx *c.Context) ([]*model.DockSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return dcks, nil
 	}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var dck = &model.DockSpec{}
 		if err := json.Unmarshal([]byte(msg), dck); err != nil {
 			log.Error("When parsing dock in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		dck.ResourceVersion = dbRes.revision(i)
 		dcks = append(dcks, dck)
 	}
 	return dcks, nil
@@ -406,12 +427,14 @@ func (c *Client) UpdateDock(ctx *c.Context, dckID, name, desp string) (*model.Do
 	dbReq := &Request{
 		Url:        urls.GenerateDockURL(urls.Etcd, "", dckID),
 		NewContent: string(dckBody),
+		Revision:   dck.ResourceVersion,
 	}
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update dock in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	dck.ResourceVersion = dbRes.revision(0)
 	return dck, nil
 }
 
@@ -576,6 +599,7 @@ func (c *Client) GetPool(ctx *c.Context, polID string) (*model.StoragePoolSpec,
 		log.Error("When parsing pool in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	pol.ResourceVersion = dbRes.revision(0)
 	return pol, nil
 }
 
@@ -621,12 +645,13 @@ func (c *Client) ListPools(ctx *c.Context) ([]*model.StoragePoolSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return pols, nil
 	}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var pol = &model.StoragePoolSpec{}
 		if err := json.Unmarshal([]byte(msg), pol); err != nil {
 			log.Error("When parsing pool in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		pol.ResourceVersion = dbRes.revision(i)
 		pols = append(pols, pol)
 	}
 	return pols, nil
@@ -654,12 +679,14 @@ func (c *Client) UpdatePool(ctx *c.Context, polID, name, desp string, usedCapaci
 	dbReq := &Request{
 		Url:        urls.GeneratePoolURL(urls.Etcd, "", polID),
 		NewContent: string(polBody),
+		Revision:   pol.ResourceVersion,
 	}
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update pool in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	pol.ResourceVersion = dbRes.revision(0)
 	return pol, nil
 }
 
@@ -676,6 +703,61 @@ func (c *Client) DeletePool(ctx *c.Context, polID string) error {
 	return nil
 }
 
+// Heartbeat puts the heartbeat of dock with a lease, so that it's deleted by
+// etcd if the dock doesn't send the next one in ttl.
+func (c *Client) Heartbeat(ctx *c.Context, dckID string, ttl time.Duration) error {
+	dbReq := &Request{
+		Url:     urls.GenerateHeartbeatURL(urls.Etcd, "", dckID),
+		Content: dckID,
+		TTL:     ttl,
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When put heartbeat in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// ListHeartbeats
+func (c *Client) ListHeartbeats(ctx *c.Context) ([]string, error) {
+	dbReq := &Request{
+		Url: urls.GenerateHeartbeatURL(urls.Etcd, "") + "/",
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list heartbeats in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return dbRes.Message, nil
+}
+
+// AcquireLock acquires the lock for the holder or renews it if the holder
+// has acquired it. The lock is put with a lease, so that it's released once
+// the holder stops renewing it within ttl.
+func (c *Client) AcquireLock(ctx *c.Context, name, holder string, ttl time.Duration) (bool, error) {
+	// The lock is created if nobody holds it, or renewed if it's held by the
+	// holder, otherwise it's held by others.
+	for _, current := range []string{"", holder} {
+		dbReq := &Request{
+			Url:        urls.GenerateLockURL(urls.Etcd, "", name),
+			Content:    current,
+			NewContent: holder,
+			TTL:        ttl,
+		}
+		dbRes := c.CompareAndSwap(dbReq)
+		switch dbRes.Status {
+		case "Success":
+			return true, nil
+		case "Conflict":
+		default:
+			log.Error("When acquire lock in db:", dbRes.Error)
+			return false, errors.New(dbRes.Error)
+		}
+	}
+	return false, nil
+}
+
 // CreateProfile
 func (c *Client) CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.ProfileSpec, error) {
 	if prf.Id == "" {
@@ -719,6 +801,7 @@ func (c *Client) GetProfile(ctx *c.Context, prfID string) (*model.ProfileSpec, e
 		log.Error("When parsing profile in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	prf.ResourceVersion = dbRes.revision(0)
 	return prf, nil
 }
 
@@ -754,12 +837,13 @@ func (c *Client) ListProfiles(ctx *c.Context) ([]*model.ProfileSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return prfs, nil
 	}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var prf = &model.ProfileSpec{}
 		if err := json.Unmarshal([]byte(msg), prf); err != nil {
 			log.Error("When parsing profile in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		prf.ResourceVersion = dbRes.revision(i)
 		prfs = append(prfs, prf)
 	}
 	return prfs, nil
@@ -889,12 +973,14 @@ func (c *Client) UpdateProfile(ctx *c.Context, prfID string, input *model.Profil
 	dbReq := &Request{
 		Url:        urls.GenerateProfileURL(urls.Etcd, "", prfID),
 		NewContent: string(prfBody),
+		Revision:   expectedRevision(input.BaseModel, prf.ResourceVersion),
 	}
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update profile in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	prf.ResourceVersion = dbRes.revision(0)
 	return prf, nil
 }
 
@@ -1018,6 +1104,7 @@ func (c *Client) getVolume(ctx *c.Context, volID string) (*model.VolumeSpec, err
 		log.Error("When parsing volume in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	vol.ResourceVersion = dbRes.revision(0)
 	return vol, nil
 }
 
@@ -1042,12 +1129,13 @@ func (c *Client) ListVolumes(ctx *c.Context) ([]*model.VolumeSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return vols, nil
 	}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var vol = &model.VolumeSpec{}
 		if err := json.Unmarshal([]byte(msg), vol); err != nil {
 			log.Error("When parsing volume in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		vol.ResourceVersion = dbRes.revision(i)
 		vols = append(vols, vol)
 	}
 	return vols, nil
@@ -1180,6 +1268,7 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	if err != nil {
 		return nil, err
 	}
+	oldStatus, oldPolicyStatus := result.Status, result.PolicyStatus
 	if vol.Name != "" {
 		result.Name = vol.Name
 	}
@@ -1207,6 +1296,16 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	if vol.ReplicationDriverData != nil {
 		result.ReplicationDriverData = vol.ReplicationDriverData
 	}
+	if vol.MigrationStatus != "" {
+		result.MigrationStatus = vol.MigrationStatus
+		result.MigrationProgress = vol.MigrationProgress
+	}
+	if vol.PolicyStatus != "" {
+		result.PolicyStatus = vol.PolicyStatus
+	}
+	if vol.Qos != nil {
+		result.Qos = vol.Qos
+	}
 	result.GroupId = vol.GroupId
 
 	// Set update time
@@ -1225,13 +1324,19 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	dbReq := &Request{
 		Url:        urls.GenerateVolumeURL(urls.Etcd, result.TenantId, vol.Id),
 		NewContent: string(body),
+		Revision:   expectedRevision(vol.BaseModel, result.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(result.TenantId,
+			model.EventResourceVolume, vol.Id, oldStatus, result.Status),
+			model.NewStatusEvent(result.TenantId, model.EventResourceVolumePolicy,
+				vol.Id, oldPolicyStatus, result.PolicyStatus)),
 	}
 
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update volume in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	result.ResourceVersion = dbRes.revision(0)
 	return result, nil
 }
 
@@ -1265,6 +1370,7 @@ func (c *Client) ExtendVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	if err != nil {
 		return nil, err
 	}
+	oldStatus := result.Status
 
 	if vol.Size > 0 {
 		result.Size = vol.Size
@@ -1279,15 +1385,19 @@ func (c *Client) ExtendVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	}
 
 	dbReq := &Request{
-		Url:        urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId, vol.Id),
+		Url:        urls.GenerateVolumeURL(urls.Etcd, result.TenantId, vol.Id),
 		NewContent: string(body),
+		Revision:   expectedRevision(vol.BaseModel, result.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(result.TenantId,
+			model.EventResourceVolume, vol.Id, oldStatus, result.Status)),
 	}
 
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When extend volume in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	result.ResourceVersion = dbRes.revision(0)
 	return result, nil
 }
 
@@ -1345,6 +1455,7 @@ func (c *Client) getVolumeAttachment(ctx *c.Context, attachmentId string) (*mode
 		log.Error("When parsing volume attachment in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	atc.ResourceVersion = dbRes.revision(0)
 	return atc, nil
 }
 
@@ -1363,12 +1474,13 @@ func (c *Client) ListVolumeAttachments(ctx *c.Context, volumeId string) ([]*mode
 	}
 
 	var atcs = []*model.VolumeAttachmentSpec{}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var atc = &model.VolumeAttachmentSpec{}
 		if err := json.Unmarshal([]byte(msg), atc); err != nil {
 			log.Error("When parsing volume attachment in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		atc.ResourceVersion = dbRes.revision(i)
 
 		if len(volumeId) == 0 || atc.VolumeId == volumeId {
 			atcs = append(atcs, atc)
@@ -1489,6 +1601,7 @@ func (c *Client) UpdateVolumeAttachment(ctx *c.Context, attachmentId string, att
 	if err != nil {
 		return nil, err
 	}
+	oldStatus := result.Status
 	if len(attachment.Mountpoint) > 0 {
 		result.Mountpoint = attachment.Mountpoint
 	}
@@ -1543,13 +1656,17 @@ func (c *Client) UpdateVolumeAttachment(ctx *c.Context, attachmentId string, att
 	dbReq := &Request{
 		Url:        urls.GenerateAttachmentURL(urls.Etcd, result.TenantId, attachmentId),
 		NewContent: string(atcBody),
+		Revision:   expectedRevision(attachment.BaseModel, result.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(result.TenantId,
+			model.EventResourceAttachment, attachmentId, oldStatus, result.Status)),
 	}
 
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update volume attachment in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	result.ResourceVersion = dbRes.revision(0)
 	return result, nil
 }
 
@@ -1631,6 +1748,7 @@ func (c *Client) getVolumeSnapshot(ctx *c.Context, snpID string) (*model.VolumeS
 		log.Error("When parsing volume snapshot in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	vs.ResourceVersion = dbRes.revision(0)
 	return vs, nil
 }
 
@@ -1652,12 +1770,13 @@ func (c *Client) ListVolumeSnapshots(ctx *c.Context) ([]*model.VolumeSnapshotSpe
 	if len(dbRes.Message) == 0 {
 		return vss, nil
 	}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var vs = &model.VolumeSnapshotSpec{}
 		if err := json.Unmarshal([]byte(msg), vs); err != nil {
 			log.Error("When parsing volume snapshot in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		vs.ResourceVersion = dbRes.revision(i)
 		vss = append(vss, vs)
 	}
 	return vss, nil
@@ -1715,6 +1834,8 @@ func (c *Client) FindSnapshotsValue(k string, p *model.VolumeSnapshotSpec) strin
 		return strconv.FormatInt(p.Size, 10)
 	case "VolumeId":
 		return p.VolumeId
+	case "GroupSnapshotId":
+		return p.GroupSnapshotId
 	}
 	return ""
 }
@@ -1777,6 +1898,7 @@ func (c *Client) UpdateVolumeSnapshot(ctx *c.Context, snpID string, snp *model.V
 	if err != nil {
 		return nil, err
 	}
+	oldStatus := result.Status
 	if snp.Name != "" {
 		result.Name = snp.Name
 	}
@@ -1811,13 +1933,17 @@ func (c *Client) UpdateVolumeSnapshot(ctx *c.Context, snpID string, snp *model.V
 	dbReq := &Request{
 		Url:        urls.GenerateSnapshotURL(urls.Etcd, result.TenantId, snpID),
 		NewContent: string(atcBody),
+		Revision:   expectedRevision(snp.BaseModel, result.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(result.TenantId,
+			model.EventResourceSnapshot, snpID, oldStatus, result.Status)),
 	}
 
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update volume snapshot in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	result.ResourceVersion = dbRes.revision(0)
 	return result, nil
 }
 
@@ -1916,6 +2042,7 @@ func (c *Client) getReplication(ctx *c.Context, replicationId string) (*model.Re
 		log.Error("When parsing replication in db:", resp.Error)
 		return nil, errors.New(resp.Error)
 	}
+	r.ResourceVersion = resp.revision(0)
 	return r, nil
 }
 
@@ -1936,12 +2063,13 @@ func (c *Client) ListReplication(ctx *c.Context) ([]*model.ReplicationSpec, erro
 	if len(resp.Message) == 0 {
 		return replicas, nil
 	}
-	for _, msg := range resp.Message {
+	for i, msg := range resp.Message {
 		var r = &model.ReplicationSpec{}
 		if err := json.Unmarshal([]byte(msg), r); err != nil {
 			log.Error("When parsing replication in db:", resp.Error)
 			return nil, errors.New(resp.Error)
 		}
+		r.ResourceVersion = resp.revision(i)
 		replicas = append(replicas, r)
 	}
 	return replicas, nil
@@ -2120,12 +2248,14 @@ func (c *Client) UpdateReplication(ctx *c.Context, replicationId string, input *
 	req := &Request{
 		Url:        urls.GenerateReplicationURL(urls.Etcd, tenantId, replicationId),
 		NewContent: string(b),
+		Revision:   expectedRevision(input.BaseModel, r.ResourceVersion),
 	}
 	resp := c.Update(req)
 	if resp.Status != "Success" {
 		log.Error("When update replication in db:", resp.Error)
-		return nil, errors.New(resp.Error)
+		return nil, updateError(resp)
 	}
+	r.ResourceVersion = resp.revision(0)
 	return r, nil
 }
 func (c *Client) CreateVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
@@ -2163,6 +2293,7 @@ func (c *Client) GetVolumeGroup(ctx *c.Context, vgId string) (*model.VolumeGroup
 		log.Error("When parsing volume group in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	vg.ResourceVersion = dbRes.revision(0)
 	return vg, nil
 }
 
@@ -2171,6 +2302,7 @@ func (c *Client) UpdateVolumeGroup(ctx *c.Context, vgUpdate *model.VolumeGroupSp
 	if err != nil {
 		return nil, err
 	}
+	oldStatus := vg.Status
 	if vgUpdate.Name != "" && vgUpdate.Name != vg.Name {
 		vg.Name = vgUpdate.Name
 	}
@@ -2195,6 +2327,9 @@ func (c *Client) UpdateVolumeGroup(ctx *c.Context, vgUpdate *model.VolumeGroupSp
 	if vgUpdate.UpdatedAt != "" && vgUpdate.UpdatedAt != vg.UpdatedAt {
 		vg.UpdatedAt = vgUpdate.UpdatedAt
 	}
+	if vgUpdate.GroupSnapshots != nil {
+		vg.GroupSnapshots = vgUpdate.GroupSnapshots
+	}
 
 	vgBody, err := json.Marshal(vg)
 	if err != nil {
@@ -2202,60 +2337,334 @@ func (c *Client) UpdateVolumeGroup(ctx *c.Context, vgUpdate *model.VolumeGroupSp
 	}
 
 	dbReq := &Request{
-		Url:        urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId, vgUpdate.Id),
+		Url:        urls.GenerateVolumeGroupURL(urls.Etcd, vg.TenantId, vgUpdate.Id),
 		NewContent: string(vgBody),
+		Revision:   expectedRevision(vgUpdate.BaseModel, vg.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(vg.TenantId,
+			model.EventResourceVolumeGroup, vgUpdate.Id, oldStatus, vg.Status)),
 	}
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
 		log.Error("When update volume group in db:", dbRes.Error)
-		return nil, errors.New(dbRes.Error)
+		return nil, updateError(dbRes)
 	}
+	vg.ResourceVersion = dbRes.revision(0)
 	return vg, nil
 }
 
+// UpdateStatus applies the status to the latest revision of resource no matter
+// which version the caller has read, because the status is maintained by the
+// system. It's retried if the resource is modified by others concurrently.
 func (c *Client) UpdateStatus(ctx *c.Context, in interface{}, status string) error {
+	for i := 0; ; i++ {
+		err := c.updateStatus(ctx, in, status)
+		if _, ok := err.(*model.ConflictError); !ok || i >= retryNum {
+			return err
+		}
+		log.V(5).Infof("Resource has been modified by others, retry updating status to %s.", status)
+	}
+}
+
+func (c *Client) updateStatus(ctx *c.Context, in interface{}, status string) error {
 	switch in.(type) {
 	case *model.VolumeSnapshotSpec:
 		snap := in.(*model.VolumeSnapshotSpec)
-		snap.Status = status
-		if _, errUpdate := c.UpdateVolumeSnapshot(ctx, snap.Id, snap); errUpdate != nil {
+		if errUpdate := c.updateSnapshotStatus(ctx, snap, status); errUpdate != nil {
 			log.Error("Error occurs when update volume snapshot status in db:", errUpdate.Error())
 			return errUpdate
 		}
 
 	case *model.VolumeAttachmentSpec:
 		attm := in.(*model.VolumeAttachmentSpec)
-		attm.Status = status
-		if _, errUpdate := c.UpdateVolumeAttachment(ctx, attm.Id, attm); errUpdate != nil {
+		if errUpdate := c.updateAttachmentStatus(ctx, attm, status); errUpdate != nil {
 			log.Error("Error occurred in dock module when update volume attachment status in db:", errUpdate)
 			return errUpdate
 		}
 
 	case *model.VolumeSpec:
 		volume := in.(*model.VolumeSpec)
-		volume.Status = status
-		if _, errUpdate := c.UpdateVolume(ctx, volume); errUpdate != nil {
+		if errUpdate := c.updateVolumeStatus(ctx, volume, status); errUpdate != nil {
 			log.Error("When update volume status in db:", errUpdate.Error())
 			return errUpdate
 		}
 
 	case *model.VolumeGroupSpec:
 		vg := in.(*model.VolumeGroupSpec)
-		vg.Status = status
-		if _, errUpdate := c.UpdateVolumeGroup(ctx, vg); errUpdate != nil {
+		if errUpdate := c.updateVolumeGroupStatus(ctx, vg, status); errUpdate != nil {
 			log.Error("When update volume status in db:", errUpdate.Error())
 			return errUpdate
 		}
 
+	case *model.GroupSnapshotSpec:
+		gs := in.(*model.GroupSnapshotSpec)
+		if errUpdate := c.updateGroupSnapshotStatus(ctx, gs, status); errUpdate != nil {
+			log.Error("When update group snapshot status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.FileShareSpec:
+		fshare := in.(*model.FileShareSpec)
+		if errUpdate := c.updateFileShareStatus(ctx, fshare, status); errUpdate != nil {
+			log.Error("When update file share status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.FileShareAclSpec:
+		acl := in.(*model.FileShareAclSpec)
+		if errUpdate := c.updateFileShareAclStatus(ctx, acl, status); errUpdate != nil {
+			log.Error("When update file share acl status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.DockSpec:
+		dck := in.(*model.DockSpec)
+		if errUpdate := c.updateDockStatus(ctx, dck, status); errUpdate != nil {
+			log.Error("When update dock status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.StoragePoolSpec:
+		pol := in.(*model.StoragePoolSpec)
+		if errUpdate := c.updatePoolStatus(ctx, pol, status); errUpdate != nil {
+			log.Error("When update pool status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
 	case []*model.VolumeSpec:
 		vols := in.([]*model.VolumeSpec)
-		if _, errUpdate := c.VolumesToUpdate(ctx, vols); errUpdate != nil {
-			return errUpdate
+		for _, vol := range vols {
+			if errUpdate := c.updateVolumeStatus(ctx, vol, status); errUpdate != nil {
+				log.Error("When update volume status in db:", errUpdate.Error())
+				return errUpdate
+			}
 		}
 	}
 	return nil
 }
 
+// statusVersion returns the base model of the status update, which is based
+// on the latest revision of resource rather than the one caller has read.
+func statusVersion(in *model.BaseModel, latest int64) *model.BaseModel {
+	b := *in
+	b.ResourceVersion = latest
+	return &b
+}
+
+// mergeMetadata returns the latest metadata overlaid with the keys reported by
+// the caller, so that the keys added through the API concurrently are kept.
+func mergeMetadata(latest, reported map[string]string) map[string]string {
+	if reported == nil {
+		return nil
+	}
+	merged := make(map[string]string, len(latest)+len(reported))
+	for k, v := range latest {
+		merged[k] = v
+	}
+	for k, v := range reported {
+		merged[k] = v
+	}
+	return merged
+}
+
+// updateVolumeStatus reads the volume again and applies the status along with
+// the fields maintained by the system, such as pool, size, qos and migration
+// progress. The name, description and availability zone are always taken from
+// the latest volume, so that the stale copy of caller doesn't revert the ones
+// modified through the API concurrently. An empty status keeps the current
+// one of volume.
+func (c *Client) updateVolumeStatus(ctx *c.Context, in *model.VolumeSpec, status string) error {
+	latest, err := c.GetVolume(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	vol := *in
+	vol.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	vol.Name, vol.Description = latest.Name, latest.Description
+	vol.AvailabilityZone = latest.AvailabilityZone
+	vol.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	if status != "" {
+		vol.Status = status
+	}
+	result, err := c.UpdateVolume(ctx, &vol)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateSnapshotStatus reads the snapshot again and applies the status, the
+// name and description modified concurrently are kept.
+func (c *Client) updateSnapshotStatus(ctx *c.Context, in *model.VolumeSnapshotSpec, status string) error {
+	latest, err := c.GetVolumeSnapshot(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	snap := *in
+	snap.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	snap.Name, snap.Description = latest.Name, latest.Description
+	snap.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	snap.Status = status
+	result, err := c.UpdateVolumeSnapshot(ctx, in.Id, &snap)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateAttachmentStatus reads the attachment again and applies the status
+// along with the connection info reported by the driver.
+func (c *Client) updateAttachmentStatus(ctx *c.Context, in *model.VolumeAttachmentSpec, status string) error {
+	latest, err := c.GetVolumeAttachment(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	atc := *in
+	atc.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	atc.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	atc.Status = status
+	result, err := c.UpdateVolumeAttachment(ctx, in.Id, &atc)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateVolumeGroupStatus reads the volume group again and applies the status,
+// the name, description and availability zone modified concurrently are kept.
+func (c *Client) updateVolumeGroupStatus(ctx *c.Context, in *model.VolumeGroupSpec, status string) error {
+	latest, err := c.GetVolumeGroup(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	vg := *in
+	vg.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	vg.Name, vg.Description = latest.Name, latest.Description
+	vg.AvailabilityZone = latest.AvailabilityZone
+	vg.Status = status
+	result, err := c.UpdateVolumeGroup(ctx, &vg)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateGroupSnapshotStatus reads the group snapshot again and applies the
+// status, the name and description modified concurrently are kept.
+func (c *Client) updateGroupSnapshotStatus(ctx *c.Context, in *model.GroupSnapshotSpec, status string) error {
+	latest, err := c.GetGroupSnapshot(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	gs := *in
+	gs.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	gs.Name, gs.Description = latest.Name, latest.Description
+	gs.Status = status
+	result, err := c.UpdateGroupSnapshot(ctx, &gs)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateFileShareStatus reads the file share again and applies the status
+// along with the fields maintained by the system, the name, description and
+// availability zone modified concurrently are kept.
+func (c *Client) updateFileShareStatus(ctx *c.Context, in *model.FileShareSpec, status string) error {
+	latest, err := c.GetFileShare(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	fshare := *in
+	fshare.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	fshare.Name, fshare.Description = latest.Name, latest.Description
+	fshare.AvailabilityZone = latest.AvailabilityZone
+	fshare.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	fshare.Status = status
+	result, err := c.UpdateFileShare(ctx, &fshare)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateFileShareAclStatus reads the file share acl again and applies the
+// status, the description modified concurrently is kept.
+func (c *Client) updateFileShareAclStatus(ctx *c.Context, in *model.FileShareAclSpec, status string) error {
+	latest, err := c.GetFileShareAcl(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	acl := *in
+	acl.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	acl.Description = latest.Description
+	acl.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	acl.Status = status
+	result, err := c.UpdateFileShareAcl(ctx, &acl)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateDockStatus reads the dock again before updating its status, since
+// the dock may be registered again by the dock service at the same time.
+func (c *Client) updateDockStatus(ctx *c.Context, in *model.DockSpec, status string) error {
+	dck, err := c.GetDock(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	dck.Status = status
+	dck.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	dckBody, err := json.Marshal(dck)
+	if err != nil {
+		return err
+	}
+
+	dbRes := c.Update(&Request{
+		Url:        urls.GenerateDockURL(urls.Etcd, "", dck.Id),
+		NewContent: string(dckBody),
+		Revision:   dck.ResourceVersion,
+	})
+	if dbRes.Status != "Success" {
+		return updateError(dbRes)
+	}
+	in.Status = status
+	return nil
+}
+
+// updatePoolStatus reads the pool again before updating its status, since
+// the pool may be registered again by the dock service at the same time.
+func (c *Client) updatePoolStatus(ctx *c.Context, in *model.StoragePoolSpec, status string) error {
+	pol, err := c.GetPool(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	pol.Status = status
+	pol.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	polBody, err := json.Marshal(pol)
+	if err != nil {
+		return err
+	}
+
+	dbRes := c.Update(&Request{
+		Url:        urls.GeneratePoolURL(urls.Etcd, "", pol.Id),
+		NewContent: string(polBody),
+		Revision:   pol.ResourceVersion,
+	})
+	if dbRes.Status != "Success" {
+		return updateError(dbRes)
+	}
+	in.Status = status
+	return nil
+}
+
 func (c *Client) ListVolumesByGroupId(ctx *c.Context, vgId string) ([]*model.VolumeSpec, error) {
 	volumes, err := c.ListVolumes(ctx)
 	if err != nil {
@@ -2303,12 +2712,13 @@ func (c *Client) ListVolumeGroups(ctx *c.Context) ([]*model.VolumeGroupSpec, err
 	if len(dbRes.Message) == 0 {
 		return groups, nil
 	}
-	for _, msg := range dbRes.Message {
+	for i, msg := range dbRes.Message {
 		var group = &model.VolumeGroupSpec{}
 		if err := json.Unmarshal([]byte(msg), group); err != nil {
 			log.Error("When parsing volume group in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
+		group.ResourceVersion = dbRes.revision(i)
 		groups = append(groups, group)
 	}
 	return groups, nil
@@ -2431,3 +2841,1042 @@ func (c *Client) SelectVolumeGroup(param map[string][]string, vgs []*model.Volum
 	}
 	return vglist
 }
+
+// CreateGroupSnapshot
+func (c *Client) CreateGroupSnapshot(ctx *c.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	gs.TenantId = ctx.TenantId
+	gsBody, err := json.Marshal(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:     urls.GenerateGroupSnapshotURL(urls.Etcd, ctx.TenantId, gs.Id),
+		Content: string(gsBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When create group snapshot in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return gs, nil
+}
+
+// GetGroupSnapshot
+func (c *Client) GetGroupSnapshot(ctx *c.Context, gsId string) (*model.GroupSnapshotSpec, error) {
+	gs, err := c.getGroupSnapshot(ctx, gsId)
+	if !IsAdminContext(ctx) || err == nil {
+		return gs, err
+	}
+	gss, err := c.ListGroupSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range gss {
+		if v.Id == gsId {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified group snapshot(%s) can't find", gsId)
+}
+
+func (c *Client) getGroupSnapshot(ctx *c.Context, gsId string) (*model.GroupSnapshotSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateGroupSnapshotURL(urls.Etcd, ctx.TenantId, gsId),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get group snapshot in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var gs = &model.GroupSnapshotSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), gs); err != nil {
+		log.Error("When parsing group snapshot in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	gs.ResourceVersion = dbRes.revision(0)
+	return gs, nil
+}
+
+// ListGroupSnapshots
+func (c *Client) ListGroupSnapshots(ctx *c.Context) ([]*model.GroupSnapshotSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateGroupSnapshotURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAdminContext(ctx) {
+		dbReq.Url = urls.GenerateGroupSnapshotURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list group snapshots in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var gss = []*model.GroupSnapshotSpec{}
+	for i, msg := range dbRes.Message {
+		var gs = &model.GroupSnapshotSpec{}
+		if err := json.Unmarshal([]byte(msg), gs); err != nil {
+			log.Error("When parsing group snapshot in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		gs.ResourceVersion = dbRes.revision(i)
+		gss = append(gss, gs)
+	}
+	return gss, nil
+}
+
+type GroupSnapshotCompareFunc func(a *model.GroupSnapshotSpec, b *model.GroupSnapshotSpec) bool
+
+var groupSnapshotCompareFunc GroupSnapshotCompareFunc
+
+type GroupSnapshotSlice []*model.GroupSnapshotSpec
+
+func (g GroupSnapshotSlice) Len() int           { return len(g) }
+func (g GroupSnapshotSlice) Swap(i, j int)      { g[i], g[j] = g[j], g[i] }
+func (g GroupSnapshotSlice) Less(i, j int) bool { return groupSnapshotCompareFunc(g[i], g[j]) }
+
+var groupSnapshotSortKey2Func = map[string]GroupSnapshotCompareFunc{
+	"ID":       func(a *model.GroupSnapshotSpec, b *model.GroupSnapshotSpec) bool { return a.Id > b.Id },
+	"NAME":     func(a *model.GroupSnapshotSpec, b *model.GroupSnapshotSpec) bool { return a.Name > b.Name },
+	"STATUS":   func(a *model.GroupSnapshotSpec, b *model.GroupSnapshotSpec) bool { return a.Status > b.Status },
+	"TENANTID": func(a *model.GroupSnapshotSpec, b *model.GroupSnapshotSpec) bool { return a.TenantId > b.TenantId },
+	"GROUPID":  func(a *model.GroupSnapshotSpec, b *model.GroupSnapshotSpec) bool { return a.GroupId > b.GroupId },
+}
+
+// ListGroupSnapshotsWithFilter
+func (c *Client) ListGroupSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.GroupSnapshotSpec, error) {
+	gss, err := c.ListGroupSnapshots(ctx)
+	if err != nil {
+		log.Error("List group snapshots failed: ", err)
+		return nil, err
+	}
+
+	var rlist = gss
+	if c.SelectOrNot(m) {
+		filterList := map[string]interface{}{
+			"Id":          nil,
+			"CreatedAt":   nil,
+			"UpdatedAt":   nil,
+			"Name":        nil,
+			"Status":      nil,
+			"TenantId":    nil,
+			"UserId":      nil,
+			"Description": nil,
+			"GroupId":     nil,
+		}
+		rlist = []*model.GroupSnapshotSpec{}
+		for _, gs := range gss {
+			if c.filterByName(m, gs, filterList) {
+				rlist = append(rlist, gs)
+			}
+		}
+	}
+
+	var sortKeys []string
+	for k := range groupSnapshotSortKey2Func {
+		sortKeys = append(sortKeys, k)
+	}
+	p := c.ParameterFilter(m, len(rlist), sortKeys)
+	groupSnapshotCompareFunc = groupSnapshotSortKey2Func[p.sortKey]
+	if strings.EqualFold(p.sortDir, "asc") {
+		sort.Sort(GroupSnapshotSlice(rlist))
+	} else {
+		sort.Sort(sort.Reverse(GroupSnapshotSlice(rlist)))
+	}
+	return rlist[p.beginIdx:p.endIdx], nil
+}
+
+// UpdateGroupSnapshot
+func (c *Client) UpdateGroupSnapshot(ctx *c.Context, gsUpdate *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	gs, err := c.GetGroupSnapshot(ctx, gsUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus := gs.Status
+	if gsUpdate.Name != "" {
+		gs.Name = gsUpdate.Name
+	}
+	if gsUpdate.Description != "" {
+		gs.Description = gsUpdate.Description
+	}
+	if gsUpdate.Status != "" {
+		gs.Status = gsUpdate.Status
+	}
+	if gsUpdate.Snapshots != nil {
+		gs.Snapshots = gsUpdate.Snapshots
+	}
+	gs.UpdatedAt = time.Now().Format(constants.TimeFormat)
+
+	gsBody, err := json.Marshal(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:        urls.GenerateGroupSnapshotURL(urls.Etcd, gs.TenantId, gs.Id),
+		NewContent: string(gsBody),
+		Revision:   expectedRevision(gsUpdate.BaseModel, gs.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(gs.TenantId,
+			model.EventResourceGroupSnapshot, gs.Id, oldStatus, gs.Status)),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update group snapshot in db:", dbRes.Error)
+		return nil, updateError(dbRes)
+	}
+	gs.ResourceVersion = dbRes.revision(0)
+	return gs, nil
+}
+
+// DeleteGroupSnapshot
+func (c *Client) DeleteGroupSnapshot(ctx *c.Context, gsId string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		gs, err := c.GetGroupSnapshot(ctx, gsId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = gs.TenantId
+	}
+	dbReq := &Request{
+		Url: urls.GenerateGroupSnapshotURL(urls.Etcd, tenantId, gsId),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When delete group snapshot in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateFileShare
+func (c *Client) CreateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error) {
+	fshare.TenantId = ctx.TenantId
+	fshareBody, err := json.Marshal(fshare)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:     urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId, fshare.Id),
+		Content: string(fshareBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When create file share in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return fshare, nil
+}
+
+// GetFileShare
+func (c *Client) GetFileShare(ctx *c.Context, fshareId string) (*model.FileShareSpec, error) {
+	fshare, err := c.getFileShare(ctx, fshareId)
+	if !IsAdminContext(ctx) || err == nil {
+		return fshare, err
+	}
+	fshares, err := c.ListFileShares(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range fshares {
+		if v.Id == fshareId {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified file share(%s) can't find", fshareId)
+}
+
+func (c *Client) getFileShare(ctx *c.Context, fshareId string) (*model.FileShareSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId, fshareId),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get file share in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var fshare = &model.FileShareSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), fshare); err != nil {
+		log.Error("When parsing file share in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	fshare.ResourceVersion = dbRes.revision(0)
+	return fshare, nil
+}
+
+// ListFileShares
+func (c *Client) ListFileShares(ctx *c.Context) ([]*model.FileShareSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAdminContext(ctx) {
+		dbReq.Url = urls.GenerateFileShareURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list file shares in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var fshares = []*model.FileShareSpec{}
+	for i, msg := range dbRes.Message {
+		var fshare = &model.FileShareSpec{}
+		if err := json.Unmarshal([]byte(msg), fshare); err != nil {
+			log.Error("When parsing file share in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		fshare.ResourceVersion = dbRes.revision(i)
+		fshares = append(fshares, fshare)
+	}
+	return fshares, nil
+}
+
+type FileShareCompareFunc func(a *model.FileShareSpec, b *model.FileShareSpec) bool
+
+var fileShareCompareFunc FileShareCompareFunc
+
+type FileShareSlice []*model.FileShareSpec
+
+func (f FileShareSlice) Len() int           { return len(f) }
+func (f FileShareSlice) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f FileShareSlice) Less(i, j int) bool { return fileShareCompareFunc(f[i], f[j]) }
+
+var fileShareSortKey2Func = map[string]FileShareCompareFunc{
+	"ID":               func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.Id > b.Id },
+	"NAME":             func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.Name > b.Name },
+	"STATUS":           func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.Status > b.Status },
+	"TENANTID":         func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.TenantId > b.TenantId },
+	"SIZE":             func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.Size > b.Size },
+	"AVAILABILITYZONE": func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.AvailabilityZone > b.AvailabilityZone },
+	"POOLID":           func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.PoolId > b.PoolId },
+	"PROFILEID":        func(a *model.FileShareSpec, b *model.FileShareSpec) bool { return a.ProfileId > b.ProfileId },
+}
+
+// ListFileSharesWithFilter
+func (c *Client) ListFileSharesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, error) {
+	fshares, err := c.ListFileShares(ctx)
+	if err != nil {
+		log.Error("List file shares failed: ", err)
+		return nil, err
+	}
+
+	var rlist = fshares
+	if c.SelectOrNot(m) {
+		filterList := map[string]interface{}{
+			"Id":               nil,
+			"CreatedAt":        nil,
+			"UpdatedAt":        nil,
+			"Name":             nil,
+			"Status":           nil,
+			"TenantId":         nil,
+			"UserId":           nil,
+			"Description":      nil,
+			"Size":             nil,
+			"AvailabilityZone": nil,
+			"PoolId":           nil,
+			"ProfileId":        nil,
+			"Protocol":         nil,
+		}
+		rlist = []*model.FileShareSpec{}
+		for _, fshare := range fshares {
+			if c.filterByName(m, fshare, filterList) {
+				rlist = append(rlist, fshare)
+			}
+		}
+	}
+
+	var sortKeys []string
+	for k := range fileShareSortKey2Func {
+		sortKeys = append(sortKeys, k)
+	}
+	p := c.ParameterFilter(m, len(rlist), sortKeys)
+	fileShareCompareFunc = fileShareSortKey2Func[p.sortKey]
+	if strings.EqualFold(p.sortDir, "asc") {
+		sort.Sort(FileShareSlice(rlist))
+	} else {
+		sort.Sort(sort.Reverse(FileShareSlice(rlist)))
+	}
+	return rlist[p.beginIdx:p.endIdx], nil
+}
+
+// UpdateFileShare
+func (c *Client) UpdateFileShare(ctx *c.Context, fshareUpdate *model.FileShareSpec) (*model.FileShareSpec, error) {
+	fshare, err := c.GetFileShare(ctx, fshareUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus := fshare.Status
+	if fshareUpdate.Name != "" {
+		fshare.Name = fshareUpdate.Name
+	}
+	if fshareUpdate.Description != "" {
+		fshare.Description = fshareUpdate.Description
+	}
+	if fshareUpdate.Size != 0 {
+		fshare.Size = fshareUpdate.Size
+	}
+	if fshareUpdate.Status != "" {
+		fshare.Status = fshareUpdate.Status
+	}
+	if fshareUpdate.PoolId != "" {
+		fshare.PoolId = fshareUpdate.PoolId
+	}
+	if fshareUpdate.ProfileId != "" {
+		fshare.ProfileId = fshareUpdate.ProfileId
+	}
+	if fshareUpdate.ExportLocations != nil {
+		fshare.ExportLocations = fshareUpdate.ExportLocations
+	}
+	if fshareUpdate.Metadata != nil {
+		fshare.Metadata = utils.MergeStringMaps(fshare.Metadata, fshareUpdate.Metadata)
+	}
+	fshare.UpdatedAt = time.Now().Format(constants.TimeFormat)
+
+	fshareBody, err := json.Marshal(fshare)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:        urls.GenerateFileShareURL(urls.Etcd, fshare.TenantId, fshare.Id),
+		NewContent: string(fshareBody),
+		Revision:   expectedRevision(fshareUpdate.BaseModel, fshare.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(fshare.TenantId,
+			model.EventResourceFileShare, fshare.Id, oldStatus, fshare.Status)),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update file share in db:", dbRes.Error)
+		return nil, updateError(dbRes)
+	}
+	fshare.ResourceVersion = dbRes.revision(0)
+	return fshare, nil
+}
+
+// DeleteFileShare
+func (c *Client) DeleteFileShare(ctx *c.Context, fshareId string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		fshare, err := c.GetFileShare(ctx, fshareId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = fshare.TenantId
+	}
+	dbReq := &Request{
+		Url: urls.GenerateFileShareURL(urls.Etcd, tenantId, fshareId),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When delete file share in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateFileShareAcl
+func (c *Client) CreateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	acl.TenantId = ctx.TenantId
+	aclBody, err := json.Marshal(acl)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:     urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId, acl.Id),
+		Content: string(aclBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When create file share acl in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return acl, nil
+}
+
+// GetFileShareAcl
+func (c *Client) GetFileShareAcl(ctx *c.Context, aclId string) (*model.FileShareAclSpec, error) {
+	acl, err := c.getFileShareAcl(ctx, aclId)
+	if !IsAdminContext(ctx) || err == nil {
+		return acl, err
+	}
+	acls, err := c.ListFileShareAcls(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range acls {
+		if v.Id == aclId {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified file share acl(%s) can't find", aclId)
+}
+
+func (c *Client) getFileShareAcl(ctx *c.Context, aclId string) (*model.FileShareAclSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId, aclId),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get file share acl in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var acl = &model.FileShareAclSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), acl); err != nil {
+		log.Error("When parsing file share acl in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	acl.ResourceVersion = dbRes.revision(0)
+	return acl, nil
+}
+
+// ListFileShareAcls
+func (c *Client) ListFileShareAcls(ctx *c.Context) ([]*model.FileShareAclSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAdminContext(ctx) {
+		dbReq.Url = urls.GenerateFileShareAclURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list file share acls in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var acls = []*model.FileShareAclSpec{}
+	for i, msg := range dbRes.Message {
+		var acl = &model.FileShareAclSpec{}
+		if err := json.Unmarshal([]byte(msg), acl); err != nil {
+			log.Error("When parsing file share acl in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		acl.ResourceVersion = dbRes.revision(i)
+		acls = append(acls, acl)
+	}
+	return acls, nil
+}
+
+type FileShareAclCompareFunc func(a *model.FileShareAclSpec, b *model.FileShareAclSpec) bool
+
+var fileShareAclCompareFunc FileShareAclCompareFunc
+
+type FileShareAclSlice []*model.FileShareAclSpec
+
+func (f FileShareAclSlice) Len() int           { return len(f) }
+func (f FileShareAclSlice) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f FileShareAclSlice) Less(i, j int) bool { return fileShareAclCompareFunc(f[i], f[j]) }
+
+var fileShareAclSortKey2Func = map[string]FileShareAclCompareFunc{
+	"ID":          func(a *model.FileShareAclSpec, b *model.FileShareAclSpec) bool { return a.Id > b.Id },
+	"STATUS":      func(a *model.FileShareAclSpec, b *model.FileShareAclSpec) bool { return a.Status > b.Status },
+	"TENANTID":    func(a *model.FileShareAclSpec, b *model.FileShareAclSpec) bool { return a.TenantId > b.TenantId },
+	"FILESHAREID": func(a *model.FileShareAclSpec, b *model.FileShareAclSpec) bool { return a.FileShareId > b.FileShareId },
+	"ACCESSTO":    func(a *model.FileShareAclSpec, b *model.FileShareAclSpec) bool { return a.AccessTo > b.AccessTo },
+}
+
+// ListFileShareAclsWithFilter
+func (c *Client) ListFileShareAclsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareAclSpec, error) {
+	acls, err := c.ListFileShareAcls(ctx)
+	if err != nil {
+		log.Error("List file share acls failed: ", err)
+		return nil, err
+	}
+
+	var rlist = acls
+	if c.SelectOrNot(m) {
+		filterList := map[string]interface{}{
+			"Id":          nil,
+			"CreatedAt":   nil,
+			"UpdatedAt":   nil,
+			"Status":      nil,
+			"TenantId":    nil,
+			"UserId":      nil,
+			"FileShareId": nil,
+			"Type":        nil,
+			"AccessTo":    nil,
+			"AccessLevel": nil,
+		}
+		rlist = []*model.FileShareAclSpec{}
+		for _, acl := range acls {
+			if c.filterByName(m, acl, filterList) {
+				rlist = append(rlist, acl)
+			}
+		}
+	}
+
+	var sortKeys []string
+	for k := range fileShareAclSortKey2Func {
+		sortKeys = append(sortKeys, k)
+	}
+	p := c.ParameterFilter(m, len(rlist), sortKeys)
+	fileShareAclCompareFunc = fileShareAclSortKey2Func[p.sortKey]
+	if strings.EqualFold(p.sortDir, "asc") {
+		sort.Sort(FileShareAclSlice(rlist))
+	} else {
+		sort.Sort(sort.Reverse(FileShareAclSlice(rlist)))
+	}
+	return rlist[p.beginIdx:p.endIdx], nil
+}
+
+// UpdateFileShareAcl
+func (c *Client) UpdateFileShareAcl(ctx *c.Context, aclUpdate *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	acl, err := c.GetFileShareAcl(ctx, aclUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus := acl.Status
+	if aclUpdate.Description != "" {
+		acl.Description = aclUpdate.Description
+	}
+	if aclUpdate.Status != "" {
+		acl.Status = aclUpdate.Status
+	}
+	if aclUpdate.Metadata != nil {
+		acl.Metadata = utils.MergeStringMaps(acl.Metadata, aclUpdate.Metadata)
+	}
+	acl.UpdatedAt = time.Now().Format(constants.TimeFormat)
+
+	aclBody, err := json.Marshal(acl)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:        urls.GenerateFileShareAclURL(urls.Etcd, acl.TenantId, acl.Id),
+		NewContent: string(aclBody),
+		Revision:   expectedRevision(aclUpdate.BaseModel, acl.ResourceVersion),
+		Events: statusEvents(model.NewStatusEvent(acl.TenantId,
+			model.EventResourceFileShareAcl, acl.Id, oldStatus, acl.Status)),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update file share acl in db:", dbRes.Error)
+		return nil, updateError(dbRes)
+	}
+	acl.ResourceVersion = dbRes.revision(0)
+	return acl, nil
+}
+
+// DeleteFileShareAcl
+func (c *Client) DeleteFileShareAcl(ctx *c.Context, aclId string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		acl, err := c.GetFileShareAcl(ctx, aclId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = acl.TenantId
+	}
+	dbReq := &Request{
+		Url: urls.GenerateFileShareAclURL(urls.Etcd, tenantId, aclId),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When delete file share acl in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// quotaRetryNum is the number of times of retrying when the quota has been
+// modified by others during updating.
+const quotaRetryNum = 10
+
+// getQuota returns the quota of tenant along with its raw content in db, the
+// raw content is empty if the quota has never been set.
+func (c *Client) getQuota(tenantId string) (*model.QuotaSpec, string, error) {
+	dbReq := &Request{
+		Url: urls.GenerateQuotaURL(urls.Etcd, tenantId),
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get quota in db:", dbRes.Error)
+		return nil, "", errors.New(dbRes.Error)
+	}
+
+	for i, msg := range dbRes.Message {
+		var quota = &model.QuotaSpec{}
+		if err := json.Unmarshal([]byte(msg), quota); err != nil {
+			log.Error("When parsing quota in db:", err)
+			return nil, "", err
+		}
+		quota.ResourceVersion = dbRes.revision(i)
+		// Keys of other tenants may share the same prefix.
+		if quota.TenantId == tenantId {
+			return quota, msg, nil
+		}
+	}
+	return model.NewDefaultQuota(tenantId), "", nil
+}
+
+// updateQuota applies the change to the quota of tenant with compare-and-swap,
+// and retries if the quota has been modified by others in the meantime.
+func (c *Client) updateQuota(tenantId string, change func(quota *model.QuotaSpec) error) (*model.QuotaSpec, error) {
+	for i := 0; i < quotaRetryNum; i++ {
+		quota, oldBody, err := c.getQuota(tenantId)
+		if err != nil {
+			return nil, err
+		}
+		if err = change(quota); err != nil {
+			return nil, err
+		}
+
+		if oldBody == "" {
+			quota.CreatedAt = time.Now().Format(constants.TimeFormat)
+		} else {
+			quota.UpdatedAt = time.Now().Format(constants.TimeFormat)
+		}
+		quotaBody, err := json.Marshal(quota)
+		if err != nil {
+			return nil, err
+		}
+
+		dbReq := &Request{
+			Url:        urls.GenerateQuotaURL(urls.Etcd, tenantId),
+			Content:    oldBody,
+			NewContent: string(quotaBody),
+		}
+		dbRes := c.CompareAndSwap(dbReq)
+		switch dbRes.Status {
+		case "Success":
+			return quota, nil
+		case "Conflict":
+			log.V(5).Infof("Quota of tenant %s has been modified, retry updating.", tenantId)
+		default:
+			log.Error("When update quota in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+	}
+	return nil, fmt.Errorf("update quota of tenant %s failed after %d retries", tenantId, quotaRetryNum)
+}
+
+// GetQuota
+func (c *Client) GetQuota(ctx *c.Context, tenantId string) (*model.QuotaSpec, error) {
+	quota, _, err := c.getQuota(tenantId)
+	return quota, err
+}
+
+// UpdateQuota only changes the limits of quota, the usage is kept as it is.
+func (c *Client) UpdateQuota(ctx *c.Context, in *model.QuotaSpec) (*model.QuotaSpec, error) {
+	return c.updateQuota(in.TenantId, func(quota *model.QuotaSpec) error {
+		quota.Volumes = in.Volumes
+		quota.Gigabytes = in.Gigabytes
+		quota.Snapshots = in.Snapshots
+		quota.Replications = in.Replications
+		return nil
+	})
+}
+
+// ReserveQuota adds the delta to the usage of tenant atomically, and fails
+// with QuotaExceededError if any limit is exceeded.
+func (c *Client) ReserveQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	// Resources without tenant are not limited.
+	if tenantId == "" {
+		return nil
+	}
+	_, err := c.updateQuota(tenantId, func(quota *model.QuotaSpec) error {
+		return quota.Reserve(delta)
+	})
+	return err
+}
+
+// ReleaseQuota subtracts the delta from the usage of tenant atomically.
+func (c *Client) ReleaseQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	if tenantId == "" {
+		return nil
+	}
+	_, err := c.updateQuota(tenantId, func(quota *model.QuotaSpec) error {
+		quota.Release(delta)
+		return nil
+	})
+	return err
+}
+
+// CreateSnapshotSchedule
+func (c *Client) CreateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	schedBody, err := json.Marshal(sched)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schedules are only accessed by the controller, so they aren't
+	// stored under the tenants.
+	dbReq := &Request{
+		Url:     urls.GenerateSnapshotScheduleURL(urls.Etcd, "", sched.Id),
+		Content: string(schedBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When create snapshot schedule in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return sched, nil
+}
+
+// GetSnapshotSchedule
+func (c *Client) GetSnapshotSchedule(ctx *c.Context, schedId string) (*model.SnapshotScheduleSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateSnapshotScheduleURL(urls.Etcd, "", schedId),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get snapshot schedule in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var sched = &model.SnapshotScheduleSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), sched); err != nil {
+		log.Error("When parsing snapshot schedule in db:", err)
+		return nil, err
+	}
+	sched.ResourceVersion = dbRes.revision(0)
+	return sched, nil
+}
+
+// ListSnapshotSchedules
+func (c *Client) ListSnapshotSchedules(ctx *c.Context) ([]*model.SnapshotScheduleSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateSnapshotScheduleURL(urls.Etcd, ""),
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list snapshot schedules in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var scheds = []*model.SnapshotScheduleSpec{}
+	for i, msg := range dbRes.Message {
+		var sched = &model.SnapshotScheduleSpec{}
+		if err := json.Unmarshal([]byte(msg), sched); err != nil {
+			log.Error("When parsing snapshot schedule in db:", err)
+			return nil, err
+		}
+		sched.ResourceVersion = dbRes.revision(i)
+		scheds = append(scheds, sched)
+	}
+	return scheds, nil
+}
+
+// UpdateSnapshotSchedule replaces the schedule as a whole. If the resource
+// version of schedule is specified, the update fails with ConflictError when
+// the schedule has been modified since that version.
+func (c *Client) UpdateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	sched.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	schedBody, err := json.Marshal(sched)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:        urls.GenerateSnapshotScheduleURL(urls.Etcd, "", sched.Id),
+		NewContent: string(schedBody),
+		Revision:   sched.ResourceVersion,
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update snapshot schedule in db:", dbRes.Error)
+		return nil, updateError(dbRes)
+	}
+	sched.ResourceVersion = dbRes.revision(0)
+	return sched, nil
+}
+
+// DeleteSnapshotSchedule
+func (c *Client) DeleteSnapshotSchedule(ctx *c.Context, schedId string) error {
+	dbReq := &Request{
+		Url: urls.GenerateSnapshotScheduleURL(urls.Etcd, "", schedId),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When delete snapshot schedule in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateJob
+func (c *Client) CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	job.TenantId = ctx.TenantId
+	jobBody, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:     urls.GenerateJobURL(urls.Etcd, ctx.TenantId, job.Id),
+		Content: string(jobBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When create job in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return job, nil
+}
+
+// GetJob
+func (c *Client) GetJob(ctx *c.Context, jobId string) (*model.JobSpec, error) {
+	job, err := c.getJob(ctx, jobId)
+	if !IsAdminContext(ctx) || err == nil {
+		return job, err
+	}
+	jobs, err := c.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range jobs {
+		if v.Id == jobId {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified job(%s) can't find", jobId)
+}
+
+func (c *Client) getJob(ctx *c.Context, jobId string) (*model.JobSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateJobURL(urls.Etcd, ctx.TenantId, jobId),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get job in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var job = &model.JobSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), job); err != nil {
+		log.Error("When parsing job in db:", err)
+		return nil, err
+	}
+	job.ResourceVersion = dbRes.revision(0)
+	return job, nil
+}
+
+// ListJobs
+func (c *Client) ListJobs(ctx *c.Context) ([]*model.JobSpec, error) {
+	dbReq := &Request{
+		Url: urls.GenerateJobURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAdminContext(ctx) {
+		dbReq.Url = urls.GenerateJobURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list jobs in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var jobs = []*model.JobSpec{}
+	for i, msg := range dbRes.Message {
+		var job = &model.JobSpec{}
+		if err := json.Unmarshal([]byte(msg), job); err != nil {
+			log.Error("When parsing job in db:", err)
+			return nil, err
+		}
+		job.ResourceVersion = dbRes.revision(i)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+type JobCompareFunc func(a *model.JobSpec, b *model.JobSpec) bool
+
+var jobCompareFunc JobCompareFunc
+
+type JobSlice []*model.JobSpec
+
+func (j JobSlice) Len() int           { return len(j) }
+func (j JobSlice) Swap(i, k int)      { j[i], j[k] = j[k], j[i] }
+func (j JobSlice) Less(i, k int) bool { return jobCompareFunc(j[i], j[k]) }
+
+var jobSortKey2Func = map[string]JobCompareFunc{
+	"ID":         func(a *model.JobSpec, b *model.JobSpec) bool { return a.Id > b.Id },
+	"CREATEDAT":  func(a *model.JobSpec, b *model.JobSpec) bool { return a.CreatedAt > b.CreatedAt },
+	"TYPE":       func(a *model.JobSpec, b *model.JobSpec) bool { return a.Type > b.Type },
+	"STATUS":     func(a *model.JobSpec, b *model.JobSpec) bool { return a.Status > b.Status },
+	"TENANTID":   func(a *model.JobSpec, b *model.JobSpec) bool { return a.TenantId > b.TenantId },
+	"RESOURCEID": func(a *model.JobSpec, b *model.JobSpec) bool { return a.ResourceId > b.ResourceId },
+}
+
+// ListJobsWithFilter
+func (c *Client) ListJobsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.JobSpec, error) {
+	jobs, err := c.ListJobs(ctx)
+	if err != nil {
+		log.Error("List jobs failed: ", err)
+		return nil, err
+	}
+
+	var rlist = jobs
+	if c.SelectOrNot(m) {
+		filterList := map[string]interface{}{
+			"Id":         nil,
+			"CreatedAt":  nil,
+			"UpdatedAt":  nil,
+			"TenantId":   nil,
+			"UserId":     nil,
+			"Type":       nil,
+			"ResourceId": nil,
+			"Status":     nil,
+			"Host":       nil,
+		}
+		rlist = []*model.JobSpec{}
+		for _, job := range jobs {
+			if c.filterByName(m, job, filterList) {
+				rlist = append(rlist, job)
+			}
+		}
+	}
+
+	var sortKeys []string
+	for k := range jobSortKey2Func {
+		sortKeys = append(sortKeys, k)
+	}
+	p := c.ParameterFilter(m, len(rlist), sortKeys)
+	jobCompareFunc = jobSortKey2Func[p.sortKey]
+	if strings.EqualFold(p.sortDir, "asc") {
+		sort.Sort(JobSlice(rlist))
+	} else {
+		sort.Sort(sort.Reverse(JobSlice(rlist)))
+	}
+	return rlist[p.beginIdx:p.endIdx], nil
+}
+
+// UpdateJob replaces the job as a whole. If the resource version of job is
+// specified, the update fails with ConflictError when the job has been
+// modified since that version.
+func (c *Client) UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	job.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	jobBody, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Url:        urls.GenerateJobURL(urls.Etcd, job.TenantId, job.Id),
+		NewContent: string(jobBody),
+		Revision:   job.ResourceVersion,
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update job in db:", dbRes.Error)
+		return nil, updateError(dbRes)
+	}
+	job.ResourceVersion = dbRes.revision(0)
+	return job, nil
+}
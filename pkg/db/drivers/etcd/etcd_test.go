@@ -21,9 +21,11 @@ defined in api module.
 package etcd
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/model"
@@ -92,12 +94,36 @@ func (*fakeClientCaller) List(req *Request) *Response {
 	if strings.Contains(req.Url, "replications") {
 		resp = StringSliceReplications
 	}
+	if strings.Contains(req.Url, "quotas") {
+		resp = StringSliceQuotas
+	}
+	if strings.Contains(req.Url, "events") {
+		resp = StringSliceEvents
+	}
 	return &Response{
 		Status:  "Success",
 		Message: resp,
 	}
 }
 
+func (f *fakeClientCaller) ListPage(req *Request) *Response {
+	resp := f.List(req)
+	resp.Count = int64(len(resp.Message))
+	return resp
+}
+
+func (*fakeClientCaller) Watch(req *Request) *Response {
+	var resp []string
+	if strings.Contains(req.Url, "events") {
+		resp = StringSliceEvents
+	}
+	return &Response{
+		Status:    "Success",
+		Message:   resp,
+		Revisions: []int64{req.Revision + 1, req.Revision + 2},
+	}
+}
+
 func (*fakeClientCaller) Update(req *Request) *Response {
 	return &Response{
 		Status: "Success",
@@ -110,6 +136,12 @@ func (*fakeClientCaller) Delete(req *Request) *Response {
 	}
 }
 
+func (*fakeClientCaller) CompareAndSwap(req *Request) *Response {
+	return &Response{
+		Status: "Success",
+	}
+}
+
 var fc = &Client{
 	clientInterface: &fakeClientCaller{},
 }
@@ -613,3 +645,577 @@ func TestExtendVolume(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v\n", 9, result.Size)
 	}
 }
+
+func TestGetQuota(t *testing.T) {
+	quota, err := fc.GetQuota(c.NewAdminContext(), "ef305038-cd12-4f3b-90bd-0612f83e14ee")
+	if err != nil {
+		t.Error("Get quota failed:", err)
+	}
+
+	var expected = &SampleQuotas[0]
+	if !reflect.DeepEqual(quota, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, quota)
+	}
+
+	// The quota of tenant which has never been set is unlimited.
+	quota, err = fc.GetQuota(c.NewAdminContext(), "ef305038")
+	if err != nil {
+		t.Error("Get quota failed:", err)
+	}
+	if expected = model.NewDefaultQuota("ef305038"); !reflect.DeepEqual(quota, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, quota)
+	}
+}
+
+func TestReserveQuota(t *testing.T) {
+	var tenantId = "ef305038-cd12-4f3b-90bd-0612f83e14ee"
+	if err := fc.ReserveQuota(c.NewAdminContext(), tenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 97}); err != nil {
+		t.Error("Reserve quota failed:", err)
+	}
+
+	err := fc.ReserveQuota(c.NewAdminContext(), tenantId, &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 98})
+	if _, ok := err.(*model.QuotaExceededError); !ok {
+		t.Errorf("Expected QuotaExceededError, got %v\n", err)
+	}
+}
+
+func TestReleaseQuota(t *testing.T) {
+	if err := fc.ReleaseQuota(c.NewAdminContext(), "ef305038-cd12-4f3b-90bd-0612f83e14ee", &model.QuotaUsageSpec{Volumes: 1}); err != nil {
+		t.Error("Release quota failed:", err)
+	}
+}
+
+// revisionClientCaller stores every key at revision 5, and only accepts
+// updates based on that revision.
+type revisionClientCaller struct {
+	fakeClientCaller
+}
+
+func (r *revisionClientCaller) Get(req *Request) *Response {
+	resp := r.fakeClientCaller.Get(req)
+	resp.Revisions = []int64{5}
+	return resp
+}
+
+func (*revisionClientCaller) Update(req *Request) *Response {
+	if req.Revision != 5 {
+		return &Response{
+			Status: "Conflict",
+			Error:  "The resource has been modified by others!",
+		}
+	}
+	return &Response{
+		Status:    "Success",
+		Message:   []string{req.NewContent},
+		Revisions: []int64{6},
+	}
+}
+
+var rc = &Client{
+	clientInterface: &revisionClientCaller{},
+}
+
+func TestUpdateVolumeWithRevision(t *testing.T) {
+	var vol = model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		},
+		Name: "Test Name",
+	}
+
+	result, err := rc.UpdateVolume(c.NewAdminContext(), &vol)
+	if err != nil {
+		t.Error("Update volume failed:", err)
+	}
+	if result.ResourceVersion != 6 {
+		t.Errorf("Expected resource version %d, got %d\n", 6, result.ResourceVersion)
+	}
+
+	vol.ResourceVersion = 5
+	if _, err := rc.UpdateVolume(c.NewAdminContext(), &vol); err != nil {
+		t.Error("Update volume with current version failed:", err)
+	}
+
+	vol.ResourceVersion = 4
+	_, err = rc.UpdateVolume(c.NewAdminContext(), &vol)
+	if _, ok := err.(*model.ConflictError); !ok {
+		t.Errorf("Expected ConflictError, got %v\n", err)
+	}
+}
+
+func TestUpdateStatusIgnoresStaleVersion(t *testing.T) {
+	var vol = model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id:              "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			ResourceVersion: 4,
+		},
+	}
+
+	if err := rc.UpdateStatus(c.NewAdminContext(), &vol, model.VolumeAvailable); err != nil {
+		t.Error("Update volume status failed:", err)
+	}
+}
+
+// renameClientCaller keeps one volume, which is renamed by others right before
+// the first update of it.
+type renameClientCaller struct {
+	fakeClientCaller
+	content  string
+	revision int64
+	updates  int
+}
+
+func (r *renameClientCaller) Get(req *Request) *Response {
+	return &Response{
+		Status:    "Success",
+		Message:   []string{r.content},
+		Revisions: []int64{r.revision},
+	}
+}
+
+func (r *renameClientCaller) Update(req *Request) *Response {
+	if r.updates++; r.updates == 1 {
+		r.content = strings.Replace(r.content, "sample-volume", "renamed-volume", 1)
+		r.revision++
+	}
+	if req.Revision != r.revision {
+		return &Response{
+			Status: "Conflict",
+			Error:  "The resource has been modified by others!",
+		}
+	}
+	r.content, r.revision = req.NewContent, r.revision+1
+	return &Response{
+		Status:    "Success",
+		Message:   []string{req.NewContent},
+		Revisions: []int64{r.revision},
+	}
+}
+
+func TestUpdateStatusKeepsConcurrentChanges(t *testing.T) {
+	var rn = &renameClientCaller{content: StringSliceVolumes[0], revision: 5}
+	var rnc = &Client{clientInterface: rn}
+	var vol = model.VolumeSpec{
+		BaseModel: &model.BaseModel{
+			Id:              "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			ResourceVersion: 5,
+		},
+		Name:     "sample-volume",
+		PoolId:   "a594b8ac-a103-11e7-985f-d723bcf01b5f",
+		Metadata: map[string]string{"lvPath": "/dev/vg001/volume-01"},
+	}
+
+	if err := rnc.UpdateStatus(c.NewAdminContext(), &vol, model.VolumeAvailable); err != nil {
+		t.Error("Update volume status failed:", err)
+	}
+	if rn.updates != 2 {
+		t.Errorf("Expected %d updates, got %d\n", 2, rn.updates)
+	}
+	var result = &model.VolumeSpec{}
+	json.Unmarshal([]byte(rn.content), result)
+	if result.Name != "renamed-volume" || result.Status != model.VolumeAvailable ||
+		result.PoolId != vol.PoolId || result.Metadata["lvPath"] != "/dev/vg001/volume-01" {
+		t.Errorf("Unexpected volume %+v\n", result)
+	}
+	if vol.ResourceVersion != 7 {
+		t.Errorf("Expected resource version %d, got %d\n", 7, vol.ResourceVersion)
+	}
+}
+
+// pageClientCaller stores two volume keys, and returns one of them in each page.
+type pageClientCaller struct {
+	fakeClientCaller
+	lastReq *Request
+}
+
+func (p *pageClientCaller) ListPage(req *Request) *Response {
+	p.lastReq = req
+	var keys = []string{
+		req.Url + "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		req.Url + "3769855c-a102-11e7-b772-17b880d2f537",
+	}
+	var idx = 0
+	if req.Marker == keys[0] {
+		idx = 1
+	}
+	return &Response{
+		Status:    "Success",
+		Message:   []string{StringSliceVolumes[0]},
+		Revisions: []int64{5},
+		Keys:      []string{keys[idx]},
+		Count:     2,
+		More:      idx == 0,
+	}
+}
+
+func TestListVolumesWithPage(t *testing.T) {
+	var pc = &pageClientCaller{}
+	var pfc = &Client{clientInterface: pc}
+
+	m := map[string][]string{"limit": {"1"}}
+	vols, page, err := pfc.ListVolumesWithPage(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List volumes with page failed:", err)
+	}
+	if len(vols) != 1 || vols[0].Id != SampleVolumes[0].Id {
+		t.Errorf("Expected volume %s, got %+v\n", SampleVolumes[0].Id, vols)
+	}
+	if page.Total != 2 || page.Next == "" {
+		t.Errorf("Unexpected page: %+v\n", page)
+	}
+	if pc.lastReq.Url != "v1beta/block/volumes/" || pc.lastReq.Limit != 1 || !pc.lastReq.Descend {
+		t.Errorf("Unexpected page request: %+v\n", pc.lastReq)
+	}
+
+	m["marker"] = []string{page.Next}
+	vols, page, err = pfc.ListVolumesWithPage(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List volumes with marker failed:", err)
+	}
+	if pc.lastReq.Marker != "v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8" {
+		t.Errorf("Unexpected page request: %+v\n", pc.lastReq)
+	}
+	if len(vols) != 1 || page.Next != "" {
+		t.Errorf("Expected the last page, got %+v\n", page)
+	}
+}
+
+func TestListVolumesWithPageOutOfTenant(t *testing.T) {
+	var pfc = &Client{clientInterface: &pageClientCaller{}}
+	marker := (&pageToken{Key: "v1beta/block/volumes/other/bd5b12a8-a101-11e7-941e-d77981b584d8"}).encode()
+
+	m := map[string][]string{"marker": {marker}}
+	if _, _, err := pfc.ListVolumesWithPage(&c.Context{TenantId: "tenant"}, m); err == nil {
+		t.Error("Expected error when marker belongs to another tenant")
+	}
+	m["marker"] = []string{"invalid marker"}
+	if _, _, err := pfc.ListVolumesWithPage(c.NewAdminContext(), m); err == nil {
+		t.Error("Expected error when marker is invalid")
+	}
+}
+
+func TestListSharedResourcesWithPage(t *testing.T) {
+	var pc = &pageClientCaller{}
+	var pfc = &Client{clientInterface: pc}
+	var ctx = &c.Context{TenantId: "tenant"}
+
+	m := map[string][]string{"limit": {"1"}}
+	// Pools are shared by all the tenants.
+	pols, page, err := pfc.ListPoolsWithPage(ctx, m)
+	if err != nil {
+		t.Error("List pools with page failed:", err)
+	}
+	if len(pols) != 1 || page.Total != 2 || page.Next == "" {
+		t.Errorf("Unexpected page %+v with pools %+v\n", page, pols)
+	}
+	if pc.lastReq.Url != "v1beta/pools/" {
+		t.Errorf("Unexpected page request: %+v\n", pc.lastReq)
+	}
+
+	// File shares are listed in the tenant.
+	if _, _, err := pfc.ListFileSharesWithPage(ctx, m); err != nil {
+		t.Error("List file shares with page failed:", err)
+	}
+	if pc.lastReq.Url != "v1beta/file/shares/tenant/" {
+		t.Errorf("Unexpected page request: %+v\n", pc.lastReq)
+	}
+}
+
+func TestListVolumeSnapshotsWithPageAndSort(t *testing.T) {
+	m := map[string][]string{
+		"sortKey": {"name"},
+		"sortDir": {"asc"},
+		"limit":   {"1"},
+	}
+	snps, page, err := fc.ListVolumeSnapshotsWithPage(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List volume snapshots with page failed:", err)
+	}
+	if len(snps) != 1 || page.Total != 2 || page.Next == "" {
+		t.Errorf("Unexpected page %+v with snapshots %+v\n", page, snps)
+	}
+
+	m["marker"] = []string{page.Next}
+	next, page, err := fc.ListVolumeSnapshotsWithPage(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List volume snapshots with marker failed:", err)
+	}
+	if len(next) != 1 || next[0].Id == snps[0].Id || page.Next != "" {
+		t.Errorf("Unexpected page %+v with snapshots %+v\n", page, next)
+	}
+}
+
+func TestListEventsWithFilter(t *testing.T) {
+	m := map[string][]string{"resourceType": {"snapshot"}}
+	evts, err := fc.ListEventsWithFilter(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List events failed:", err)
+	}
+	if len(evts) != 1 || evts[0].ResourceId != "3769855c-a102-11e7-b772-17b880d2f537" {
+		t.Errorf("Unexpected events %+v\n", evts)
+	}
+
+	m["since"] = []string{"invalid"}
+	if _, err := fc.ListEventsWithFilter(c.NewAdminContext(), m); err == nil {
+		t.Error("Expected error when since is invalid")
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	m := map[string][]string{
+		"resourceId": {"3769855c-a102-11e7-b772-17b880d2f537"},
+		"since":      {"10"},
+	}
+	evts, err := fc.WatchEvents(c.NewAdminContext(), m, time.Second)
+	if err != nil {
+		t.Error("Watch events failed:", err)
+	}
+	if len(evts) != 1 || evts[0].ResourceVersion != 12 || evts[0].NewStatus != "available" {
+		t.Errorf("Unexpected events %+v\n", evts)
+	}
+}
+
+// eventClientCaller records the update requests.
+type eventClientCaller struct {
+	fakeClientCaller
+	updates []*Request
+}
+
+func (e *eventClientCaller) Update(req *Request) *Response {
+	e.updates = append(e.updates, req)
+	return e.fakeClientCaller.Update(req)
+}
+
+func TestUpdateVolumeRecordsEvent(t *testing.T) {
+	var ec = &eventClientCaller{}
+	var efc = &Client{clientInterface: ec}
+
+	var vol = model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Name:      "Test Name",
+	}
+	if _, err := efc.UpdateVolume(c.NewAdminContext(), &vol); err != nil {
+		t.Error("Update volume failed:", err)
+	}
+	vol.Status = model.VolumeDeleting
+	if _, err := efc.UpdateVolume(c.NewAdminContext(), &vol); err != nil {
+		t.Error("Update volume failed:", err)
+	}
+
+	if len(ec.updates) != 2 || len(ec.updates[0].Events) != 0 || len(ec.updates[1].Events) != 1 {
+		t.Fatalf("Expected an event only when status changes, got %+v\n", ec.updates)
+	}
+	for k, v := range ec.updates[1].Events {
+		var evt = &model.EventSpec{}
+		if err := json.Unmarshal([]byte(v), evt); err != nil {
+			t.Error("Parse event failed:", err)
+		}
+		if !strings.HasPrefix(k, "v1beta/events/") || evt.OldStatus != "available" ||
+			evt.NewStatus != model.VolumeDeleting || evt.ResourceType != model.EventResourceVolume {
+			t.Errorf("Unexpected event %s: %+v\n", k, evt)
+		}
+	}
+}
+
+// heartbeatClientCaller records the create requests, and lists the
+// heartbeats which have been put.
+type heartbeatClientCaller struct {
+	eventClientCaller
+	creates []*Request
+}
+
+func (h *heartbeatClientCaller) Create(req *Request) *Response {
+	h.creates = append(h.creates, req)
+	return h.eventClientCaller.Create(req)
+}
+
+func (h *heartbeatClientCaller) List(req *Request) *Response {
+	if !strings.Contains(req.Url, "heartbeats") {
+		return h.eventClientCaller.List(req)
+	}
+	var resp = []string{}
+	for _, r := range h.creates {
+		resp = append(resp, r.Content)
+	}
+	return &Response{
+		Status:  "Success",
+		Message: resp,
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	var hc = &heartbeatClientCaller{}
+	var hfc = &Client{clientInterface: hc}
+
+	if err := hfc.Heartbeat(c.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0", 30*time.Second); err != nil {
+		t.Error("Put heartbeat failed:", err)
+	}
+	if len(hc.creates) != 1 || hc.creates[0].TTL != 30*time.Second ||
+		hc.creates[0].Url != "v1beta/heartbeats/b7602e18-771e-11e7-8f38-dbd6d291f4e0" {
+		t.Fatalf("Unexpected create requests %+v\n", hc.creates)
+	}
+
+	ids, err := hfc.ListHeartbeats(c.NewAdminContext())
+	if err != nil {
+		t.Error("List heartbeats failed:", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"b7602e18-771e-11e7-8f38-dbd6d291f4e0"}) {
+		t.Errorf("Unexpected heartbeats %v\n", ids)
+	}
+}
+
+func TestUpdateDockAndPoolStatus(t *testing.T) {
+	var ec = &eventClientCaller{}
+	var efc = &Client{clientInterface: ec}
+
+	var dck = &model.DockSpec{BaseModel: &model.BaseModel{Id: "b7602e18-771e-11e7-8f38-dbd6d291f4e0"}}
+	if err := efc.UpdateStatus(c.NewAdminContext(), dck, model.DockUnavailable); err != nil {
+		t.Error("Update dock status failed:", err)
+	}
+	var pol = &model.StoragePoolSpec{BaseModel: &model.BaseModel{Id: "084bf71e-a102-11e7-88a8-e31fe6d52248"}}
+	if err := efc.UpdateStatus(c.NewAdminContext(), pol, model.PoolUnavailable); err != nil {
+		t.Error("Update pool status failed:", err)
+	}
+
+	if len(ec.updates) != 2 {
+		t.Fatalf("Expected 2 update requests, got %+v\n", ec.updates)
+	}
+	var dckRes = &model.DockSpec{}
+	json.Unmarshal([]byte(ec.updates[0].NewContent), dckRes)
+	if dckRes.Status != model.DockUnavailable || dckRes.Name != "sample" || dck.Status != model.DockUnavailable {
+		t.Errorf("Unexpected dock %+v\n", dckRes)
+	}
+	var polRes = &model.StoragePoolSpec{}
+	json.Unmarshal([]byte(ec.updates[1].NewContent), polRes)
+	if polRes.Status != model.PoolUnavailable || polRes.Name != "sample-pool-01" || pol.Status != model.PoolUnavailable {
+		t.Errorf("Unexpected pool %+v\n", polRes)
+	}
+}
+
+// lockClientCaller keeps the content of keys, and swaps them as etcd does.
+type lockClientCaller struct {
+	fakeClientCaller
+	keys map[string]string
+	ttls []time.Duration
+}
+
+func (l *lockClientCaller) CompareAndSwap(req *Request) *Response {
+	l.ttls = append(l.ttls, req.TTL)
+	if l.keys[req.Url] != req.Content {
+		return &Response{
+			Status: "Conflict",
+			Error:  "The resource has been modified by others!",
+		}
+	}
+	l.keys[req.Url] = req.NewContent
+	return &Response{
+		Status: "Success",
+	}
+}
+
+func TestAcquireLock(t *testing.T) {
+	var lc = &lockClientCaller{keys: map[string]string{}}
+	var lfc = &Client{clientInterface: lc}
+
+	for i, tc := range []struct {
+		holder   string
+		expected bool
+	}{
+		{"osdslet-1", true},
+		{"osdslet-1", true},
+		{"osdslet-2", false},
+	} {
+		ok, err := lfc.AcquireLock(c.NewAdminContext(), "snapshot-scheduler", tc.holder, time.Minute)
+		if err != nil {
+			t.Fatal("Acquire lock failed:", err)
+		}
+		if ok != tc.expected {
+			t.Errorf("Case %d: expected %v, got %v\n", i, tc.expected, ok)
+		}
+	}
+	if lc.keys["v1beta/locks/snapshot-scheduler"] != "osdslet-1" {
+		t.Errorf("Unexpected locks %v\n", lc.keys)
+	}
+	for _, ttl := range lc.ttls {
+		if ttl != time.Minute {
+			t.Errorf("Unexpected ttl %v\n", ttl)
+		}
+	}
+}
+
+func TestUpdateSnapshotSchedule(t *testing.T) {
+	var ec = &eventClientCaller{}
+	var efc = &Client{clientInterface: ec}
+
+	var sched = &model.SnapshotScheduleSpec{
+		BaseModel: &model.BaseModel{
+			Id:              "6e9ec0f8-52b5-4ebd-9a6c-1b3b1fe5ff06",
+			ResourceVersion: 5,
+		},
+		VolumeId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Occurrence: "Daily",
+		NextRunAt:  "2018-09-15T15:53:00Z",
+	}
+	if _, err := efc.UpdateSnapshotSchedule(c.NewAdminContext(), sched); err != nil {
+		t.Fatal("Update snapshot schedule failed:", err)
+	}
+	if len(ec.updates) != 1 || ec.updates[0].Revision != 5 ||
+		ec.updates[0].Url != "v1beta/block/snapshotSchedules/6e9ec0f8-52b5-4ebd-9a6c-1b3b1fe5ff06" {
+		t.Fatalf("Unexpected update requests %+v\n", ec.updates)
+	}
+	var result = &model.SnapshotScheduleSpec{}
+	json.Unmarshal([]byte(ec.updates[0].NewContent), result)
+	if result.NextRunAt != sched.NextRunAt || result.UpdatedAt == "" {
+		t.Errorf("Unexpected snapshot schedule %+v\n", result)
+	}
+}
+
+// jobClientCaller lists the jobs which have been created.
+type jobClientCaller struct {
+	heartbeatClientCaller
+}
+
+func (j *jobClientCaller) List(req *Request) *Response {
+	var resp = []string{}
+	for _, r := range j.creates {
+		if strings.HasPrefix(r.Url, req.Url) {
+			resp = append(resp, r.Content)
+		}
+	}
+	return &Response{Status: "Success", Message: resp}
+}
+
+func TestJobs(t *testing.T) {
+	var jc = &jobClientCaller{}
+	var jfc = &Client{clientInterface: jc}
+	var ctx = c.NewInternalTenantContext("tenant", "user")
+
+	for _, job := range []*model.JobSpec{
+		{BaseModel: &model.BaseModel{Id: "job-1"}, Type: model.JobCreateVolume, Status: model.JobSucceeded},
+		{BaseModel: &model.BaseModel{Id: "job-2"}, Type: model.JobDeleteVolume, Status: model.JobPending},
+	} {
+		if _, err := jfc.CreateJob(ctx, job); err != nil {
+			t.Fatal("Create job failed:", err)
+		}
+	}
+	if jc.creates[0].Url != "v1beta/jobs/tenant/job-1" {
+		t.Errorf("Unexpected create request %+v\n", jc.creates[0])
+	}
+
+	jobs, err := jfc.ListJobsWithFilter(ctx, map[string][]string{"Status": {model.JobPending}})
+	if err != nil {
+		t.Fatal("List jobs failed:", err)
+	}
+	if len(jobs) != 1 || jobs[0].Id != "job-2" || jobs[0].TenantId != "tenant" {
+		t.Fatalf("Unexpected jobs %+v\n", jobs)
+	}
+
+	jobs[0].Status, jobs[0].ResourceVersion = model.JobRunning, 3
+	if _, err = jfc.UpdateJob(ctx, jobs[0]); err != nil {
+		t.Fatal("Update job failed:", err)
+	}
+	if len(jc.updates) != 1 || jc.updates[0].Revision != 3 ||
+		jc.updates[0].Url != "v1beta/jobs/tenant/job-2" {
+		t.Fatalf("Unexpected update requests %+v\n", jc.updates)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the events of resource status transitions in etcd.
+
+*/
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/opensds/opensds/pkg/utils/urls"
+	"github.com/satori/go.uuid"
+)
+
+// statusEvents returns the keys and values of events which are put along with
+// the update of resource, or nil if there is no event.
+func statusEvents(evts ...*model.EventSpec) map[string]string {
+	var kvs map[string]string
+	for _, evt := range evts {
+		if evt == nil {
+			continue
+		}
+		evt.Id = uuid.NewV4().String()
+		evt.CreatedAt = time.Now().Format(constants.TimeFormat)
+		body, err := json.Marshal(evt)
+		if err != nil {
+			log.Error("When marshal event:", err)
+			continue
+		}
+		if kvs == nil {
+			kvs = map[string]string{}
+		}
+		kvs[urls.GenerateEventURL(urls.Etcd, evt.TenantId, evt.Id)] = string(body)
+	}
+	return kvs
+}
+
+// eventPrefix returns the prefix of events visible in the context.
+func eventPrefix(ctx *c.Context) string {
+	// Admin user should get all events including the events whose tenant is not admin.
+	if IsAdminContext(ctx) {
+		return urls.GenerateEventURL(urls.Etcd, "") + "/"
+	}
+	return urls.GenerateEventURL(urls.Etcd, ctx.TenantId) + "/"
+}
+
+// parseSince returns the resource version after which the events are
+// selected, zero means all the events.
+func parseSince(m map[string][]string) (int64, error) {
+	v, ok := m["since"]
+	if !ok || len(v) == 0 || v[0] == "" {
+		return 0, nil
+	}
+	since, err := strconv.ParseInt(v[0], 10, 64)
+	if err != nil || since < 0 {
+		return 0, fmt.Errorf("invalid since: %s", v[0])
+	}
+	return since, nil
+}
+
+// selectEvents selects the events which happen after since and match the
+// resourceType, resourceId and newStatus parameters, in the order in which
+// they happen.
+func selectEvents(m map[string][]string, since int64, evts []*model.EventSpec) []*model.EventSpec {
+	match := func(key, value string) bool {
+		v, ok := m[key]
+		return !ok || len(v) == 0 || v[0] == "" || v[0] == value
+	}
+
+	var selected = []*model.EventSpec{}
+	for _, evt := range evts {
+		if (since == 0 || evt.ResourceVersion > since) && match("resourceType", evt.ResourceType) &&
+			match("resourceId", evt.ResourceId) && match("newStatus", evt.NewStatus) {
+			selected = append(selected, evt)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].ResourceVersion < selected[j].ResourceVersion
+	})
+	return selected
+}
+
+func parseEvents(dbRes *Response) ([]*model.EventSpec, error) {
+	var evts = []*model.EventSpec{}
+	for i, msg := range dbRes.Message {
+		var evt = &model.EventSpec{}
+		if err := json.Unmarshal([]byte(msg), evt); err != nil {
+			log.Error("When parsing event in db:", err)
+			return nil, err
+		}
+		evt.ResourceVersion = dbRes.revision(i)
+		evts = append(evts, evt)
+	}
+	return evts, nil
+}
+
+// ListEventsWithFilter
+func (c *Client) ListEventsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.EventSpec, error) {
+	since, err := parseSince(m)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRes := c.List(&Request{Url: eventPrefix(ctx)})
+	if dbRes.Status != "Success" {
+		log.Error("When list events in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	evts, err := parseEvents(dbRes)
+	if err != nil {
+		return nil, err
+	}
+	return selectEvents(m, since, evts), nil
+}
+
+// WatchEvents waits for the events which happen after since and match the
+// filter. The selected events are returned as soon as there is any of them,
+// and an empty list is returned if none happens before timeout.
+func (c *Client) WatchEvents(ctx *c.Context, m map[string][]string, timeout time.Duration) ([]*model.EventSpec, error) {
+	since, err := parseSince(m)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return []*model.EventSpec{}, nil
+		}
+		dbRes := c.Watch(&Request{
+			Url:      eventPrefix(ctx),
+			Revision: since,
+			Timeout:  remaining,
+		})
+		if dbRes.Status != "Success" {
+			log.Error("When watch events in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		evts, err := parseEvents(dbRes)
+		if err != nil {
+			return nil, err
+		}
+		if selected := selectEvents(m, since, evts); len(selected) > 0 {
+			return selected, nil
+		}
+		// None of the events matches the filter, continue watching the
+		// events after them.
+		for _, evt := range evts {
+			if evt.ResourceVersion > since {
+				since = evt.ResourceVersion
+			}
+		}
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the paginated list of resources in etcd.
+
+*/
+
+package etcd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils/urls"
+)
+
+// pageToken is the content of continuation token. A page read from etcd
+// directly continues from the key of its last resource, and a page located in
+// the filtered and sorted resources continues from its offset.
+type pageToken struct {
+	Key    string `json:"key,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+}
+
+func (t *pageToken) encode() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(s string) (*pageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid marker: %s", s)
+	}
+	var t = &pageToken{}
+	if err = json.Unmarshal(b, t); err != nil || t.Offset < 0 {
+		return nil, fmt.Errorf("invalid marker: %s", s)
+	}
+	return t, nil
+}
+
+// pageQuery is the paging parameters of list request.
+type pageQuery struct {
+	// Zero limit means that all the remaining resources are returned.
+	limit int
+	// The page is read from etcd directly in the order of keys if the
+	// resources are neither filtered nor sorted by other keys than id.
+	rangeRead bool
+	descend   bool
+	marker    string
+	offset    int
+}
+
+// parsePageQuery parses the paging parameters of list request, and returns
+// the parameters left for filtering and sorting.
+func (c *Client) parsePageQuery(m map[string][]string) (*pageQuery, map[string][]string, error) {
+	var q = &pageQuery{}
+	var rest = map[string][]string{}
+	for k, v := range m {
+		if k != "limit" && k != "offset" && k != "marker" {
+			rest[k] = v
+		}
+	}
+
+	if v, ok := m["limit"]; ok && len(v) > 0 {
+		limit, err := strconv.Atoi(v[0])
+		if err != nil || limit < 0 {
+			log.Warning("Invalid input limit:", v[0], ",return all the items instead")
+		} else {
+			q.limit = limit
+		}
+	}
+	if v, ok := m["offset"]; ok && len(v) > 0 {
+		offset, err := strconv.Atoi(v[0])
+		if err != nil || offset < 0 {
+			log.Warning("Invalid input offset:", v[0], ",use default value instead:0")
+		} else {
+			q.offset = offset
+		}
+	}
+
+	sortById := true
+	if v, ok := rest["sortKey"]; ok && len(v) > 0 {
+		sortById = strings.EqualFold(v[0], defaultSortKey)
+	}
+	q.descend = !strings.EqualFold(c.GetSortDir(rest), "asc")
+	q.rangeRead = !c.SelectOrNot(rest) && sortById && q.offset == 0
+
+	if v, ok := m["marker"]; ok && len(v) > 0 && v[0] != "" {
+		t, err := decodePageToken(v[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case t.Key != "":
+			if !q.rangeRead {
+				return nil, nil, fmt.Errorf("marker %s doesn't match the filter of request", v[0])
+			}
+			q.marker = t.Key
+		case t.Offset > 0:
+			q.offset, q.rangeRead = t.Offset, false
+		}
+	}
+	return q, rest, nil
+}
+
+// readPage reads a page of resources under the prefix from etcd directly.
+func (c *Client) readPage(prefix string, q *pageQuery) (*Response, *model.PageSpec, error) {
+	// Add the separator so that the keys of other tenants which share the
+	// same prefix are excluded.
+	prefix += "/"
+	if q.marker != "" && !strings.HasPrefix(q.marker, prefix) {
+		return nil, nil, fmt.Errorf("invalid marker: %s", q.marker)
+	}
+
+	dbRes := c.ListPage(&Request{
+		Url:     prefix,
+		Marker:  q.marker,
+		Limit:   int64(q.limit),
+		Descend: q.descend,
+	})
+	if dbRes.Status != "Success" {
+		return nil, nil, errors.New(dbRes.Error)
+	}
+
+	var page = &model.PageSpec{Total: int(dbRes.Count)}
+	if dbRes.More && len(dbRes.Keys) > 0 {
+		page.Next = (&pageToken{Key: dbRes.Keys[len(dbRes.Keys)-1]}).encode()
+	}
+	return dbRes, page, nil
+}
+
+// slicePage locates the page in the filtered and sorted resources, and
+// returns the range of page in them.
+func (q *pageQuery) slicePage(total int) (int, int, *model.PageSpec) {
+	var page = &model.PageSpec{Total: total}
+	begin := q.offset
+	if begin > total {
+		begin = total
+	}
+	end := total
+	if q.limit > 0 && begin+q.limit < total {
+		end = begin + q.limit
+		page.Next = (&pageToken{Offset: end}).encode()
+	}
+	return begin, end, page
+}
+
+// ListVolumesWithPage
+func (c *Client) ListVolumesWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		vols, err := c.ListVolumesWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(vols))
+		return vols[begin:end], page, nil
+	}
+
+	prefix := urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId)
+	// Admin user should get all volumes including the volumes whose tenant is not admin.
+	if IsAdminContext(ctx) {
+		prefix = urls.GenerateVolumeURL(urls.Etcd, "")
+	}
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list volumes in db:", err)
+		return nil, nil, err
+	}
+
+	var vols = []*model.VolumeSpec{}
+	for i, msg := range dbRes.Message {
+		var vol = &model.VolumeSpec{}
+		if err := json.Unmarshal([]byte(msg), vol); err != nil {
+			log.Error("When parsing volume in db:", err)
+			return nil, nil, err
+		}
+		vol.ResourceVersion = dbRes.revision(i)
+		vols = append(vols, vol)
+	}
+	return vols, page, nil
+}
+
+// ListVolumeSnapshotsWithPage
+func (c *Client) ListVolumeSnapshotsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		snps, err := c.ListVolumeSnapshotsWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(snps))
+		return snps[begin:end], page, nil
+	}
+
+	prefix := urls.GenerateSnapshotURL(urls.Etcd, ctx.TenantId)
+	if IsAdminContext(ctx) {
+		prefix = urls.GenerateSnapshotURL(urls.Etcd, "")
+	}
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list volume snapshots in db:", err)
+		return nil, nil, err
+	}
+
+	var snps = []*model.VolumeSnapshotSpec{}
+	for i, msg := range dbRes.Message {
+		var snp = &model.VolumeSnapshotSpec{}
+		if err := json.Unmarshal([]byte(msg), snp); err != nil {
+			log.Error("When parsing volume snapshot in db:", err)
+			return nil, nil, err
+		}
+		snp.ResourceVersion = dbRes.revision(i)
+		snps = append(snps, snp)
+	}
+	return snps, page, nil
+}
+
+// ListDocksWithPage
+func (c *Client) ListDocksWithPage(ctx *c.Context, m map[string][]string) ([]*model.DockSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		dcks, err := c.ListDocksWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(dcks))
+		return dcks[begin:end], page, nil
+	}
+
+	// Docks are shared by all the tenants.
+	prefix := urls.GenerateDockURL(urls.Etcd, "")
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list docks in db:", err)
+		return nil, nil, err
+	}
+
+	var dcks = []*model.DockSpec{}
+	for i, msg := range dbRes.Message {
+		var dck = &model.DockSpec{}
+		if err := json.Unmarshal([]byte(msg), dck); err != nil {
+			log.Error("When parsing dock in db:", err)
+			return nil, nil, err
+		}
+		dck.ResourceVersion = dbRes.revision(i)
+		dcks = append(dcks, dck)
+	}
+	return dcks, page, nil
+}
+
+// ListPoolsWithPage
+func (c *Client) ListPoolsWithPage(ctx *c.Context, m map[string][]string) ([]*model.StoragePoolSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		pols, err := c.ListPoolsWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(pols))
+		return pols[begin:end], page, nil
+	}
+
+	// Pools are shared by all the tenants.
+	prefix := urls.GeneratePoolURL(urls.Etcd, "")
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list pools in db:", err)
+		return nil, nil, err
+	}
+
+	var pols = []*model.StoragePoolSpec{}
+	for i, msg := range dbRes.Message {
+		var pol = &model.StoragePoolSpec{}
+		if err := json.Unmarshal([]byte(msg), pol); err != nil {
+			log.Error("When parsing pool in db:", err)
+			return nil, nil, err
+		}
+		pol.ResourceVersion = dbRes.revision(i)
+		pols = append(pols, pol)
+	}
+	return pols, page, nil
+}
+
+// ListProfilesWithPage
+func (c *Client) ListProfilesWithPage(ctx *c.Context, m map[string][]string) ([]*model.ProfileSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		prfs, err := c.ListProfilesWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(prfs))
+		return prfs[begin:end], page, nil
+	}
+
+	// Profiles are shared by all the tenants.
+	prefix := urls.GenerateProfileURL(urls.Etcd, "")
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list profiles in db:", err)
+		return nil, nil, err
+	}
+
+	var prfs = []*model.ProfileSpec{}
+	for i, msg := range dbRes.Message {
+		var prf = &model.ProfileSpec{}
+		if err := json.Unmarshal([]byte(msg), prf); err != nil {
+			log.Error("When parsing profile in db:", err)
+			return nil, nil, err
+		}
+		prf.ResourceVersion = dbRes.revision(i)
+		prfs = append(prfs, prf)
+	}
+	return prfs, page, nil
+}
+
+// ListReplicationWithPage
+func (c *Client) ListReplicationWithPage(ctx *c.Context, m map[string][]string) ([]*model.ReplicationSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		replicas, err := c.ListReplicationWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(replicas))
+		return replicas[begin:end], page, nil
+	}
+
+	prefix := urls.GenerateReplicationURL(urls.Etcd, ctx.TenantId)
+	if IsAdminContext(ctx) {
+		prefix = urls.GenerateReplicationURL(urls.Etcd, "")
+	}
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list replications in db:", err)
+		return nil, nil, err
+	}
+
+	var replicas = []*model.ReplicationSpec{}
+	for i, msg := range dbRes.Message {
+		var r = &model.ReplicationSpec{}
+		if err := json.Unmarshal([]byte(msg), r); err != nil {
+			log.Error("When parsing replication in db:", err)
+			return nil, nil, err
+		}
+		r.ResourceVersion = dbRes.revision(i)
+		replicas = append(replicas, r)
+	}
+	return replicas, page, nil
+}
+
+// ListVolumeGroupsWithPage
+func (c *Client) ListVolumeGroupsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeGroupSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		groups, err := c.ListVolumeGroupsWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(groups))
+		return groups[begin:end], page, nil
+	}
+
+	prefix := urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId)
+	if IsAdminContext(ctx) {
+		prefix = urls.GenerateVolumeGroupURL(urls.Etcd, "")
+	}
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list volume groups in db:", err)
+		return nil, nil, err
+	}
+
+	var groups = []*model.VolumeGroupSpec{}
+	for i, msg := range dbRes.Message {
+		var group = &model.VolumeGroupSpec{}
+		if err := json.Unmarshal([]byte(msg), group); err != nil {
+			log.Error("When parsing volume group in db:", err)
+			return nil, nil, err
+		}
+		group.ResourceVersion = dbRes.revision(i)
+		groups = append(groups, group)
+	}
+	return groups, page, nil
+}
+
+// ListFileSharesWithPage
+func (c *Client) ListFileSharesWithPage(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, *model.PageSpec, error) {
+	q, rest, err := c.parsePageQuery(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !q.rangeRead {
+		fshares, err := c.ListFileSharesWithFilter(ctx, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		begin, end, page := q.slicePage(len(fshares))
+		return fshares[begin:end], page, nil
+	}
+
+	prefix := urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId)
+	if IsAdminContext(ctx) {
+		prefix = urls.GenerateFileShareURL(urls.Etcd, "")
+	}
+	dbRes, page, err := c.readPage(prefix, q)
+	if err != nil {
+		log.Error("When list file shares in db:", err)
+		return nil, nil, err
+	}
+
+	var fshares = []*model.FileShareSpec{}
+	for i, msg := range dbRes.Message {
+		var fshare = &model.FileShareSpec{}
+		if err := json.Unmarshal([]byte(msg), fshare); err != nil {
+			log.Error("When parsing file share in db:", err)
+			return nil, nil, err
+		}
+		fshare.ResourceVersion = dbRes.revision(i)
+		fshares = append(fshares, fshare)
+	}
+	return fshares, page, nil
+}
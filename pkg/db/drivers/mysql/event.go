@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the events of resource status transitions in mysql
+database.
+
+*/
+
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/satori/go.uuid"
+)
+
+// The interval of polling new events, since mysql can't notify the changes.
+var eventPollInterval = time.Second
+
+// The resource version of event is the auto increment seq column, so it isn't
+// one of the columns put by the table.
+var eventTable = newTable("events", "event", true,
+	"Id", "CreatedAt", "TenantId", "ResourceType", "ResourceId", "NewStatus")
+
+// recordEvent puts the event in the transaction which updates the resource,
+// nothing is done if there is no event.
+func recordEvent(q queryer, evt *model.EventSpec) error {
+	if evt == nil {
+		return nil
+	}
+	evt.Id = uuid.NewV4().String()
+	evt.CreatedAt = now()
+	return eventTable.insert(q, evt)
+}
+
+func parseSince(m map[string][]string) (int64, error) {
+	v, ok := m["since"]
+	if !ok || len(v) == 0 || v[0] == "" {
+		return 0, nil
+	}
+	since, err := strconv.ParseInt(v[0], 10, 64)
+	if err != nil || since < 0 {
+		return 0, fmt.Errorf("invalid since: %s", v[0])
+	}
+	return since, nil
+}
+
+// listEvents selects the events which happen after since and match the
+// filter, in the order in which they happen.
+func (c *Client) listEvents(ctx *c.Context, m map[string][]string, since int64) ([]*model.EventSpec, error) {
+	var params = map[string][]string{}
+	for k, v := range m {
+		if k == "resourceType" || k == "resourceId" || k == "newStatus" {
+			params[k] = v
+		}
+	}
+	f := eventTable.newFilter(ctx).withParameters(params).where("seq > ?", since)
+	f.order = "seq ASC"
+
+	query, args := f.build("SELECT seq, body FROM " + eventTable.name)
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		log.Error("When list events in db:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evts = []*model.EventSpec{}
+	for rows.Next() {
+		var seq int64
+		var body string
+		if err := rows.Scan(&seq, &body); err != nil {
+			return nil, err
+		}
+		var evt = &model.EventSpec{}
+		if err := json.Unmarshal([]byte(body), evt); err != nil {
+			log.Error("When parsing event in db:", err)
+			return nil, err
+		}
+		evt.ResourceVersion = seq
+		evts = append(evts, evt)
+	}
+	return evts, rows.Err()
+}
+
+// ListEventsWithFilter
+func (c *Client) ListEventsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.EventSpec, error) {
+	since, err := parseSince(m)
+	if err != nil {
+		return nil, err
+	}
+	return c.listEvents(ctx, m, since)
+}
+
+// WatchEvents polls the events which happen after since and match the
+// filter. The selected events are returned as soon as there is any of them,
+// and an empty list is returned if none happens before timeout.
+func (c *Client) WatchEvents(ctx *c.Context, m map[string][]string, timeout time.Duration) ([]*model.EventSpec, error) {
+	since, err := parseSince(m)
+	if err != nil {
+		return nil, err
+	}
+	// Zero means that only the events from now on are watched.
+	if since == 0 {
+		if err := c.db.QueryRow("SELECT COALESCE(MAX(seq), 0) FROM " + eventTable.name).Scan(&since); err != nil {
+			log.Error("When get latest event in db:", err)
+			return nil, err
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		evts, err := c.listEvents(ctx, m, since)
+		if err != nil || len(evts) > 0 {
+			return evts, err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return evts, nil
+		}
+		if remaining > eventPollInterval {
+			remaining = eventPollInterval
+		}
+		time.Sleep(remaining)
+	}
+}
@@ -22,31 +22,1726 @@ package mysql
 
 import (
 	"database/sql"
-	"sync"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/satori/go.uuid"
 )
 
-var c = &client{}
+var retryNum = 3
 
-// Init
-func Init(driver, credential string) *client {
+// Init connects to the database and upgrades its schema to the latest
+// version, the credential is a dsn like username:password@tcp(ip:port)/dbname.
+func Init(driver, credential string) *Client {
 	// driver equals "mysql"
 	db, err := sql.Open(driver, credential)
+	if err != nil {
+		panic(err)
+	}
+
+	// Open doesn't open a connection, so validate the dsn by ping.
+	err = utils.Retry(retryNum, "Connect to mysql", false, func(retryIdx int, lastErr error) error {
+		return db.Ping()
+	})
 	if err != nil {
 		db.Close()
-		panic(err.Error()) // Just for example purpose. You should use proper error handling instead of panic
+		panic(err)
 	}
 
-	// Open doesn't open a connection. Validate DSN data:
-	if err = db.Ping(); err != nil {
+	if err = Migrate(db); err != nil {
 		db.Close()
-		panic(err) // proper error handling instead of panic in your app
+		panic(err)
+	}
+	return NewClient(db)
+}
+
+// NewClient returns a client of the database whose schema is up to date.
+func NewClient(db *sql.DB) *Client {
+	return &Client{db: db}
+}
+
+// Client
+type Client struct {
+	db *sql.DB
+}
+
+// transaction runs fn in a transaction, which is committed if fn succeeds
+// and rolled back otherwise.
+func (c *Client) transaction(fn func(tx *sql.Tx) error) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		log.Error("When begin transaction in db:", err)
+		return err
+	}
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func now() string {
+	return time.Now().Format(constants.TimeFormat)
+}
+
+// CreateDock
+func (c *Client) CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpec, error) {
+	if dck.Id == "" {
+		dck.Id = uuid.NewV4().String()
+	}
+	if dck.CreatedAt == "" {
+		dck.CreatedAt = now()
+	}
+
+	if err := dockTable.put(c.db, dck); err != nil {
+		return nil, err
+	}
+	return dck, nil
+}
+
+// GetDock
+func (c *Client) GetDock(ctx *c.Context, dckID string) (*model.DockSpec, error) {
+	var dck = &model.DockSpec{}
+	if err := dockTable.get(c.db, ctx, dckID, dck, false); err != nil {
+		return nil, err
+	}
+	return dck, nil
+}
+
+// GetDockByPoolId
+func (c *Client) GetDockByPoolId(ctx *c.Context, poolId string) (*model.DockSpec, error) {
+	pool, err := c.GetPool(ctx, poolId)
+	if err != nil {
+		log.Error("Get pool failed in db: ", err)
+		return nil, err
+	}
+	return c.GetDock(ctx, pool.DockId)
+}
+
+// ListDocks
+func (c *Client) ListDocks(ctx *c.Context) ([]*model.DockSpec, error) {
+	return c.ListDocksWithFilter(ctx, nil)
+}
+
+// ListDocksWithFilter
+func (c *Client) ListDocksWithFilter(ctx *c.Context, m map[string][]string) ([]*model.DockSpec, error) {
+	var dcks []*model.DockSpec
+	f := dockTable.newFilter(ctx).withParameters(m)
+	if err := dockTable.list(c.db, f, &dcks); err != nil {
+		return nil, err
+	}
+	return dcks, nil
+}
+
+// ListDocksWithPage
+func (c *Client) ListDocksWithPage(ctx *c.Context, m map[string][]string) ([]*model.DockSpec, *model.PageSpec, error) {
+	var dcks []*model.DockSpec
+	f, err := dockTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := dockTable.listPage(c.db, f, &dcks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dcks, page, nil
+}
+
+// UpdateDock
+func (c *Client) UpdateDock(ctx *c.Context, dckID, name, desp string) (*model.DockSpec, error) {
+	var dck = &model.DockSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := dockTable.get(tx, ctx, dckID, dck, true); err != nil {
+			return err
+		}
+		if name != "" {
+			dck.Name = name
+		}
+		if desp != "" {
+			dck.Description = desp
+		}
+		dck.UpdatedAt = now()
+		return dockTable.update(tx, dckID, dck.ResourceVersion, dck)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dck, nil
+}
+
+// DeleteDock
+func (c *Client) DeleteDock(ctx *c.Context, dckID string) error {
+	return dockTable.delete(c.db, ctx, dckID)
+}
+
+// CreatePool
+func (c *Client) CreatePool(ctx *c.Context, pol *model.StoragePoolSpec) (*model.StoragePoolSpec, error) {
+	if pol.Id == "" {
+		pol.Id = uuid.NewV4().String()
+	}
+	if pol.CreatedAt == "" {
+		pol.CreatedAt = now()
+	}
+
+	if err := poolTable.put(c.db, pol); err != nil {
+		return nil, err
+	}
+	return pol, nil
+}
+
+// GetPool
+func (c *Client) GetPool(ctx *c.Context, polID string) (*model.StoragePoolSpec, error) {
+	var pol = &model.StoragePoolSpec{}
+	if err := poolTable.get(c.db, ctx, polID, pol, false); err != nil {
+		return nil, err
+	}
+	return pol, nil
+}
+
+// ListAvailabilityZones
+func (c *Client) ListAvailabilityZones(ctx *c.Context) ([]string, error) {
+	rows, err := c.db.Query("SELECT DISTINCT availability_zone FROM pools")
+	if err != nil {
+		log.Error("Failed to get AZ for pools in db:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var azs = []string{}
+	for rows.Next() {
+		var az string
+		if err := rows.Scan(&az); err != nil {
+			return nil, err
+		}
+		azs = append(azs, az)
+	}
+	return azs, rows.Err()
+}
+
+// ListPools
+func (c *Client) ListPools(ctx *c.Context) ([]*model.StoragePoolSpec, error) {
+	return c.ListPoolsWithFilter(ctx, nil)
+}
+
+// ListPoolsWithFilter
+func (c *Client) ListPoolsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.StoragePoolSpec, error) {
+	var pols []*model.StoragePoolSpec
+	f := poolTable.newFilter(ctx).withParameters(m)
+	if err := poolTable.list(c.db, f, &pols); err != nil {
+		return nil, err
+	}
+	return pols, nil
+}
+
+// ListPoolsWithPage
+func (c *Client) ListPoolsWithPage(ctx *c.Context, m map[string][]string) ([]*model.StoragePoolSpec, *model.PageSpec, error) {
+	var pols []*model.StoragePoolSpec
+	f, err := poolTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := poolTable.listPage(c.db, f, &pols)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pols, page, nil
+}
+
+// UpdatePool
+func (c *Client) UpdatePool(ctx *c.Context, polID, name, desp string, usedCapacity int64, used bool) (*model.StoragePoolSpec, error) {
+	var pol = &model.StoragePoolSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := poolTable.get(tx, ctx, polID, pol, true); err != nil {
+			return err
+		}
+		if name != "" {
+			pol.Name = name
+		}
+		if desp != "" {
+			pol.Description = desp
+		}
+		pol.UpdatedAt = now()
+		return poolTable.update(tx, polID, pol.ResourceVersion, pol)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pol, nil
+}
+
+// DeletePool
+func (c *Client) DeletePool(ctx *c.Context, polID string) error {
+	return poolTable.delete(c.db, ctx, polID)
+}
+
+// Heartbeat records the expiration time of the heartbeat of dock. The clock
+// of database server is used, so that the clocks of docks and controller
+// needn't be synchronized.
+func (c *Client) Heartbeat(ctx *c.Context, dckID string, ttl time.Duration) error {
+	if _, err := c.db.Exec("REPLACE INTO heartbeats (dock_id, expires_at) VALUES (?, UNIX_TIMESTAMP() + ?)",
+		dckID, int64(ttl/time.Second)); err != nil {
+		log.Error("When put heartbeat in db:", err)
+		return err
+	}
+	return nil
+}
+
+// ListHeartbeats
+func (c *Client) ListHeartbeats(ctx *c.Context) ([]string, error) {
+	rows, err := c.db.Query("SELECT dock_id FROM heartbeats WHERE expires_at > UNIX_TIMESTAMP()")
+	if err != nil {
+		log.Error("When list heartbeats in db:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids = []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// AcquireLock acquires the lock for the holder or renews it if the holder
+// has acquired it. The lock held by others can be taken over only after it
+// expires, and the clock of database server is used as the heartbeats.
+func (c *Client) AcquireLock(ctx *c.Context, name, holder string, ttl time.Duration) (bool, error) {
+	// Mysql evaluates the assignments from left to right, so the expiration
+	// is only extended if the holder has been replaced by the new one.
+	if _, err := c.db.Exec("INSERT INTO locks (name, holder, expires_at) VALUES (?, ?, UNIX_TIMESTAMP() + ?) "+
+		"ON DUPLICATE KEY UPDATE "+
+		"holder = IF(expires_at <= UNIX_TIMESTAMP(), VALUES(holder), holder), "+
+		"expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)",
+		name, holder, int64(ttl/time.Second)); err != nil {
+		log.Error("When acquire lock in db:", err)
+		return false, err
+	}
+
+	var current string
+	if err := c.db.QueryRow("SELECT holder FROM locks WHERE name = ?", name).Scan(&current); err != nil {
+		log.Error("When get lock in db:", err)
+		return false, err
+	}
+	return current == holder, nil
+}
+
+// CreateProfile
+func (c *Client) CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.ProfileSpec, error) {
+	if prf.Id == "" {
+		prf.Id = uuid.NewV4().String()
+	}
+	if prf.CreatedAt == "" {
+		prf.CreatedAt = now()
+	}
+
+	if err := profileTable.insert(c.db, prf); err != nil {
+		return nil, err
+	}
+	return prf, nil
+}
+
+// GetProfile
+func (c *Client) GetProfile(ctx *c.Context, prfID string) (*model.ProfileSpec, error) {
+	var prf = &model.ProfileSpec{}
+	if err := profileTable.get(c.db, ctx, prfID, prf, false); err != nil {
+		return nil, err
+	}
+	return prf, nil
+}
+
+// GetDefaultProfile
+func (c *Client) GetDefaultProfile(ctx *c.Context) (*model.ProfileSpec, error) {
+	var prfs []*model.ProfileSpec
+	f := profileTable.newFilter(ctx).where("name = ?", "default")
+	if err := profileTable.list(c.db, f, &prfs); err != nil {
+		log.Error("Get default profile failed in db: ", err)
+		return nil, err
+	}
+	if len(prfs) == 0 {
+		return nil, errors.New("No default profile in db.")
+	}
+	return prfs[0], nil
+}
+
+// ListProfiles
+func (c *Client) ListProfiles(ctx *c.Context) ([]*model.ProfileSpec, error) {
+	return c.ListProfilesWithFilter(ctx, nil)
+}
+
+// ListProfilesWithFilter
+func (c *Client) ListProfilesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.ProfileSpec, error) {
+	var prfs []*model.ProfileSpec
+	f := profileTable.newFilter(ctx).withParameters(m)
+	if err := profileTable.list(c.db, f, &prfs); err != nil {
+		return nil, err
+	}
+	return prfs, nil
+}
+
+// ListProfilesWithPage
+func (c *Client) ListProfilesWithPage(ctx *c.Context, m map[string][]string) ([]*model.ProfileSpec, *model.PageSpec, error) {
+	var prfs []*model.ProfileSpec
+	f, err := profileTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := profileTable.listPage(c.db, f, &prfs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prfs, page, nil
+}
+
+// updateProfile applies the change to the profile in a transaction, which is
+// based on the specified version of profile if any.
+func (c *Client) updateProfile(ctx *c.Context, prfID string, specified *model.BaseModel, change func(prf *model.ProfileSpec)) (*model.ProfileSpec, error) {
+	var prf = &model.ProfileSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := profileTable.get(tx, ctx, prfID, prf, true); err != nil {
+			return err
+		}
+		change(prf)
+		prf.UpdatedAt = now()
+		return profileTable.update(tx, prfID, expectedVersion(specified, prf.ResourceVersion), prf)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prf, nil
+}
+
+// UpdateProfile
+func (c *Client) UpdateProfile(ctx *c.Context, prfID string, input *model.ProfileSpec) (*model.ProfileSpec, error) {
+	return c.updateProfile(ctx, prfID, input.BaseModel, func(prf *model.ProfileSpec) {
+		if name := input.Name; name != "" {
+			prf.Name = name
+		}
+		if desp := input.Description; desp != "" {
+			prf.Description = desp
+		}
+		if props := input.CustomProperties; len(props) != 0 {
+			if prf.CustomProperties == nil {
+				prf.CustomProperties = make(map[string]interface{})
+			}
+			for k, v := range props {
+				prf.CustomProperties[k] = v
+			}
+		}
+	})
+}
+
+// DeleteProfile
+func (c *Client) DeleteProfile(ctx *c.Context, prfID string) error {
+	return profileTable.delete(c.db, ctx, prfID)
+}
+
+// AddCustomProperty
+func (c *Client) AddCustomProperty(ctx *c.Context, prfID string, ext model.CustomPropertiesSpec) (*model.CustomPropertiesSpec, error) {
+	prf, err := c.updateProfile(ctx, prfID, nil, func(prf *model.ProfileSpec) {
+		if prf.CustomProperties == nil {
+			prf.CustomProperties = make(map[string]interface{})
+		}
+		for k, v := range ext {
+			prf.CustomProperties[k] = v
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &prf.CustomProperties, nil
+}
+
+// ListCustomProperties
+func (c *Client) ListCustomProperties(ctx *c.Context, prfID string) (*model.CustomPropertiesSpec, error) {
+	prf, err := c.GetProfile(ctx, prfID)
+	if err != nil {
+		return nil, err
+	}
+	return &prf.CustomProperties, nil
+}
+
+// RemoveCustomProperty
+func (c *Client) RemoveCustomProperty(ctx *c.Context, prfID, customKey string) error {
+	_, err := c.updateProfile(ctx, prfID, nil, func(prf *model.ProfileSpec) {
+		delete(prf.CustomProperties, customKey)
+	})
+	return err
+}
+
+// CreateVolume
+func (c *Client) CreateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error) {
+	var count int
+	if err := c.db.QueryRow("SELECT COUNT(*) FROM profiles").Scan(&count); err != nil {
+		return nil, err
+	} else if count == 0 {
+		return nil, errors.New("No profile in db.")
+	}
+
+	vol.TenantId = ctx.TenantId
+	if err := volumeTable.insert(c.db, vol); err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+// GetVolume
+func (c *Client) GetVolume(ctx *c.Context, volID string) (*model.VolumeSpec, error) {
+	var vol = &model.VolumeSpec{}
+	if err := volumeTable.get(c.db, ctx, volID, vol, false); err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+// ListVolumes
+func (c *Client) ListVolumes(ctx *c.Context) ([]*model.VolumeSpec, error) {
+	return c.ListVolumesWithFilter(ctx, nil)
+}
+
+// ListVolumesWithFilter
+func (c *Client) ListVolumesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeSpec, error) {
+	var vols []*model.VolumeSpec
+	f := volumeTable.newFilter(ctx).withParameters(m)
+	if err := volumeTable.list(c.db, f, &vols); err != nil {
+		return nil, err
+	}
+	return vols, nil
+}
+
+// ListVolumesWithPage
+func (c *Client) ListVolumesWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSpec, *model.PageSpec, error) {
+	var vols []*model.VolumeSpec
+	f, err := volumeTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := volumeTable.listPage(c.db, f, &vols)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vols, page, nil
+}
+
+// updateVolume applies the change to the volume in a transaction, which is
+// based on the specified version of volume if any.
+func (c *Client) updateVolume(ctx *c.Context, volID string, specified *model.BaseModel, change func(result *model.VolumeSpec)) (*model.VolumeSpec, error) {
+	var result = &model.VolumeSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := volumeTable.get(tx, ctx, volID, result, true); err != nil {
+			return err
+		}
+		oldStatus, oldPolicyStatus := result.Status, result.PolicyStatus
+		change(result)
+		// Set update time
+		result.UpdatedAt = now()
+		if err := volumeTable.update(tx, volID, expectedVersion(specified, result.ResourceVersion), result); err != nil {
+			return err
+		}
+		if err := recordEvent(tx, model.NewStatusEvent(result.TenantId,
+			model.EventResourceVolume, volID, oldStatus, result.Status)); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(result.TenantId,
+			model.EventResourceVolumePolicy, volID, oldPolicyStatus, result.PolicyStatus))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateVolume ...
+func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error) {
+	return c.updateVolume(ctx, vol.Id, vol.BaseModel, func(result *model.VolumeSpec) {
+		if vol.Name != "" {
+			result.Name = vol.Name
+		}
+		if vol.AvailabilityZone != "" {
+			result.AvailabilityZone = vol.AvailabilityZone
+		}
+		if vol.Description != "" {
+			result.Description = vol.Description
+		}
+		if vol.Metadata != nil {
+			result.Metadata = vol.Metadata
+		}
+		if vol.PoolId != "" {
+			result.PoolId = vol.PoolId
+		}
+		if vol.ProfileId != "" {
+			result.ProfileId = vol.ProfileId
+		}
+		if vol.Size != 0 {
+			result.Size = vol.Size
+		}
+		if vol.Status != "" {
+			result.Status = vol.Status
+		}
+		if vol.ReplicationDriverData != nil {
+			result.ReplicationDriverData = vol.ReplicationDriverData
+		}
+		if vol.MigrationStatus != "" {
+			result.MigrationStatus = vol.MigrationStatus
+			result.MigrationProgress = vol.MigrationProgress
+		}
+		if vol.PolicyStatus != "" {
+			result.PolicyStatus = vol.PolicyStatus
+		}
+		if vol.Qos != nil {
+			result.Qos = vol.Qos
+		}
+		result.GroupId = vol.GroupId
+	})
+}
+
+// DeleteVolume
+func (c *Client) DeleteVolume(ctx *c.Context, volID string) error {
+	return volumeTable.delete(c.db, ctx, volID)
+}
+
+// ExtendVolume ...
+func (c *Client) ExtendVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error) {
+	return c.updateVolume(ctx, vol.Id, vol.BaseModel, func(result *model.VolumeSpec) {
+		if vol.Size > 0 {
+			result.Size = vol.Size
+		}
+		result.Status = vol.Status
+	})
+}
+
+// CreateVolumeAttachment
+func (c *Client) CreateVolumeAttachment(ctx *c.Context, attachment *model.VolumeAttachmentSpec) (*model.VolumeAttachmentSpec, error) {
+	attachment.TenantId = ctx.TenantId
+	if err := attachmentTable.insert(c.db, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetVolumeAttachment
+func (c *Client) GetVolumeAttachment(ctx *c.Context, attachmentId string) (*model.VolumeAttachmentSpec, error) {
+	var atc = &model.VolumeAttachmentSpec{}
+	if err := attachmentTable.get(c.db, ctx, attachmentId, atc, false); err != nil {
+		return nil, err
+	}
+	return atc, nil
+}
+
+// ListVolumeAttachments
+func (c *Client) ListVolumeAttachments(ctx *c.Context, volumeId string) ([]*model.VolumeAttachmentSpec, error) {
+	var m map[string][]string
+	if len(volumeId) != 0 {
+		m = map[string][]string{"VolumeId": {volumeId}}
+	}
+	return c.ListVolumeAttachmentsWithFilter(ctx, m)
+}
+
+// ListVolumeAttachmentsWithFilter
+func (c *Client) ListVolumeAttachmentsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeAttachmentSpec, error) {
+	var atcs []*model.VolumeAttachmentSpec
+	f := attachmentTable.newFilter(ctx).withParameters(m)
+	if err := attachmentTable.list(c.db, f, &atcs); err != nil {
+		return nil, err
+	}
+	return atcs, nil
+}
+
+// UpdateVolumeAttachment
+func (c *Client) UpdateVolumeAttachment(ctx *c.Context, attachmentId string, attachment *model.VolumeAttachmentSpec) (*model.VolumeAttachmentSpec, error) {
+	var result = &model.VolumeAttachmentSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := attachmentTable.get(tx, ctx, attachmentId, result, true); err != nil {
+			return err
+		}
+		oldStatus := result.Status
+		if len(attachment.Mountpoint) > 0 {
+			result.Mountpoint = attachment.Mountpoint
+		}
+		if len(attachment.Status) > 0 {
+			result.Status = attachment.Status
+		}
+		if len(attachment.Platform) > 0 {
+			result.Platform = attachment.Platform
+		}
+		if len(attachment.OsType) > 0 {
+			result.OsType = attachment.OsType
+		}
+		if len(attachment.Ip) > 0 {
+			result.Ip = attachment.Ip
+		}
+		if len(attachment.Host) > 0 {
+			result.Host = attachment.Host
+		}
+		if len(attachment.Initiator) > 0 {
+			result.Initiator = attachment.Initiator
+		}
+		if len(attachment.DriverVolumeType) > 0 {
+			result.DriverVolumeType = attachment.DriverVolumeType
+		}
+		if len(attachment.AccessProtocol) > 0 {
+			result.AccessProtocol = attachment.AccessProtocol
+		}
+		// Update metadata
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]string)
+		}
+		for k, v := range attachment.Metadata {
+			result.Metadata[k] = v
+		}
+		// Update connectionData
+		if result.ConnectionData == nil {
+			result.ConnectionData = make(map[string]interface{})
+		}
+		for k, v := range attachment.ConnectionData {
+			result.ConnectionData[k] = v
+		}
+		// Set update time
+		result.UpdatedAt = now()
+		if err := attachmentTable.update(tx, attachmentId, expectedVersion(attachment.BaseModel, result.ResourceVersion), result); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(result.TenantId,
+			model.EventResourceAttachment, attachmentId, oldStatus, result.Status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteVolumeAttachment
+func (c *Client) DeleteVolumeAttachment(ctx *c.Context, attachmentId string) error {
+	return attachmentTable.delete(c.db, ctx, attachmentId)
+}
+
+// CreateVolumeSnapshot
+func (c *Client) CreateVolumeSnapshot(ctx *c.Context, snp *model.VolumeSnapshotSpec) (*model.VolumeSnapshotSpec, error) {
+	snp.TenantId = ctx.TenantId
+	if err := snapshotTable.insert(c.db, snp); err != nil {
+		return nil, err
+	}
+	return snp, nil
+}
+
+// GetVolumeSnapshot
+func (c *Client) GetVolumeSnapshot(ctx *c.Context, snpID string) (*model.VolumeSnapshotSpec, error) {
+	var snp = &model.VolumeSnapshotSpec{}
+	if err := snapshotTable.get(c.db, ctx, snpID, snp, false); err != nil {
+		return nil, err
+	}
+	return snp, nil
+}
+
+// ListVolumeSnapshots
+func (c *Client) ListVolumeSnapshots(ctx *c.Context) ([]*model.VolumeSnapshotSpec, error) {
+	return c.ListVolumeSnapshotsWithFilter(ctx, nil)
+}
+
+// ListVolumeSnapshotsWithFilter
+func (c *Client) ListVolumeSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, error) {
+	var snps []*model.VolumeSnapshotSpec
+	f := snapshotTable.newFilter(ctx).withParameters(m)
+	if err := snapshotTable.list(c.db, f, &snps); err != nil {
+		return nil, err
+	}
+	return snps, nil
+}
+
+// ListVolumeSnapshotsWithPage
+func (c *Client) ListVolumeSnapshotsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, *model.PageSpec, error) {
+	var snps []*model.VolumeSnapshotSpec
+	f, err := snapshotTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := snapshotTable.listPage(c.db, f, &snps)
+	if err != nil {
+		return nil, nil, err
 	}
+	return snps, page, nil
+}
+
+// UpdateVolumeSnapshot
+func (c *Client) UpdateVolumeSnapshot(ctx *c.Context, snpID string, snp *model.VolumeSnapshotSpec) (*model.VolumeSnapshotSpec, error) {
+	var result = &model.VolumeSnapshotSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := snapshotTable.get(tx, ctx, snpID, result, true); err != nil {
+			return err
+		}
+		oldStatus := result.Status
+		if snp.Name != "" {
+			result.Name = snp.Name
+		}
+		if snp.Metadata != nil {
+			result.Metadata = snp.Metadata
+		}
+		if snp.Size > 0 {
+			result.Size = snp.Size
+		}
+		if snp.VolumeId != "" {
+			result.VolumeId = snp.VolumeId
+		}
+		if snp.Description != "" {
+			result.Description = snp.Description
+		}
+		if snp.Status != "" {
+			result.Status = snp.Status
+		}
+		// Set update time
+		result.UpdatedAt = now()
+		if err := snapshotTable.update(tx, snpID, expectedVersion(snp.BaseModel, result.ResourceVersion), result); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(result.TenantId,
+			model.EventResourceSnapshot, snpID, oldStatus, result.Status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteVolumeSnapshot
+func (c *Client) DeleteVolumeSnapshot(ctx *c.Context, snpID string) error {
+	return snapshotTable.delete(c.db, ctx, snpID)
+}
+
+// CreateReplication
+func (c *Client) CreateReplication(ctx *c.Context, r *model.ReplicationSpec) (*model.ReplicationSpec, error) {
+	if r.Id == "" {
+		r.Id = uuid.NewV4().String()
+	}
+	r.TenantId = ctx.TenantId
+	r.CreatedAt = now()
+
+	if err := replicationTable.insert(c.db, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetReplication
+func (c *Client) GetReplication(ctx *c.Context, replicationId string) (*model.ReplicationSpec, error) {
+	var r = &model.ReplicationSpec{}
+	if err := replicationTable.get(c.db, ctx, replicationId, r, false); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetReplicationByVolumeId
+func (c *Client) GetReplicationByVolumeId(ctx *c.Context, volumeId string) (*model.ReplicationSpec, error) {
+	var replicas []*model.ReplicationSpec
+	f := replicationTable.newFilter(ctx).where("primary_volume_id = ? OR secondary_volume_id = ?", volumeId, volumeId)
+	if err := replicationTable.list(c.db, f, &replicas); err != nil {
+		return nil, err
+	}
+	if len(replicas) == 0 {
+		return nil, model.NewNotFoundError(fmt.Sprintf("can't find specified replication by volume id %s", volumeId))
+	}
+	return replicas[0], nil
+}
 
-	c.cli = db
-	return c
+// ListReplication
+func (c *Client) ListReplication(ctx *c.Context) ([]*model.ReplicationSpec, error) {
+	return c.ListReplicationWithFilter(ctx, nil)
 }
 
-type client struct {
-	cli  *sql.DB
-	lock sync.Mutex
+// ListReplicationWithFilter
+func (c *Client) ListReplicationWithFilter(ctx *c.Context, m map[string][]string) ([]*model.ReplicationSpec, error) {
+	var replicas []*model.ReplicationSpec
+	f := replicationTable.newFilter(ctx).withParameters(m)
+	if err := replicationTable.list(c.db, f, &replicas); err != nil {
+		return nil, err
+	}
+	return replicas, nil
+}
+
+// ListReplicationWithPage
+func (c *Client) ListReplicationWithPage(ctx *c.Context, m map[string][]string) ([]*model.ReplicationSpec, *model.PageSpec, error) {
+	var replicas []*model.ReplicationSpec
+	f, err := replicationTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := replicationTable.listPage(c.db, f, &replicas)
+	if err != nil {
+		return nil, nil, err
+	}
+	return replicas, page, nil
+}
+
+// DeleteReplication
+func (c *Client) DeleteReplication(ctx *c.Context, replicationId string) error {
+	return replicationTable.delete(c.db, ctx, replicationId)
+}
+
+// UpdateReplication
+func (c *Client) UpdateReplication(ctx *c.Context, replicationId string, input *model.ReplicationSpec) (*model.ReplicationSpec, error) {
+	var r = &model.ReplicationSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := replicationTable.get(tx, ctx, replicationId, r, true); err != nil {
+			return err
+		}
+		if input.ProfileId != "" {
+			r.ProfileId = input.ProfileId
+		}
+		if input.Name != "" {
+			r.Name = input.Name
+		}
+		if input.Description != "" {
+			r.Description = input.Description
+		}
+		if input.PrimaryReplicationDriverData != nil {
+			r.PrimaryReplicationDriverData = input.PrimaryReplicationDriverData
+		}
+		if input.SecondaryReplicationDriverData != nil {
+			r.SecondaryReplicationDriverData = input.SecondaryReplicationDriverData
+		}
+		if input.Metadata != nil {
+			r.Metadata = input.Metadata
+		}
+		if input.ReplicationStatus != "" {
+			r.ReplicationStatus = input.ReplicationStatus
+		}
+		r.UpdatedAt = now()
+		return replicationTable.update(tx, replicationId, expectedVersion(input.BaseModel, r.ResourceVersion), r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateVolumeGroup
+func (c *Client) CreateVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
+	vg.TenantId = ctx.TenantId
+	if err := volumeGroupTable.insert(c.db, vg); err != nil {
+		return nil, err
+	}
+	return vg, nil
+}
+
+// GetVolumeGroup
+func (c *Client) GetVolumeGroup(ctx *c.Context, vgId string) (*model.VolumeGroupSpec, error) {
+	var vg = &model.VolumeGroupSpec{}
+	if err := volumeGroupTable.get(c.db, ctx, vgId, vg, false); err != nil {
+		return nil, err
+	}
+	return vg, nil
+}
+
+// UpdateVolumeGroup
+func (c *Client) UpdateVolumeGroup(ctx *c.Context, vgUpdate *model.VolumeGroupSpec) (*model.VolumeGroupSpec, error) {
+	var vg = &model.VolumeGroupSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := volumeGroupTable.get(tx, ctx, vgUpdate.Id, vg, true); err != nil {
+			return err
+		}
+		oldStatus := vg.Status
+		if vgUpdate.Name != "" {
+			vg.Name = vgUpdate.Name
+		}
+		if vgUpdate.AvailabilityZone != "" {
+			vg.AvailabilityZone = vgUpdate.AvailabilityZone
+		}
+		if vgUpdate.Description != "" {
+			vg.Description = vgUpdate.Description
+		}
+		if vgUpdate.PoolId != "" {
+			vg.PoolId = vgUpdate.PoolId
+		}
+		if vgUpdate.Status != "" {
+			vg.Status = vgUpdate.Status
+		}
+		if vgUpdate.CreatedAt != "" {
+			vg.CreatedAt = vgUpdate.CreatedAt
+		}
+		if vgUpdate.UpdatedAt != "" {
+			vg.UpdatedAt = vgUpdate.UpdatedAt
+		}
+		if vgUpdate.GroupSnapshots != nil {
+			vg.GroupSnapshots = vgUpdate.GroupSnapshots
+		}
+		if err := volumeGroupTable.update(tx, vgUpdate.Id, expectedVersion(vgUpdate.BaseModel, vg.ResourceVersion), vg); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(vg.TenantId,
+			model.EventResourceVolumeGroup, vgUpdate.Id, oldStatus, vg.Status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vg, nil
+}
+
+// UpdateStatus applies the status to the latest version of resource no matter
+// which version the caller has read, because the status is maintained by the
+// system. It's retried if the resource is modified by others concurrently.
+func (c *Client) UpdateStatus(ctx *c.Context, in interface{}, status string) error {
+	for i := 0; ; i++ {
+		err := c.updateStatus(ctx, in, status)
+		if _, ok := err.(*model.ConflictError); !ok || i >= retryNum {
+			return err
+		}
+		log.V(5).Infof("Resource has been modified by others, retry updating status to %s.", status)
+	}
+}
+
+func (c *Client) updateStatus(ctx *c.Context, in interface{}, status string) error {
+	switch in.(type) {
+	case *model.VolumeSnapshotSpec:
+		snap := in.(*model.VolumeSnapshotSpec)
+		if errUpdate := c.updateSnapshotStatus(ctx, snap, status); errUpdate != nil {
+			log.Error("Error occurs when update volume snapshot status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.VolumeAttachmentSpec:
+		attm := in.(*model.VolumeAttachmentSpec)
+		if errUpdate := c.updateAttachmentStatus(ctx, attm, status); errUpdate != nil {
+			log.Error("Error occurred in dock module when update volume attachment status in db:", errUpdate)
+			return errUpdate
+		}
+
+	case *model.VolumeSpec:
+		volume := in.(*model.VolumeSpec)
+		if errUpdate := c.updateVolumeStatus(ctx, volume, status); errUpdate != nil {
+			log.Error("When update volume status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.VolumeGroupSpec:
+		vg := in.(*model.VolumeGroupSpec)
+		if errUpdate := c.updateVolumeGroupStatus(ctx, vg, status); errUpdate != nil {
+			log.Error("When update volume status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.GroupSnapshotSpec:
+		gs := in.(*model.GroupSnapshotSpec)
+		if errUpdate := c.updateGroupSnapshotStatus(ctx, gs, status); errUpdate != nil {
+			log.Error("When update group snapshot status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.FileShareSpec:
+		fshare := in.(*model.FileShareSpec)
+		if errUpdate := c.updateFileShareStatus(ctx, fshare, status); errUpdate != nil {
+			log.Error("When update file share status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.FileShareAclSpec:
+		acl := in.(*model.FileShareAclSpec)
+		if errUpdate := c.updateFileShareAclStatus(ctx, acl, status); errUpdate != nil {
+			log.Error("When update file share acl status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
+	case *model.DockSpec:
+		dck := in.(*model.DockSpec)
+		err := c.transaction(func(tx *sql.Tx) error {
+			var result = &model.DockSpec{}
+			if err := dockTable.get(tx, ctx, dck.Id, result, true); err != nil {
+				return err
+			}
+			result.Status = status
+			result.UpdatedAt = now()
+			return dockTable.update(tx, dck.Id, result.ResourceVersion, result)
+		})
+		if err != nil {
+			log.Error("When update dock status in db:", err)
+			return err
+		}
+		dck.Status = status
+
+	case *model.StoragePoolSpec:
+		pol := in.(*model.StoragePoolSpec)
+		err := c.transaction(func(tx *sql.Tx) error {
+			var result = &model.StoragePoolSpec{}
+			if err := poolTable.get(tx, ctx, pol.Id, result, true); err != nil {
+				return err
+			}
+			result.Status = status
+			result.UpdatedAt = now()
+			return poolTable.update(tx, pol.Id, result.ResourceVersion, result)
+		})
+		if err != nil {
+			log.Error("When update pool status in db:", err)
+			return err
+		}
+		pol.Status = status
+
+	case []*model.VolumeSpec:
+		vols := in.([]*model.VolumeSpec)
+		for _, vol := range vols {
+			if errUpdate := c.updateVolumeStatus(ctx, vol, status); errUpdate != nil {
+				log.Error("When update volume status in db:", errUpdate.Error())
+				return errUpdate
+			}
+		}
+	}
+	return nil
+}
+
+// statusVersion returns the base model of the status update, which is based
+// on the latest version of resource rather than the one caller has read.
+func statusVersion(in *model.BaseModel, latest int64) *model.BaseModel {
+	b := *in
+	b.ResourceVersion = latest
+	return &b
+}
+
+// mergeMetadata returns the latest metadata overlaid with the keys reported by
+// the caller, so that the keys added through the API concurrently are kept.
+func mergeMetadata(latest, reported map[string]string) map[string]string {
+	if reported == nil {
+		return nil
+	}
+	merged := make(map[string]string, len(latest)+len(reported))
+	for k, v := range latest {
+		merged[k] = v
+	}
+	for k, v := range reported {
+		merged[k] = v
+	}
+	return merged
+}
+
+// updateVolumeStatus reads the volume again and applies the status along with
+// the fields maintained by the system, such as pool, size, qos and migration
+// progress. The name, description and availability zone are always taken from
+// the latest volume, so that the stale copy of caller doesn't revert the ones
+// modified through the API concurrently. An empty status keeps the current
+// one of volume.
+func (c *Client) updateVolumeStatus(ctx *c.Context, in *model.VolumeSpec, status string) error {
+	latest, err := c.GetVolume(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	vol := *in
+	vol.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	vol.Name, vol.Description = latest.Name, latest.Description
+	vol.AvailabilityZone = latest.AvailabilityZone
+	vol.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	if status != "" {
+		vol.Status = status
+	}
+	result, err := c.UpdateVolume(ctx, &vol)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateSnapshotStatus reads the snapshot again and applies the status, the
+// name and description modified concurrently are kept.
+func (c *Client) updateSnapshotStatus(ctx *c.Context, in *model.VolumeSnapshotSpec, status string) error {
+	latest, err := c.GetVolumeSnapshot(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	snap := *in
+	snap.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	snap.Name, snap.Description = latest.Name, latest.Description
+	snap.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	snap.Status = status
+	result, err := c.UpdateVolumeSnapshot(ctx, in.Id, &snap)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateAttachmentStatus reads the attachment again and applies the status
+// along with the connection info reported by the driver.
+func (c *Client) updateAttachmentStatus(ctx *c.Context, in *model.VolumeAttachmentSpec, status string) error {
+	latest, err := c.GetVolumeAttachment(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	atc := *in
+	atc.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	atc.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	atc.Status = status
+	result, err := c.UpdateVolumeAttachment(ctx, in.Id, &atc)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateVolumeGroupStatus reads the volume group again and applies the status,
+// the name, description and availability zone modified concurrently are kept.
+func (c *Client) updateVolumeGroupStatus(ctx *c.Context, in *model.VolumeGroupSpec, status string) error {
+	latest, err := c.GetVolumeGroup(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	vg := *in
+	vg.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	vg.Name, vg.Description = latest.Name, latest.Description
+	vg.AvailabilityZone = latest.AvailabilityZone
+	vg.Status = status
+	result, err := c.UpdateVolumeGroup(ctx, &vg)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateGroupSnapshotStatus reads the group snapshot again and applies the
+// status, the name and description modified concurrently are kept.
+func (c *Client) updateGroupSnapshotStatus(ctx *c.Context, in *model.GroupSnapshotSpec, status string) error {
+	latest, err := c.GetGroupSnapshot(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	gs := *in
+	gs.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	gs.Name, gs.Description = latest.Name, latest.Description
+	gs.Status = status
+	result, err := c.UpdateGroupSnapshot(ctx, &gs)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateFileShareStatus reads the file share again and applies the status
+// along with the fields maintained by the system, the name, description and
+// availability zone modified concurrently are kept.
+func (c *Client) updateFileShareStatus(ctx *c.Context, in *model.FileShareSpec, status string) error {
+	latest, err := c.GetFileShare(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	fshare := *in
+	fshare.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	fshare.Name, fshare.Description = latest.Name, latest.Description
+	fshare.AvailabilityZone = latest.AvailabilityZone
+	fshare.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	fshare.Status = status
+	result, err := c.UpdateFileShare(ctx, &fshare)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// updateFileShareAclStatus reads the file share acl again and applies the
+// status, the description modified concurrently is kept.
+func (c *Client) updateFileShareAclStatus(ctx *c.Context, in *model.FileShareAclSpec, status string) error {
+	latest, err := c.GetFileShareAcl(ctx, in.Id)
+	if err != nil {
+		return err
+	}
+	acl := *in
+	acl.BaseModel = statusVersion(in.BaseModel, latest.ResourceVersion)
+	acl.Description = latest.Description
+	acl.Metadata = mergeMetadata(latest.Metadata, in.Metadata)
+	acl.Status = status
+	result, err := c.UpdateFileShareAcl(ctx, &acl)
+	if err != nil {
+		return err
+	}
+	in.Status, in.ResourceVersion = result.Status, result.ResourceVersion
+	return nil
+}
+
+// ListVolumesByGroupId
+func (c *Client) ListVolumesByGroupId(ctx *c.Context, vgId string) ([]*model.VolumeSpec, error) {
+	return c.ListVolumesWithFilter(ctx, map[string][]string{"GroupId": {vgId}})
+}
+
+// VolumesToUpdate
+func (c *Client) VolumesToUpdate(ctx *c.Context, volumeList []*model.VolumeSpec) ([]*model.VolumeSpec, error) {
+	var volumeRefs []*model.VolumeSpec
+	for _, values := range volumeList {
+		v, err := c.UpdateVolume(ctx, values)
+		if err != nil {
+			return nil, err
+		}
+		volumeRefs = append(volumeRefs, v)
+	}
+	return volumeRefs, nil
+}
+
+// ListVolumeGroups
+func (c *Client) ListVolumeGroups(ctx *c.Context) ([]*model.VolumeGroupSpec, error) {
+	return c.ListVolumeGroupsWithFilter(ctx, nil)
+}
+
+// DeleteVolumeGroup
+func (c *Client) DeleteVolumeGroup(ctx *c.Context, volumeGroupId string) error {
+	return volumeGroupTable.delete(c.db, ctx, volumeGroupId)
+}
+
+// ListSnapshotsByVolumeId
+func (c *Client) ListSnapshotsByVolumeId(ctx *c.Context, volumeId string) ([]*model.VolumeSnapshotSpec, error) {
+	return c.ListVolumeSnapshotsWithFilter(ctx, map[string][]string{"VolumeId": {volumeId}})
+}
+
+// ListVolumeGroupsWithFilter
+func (c *Client) ListVolumeGroupsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.VolumeGroupSpec, error) {
+	var vgs []*model.VolumeGroupSpec
+	f := volumeGroupTable.newFilter(ctx).withParameters(m)
+	if err := volumeGroupTable.list(c.db, f, &vgs); err != nil {
+		return nil, err
+	}
+	return vgs, nil
+}
+
+// ListVolumeGroupsWithPage
+func (c *Client) ListVolumeGroupsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeGroupSpec, *model.PageSpec, error) {
+	var vgs []*model.VolumeGroupSpec
+	f, err := volumeGroupTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := volumeGroupTable.listPage(c.db, f, &vgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vgs, page, nil
+}
+
+// CreateGroupSnapshot
+func (c *Client) CreateGroupSnapshot(ctx *c.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	gs.TenantId = ctx.TenantId
+	if err := groupSnapshotTable.insert(c.db, gs); err != nil {
+		return nil, err
+	}
+	return gs, nil
+}
+
+// GetGroupSnapshot
+func (c *Client) GetGroupSnapshot(ctx *c.Context, gsId string) (*model.GroupSnapshotSpec, error) {
+	var gs = &model.GroupSnapshotSpec{}
+	if err := groupSnapshotTable.get(c.db, ctx, gsId, gs, false); err != nil {
+		return nil, err
+	}
+	return gs, nil
+}
+
+// ListGroupSnapshots
+func (c *Client) ListGroupSnapshots(ctx *c.Context) ([]*model.GroupSnapshotSpec, error) {
+	return c.ListGroupSnapshotsWithFilter(ctx, nil)
+}
+
+// ListGroupSnapshotsWithFilter
+func (c *Client) ListGroupSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.GroupSnapshotSpec, error) {
+	var gss []*model.GroupSnapshotSpec
+	f := groupSnapshotTable.newFilter(ctx).withParameters(m)
+	if err := groupSnapshotTable.list(c.db, f, &gss); err != nil {
+		return nil, err
+	}
+	return gss, nil
+}
+
+// UpdateGroupSnapshot
+func (c *Client) UpdateGroupSnapshot(ctx *c.Context, gsUpdate *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	var gs = &model.GroupSnapshotSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := groupSnapshotTable.get(tx, ctx, gsUpdate.Id, gs, true); err != nil {
+			return err
+		}
+		oldStatus := gs.Status
+		if gsUpdate.Name != "" {
+			gs.Name = gsUpdate.Name
+		}
+		if gsUpdate.Description != "" {
+			gs.Description = gsUpdate.Description
+		}
+		if gsUpdate.Status != "" {
+			gs.Status = gsUpdate.Status
+		}
+		if gsUpdate.Snapshots != nil {
+			gs.Snapshots = gsUpdate.Snapshots
+		}
+		gs.UpdatedAt = now()
+		if err := groupSnapshotTable.update(tx, gsUpdate.Id, expectedVersion(gsUpdate.BaseModel, gs.ResourceVersion), gs); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(gs.TenantId,
+			model.EventResourceGroupSnapshot, gsUpdate.Id, oldStatus, gs.Status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gs, nil
+}
+
+// DeleteGroupSnapshot
+func (c *Client) DeleteGroupSnapshot(ctx *c.Context, gsId string) error {
+	return groupSnapshotTable.delete(c.db, ctx, gsId)
+}
+
+// CreateFileShare
+func (c *Client) CreateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error) {
+	fshare.TenantId = ctx.TenantId
+	if err := fileShareTable.insert(c.db, fshare); err != nil {
+		return nil, err
+	}
+	return fshare, nil
+}
+
+// GetFileShare
+func (c *Client) GetFileShare(ctx *c.Context, fshareId string) (*model.FileShareSpec, error) {
+	var fshare = &model.FileShareSpec{}
+	if err := fileShareTable.get(c.db, ctx, fshareId, fshare, false); err != nil {
+		return nil, err
+	}
+	return fshare, nil
+}
+
+// ListFileShares
+func (c *Client) ListFileShares(ctx *c.Context) ([]*model.FileShareSpec, error) {
+	return c.ListFileSharesWithFilter(ctx, nil)
+}
+
+// ListFileSharesWithFilter
+func (c *Client) ListFileSharesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, error) {
+	var fshares []*model.FileShareSpec
+	f := fileShareTable.newFilter(ctx).withParameters(m)
+	if err := fileShareTable.list(c.db, f, &fshares); err != nil {
+		return nil, err
+	}
+	return fshares, nil
+}
+
+// ListFileSharesWithPage
+func (c *Client) ListFileSharesWithPage(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, *model.PageSpec, error) {
+	var fshares []*model.FileShareSpec
+	f, err := fileShareTable.newFilter(ctx).withParameters(m).withMarker(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := fileShareTable.listPage(c.db, f, &fshares)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fshares, page, nil
+}
+
+// UpdateFileShare
+func (c *Client) UpdateFileShare(ctx *c.Context, fshareUpdate *model.FileShareSpec) (*model.FileShareSpec, error) {
+	var fshare = &model.FileShareSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := fileShareTable.get(tx, ctx, fshareUpdate.Id, fshare, true); err != nil {
+			return err
+		}
+		oldStatus := fshare.Status
+		if fshareUpdate.Name != "" {
+			fshare.Name = fshareUpdate.Name
+		}
+		if fshareUpdate.Description != "" {
+			fshare.Description = fshareUpdate.Description
+		}
+		if fshareUpdate.Size != 0 {
+			fshare.Size = fshareUpdate.Size
+		}
+		if fshareUpdate.Status != "" {
+			fshare.Status = fshareUpdate.Status
+		}
+		if fshareUpdate.PoolId != "" {
+			fshare.PoolId = fshareUpdate.PoolId
+		}
+		if fshareUpdate.ProfileId != "" {
+			fshare.ProfileId = fshareUpdate.ProfileId
+		}
+		if fshareUpdate.ExportLocations != nil {
+			fshare.ExportLocations = fshareUpdate.ExportLocations
+		}
+		if fshareUpdate.Metadata != nil {
+			fshare.Metadata = utils.MergeStringMaps(fshare.Metadata, fshareUpdate.Metadata)
+		}
+		fshare.UpdatedAt = now()
+		if err := fileShareTable.update(tx, fshareUpdate.Id, expectedVersion(fshareUpdate.BaseModel, fshare.ResourceVersion), fshare); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(fshare.TenantId,
+			model.EventResourceFileShare, fshareUpdate.Id, oldStatus, fshare.Status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fshare, nil
+}
+
+// DeleteFileShare
+func (c *Client) DeleteFileShare(ctx *c.Context, fshareId string) error {
+	return fileShareTable.delete(c.db, ctx, fshareId)
+}
+
+// CreateFileShareAcl
+func (c *Client) CreateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	acl.TenantId = ctx.TenantId
+	if err := fileShareAclTable.insert(c.db, acl); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// GetFileShareAcl
+func (c *Client) GetFileShareAcl(ctx *c.Context, aclId string) (*model.FileShareAclSpec, error) {
+	var acl = &model.FileShareAclSpec{}
+	if err := fileShareAclTable.get(c.db, ctx, aclId, acl, false); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// ListFileShareAcls
+func (c *Client) ListFileShareAcls(ctx *c.Context) ([]*model.FileShareAclSpec, error) {
+	return c.ListFileShareAclsWithFilter(ctx, nil)
+}
+
+// ListFileShareAclsWithFilter
+func (c *Client) ListFileShareAclsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareAclSpec, error) {
+	var acls []*model.FileShareAclSpec
+	f := fileShareAclTable.newFilter(ctx).withParameters(m)
+	if err := fileShareAclTable.list(c.db, f, &acls); err != nil {
+		return nil, err
+	}
+	return acls, nil
+}
+
+// UpdateFileShareAcl
+func (c *Client) UpdateFileShareAcl(ctx *c.Context, aclUpdate *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	var acl = &model.FileShareAclSpec{}
+	err := c.transaction(func(tx *sql.Tx) error {
+		if err := fileShareAclTable.get(tx, ctx, aclUpdate.Id, acl, true); err != nil {
+			return err
+		}
+		oldStatus := acl.Status
+		if aclUpdate.Description != "" {
+			acl.Description = aclUpdate.Description
+		}
+		if aclUpdate.Status != "" {
+			acl.Status = aclUpdate.Status
+		}
+		if aclUpdate.Metadata != nil {
+			acl.Metadata = utils.MergeStringMaps(acl.Metadata, aclUpdate.Metadata)
+		}
+		acl.UpdatedAt = now()
+		if err := fileShareAclTable.update(tx, aclUpdate.Id, expectedVersion(aclUpdate.BaseModel, acl.ResourceVersion), acl); err != nil {
+			return err
+		}
+		return recordEvent(tx, model.NewStatusEvent(acl.TenantId,
+			model.EventResourceFileShareAcl, aclUpdate.Id, oldStatus, acl.Status))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// DeleteFileShareAcl
+func (c *Client) DeleteFileShareAcl(ctx *c.Context, aclId string) error {
+	return fileShareAclTable.delete(c.db, ctx, aclId)
+}
+
+// CreateSnapshotSchedule
+func (c *Client) CreateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	if err := snapshotScheduleTable.insert(c.db, sched); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// GetSnapshotSchedule
+func (c *Client) GetSnapshotSchedule(ctx *c.Context, schedId string) (*model.SnapshotScheduleSpec, error) {
+	var sched = &model.SnapshotScheduleSpec{}
+	if err := snapshotScheduleTable.get(c.db, ctx, schedId, sched, false); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// ListSnapshotSchedules
+func (c *Client) ListSnapshotSchedules(ctx *c.Context) ([]*model.SnapshotScheduleSpec, error) {
+	var scheds []*model.SnapshotScheduleSpec
+	if err := snapshotScheduleTable.list(c.db, snapshotScheduleTable.newFilter(ctx), &scheds); err != nil {
+		return nil, err
+	}
+	return scheds, nil
+}
+
+// UpdateSnapshotSchedule replaces the schedule as a whole. If the resource
+// version of schedule is specified, the update fails with ConflictError when
+// the schedule has been modified since that version.
+func (c *Client) UpdateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	sched.UpdatedAt = now()
+	if err := snapshotScheduleTable.update(c.db, sched.Id, sched.ResourceVersion, sched); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// DeleteSnapshotSchedule
+func (c *Client) DeleteSnapshotSchedule(ctx *c.Context, schedId string) error {
+	return snapshotScheduleTable.delete(c.db, ctx, schedId)
+}
+
+// CreateJob
+func (c *Client) CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	job.TenantId = ctx.TenantId
+	if err := jobTable.insert(c.db, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob
+func (c *Client) GetJob(ctx *c.Context, jobId string) (*model.JobSpec, error) {
+	var job = &model.JobSpec{}
+	if err := jobTable.get(c.db, ctx, jobId, job, false); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListJobs
+func (c *Client) ListJobs(ctx *c.Context) ([]*model.JobSpec, error) {
+	return c.ListJobsWithFilter(ctx, nil)
+}
+
+// ListJobsWithFilter
+func (c *Client) ListJobsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.JobSpec, error) {
+	var jobs []*model.JobSpec
+	f := jobTable.newFilter(ctx).withParameters(m)
+	if err := jobTable.list(c.db, f, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// UpdateJob replaces the job as a whole. If the resource version of job is
+// specified, the update fails with ConflictError when the job has been
+// modified since that version.
+func (c *Client) UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	job.UpdatedAt = now()
+	if err := jobTable.update(c.db, job.Id, job.ResourceVersion, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// getQuota returns the quota of tenant, the quota which has never been set
+// is unlimited.
+func getQuota(q queryer, tenantId string, forUpdate bool) (*model.QuotaSpec, error) {
+	query := "SELECT body FROM quotas WHERE tenant_id = ?"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var body string
+	if err := q.QueryRow(query, tenantId).Scan(&body); err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewDefaultQuota(tenantId), nil
+		}
+		log.Error("When get quota in db:", err)
+		return nil, err
+	}
+
+	var quota = &model.QuotaSpec{}
+	if err := json.Unmarshal([]byte(body), quota); err != nil {
+		log.Error("When parsing quota in db:", err)
+		return nil, err
+	}
+	return quota, nil
+}
+
+// updateQuota applies the change to the quota of tenant in a transaction.
+func (c *Client) updateQuota(tenantId string, change func(quota *model.QuotaSpec) error) (*model.QuotaSpec, error) {
+	var quota *model.QuotaSpec
+	err := c.transaction(func(tx *sql.Tx) error {
+		var err error
+		if quota, err = getQuota(tx, tenantId, true); err != nil {
+			return err
+		}
+		if err = change(quota); err != nil {
+			return err
+		}
+
+		if quota.CreatedAt == "" {
+			quota.CreatedAt = now()
+		} else {
+			quota.UpdatedAt = now()
+		}
+		body, err := json.Marshal(quota)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec("REPLACE INTO quotas (tenant_id, body) VALUES (?, ?)", tenantId, string(body)); err != nil {
+			log.Error("When update quota in db:", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuota
+func (c *Client) GetQuota(ctx *c.Context, tenantId string) (*model.QuotaSpec, error) {
+	return getQuota(c.db, tenantId, false)
+}
+
+// UpdateQuota only changes the limits of quota, the usage is kept as it is.
+func (c *Client) UpdateQuota(ctx *c.Context, in *model.QuotaSpec) (*model.QuotaSpec, error) {
+	return c.updateQuota(in.TenantId, func(quota *model.QuotaSpec) error {
+		quota.Volumes = in.Volumes
+		quota.Gigabytes = in.Gigabytes
+		quota.Snapshots = in.Snapshots
+		quota.Replications = in.Replications
+		return nil
+	})
+}
+
+// ReserveQuota adds the delta to the usage of tenant atomically, and fails
+// with QuotaExceededError if any limit is exceeded.
+func (c *Client) ReserveQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	// Resources without tenant are not limited.
+	if tenantId == "" {
+		return nil
+	}
+	_, err := c.updateQuota(tenantId, func(quota *model.QuotaSpec) error {
+		return quota.Reserve(delta)
+	})
+	return err
+}
+
+// ReleaseQuota subtracts the delta from the usage of tenant atomically.
+func (c *Client) ReleaseQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	if tenantId == "" {
+		return nil
+	}
+	_, err := c.updateQuota(tenantId, func(quota *model.QuotaSpec) error {
+		quota.Release(delta)
+		return nil
+	})
+	return err
 }
@@ -0,0 +1,540 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+)
+
+func newMockClient(t *testing.T) (*Client, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal("Failed to create sqlmock:", err)
+	}
+	return NewClient(db), mock
+}
+
+func checkExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectExec(createMigrationTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	for _, m := range migrations {
+		for _, stmt := range m.statements {
+			mock.ExpectExec(stmt).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec("INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)").
+			WithArgs(m.version, m.description, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	if err := Migrate(fc.db); err != nil {
+		t.Error("Migrate failed:", err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestMigrateUpToDate(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectExec(createMigrationTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(migrations[len(migrations)-1].version))
+
+	if err := Migrate(fc.db); err != nil {
+		t.Error("Migrate failed:", err)
+	}
+	checkExpectations(t, mock)
+}
+
+// sampleVolumeWithVersion returns a copy of the sample volume, whose resource
+// version is read from the version column.
+func sampleVolumeWithVersion(version int64) *model.VolumeSpec {
+	var vol = SampleVolumes[0]
+	var base = *vol.BaseModel
+	base.ResourceVersion = version
+	vol.BaseModel = &base
+	return &vol
+}
+
+func TestCreateVolume(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT COUNT(*) FROM profiles").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO volumes (id, created_at, updated_at, tenant_id, user_id, " +
+		"name, description, availability_zone, size, status, pool_id, profile_id, group_id, body) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	var vol = SampleVolumes[0]
+	ctx := &c.Context{TenantId: "tenant"}
+	result, err := fc.CreateVolume(ctx, &vol)
+	if err != nil {
+		t.Error("Create volume failed:", err)
+	}
+	if result.TenantId != "tenant" {
+		t.Errorf("Expected tenant %s, got %s\n", "tenant", result.TenantId)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestCreateVolumeAlreadyExists(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT COUNT(*) FROM profiles").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO volumes (id, created_at, updated_at, tenant_id, user_id, " +
+		"name, description, availability_zone, size, status, pool_id, profile_id, group_id, body) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WillReturnError(&mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	var vol = SampleVolumes[0]
+	_, err := fc.CreateVolume(c.NewAdminContext(), &vol)
+	if _, ok := err.(*model.ConflictError); !ok {
+		t.Errorf("Expected ConflictError, got %v\n", err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestCreateVolumeWithoutProfile(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT COUNT(*) FROM profiles").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	if _, err := fc.CreateVolume(c.NewAdminContext(), &model.VolumeSpec{BaseModel: &model.BaseModel{}}); err == nil {
+		t.Error("Expected error when no profile in db")
+	}
+	checkExpectations(t, mock)
+}
+
+func TestGetVolume(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (id = ?)").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 1))
+
+	vol, err := fc.GetVolume(c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	if err != nil {
+		t.Error("Get volume failed:", err)
+	}
+
+	var expected = sampleVolumeWithVersion(1)
+	if !reflect.DeepEqual(vol, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, vol)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestGetVolumeNotFound(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (tenant_id = ?) AND (id = ?)").
+		WithArgs("tenant", "bd5b12a8-a101-11e7-941e-d77981b584d8").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}))
+
+	ctx := &c.Context{TenantId: "tenant"}
+	_, err := fc.GetVolume(ctx, "bd5b12a8-a101-11e7-941e-d77981b584d8")
+	if _, ok := err.(*model.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v\n", err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestListVolumesWithFilter(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (pool_id = ?) ORDER BY name ASC, id ASC LIMIT ? OFFSET ?").
+		WithArgs("084bf71e-a102-11e7-88a8-e31fe6d52248", 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 1))
+
+	m := map[string][]string{
+		"poolId":  {"084bf71e-a102-11e7-88a8-e31fe6d52248"},
+		"limit":   {"1"},
+		"offset":  {"2"},
+		"sortKey": {"name"},
+		"sortDir": {"asc"},
+		"unknown": {"ignored"},
+	}
+	vols, err := fc.ListVolumesWithFilter(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List volumes failed:", err)
+	}
+
+	var expected = []*model.VolumeSpec{sampleVolumeWithVersion(1)}
+	if !reflect.DeepEqual(vols, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, vols)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestListVolumesWithPage(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT COUNT(*) FROM volumes WHERE (pool_id = ?)").
+		WithArgs("084bf71e-a102-11e7-88a8-e31fe6d52248").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (pool_id = ?) ORDER BY id DESC LIMIT ? OFFSET ?").
+		WithArgs("084bf71e-a102-11e7-88a8-e31fe6d52248", 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 1))
+
+	m := map[string][]string{
+		"poolId": {"084bf71e-a102-11e7-88a8-e31fe6d52248"},
+		"limit":  {"1"},
+		"marker": {(&pageToken{Offset: 1}).encode()},
+	}
+	vols, page, err := fc.ListVolumesWithPage(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List volumes with page failed:", err)
+	}
+	if len(vols) != 1 || page.Total != 3 {
+		t.Errorf("Unexpected page %+v with volumes %+v\n", page, vols)
+	}
+	if next, _ := decodePageToken(page.Next); next == nil || next.Offset != 2 {
+		t.Errorf("Expected next offset 2, got %+v\n", next)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestListPoolsWithPage(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT COUNT(*) FROM pools").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("SELECT body, version FROM pools ORDER BY id DESC LIMIT ? OFFSET ?").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSlicePools[0], 1))
+
+	m := map[string][]string{
+		"limit":  {"1"},
+		"marker": {(&pageToken{Offset: 1}).encode()},
+	}
+	pols, page, err := fc.ListPoolsWithPage(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List pools with page failed:", err)
+	}
+	if len(pols) != 1 || page.Total != 2 || page.Next != "" {
+		t.Errorf("Unexpected page %+v with pools %+v\n", page, pols)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestListVolumesWithInvalidMarker(t *testing.T) {
+	fc, mock := newMockClient(t)
+
+	m := map[string][]string{"marker": {"invalid marker"}}
+	if _, _, err := fc.ListVolumesWithPage(c.NewAdminContext(), m); err == nil {
+		t.Error("Expected error when marker is invalid")
+	}
+	checkExpectations(t, mock)
+}
+
+func TestUpdateVolume(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (id = ?) FOR UPDATE").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 1))
+	mock.ExpectExec("UPDATE volumes SET id = ?, created_at = ?, updated_at = ?, tenant_id = ?, " +
+		"user_id = ?, name = ?, description = ?, availability_zone = ?, size = ?, status = ?, " +
+		"pool_id = ?, profile_id = ?, group_id = ?, body = ?, version = version + 1 WHERE id = ? AND version = ?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	vol, err := fc.UpdateVolume(c.NewAdminContext(), &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Name:      "updated-volume",
+	})
+	if err != nil {
+		t.Error("Update volume failed:", err)
+	}
+	if vol.Name != "updated-volume" || vol.Size != SampleVolumes[0].Size || vol.ResourceVersion != 2 {
+		t.Errorf("Unexpected volume after update: %+v\n", vol)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestUpdateVolumeWithStaleVersion(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (id = ?) FOR UPDATE").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 5))
+	mock.ExpectExec("UPDATE volumes SET id = ?, created_at = ?, updated_at = ?, tenant_id = ?, "+
+		"user_id = ?, name = ?, description = ?, availability_zone = ?, size = ?, status = ?, "+
+		"pool_id = ?, profile_id = ?, group_id = ?, body = ?, version = version + 1 WHERE id = ? AND version = ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"updated-volume", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := fc.UpdateVolume(c.NewAdminContext(), &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8", ResourceVersion: 4},
+		Name:      "updated-volume",
+	})
+	if _, ok := err.(*model.ConflictError); !ok {
+		t.Errorf("Expected ConflictError, got %v\n", err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestUpdateVolumeStatusRecordsEvent(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (id = ?) FOR UPDATE").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 1))
+	mock.ExpectExec("UPDATE volumes SET id = ?, created_at = ?, updated_at = ?, tenant_id = ?, " +
+		"user_id = ?, name = ?, description = ?, availability_zone = ?, size = ?, status = ?, " +
+		"pool_id = ?, profile_id = ?, group_id = ?, body = ?, version = version + 1 WHERE id = ? AND version = ?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO events (id, created_at, tenant_id, resource_type, resource_id, "+
+		"new_status, body) VALUES (?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "", model.EventResourceVolume,
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", model.VolumeDeleting, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err := fc.UpdateVolume(c.NewAdminContext(), &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		Status:    model.VolumeDeleting,
+	})
+	if err != nil {
+		t.Error("Update volume failed:", err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestUpdateVolumePolicyStatusRecordsEvent(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body, version FROM volumes WHERE (id = ?) FOR UPDATE").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSliceVolumes[0], 1))
+	mock.ExpectExec("UPDATE volumes SET id = ?, created_at = ?, updated_at = ?, tenant_id = ?, " +
+		"user_id = ?, name = ?, description = ?, availability_zone = ?, size = ?, status = ?, " +
+		"pool_id = ?, profile_id = ?, group_id = ?, body = ?, version = version + 1 WHERE id = ? AND version = ?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO events (id, created_at, tenant_id, resource_type, resource_id, "+
+		"new_status, body) VALUES (?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "", model.EventResourceVolumePolicy,
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", model.VolumePolicyError, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	vol, err := fc.UpdateVolume(c.NewAdminContext(), &model.VolumeSpec{
+		BaseModel:    &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		PolicyStatus: model.VolumePolicyError,
+	})
+	if err != nil {
+		t.Error("Update volume failed:", err)
+	}
+	if vol.PolicyStatus != model.VolumePolicyError {
+		t.Errorf("Expected %v, got %v\n", model.VolumePolicyError, vol.PolicyStatus)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestWatchEvents(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT seq, body FROM events WHERE (resource_id = ?) AND (seq > ?) ORDER BY seq ASC").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8", 6).
+		WillReturnRows(sqlmock.NewRows([]string{"seq", "body"}))
+	mock.ExpectQuery("SELECT seq, body FROM events WHERE (resource_id = ?) AND (seq > ?) ORDER BY seq ASC").
+		WithArgs("bd5b12a8-a101-11e7-941e-d77981b584d8", 6).
+		WillReturnRows(sqlmock.NewRows([]string{"seq", "body"}).AddRow(7, StringSliceEvents[0]))
+
+	eventPollInterval = time.Millisecond
+	m := map[string][]string{
+		"resourceId": {"bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		"since":      {"6"},
+	}
+	evts, err := fc.WatchEvents(c.NewAdminContext(), m, time.Second)
+	if err != nil {
+		t.Error("Watch events failed:", err)
+	}
+	if len(evts) != 1 || evts[0].ResourceVersion != 7 || evts[0].NewStatus != "available" {
+		t.Errorf("Unexpected events %+v\n", evts)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestHeartbeat(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectExec("REPLACE INTO heartbeats (dock_id, expires_at) VALUES (?, UNIX_TIMESTAMP() + ?)").
+		WithArgs("b7602e18-771e-11e7-8f38-dbd6d291f4e0", int64(30)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT dock_id FROM heartbeats WHERE expires_at > UNIX_TIMESTAMP()").
+		WillReturnRows(sqlmock.NewRows([]string{"dock_id"}).AddRow("b7602e18-771e-11e7-8f38-dbd6d291f4e0"))
+
+	if err := fc.Heartbeat(c.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0", 30*time.Second); err != nil {
+		t.Error("Put heartbeat failed:", err)
+	}
+	ids, err := fc.ListHeartbeats(c.NewAdminContext())
+	if err != nil {
+		t.Error("List heartbeats failed:", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"b7602e18-771e-11e7-8f38-dbd6d291f4e0"}) {
+		t.Errorf("Unexpected heartbeats %v\n", ids)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestAcquireLock(t *testing.T) {
+	fc, mock := newMockClient(t)
+	const acquire = "INSERT INTO locks (name, holder, expires_at) VALUES (?, ?, UNIX_TIMESTAMP() + ?) " +
+		"ON DUPLICATE KEY UPDATE " +
+		"holder = IF(expires_at <= UNIX_TIMESTAMP(), VALUES(holder), holder), " +
+		"expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)"
+	for _, holder := range []string{"osdslet-1", "osdslet-2"} {
+		mock.ExpectExec(acquire).
+			WithArgs("snapshot-scheduler", holder, int64(60)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT holder FROM locks WHERE name = ?").
+			WithArgs("snapshot-scheduler").
+			WillReturnRows(sqlmock.NewRows([]string{"holder"}).AddRow("osdslet-1"))
+	}
+
+	if ok, err := fc.AcquireLock(c.NewAdminContext(), "snapshot-scheduler", "osdslet-1", time.Minute); err != nil || !ok {
+		t.Errorf("Expected the lock to be acquired, got %v, %v\n", ok, err)
+	}
+	if ok, err := fc.AcquireLock(c.NewAdminContext(), "snapshot-scheduler", "osdslet-2", time.Minute); err != nil || ok {
+		t.Errorf("Expected the lock to be held by others, got %v, %v\n", ok, err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestListSnapshotSchedules(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT body, version FROM snapshot_schedules").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(`{"id": "6e9ec0f8-52b5-4ebd-9a6c-1b3b1fe5ff06", `+
+			`"tenantId": "ef305038-cd12-4f3b-90bd-0612f83e14ee", "volumeId": "bd5b12a8-a101-11e7-941e-d77981b584d8", `+
+			`"occurrence": "Daily", "retentionNumber": 3}`, 1))
+
+	// The schedules of all tenants are listed by the controller.
+	scheds, err := fc.ListSnapshotSchedules(c.NewInternalTenantContext("another-tenant", ""))
+	if err != nil {
+		t.Error("List snapshot schedules failed:", err)
+	}
+	if len(scheds) != 1 || scheds[0].VolumeId != "bd5b12a8-a101-11e7-941e-d77981b584d8" ||
+		scheds[0].RetentionNumber != 3 {
+		t.Errorf("Unexpected snapshot schedules %+v\n", scheds)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestCreateJob(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectExec("INSERT INTO jobs (id, created_at, updated_at, tenant_id, user_id, "+
+		"type, resource_id, status, host, body) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(SampleJobs[0].Id, SampleJobs[0].CreatedAt, "", "tenant", "", "createVolume",
+			SampleJobs[0].ResourceId, "succeeded", "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	var job = SampleJobs[0]
+	result, err := fc.CreateJob(&c.Context{TenantId: "tenant"}, &job)
+	if err != nil {
+		t.Error("Create job failed:", err)
+	}
+	if result.TenantId != "tenant" {
+		t.Errorf("Expected tenant %s, got %s\n", "tenant", result.TenantId)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestUpdatePoolStatus(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body, version FROM pools WHERE (id = ?) FOR UPDATE").
+		WithArgs("084bf71e-a102-11e7-88a8-e31fe6d52248").
+		WillReturnRows(sqlmock.NewRows([]string{"body", "version"}).AddRow(StringSlicePools[0], 1))
+	mock.ExpectExec("UPDATE pools SET id = ?, created_at = ?, updated_at = ?, name = ?, description = ?, "+
+		"status = ?, storage_type = ?, dock_id = ?, availability_zone = ?, total_capacity = ?, "+
+		"free_capacity = ?, body = ?, version = version + 1 WHERE id = ? AND version = ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "sample-pool-01", sqlmock.AnyArg(),
+			model.PoolUnavailable, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), "084bf71e-a102-11e7-88a8-e31fe6d52248", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	var pol = &model.StoragePoolSpec{BaseModel: &model.BaseModel{Id: "084bf71e-a102-11e7-88a8-e31fe6d52248"}}
+	if err := fc.UpdateStatus(c.NewAdminContext(), pol, model.PoolUnavailable); err != nil {
+		t.Error("Update pool status failed:", err)
+	}
+	if pol.Status != model.PoolUnavailable {
+		t.Errorf("Expected status %s, got %s\n", model.PoolUnavailable, pol.Status)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestGetQuotaDefault(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectQuery("SELECT body FROM quotas WHERE tenant_id = ?").
+		WithArgs("tenant").
+		WillReturnRows(sqlmock.NewRows([]string{"body"}))
+
+	quota, err := fc.GetQuota(c.NewAdminContext(), "tenant")
+	if err != nil {
+		t.Error("Get quota failed:", err)
+	}
+
+	var expected = model.NewDefaultQuota("tenant")
+	if !reflect.DeepEqual(quota, expected) {
+		t.Errorf("Expected %+v, got %+v\n", expected, quota)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestReserveQuota(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body FROM quotas WHERE tenant_id = ? FOR UPDATE").
+		WithArgs(SampleQuotas[0].TenantId).
+		WillReturnRows(sqlmock.NewRows([]string{"body"}).AddRow(ByteQuota))
+	mock.ExpectExec("REPLACE INTO quotas (tenant_id, body) VALUES (?, ?)").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	delta := &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}
+	if err := fc.ReserveQuota(c.NewAdminContext(), SampleQuotas[0].TenantId, delta); err != nil {
+		t.Error("Reserve quota failed:", err)
+	}
+	checkExpectations(t, mock)
+}
+
+func TestReserveQuotaExceeded(t *testing.T) {
+	fc, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT body FROM quotas WHERE tenant_id = ? FOR UPDATE").
+		WithArgs(SampleQuotas[0].TenantId).
+		WillReturnRows(sqlmock.NewRows([]string{"body"}).AddRow(ByteQuota))
+	mock.ExpectRollback()
+
+	delta := &model.QuotaUsageSpec{Volumes: 9}
+	err := fc.ReserveQuota(c.NewAdminContext(), SampleQuotas[0].TenantId, delta)
+	if _, ok := err.(*model.QuotaExceededError); !ok {
+		t.Errorf("Expected QuotaExceededError, got %v\n", err)
+	}
+	checkExpectations(t, mock)
+}
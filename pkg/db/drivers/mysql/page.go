@@ -0,0 +1,98 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the paginated list of resources in mysql database.
+
+*/
+
+package mysql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+// pageToken is the content of continuation token, the next page begins at
+// the offset in the filtered and sorted resources.
+type pageToken struct {
+	Offset int64 `json:"offset,omitempty"`
+}
+
+func (t *pageToken) encode() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(s string) (*pageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid marker: %s", s)
+	}
+	var t = &pageToken{}
+	if err = json.Unmarshal(b, t); err != nil || t.Offset < 0 {
+		return nil, fmt.Errorf("invalid marker: %s", s)
+	}
+	return t, nil
+}
+
+// withMarker continues the filter from the page marker of list request, the
+// marker takes precedence over the offset parameter.
+func (f *filter) withMarker(m map[string][]string) (*filter, error) {
+	if v, ok := m["marker"]; ok && len(v) > 0 && v[0] != "" {
+		t, err := decodePageToken(v[0])
+		if err != nil {
+			return nil, err
+		}
+		f.offset = t.Offset
+	}
+	return f, nil
+}
+
+// count returns the number of resources selected by the filter regardless
+// of paging.
+func (t *table) count(q queryer, f *filter) (int64, error) {
+	countFilter := &filter{table: t, conds: f.conds, args: f.args, limit: -1}
+	query, args := countFilter.build("SELECT COUNT(*) FROM " + t.name)
+
+	var total int64
+	if err := q.QueryRow(query, args...).Scan(&total); err != nil {
+		log.Errorf("When count %ss in db: %v", t.resource, err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// listPage lists a page of resources selected by the filter, and returns
+// the total number of them along with the marker of next page.
+func (t *table) listPage(q queryer, f *filter, out interface{}) (*model.PageSpec, error) {
+	total, err := t.count(q, f)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.list(q, f, out); err != nil {
+		return nil, err
+	}
+
+	var page = &model.PageSpec{Total: int(total)}
+	if end := f.offset + int64(reflect.ValueOf(out).Elem().Len()); f.limit > 0 && end < total {
+		page.Next = (&pageToken{Offset: end}).encode()
+	}
+	return page, nil
+}
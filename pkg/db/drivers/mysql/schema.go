@@ -0,0 +1,357 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the versioned schema of mysql database.
+
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/opensds/opensds/pkg/utils/constants"
+)
+
+// migration is a change of schema. The applied migrations are recorded in
+// schema_migrations table, so that each of them is applied only once.
+type migration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+// migrations must be sorted by version. Never modify a migration which has
+// been released, append a new one instead.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create initial tables",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS docks (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				storage_type VARCHAR(64) NOT NULL DEFAULT '',
+				endpoint VARCHAR(255) NOT NULL DEFAULT '',
+				driver_name VARCHAR(255) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS pools (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				storage_type VARCHAR(64) NOT NULL DEFAULT '',
+				dock_id VARCHAR(64) NOT NULL DEFAULT '',
+				availability_zone VARCHAR(255) NOT NULL DEFAULT '',
+				total_capacity BIGINT NOT NULL DEFAULT 0,
+				free_capacity BIGINT NOT NULL DEFAULT 0,
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_pools_dock_id (dock_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS profiles (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				storage_type VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_profiles_name (name)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS volumes (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				availability_zone VARCHAR(255) NOT NULL DEFAULT '',
+				size BIGINT NOT NULL DEFAULT 0,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				pool_id VARCHAR(64) NOT NULL DEFAULT '',
+				profile_id VARCHAR(64) NOT NULL DEFAULT '',
+				group_id VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_volumes_tenant_id (tenant_id),
+				INDEX idx_volumes_group_id (group_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS volume_attachments (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				volume_id VARCHAR(64) NOT NULL DEFAULT '',
+				mountpoint VARCHAR(1024) NOT NULL DEFAULT '',
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_volume_attachments_tenant_id (tenant_id),
+				INDEX idx_volume_attachments_volume_id (volume_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS volume_snapshots (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				size BIGINT NOT NULL DEFAULT 0,
+				volume_id VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_volume_snapshots_tenant_id (tenant_id),
+				INDEX idx_volume_snapshots_volume_id (volume_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS replications (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				primary_volume_id VARCHAR(64) NOT NULL DEFAULT '',
+				secondary_volume_id VARCHAR(64) NOT NULL DEFAULT '',
+				availability_zone VARCHAR(255) NOT NULL DEFAULT '',
+				replication_status VARCHAR(64) NOT NULL DEFAULT '',
+				profile_id VARCHAR(64) NOT NULL DEFAULT '',
+				pool_id VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_replications_tenant_id (tenant_id),
+				INDEX idx_replications_primary_volume_id (primary_volume_id),
+				INDEX idx_replications_secondary_volume_id (secondary_volume_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS volume_groups (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				availability_zone VARCHAR(255) NOT NULL DEFAULT '',
+				pool_id VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_volume_groups_tenant_id (tenant_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS quotas (
+				tenant_id VARCHAR(64) NOT NULL PRIMARY KEY,
+				body MEDIUMTEXT NOT NULL
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     2,
+		description: "create events table",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS events (
+				seq BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+				id VARCHAR(64) NOT NULL,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				resource_type VARCHAR(64) NOT NULL DEFAULT '',
+				resource_id VARCHAR(64) NOT NULL DEFAULT '',
+				new_status VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_events_tenant_id (tenant_id),
+				INDEX idx_events_resource_id (resource_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     3,
+		description: "create heartbeats table",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS heartbeats (
+				dock_id VARCHAR(64) NOT NULL PRIMARY KEY,
+				expires_at BIGINT NOT NULL
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     4,
+		description: "create group snapshots table",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS group_snapshots (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				group_id VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_group_snapshots_tenant_id (tenant_id),
+				INDEX idx_group_snapshots_group_id (group_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     5,
+		description: "create file shares and file share acls tables",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS file_shares (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				name VARCHAR(255) NOT NULL DEFAULT '',
+				description TEXT,
+				availability_zone VARCHAR(255) NOT NULL DEFAULT '',
+				size BIGINT NOT NULL DEFAULT 0,
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				pool_id VARCHAR(64) NOT NULL DEFAULT '',
+				profile_id VARCHAR(64) NOT NULL DEFAULT '',
+				protocol VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_file_shares_tenant_id (tenant_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS file_share_acls (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				file_share_id VARCHAR(64) NOT NULL DEFAULT '',
+				type VARCHAR(64) NOT NULL DEFAULT '',
+				access_to VARCHAR(255) NOT NULL DEFAULT '',
+				access_level VARCHAR(64) NOT NULL DEFAULT '',
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_file_share_acls_tenant_id (tenant_id),
+				INDEX idx_file_share_acls_file_share_id (file_share_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     6,
+		description: "create snapshot schedules and locks tables",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS snapshot_schedules (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				volume_id VARCHAR(64) NOT NULL DEFAULT '',
+				profile_id VARCHAR(64) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_snapshot_schedules_volume_id (volume_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+			`CREATE TABLE IF NOT EXISTS locks (
+				name VARCHAR(255) NOT NULL PRIMARY KEY,
+				holder VARCHAR(255) NOT NULL,
+				expires_at BIGINT NOT NULL
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     7,
+		description: "create jobs table",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS jobs (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				created_at VARCHAR(32) NOT NULL DEFAULT '',
+				updated_at VARCHAR(32) NOT NULL DEFAULT '',
+				tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				type VARCHAR(64) NOT NULL DEFAULT '',
+				resource_id VARCHAR(64) NOT NULL DEFAULT '',
+				status VARCHAR(64) NOT NULL DEFAULT '',
+				host VARCHAR(255) NOT NULL DEFAULT '',
+				body MEDIUMTEXT NOT NULL,
+				INDEX idx_jobs_tenant_id (tenant_id),
+				INDEX idx_jobs_status (status)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8`,
+		},
+	},
+	{
+		version:     8,
+		description: "add version column to resource tables",
+		statements: []string{
+			"ALTER TABLE docks ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE pools ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE profiles ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE volumes ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE volume_attachments ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE volume_snapshots ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE replications ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE volume_groups ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE group_snapshots ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE file_shares ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE file_share_acls ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE snapshot_schedules ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+			"ALTER TABLE jobs ADD COLUMN version BIGINT NOT NULL DEFAULT 1",
+		},
+	},
+}
+
+const createMigrationTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INT NOT NULL PRIMARY KEY,
+	description VARCHAR(255) NOT NULL,
+	applied_at VARCHAR(32) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8`
+
+// Migrate upgrades the schema of database to the latest version. Since the
+// DDL statements are committed implicitly by mysql, every statement must be
+// idempotent so that a migration interrupted in the middle can be retried.
+func Migrate(q queryer) error {
+	if _, err := q.Exec(createMigrationTable); err != nil {
+		log.Error("When create schema_migrations table:", err)
+		return err
+	}
+
+	// The version of a database which has never been migrated is 0.
+	var current int
+	err := q.QueryRow("SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		log.Error("When get current schema version:", err)
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		log.Infof("Applying schema migration %d: %s", m.version, m.description)
+		for _, stmt := range m.statements {
+			if _, err := q.Exec(stmt); err != nil && !isDuplicateColumn(err) {
+				log.Errorf("When apply schema migration %d: %v", m.version, err)
+				return err
+			}
+		}
+		if _, err := q.Exec("INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)",
+			m.version, m.description, time.Now().Format(constants.TimeFormat)); err != nil {
+			log.Errorf("When record schema migration %d: %v", m.version, err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,482 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the mapping between the data structures defined in
+model package and the tables in mysql database.
+
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	log "github.com/golang/glog"
+	c "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/model"
+)
+
+const (
+	defaultSortDir = "DESC"
+	defaultSortKey = "ID"
+
+	// errDuplicateEntry is the error number of mysql server for the duplicate
+	// entry of primary key or unique index.
+	errDuplicateEntry = 1062
+	// errDuplicateColumn is the error number of mysql server for adding a
+	// column which already exists.
+	errDuplicateColumn = 1060
+)
+
+// The keys of list parameters which are used for paging and sorting, all the
+// other keys are used for filtering.
+var pageKeys = []string{"limit", "offset", "sortDir", "sortKey", "marker"}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// column maps a field of resource to a column of table, the field name is
+// also the key used for filtering and sorting resources in list requests.
+type column struct {
+	field string
+	name  string
+}
+
+// table describes how a kind of resource is stored. Every resource is stored
+// as a json document in the body column, and the fields which are used for
+// filtering and sorting are duplicated into indexed columns. The version
+// column is increased by every update, and it's reported as the resource
+// version of resource for optimistic concurrency control.
+type table struct {
+	name     string
+	resource string
+	// Resources owned by tenants can only be accessed by their owners or
+	// admin, and such tables must have a tenant_id column.
+	tenantScoped bool
+	columns      []column
+}
+
+func newTable(name, resource string, tenantScoped bool, fields ...string) *table {
+	t := &table{name: name, resource: resource, tenantScoped: tenantScoped}
+	for _, f := range fields {
+		t.columns = append(t.columns, column{field: f, name: toSnakeCase(f)})
+	}
+	return t
+}
+
+var (
+	dockTable = newTable("docks", "dock", false,
+		"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Status",
+		"StorageType", "Endpoint", "DriverName")
+	poolTable = newTable("pools", "pool", false,
+		"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "Status",
+		"StorageType", "DockId", "AvailabilityZone", "TotalCapacity", "FreeCapacity")
+	profileTable = newTable("profiles", "profile", false,
+		"Id", "CreatedAt", "UpdatedAt", "Name", "Description", "StorageType")
+	volumeTable = newTable("volumes", "volume", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Name", "Description",
+		"AvailabilityZone", "Size", "Status", "PoolId", "ProfileId", "GroupId")
+	attachmentTable = newTable("volume_attachments", "volume attachment", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "VolumeId",
+		"Mountpoint", "Status")
+	snapshotTable = newTable("volume_snapshots", "volume snapshot", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Name", "Description",
+		"Status", "Size", "VolumeId")
+	replicationTable = newTable("replications", "replication", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Name", "Description",
+		"PrimaryVolumeId", "SecondaryVolumeId", "AvailabilityZone",
+		"ReplicationStatus", "ProfileId", "PoolId")
+	volumeGroupTable = newTable("volume_groups", "volume group", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Name", "Description",
+		"Status", "AvailabilityZone", "PoolId")
+	groupSnapshotTable = newTable("group_snapshots", "group snapshot", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Name", "Description",
+		"Status", "GroupId")
+	fileShareTable = newTable("file_shares", "file share", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Name", "Description",
+		"AvailabilityZone", "Size", "Status", "PoolId", "ProfileId", "Protocol")
+	fileShareAclTable = newTable("file_share_acls", "file share acl", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "FileShareId",
+		"Type", "AccessTo", "AccessLevel", "Status")
+	// The snapshot schedules are only accessed by the controller, so they
+	// aren't scoped by tenants although the owner is recorded.
+	snapshotScheduleTable = newTable("snapshot_schedules", "snapshot schedule", false,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "VolumeId", "ProfileId")
+	jobTable = newTable("jobs", "job", true,
+		"Id", "CreatedAt", "UpdatedAt", "TenantId", "UserId", "Type", "ResourceId",
+		"Status", "Host")
+)
+
+// toSnakeCase converts the field name such as AvailabilityZone to the column
+// name such as availability_zone.
+func toSnakeCase(s string) string {
+	var b []rune
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b = append(b, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b = append(b, r)
+	}
+	return string(b)
+}
+
+func (t *table) columnNames() []string {
+	var names []string
+	for _, col := range t.columns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// values returns the values of columns and body of the resource.
+func (t *table) values(res interface{}) ([]interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(res))
+	var vals []interface{}
+	for _, col := range t.columns {
+		vals = append(vals, v.FieldByName(col.field).Interface())
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return append(vals, string(body)), nil
+}
+
+// insert creates the resource, ConflictError is returned if the resource
+// which has the same id already exists.
+func (t *table) insert(q queryer, res interface{}) error {
+	vals, err := t.values(res)
+	if err != nil {
+		return err
+	}
+	names := append(t.columnNames(), "body")
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.name,
+		strings.Join(names, ", "), placeholders(len(names)))
+	if _, err := q.Exec(query, vals...); err != nil {
+		if isDuplicateEntry(err) {
+			// The first column of every table is id.
+			return model.NewConflictError(fmt.Sprintf("%s(%v) already exists", t.resource, vals[0]))
+		}
+		log.Errorf("When insert %s in db: %v", t.resource, err)
+		return err
+	}
+	return nil
+}
+
+// put creates the resource, or replaces it if it already exists. It's only
+// used for the docks and pools, which are registered again every time the
+// dock discovers the backends.
+func (t *table) put(q queryer, res interface{}) error {
+	vals, err := t.values(res)
+	if err != nil {
+		return err
+	}
+	names := append(t.columnNames(), "body")
+	var sets []string
+	for _, name := range names {
+		sets = append(sets, name+" = VALUES("+name+")")
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s, version = version + 1",
+		t.name, strings.Join(names, ", "), placeholders(len(names)), strings.Join(sets, ", "))
+	if _, err := q.Exec(query, vals...); err != nil {
+		log.Errorf("When put %s in db: %v", t.resource, err)
+		return err
+	}
+	return nil
+}
+
+// isDuplicateEntry reports whether err is caused by the violation of primary
+// key or unique index.
+func isDuplicateEntry(err error) bool {
+	e, ok := err.(*mysqldriver.MySQLError)
+	return ok && e.Number == errDuplicateEntry
+}
+
+// isDuplicateColumn reports whether err is caused by adding a column which
+// already exists, so that the statement adding columns is idempotent.
+func isDuplicateColumn(err error) bool {
+	e, ok := err.(*mysqldriver.MySQLError)
+	return ok && e.Number == errDuplicateColumn
+}
+
+// expectedVersion returns the version which the update is based on. The
+// resource version specified by caller takes precedence over the current one,
+// so that the update fails if the caller's copy of resource is stale.
+func expectedVersion(specified *model.BaseModel, current int64) int64 {
+	if specified != nil && specified.ResourceVersion != 0 {
+		return specified.ResourceVersion
+	}
+	return current
+}
+
+// update replaces the existing resource which has the same id. If version is
+// not 0, the update fails with ConflictError when the resource has been
+// modified since that version. The resource version of res is set to the new
+// version on success.
+func (t *table) update(q queryer, id string, version int64, res interface{}) error {
+	vals, err := t.values(res)
+	if err != nil {
+		return err
+	}
+	var sets []string
+	for _, name := range append(t.columnNames(), "body") {
+		sets = append(sets, name+" = ?")
+	}
+	query := fmt.Sprintf("UPDATE %s SET %s, version = version + 1 WHERE id = ?", t.name, strings.Join(sets, ", "))
+	args := append(vals, id)
+	if version != 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	result, err := q.Exec(query, args...)
+	if err != nil {
+		log.Errorf("When update %s in db: %v", t.resource, err)
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return model.NewConflictError(fmt.Sprintf("%s(%s) has been modified by others", t.resource, id))
+	}
+	setVersion(res, version+1)
+	return nil
+}
+
+// setVersion sets the resource version of res, which embeds the base model.
+func setVersion(res interface{}, version int64) {
+	b := reflect.Indirect(reflect.ValueOf(res)).FieldByName("BaseModel")
+	if b.IsValid() && !b.IsNil() {
+		b.Interface().(*model.BaseModel).ResourceVersion = version
+	}
+}
+
+// get decodes the resource into out. If forUpdate is true, the row is locked
+// until the end of current transaction.
+func (t *table) get(q queryer, ctx *c.Context, id string, out interface{}, forUpdate bool) error {
+	f := t.newFilter(ctx).where("id = ?", id)
+	query, args := f.build("SELECT body, version FROM " + t.name)
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var body string
+	var version int64
+	if err := q.QueryRow(query, args...).Scan(&body, &version); err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError(fmt.Sprintf("specified %s(%s) can't find", t.resource, id))
+		}
+		log.Errorf("When get %s in db: %v", t.resource, err)
+		return err
+	}
+	if err := json.Unmarshal([]byte(body), out); err != nil {
+		log.Errorf("When parsing %s in db: %v", t.resource, err)
+		return err
+	}
+	setVersion(out, version)
+	return nil
+}
+
+// list decodes the resources selected by filter into out, which must be a
+// pointer to a slice of resource pointers.
+func (t *table) list(q queryer, f *filter, out interface{}) error {
+	query, args := f.build("SELECT body, version FROM " + t.name)
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		log.Errorf("When list %ss in db: %v", t.resource, err)
+		return err
+	}
+	defer rows.Close()
+
+	slice := reflect.ValueOf(out).Elem()
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+	for rows.Next() {
+		var body string
+		var version int64
+		if err := rows.Scan(&body, &version); err != nil {
+			return err
+		}
+		item := reflect.New(slice.Type().Elem().Elem())
+		if err := json.Unmarshal([]byte(body), item.Interface()); err != nil {
+			log.Errorf("When parsing %s in db: %v", t.resource, err)
+			return err
+		}
+		setVersion(item.Interface(), version)
+		slice.Set(reflect.Append(slice, item))
+	}
+	return rows.Err()
+}
+
+// delete removes the resource, it's not an error if the resource doesn't
+// exist.
+func (t *table) delete(q queryer, ctx *c.Context, id string) error {
+	query, args := t.newFilter(ctx).where("id = ?", id).build("DELETE FROM " + t.name)
+	if _, err := q.Exec(query, args...); err != nil {
+		log.Errorf("When delete %s in db: %v", t.resource, err)
+		return err
+	}
+	return nil
+}
+
+// filter is the where, order by and limit clauses of a query.
+type filter struct {
+	table  *table
+	conds  []string
+	args   []interface{}
+	order  string
+	limit  int64
+	offset int64
+}
+
+// newFilter returns a filter which selects all the resources visible in
+// the context.
+func (t *table) newFilter(ctx *c.Context) *filter {
+	f := &filter{table: t, limit: -1}
+	// Admin user should get all resources including the ones whose tenant is
+	// not admin.
+	if t.tenantScoped && !ctx.IsAdmin {
+		f.where("tenant_id = ?", ctx.TenantId)
+	}
+	return f
+}
+
+// where adds a condition to the filter.
+func (f *filter) where(cond string, args ...interface{}) *filter {
+	f.conds = append(f.conds, "("+cond+")")
+	f.args = append(f.args, args...)
+	return f
+}
+
+// withParameters applies the filtering, sorting and paging parameters of list
+// request to the filter. Keys which don't match any column are ignored.
+func (f *filter) withParameters(m map[string][]string) *filter {
+	for key, vals := range m {
+		if isPageKey(key) || len(vals) == 0 {
+			continue
+		}
+		col, ok := f.table.findColumn(key)
+		if !ok {
+			log.Warningf("Filter key %s of %s is not supported, ignore it.", key, f.table.resource)
+			continue
+		}
+		f.where(col.name+" = ?", vals[0])
+	}
+
+	sortKey, sortDir := defaultSortKey, defaultSortDir
+	if v, ok := m["sortKey"]; ok && len(v) > 0 {
+		sortKey = v[0]
+	}
+	if v, ok := m["sortDir"]; ok && len(v) > 0 {
+		if strings.EqualFold(v[0], "asc") || strings.EqualFold(v[0], "desc") {
+			sortDir = strings.ToUpper(v[0])
+		} else {
+			log.Warningf("Invalid input sortDir: %s, use default value instead: %s", v[0], defaultSortDir)
+		}
+	}
+	col, ok := f.table.findColumn(sortKey)
+	if !ok {
+		log.Warningf("Invalid input sortKey: %s, use default value instead: %s", sortKey, defaultSortKey)
+		col, _ = f.table.findColumn(defaultSortKey)
+	}
+	f.order = col.name + " " + sortDir
+	// Sort by id in addition to keep the order stable between pages.
+	if col.name != "id" {
+		f.order += ", id " + sortDir
+	}
+
+	// If the limit is not specified, return all the items.
+	f.limit = parseNonNegative(m, "limit", -1)
+	f.offset = parseNonNegative(m, "offset", 0)
+	return f
+}
+
+// build appends the clauses of filter to the statement.
+func (f *filter) build(stmt string) (string, []interface{}) {
+	query := stmt
+	if len(f.conds) > 0 {
+		query += " WHERE " + strings.Join(f.conds, " AND ")
+	}
+	if f.order != "" {
+		query += " ORDER BY " + f.order
+	}
+	args := append([]interface{}{}, f.args...)
+	if f.limit >= 0 || f.offset > 0 {
+		limit := f.limit
+		if limit < 0 {
+			// Mysql doesn't support offset without limit.
+			limit = math.MaxInt64
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, f.offset)
+	}
+	return query, args
+}
+
+// findColumn looks up the column by field name case-insensitively, and the
+// legacy key ProjectId is treated as TenantId.
+func (t *table) findColumn(key string) (column, bool) {
+	if strings.EqualFold(key, "ProjectId") {
+		key = "TenantId"
+	}
+	for _, col := range t.columns {
+		if strings.EqualFold(col.field, key) {
+			return col, true
+		}
+	}
+	return column{}, false
+}
+
+func isPageKey(key string) bool {
+	for _, k := range pageKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNonNegative(m map[string][]string, key string, defaultValue int64) int64 {
+	v, ok := m[key]
+	if !ok || len(v) == 0 {
+		return defaultValue
+	}
+	n, err := strconv.ParseInt(v[0], 10, 64)
+	if err != nil || n < 0 {
+		log.Warningf("Invalid input %s: %s, use default value instead: %d", key, v[0], defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+)
+
+// Pool caches the connected dock clients keyed by the endpoint of dock. The
+// gRPC connection under a client is safe for concurrent use, so a client is
+// shared by all the requests sent to the same dock and is never closed by
+// them.
+type Pool struct {
+	newClient func() Client
+
+	mutex   sync.Mutex
+	clients map[string]Client
+}
+
+// NewPool creates a pool which connects to the docks with the clients
+// created by newClient.
+func NewPool(newClient func() Client) *Pool {
+	return &Pool{
+		newClient: newClient,
+		clients:   make(map[string]Client),
+	}
+}
+
+// Get returns the client connected to the dock at edp, the connection is set
+// up on the first request to the dock.
+func (p *Pool) Get(edp string) (Client, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if c, ok := p.clients[edp]; ok {
+		return c, nil
+	}
+	c := p.newClient()
+	if err := c.Connect(edp); err != nil {
+		return nil, err
+	}
+	p.clients[edp] = c
+	return c, nil
+}
+
+// Close closes the clients of all docks.
+func (p *Pool) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for edp, c := range p.clients {
+		c.Close()
+		delete(p.clients, edp)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	dockclient "github.com/opensds/opensds/testutils/dock/testing"
+)
+
+func TestPoolGet(t *testing.T) {
+	var created []*dockclient.Client
+	p := NewPool(func() Client {
+		c := new(dockclient.Client)
+		c.On("Connect", "localhost:50050").Return(nil)
+		c.On("Connect", "localhost:50051").Return(nil)
+		c.On("Close").Return()
+		created = append(created, c)
+		return c
+	})
+
+	c1, err := p.Get("localhost:50050")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := p.Get("localhost:50050")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("Expected the client of the same dock to be shared")
+	}
+	c3, err := p.Get("localhost:50051")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c3 == c1 {
+		t.Error("Expected the clients of different docks not to be shared")
+	}
+	if len(created) != 2 {
+		t.Errorf("Expected 2 clients created, got %d", len(created))
+	}
+	created[0].AssertNumberOfCalls(t, "Connect", 1)
+
+	p.Close()
+	for _, c := range created {
+		c.AssertCalled(t, "Close")
+	}
+}
+
+func TestPoolGetWithConnectError(t *testing.T) {
+	var connectErr = errors.New("connection refused")
+	var n int
+	p := NewPool(func() Client {
+		n++
+		c := new(dockclient.Client)
+		c.On("Connect", "localhost:50050").Return(connectErr)
+		return c
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.Get("localhost:50050"); err != connectErr {
+			t.Errorf("Expected %v, got %v", connectErr, err)
+		}
+	}
+	// The client failing to connect is not kept in the pool.
+	if n != 2 {
+		t.Errorf("Expected 2 clients created, got %d", n)
+	}
+}
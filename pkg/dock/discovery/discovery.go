@@ -24,6 +24,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/golang/glog"
@@ -63,12 +64,32 @@ func DiscoveryAndReport(dd DockDiscoverer, ctx *Context) {
 	}
 }
 
+// SendHeartbeats sends the heartbeats of docks every interval until it's
+// stopped, the docks are regarded as down by the controller if there is no
+// heartbeat in ttl.
+func SendHeartbeats(dd DockDiscoverer, ctx *Context, interval, ttl time.Duration) {
+	for {
+		select {
+		case <-ctx.StopChan:
+			return
+		default:
+			if err := dd.Heartbeat(ttl); err != nil {
+				log.Error("When send heartbeat:", err)
+			}
+		}
+
+		time.Sleep(interval)
+	}
+}
+
 type DockDiscoverer interface {
 	Init() error
 
 	Discover() error
 
 	Report() error
+
+	Heartbeat(ttl time.Duration) error
 }
 
 // NewDockDiscoverer method creates a new DockDiscoverer.
@@ -93,6 +114,10 @@ type provisionDockDiscoverer struct {
 
 	dcks []*model.DockSpec
 	pols []*model.StoragePoolSpec
+	// The docks whose pools are listed by the backends in the last discovery.
+	listed map[string]bool
+	// The max over-subscription ratio configured for the backend of each dock.
+	ratios map[string]float64
 }
 
 func (pdd *provisionDockDiscoverer) Init() error {
@@ -120,21 +145,43 @@ func (pdd *provisionDockDiscoverer) Init() error {
 			Endpoint:    CONF.OsdsDock.ApiEndpoint,
 			NodeId:      host,
 			Type:        model.DockTypeProvioner,
+			Status:      model.DockAvailable,
 			Metadata:    map[string]string{"HostReplicationDriver": CONF.OsdsDock.HostBasedReplicationDriver},
 		}
 		pdd.dcks = append(pdd.dcks, dck)
+		if pdd.ratios == nil {
+			pdd.ratios = make(map[string]float64)
+		}
+		pdd.ratios[dck.Id] = b.MaxOverSubscriptionRatio
 	}
 
 	return nil
 }
 
+// listPools lists the pools of backend by the file share driver if the backend
+// provides file shares, or by the volume driver otherwise.
+func listPools(driverName string) ([]*model.StoragePoolSpec, error) {
+	if drivers.IsFileShareDriver(driverName) {
+		d := drivers.InitFileShareDriver(driverName)
+		defer drivers.CleanFileShareDriver(d)
+		return d.ListPools()
+	}
+	d, err := drivers.Init(driverName)
+	if err != nil {
+		return nil, err
+	}
+	defer drivers.Clean(d)
+	return d.ListPools()
+}
+
 func (pdd *provisionDockDiscoverer) Discover() error {
 	// Clear existing pool info
 	pdd.pols = pdd.pols[:0]
+	pdd.listed = make(map[string]bool)
 
 	for _, dck := range pdd.dcks {
 		// Call function of StorageDrivers configured by storage drivers.
-		pols, err := drivers.Init(dck.DriverName).ListPools()
+		pols, err := listPools(dck.DriverName)
 		if err != nil {
 			log.Error("Call driver to list pools failed:", err)
 			continue
@@ -143,6 +190,7 @@ func (pdd *provisionDockDiscoverer) Discover() error {
 		if len(pols) == 0 {
 			log.Warningf("The pool of dock %s is empty!\n", dck.Id)
 		}
+		pdd.listed[dck.Id] = true
 
 		replicationDriverName := dck.Metadata["HostReplicationDriver"]
 		replicationType := model.ReplicationTypeHost
@@ -153,8 +201,13 @@ func (pdd *provisionDockDiscoverer) Discover() error {
 		for _, pol := range pols {
 			log.Infof("Backend %s discovered pool %s", dck.DriverName, pol.Name)
 			pol.DockId = dck.Id
+			pol.Status = model.PoolAvailable
 			pol.ReplicationType = replicationType
 			pol.ReplicationDriverName = replicationDriverName
+			// The ratio reported by the driver itself takes precedence.
+			if pol.MaxOverSubscriptionRatio == 0 {
+				pol.MaxOverSubscriptionRatio = pdd.ratios[dck.Id]
+			}
 		}
 		pdd.pols = append(pdd.pols, pols...)
 	}
@@ -183,7 +236,47 @@ func (pdd *provisionDockDiscoverer) Report() error {
 		err = pdd.Register(pol)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+	return pdd.removeStalePools()
+}
+
+// removeStalePools removes the pools which the backends no longer report. The
+// pools of docks whose backend failed to list pools are kept.
+func (pdd *provisionDockDiscoverer) removeStalePools() error {
+	if len(pdd.listed) == 0 {
+		return nil
+	}
+	pols, err := pdd.c.ListPools(c.NewAdminContext())
+	if err != nil {
+		log.Error("When list pools in db:", err)
+		return err
+	}
+
+	var reported = make(map[string]bool)
+	for _, pol := range pdd.pols {
+		reported[pol.Id] = true
+	}
+	for _, pol := range pols {
+		if !pdd.listed[pol.DockId] || reported[pol.Id] {
+			continue
+		}
+		log.Warningf("Pool %s is no longer reported by dock %s, remove it.", pol.Id, pol.DockId)
+		if err := pdd.Unregister(pol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pdd *provisionDockDiscoverer) Heartbeat(ttl time.Duration) error {
+	for _, dck := range pdd.dcks {
+		if err := pdd.DockRegister.Heartbeat(dck, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // attachDockDiscoverer is a struct for exposing some operations of attach
@@ -191,7 +284,10 @@ func (pdd *provisionDockDiscoverer) Report() error {
 type attachDockDiscoverer struct {
 	*DockRegister
 
-	dck *model.DockSpec
+	// The lock protects dck, which is discovered again while the heartbeat
+	// of it is being sent.
+	lock sync.Mutex
+	dck  *model.DockSpec
 }
 
 func (add *attachDockDiscoverer) Init() error { return nil }
@@ -220,13 +316,14 @@ func (add *attachDockDiscoverer) Discover() error {
 	wwpns, _ := fc.GetWWPNs()
 	segments := strings.Split(CONF.OsdsDock.ApiEndpoint, ":")
 	endpointIp := segments[len(segments)-2]
-	add.dck = &model.DockSpec{
+	dck := &model.DockSpec{
 		BaseModel: &model.BaseModel{
 			Id: uuid.NewV5(uuid.NamespaceOID, host+":"+endpointIp).String(),
 		},
 		Endpoint: CONF.OsdsDock.ApiEndpoint,
 		NodeId:   host,
 		Type:     model.DockTypeAttacher,
+		Status:   model.DockAvailable,
 		Metadata: map[string]string{
 			"Platform":  runtime.GOARCH,
 			"OsType":    runtime.GOOS,
@@ -235,13 +332,29 @@ func (add *attachDockDiscoverer) Discover() error {
 			"WWPNS":     strings.Join(wwpns, ","),
 		},
 	}
+
+	add.lock.Lock()
+	defer add.lock.Unlock()
+	add.dck = dck
 	return nil
 }
 
 func (add *attachDockDiscoverer) Report() error {
+	add.lock.Lock()
+	defer add.lock.Unlock()
 	return add.Register(add.dck)
 }
 
+func (add *attachDockDiscoverer) Heartbeat(ttl time.Duration) error {
+	add.lock.Lock()
+	defer add.lock.Unlock()
+	// The dock hasn't been discovered yet.
+	if add.dck == nil {
+		return nil
+	}
+	return add.DockRegister.Heartbeat(add.dck, ttl)
+}
+
 func NewDockRegister() *DockRegister {
 	return &DockRegister{c: db.C}
 }
@@ -303,3 +416,12 @@ func (dr *DockRegister) Unregister(in interface{}) error {
 
 	return nil
 }
+
+// Heartbeat tells the controller that the dock is alive in ttl.
+func (dr *DockRegister) Heartbeat(dck *model.DockSpec, ttl time.Duration) error {
+	if err := dr.c.Heartbeat(c.NewAdminContext(), dck.Id, ttl); err != nil {
+		log.Errorf("When put heartbeat of dock %s in db: %v\n", dck.Id, err)
+		return err
+	}
+	return nil
+}
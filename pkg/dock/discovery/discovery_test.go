@@ -17,6 +17,7 @@ package discovery
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/model"
@@ -56,7 +57,11 @@ func TestInit(t *testing.T) {
 	var expected []*model.DockSpec
 
 	for i := range SampleDocks {
-		expected = append(expected, &SampleDocks[i])
+		dck := SampleDocks[i]
+		// Don't share the base model with sample dock, whose id is cleared below.
+		dck.BaseModel = &model.BaseModel{}
+		dck.Status = model.DockAvailable
+		expected = append(expected, &dck)
 	}
 	if err := fdd.Init(); err != nil {
 		t.Errorf("Failed to init discoverer struct: %v\n", err)
@@ -65,7 +70,6 @@ func TestInit(t *testing.T) {
 		fdd.dcks[i].Id = ""
 		fdd.dcks[i].NodeId = ""
 		fdd.dcks[i].Metadata = nil
-		expected[i].Id = ""
 	}
 	if !reflect.DeepEqual(fdd.dcks, expected) {
 		t.Errorf("Expected %+v, got %+v\n", expected, fdd.dcks)
@@ -76,18 +80,21 @@ func TestDiscover(t *testing.T) {
 	var fdd = NewFakeDockDiscoverer()
 	var expected []*model.StoragePoolSpec
 
+	fdd.ratios = make(map[string]float64)
 	for i := range SampleDocks {
 		fdd.dcks = append(fdd.dcks, &SampleDocks[i])
+		fdd.ratios[SampleDocks[i].Id] = 1.5
 	}
 	for i := range SamplePools {
-		expected = append(expected, &SamplePools[i])
+		pol := SamplePools[i]
+		pol.Status = model.PoolAvailable
+		pol.ReplicationType = model.ReplicationTypeHost
+		pol.MaxOverSubscriptionRatio = 1.5
+		expected = append(expected, &pol)
 	}
 	if err := fdd.Discover(); err != nil {
 		t.Errorf("Failed to discoverer pools: %v\n", err)
 	}
-	for _, pol := range fdd.pols {
-		pol.Id = ""
-	}
 	if !reflect.DeepEqual(fdd.pols, expected) {
 		t.Errorf("Expected %+v, got %+v\n", expected, fdd.pols)
 	}
@@ -113,3 +120,48 @@ func TestReport(t *testing.T) {
 		t.Errorf("Failed to store docks and pools into database: %v\n", err)
 	}
 }
+
+func TestReportRemovesStalePools(t *testing.T) {
+	var fdd = NewFakeDockDiscoverer()
+
+	for i := range SampleDocks {
+		fdd.dcks = append(fdd.dcks, &SampleDocks[i])
+	}
+	fdd.pols = append(fdd.pols, &SamplePools[0])
+	fdd.listed = map[string]bool{SampleDocks[0].Id: true}
+	var stale = SamplePools[1]
+	var other = &model.StoragePoolSpec{
+		BaseModel: &model.BaseModel{Id: "3b5e5a46-4e5c-11e9-9f4e-4f3b8c3e6a01"},
+		DockId:    "other-dock",
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("CreateDock", c.NewAdminContext(), fdd.dcks[0]).Return(nil, nil)
+	mockClient.On("CreatePool", c.NewAdminContext(), fdd.pols[0]).Return(nil, nil)
+	mockClient.On("ListPools", c.NewAdminContext()).
+		Return([]*model.StoragePoolSpec{&SamplePools[0], &stale, other}, nil)
+	mockClient.On("DeletePool", c.NewAdminContext(), stale.Id).Return(nil)
+	fdd.c = mockClient
+
+	if err := fdd.Report(); err != nil {
+		t.Errorf("Failed to store docks and pools into database: %v\n", err)
+	}
+	mockClient.AssertNumberOfCalls(t, "DeletePool", 1)
+}
+
+func TestHeartbeat(t *testing.T) {
+	var fdd = NewFakeDockDiscoverer()
+
+	for i := range SampleDocks {
+		fdd.dcks = append(fdd.dcks, &SampleDocks[i])
+	}
+
+	mockClient := new(dbtest.Client)
+	mockClient.On("Heartbeat", c.NewAdminContext(), SampleDocks[0].Id, 30*time.Second).Return(nil)
+	fdd.c = mockClient
+
+	if err := fdd.Heartbeat(30 * time.Second); err != nil {
+		t.Errorf("Failed to send heartbeat: %v\n", err)
+	}
+	mockClient.AssertExpectations(t)
+}
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	log "github.com/golang/glog"
@@ -34,13 +35,19 @@ import (
 	"github.com/opensds/opensds/pkg/dock/discovery"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
+	"github.com/opensds/opensds/pkg/utils"
+	. "github.com/opensds/opensds/pkg/utils/config"
 	"github.com/opensds/opensds/pkg/utils/constants"
+	"github.com/opensds/opensds/pkg/utils/exec"
 
 	_ "github.com/opensds/opensds/contrib/connector/fc"
 	_ "github.com/opensds/opensds/contrib/connector/iscsi"
 	_ "github.com/opensds/opensds/contrib/connector/rbd"
 )
 
+// copyBlockSize is the block size in bytes used when copying volume data.
+const copyBlockSize = 1 << 20
+
 // Brain is a global variable that controls the dock module.
 var Brain *DockHub
 
@@ -50,15 +57,55 @@ type DockHub struct {
 	// Discoverer represents the mechanism of DockHub discovering the storage
 	// capabilities from different backends.
 	Discoverer discovery.DockDiscoverer
-	// Driver represents the specified backend resource. This field is used
-	// for initializing the specified volume driver.
-	Driver drivers.VolumeDriver
+
+	// volumeDrivers caches the volume drivers keyed by the driver name of
+	// backend. The drivers are shared by the concurrent requests and never
+	// stored on DockHub per request, so that a request can't be served by the
+	// driver of another backend.
+	volumeDrivers map[string]drivers.VolumeDriver
+	mutex         sync.Mutex
+	// initVolumeDriver sets up the volume driver of backend.
+	initVolumeDriver func(name string) (drivers.VolumeDriver, error)
 }
 
 // NewDockHub method creates a new DockHub and returns its pointer.
 func NewDockHub(dockType string) *DockHub {
 	return &DockHub{
-		Discoverer: discovery.NewDockDiscoverer(dockType),
+		Discoverer:       discovery.NewDockDiscoverer(dockType),
+		volumeDrivers:    make(map[string]drivers.VolumeDriver),
+		initVolumeDriver: drivers.Init,
+	}
+}
+
+// volumeDriver returns the volume driver of the backend, which is set up on
+// the first request to the backend. The driver failing to set up is never
+// cached, so that the next request to the backend sets it up again.
+func (d *DockHub) volumeDriver(name string) (drivers.VolumeDriver, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if driver, ok := d.volumeDrivers[name]; ok {
+		return driver, nil
+	}
+	driver, err := d.initVolumeDriver(name)
+	if err != nil {
+		// The driver is not cleaned since its resources, such as the client
+		// of backend, may not be set up.
+		log.Errorf("Set up volume driver %s failed: %v", name, err)
+		return nil, err
+	}
+	d.volumeDrivers[name] = driver
+	return driver, nil
+}
+
+// Close cleans up the volume drivers set up by DockHub.
+func (d *DockHub) Close() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for name, driver := range d.volumeDrivers {
+		drivers.Clean(driver)
+		delete(d.volumeDrivers, name)
 	}
 }
 
@@ -76,10 +123,13 @@ func (d *DockHub) TriggerDiscovery() error {
 		MetaChan: make(chan string),
 	}
 	go discovery.DiscoveryAndReport(d.Discoverer, ctx)
+	go discovery.SendHeartbeats(d.Discoverer, ctx, CONF.OsdsDock.HeartbeatInterval, CONF.OsdsDock.HeartbeatTTL)
 	go func(ctx *discovery.Context) {
 		if err = <-ctx.ErrChan; err != nil {
 			log.Error("When calling capabilty report method:", err)
-			ctx.StopChan <- true
+			// Stop sending heartbeats as well, so that the dock is regarded
+			// as down when its resources are no longer reported.
+			close(ctx.StopChan)
 		}
 	}(ctx)
 
@@ -88,14 +138,28 @@ func (d *DockHub) TriggerDiscovery() error {
 
 // CreateVolume
 func (d *DockHub) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	// Clone volume from source volume if it is specified.
+	if opt.GetSourceVolumeId() != "" {
+		log.Info("Calling volume driver to clone volume...")
+
+		vol, err := driver.CloneVolume(opt)
+		if err != nil {
+			log.Error("When calling volume driver to clone volume:", err)
+			return nil, err
+		}
+		return vol, nil
+	}
 
 	log.Info("Calling volume driver to create volume...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	vol, err := d.Driver.CreateVolume(opt)
+	vol, err := driver.CreateVolume(opt)
 	if err != nil {
 		log.Error("When calling volume driver to create volume:", err)
 		return nil, err
@@ -105,16 +169,16 @@ func (d *DockHub) CreateVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, err
 
 // DeleteVolume
 func (d *DockHub) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
-	var err error
-
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
 
 	log.Info("Calling volume driver to delete volume...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	if err = d.Driver.DeleteVolume(opt); err != nil {
+	if err = driver.DeleteVolume(opt); err != nil {
 		log.Error("When calling volume driver to delete volume:", err)
 		return err
 	}
@@ -123,14 +187,16 @@ func (d *DockHub) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
 
 // ExtendVolume ...
 func (d *DockHub) ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error) {
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Info("Calling volume driver to extend volume...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	vol, err := d.Driver.ExtendVolume(opt)
+	vol, err := driver.ExtendVolume(opt)
 	if err != nil {
 		log.Error("When calling volume driver to extend volume:", err)
 		return nil, err
@@ -138,16 +204,74 @@ func (d *DockHub) ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, err
 	return vol, nil
 }
 
+// RevertToSnapshot
+func (d *DockHub) RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
+
+	log.Info("Calling volume driver to revert volume to snapshot...")
+
+	//Call function of StorageDrivers configured by storage drivers.
+	if err := driver.RevertToSnapshot(opt); err != nil {
+		log.Error("When calling volume driver to revert volume to snapshot:", err)
+		return err
+	}
+	return nil
+}
+
+// MigrateVolume
+func (d *DockHub) MigrateVolume(opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Calling volume driver to migrate volume...")
+
+	//Call function of StorageDrivers configured by storage drivers.
+	vol, err := driver.MigrateVolume(opt)
+	if err != nil {
+		log.Error("When calling volume driver to migrate volume:", err)
+		return nil, err
+	}
+	return vol, nil
+}
+
+// RetypeVolume
+func (d *DockHub) RetypeVolume(opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Calling volume driver to retype volume...")
+
+	//Call function of StorageDrivers configured by storage drivers.
+	vol, err := driver.RetypeVolume(opt)
+	if err != nil {
+		log.Error("When calling volume driver to retype volume:", err)
+		return nil, err
+	}
+	return vol, nil
+}
+
 // CreateVolumeAttachment
 func (d *DockHub) CreateVolumeAttachment(opt *pb.CreateAttachmentOpts) (*model.VolumeAttachmentSpec, error) {
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Info("Calling volume driver to initialize volume connection...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	connInfo, err := d.Driver.InitializeConnection(opt)
+	connInfo, err := driver.InitializeConnection(opt)
 	if err != nil {
 		log.Error("Call driver to initialize volume connection failed:", err)
 		return nil, err
@@ -174,14 +298,16 @@ func (d *DockHub) CreateVolumeAttachment(opt *pb.CreateAttachmentOpts) (*model.V
 
 // DeleteVolumeAttachment
 func (d *DockHub) DeleteVolumeAttachment(opt *pb.DeleteAttachmentOpts) error {
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
 
 	log.Info("Calling volume driver to terminate volume connection...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	if err := d.Driver.TerminateConnection(opt); err != nil {
+	if err := driver.TerminateConnection(opt); err != nil {
 		log.Error("Call driver to terminate volume connection failed:", err)
 		return err
 	}
@@ -190,14 +316,16 @@ func (d *DockHub) DeleteVolumeAttachment(opt *pb.DeleteAttachmentOpts) error {
 
 // CreateSnapshot
 func (d *DockHub) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Info("Calling volume driver to create snapshot...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	snp, err := d.Driver.CreateSnapshot(opt)
+	snp, err := driver.CreateSnapshot(opt)
 	if err != nil {
 		log.Error("Call driver to create volume snashot failed:", err)
 		return nil, err
@@ -207,22 +335,60 @@ func (d *DockHub) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.Volum
 
 // DeleteSnapshot
 func (d *DockHub) DeleteSnapshot(opt *pb.DeleteVolumeSnapshotOpts) error {
-	var err error
-
-	//Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
 
 	log.Info("Calling volume driver to delete snapshot...")
 
 	//Call function of StorageDrivers configured by storage drivers.
-	if err = d.Driver.DeleteSnapshot(opt); err != nil {
+	if err = driver.DeleteSnapshot(opt); err != nil {
 		log.Error("When calling volume driver to delete volume:", err)
 		return err
 	}
 	return nil
 }
 
+// PullVolume
+func (d *DockHub) PullVolume(opt *pb.PullVolumeOpts) (*model.VolumeSpec, error) {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Calling volume driver to pull volume...")
+
+	//Call function of StorageDrivers configured by storage drivers.
+	vol, err := driver.PullVolume(opt.GetId())
+	if err != nil {
+		log.Error("When calling volume driver to pull volume:", err)
+		return nil, err
+	}
+	return vol, nil
+}
+
+// PullSnapshot
+func (d *DockHub) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Calling volume driver to pull snapshot...")
+
+	//Call function of StorageDrivers configured by storage drivers.
+	snp, err := driver.PullSnapshot(opt)
+	if err != nil {
+		log.Error("When calling volume driver to pull snapshot:", err)
+		return nil, err
+	}
+	return snp, nil
+}
+
 // AttachVolume
 func (d *DockHub) AttachVolume(opt *pb.AttachVolumeOpts) (string, error) {
 	var connData = make(map[string]interface{})
@@ -253,6 +419,27 @@ func (d *DockHub) DetachVolume(opt *pb.DetachVolumeOpts) error {
 	return con.Detach(connData)
 }
 
+// CopyVolume copies data between two volumes which have been attached to the
+// host of this dock.
+func (d *DockHub) CopyVolume(opt *pb.CopyVolumeOpts) error {
+	if opt.GetSrcPath() == "" || opt.GetDestPath() == "" {
+		return fmt.Errorf("Both source and destination device path are required!")
+	}
+
+	var count = (uint64(opt.GetSize()) << 30) / copyBlockSize
+	if _, err := exec.Run("dd",
+		"if="+opt.GetSrcPath(),
+		"of="+opt.GetDestPath(),
+		"count="+fmt.Sprint(count),
+		"bs="+fmt.Sprint(copyBlockSize),
+		"oflag=direct",
+	); err != nil {
+		log.Errorf("Copy data from %s to %s failed: %v", opt.GetSrcPath(), opt.GetDestPath(), err)
+		return err
+	}
+	return nil
+}
+
 func (d *DockHub) CreateReplication(opt *pb.CreateReplicationOpts) (*model.ReplicationSpec, error) {
 	//Get the storage drivers and do some initializations.
 	driver, err := drivers.InitReplicationDriver(opt.GetDriverName())
@@ -372,9 +559,11 @@ func (d *DockHub) FailoverReplication(opt *pb.FailoverReplicationOpts) error {
 }
 
 func (d *DockHub) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts) (*model.VolumeGroupSpec, error) {
-	// Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Info("Creating group...", opt.GetId())
 
@@ -384,7 +573,7 @@ func (d *DockHub) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts) (*model.Volum
 		return nil, err
 	}
 
-	vgUpdate, err := d.Driver.CreateVolumeGroup(opt, vg)
+	vgUpdate, err := driver.CreateVolumeGroup(opt, vg)
 
 	if _, ok := err.(*model.NotImplementError); ok {
 		vgUpdate = &model.VolumeGroupSpec{
@@ -393,7 +582,7 @@ func (d *DockHub) CreateVolumeGroup(opt *pb.CreateVolumeGroupOpts) (*model.Volum
 			},
 			Status: model.VolumeGroupAvailable,
 		}
-	} else {
+	} else if err != nil {
 		db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), vg, model.VolumeGroupError)
 		log.Error("When calling volume driver to create volume group:", err)
 		return nil, err
@@ -427,9 +616,11 @@ func (d *DockHub) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts) error {
 		return err
 	}
 
-	// Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
 
 	log.Info("Calling volume driver to update volume group...")
 
@@ -438,25 +629,24 @@ func (d *DockHub) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts) error {
 		return err
 	}
 
-	groupUpdate, addVolumesUpdate, removeVolumesUpdate, err := d.Driver.UpdateVolumeGroup(opt, group, addVolumesRef, removeVolumesRef)
+	groupUpdate, addVolumesUpdate, removeVolumesUpdate, err := driver.UpdateVolumeGroup(opt, group, addVolumesRef, removeVolumesRef)
 	// Group update faild...
 
 	if _, ok := err.(*model.NotImplementError); ok {
 		groupUpdate, addVolumesUpdate, removeVolumesUpdate = nil, nil, nil
-	} else {
-		err = db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), group, model.VolumeGroupError)
-		if err != nil {
-			return err
+	} else if err != nil {
+		if e := db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), group, model.VolumeGroupError); e != nil {
+			return e
 		}
 
 		for _, addVol := range addVolumesRef {
-			if err = db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), addVol, model.VolumeError); err != nil {
-				return err
+			if e := db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), addVol, model.VolumeError); e != nil {
+				return e
 			}
 		}
 		for _, remVol := range removeVolumesRef {
-			if err = db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), remVol, model.VolumeError); err != nil {
-				return err
+			if e := db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), remVol, model.VolumeError); e != nil {
+				return e
 			}
 		}
 		return errors.New("Error occurred when updating group" + opt.GetId() + "," + err.Error())
@@ -547,15 +737,19 @@ func (d *DockHub) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts) error {
 		return err
 	}
 
-	// Get the storage drivers and do some initializations.
-	d.Driver = drivers.Init(opt.GetDriverName())
-	defer drivers.Clean(d.Driver)
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
 	log.Info("Calling volume driver to delete volume group...")
 
-	groupUpdate, volumesUpdate, err := d.Driver.DeleteVolumeGroup(opt, group, volumes)
+	groupUpdate, volumesUpdate, err := driver.DeleteVolumeGroup(opt, group, volumes)
 
 	if _, ok := err.(*model.NotImplementError); ok {
-		groupUpdate, volumesUpdate = d.deleteGroupGeneric(d.Driver, group, volumes, opt)
+		groupUpdate, volumesUpdate = d.deleteGroupGeneric(driver, group, volumes, opt)
+	} else if err == nil {
+		volumesUpdate = d.deleteVolumeEntries(groupUpdate, volumes, volumesUpdate, opt)
 	} else {
 		db.C.UpdateStatus(c.NewContextFromJson(opt.GetContext()), group, model.VolumeGroupError)
 		// If driver returns none for volumesUpdate, set volume status to error.
@@ -615,19 +809,205 @@ func (d *DockHub) deleteGroupGeneric(driver drivers.VolumeDriver, vg *model.Volu
 				Id: volumeRef.Id,
 			},
 		}
-		if err := driver.DeleteVolume(&pb.DeleteVolumeOpts{Metadata: volumeRef.Metadata}); err != nil {
+		if err := driver.DeleteVolume(&pb.DeleteVolumeOpts{Id: volumeRef.Id, Metadata: volumeRef.Metadata}); err != nil {
 			v.Status = model.VolumeError
 			vgUpdate.Status = model.VolumeGroupError
 			volumesUpdate = append(volumesUpdate, v)
 			log.Error(fmt.Sprintf("Error occurred when delete volume %s from group.", volumeRef.Id))
-		} else {
-			// Delete the volume entry in DB after successfully deleting the volume on the storage.
-			if err = db.C.DeleteVolume(c.NewContextFromJson(opt.GetContext()), volumeRef.Id); err != nil {
-				log.Errorf("Error occurred in dock module when delete volume %s in db:%v", volumeRef.Id, err)
-				vgUpdate.Status = model.VolumeGroupError
-			}
+		} else if err = d.deleteVolumeEntry(volumeRef, opt); err != nil {
+			vgUpdate.Status = model.VolumeGroupError
 		}
 	}
 
 	return vgUpdate, volumesUpdate
 }
+
+// deleteVolumeEntries deletes the entries of volumes which have been deleted
+// by driver along with the group, and returns the volumes failed to be deleted.
+func (d *DockHub) deleteVolumeEntries(vgUpdate *model.VolumeGroupSpec, volumes, volumesUpdate []*model.VolumeSpec, opt *pb.DeleteVolumeGroupOpts) []*model.VolumeSpec {
+	var status = make(map[string]string)
+	for _, v := range volumesUpdate {
+		status[v.Id] = v.Status
+	}
+
+	var failed []*model.VolumeSpec
+	for _, volumeRef := range volumes {
+		if s := status[volumeRef.Id]; s == model.VolumeError || s == model.VolumeErrorDeleting {
+			failed = append(failed, &model.VolumeSpec{
+				BaseModel: &model.BaseModel{Id: volumeRef.Id},
+				Status:    s,
+			})
+			continue
+		}
+		if err := d.deleteVolumeEntry(volumeRef, opt); err != nil && vgUpdate != nil {
+			vgUpdate.Status = model.VolumeGroupError
+		}
+	}
+	return failed
+}
+
+// deleteVolumeEntry deletes the volume entry in DB after successfully deleting
+// the volume on the storage, and releases its quota.
+func (d *DockHub) deleteVolumeEntry(volumeRef *model.VolumeSpec, opt *pb.DeleteVolumeGroupOpts) error {
+	if err := db.C.DeleteVolume(c.NewContextFromJson(opt.GetContext()), volumeRef.Id); err != nil {
+		log.Errorf("Error occurred in dock module when delete volume %s in db:%v", volumeRef.Id, err)
+		return err
+	}
+	db.C.ReleaseQuota(c.NewContextFromJson(opt.GetContext()), volumeRef.TenantId,
+		&model.QuotaUsageSpec{Volumes: 1, Gigabytes: volumeRef.Size})
+	return nil
+}
+
+// CreateGroupSnapshot
+func (d *DockHub) CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Calling volume driver to create group snapshot...")
+
+	snps, err := driver.CreateGroupSnapshot(opt)
+	if _, ok := err.(*model.NotImplementError); ok {
+		log.Warningf("Volume driver doesn't support group snapshot, snapshots of group %s are created one by one.", opt.GetGroupId())
+		snps, err = d.createGroupSnapshotGeneric(driver, opt)
+	}
+	if err != nil {
+		log.Error("When calling volume driver to create group snapshot:", err)
+		return nil, err
+	}
+	if len(snps) != len(opt.GetSnapshots()) {
+		return nil, fmt.Errorf("volume driver returns %d snapshots for %d volumes of group %s",
+			len(snps), len(opt.GetSnapshots()), opt.GetGroupId())
+	}
+	return snps, nil
+}
+
+// createGroupSnapshotGeneric creates the snapshots of volumes in the group one
+// by one, and removes the created ones if any of them fails.
+func (d *DockHub) createGroupSnapshotGeneric(driver drivers.VolumeDriver, opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	var snps []*model.VolumeSnapshotSpec
+	for _, snpOpt := range opt.GetSnapshots() {
+		snp, err := driver.CreateSnapshot(snpOpt)
+		if err != nil {
+			log.Errorf("Error occurred when create snapshot of volume %s in group %s: %v", snpOpt.GetVolumeId(), opt.GetGroupId(), err)
+			for i, created := range snps {
+				if e := driver.DeleteSnapshot(&pb.DeleteVolumeSnapshotOpts{
+					Id:       created.Id,
+					VolumeId: created.VolumeId,
+					Metadata: utils.MergeStringMaps(opt.GetSnapshots()[i].GetMetadata(), created.Metadata),
+				}); e != nil {
+					log.Errorf("Error occurred when remove snapshot %s: %v", created.Id, e)
+				}
+			}
+			return nil, err
+		}
+		snps = append(snps, snp)
+	}
+	return snps, nil
+}
+
+// DeleteGroupSnapshot
+func (d *DockHub) DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error {
+	// Get the storage driver of the backend.
+	driver, err := d.volumeDriver(opt.GetDriverName())
+	if err != nil {
+		return err
+	}
+
+	log.Info("Calling volume driver to delete group snapshot...")
+
+	err = driver.DeleteGroupSnapshot(opt)
+	if _, ok := err.(*model.NotImplementError); ok {
+		for _, snpOpt := range opt.GetSnapshots() {
+			if err = driver.DeleteSnapshot(snpOpt); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		log.Error("When calling volume driver to delete group snapshot:", err)
+		return err
+	}
+	return nil
+}
+
+// CreateFileShare
+func (d *DockHub) CreateFileShare(opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error) {
+	// Get the file share drivers and do some initializations.
+	fd := drivers.InitFileShareDriver(opt.GetDriverName())
+	defer drivers.CleanFileShareDriver(fd)
+
+	log.Info("Calling file share driver to create file share...")
+
+	share, err := fd.CreateFileShare(opt)
+	if err != nil {
+		log.Error("When calling file share driver to create file share:", err)
+		return nil, err
+	}
+	share.PoolId, share.ProfileId = opt.GetPoolId(), opt.GetProfileId()
+	return share, nil
+}
+
+// DeleteFileShare
+func (d *DockHub) DeleteFileShare(opt *pb.DeleteFileShareOpts) error {
+	// Get the file share drivers and do some initializations.
+	fd := drivers.InitFileShareDriver(opt.GetDriverName())
+	defer drivers.CleanFileShareDriver(fd)
+
+	log.Info("Calling file share driver to delete file share...")
+
+	if err := fd.DeleteFileShare(opt); err != nil {
+		log.Error("When calling file share driver to delete file share:", err)
+		return err
+	}
+	return nil
+}
+
+// ExtendFileShare
+func (d *DockHub) ExtendFileShare(opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error) {
+	// Get the file share drivers and do some initializations.
+	fd := drivers.InitFileShareDriver(opt.GetDriverName())
+	defer drivers.CleanFileShareDriver(fd)
+
+	log.Info("Calling file share driver to extend file share...")
+
+	share, err := fd.ExtendFileShare(opt)
+	if err != nil {
+		log.Error("When calling file share driver to extend file share:", err)
+		return nil, err
+	}
+	return share, nil
+}
+
+// CreateFileShareAcl
+func (d *DockHub) CreateFileShareAcl(opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error) {
+	// Get the file share drivers and do some initializations.
+	fd := drivers.InitFileShareDriver(opt.GetDriverName())
+	defer drivers.CleanFileShareDriver(fd)
+
+	log.Info("Calling file share driver to create file share acl...")
+
+	acl, err := fd.CreateFileShareAcl(opt)
+	if err != nil {
+		log.Error("When calling file share driver to create file share acl:", err)
+		return nil, err
+	}
+	return acl, nil
+}
+
+// DeleteFileShareAcl
+func (d *DockHub) DeleteFileShareAcl(opt *pb.DeleteFileShareAclOpts) error {
+	// Get the file share drivers and do some initializations.
+	fd := drivers.InitFileShareDriver(opt.GetDriverName())
+	defer drivers.CleanFileShareDriver(fd)
+
+	log.Info("Calling file share driver to delete file share acl...")
+
+	if err := fd.DeleteFileShareAcl(opt); err != nil {
+		log.Error("When calling file share driver to delete file share acl:", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opensds/opensds/contrib/drivers"
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	sample "github.com/opensds/opensds/testutils/driver"
+)
+
+// setupDriver is a sample driver whose setup fails until the backend is up.
+type setupDriver struct {
+	sample.Driver
+	backendUp *bool
+}
+
+func (d *setupDriver) Setup() error {
+	if !*d.backendUp {
+		return errors.New("login to backend failed")
+	}
+	return nil
+}
+
+func newTestDockHub(backendUp *bool, inits *int) *DockHub {
+	return &DockHub{
+		volumeDrivers: make(map[string]drivers.VolumeDriver),
+		initVolumeDriver: func(name string) (drivers.VolumeDriver, error) {
+			*inits++
+			d := &setupDriver{backendUp: backendUp}
+			return d, d.Setup()
+		},
+	}
+}
+
+func TestVolumeDriverCached(t *testing.T) {
+	var backendUp = true
+	var inits int
+	d := newTestDockHub(&backendUp, &inits)
+
+	d1, err := d.volumeDriver("sample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d2, err := d.volumeDriver("sample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d1 != d2 || inits != 1 {
+		t.Errorf("Expected the driver to be set up once and shared, got %d setups", inits)
+	}
+	if _, err := d.volumeDriver("others"); err != nil {
+		t.Fatal(err)
+	}
+	if inits != 2 {
+		t.Errorf("Expected the driver of each backend to be set up, got %d setups", inits)
+	}
+}
+
+func TestVolumeDriverSetupFailed(t *testing.T) {
+	var backendUp = false
+	var inits int
+	d := newTestDockHub(&backendUp, &inits)
+
+	if _, err := d.CreateVolume(&pb.CreateVolumeOpts{DriverName: "sample"}); err == nil {
+		t.Error("Expected error of creating volume when driver setup failed")
+	}
+	if _, ok := d.volumeDrivers["sample"]; ok {
+		t.Error("Expected the driver failing to set up not to be cached")
+	}
+
+	// The driver is set up again once the backend is up.
+	backendUp = true
+	if _, err := d.CreateVolume(&pb.CreateVolumeOpts{DriverName: "sample"}); err != nil {
+		t.Errorf("Expected %v, got %v", nil, err)
+	}
+	if _, ok := d.volumeDrivers["sample"]; !ok {
+		t.Error("Expected the driver to be cached after setup succeeded")
+	}
+	if inits != 2 {
+		t.Errorf("Expected 2 setups, got %d", inits)
+	}
+}
@@ -11,13 +11,19 @@ It has these top-level messages:
 	CreateVolumeOpts
 	DeleteVolumeOpts
 	ExtendVolumeOpts
+	RevertToSnapshotOpts
+	MigrateVolumeOpts
+	RetypeVolumeOpts
 	CreateVolumeSnapshotOpts
 	DeleteVolumeSnapshotOpts
+	PullVolumeOpts
+	PullVolumeSnapshotOpts
 	CreateAttachmentOpts
 	DeleteAttachmentOpts
 	CreateSnapshotAttachmentOpts
 	DeleteSnapshotAttachmentOpts
 	HostInfo
+	Qos
 	VolumeData
 	CreateReplicationOpts
 	DeleteReplicationOpts
@@ -27,8 +33,16 @@ It has these top-level messages:
 	CreateVolumeGroupOpts
 	UpdateVolumeGroupOpts
 	DeleteVolumeGroupOpts
+	CreateGroupSnapshotOpts
+	DeleteGroupSnapshotOpts
+	CreateFileShareOpts
+	DeleteFileShareOpts
+	ExtendFileShareOpts
+	CreateFileShareAclOpts
+	DeleteFileShareAclOpts
 	AttachVolumeOpts
 	DetachVolumeOpts
+	CopyVolumeOpts
 	GenericResponse
 */
 package proto
@@ -88,6 +102,14 @@ type CreateVolumeOpts struct {
 	SnapshotSize int64 `protobuf:"varint,15,opt,name=snapshotSize" json:"snapshotSize,omitempty"`
 	// Down load snapshot from cloud
 	SnapshotFromCloud bool `protobuf:"varint,16,opt,name=snapshotFromCloud" json:"snapshotFromCloud,omitempty"`
+	// When clone volume from another volume, this field is required.
+	SourceVolumeId string `protobuf:"bytes,17,opt,name=sourceVolumeId" json:"sourceVolumeId,omitempty"`
+	// The size of source volume
+	SourceVolumeSize int64 `protobuf:"varint,18,opt,name=sourceVolumeSize" json:"sourceVolumeSize,omitempty"`
+	// The metadata of source volume
+	SourceVolumeMetadata map[string]string `protobuf:"bytes,19,rep,name=sourceVolumeMetadata" json:"sourceVolumeMetadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The qos limits of the volume, optional.
+	Qos *Qos `protobuf:"bytes,20,opt,name=qos" json:"qos,omitempty"`
 }
 
 func (m *CreateVolumeOpts) Reset()                    { *m = CreateVolumeOpts{} }
@@ -207,6 +229,34 @@ func (m *CreateVolumeOpts) GetSnapshotFromCloud() bool {
 	return false
 }
 
+func (m *CreateVolumeOpts) GetSourceVolumeId() string {
+	if m != nil {
+		return m.SourceVolumeId
+	}
+	return ""
+}
+
+func (m *CreateVolumeOpts) GetSourceVolumeSize() int64 {
+	if m != nil {
+		return m.SourceVolumeSize
+	}
+	return 0
+}
+
+func (m *CreateVolumeOpts) GetSourceVolumeMetadata() map[string]string {
+	if m != nil {
+		return m.SourceVolumeMetadata
+	}
+	return nil
+}
+
+func (m *CreateVolumeOpts) GetQos() *Qos {
+	if m != nil {
+		return m.Qos
+	}
+	return nil
+}
+
 // DeleteVolumeOpts is a structure which indicates all required properties
 // for deleting a volume.
 type DeleteVolumeOpts struct {
@@ -371,408 +421,619 @@ func (m *ExtendVolumeOpts) GetContext() string {
 	return ""
 }
 
-// CreateVolumeSnapshotOpts is a structure which indicates all required
-// properties for creating a volume snapshot.
-type CreateVolumeSnapshotOpts struct {
-	// The uuid of the volume snapshot, optional.
+// RevertToSnapshotOpts is a structure which indicates all required properties
+// for reverting a volume to one of its snapshots.
+type RevertToSnapshotOpts struct {
+	// The uuid of the volume, required.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The name of the volume snapshot, required.
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// The size of the volume that snapshot belongs to, required.
+	// The uuid of the snapshot which the volume is reverted to, required.
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshotId" json:"snapshotId,omitempty"`
+	// The size of the volume, required.
 	Size int64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
-	// The description of the volume snapshot, optional.
-	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
-	// The uuid of the volume that snapshot belongs to, required.
-	VolumeId string `protobuf:"bytes,5,opt,name=volumeId" json:"volumeId,omitempty"`
-	// The metadata of the volume snapshot, optional.
-	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The name of the pool on which volume is located, required.
+	PoolName string `protobuf:"bytes,4,opt,name=poolName" json:"poolName,omitempty"`
+	// The metadata of the volume, optional.
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The metadata of the snapshot, optional.
+	SnapshotMetadata map[string]string `protobuf:"bytes,6,rep,name=snapshotMetadata" json:"snapshotMetadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// The storage driver type.
 	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
 	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *CreateVolumeSnapshotOpts) Reset()                    { *m = CreateVolumeSnapshotOpts{} }
-func (m *CreateVolumeSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
-func (*CreateVolumeSnapshotOpts) ProtoMessage()               {}
-func (*CreateVolumeSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (m *RevertToSnapshotOpts) Reset()                    { *m = RevertToSnapshotOpts{} }
+func (m *RevertToSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
+func (*RevertToSnapshotOpts) ProtoMessage()               {}
+func (*RevertToSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
-func (m *CreateVolumeSnapshotOpts) GetId() string {
+func (m *RevertToSnapshotOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *CreateVolumeSnapshotOpts) GetName() string {
+func (m *RevertToSnapshotOpts) GetSnapshotId() string {
 	if m != nil {
-		return m.Name
+		return m.SnapshotId
 	}
 	return ""
 }
 
-func (m *CreateVolumeSnapshotOpts) GetSize() int64 {
+func (m *RevertToSnapshotOpts) GetSize() int64 {
 	if m != nil {
 		return m.Size
 	}
 	return 0
 }
 
-func (m *CreateVolumeSnapshotOpts) GetDescription() string {
+func (m *RevertToSnapshotOpts) GetPoolName() string {
 	if m != nil {
-		return m.Description
+		return m.PoolName
 	}
 	return ""
 }
 
-func (m *CreateVolumeSnapshotOpts) GetVolumeId() string {
+func (m *RevertToSnapshotOpts) GetMetadata() map[string]string {
 	if m != nil {
-		return m.VolumeId
+		return m.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (m *CreateVolumeSnapshotOpts) GetMetadata() map[string]string {
+func (m *RevertToSnapshotOpts) GetSnapshotMetadata() map[string]string {
 	if m != nil {
-		return m.Metadata
+		return m.SnapshotMetadata
 	}
 	return nil
 }
 
-func (m *CreateVolumeSnapshotOpts) GetDriverName() string {
+func (m *RevertToSnapshotOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *CreateVolumeSnapshotOpts) GetContext() string {
+func (m *RevertToSnapshotOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-// DeleteVolumeSnapshotOpts is a structure which indicates all required
-// properties for deleting a volume snapshot.
-type DeleteVolumeSnapshotOpts struct {
-	// The uuid of the volume snapshot, required.
+// MigrateVolumeOpts is a structure which indicates all required properties
+// for migrating a volume to another pool of the same backend.
+type MigrateVolumeOpts struct {
+	// The uuid of the volume, required.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The uuid of the volume that snapshot belongs to, required.
-	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
-	// The metadata of the volume snapshot, optional.
-	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The size of the volume, required.
+	Size int64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	// The name of the pool on which volume is located, required.
+	PoolName string `protobuf:"bytes,3,opt,name=poolName" json:"poolName,omitempty"`
+	// The uuid of the pool which the volume is migrated to, required.
+	TargetPoolId string `protobuf:"bytes,4,opt,name=targetPoolId" json:"targetPoolId,omitempty"`
+	// The name of the pool which the volume is migrated to, required.
+	TargetPoolName string `protobuf:"bytes,5,opt,name=targetPoolName" json:"targetPoolName,omitempty"`
+	// The metadata of the volume, optional.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// The storage driver type.
-	DriverName string `protobuf:"bytes,4,opt,name=driverName" json:"driverName,omitempty"`
+	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,5,opt,name=context" json:"context,omitempty"`
+	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
+	// The qos limits of the volume, optional.
+	Qos *Qos `protobuf:"bytes,9,opt,name=qos" json:"qos,omitempty"`
 }
 
-func (m *DeleteVolumeSnapshotOpts) Reset()                    { *m = DeleteVolumeSnapshotOpts{} }
-func (m *DeleteVolumeSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
-func (*DeleteVolumeSnapshotOpts) ProtoMessage()               {}
-func (*DeleteVolumeSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (m *MigrateVolumeOpts) Reset()                    { *m = MigrateVolumeOpts{} }
+func (m *MigrateVolumeOpts) String() string            { return proto1.CompactTextString(m) }
+func (*MigrateVolumeOpts) ProtoMessage()               {}
+func (*MigrateVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-func (m *DeleteVolumeSnapshotOpts) GetId() string {
+func (m *MigrateVolumeOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *DeleteVolumeSnapshotOpts) GetVolumeId() string {
+func (m *MigrateVolumeOpts) GetSize() int64 {
 	if m != nil {
-		return m.VolumeId
+		return m.Size
+	}
+	return 0
+}
+
+func (m *MigrateVolumeOpts) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
 	}
 	return ""
 }
 
-func (m *DeleteVolumeSnapshotOpts) GetMetadata() map[string]string {
+func (m *MigrateVolumeOpts) GetTargetPoolId() string {
+	if m != nil {
+		return m.TargetPoolId
+	}
+	return ""
+}
+
+func (m *MigrateVolumeOpts) GetTargetPoolName() string {
+	if m != nil {
+		return m.TargetPoolName
+	}
+	return ""
+}
+
+func (m *MigrateVolumeOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *DeleteVolumeSnapshotOpts) GetDriverName() string {
+func (m *MigrateVolumeOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DeleteVolumeSnapshotOpts) GetContext() string {
+func (m *MigrateVolumeOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-// CreateAttachmentOpts is a structure which indicates all required
-// properties for creating a volume attachment.
-type CreateAttachmentOpts struct {
-	// The uuid of the volume attachment, optional.
-	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+func (m *MigrateVolumeOpts) GetQos() *Qos {
+	if m != nil {
+		return m.Qos
+	}
+	return nil
+}
+
+// RetypeVolumeOpts is a structure which indicates all required properties
+// for changing the properties of a volume according to a new profile.
+type RetypeVolumeOpts struct {
 	// The uuid of the volume, required.
-	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
-	// This field indicates if the volume is attached locally, optional.
-	DoLocalAttach bool `protobuf:"varint,3,opt,name=doLocalAttach" json:"doLocalAttach,omitempty"`
-	// This field indicates if the volume is attached multiple times, optional.
-	MultiPath bool `protobuf:"varint,4,opt,name=multiPath" json:"multiPath,omitempty"`
-	// The infomation of the host node on which the volume will be attached.
-	HostInfo *HostInfo `protobuf:"bytes,5,opt,name=hostInfo" json:"hostInfo,omitempty"`
-	// The metadata of the volume attachment, optional.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The size of the volume, required.
+	Size int64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	// The name of the pool on which volume is located, required.
+	PoolName string `protobuf:"bytes,3,opt,name=poolName" json:"poolName,omitempty"`
+	// The uuid of the new profile, required.
+	ProfileId string `protobuf:"bytes,4,opt,name=profileId" json:"profileId,omitempty"`
+	// The new profile encoded in json, required.
+	Profile string `protobuf:"bytes,5,opt,name=profile" json:"profile,omitempty"`
+	// The metadata of the volume, optional.
 	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// The storage driver type.
 	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
 	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
-	// The protocol
-	AccessProtocol string `protobuf:"bytes,9,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
+	// The qos limits required by the new profile, optional. A zero limit
+	// means that the limit should be removed.
+	Qos *Qos `protobuf:"bytes,9,opt,name=qos" json:"qos,omitempty"`
 }
 
-func (m *CreateAttachmentOpts) Reset()                    { *m = CreateAttachmentOpts{} }
-func (m *CreateAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
-func (*CreateAttachmentOpts) ProtoMessage()               {}
-func (*CreateAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *RetypeVolumeOpts) Reset()                    { *m = RetypeVolumeOpts{} }
+func (m *RetypeVolumeOpts) String() string            { return proto1.CompactTextString(m) }
+func (*RetypeVolumeOpts) ProtoMessage()               {}
+func (*RetypeVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-func (m *CreateAttachmentOpts) GetId() string {
+func (m *RetypeVolumeOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *CreateAttachmentOpts) GetVolumeId() string {
+func (m *RetypeVolumeOpts) GetSize() int64 {
 	if m != nil {
-		return m.VolumeId
+		return m.Size
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateAttachmentOpts) GetDoLocalAttach() bool {
+func (m *RetypeVolumeOpts) GetPoolName() string {
 	if m != nil {
-		return m.DoLocalAttach
+		return m.PoolName
 	}
-	return false
+	return ""
 }
 
-func (m *CreateAttachmentOpts) GetMultiPath() bool {
+func (m *RetypeVolumeOpts) GetProfileId() string {
 	if m != nil {
-		return m.MultiPath
+		return m.ProfileId
 	}
-	return false
+	return ""
 }
 
-func (m *CreateAttachmentOpts) GetHostInfo() *HostInfo {
+func (m *RetypeVolumeOpts) GetProfile() string {
 	if m != nil {
-		return m.HostInfo
+		return m.Profile
 	}
-	return nil
+	return ""
 }
 
-func (m *CreateAttachmentOpts) GetMetadata() map[string]string {
+func (m *RetypeVolumeOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *CreateAttachmentOpts) GetDriverName() string {
+func (m *RetypeVolumeOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *CreateAttachmentOpts) GetContext() string {
+func (m *RetypeVolumeOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *CreateAttachmentOpts) GetAccessProtocol() string {
+func (m *RetypeVolumeOpts) GetQos() *Qos {
 	if m != nil {
-		return m.AccessProtocol
+		return m.Qos
 	}
-	return ""
+	return nil
 }
 
-// DeleteAttachmentOpts is a structure which indicates all required
-// properties for deleting a volume attachment.
-type DeleteAttachmentOpts struct {
-	// The uuid of the volume attachment, optional.
+// CreateVolumeSnapshotOpts is a structure which indicates all required
+// properties for creating a volume snapshot.
+type CreateVolumeSnapshotOpts struct {
+	// The uuid of the volume snapshot, optional.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The uuid of the volume, required.
-	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
-	// The infomation of the host node on which the volume will be attached.
-	HostInfo *HostInfo `protobuf:"bytes,3,opt,name=hostInfo" json:"hostInfo,omitempty"`
-	// The metadata of the volume attachment, optional.
-	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The name of the volume snapshot, required.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// The size of the volume that snapshot belongs to, required.
+	Size int64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	// The description of the volume snapshot, optional.
+	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	// The uuid of the volume that snapshot belongs to, required.
+	VolumeId string `protobuf:"bytes,5,opt,name=volumeId" json:"volumeId,omitempty"`
+	// The metadata of the volume snapshot, optional.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// The storage driver type.
-	DriverName string `protobuf:"bytes,5,opt,name=driverName" json:"driverName,omitempty"`
+	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,6,opt,name=context" json:"context,omitempty"`
-	// The protocol
-	AccessProtocol string `protobuf:"bytes,7,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
+	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *DeleteAttachmentOpts) Reset()                    { *m = DeleteAttachmentOpts{} }
-func (m *DeleteAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
-func (*DeleteAttachmentOpts) ProtoMessage()               {}
-func (*DeleteAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *CreateVolumeSnapshotOpts) Reset()                    { *m = CreateVolumeSnapshotOpts{} }
+func (m *CreateVolumeSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateVolumeSnapshotOpts) ProtoMessage()               {}
+func (*CreateVolumeSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-func (m *DeleteAttachmentOpts) GetId() string {
+func (m *CreateVolumeSnapshotOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *DeleteAttachmentOpts) GetVolumeId() string {
+func (m *CreateVolumeSnapshotOpts) GetName() string {
 	if m != nil {
-		return m.VolumeId
+		return m.Name
 	}
 	return ""
 }
 
-func (m *DeleteAttachmentOpts) GetHostInfo() *HostInfo {
+func (m *CreateVolumeSnapshotOpts) GetSize() int64 {
 	if m != nil {
-		return m.HostInfo
+		return m.Size
 	}
-	return nil
+	return 0
 }
 
-func (m *DeleteAttachmentOpts) GetMetadata() map[string]string {
+func (m *CreateVolumeSnapshotOpts) GetDescription() string {
 	if m != nil {
-		return m.Metadata
+		return m.Description
 	}
-	return nil
+	return ""
 }
 
-func (m *DeleteAttachmentOpts) GetDriverName() string {
+func (m *CreateVolumeSnapshotOpts) GetVolumeId() string {
 	if m != nil {
-		return m.DriverName
+		return m.VolumeId
 	}
 	return ""
 }
 
-func (m *DeleteAttachmentOpts) GetContext() string {
+func (m *CreateVolumeSnapshotOpts) GetMetadata() map[string]string {
 	if m != nil {
-		return m.Context
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CreateVolumeSnapshotOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DeleteAttachmentOpts) GetAccessProtocol() string {
+func (m *CreateVolumeSnapshotOpts) GetContext() string {
 	if m != nil {
-		return m.AccessProtocol
+		return m.Context
 	}
 	return ""
 }
 
-// CreateSnapshotAttachmentOpts is a structure which indicates all required
-// properties for creating a snapshot attachment.
-type CreateSnapshotAttachmentOpts struct {
-	// The uuid of the snapshot attachment, optional.
+// DeleteVolumeSnapshotOpts is a structure which indicates all required
+// properties for deleting a volume snapshot.
+type DeleteVolumeSnapshotOpts struct {
+	// The uuid of the volume snapshot, required.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The uuid of the snapshot, required.
-	SnapshotId string `protobuf:"bytes,2,opt,name=snapshotId" json:"snapshotId,omitempty"`
-	// This field indicates if the snapshot is attached locally, optional.
-	DoLocalAttach bool `protobuf:"varint,3,opt,name=doLocalAttach" json:"doLocalAttach,omitempty"`
-	// This field indicates if the snapshot is attached multiple times, optional.
-	MultiPath bool `protobuf:"varint,4,opt,name=multiPath" json:"multiPath,omitempty"`
-	// The infomation of the host node on which the snapshot will be attached.
-	HostInfo *HostInfo `protobuf:"bytes,5,opt,name=hostInfo" json:"hostInfo,omitempty"`
-	// The metadata of the snapshot attachment, optional.
-	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The uuid of the volume that snapshot belongs to, required.
+	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
+	// The metadata of the volume snapshot, optional.
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// The storage driver type.
-	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
+	DriverName string `protobuf:"bytes,4,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
-	// The protocol
-	AccessProtocol string `protobuf:"bytes,9,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
+	Context string `protobuf:"bytes,5,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *CreateSnapshotAttachmentOpts) Reset()                    { *m = CreateSnapshotAttachmentOpts{} }
-func (m *CreateSnapshotAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
-func (*CreateSnapshotAttachmentOpts) ProtoMessage()               {}
-func (*CreateSnapshotAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (m *DeleteVolumeSnapshotOpts) Reset()                    { *m = DeleteVolumeSnapshotOpts{} }
+func (m *DeleteVolumeSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteVolumeSnapshotOpts) ProtoMessage()               {}
+func (*DeleteVolumeSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
-func (m *CreateSnapshotAttachmentOpts) GetId() string {
+func (m *DeleteVolumeSnapshotOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetSnapshotId() string {
+func (m *DeleteVolumeSnapshotOpts) GetVolumeId() string {
 	if m != nil {
-		return m.SnapshotId
+		return m.VolumeId
 	}
 	return ""
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetDoLocalAttach() bool {
+func (m *DeleteVolumeSnapshotOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *DeleteVolumeSnapshotOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *DeleteVolumeSnapshotOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+// PullVolumeOpts is a structure which indicates all required properties
+// for pulling a volume from the backend.
+type PullVolumeOpts struct {
+	// The uuid of the volume, required.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The metadata of the volume, optional.
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,3,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,4,opt,name=context" json:"context,omitempty"`
+}
+
+func (m *PullVolumeOpts) Reset()                    { *m = PullVolumeOpts{} }
+func (m *PullVolumeOpts) String() string            { return proto1.CompactTextString(m) }
+func (*PullVolumeOpts) ProtoMessage()               {}
+func (*PullVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *PullVolumeOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PullVolumeOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PullVolumeOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *PullVolumeOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+// PullVolumeSnapshotOpts is a structure which indicates all required
+// properties for pulling a volume snapshot from the backend.
+type PullVolumeSnapshotOpts struct {
+	// The uuid of the volume snapshot, required.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The uuid of the volume that snapshot belongs to, required.
+	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
+	// The metadata of the volume snapshot, optional.
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,4,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,5,opt,name=context" json:"context,omitempty"`
+}
+
+func (m *PullVolumeSnapshotOpts) Reset()                    { *m = PullVolumeSnapshotOpts{} }
+func (m *PullVolumeSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
+func (*PullVolumeSnapshotOpts) ProtoMessage()               {}
+func (*PullVolumeSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *PullVolumeSnapshotOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PullVolumeSnapshotOpts) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *PullVolumeSnapshotOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PullVolumeSnapshotOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *PullVolumeSnapshotOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+// CreateAttachmentOpts is a structure which indicates all required
+// properties for creating a volume attachment.
+type CreateAttachmentOpts struct {
+	// The uuid of the volume attachment, optional.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The uuid of the volume, required.
+	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
+	// This field indicates if the volume is attached locally, optional.
+	DoLocalAttach bool `protobuf:"varint,3,opt,name=doLocalAttach" json:"doLocalAttach,omitempty"`
+	// This field indicates if the volume is attached multiple times, optional.
+	MultiPath bool `protobuf:"varint,4,opt,name=multiPath" json:"multiPath,omitempty"`
+	// The infomation of the host node on which the volume will be attached.
+	HostInfo *HostInfo `protobuf:"bytes,5,opt,name=hostInfo" json:"hostInfo,omitempty"`
+	// The metadata of the volume attachment, optional.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
+	// The protocol
+	AccessProtocol string `protobuf:"bytes,9,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
+	// The qos limits of the volume, optional.
+	Qos *Qos `protobuf:"bytes,10,opt,name=qos" json:"qos,omitempty"`
+}
+
+func (m *CreateAttachmentOpts) Reset()                    { *m = CreateAttachmentOpts{} }
+func (m *CreateAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateAttachmentOpts) ProtoMessage()               {}
+func (*CreateAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *CreateAttachmentOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CreateAttachmentOpts) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *CreateAttachmentOpts) GetDoLocalAttach() bool {
 	if m != nil {
 		return m.DoLocalAttach
 	}
 	return false
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetMultiPath() bool {
+func (m *CreateAttachmentOpts) GetMultiPath() bool {
 	if m != nil {
 		return m.MultiPath
 	}
 	return false
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetHostInfo() *HostInfo {
+func (m *CreateAttachmentOpts) GetHostInfo() *HostInfo {
 	if m != nil {
 		return m.HostInfo
 	}
 	return nil
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetMetadata() map[string]string {
+func (m *CreateAttachmentOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetDriverName() string {
+func (m *CreateAttachmentOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetContext() string {
+func (m *CreateAttachmentOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *CreateSnapshotAttachmentOpts) GetAccessProtocol() string {
+func (m *CreateAttachmentOpts) GetAccessProtocol() string {
 	if m != nil {
 		return m.AccessProtocol
 	}
 	return ""
 }
 
-// DeleteSnapshotAttachmentOpts is a structure which indicates all required
-// properties for deleting a snapshot attachment.
-type DeleteSnapshotAttachmentOpts struct {
-	// The uuid of the snapshot attachment, optional.
+func (m *CreateAttachmentOpts) GetQos() *Qos {
+	if m != nil {
+		return m.Qos
+	}
+	return nil
+}
+
+// DeleteAttachmentOpts is a structure which indicates all required
+// properties for deleting a volume attachment.
+type DeleteAttachmentOpts struct {
+	// The uuid of the volume attachment, optional.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The uuid of the snapshot, required.
-	SnapshotId string `protobuf:"bytes,2,opt,name=snapshotId" json:"snapshotId,omitempty"`
+	// The uuid of the volume, required.
+	VolumeId string `protobuf:"bytes,2,opt,name=volumeId" json:"volumeId,omitempty"`
 	// The infomation of the host node on which the volume will be attached.
 	HostInfo *HostInfo `protobuf:"bytes,3,opt,name=hostInfo" json:"hostInfo,omitempty"`
-	// The metadata of the snapshot attachment, optional.
+	// The metadata of the volume attachment, optional.
 	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// The storage driver type.
 	DriverName string `protobuf:"bytes,5,opt,name=driverName" json:"driverName,omitempty"`
@@ -782,133 +1043,821 @@ type DeleteSnapshotAttachmentOpts struct {
 	AccessProtocol string `protobuf:"bytes,7,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
 }
 
-func (m *DeleteSnapshotAttachmentOpts) Reset()                    { *m = DeleteSnapshotAttachmentOpts{} }
-func (m *DeleteSnapshotAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
-func (*DeleteSnapshotAttachmentOpts) ProtoMessage()               {}
-func (*DeleteSnapshotAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *DeleteAttachmentOpts) Reset()                    { *m = DeleteAttachmentOpts{} }
+func (m *DeleteAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteAttachmentOpts) ProtoMessage()               {}
+func (*DeleteAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-func (m *DeleteSnapshotAttachmentOpts) GetId() string {
+func (m *DeleteAttachmentOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *DeleteSnapshotAttachmentOpts) GetSnapshotId() string {
+func (m *DeleteAttachmentOpts) GetVolumeId() string {
 	if m != nil {
-		return m.SnapshotId
+		return m.VolumeId
 	}
 	return ""
 }
 
-func (m *DeleteSnapshotAttachmentOpts) GetHostInfo() *HostInfo {
+func (m *DeleteAttachmentOpts) GetHostInfo() *HostInfo {
 	if m != nil {
 		return m.HostInfo
 	}
 	return nil
 }
 
-func (m *DeleteSnapshotAttachmentOpts) GetMetadata() map[string]string {
+func (m *DeleteAttachmentOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *DeleteSnapshotAttachmentOpts) GetDriverName() string {
+func (m *DeleteAttachmentOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DeleteSnapshotAttachmentOpts) GetContext() string {
+func (m *DeleteAttachmentOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *DeleteSnapshotAttachmentOpts) GetAccessProtocol() string {
+func (m *DeleteAttachmentOpts) GetAccessProtocol() string {
+	if m != nil {
+		return m.AccessProtocol
+	}
+	return ""
+}
+
+// CreateSnapshotAttachmentOpts is a structure which indicates all required
+// properties for creating a snapshot attachment.
+type CreateSnapshotAttachmentOpts struct {
+	// The uuid of the snapshot attachment, optional.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The uuid of the snapshot, required.
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshotId" json:"snapshotId,omitempty"`
+	// This field indicates if the snapshot is attached locally, optional.
+	DoLocalAttach bool `protobuf:"varint,3,opt,name=doLocalAttach" json:"doLocalAttach,omitempty"`
+	// This field indicates if the snapshot is attached multiple times, optional.
+	MultiPath bool `protobuf:"varint,4,opt,name=multiPath" json:"multiPath,omitempty"`
+	// The infomation of the host node on which the snapshot will be attached.
+	HostInfo *HostInfo `protobuf:"bytes,5,opt,name=hostInfo" json:"hostInfo,omitempty"`
+	// The metadata of the snapshot attachment, optional.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
+	// The protocol
+	AccessProtocol string `protobuf:"bytes,9,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
+}
+
+func (m *CreateSnapshotAttachmentOpts) Reset()                    { *m = CreateSnapshotAttachmentOpts{} }
+func (m *CreateSnapshotAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateSnapshotAttachmentOpts) ProtoMessage()               {}
+func (*CreateSnapshotAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *CreateSnapshotAttachmentOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetDoLocalAttach() bool {
+	if m != nil {
+		return m.DoLocalAttach
+	}
+	return false
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetMultiPath() bool {
+	if m != nil {
+		return m.MultiPath
+	}
+	return false
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetHostInfo() *HostInfo {
+	if m != nil {
+		return m.HostInfo
+	}
+	return nil
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *CreateSnapshotAttachmentOpts) GetAccessProtocol() string {
+	if m != nil {
+		return m.AccessProtocol
+	}
+	return ""
+}
+
+// DeleteSnapshotAttachmentOpts is a structure which indicates all required
+// properties for deleting a snapshot attachment.
+type DeleteSnapshotAttachmentOpts struct {
+	// The uuid of the snapshot attachment, optional.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The uuid of the snapshot, required.
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshotId" json:"snapshotId,omitempty"`
+	// The infomation of the host node on which the volume will be attached.
+	HostInfo *HostInfo `protobuf:"bytes,3,opt,name=hostInfo" json:"hostInfo,omitempty"`
+	// The metadata of the snapshot attachment, optional.
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,5,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,6,opt,name=context" json:"context,omitempty"`
+	// The protocol
+	AccessProtocol string `protobuf:"bytes,7,opt,name=AccessProtocol" json:"AccessProtocol,omitempty"`
+}
+
+func (m *DeleteSnapshotAttachmentOpts) Reset()                    { *m = DeleteSnapshotAttachmentOpts{} }
+func (m *DeleteSnapshotAttachmentOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteSnapshotAttachmentOpts) ProtoMessage()               {}
+func (*DeleteSnapshotAttachmentOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *DeleteSnapshotAttachmentOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteSnapshotAttachmentOpts) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+func (m *DeleteSnapshotAttachmentOpts) GetHostInfo() *HostInfo {
+	if m != nil {
+		return m.HostInfo
+	}
+	return nil
+}
+
+func (m *DeleteSnapshotAttachmentOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *DeleteSnapshotAttachmentOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *DeleteSnapshotAttachmentOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *DeleteSnapshotAttachmentOpts) GetAccessProtocol() string {
+	if m != nil {
+		return m.AccessProtocol
+	}
+	return ""
+}
+
+type HostInfo struct {
+	// The platform of the host, such as "x86_64"
+	Platform string `protobuf:"bytes,1,opt,name=platform" json:"platform,omitempty"`
+	// The type of OS, such as "linux","windows", etc.
+	OsType string `protobuf:"bytes,2,opt,name=osType" json:"osType,omitempty"`
+	// The name of the host
+	Host string `protobuf:"bytes,3,opt,name=host" json:"host,omitempty"`
+	// The ip address of the host
+	Ip string `protobuf:"bytes,4,opt,name=ip" json:"ip,omitempty"`
+	// The initiator infomation, such as: "iqn.2017.com.redhat:e08039b48d5c"
+	Initiator string `protobuf:"bytes,5,opt,name=initiator" json:"initiator,omitempty"`
+}
+
+func (m *HostInfo) Reset()                    { *m = HostInfo{} }
+func (m *HostInfo) String() string            { return proto1.CompactTextString(m) }
+func (*HostInfo) ProtoMessage()               {}
+func (*HostInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *HostInfo) GetPlatform() string {
+	if m != nil {
+		return m.Platform
+	}
+	return ""
+}
+
+func (m *HostInfo) GetOsType() string {
+	if m != nil {
+		return m.OsType
+	}
+	return ""
+}
+
+func (m *HostInfo) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *HostInfo) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *HostInfo) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+// Qos is a structure which indicates the io limits of a volume.
+type Qos struct {
+	// The maximum io operations per second, zero means no limit.
+	MaxIOPS int64 `protobuf:"varint,1,opt,name=maxIOPS" json:"maxIOPS,omitempty"`
+	// The maximum bandwidth in MB per second, zero means no limit.
+	MaxBWS int64 `protobuf:"varint,2,opt,name=maxBWS" json:"maxBWS,omitempty"`
+}
+
+func (m *Qos) Reset()                    { *m = Qos{} }
+func (m *Qos) String() string            { return proto1.CompactTextString(m) }
+func (*Qos) ProtoMessage()               {}
+func (*Qos) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *Qos) GetMaxIOPS() int64 {
+	if m != nil {
+		return m.MaxIOPS
+	}
+	return 0
+}
+
+func (m *Qos) GetMaxBWS() int64 {
+	if m != nil {
+		return m.MaxBWS
+	}
+	return 0
+}
+
+type VolumeData struct {
+	Data map[string]string `protobuf:"bytes,1,rep,name=data" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *VolumeData) Reset()                    { *m = VolumeData{} }
+func (m *VolumeData) String() string            { return proto1.CompactTextString(m) }
+func (*VolumeData) ProtoMessage()               {}
+func (*VolumeData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *VolumeData) GetData() map[string]string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// CreateReplicationOpts is a structure which indicates all required properties
+// for creating a replication.
+// NOTE: Need to figure out how to handle more than 2 sites.
+type CreateReplicationOpts struct {
+	// The uuid of the replication, optional when creating.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The name of the replication, optional.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// The description of the replication, optional.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// The uuid of the primary volume. This field is required.
+	PrimaryVolumeId string `protobuf:"bytes,4,opt,name=primaryVolumeId" json:"primaryVolumeId,omitempty"`
+	// The uuid of the secondary volume. This field is required.
+	SecondaryVolumeId string `protobuf:"bytes,5,opt,name=secondaryVolumeId" json:"secondaryVolumeId,omitempty"`
+	// The dock infomation on which the request will be executed
+	AvailabilityZone string `protobuf:"bytes,6,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
+	// The service level that volume belongs to, required.
+	ProfileId string `protobuf:"bytes,7,opt,name=profileId" json:"profileId,omitempty"`
+	// The uuid of the pool on which volume will be created, required.
+	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
+	// The name of the pool on which volume will be created, required.
+	PoolName string `protobuf:"bytes,9,opt,name=poolName" json:"poolName,omitempty"`
+	// The metadata of the primary replication, optional.
+	PrimaryReplicationDriverData map[string]string `protobuf:"bytes,11,rep,name=primaryReplicationDriverData" json:"primaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The metadata of the seondary replication, optional.
+	SecondaryReplicationDriverData map[string]string `protobuf:"bytes,12,rep,name=secondaryReplicationDriverData" json:"secondaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The dock id.
+	DockId string `protobuf:"bytes,13,opt,name=dockId" json:"dockId,omitempty"`
+	// The replication driver type.
+	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
+	// Whether is primary replication
+	IsPrimary bool `protobuf:"varint,16,opt,name=isPrimary" json:"isPrimary,omitempty"`
+	// The replication metadata
+	Metadata map[string]string `protobuf:"bytes,17,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The volume data list
+	VolumeDataList []*VolumeData `protobuf:"bytes,18,rep,name=volumeDataList" json:"volumeDataList,omitempty"`
+	// the replication mode sync/async
+	ReplicationMode string `protobuf:"bytes,19,opt,name=replicationMode" json:"replicationMode,omitempty"`
+	// 0 means sync replication.
+	ReplicationPeriod int64 `protobuf:"varint,20,opt,name=ReplicationPeriod" json:"ReplicationPeriod,omitempty"`
+	// replication bandwidth
+	ReplicationBandwidth int64 `protobuf:"varint,21,opt,name=ReplicationBandwidth" json:"ReplicationBandwidth,omitempty"`
+}
+
+func (m *CreateReplicationOpts) Reset()                    { *m = CreateReplicationOpts{} }
+func (m *CreateReplicationOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateReplicationOpts) ProtoMessage()               {}
+func (*CreateReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *CreateReplicationOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetPrimaryVolumeId() string {
+	if m != nil {
+		return m.PrimaryVolumeId
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetSecondaryVolumeId() string {
+	if m != nil {
+		return m.SecondaryVolumeId
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetAvailabilityZone() string {
+	if m != nil {
+		return m.AvailabilityZone
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetProfileId() string {
+	if m != nil {
+		return m.ProfileId
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetPoolId() string {
+	if m != nil {
+		return m.PoolId
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
+	if m != nil {
+		return m.PrimaryReplicationDriverData
+	}
+	return nil
+}
+
+func (m *CreateReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
+	if m != nil {
+		return m.SecondaryReplicationDriverData
+	}
+	return nil
+}
+
+func (m *CreateReplicationOpts) GetDockId() string {
+	if m != nil {
+		return m.DockId
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetIsPrimary() bool {
+	if m != nil {
+		return m.IsPrimary
+	}
+	return false
+}
+
+func (m *CreateReplicationOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CreateReplicationOpts) GetVolumeDataList() []*VolumeData {
+	if m != nil {
+		return m.VolumeDataList
+	}
+	return nil
+}
+
+func (m *CreateReplicationOpts) GetReplicationMode() string {
+	if m != nil {
+		return m.ReplicationMode
+	}
+	return ""
+}
+
+func (m *CreateReplicationOpts) GetReplicationPeriod() int64 {
+	if m != nil {
+		return m.ReplicationPeriod
+	}
+	return 0
+}
+
+func (m *CreateReplicationOpts) GetReplicationBandwidth() int64 {
+	if m != nil {
+		return m.ReplicationBandwidth
+	}
+	return 0
+}
+
+// Delete ReplicationOpts is a structure which indicates all required properties
+// for deleting a replication.
+// NOTE: Need to figure out how to handle more than 2 sites.
+type DeleteReplicationOpts struct {
+	// The uuid of the replication, optional when creating.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The name of the replication, optional.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// The description of the replication, optional.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// The uuid of the primary volume. This field is required.
+	PrimaryVolumeId string `protobuf:"bytes,4,opt,name=primaryVolumeId" json:"primaryVolumeId,omitempty"`
+	// The uuid of the secondary volume. This field is required.
+	SecondaryVolumeId string `protobuf:"bytes,5,opt,name=secondaryVolumeId" json:"secondaryVolumeId,omitempty"`
+	// The dock infomation on which the request will be executed
+	AvailabilityZone string `protobuf:"bytes,6,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
+	// The service level that volume belongs to, required.
+	ProfileId string `protobuf:"bytes,7,opt,name=profileId" json:"profileId,omitempty"`
+	// The uuid of the pool on which volume will be created, required.
+	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
+	// The name of the pool on which volume will be created, required.
+	PoolName string `protobuf:"bytes,9,opt,name=poolName" json:"poolName,omitempty"`
+	// The metadata of the primary replication, optional.
+	PrimaryReplicationDriverData map[string]string `protobuf:"bytes,11,rep,name=primaryReplicationDriverData" json:"primaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The metadata of the seondary replication, optional.
+	SecondaryReplicationDriverData map[string]string `protobuf:"bytes,12,rep,name=secondaryReplicationDriverData" json:"secondaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The dock id.
+	DockId string `protobuf:"bytes,13,opt,name=dockId" json:"dockId,omitempty"`
+	// The replication driver type.
+	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
+	// The replication metadata
+	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Whether is primary replication
+	IsPrimary bool `protobuf:"varint,17,opt,name=isPrimary" json:"isPrimary,omitempty"`
+}
+
+func (m *DeleteReplicationOpts) Reset()                    { *m = DeleteReplicationOpts{} }
+func (m *DeleteReplicationOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteReplicationOpts) ProtoMessage()               {}
+func (*DeleteReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *DeleteReplicationOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetPrimaryVolumeId() string {
+	if m != nil {
+		return m.PrimaryVolumeId
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetSecondaryVolumeId() string {
+	if m != nil {
+		return m.SecondaryVolumeId
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetAvailabilityZone() string {
+	if m != nil {
+		return m.AvailabilityZone
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetProfileId() string {
+	if m != nil {
+		return m.ProfileId
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetPoolId() string {
+	if m != nil {
+		return m.PoolId
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
+	if m != nil {
+		return m.PrimaryReplicationDriverData
+	}
+	return nil
+}
+
+func (m *DeleteReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
+	if m != nil {
+		return m.SecondaryReplicationDriverData
+	}
+	return nil
+}
+
+func (m *DeleteReplicationOpts) GetDockId() string {
+	if m != nil {
+		return m.DockId
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *DeleteReplicationOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *DeleteReplicationOpts) GetIsPrimary() bool {
+	if m != nil {
+		return m.IsPrimary
+	}
+	return false
+}
+
+// Delete ReplicationOpts is a structure which indicates all required properties
+type EnableReplicationOpts struct {
+	// The uuid of the replication, optional when creating.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The name of the replication, optional.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// The description of the replication, optional.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// The uuid of the primary volume. This field is required.
+	PrimaryVolumeId string `protobuf:"bytes,4,opt,name=primaryVolumeId" json:"primaryVolumeId,omitempty"`
+	// The uuid of the secondary volume. This field is required.
+	SecondaryVolumeId string `protobuf:"bytes,5,opt,name=secondaryVolumeId" json:"secondaryVolumeId,omitempty"`
+	// The dock infomation on which the request will be executed
+	AvailabilityZone string `protobuf:"bytes,6,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
+	// The service level that volume belongs to, required.
+	ProfileId string `protobuf:"bytes,7,opt,name=profileId" json:"profileId,omitempty"`
+	// The uuid of the pool on which volume will be created, required.
+	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
+	// The name of the pool on which volume will be created, required.
+	PoolName string `protobuf:"bytes,9,opt,name=poolName" json:"poolName,omitempty"`
+	// The metadata of the primary replication, optional.
+	PrimaryReplicationDriverData map[string]string `protobuf:"bytes,11,rep,name=primaryReplicationDriverData" json:"primaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The metadata of the seondary replication, optional.
+	SecondaryReplicationDriverData map[string]string `protobuf:"bytes,12,rep,name=secondaryReplicationDriverData" json:"secondaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The dock id.
+	DockId string `protobuf:"bytes,13,opt,name=dockId" json:"dockId,omitempty"`
+	// The replication driver type.
+	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
+	// The replication metadata
+	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Whether is primary replication
+	IsPrimary bool `protobuf:"varint,17,opt,name=isPrimary" json:"isPrimary,omitempty"`
+}
+
+func (m *EnableReplicationOpts) Reset()                    { *m = EnableReplicationOpts{} }
+func (m *EnableReplicationOpts) String() string            { return proto1.CompactTextString(m) }
+func (*EnableReplicationOpts) ProtoMessage()               {}
+func (*EnableReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *EnableReplicationOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetPrimaryVolumeId() string {
+	if m != nil {
+		return m.PrimaryVolumeId
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetSecondaryVolumeId() string {
+	if m != nil {
+		return m.SecondaryVolumeId
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetAvailabilityZone() string {
+	if m != nil {
+		return m.AvailabilityZone
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetProfileId() string {
+	if m != nil {
+		return m.ProfileId
+	}
+	return ""
+}
+
+func (m *EnableReplicationOpts) GetPoolId() string {
 	if m != nil {
-		return m.AccessProtocol
+		return m.PoolId
 	}
 	return ""
 }
 
-type HostInfo struct {
-	// The platform of the host, such as "x86_64"
-	Platform string `protobuf:"bytes,1,opt,name=platform" json:"platform,omitempty"`
-	// The type of OS, such as "linux","windows", etc.
-	OsType string `protobuf:"bytes,2,opt,name=osType" json:"osType,omitempty"`
-	// The name of the host
-	Host string `protobuf:"bytes,3,opt,name=host" json:"host,omitempty"`
-	// The ip address of the host
-	Ip string `protobuf:"bytes,4,opt,name=ip" json:"ip,omitempty"`
-	// The initiator infomation, such as: "iqn.2017.com.redhat:e08039b48d5c"
-	Initiator string `protobuf:"bytes,5,opt,name=initiator" json:"initiator,omitempty"`
+func (m *EnableReplicationOpts) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
+	}
+	return ""
 }
 
-func (m *HostInfo) Reset()                    { *m = HostInfo{} }
-func (m *HostInfo) String() string            { return proto1.CompactTextString(m) }
-func (*HostInfo) ProtoMessage()               {}
-func (*HostInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
-
-func (m *HostInfo) GetPlatform() string {
+func (m *EnableReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
 	if m != nil {
-		return m.Platform
+		return m.PrimaryReplicationDriverData
 	}
-	return ""
+	return nil
 }
 
-func (m *HostInfo) GetOsType() string {
+func (m *EnableReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
 	if m != nil {
-		return m.OsType
+		return m.SecondaryReplicationDriverData
 	}
-	return ""
+	return nil
 }
 
-func (m *HostInfo) GetHost() string {
+func (m *EnableReplicationOpts) GetDockId() string {
 	if m != nil {
-		return m.Host
+		return m.DockId
 	}
 	return ""
 }
 
-func (m *HostInfo) GetIp() string {
+func (m *EnableReplicationOpts) GetDriverName() string {
 	if m != nil {
-		return m.Ip
+		return m.DriverName
 	}
 	return ""
 }
 
-func (m *HostInfo) GetInitiator() string {
+func (m *EnableReplicationOpts) GetContext() string {
 	if m != nil {
-		return m.Initiator
+		return m.Context
 	}
 	return ""
 }
 
-type VolumeData struct {
-	Data map[string]string `protobuf:"bytes,1,rep,name=data" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+func (m *EnableReplicationOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
 }
 
-func (m *VolumeData) Reset()                    { *m = VolumeData{} }
-func (m *VolumeData) String() string            { return proto1.CompactTextString(m) }
-func (*VolumeData) ProtoMessage()               {}
-func (*VolumeData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
-
-func (m *VolumeData) GetData() map[string]string {
+func (m *EnableReplicationOpts) GetIsPrimary() bool {
 	if m != nil {
-		return m.Data
+		return m.IsPrimary
 	}
-	return nil
+	return false
 }
 
-// CreateReplicationOpts is a structure which indicates all required properties
-// for creating a replication.
-// NOTE: Need to figure out how to handle more than 2 sites.
-type CreateReplicationOpts struct {
+// Delete ReplicationOpts is a structure which indicates all required properties
+type DisableReplicationOpts struct {
 	// The uuid of the replication, optional when creating.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	// The name of the replication, optional.
@@ -937,169 +1886,131 @@ type CreateReplicationOpts struct {
 	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
 	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
-	// Whether is primary replication
-	IsPrimary bool `protobuf:"varint,16,opt,name=isPrimary" json:"isPrimary,omitempty"`
 	// The replication metadata
-	Metadata map[string]string `protobuf:"bytes,17,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The volume data list
-	VolumeDataList []*VolumeData `protobuf:"bytes,18,rep,name=volumeDataList" json:"volumeDataList,omitempty"`
-	// the replication mode sync/async
-	ReplicationMode string `protobuf:"bytes,19,opt,name=replicationMode" json:"replicationMode,omitempty"`
-	// 0 means sync replication.
-	ReplicationPeriod int64 `protobuf:"varint,20,opt,name=ReplicationPeriod" json:"ReplicationPeriod,omitempty"`
-	// replication bandwidth
-	ReplicationBandwidth int64 `protobuf:"varint,21,opt,name=ReplicationBandwidth" json:"ReplicationBandwidth,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Whether is primary replication
+	IsPrimary bool `protobuf:"varint,17,opt,name=isPrimary" json:"isPrimary,omitempty"`
 }
 
-func (m *CreateReplicationOpts) Reset()                    { *m = CreateReplicationOpts{} }
-func (m *CreateReplicationOpts) String() string            { return proto1.CompactTextString(m) }
-func (*CreateReplicationOpts) ProtoMessage()               {}
-func (*CreateReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (m *DisableReplicationOpts) Reset()                    { *m = DisableReplicationOpts{} }
+func (m *DisableReplicationOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DisableReplicationOpts) ProtoMessage()               {}
+func (*DisableReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
-func (m *CreateReplicationOpts) GetId() string {
+func (m *DisableReplicationOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetName() string {
+func (m *DisableReplicationOpts) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetDescription() string {
+func (m *DisableReplicationOpts) GetDescription() string {
 	if m != nil {
 		return m.Description
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetPrimaryVolumeId() string {
+func (m *DisableReplicationOpts) GetPrimaryVolumeId() string {
 	if m != nil {
 		return m.PrimaryVolumeId
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetSecondaryVolumeId() string {
+func (m *DisableReplicationOpts) GetSecondaryVolumeId() string {
 	if m != nil {
 		return m.SecondaryVolumeId
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetAvailabilityZone() string {
+func (m *DisableReplicationOpts) GetAvailabilityZone() string {
 	if m != nil {
 		return m.AvailabilityZone
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetProfileId() string {
+func (m *DisableReplicationOpts) GetProfileId() string {
 	if m != nil {
 		return m.ProfileId
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetPoolId() string {
+func (m *DisableReplicationOpts) GetPoolId() string {
 	if m != nil {
 		return m.PoolId
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetPoolName() string {
+func (m *DisableReplicationOpts) GetPoolName() string {
 	if m != nil {
 		return m.PoolName
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
+func (m *DisableReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
 	if m != nil {
 		return m.PrimaryReplicationDriverData
 	}
 	return nil
 }
 
-func (m *CreateReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
+func (m *DisableReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
 	if m != nil {
 		return m.SecondaryReplicationDriverData
 	}
 	return nil
 }
 
-func (m *CreateReplicationOpts) GetDockId() string {
+func (m *DisableReplicationOpts) GetDockId() string {
 	if m != nil {
 		return m.DockId
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetDriverName() string {
+func (m *DisableReplicationOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetContext() string {
+func (m *DisableReplicationOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *CreateReplicationOpts) GetIsPrimary() bool {
-	if m != nil {
-		return m.IsPrimary
-	}
-	return false
-}
-
-func (m *CreateReplicationOpts) GetMetadata() map[string]string {
+func (m *DisableReplicationOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *CreateReplicationOpts) GetVolumeDataList() []*VolumeData {
-	if m != nil {
-		return m.VolumeDataList
-	}
-	return nil
-}
-
-func (m *CreateReplicationOpts) GetReplicationMode() string {
-	if m != nil {
-		return m.ReplicationMode
-	}
-	return ""
-}
-
-func (m *CreateReplicationOpts) GetReplicationPeriod() int64 {
-	if m != nil {
-		return m.ReplicationPeriod
-	}
-	return 0
-}
-
-func (m *CreateReplicationOpts) GetReplicationBandwidth() int64 {
+func (m *DisableReplicationOpts) GetIsPrimary() bool {
 	if m != nil {
-		return m.ReplicationBandwidth
+		return m.IsPrimary
 	}
-	return 0
+	return false
 }
 
 // Delete ReplicationOpts is a structure which indicates all required properties
-// for deleting a replication.
-// NOTE: Need to figure out how to handle more than 2 sites.
-type DeleteReplicationOpts struct {
+type FailoverReplicationOpts struct {
 	// The uuid of the replication, optional when creating.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	// The name of the replication, optional.
@@ -1128,779 +2039,831 @@ type DeleteReplicationOpts struct {
 	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
 	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
+	// Allow attached volume
+	AllowAttachedVolume bool `protobuf:"varint,16,opt,name=allowAttachedVolume" json:"allowAttachedVolume,omitempty"`
+	// The secondary backend id.
+	SecondaryBackendId string `protobuf:"bytes,17,opt,name=secondaryBackendId" json:"secondaryBackendId,omitempty"`
 	// The replication metadata
-	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,18,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Whether is primary replication
-	IsPrimary bool `protobuf:"varint,17,opt,name=isPrimary" json:"isPrimary,omitempty"`
+	IsPrimary bool `protobuf:"varint,19,opt,name=isPrimary" json:"isPrimary,omitempty"`
 }
 
-func (m *DeleteReplicationOpts) Reset()                    { *m = DeleteReplicationOpts{} }
-func (m *DeleteReplicationOpts) String() string            { return proto1.CompactTextString(m) }
-func (*DeleteReplicationOpts) ProtoMessage()               {}
-func (*DeleteReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *FailoverReplicationOpts) Reset()                    { *m = FailoverReplicationOpts{} }
+func (m *FailoverReplicationOpts) String() string            { return proto1.CompactTextString(m) }
+func (*FailoverReplicationOpts) ProtoMessage()               {}
+func (*FailoverReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
-func (m *DeleteReplicationOpts) GetId() string {
+func (m *FailoverReplicationOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetName() string {
+func (m *FailoverReplicationOpts) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetDescription() string {
+func (m *FailoverReplicationOpts) GetDescription() string {
 	if m != nil {
 		return m.Description
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetPrimaryVolumeId() string {
+func (m *FailoverReplicationOpts) GetPrimaryVolumeId() string {
 	if m != nil {
 		return m.PrimaryVolumeId
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetSecondaryVolumeId() string {
+func (m *FailoverReplicationOpts) GetSecondaryVolumeId() string {
 	if m != nil {
 		return m.SecondaryVolumeId
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetAvailabilityZone() string {
+func (m *FailoverReplicationOpts) GetAvailabilityZone() string {
 	if m != nil {
 		return m.AvailabilityZone
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetProfileId() string {
+func (m *FailoverReplicationOpts) GetProfileId() string {
 	if m != nil {
 		return m.ProfileId
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetPoolId() string {
+func (m *FailoverReplicationOpts) GetPoolId() string {
 	if m != nil {
 		return m.PoolId
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetPoolName() string {
+func (m *FailoverReplicationOpts) GetPoolName() string {
 	if m != nil {
 		return m.PoolName
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
+func (m *FailoverReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
 	if m != nil {
 		return m.PrimaryReplicationDriverData
 	}
 	return nil
 }
 
-func (m *DeleteReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
+func (m *FailoverReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
 	if m != nil {
 		return m.SecondaryReplicationDriverData
 	}
 	return nil
 }
 
-func (m *DeleteReplicationOpts) GetDockId() string {
+func (m *FailoverReplicationOpts) GetDockId() string {
 	if m != nil {
 		return m.DockId
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetDriverName() string {
+func (m *FailoverReplicationOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetContext() string {
+func (m *FailoverReplicationOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *DeleteReplicationOpts) GetMetadata() map[string]string {
+func (m *FailoverReplicationOpts) GetAllowAttachedVolume() bool {
+	if m != nil {
+		return m.AllowAttachedVolume
+	}
+	return false
+}
+
+func (m *FailoverReplicationOpts) GetSecondaryBackendId() string {
+	if m != nil {
+		return m.SecondaryBackendId
+	}
+	return ""
+}
+
+func (m *FailoverReplicationOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *DeleteReplicationOpts) GetIsPrimary() bool {
+func (m *FailoverReplicationOpts) GetIsPrimary() bool {
 	if m != nil {
 		return m.IsPrimary
 	}
 	return false
 }
 
-// Delete ReplicationOpts is a structure which indicates all required properties
-type EnableReplicationOpts struct {
-	// The uuid of the replication, optional when creating.
+// CreateVolumeGroupOpts is a structure which indicates all required
+// properties for creating a volume group.
+type CreateVolumeGroupOpts struct {
+	// The uuid of the volume group, optional when creating.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The name of the replication, optional.
+	// The name of the volume, required.
 	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// The description of the replication, optional.
-	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	// The uuid of the primary volume. This field is required.
-	PrimaryVolumeId string `protobuf:"bytes,4,opt,name=primaryVolumeId" json:"primaryVolumeId,omitempty"`
-	// The uuid of the secondary volume. This field is required.
-	SecondaryVolumeId string `protobuf:"bytes,5,opt,name=secondaryVolumeId" json:"secondaryVolumeId,omitempty"`
-	// The dock infomation on which the request will be executed
-	AvailabilityZone string `protobuf:"bytes,6,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
-	// The service level that volume belongs to, required.
-	ProfileId string `protobuf:"bytes,7,opt,name=profileId" json:"profileId,omitempty"`
-	// The uuid of the pool on which volume will be created, required.
-	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
-	// The name of the pool on which volume will be created, required.
-	PoolName string `protobuf:"bytes,9,opt,name=poolName" json:"poolName,omitempty"`
-	// The metadata of the primary replication, optional.
-	PrimaryReplicationDriverData map[string]string `protobuf:"bytes,11,rep,name=primaryReplicationDriverData" json:"primaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The metadata of the seondary replication, optional.
-	SecondaryReplicationDriverData map[string]string `protobuf:"bytes,12,rep,name=secondaryReplicationDriverData" json:"secondaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The dock id.
-	DockId string `protobuf:"bytes,13,opt,name=dockId" json:"dockId,omitempty"`
-	// The replication driver type.
-	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
+	// The driver of the volume group.
+	DriverName string `protobuf:"bytes,3,opt,name=driverName" json:"driverName,omitempty"`
+	// The description of the volume, optional.
+	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	// When create volume from snapshot, this field is required.
+	AvailabilityZone string `protobuf:"bytes,5,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
+	// The addVolumes contain UUIDs of volumes to be added to the group.
+	AddVolumes []string `protobuf:"bytes,6,rep,name=addVolumes" json:"addVolumes,omitempty"`
+	// The removeVolumes contains the volumes to be removed from the group.
+	RemoveVolumes []string `protobuf:"bytes,7,rep,name=removeVolumes" json:"removeVolumes,omitempty"`
+	// The pool belongs to the group.
+	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
-	// The replication metadata
-	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Whether is primary replication
-	IsPrimary bool `protobuf:"varint,17,opt,name=isPrimary" json:"isPrimary,omitempty"`
+	Context string `protobuf:"bytes,9,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *EnableReplicationOpts) Reset()                    { *m = EnableReplicationOpts{} }
-func (m *EnableReplicationOpts) String() string            { return proto1.CompactTextString(m) }
-func (*EnableReplicationOpts) ProtoMessage()               {}
-func (*EnableReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *CreateVolumeGroupOpts) Reset()                    { *m = CreateVolumeGroupOpts{} }
+func (m *CreateVolumeGroupOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateVolumeGroupOpts) ProtoMessage()               {}
+func (*CreateVolumeGroupOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
-func (m *EnableReplicationOpts) GetId() string {
+func (m *CreateVolumeGroupOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetName() string {
+func (m *CreateVolumeGroupOpts) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetDescription() string {
+func (m *CreateVolumeGroupOpts) GetDriverName() string {
 	if m != nil {
-		return m.Description
+		return m.DriverName
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetPrimaryVolumeId() string {
+func (m *CreateVolumeGroupOpts) GetDescription() string {
 	if m != nil {
-		return m.PrimaryVolumeId
+		return m.Description
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetSecondaryVolumeId() string {
+func (m *CreateVolumeGroupOpts) GetAvailabilityZone() string {
 	if m != nil {
-		return m.SecondaryVolumeId
+		return m.AvailabilityZone
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetAvailabilityZone() string {
+func (m *CreateVolumeGroupOpts) GetAddVolumes() []string {
 	if m != nil {
-		return m.AvailabilityZone
+		return m.AddVolumes
 	}
-	return ""
+	return nil
 }
 
-func (m *EnableReplicationOpts) GetProfileId() string {
+func (m *CreateVolumeGroupOpts) GetRemoveVolumes() []string {
 	if m != nil {
-		return m.ProfileId
+		return m.RemoveVolumes
 	}
-	return ""
+	return nil
 }
 
-func (m *EnableReplicationOpts) GetPoolId() string {
+func (m *CreateVolumeGroupOpts) GetPoolId() string {
 	if m != nil {
 		return m.PoolId
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetPoolName() string {
+func (m *CreateVolumeGroupOpts) GetContext() string {
 	if m != nil {
-		return m.PoolName
+		return m.Context
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
-	if m != nil {
-		return m.PrimaryReplicationDriverData
-	}
-	return nil
+type UpdateVolumeGroupOpts struct {
+	// The uuid of the volume group, optional when updating.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The driver of the volume group.
+	DriverName string `protobuf:"bytes,2,opt,name=driverName" json:"driverName,omitempty"`
+	// The addVolumes contain UUIDs of volumes to be added to the group.
+	AddVolumes []string `protobuf:"bytes,3,rep,name=addVolumes" json:"addVolumes,omitempty"`
+	// The removeVolumes contains the volumes to be removed from the group.
+	RemoveVolumes []string `protobuf:"bytes,4,rep,name=removeVolumes" json:"removeVolumes,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,5,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *EnableReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
-	if m != nil {
-		return m.SecondaryReplicationDriverData
-	}
-	return nil
-}
+func (m *UpdateVolumeGroupOpts) Reset()                    { *m = UpdateVolumeGroupOpts{} }
+func (m *UpdateVolumeGroupOpts) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateVolumeGroupOpts) ProtoMessage()               {}
+func (*UpdateVolumeGroupOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
-func (m *EnableReplicationOpts) GetDockId() string {
+func (m *UpdateVolumeGroupOpts) GetId() string {
 	if m != nil {
-		return m.DockId
+		return m.Id
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetDriverName() string {
+func (m *UpdateVolumeGroupOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *EnableReplicationOpts) GetContext() string {
+func (m *UpdateVolumeGroupOpts) GetAddVolumes() []string {
 	if m != nil {
-		return m.Context
+		return m.AddVolumes
 	}
-	return ""
+	return nil
 }
 
-func (m *EnableReplicationOpts) GetMetadata() map[string]string {
+func (m *UpdateVolumeGroupOpts) GetRemoveVolumes() []string {
 	if m != nil {
-		return m.Metadata
+		return m.RemoveVolumes
 	}
 	return nil
 }
 
-func (m *EnableReplicationOpts) GetIsPrimary() bool {
+func (m *UpdateVolumeGroupOpts) GetContext() string {
 	if m != nil {
-		return m.IsPrimary
+		return m.Context
 	}
-	return false
+	return ""
 }
 
-// Delete ReplicationOpts is a structure which indicates all required properties
-type DisableReplicationOpts struct {
-	// The uuid of the replication, optional when creating.
+type DeleteVolumeGroupOpts struct {
+	// The uuid of the volume group, optional when deleting.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The name of the replication, optional.
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// The description of the replication, optional.
-	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	// The uuid of the primary volume. This field is required.
-	PrimaryVolumeId string `protobuf:"bytes,4,opt,name=primaryVolumeId" json:"primaryVolumeId,omitempty"`
-	// The uuid of the secondary volume. This field is required.
-	SecondaryVolumeId string `protobuf:"bytes,5,opt,name=secondaryVolumeId" json:"secondaryVolumeId,omitempty"`
-	// The dock infomation on which the request will be executed
-	AvailabilityZone string `protobuf:"bytes,6,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
-	// The service level that volume belongs to, required.
-	ProfileId string `protobuf:"bytes,7,opt,name=profileId" json:"profileId,omitempty"`
-	// The uuid of the pool on which volume will be created, required.
-	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
-	// The name of the pool on which volume will be created, required.
-	PoolName string `protobuf:"bytes,9,opt,name=poolName" json:"poolName,omitempty"`
-	// The metadata of the primary replication, optional.
-	PrimaryReplicationDriverData map[string]string `protobuf:"bytes,11,rep,name=primaryReplicationDriverData" json:"primaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The metadata of the seondary replication, optional.
-	SecondaryReplicationDriverData map[string]string `protobuf:"bytes,12,rep,name=secondaryReplicationDriverData" json:"secondaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The dock id.
-	DockId string `protobuf:"bytes,13,opt,name=dockId" json:"dockId,omitempty"`
-	// The replication driver type.
-	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
+	// The driver of the volume group.
+	DriverName string `protobuf:"bytes,2,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
-	// The replication metadata
-	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Whether is primary replication
-	IsPrimary bool `protobuf:"varint,17,opt,name=isPrimary" json:"isPrimary,omitempty"`
+	Context string `protobuf:"bytes,3,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *DisableReplicationOpts) Reset()                    { *m = DisableReplicationOpts{} }
-func (m *DisableReplicationOpts) String() string            { return proto1.CompactTextString(m) }
-func (*DisableReplicationOpts) ProtoMessage()               {}
-func (*DisableReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *DeleteVolumeGroupOpts) Reset()                    { *m = DeleteVolumeGroupOpts{} }
+func (m *DeleteVolumeGroupOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteVolumeGroupOpts) ProtoMessage()               {}
+func (*DeleteVolumeGroupOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
-func (m *DisableReplicationOpts) GetId() string {
+func (m *DeleteVolumeGroupOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetName() string {
+func (m *DeleteVolumeGroupOpts) GetDriverName() string {
 	if m != nil {
-		return m.Name
+		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetDescription() string {
+func (m *DeleteVolumeGroupOpts) GetContext() string {
 	if m != nil {
-		return m.Description
+		return m.Context
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetPrimaryVolumeId() string {
+// CreateGroupSnapshotOpts is a structure which indicates all required
+// properties for creating a group snapshot.
+type CreateGroupSnapshotOpts struct {
+	// The uuid of the group snapshot, required.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The name of the group snapshot, optional.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// The description of the group snapshot, optional.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// The uuid of the volume group that snapshot belongs to, required.
+	GroupId string `protobuf:"bytes,4,opt,name=groupId" json:"groupId,omitempty"`
+	// The snapshots of all volumes in the group, required.
+	Snapshots []*CreateVolumeSnapshotOpts `protobuf:"bytes,5,rep,name=snapshots" json:"snapshots,omitempty"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,6,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,7,opt,name=context" json:"context,omitempty"`
+}
+
+func (m *CreateGroupSnapshotOpts) Reset()                    { *m = CreateGroupSnapshotOpts{} }
+func (m *CreateGroupSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateGroupSnapshotOpts) ProtoMessage()               {}
+func (*CreateGroupSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *CreateGroupSnapshotOpts) GetId() string {
 	if m != nil {
-		return m.PrimaryVolumeId
+		return m.Id
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetSecondaryVolumeId() string {
+func (m *CreateGroupSnapshotOpts) GetName() string {
 	if m != nil {
-		return m.SecondaryVolumeId
+		return m.Name
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetAvailabilityZone() string {
+func (m *CreateGroupSnapshotOpts) GetDescription() string {
 	if m != nil {
-		return m.AvailabilityZone
+		return m.Description
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetProfileId() string {
+func (m *CreateGroupSnapshotOpts) GetGroupId() string {
 	if m != nil {
-		return m.ProfileId
+		return m.GroupId
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetPoolId() string {
+func (m *CreateGroupSnapshotOpts) GetSnapshots() []*CreateVolumeSnapshotOpts {
 	if m != nil {
-		return m.PoolId
+		return m.Snapshots
 	}
-	return ""
+	return nil
 }
 
-func (m *DisableReplicationOpts) GetPoolName() string {
+func (m *CreateGroupSnapshotOpts) GetDriverName() string {
 	if m != nil {
-		return m.PoolName
+		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
+func (m *CreateGroupSnapshotOpts) GetContext() string {
 	if m != nil {
-		return m.PrimaryReplicationDriverData
+		return m.Context
 	}
-	return nil
+	return ""
 }
 
-func (m *DisableReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
-	if m != nil {
-		return m.SecondaryReplicationDriverData
-	}
-	return nil
+// DeleteGroupSnapshotOpts is a structure which indicates all required
+// properties for deleting a group snapshot.
+type DeleteGroupSnapshotOpts struct {
+	// The uuid of the group snapshot, required.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The uuid of the volume group that snapshot belongs to, required.
+	GroupId string `protobuf:"bytes,2,opt,name=groupId" json:"groupId,omitempty"`
+	// The snapshots of all volumes in the group, required.
+	Snapshots []*DeleteVolumeSnapshotOpts `protobuf:"bytes,3,rep,name=snapshots" json:"snapshots,omitempty"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,4,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,5,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *DisableReplicationOpts) GetDockId() string {
+func (m *DeleteGroupSnapshotOpts) Reset()                    { *m = DeleteGroupSnapshotOpts{} }
+func (m *DeleteGroupSnapshotOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteGroupSnapshotOpts) ProtoMessage()               {}
+func (*DeleteGroupSnapshotOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *DeleteGroupSnapshotOpts) GetId() string {
 	if m != nil {
-		return m.DockId
+		return m.Id
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetDriverName() string {
+func (m *DeleteGroupSnapshotOpts) GetGroupId() string {
 	if m != nil {
-		return m.DriverName
+		return m.GroupId
 	}
 	return ""
 }
 
-func (m *DisableReplicationOpts) GetContext() string {
+func (m *DeleteGroupSnapshotOpts) GetSnapshots() []*DeleteVolumeSnapshotOpts {
 	if m != nil {
-		return m.Context
+		return m.Snapshots
 	}
-	return ""
+	return nil
 }
 
-func (m *DisableReplicationOpts) GetMetadata() map[string]string {
+func (m *DeleteGroupSnapshotOpts) GetDriverName() string {
 	if m != nil {
-		return m.Metadata
+		return m.DriverName
 	}
-	return nil
+	return ""
 }
 
-func (m *DisableReplicationOpts) GetIsPrimary() bool {
+func (m *DeleteGroupSnapshotOpts) GetContext() string {
 	if m != nil {
-		return m.IsPrimary
+		return m.Context
 	}
-	return false
+	return ""
 }
 
-// Delete ReplicationOpts is a structure which indicates all required properties
-type FailoverReplicationOpts struct {
-	// The uuid of the replication, optional when creating.
+// CreateFileShareOpts is a structure which indicates all required properties
+// for creating a file share.
+type CreateFileShareOpts struct {
+	// The uuid of the file share, required.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The name of the replication, optional.
+	// The name of the file share, optional.
 	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// The description of the replication, optional.
+	// The description of the file share, optional.
 	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	// The uuid of the primary volume. This field is required.
-	PrimaryVolumeId string `protobuf:"bytes,4,opt,name=primaryVolumeId" json:"primaryVolumeId,omitempty"`
-	// The uuid of the secondary volume. This field is required.
-	SecondaryVolumeId string `protobuf:"bytes,5,opt,name=secondaryVolumeId" json:"secondaryVolumeId,omitempty"`
-	// The dock infomation on which the request will be executed
-	AvailabilityZone string `protobuf:"bytes,6,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
-	// The service level that volume belongs to, required.
-	ProfileId string `protobuf:"bytes,7,opt,name=profileId" json:"profileId,omitempty"`
-	// The uuid of the pool on which volume will be created, required.
-	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
-	// The name of the pool on which volume will be created, required.
-	PoolName string `protobuf:"bytes,9,opt,name=poolName" json:"poolName,omitempty"`
-	// The metadata of the primary replication, optional.
-	PrimaryReplicationDriverData map[string]string `protobuf:"bytes,11,rep,name=primaryReplicationDriverData" json:"primaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The metadata of the seondary replication, optional.
-	SecondaryReplicationDriverData map[string]string `protobuf:"bytes,12,rep,name=secondaryReplicationDriverData" json:"secondaryReplicationDriverData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// The dock id.
-	DockId string `protobuf:"bytes,13,opt,name=dockId" json:"dockId,omitempty"`
-	// The replication driver type.
-	DriverName string `protobuf:"bytes,14,opt,name=driverName" json:"driverName,omitempty"`
+	// The size of the file share in GB, required.
+	Size int64 `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+	// The locality that file share belongs to, required.
+	AvailabilityZone string `protobuf:"bytes,5,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
+	// The uuid of the profile which the file share belongs to, required.
+	ProfileId string `protobuf:"bytes,6,opt,name=profileId" json:"profileId,omitempty"`
+	// The uuid of the pool on which the file share will be created, required.
+	PoolId string `protobuf:"bytes,7,opt,name=poolId" json:"poolId,omitempty"`
+	// The name of the pool on which the file share will be created, required.
+	PoolName string `protobuf:"bytes,8,opt,name=poolName" json:"poolName,omitempty"`
+	// The file sharing protocol of the file share, required.
+	Protocol string `protobuf:"bytes,9,opt,name=protocol" json:"protocol,omitempty"`
+	// The metadata of the file share, optional.
+	Metadata map[string]string `protobuf:"bytes,10,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,11,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,15,opt,name=context" json:"context,omitempty"`
-	// Allow attached volume
-	AllowAttachedVolume bool `protobuf:"varint,16,opt,name=allowAttachedVolume" json:"allowAttachedVolume,omitempty"`
-	// The secondary backend id.
-	SecondaryBackendId string `protobuf:"bytes,17,opt,name=secondaryBackendId" json:"secondaryBackendId,omitempty"`
-	// The replication metadata
-	Metadata map[string]string `protobuf:"bytes,18,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Whether is primary replication
-	IsPrimary bool `protobuf:"varint,19,opt,name=isPrimary" json:"isPrimary,omitempty"`
+	Context string `protobuf:"bytes,12,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *FailoverReplicationOpts) Reset()                    { *m = FailoverReplicationOpts{} }
-func (m *FailoverReplicationOpts) String() string            { return proto1.CompactTextString(m) }
-func (*FailoverReplicationOpts) ProtoMessage()               {}
-func (*FailoverReplicationOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
-
-func (m *FailoverReplicationOpts) GetId() string {
+func (m *CreateFileShareOpts) Reset()                    { *m = CreateFileShareOpts{} }
+func (m *CreateFileShareOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateFileShareOpts) ProtoMessage()               {}
+func (*CreateFileShareOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *CreateFileShareOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetName() string {
+func (m *CreateFileShareOpts) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetDescription() string {
+func (m *CreateFileShareOpts) GetDescription() string {
 	if m != nil {
 		return m.Description
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetPrimaryVolumeId() string {
+func (m *CreateFileShareOpts) GetSize() int64 {
 	if m != nil {
-		return m.PrimaryVolumeId
+		return m.Size
 	}
-	return ""
+	return 0
 }
 
-func (m *FailoverReplicationOpts) GetSecondaryVolumeId() string {
+func (m *CreateFileShareOpts) GetAvailabilityZone() string {
 	if m != nil {
-		return m.SecondaryVolumeId
+		return m.AvailabilityZone
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetAvailabilityZone() string {
+func (m *CreateFileShareOpts) GetProfileId() string {
 	if m != nil {
-		return m.AvailabilityZone
+		return m.ProfileId
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetProfileId() string {
+func (m *CreateFileShareOpts) GetPoolId() string {
 	if m != nil {
-		return m.ProfileId
+		return m.PoolId
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetPoolId() string {
+func (m *CreateFileShareOpts) GetPoolName() string {
 	if m != nil {
-		return m.PoolId
+		return m.PoolName
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetPoolName() string {
+func (m *CreateFileShareOpts) GetProtocol() string {
 	if m != nil {
-		return m.PoolName
+		return m.Protocol
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetPrimaryReplicationDriverData() map[string]string {
+func (m *CreateFileShareOpts) GetMetadata() map[string]string {
 	if m != nil {
-		return m.PrimaryReplicationDriverData
+		return m.Metadata
 	}
 	return nil
 }
 
-func (m *FailoverReplicationOpts) GetSecondaryReplicationDriverData() map[string]string {
+func (m *CreateFileShareOpts) GetDriverName() string {
 	if m != nil {
-		return m.SecondaryReplicationDriverData
+		return m.DriverName
 	}
-	return nil
+	return ""
 }
 
-func (m *FailoverReplicationOpts) GetDockId() string {
+func (m *CreateFileShareOpts) GetContext() string {
 	if m != nil {
-		return m.DockId
+		return m.Context
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetDriverName() string {
+// DeleteFileShareOpts is a structure which indicates all required properties
+// for deleting a file share.
+type DeleteFileShareOpts struct {
+	// The uuid of the file share, required.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The metadata of the file share, optional.
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,3,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,4,opt,name=context" json:"context,omitempty"`
+}
+
+func (m *DeleteFileShareOpts) Reset()                    { *m = DeleteFileShareOpts{} }
+func (m *DeleteFileShareOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteFileShareOpts) ProtoMessage()               {}
+func (*DeleteFileShareOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *DeleteFileShareOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteFileShareOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *DeleteFileShareOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetContext() string {
+func (m *DeleteFileShareOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetAllowAttachedVolume() bool {
+// ExtendFileShareOpts is a structure which indicates all required properties
+// for extending a file share.
+type ExtendFileShareOpts struct {
+	// The uuid of the file share, required.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The new size of the file share in GB, required.
+	Size int64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	// The name of the pool which the file share belongs to, required.
+	PoolName string `protobuf:"bytes,3,opt,name=poolName" json:"poolName,omitempty"`
+	// The metadata of the file share, optional.
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,5,opt,name=driverName" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,6,opt,name=context" json:"context,omitempty"`
+}
+
+func (m *ExtendFileShareOpts) Reset()                    { *m = ExtendFileShareOpts{} }
+func (m *ExtendFileShareOpts) String() string            { return proto1.CompactTextString(m) }
+func (*ExtendFileShareOpts) ProtoMessage()               {}
+func (*ExtendFileShareOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ExtendFileShareOpts) GetId() string {
 	if m != nil {
-		return m.AllowAttachedVolume
+		return m.Id
 	}
-	return false
+	return ""
 }
 
-func (m *FailoverReplicationOpts) GetSecondaryBackendId() string {
+func (m *ExtendFileShareOpts) GetSize() int64 {
 	if m != nil {
-		return m.SecondaryBackendId
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ExtendFileShareOpts) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
 	}
 	return ""
 }
 
-func (m *FailoverReplicationOpts) GetMetadata() map[string]string {
+func (m *ExtendFileShareOpts) GetMetadata() map[string]string {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *FailoverReplicationOpts) GetIsPrimary() bool {
+func (m *ExtendFileShareOpts) GetDriverName() string {
 	if m != nil {
-		return m.IsPrimary
+		return m.DriverName
 	}
-	return false
+	return ""
 }
 
-// CreateVolumeGroupOpts is a structure which indicates all required
-// properties for creating a volume group.
-type CreateVolumeGroupOpts struct {
-	// The uuid of the volume group, optional when creating.
+func (m *ExtendFileShareOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+// CreateFileShareAclOpts is a structure which indicates all required
+// properties for granting the access to a file share.
+type CreateFileShareAclOpts struct {
+	// The uuid of the access rule, required.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The name of the volume, required.
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// The driver of the volume group.
-	DriverName string `protobuf:"bytes,3,opt,name=driverName" json:"driverName,omitempty"`
-	// The description of the volume, optional.
-	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
-	// When create volume from snapshot, this field is required.
-	AvailabilityZone string `protobuf:"bytes,5,opt,name=availabilityZone" json:"availabilityZone,omitempty"`
-	// The addVolumes contain UUIDs of volumes to be added to the group.
-	AddVolumes []string `protobuf:"bytes,6,rep,name=addVolumes" json:"addVolumes,omitempty"`
-	// The removeVolumes contains the volumes to be removed from the group.
-	RemoveVolumes []string `protobuf:"bytes,7,rep,name=removeVolumes" json:"removeVolumes,omitempty"`
-	// The pool belongs to the group.
-	PoolId string `protobuf:"bytes,8,opt,name=poolId" json:"poolId,omitempty"`
+	// The uuid of the file share, required.
+	FileShareId string `protobuf:"bytes,2,opt,name=fileShareId" json:"fileShareId,omitempty"`
+	// The type of the access rule, such as ip, required.
+	Type string `protobuf:"bytes,3,opt,name=type" json:"type,omitempty"`
+	// The clients which are allowed to access the file share, required.
+	AccessTo string `protobuf:"bytes,4,opt,name=accessTo" json:"accessTo,omitempty"`
+	// The access level of the clients, ro or rw, required.
+	AccessLevel string `protobuf:"bytes,5,opt,name=accessLevel" json:"accessLevel,omitempty"`
+	// The metadata of the file share, optional.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,7,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,9,opt,name=context" json:"context,omitempty"`
+	Context string `protobuf:"bytes,8,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *CreateVolumeGroupOpts) Reset()                    { *m = CreateVolumeGroupOpts{} }
-func (m *CreateVolumeGroupOpts) String() string            { return proto1.CompactTextString(m) }
-func (*CreateVolumeGroupOpts) ProtoMessage()               {}
-func (*CreateVolumeGroupOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (m *CreateFileShareAclOpts) Reset()                    { *m = CreateFileShareAclOpts{} }
+func (m *CreateFileShareAclOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CreateFileShareAclOpts) ProtoMessage()               {}
+func (*CreateFileShareAclOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
-func (m *CreateVolumeGroupOpts) GetId() string {
+func (m *CreateFileShareAclOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *CreateVolumeGroupOpts) GetName() string {
+func (m *CreateFileShareAclOpts) GetFileShareId() string {
 	if m != nil {
-		return m.Name
+		return m.FileShareId
 	}
 	return ""
 }
 
-func (m *CreateVolumeGroupOpts) GetDriverName() string {
+func (m *CreateFileShareAclOpts) GetType() string {
 	if m != nil {
-		return m.DriverName
+		return m.Type
 	}
 	return ""
 }
 
-func (m *CreateVolumeGroupOpts) GetDescription() string {
+func (m *CreateFileShareAclOpts) GetAccessTo() string {
 	if m != nil {
-		return m.Description
+		return m.AccessTo
 	}
 	return ""
 }
 
-func (m *CreateVolumeGroupOpts) GetAvailabilityZone() string {
+func (m *CreateFileShareAclOpts) GetAccessLevel() string {
 	if m != nil {
-		return m.AvailabilityZone
+		return m.AccessLevel
 	}
 	return ""
 }
 
-func (m *CreateVolumeGroupOpts) GetAddVolumes() []string {
-	if m != nil {
-		return m.AddVolumes
-	}
-	return nil
-}
-
-func (m *CreateVolumeGroupOpts) GetRemoveVolumes() []string {
+func (m *CreateFileShareAclOpts) GetMetadata() map[string]string {
 	if m != nil {
-		return m.RemoveVolumes
+		return m.Metadata
 	}
 	return nil
 }
 
-func (m *CreateVolumeGroupOpts) GetPoolId() string {
+func (m *CreateFileShareAclOpts) GetDriverName() string {
 	if m != nil {
-		return m.PoolId
+		return m.DriverName
 	}
 	return ""
 }
 
-func (m *CreateVolumeGroupOpts) GetContext() string {
+func (m *CreateFileShareAclOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-type UpdateVolumeGroupOpts struct {
-	// The uuid of the volume group, optional when updating.
+// DeleteFileShareAclOpts is a structure which indicates all required
+// properties for revoking the access to a file share.
+type DeleteFileShareAclOpts struct {
+	// The uuid of the access rule, required.
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The driver of the volume group.
-	DriverName string `protobuf:"bytes,2,opt,name=driverName" json:"driverName,omitempty"`
-	// The addVolumes contain UUIDs of volumes to be added to the group.
-	AddVolumes []string `protobuf:"bytes,3,rep,name=addVolumes" json:"addVolumes,omitempty"`
-	// The removeVolumes contains the volumes to be removed from the group.
-	RemoveVolumes []string `protobuf:"bytes,4,rep,name=removeVolumes" json:"removeVolumes,omitempty"`
+	// The uuid of the file share, required.
+	FileShareId string `protobuf:"bytes,2,opt,name=fileShareId" json:"fileShareId,omitempty"`
+	// The type of the access rule, such as ip, required.
+	Type string `protobuf:"bytes,3,opt,name=type" json:"type,omitempty"`
+	// The clients which are allowed to access the file share, required.
+	AccessTo string `protobuf:"bytes,4,opt,name=accessTo" json:"accessTo,omitempty"`
+	// The metadata of the file share, optional.
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,6,opt,name=driverName" json:"driverName,omitempty"`
 	// The Context
-	Context string `protobuf:"bytes,5,opt,name=context" json:"context,omitempty"`
+	Context string `protobuf:"bytes,7,opt,name=context" json:"context,omitempty"`
 }
 
-func (m *UpdateVolumeGroupOpts) Reset()                    { *m = UpdateVolumeGroupOpts{} }
-func (m *UpdateVolumeGroupOpts) String() string            { return proto1.CompactTextString(m) }
-func (*UpdateVolumeGroupOpts) ProtoMessage()               {}
-func (*UpdateVolumeGroupOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *DeleteFileShareAclOpts) Reset()                    { *m = DeleteFileShareAclOpts{} }
+func (m *DeleteFileShareAclOpts) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteFileShareAclOpts) ProtoMessage()               {}
+func (*DeleteFileShareAclOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
-func (m *UpdateVolumeGroupOpts) GetId() string {
+func (m *DeleteFileShareAclOpts) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *UpdateVolumeGroupOpts) GetDriverName() string {
+func (m *DeleteFileShareAclOpts) GetFileShareId() string {
 	if m != nil {
-		return m.DriverName
+		return m.FileShareId
 	}
 	return ""
 }
 
-func (m *UpdateVolumeGroupOpts) GetAddVolumes() []string {
-	if m != nil {
-		return m.AddVolumes
-	}
-	return nil
-}
-
-func (m *UpdateVolumeGroupOpts) GetRemoveVolumes() []string {
+func (m *DeleteFileShareAclOpts) GetType() string {
 	if m != nil {
-		return m.RemoveVolumes
+		return m.Type
 	}
-	return nil
+	return ""
 }
 
-func (m *UpdateVolumeGroupOpts) GetContext() string {
+func (m *DeleteFileShareAclOpts) GetAccessTo() string {
 	if m != nil {
-		return m.Context
+		return m.AccessTo
 	}
 	return ""
 }
 
-type DeleteVolumeGroupOpts struct {
-	// The uuid of the volume group, optional when deleting.
-	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	// The driver of the volume group.
-	DriverName string `protobuf:"bytes,2,opt,name=driverName" json:"driverName,omitempty"`
-	// The Context
-	Context string `protobuf:"bytes,3,opt,name=context" json:"context,omitempty"`
-}
-
-func (m *DeleteVolumeGroupOpts) Reset()                    { *m = DeleteVolumeGroupOpts{} }
-func (m *DeleteVolumeGroupOpts) String() string            { return proto1.CompactTextString(m) }
-func (*DeleteVolumeGroupOpts) ProtoMessage()               {}
-func (*DeleteVolumeGroupOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
-
-func (m *DeleteVolumeGroupOpts) GetId() string {
+func (m *DeleteFileShareAclOpts) GetMetadata() map[string]string {
 	if m != nil {
-		return m.Id
+		return m.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (m *DeleteVolumeGroupOpts) GetDriverName() string {
+func (m *DeleteFileShareAclOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DeleteVolumeGroupOpts) GetContext() string {
+func (m *DeleteFileShareAclOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
@@ -1923,7 +2886,7 @@ type AttachVolumeOpts struct {
 func (m *AttachVolumeOpts) Reset()                    { *m = AttachVolumeOpts{} }
 func (m *AttachVolumeOpts) String() string            { return proto1.CompactTextString(m) }
 func (*AttachVolumeOpts) ProtoMessage()               {}
-func (*AttachVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*AttachVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *AttachVolumeOpts) GetAccessProtocol() string {
 	if m != nil {
@@ -1969,7 +2932,7 @@ type DetachVolumeOpts struct {
 func (m *DetachVolumeOpts) Reset()                    { *m = DetachVolumeOpts{} }
 func (m *DetachVolumeOpts) String() string            { return proto1.CompactTextString(m) }
 func (*DetachVolumeOpts) ProtoMessage()               {}
-func (*DetachVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*DetachVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *DetachVolumeOpts) GetAccessProtocol() string {
 	if m != nil {
@@ -1999,6 +2962,52 @@ func (m *DetachVolumeOpts) GetContext() string {
 	return ""
 }
 
+// CopyVolumeOpts is a structure which indicates all required
+// properties for copying data between two attached volumes.
+type CopyVolumeOpts struct {
+	// The device path of the source volume, required.
+	SrcPath string `protobuf:"bytes,1,opt,name=srcPath" json:"srcPath,omitempty"`
+	// The device path of the destination volume, required.
+	DestPath string `protobuf:"bytes,2,opt,name=destPath" json:"destPath,omitempty"`
+	// The size of the data to be copied, in GB, required.
+	Size int64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,4,opt,name=context" json:"context,omitempty"`
+}
+
+func (m *CopyVolumeOpts) Reset()                    { *m = CopyVolumeOpts{} }
+func (m *CopyVolumeOpts) String() string            { return proto1.CompactTextString(m) }
+func (*CopyVolumeOpts) ProtoMessage()               {}
+func (*CopyVolumeOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *CopyVolumeOpts) GetSrcPath() string {
+	if m != nil {
+		return m.SrcPath
+	}
+	return ""
+}
+
+func (m *CopyVolumeOpts) GetDestPath() string {
+	if m != nil {
+		return m.DestPath
+	}
+	return ""
+}
+
+func (m *CopyVolumeOpts) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *CopyVolumeOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
 // Generic response, it return:
 // 1. Return result with message when create/update resource successfully.
 // 2. Return result without message when delete resource successfully.
@@ -2013,7 +3022,7 @@ type GenericResponse struct {
 func (m *GenericResponse) Reset()                    { *m = GenericResponse{} }
 func (m *GenericResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GenericResponse) ProtoMessage()               {}
-func (*GenericResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*GenericResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 type isGenericResponse_Reply interface {
 	isGenericResponse_Reply()
@@ -2131,7 +3140,7 @@ type GenericResponse_Result struct {
 func (m *GenericResponse_Result) Reset()                    { *m = GenericResponse_Result{} }
 func (m *GenericResponse_Result) String() string            { return proto1.CompactTextString(m) }
 func (*GenericResponse_Result) ProtoMessage()               {}
-func (*GenericResponse_Result) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21, 0} }
+func (*GenericResponse_Result) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35, 0} }
 
 func (m *GenericResponse_Result) GetMessage() string {
 	if m != nil {
@@ -2148,7 +3157,7 @@ type GenericResponse_Error struct {
 func (m *GenericResponse_Error) Reset()                    { *m = GenericResponse_Error{} }
 func (m *GenericResponse_Error) String() string            { return proto1.CompactTextString(m) }
 func (*GenericResponse_Error) ProtoMessage()               {}
-func (*GenericResponse_Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21, 1} }
+func (*GenericResponse_Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35, 1} }
 
 func (m *GenericResponse_Error) GetCode() string {
 	if m != nil {
@@ -2168,13 +3177,19 @@ func init() {
 	proto1.RegisterType((*CreateVolumeOpts)(nil), "proto.CreateVolumeOpts")
 	proto1.RegisterType((*DeleteVolumeOpts)(nil), "proto.DeleteVolumeOpts")
 	proto1.RegisterType((*ExtendVolumeOpts)(nil), "proto.ExtendVolumeOpts")
+	proto1.RegisterType((*RevertToSnapshotOpts)(nil), "proto.RevertToSnapshotOpts")
+	proto1.RegisterType((*MigrateVolumeOpts)(nil), "proto.MigrateVolumeOpts")
+	proto1.RegisterType((*RetypeVolumeOpts)(nil), "proto.RetypeVolumeOpts")
 	proto1.RegisterType((*CreateVolumeSnapshotOpts)(nil), "proto.CreateVolumeSnapshotOpts")
 	proto1.RegisterType((*DeleteVolumeSnapshotOpts)(nil), "proto.DeleteVolumeSnapshotOpts")
+	proto1.RegisterType((*PullVolumeOpts)(nil), "proto.PullVolumeOpts")
+	proto1.RegisterType((*PullVolumeSnapshotOpts)(nil), "proto.PullVolumeSnapshotOpts")
 	proto1.RegisterType((*CreateAttachmentOpts)(nil), "proto.CreateAttachmentOpts")
 	proto1.RegisterType((*DeleteAttachmentOpts)(nil), "proto.DeleteAttachmentOpts")
 	proto1.RegisterType((*CreateSnapshotAttachmentOpts)(nil), "proto.CreateSnapshotAttachmentOpts")
 	proto1.RegisterType((*DeleteSnapshotAttachmentOpts)(nil), "proto.DeleteSnapshotAttachmentOpts")
 	proto1.RegisterType((*HostInfo)(nil), "proto.HostInfo")
+	proto1.RegisterType((*Qos)(nil), "proto.Qos")
 	proto1.RegisterType((*VolumeData)(nil), "proto.VolumeData")
 	proto1.RegisterType((*CreateReplicationOpts)(nil), "proto.CreateReplicationOpts")
 	proto1.RegisterType((*DeleteReplicationOpts)(nil), "proto.DeleteReplicationOpts")
@@ -2184,8 +3199,16 @@ func init() {
 	proto1.RegisterType((*CreateVolumeGroupOpts)(nil), "proto.CreateVolumeGroupOpts")
 	proto1.RegisterType((*UpdateVolumeGroupOpts)(nil), "proto.UpdateVolumeGroupOpts")
 	proto1.RegisterType((*DeleteVolumeGroupOpts)(nil), "proto.DeleteVolumeGroupOpts")
+	proto1.RegisterType((*CreateGroupSnapshotOpts)(nil), "proto.CreateGroupSnapshotOpts")
+	proto1.RegisterType((*DeleteGroupSnapshotOpts)(nil), "proto.DeleteGroupSnapshotOpts")
+	proto1.RegisterType((*CreateFileShareOpts)(nil), "proto.CreateFileShareOpts")
+	proto1.RegisterType((*DeleteFileShareOpts)(nil), "proto.DeleteFileShareOpts")
+	proto1.RegisterType((*ExtendFileShareOpts)(nil), "proto.ExtendFileShareOpts")
+	proto1.RegisterType((*CreateFileShareAclOpts)(nil), "proto.CreateFileShareAclOpts")
+	proto1.RegisterType((*DeleteFileShareAclOpts)(nil), "proto.DeleteFileShareAclOpts")
 	proto1.RegisterType((*AttachVolumeOpts)(nil), "proto.AttachVolumeOpts")
 	proto1.RegisterType((*DetachVolumeOpts)(nil), "proto.DetachVolumeOpts")
+	proto1.RegisterType((*CopyVolumeOpts)(nil), "proto.CopyVolumeOpts")
 	proto1.RegisterType((*GenericResponse)(nil), "proto.GenericResponse")
 	proto1.RegisterType((*GenericResponse_Result)(nil), "proto.GenericResponse.Result")
 	proto1.RegisterType((*GenericResponse_Error)(nil), "proto.GenericResponse.Error")
@@ -2208,10 +3231,20 @@ type ProvisionDockClient interface {
 	DeleteVolume(ctx context.Context, in *DeleteVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Extend a volume
 	ExtendVolume(ctx context.Context, in *ExtendVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Revert a volume to snapshot
+	RevertToSnapshot(ctx context.Context, in *RevertToSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Migrate a volume to another pool of the same backend
+	MigrateVolume(ctx context.Context, in *MigrateVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Change the properties of a volume according to a new profile
+	RetypeVolume(ctx context.Context, in *RetypeVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Create a volume snapshot
 	CreateVolumeSnapshot(ctx context.Context, in *CreateVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Delete a volume snapshot
 	DeleteVolumeSnapshot(ctx context.Context, in *DeleteVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Pull the current state of a volume from the backend
+	PullVolume(ctx context.Context, in *PullVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Pull the current state of a volume snapshot from the backend
+	PullVolumeSnapshot(ctx context.Context, in *PullVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Create a volume attachment
 	CreateAttachment(ctx context.Context, in *CreateAttachmentOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Delete a volume attachment
@@ -2232,6 +3265,20 @@ type ProvisionDockClient interface {
 	UpdateVolumeGroup(ctx context.Context, in *UpdateVolumeGroupOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Delete volume group
 	DeleteVolumeGroup(ctx context.Context, in *DeleteVolumeGroupOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Create the snapshots of all volumes in a volume group
+	CreateGroupSnapshot(ctx context.Context, in *CreateGroupSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Delete a group snapshot
+	DeleteGroupSnapshot(ctx context.Context, in *DeleteGroupSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Create a file share
+	CreateFileShare(ctx context.Context, in *CreateFileShareOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Delete a file share
+	DeleteFileShare(ctx context.Context, in *DeleteFileShareOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Extend a file share
+	ExtendFileShare(ctx context.Context, in *ExtendFileShareOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Grant the access to a file share
+	CreateFileShareAcl(ctx context.Context, in *CreateFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Revoke the access to a file share
+	DeleteFileShareAcl(ctx context.Context, in *DeleteFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 }
 
 type provisionDockClient struct {
@@ -2269,18 +3316,63 @@ func (c *provisionDockClient) ExtendVolume(ctx context.Context, in *ExtendVolume
 	return out, nil
 }
 
-func (c *provisionDockClient) CreateVolumeSnapshot(ctx context.Context, in *CreateVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+func (c *provisionDockClient) RevertToSnapshot(ctx context.Context, in *RevertToSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/RevertToSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) MigrateVolume(ctx context.Context, in *MigrateVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/MigrateVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) RetypeVolume(ctx context.Context, in *RetypeVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/RetypeVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) CreateVolumeSnapshot(ctx context.Context, in *CreateVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/CreateVolumeSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) DeleteVolumeSnapshot(ctx context.Context, in *DeleteVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/DeleteVolumeSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) PullVolume(ctx context.Context, in *PullVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
-	err := grpc.Invoke(ctx, "/proto.ProvisionDock/CreateVolumeSnapshot", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/PullVolume", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *provisionDockClient) DeleteVolumeSnapshot(ctx context.Context, in *DeleteVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+func (c *provisionDockClient) PullVolumeSnapshot(ctx context.Context, in *PullVolumeSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
-	err := grpc.Invoke(ctx, "/proto.ProvisionDock/DeleteVolumeSnapshot", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/PullVolumeSnapshot", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2377,6 +3469,69 @@ func (c *provisionDockClient) DeleteVolumeGroup(ctx context.Context, in *DeleteV
 	return out, nil
 }
 
+func (c *provisionDockClient) CreateGroupSnapshot(ctx context.Context, in *CreateGroupSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/CreateGroupSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) DeleteGroupSnapshot(ctx context.Context, in *DeleteGroupSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/DeleteGroupSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) CreateFileShare(ctx context.Context, in *CreateFileShareOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/CreateFileShare", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) DeleteFileShare(ctx context.Context, in *DeleteFileShareOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/DeleteFileShare", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) ExtendFileShare(ctx context.Context, in *ExtendFileShareOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/ExtendFileShare", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) CreateFileShareAcl(ctx context.Context, in *CreateFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/CreateFileShareAcl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *provisionDockClient) DeleteFileShareAcl(ctx context.Context, in *DeleteFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.ProvisionDock/DeleteFileShareAcl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ProvisionDock service
 
 type ProvisionDockServer interface {
@@ -2386,10 +3541,20 @@ type ProvisionDockServer interface {
 	DeleteVolume(context.Context, *DeleteVolumeOpts) (*GenericResponse, error)
 	// Extend a volume
 	ExtendVolume(context.Context, *ExtendVolumeOpts) (*GenericResponse, error)
+	// Revert a volume to snapshot
+	RevertToSnapshot(context.Context, *RevertToSnapshotOpts) (*GenericResponse, error)
+	// Migrate a volume to another pool of the same backend
+	MigrateVolume(context.Context, *MigrateVolumeOpts) (*GenericResponse, error)
+	// Change the properties of a volume according to a new profile
+	RetypeVolume(context.Context, *RetypeVolumeOpts) (*GenericResponse, error)
 	// Create a volume snapshot
 	CreateVolumeSnapshot(context.Context, *CreateVolumeSnapshotOpts) (*GenericResponse, error)
 	// Delete a volume snapshot
 	DeleteVolumeSnapshot(context.Context, *DeleteVolumeSnapshotOpts) (*GenericResponse, error)
+	// Pull the current state of a volume from the backend
+	PullVolume(context.Context, *PullVolumeOpts) (*GenericResponse, error)
+	// Pull the current state of a volume snapshot from the backend
+	PullVolumeSnapshot(context.Context, *PullVolumeSnapshotOpts) (*GenericResponse, error)
 	// Create a volume attachment
 	CreateAttachment(context.Context, *CreateAttachmentOpts) (*GenericResponse, error)
 	// Delete a volume attachment
@@ -2410,6 +3575,20 @@ type ProvisionDockServer interface {
 	UpdateVolumeGroup(context.Context, *UpdateVolumeGroupOpts) (*GenericResponse, error)
 	// Delete volume group
 	DeleteVolumeGroup(context.Context, *DeleteVolumeGroupOpts) (*GenericResponse, error)
+	// Create the snapshots of all volumes in a volume group
+	CreateGroupSnapshot(context.Context, *CreateGroupSnapshotOpts) (*GenericResponse, error)
+	// Delete a group snapshot
+	DeleteGroupSnapshot(context.Context, *DeleteGroupSnapshotOpts) (*GenericResponse, error)
+	// Create a file share
+	CreateFileShare(context.Context, *CreateFileShareOpts) (*GenericResponse, error)
+	// Delete a file share
+	DeleteFileShare(context.Context, *DeleteFileShareOpts) (*GenericResponse, error)
+	// Extend a file share
+	ExtendFileShare(context.Context, *ExtendFileShareOpts) (*GenericResponse, error)
+	// Grant the access to a file share
+	CreateFileShareAcl(context.Context, *CreateFileShareAclOpts) (*GenericResponse, error)
+	// Revoke the access to a file share
+	DeleteFileShareAcl(context.Context, *DeleteFileShareAclOpts) (*GenericResponse, error)
 }
 
 func RegisterProvisionDockServer(s *grpc.Server, srv ProvisionDockServer) {
@@ -2470,6 +3649,60 @@ func _ProvisionDock_ExtendVolume_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionDock_RevertToSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertToSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).RevertToSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/RevertToSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).RevertToSnapshot(ctx, req.(*RevertToSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_MigrateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateVolumeOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).MigrateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/MigrateVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).MigrateVolume(ctx, req.(*MigrateVolumeOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_RetypeVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetypeVolumeOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).RetypeVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/RetypeVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).RetypeVolume(ctx, req.(*RetypeVolumeOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProvisionDock_CreateVolumeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateVolumeSnapshotOpts)
 	if err := dec(in); err != nil {
@@ -2506,6 +3739,42 @@ func _ProvisionDock_DeleteVolumeSnapshot_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionDock_PullVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullVolumeOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).PullVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/PullVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).PullVolume(ctx, req.(*PullVolumeOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_PullVolumeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullVolumeSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).PullVolumeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/PullVolumeSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).PullVolumeSnapshot(ctx, req.(*PullVolumeSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProvisionDock_CreateAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateAttachmentOpts)
 	if err := dec(in); err != nil {
@@ -2686,6 +3955,132 @@ func _ProvisionDock_DeleteVolumeGroup_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionDock_CreateGroupSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).CreateGroupSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/CreateGroupSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).CreateGroupSnapshot(ctx, req.(*CreateGroupSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_DeleteGroupSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).DeleteGroupSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/DeleteGroupSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).DeleteGroupSnapshot(ctx, req.(*DeleteGroupSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_CreateFileShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFileShareOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).CreateFileShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/CreateFileShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).CreateFileShare(ctx, req.(*CreateFileShareOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_DeleteFileShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileShareOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).DeleteFileShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/DeleteFileShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).DeleteFileShare(ctx, req.(*DeleteFileShareOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_ExtendFileShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendFileShareOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).ExtendFileShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/ExtendFileShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).ExtendFileShare(ctx, req.(*ExtendFileShareOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_CreateFileShareAcl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFileShareAclOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).CreateFileShareAcl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/CreateFileShareAcl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).CreateFileShareAcl(ctx, req.(*CreateFileShareAclOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProvisionDock_DeleteFileShareAcl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileShareAclOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).DeleteFileShareAcl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/DeleteFileShareAcl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).DeleteFileShareAcl(ctx, req.(*DeleteFileShareAclOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.ProvisionDock",
 	HandlerType: (*ProvisionDockServer)(nil),
@@ -2702,6 +4097,18 @@ var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExtendVolume",
 			Handler:    _ProvisionDock_ExtendVolume_Handler,
 		},
+		{
+			MethodName: "RevertToSnapshot",
+			Handler:    _ProvisionDock_RevertToSnapshot_Handler,
+		},
+		{
+			MethodName: "MigrateVolume",
+			Handler:    _ProvisionDock_MigrateVolume_Handler,
+		},
+		{
+			MethodName: "RetypeVolume",
+			Handler:    _ProvisionDock_RetypeVolume_Handler,
+		},
 		{
 			MethodName: "CreateVolumeSnapshot",
 			Handler:    _ProvisionDock_CreateVolumeSnapshot_Handler,
@@ -2710,6 +4117,14 @@ var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteVolumeSnapshot",
 			Handler:    _ProvisionDock_DeleteVolumeSnapshot_Handler,
 		},
+		{
+			MethodName: "PullVolume",
+			Handler:    _ProvisionDock_PullVolume_Handler,
+		},
+		{
+			MethodName: "PullVolumeSnapshot",
+			Handler:    _ProvisionDock_PullVolumeSnapshot_Handler,
+		},
 		{
 			MethodName: "CreateAttachment",
 			Handler:    _ProvisionDock_CreateAttachment_Handler,
@@ -2750,6 +4165,34 @@ var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteVolumeGroup",
 			Handler:    _ProvisionDock_DeleteVolumeGroup_Handler,
 		},
+		{
+			MethodName: "CreateGroupSnapshot",
+			Handler:    _ProvisionDock_CreateGroupSnapshot_Handler,
+		},
+		{
+			MethodName: "DeleteGroupSnapshot",
+			Handler:    _ProvisionDock_DeleteGroupSnapshot_Handler,
+		},
+		{
+			MethodName: "CreateFileShare",
+			Handler:    _ProvisionDock_CreateFileShare_Handler,
+		},
+		{
+			MethodName: "DeleteFileShare",
+			Handler:    _ProvisionDock_DeleteFileShare_Handler,
+		},
+		{
+			MethodName: "ExtendFileShare",
+			Handler:    _ProvisionDock_ExtendFileShare_Handler,
+		},
+		{
+			MethodName: "CreateFileShareAcl",
+			Handler:    _ProvisionDock_CreateFileShareAcl_Handler,
+		},
+		{
+			MethodName: "DeleteFileShareAcl",
+			Handler:    _ProvisionDock_DeleteFileShareAcl_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dock.proto",
@@ -2762,6 +4205,8 @@ type AttachDockClient interface {
 	AttachVolume(ctx context.Context, in *AttachVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Detach a volume
 	DetachVolume(ctx context.Context, in *DetachVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Copy data between two attached volumes
+	CopyVolume(ctx context.Context, in *CopyVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 }
 
 type attachDockClient struct {
@@ -2790,6 +4235,15 @@ func (c *attachDockClient) DetachVolume(ctx context.Context, in *DetachVolumeOpt
 	return out, nil
 }
 
+func (c *attachDockClient) CopyVolume(ctx context.Context, in *CopyVolumeOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := grpc.Invoke(ctx, "/proto.AttachDock/CopyVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for AttachDock service
 
 type AttachDockServer interface {
@@ -2797,6 +4251,8 @@ type AttachDockServer interface {
 	AttachVolume(context.Context, *AttachVolumeOpts) (*GenericResponse, error)
 	// Detach a volume
 	DetachVolume(context.Context, *DetachVolumeOpts) (*GenericResponse, error)
+	// Copy data between two attached volumes
+	CopyVolume(context.Context, *CopyVolumeOpts) (*GenericResponse, error)
 }
 
 func RegisterAttachDockServer(s *grpc.Server, srv AttachDockServer) {
@@ -2839,6 +4295,24 @@ func _AttachDock_DetachVolume_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttachDock_CopyVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyVolumeOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachDockServer).CopyVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AttachDock/CopyVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachDockServer).CopyVolume(ctx, req.(*CopyVolumeOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AttachDock_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.AttachDock",
 	HandlerType: (*AttachDockServer)(nil),
@@ -2851,6 +4325,10 @@ var _AttachDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DetachVolume",
 			Handler:    _AttachDock_DetachVolume_Handler,
 		},
+		{
+			MethodName: "CopyVolume",
+			Handler:    _AttachDock_CopyVolume_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dock.proto",
@@ -2859,115 +4337,163 @@ var _AttachDock_serviceDesc = grpc.ServiceDesc{
 func init() { proto1.RegisterFile("dock.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1745 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x73, 0xdb, 0x44,
-	0x14, 0xaf, 0xe5, 0x38, 0xb6, 0x5f, 0xbe, 0x9c, 0x4d, 0xd2, 0x6a, 0xdc, 0x34, 0x04, 0x53, 0x3a,
-	0xa1, 0x2d, 0x81, 0x1a, 0x66, 0xca, 0xc7, 0xf0, 0x91, 0x34, 0x69, 0xe3, 0xa1, 0xa1, 0xa9, 0x0a,
-	0x3d, 0x70, 0x53, 0xa5, 0x2d, 0xd1, 0x44, 0xd6, 0x6a, 0x24, 0xc5, 0x6d, 0x38, 0x31, 0x85, 0x43,
-	0xe1, 0xc8, 0x8d, 0x23, 0xc3, 0x81, 0x13, 0xff, 0x05, 0xc3, 0x1f, 0xc0, 0x0c, 0xb7, 0x1e, 0xb8,
-	0x32, 0xc3, 0x81, 0x3f, 0xa0, 0x07, 0x46, 0xbb, 0x92, 0xac, 0x8f, 0xd5, 0x5a, 0xc1, 0x49, 0x9a,
-	0x4e, 0x73, 0xb2, 0xf5, 0x76, 0xf5, 0xf6, 0xbd, 0xdf, 0xbe, 0xdf, 0xdb, 0x0f, 0x3d, 0x00, 0x9d,
-	0x68, 0x3b, 0xcb, 0xb6, 0x43, 0x3c, 0x82, 0x2a, 0xf4, 0xa7, 0xf5, 0x6f, 0x05, 0x1a, 0xd7, 0x1c,
-	0xac, 0x7a, 0xf8, 0x2e, 0x31, 0x77, 0xbb, 0xf8, 0x96, 0xed, 0xb9, 0x68, 0x12, 0x24, 0x43, 0x97,
-	0x4b, 0x8b, 0xa5, 0xa5, 0xba, 0x22, 0x19, 0x3a, 0x42, 0x30, 0x62, 0xa9, 0x5d, 0x2c, 0x4b, 0x54,
-	0x42, 0xff, 0xfb, 0x32, 0xd7, 0xf8, 0x0a, 0xcb, 0xe5, 0xc5, 0xd2, 0x52, 0x59, 0xa1, 0xff, 0xd1,
-	0x22, 0x8c, 0xe9, 0xd8, 0xd5, 0x1c, 0xc3, 0xf6, 0x0c, 0x62, 0xc9, 0x23, 0xb4, 0x7b, 0x5c, 0x84,
-	0x16, 0x00, 0x5c, 0x4b, 0xb5, 0xdd, 0x6d, 0xe2, 0x75, 0x74, 0xb9, 0x42, 0x3b, 0xc4, 0x24, 0xe8,
-	0x22, 0x34, 0xd4, 0x9e, 0x6a, 0x98, 0xea, 0x3d, 0xc3, 0x34, 0xbc, 0xbd, 0x2f, 0x88, 0x85, 0xe5,
-	0x51, 0xda, 0x2b, 0x23, 0x47, 0xf3, 0x50, 0xb7, 0x1d, 0x72, 0xdf, 0x30, 0x71, 0x47, 0x97, 0xab,
-	0xb4, 0x53, 0x5f, 0x80, 0x4e, 0xc3, 0xa8, 0x4d, 0x88, 0xd9, 0xd1, 0xe5, 0x1a, 0x6d, 0x0a, 0x9e,
-	0x50, 0x13, 0x6a, 0xfe, 0xbf, 0x4f, 0x7d, 0x7f, 0xea, 0xb4, 0x25, 0x7a, 0x46, 0x2b, 0x50, 0xeb,
-	0x62, 0x4f, 0xd5, 0x55, 0x4f, 0x95, 0x61, 0xb1, 0xbc, 0x34, 0xd6, 0x7e, 0x95, 0xa1, 0xb5, 0x9c,
-	0x86, 0x68, 0x79, 0x33, 0xe8, 0xb7, 0x6e, 0x79, 0xce, 0x9e, 0x12, 0xbd, 0xe6, 0x3b, 0xa8, 0x3b,
-	0x46, 0x0f, 0x3b, 0x74, 0x80, 0x31, 0xe6, 0x60, 0x5f, 0x82, 0x64, 0xa8, 0x6a, 0xc4, 0xf2, 0xf0,
-	0x43, 0x4f, 0x1e, 0xa7, 0x8d, 0xe1, 0x23, 0xda, 0x86, 0x39, 0x07, 0xdb, 0xa6, 0xa1, 0xa9, 0x3e,
-	0x52, 0x6b, 0xf4, 0x95, 0x35, 0xdf, 0x92, 0x09, 0x6a, 0x49, 0x3b, 0xcf, 0x12, 0x85, 0xf7, 0x12,
-	0x33, 0x8b, 0xaf, 0x10, 0x9d, 0x87, 0x89, 0x58, 0x43, 0x47, 0x97, 0x27, 0xa9, 0x25, 0x49, 0x21,
-	0x6a, 0xc1, 0x78, 0x38, 0x31, 0x77, 0xfc, 0x89, 0x9e, 0xa2, 0x13, 0x9d, 0x90, 0xa1, 0xcb, 0x30,
-	0x1d, 0x3e, 0x5f, 0x77, 0x48, 0xf7, 0x9a, 0x49, 0x76, 0x75, 0xb9, 0xb1, 0x58, 0x5a, 0xaa, 0x29,
-	0xd9, 0x86, 0xe6, 0xfb, 0x30, 0x91, 0x80, 0x0d, 0x35, 0xa0, 0xbc, 0x83, 0xf7, 0x82, 0x40, 0xf3,
-	0xff, 0xa2, 0x59, 0xa8, 0xf4, 0x54, 0x73, 0x37, 0x0c, 0x35, 0xf6, 0xf0, 0x9e, 0xf4, 0x4e, 0xa9,
-	0xb9, 0x01, 0xcd, 0x7c, 0x4f, 0xf7, 0xa3, 0xa9, 0xf5, 0xa4, 0x04, 0x8d, 0x35, 0x6c, 0x62, 0x61,
-	0xc8, 0xc7, 0x43, 0x41, 0x4a, 0x84, 0x42, 0xfa, 0xd5, 0x82, 0xa1, 0x50, 0x16, 0x85, 0xc2, 0x48,
-	0x22, 0x14, 0x86, 0x02, 0xaa, 0xf5, 0x5b, 0x19, 0x1a, 0xeb, 0x0f, 0x3d, 0x6c, 0xe9, 0x27, 0x8c,
-	0x16, 0x30, 0x3a, 0x0d, 0xd1, 0xc1, 0x33, 0x7a, 0xb8, 0x69, 0xfc, 0x43, 0x02, 0x39, 0xce, 0xf5,
-	0x3b, 0x01, 0xa4, 0x87, 0x3c, 0x9d, 0x4d, 0xa8, 0xf5, 0xe8, 0x78, 0xd1, 0x64, 0x46, 0xcf, 0xa8,
-	0x13, 0x03, 0x73, 0x94, 0x82, 0xf9, 0x3a, 0x27, 0x29, 0xc5, 0x0d, 0x2d, 0x08, 0x6a, 0x55, 0x04,
-	0x6a, 0xed, 0x00, 0x41, 0x7d, 0x2c, 0x81, 0x1c, 0xe7, 0xaf, 0x10, 0xd4, 0x38, 0x14, 0x92, 0x00,
-	0x8a, 0x72, 0x02, 0x8a, 0x3c, 0xf5, 0x05, 0xa1, 0x18, 0x11, 0x41, 0x51, 0x39, 0x40, 0x28, 0x7e,
-	0x2c, 0xc3, 0x2c, 0x9b, 0xb6, 0x15, 0xcf, 0x53, 0xb5, 0xed, 0x2e, 0xb6, 0xf6, 0x0f, 0xc3, 0x79,
-	0x98, 0xd0, 0xc9, 0x4d, 0xa2, 0xa9, 0x26, 0x53, 0x42, 0x83, 0xad, 0xa6, 0x24, 0x85, 0x3e, 0xad,
-	0xbb, 0xbb, 0xa6, 0x67, 0x6c, 0xa9, 0xde, 0x36, 0x75, 0xb0, 0xa6, 0xf4, 0x05, 0xe8, 0x12, 0xd4,
-	0xb6, 0x89, 0xeb, 0x75, 0xac, 0xfb, 0x84, 0x3a, 0x38, 0xd6, 0x9e, 0x0a, 0xa0, 0xdc, 0x08, 0xc4,
-	0x4a, 0xd4, 0x01, 0xad, 0x67, 0x42, 0xf0, 0xb5, 0x44, 0x08, 0x26, 0x7d, 0x39, 0xf8, 0xf0, 0x43,
-	0x17, 0x60, 0x72, 0x45, 0xd3, 0xb0, 0xeb, 0x6e, 0xf9, 0xa3, 0x6a, 0xc4, 0x0c, 0x52, 0x4e, 0x4a,
-	0x3a, 0xdc, 0xdc, 0xfc, 0x29, 0xc1, 0x2c, 0x8b, 0xa3, 0x21, 0xe6, 0x26, 0x8e, 0x6b, 0x79, 0x3f,
-	0xb8, 0x8e, 0x24, 0x70, 0xe5, 0xd9, 0x51, 0x10, 0xd7, 0x8a, 0x08, 0xd7, 0xd1, 0x41, 0xb8, 0x56,
-	0x0f, 0x1e, 0xd7, 0x5f, 0xcb, 0x30, 0xcf, 0xe2, 0x24, 0x64, 0xe6, 0x00, 0x7c, 0x93, 0x8b, 0x9b,
-	0x94, 0x59, 0xdc, 0x8e, 0x3c, 0xfe, 0x37, 0x33, 0xf1, 0x7f, 0x25, 0x11, 0xff, 0x7c, 0xbf, 0x9e,
-	0x57, 0x1e, 0xfc, 0x2d, 0xc1, 0x3c, 0x8b, 0xbf, 0x03, 0x9a, 0xaf, 0x7d, 0x71, 0x62, 0x33, 0xc3,
-	0x89, 0x2b, 0x09, 0x4e, 0x0c, 0x85, 0xf5, 0xb1, 0xe3, 0xc6, 0xd7, 0x25, 0xa8, 0x85, 0x20, 0xd0,
-	0x2d, 0x95, 0xa9, 0x7a, 0xf7, 0x89, 0xd3, 0x0d, 0xde, 0x8e, 0x9e, 0xfd, 0x6d, 0x18, 0x71, 0x3f,
-	0xdb, 0xb3, 0x43, 0x1d, 0xc1, 0x93, 0xbf, 0xdf, 0xf0, 0xa1, 0x0b, 0x36, 0xba, 0xf4, 0x3f, 0x9d,
-	0x1f, 0x3b, 0x58, 0xd3, 0x24, 0xc3, 0xf6, 0x99, 0x60, 0x58, 0x86, 0x67, 0xa8, 0x1e, 0x71, 0x02,
-	0x08, 0xfa, 0x82, 0x56, 0x0f, 0x80, 0xad, 0x9b, 0xf4, 0x94, 0xf2, 0x06, 0x8c, 0x50, 0xe8, 0x4b,
-	0x14, 0xfa, 0xb3, 0x01, 0xf4, 0xfd, 0x0e, 0xcb, 0xfd, 0x73, 0x0e, 0xed, 0xd8, 0xbc, 0x0a, 0xf5,
-	0xff, 0x77, 0x20, 0xf8, 0xb9, 0x0e, 0x73, 0x8c, 0x3e, 0xb1, 0x13, 0x46, 0xe1, 0x7d, 0x56, 0x6a,
-	0x4f, 0x55, 0xce, 0xee, 0xa9, 0x96, 0x60, 0xca, 0x76, 0x8c, 0xae, 0xea, 0xec, 0xdd, 0x0d, 0x93,
-	0x35, 0x83, 0x24, 0x2d, 0xa6, 0xe7, 0x29, 0xac, 0x11, 0x4b, 0x8f, 0xf7, 0x65, 0x38, 0x65, 0x1b,
-	0x9e, 0xf1, 0xd6, 0xfa, 0x51, 0x09, 0xe6, 0x03, 0xfb, 0xb9, 0x07, 0x33, 0x79, 0x8c, 0x4e, 0xdc,
-	0x87, 0x89, 0xfc, 0x94, 0x02, 0x78, 0x79, 0x4b, 0xa0, 0x80, 0xcd, 0xad, 0x70, 0x0c, 0xf4, 0xb8,
-	0x04, 0x0b, 0x11, 0x30, 0x7c, 0x33, 0xc6, 0xa9, 0x19, 0x1f, 0x0b, 0xcd, 0xb8, 0x23, 0x54, 0xc1,
-	0x0c, 0x19, 0x30, 0x8e, 0x8f, 0xa1, 0x4e, 0xb4, 0x9d, 0x8e, 0x2e, 0x4f, 0x30, 0x0c, 0xd9, 0x53,
-	0x8a, 0xf7, 0x93, 0x22, 0xde, 0x4f, 0x25, 0x79, 0xef, 0xb3, 0xc5, 0x0d, 0x10, 0x0a, 0x4e, 0xd5,
-	0x7d, 0x01, 0xba, 0x1e, 0x4b, 0x4f, 0xd3, 0xd4, 0xc7, 0x8b, 0x42, 0x1f, 0xf3, 0xf2, 0xd2, 0xbb,
-	0x30, 0xd9, 0x8b, 0x48, 0x75, 0xd3, 0x70, 0x3d, 0x19, 0x51, 0x6d, 0xd3, 0x19, 0xc6, 0x29, 0xa9,
-	0x8e, 0x7e, 0x60, 0xc7, 0xee, 0x0c, 0x36, 0x89, 0x8e, 0xe5, 0x19, 0x16, 0xd8, 0x29, 0xb1, 0x1f,
-	0xd8, 0x31, 0x7b, 0xb6, 0xb0, 0x63, 0x10, 0x5d, 0x9e, 0xa5, 0x27, 0x93, 0x6c, 0x03, 0x6a, 0xc3,
-	0x6c, 0x4c, 0xb8, 0xaa, 0x5a, 0xfa, 0x03, 0x43, 0xf7, 0xb6, 0xe5, 0x39, 0xfa, 0x02, 0xb7, 0xad,
-	0x79, 0x0b, 0x5e, 0x1e, 0x18, 0x4c, 0xfb, 0xba, 0x70, 0xb8, 0x0d, 0xaf, 0x14, 0x08, 0x8b, 0x7d,
-	0xa9, 0x1c, 0x2a, 0x41, 0x3f, 0xa9, 0xc2, 0x1c, 0x5b, 0x78, 0x4e, 0xb2, 0xd4, 0xa1, 0x65, 0x29,
-	0x2e, 0xc0, 0x47, 0x9f, 0xa5, 0xf8, 0x66, 0x1c, 0xcf, 0x2c, 0x15, 0xcf, 0x43, 0x8d, 0x44, 0x1e,
-	0xe2, 0x7b, 0x91, 0x97, 0x87, 0x12, 0xd9, 0x6e, 0x3a, 0x95, 0xed, 0x5e, 0x0c, 0x7a, 0xaf, 0x5b,
-	0xea, 0x3d, 0xf3, 0x84, 0xde, 0x87, 0x47, 0x6f, 0x2e, 0xc0, 0x47, 0x4f, 0x6f, 0xbe, 0x19, 0xcf,
-	0x1b, 0xbd, 0xf9, 0x5e, 0x9c, 0xd0, 0x9b, 0x4b, 0xef, 0xbf, 0xaa, 0x70, 0x7a, 0xcd, 0x70, 0x4f,
-	0xf8, 0xbd, 0x3f, 0x7e, 0x7f, 0x53, 0x8c, 0xdf, 0x1f, 0x85, 0x2b, 0x0e, 0x17, 0xe1, 0xa1, 0x09,
-	0xfe, 0x5d, 0x51, 0x82, 0xaf, 0x88, 0xed, 0x38, 0x9e, 0x0c, 0xbf, 0x91, 0x61, 0xf8, 0x25, 0xb1,
-	0x1b, 0x27, 0x14, 0xe7, 0x52, 0xfc, 0xf7, 0x1a, 0x9c, 0xb9, 0xae, 0x1a, 0x26, 0xe9, 0x61, 0xe7,
-	0x84, 0xe3, 0xc5, 0x39, 0xfe, 0x6d, 0x31, 0x8e, 0x87, 0x8b, 0x67, 0x0e, 0xc4, 0x43, 0x93, 0xfc,
-	0xfb, 0xa2, 0x24, 0x5f, 0x1d, 0x60, 0xc8, 0xf1, 0x64, 0xf9, 0x9b, 0x30, 0xa3, 0x9a, 0x26, 0x79,
-	0xc0, 0x6e, 0x2b, 0x71, 0xf0, 0xe5, 0x33, 0xb8, 0x56, 0xe0, 0x35, 0xa1, 0x65, 0x40, 0x91, 0x95,
-	0xab, 0xaa, 0xb6, 0x83, 0x2d, 0xbd, 0xa3, 0x53, 0x5e, 0xd7, 0x15, 0x4e, 0x0b, 0xda, 0x88, 0xe5,
-	0x11, 0x76, 0x85, 0x70, 0x79, 0x00, 0x52, 0x85, 0x12, 0xc9, 0xcc, 0x0b, 0x97, 0x48, 0x7e, 0x92,
-	0xc2, 0xfb, 0x48, 0x36, 0x13, 0x37, 0x1c, 0xb2, 0x6b, 0x17, 0x4e, 0x23, 0x83, 0xca, 0x0e, 0x06,
-	0x7f, 0x03, 0xe6, 0xa5, 0x83, 0x4a, 0x4e, 0x3a, 0x58, 0x00, 0x50, 0xf5, 0x20, 0x62, 0x5c, 0xfa,
-	0x49, 0xa2, 0xae, 0xc4, 0x24, 0xac, 0xd6, 0xa4, 0x4b, 0x7a, 0x38, 0xec, 0x52, 0xa5, 0x5d, 0x92,
-	0xc2, 0xdc, 0xb4, 0x11, 0x0b, 0xe7, 0x7a, 0x22, 0x9c, 0x5b, 0xbf, 0x94, 0x60, 0xee, 0x73, 0x5b,
-	0x2f, 0x80, 0x51, 0x12, 0x0f, 0x29, 0x83, 0x47, 0xd2, 0x83, 0xf2, 0x60, 0x0f, 0x46, 0x78, 0x1e,
-	0xe4, 0x7e, 0xa5, 0x6d, 0xa9, 0xe1, 0xb5, 0xcd, 0xb0, 0x86, 0xc6, 0x86, 0x28, 0x27, 0x87, 0x78,
-	0x5a, 0x82, 0x06, 0x23, 0x6f, 0xac, 0xe4, 0xe3, 0x02, 0x4c, 0xaa, 0xc9, 0xaf, 0x06, 0x6c, 0xa8,
-	0x94, 0xd4, 0xef, 0xa7, 0x11, 0xcb, 0xc2, 0x1a, 0x8d, 0x79, 0x56, 0xef, 0x42, 0xfb, 0x25, 0xa5,
-	0x89, 0x52, 0x8a, 0x72, 0xa2, 0x94, 0x22, 0x3d, 0x74, 0x2e, 0xaf, 0x0f, 0xa9, 0xe2, 0xe5, 0x29,
-	0x2d, 0xe8, 0x79, 0x66, 0xee, 0xa7, 0x87, 0x3e, 0x6a, 0xf7, 0xff, 0x29, 0xc1, 0xd4, 0x0d, 0x6c,
-	0x61, 0xc7, 0xd0, 0x14, 0xec, 0xda, 0xc4, 0x72, 0x31, 0xba, 0x0a, 0xa3, 0x0e, 0x76, 0x77, 0x4d,
-	0x8f, 0xaa, 0x18, 0x6b, 0x9f, 0x0b, 0x6c, 0x4d, 0xf5, 0x5b, 0x56, 0x68, 0xa7, 0x8d, 0x53, 0x4a,
-	0xd0, 0x1d, 0xbd, 0x0d, 0x15, 0xec, 0x38, 0xc4, 0xa1, 0xc3, 0x8c, 0xb5, 0xe7, 0x73, 0xde, 0x5b,
-	0xf7, 0xfb, 0x6c, 0x9c, 0x52, 0x58, 0xe7, 0x66, 0x0b, 0x46, 0x99, 0x26, 0xdf, 0xc7, 0x2e, 0x76,
-	0x5d, 0xf5, 0x4b, 0x1c, 0x18, 0x1f, 0x3e, 0x36, 0x3f, 0x80, 0x0a, 0x7d, 0xcb, 0x4f, 0x5a, 0x1a,
-	0xd1, 0xc3, 0x76, 0xfa, 0x3f, 0x9d, 0x94, 0xa4, 0x4c, 0x52, 0x5a, 0xad, 0x42, 0xc5, 0xc1, 0xb6,
-	0xb9, 0xd7, 0x7e, 0x54, 0x87, 0x89, 0x2d, 0x87, 0xf4, 0x0c, 0xd7, 0x9f, 0x1a, 0xa2, 0xed, 0xa0,
-	0x15, 0x18, 0x8f, 0xa7, 0x4b, 0x74, 0x26, 0xa7, 0x54, 0xae, 0x79, 0x9a, 0xef, 0x4d, 0xeb, 0x94,
-	0xaf, 0x22, 0x4e, 0xd2, 0x48, 0x45, 0xba, 0xd8, 0x4b, 0xac, 0x22, 0x5e, 0x53, 0x14, 0xa9, 0x48,
-	0x17, 0x1a, 0x09, 0x54, 0xdc, 0x0e, 0x4b, 0x32, 0x92, 0xe5, 0x23, 0xe8, 0xa5, 0x01, 0x65, 0x36,
-	0x62, 0x95, 0xbc, 0x8a, 0x94, 0x48, 0x65, 0x5e, 0xb9, 0x8a, 0x40, 0x65, 0x27, 0xac, 0x18, 0xed,
-	0x7f, 0xf8, 0x44, 0x67, 0x05, 0x55, 0x18, 0x62, 0x55, 0xe9, 0xfa, 0x82, 0x48, 0x15, 0xaf, 0xf0,
-	0x40, 0xa0, 0xea, 0x13, 0x98, 0xce, 0x7c, 0xf7, 0x40, 0xf3, 0xa2, 0x2f, 0x22, 0x62, 0x65, 0x99,
-	0xcb, 0xcb, 0x48, 0x19, 0xf7, 0x5a, 0x53, 0xac, 0x2c, 0x73, 0x55, 0x12, 0x29, 0xe3, 0x5e, 0xa2,
-	0x08, 0x94, 0x6d, 0x02, 0xca, 0x9e, 0xca, 0xd0, 0x39, 0xe1, 0x81, 0x4d, 0xa0, 0xee, 0x16, 0xcc,
-	0x70, 0x36, 0x67, 0x68, 0x41, 0xbc, 0x71, 0x2b, 0x32, 0x0d, 0xb1, 0xd5, 0x2e, 0x35, 0x0d, 0xa9,
-	0x75, 0x50, 0xac, 0x2c, 0xb3, 0xc6, 0x47, 0xca, 0xb8, 0xab, 0x7f, 0x91, 0x39, 0xe5, 0x29, 0xe3,
-	0xae, 0xd0, 0xf9, 0xca, 0xda, 0x3f, 0x94, 0x00, 0x58, 0x68, 0x86, 0x19, 0x28, 0xbe, 0x08, 0x46,
-	0xdc, 0x4f, 0xaf, 0x8c, 0x83, 0x32, 0x10, 0x47, 0x45, 0x7a, 0x75, 0xc9, 0x57, 0x71, 0x6f, 0x94,
-	0x36, 0xbc, 0xf5, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf2, 0xc0, 0xba, 0xdf, 0xe7, 0x2d, 0x00,
-	0x00,
+	// 2516 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0xcd, 0x8f, 0xe4, 0x46,
+	0x15, 0xdf, 0xb6, 0xfb, 0xf3, 0xcd, 0x57, 0x4f, 0xcd, 0xc7, 0x9a, 0xce, 0x64, 0x19, 0x3a, 0xc9,
+	0x6a, 0x92, 0x0d, 0x03, 0x19, 0x90, 0x96, 0x0f, 0x2d, 0x61, 0xbe, 0x76, 0xa6, 0xc5, 0x0e, 0x3b,
+	0xeb, 0x59, 0x40, 0x42, 0xe2, 0xe0, 0x6d, 0xd7, 0xee, 0x58, 0xeb, 0xee, 0x6a, 0x6c, 0x4f, 0x67,
+	0x87, 0x13, 0x82, 0x1c, 0x02, 0xc7, 0xfc, 0x07, 0x88, 0x03, 0x27, 0xce, 0x48, 0x88, 0x23, 0xca,
+	0x1d, 0x24, 0x24, 0x0e, 0x39, 0xa0, 0xdc, 0x90, 0x38, 0x71, 0xe2, 0x10, 0xa1, 0xc8, 0x55, 0xb6,
+	0xdb, 0x55, 0x2e, 0x57, 0x77, 0x6f, 0xcf, 0x57, 0x94, 0x39, 0x75, 0xd7, 0x73, 0xf9, 0xf9, 0xbd,
+	0xdf, 0xab, 0xf7, 0xea, 0xf9, 0xd5, 0x33, 0x80, 0x4d, 0xda, 0xcf, 0xd7, 0x7b, 0x1e, 0x09, 0x08,
+	0x2a, 0xd1, 0x9f, 0xe6, 0x87, 0x55, 0xa8, 0x6f, 0x7b, 0xd8, 0x0a, 0xf0, 0x8f, 0x89, 0x7b, 0xd2,
+	0xc1, 0x0f, 0x7b, 0x81, 0x8f, 0x66, 0x41, 0x73, 0x6c, 0xa3, 0xb0, 0x5a, 0x58, 0xab, 0x99, 0x9a,
+	0x63, 0x23, 0x04, 0xc5, 0xae, 0xd5, 0xc1, 0x86, 0x46, 0x29, 0xf4, 0x7f, 0x48, 0xf3, 0x9d, 0x5f,
+	0x60, 0x43, 0x5f, 0x2d, 0xac, 0xe9, 0x26, 0xfd, 0x8f, 0x56, 0x61, 0xca, 0xc6, 0x7e, 0xdb, 0x73,
+	0x7a, 0x81, 0x43, 0xba, 0x46, 0x91, 0x4e, 0x4f, 0x93, 0xd0, 0x2d, 0x00, 0xbf, 0x6b, 0xf5, 0xfc,
+	0x63, 0x12, 0xb4, 0x6c, 0xa3, 0x44, 0x27, 0xa4, 0x28, 0xe8, 0x2d, 0xa8, 0x5b, 0x7d, 0xcb, 0x71,
+	0xad, 0x27, 0x8e, 0xeb, 0x04, 0xa7, 0x3f, 0x25, 0x5d, 0x6c, 0x94, 0xe9, 0xac, 0x0c, 0x1d, 0xad,
+	0x40, 0xad, 0xe7, 0x91, 0xa7, 0x8e, 0x8b, 0x5b, 0xb6, 0x51, 0xa1, 0x93, 0x06, 0x04, 0xb4, 0x0c,
+	0xe5, 0x1e, 0x21, 0x6e, 0xcb, 0x36, 0xaa, 0xf4, 0x52, 0x34, 0x42, 0x0d, 0xa8, 0x86, 0xff, 0x7e,
+	0x18, 0xea, 0x53, 0xa3, 0x57, 0x92, 0x31, 0xda, 0x84, 0x6a, 0x07, 0x07, 0x96, 0x6d, 0x05, 0x96,
+	0x01, 0xab, 0xfa, 0xda, 0xd4, 0xc6, 0x1b, 0x0c, 0xad, 0x75, 0x11, 0xa2, 0xf5, 0x83, 0x68, 0xde,
+	0x6e, 0x37, 0xf0, 0x4e, 0xcd, 0xe4, 0xb6, 0x50, 0x41, 0xdb, 0x73, 0xfa, 0xd8, 0xa3, 0x0f, 0x98,
+	0x62, 0x0a, 0x0e, 0x28, 0xc8, 0x80, 0x4a, 0x9b, 0x74, 0x03, 0xfc, 0x22, 0x30, 0xa6, 0xe9, 0xc5,
+	0x78, 0x88, 0x8e, 0x61, 0xc9, 0xc3, 0x3d, 0xd7, 0x69, 0x5b, 0x21, 0x52, 0x3b, 0xf4, 0x96, 0x9d,
+	0x50, 0x92, 0x19, 0x2a, 0xc9, 0x46, 0x9e, 0x24, 0xa6, 0xec, 0x26, 0x26, 0x96, 0x9c, 0x21, 0x7a,
+	0x1d, 0x66, 0x52, 0x17, 0x5a, 0xb6, 0x31, 0x4b, 0x25, 0xe1, 0x89, 0xa8, 0x09, 0xd3, 0xb1, 0x61,
+	0x8e, 0x42, 0x43, 0xcf, 0x51, 0x43, 0x73, 0x34, 0xf4, 0x36, 0xcc, 0xc7, 0xe3, 0xfb, 0x1e, 0xe9,
+	0x6c, 0xbb, 0xe4, 0xc4, 0x36, 0xea, 0xab, 0x85, 0xb5, 0xaa, 0x99, 0xbd, 0x80, 0x6e, 0xc3, 0xac,
+	0x4f, 0x4e, 0xbc, 0x76, 0x24, 0x7d, 0xcb, 0x36, 0xe6, 0xe9, 0x83, 0x05, 0x6a, 0xb8, 0x08, 0xd2,
+	0x14, 0xfa, 0x74, 0x44, 0x9f, 0x9e, 0xa1, 0x23, 0x0c, 0x8b, 0x69, 0x5a, 0x6c, 0x16, 0x63, 0x81,
+	0x82, 0xf6, 0x4e, 0x1e, 0x68, 0x47, 0x92, 0x7b, 0x18, 0x66, 0x52, 0x76, 0x68, 0x05, 0xf4, 0x9f,
+	0x13, 0xdf, 0x58, 0x5c, 0x2d, 0xac, 0x4d, 0x6d, 0x40, 0xc4, 0xf5, 0x11, 0xf1, 0xcd, 0x90, 0xdc,
+	0xf8, 0x2e, 0xcc, 0x70, 0x4c, 0x50, 0x1d, 0xf4, 0xe7, 0xf8, 0x34, 0xf2, 0xa0, 0xf0, 0x2f, 0x5a,
+	0x84, 0x52, 0xdf, 0x72, 0x4f, 0x62, 0x1f, 0x62, 0x83, 0xef, 0x68, 0xdf, 0x2a, 0x34, 0xf6, 0xa1,
+	0x91, 0x6f, 0xc2, 0xb1, 0x38, 0xed, 0xc1, 0x97, 0x72, 0xf5, 0x1a, 0x87, 0x51, 0xf3, 0xe3, 0x02,
+	0xd4, 0x77, 0xb0, 0x8b, 0x95, 0x41, 0x21, 0xed, 0x2c, 0x1a, 0xe7, 0x2c, 0xe2, 0xad, 0x23, 0x3a,
+	0x8b, 0xae, 0x72, 0x96, 0x22, 0xe7, 0x2c, 0x13, 0x21, 0xde, 0xfc, 0xab, 0x0e, 0xf5, 0xdd, 0x17,
+	0x01, 0xee, 0xda, 0xd7, 0x31, 0x4f, 0x11, 0xf3, 0x44, 0x88, 0xce, 0x3e, 0xe6, 0x4d, 0x66, 0xc6,
+	0x7f, 0xea, 0xb0, 0x68, 0xe2, 0x3e, 0xf6, 0x82, 0xc7, 0xe4, 0x28, 0x82, 0x53, 0x6a, 0x4a, 0xde,
+	0x00, 0x5a, 0xc6, 0x00, 0x32, 0xb3, 0xa6, 0x21, 0x2b, 0x0a, 0x90, 0xed, 0xa6, 0x20, 0x2b, 0x51,
+	0xc8, 0xde, 0x8c, 0x20, 0x93, 0x89, 0x93, 0x0b, 0xdb, 0xcf, 0xa0, 0x1e, 0x0b, 0x11, 0x4f, 0x31,
+	0xca, 0x5c, 0xd8, 0x92, 0xb2, 0x3b, 0x12, 0xee, 0x61, 0x6c, 0x33, 0xac, 0x04, 0xab, 0x54, 0x54,
+	0x56, 0xa9, 0x9e, 0x9d, 0x55, 0x1a, 0xdb, 0xb0, 0x24, 0x95, 0x70, 0x2c, 0xd3, 0xfe, 0x4f, 0x83,
+	0xf9, 0x03, 0xe7, 0x99, 0x37, 0x34, 0x2d, 0xa1, 0x76, 0xd3, 0x72, 0xec, 0xa6, 0x0b, 0x76, 0x6b,
+	0xc2, 0x74, 0x60, 0x79, 0xcf, 0x70, 0x70, 0xc8, 0x9c, 0x84, 0xd9, 0x95, 0xa3, 0x85, 0x7b, 0xd4,
+	0x60, 0x4c, 0xb9, 0x30, 0x87, 0x15, 0xa8, 0x68, 0x2b, 0xb5, 0x06, 0x98, 0xd1, 0x6e, 0x47, 0x46,
+	0xcb, 0xc8, 0x3d, 0xa2, 0xdf, 0x8c, 0x61, 0xa1, 0x78, 0x3b, 0xaa, 0x9d, 0xfd, 0x76, 0xd4, 0xfc,
+	0x44, 0x83, 0xba, 0x89, 0x83, 0xd3, 0xde, 0x59, 0x22, 0xcf, 0x85, 0xad, 0xa2, 0x18, 0xb6, 0x0c,
+	0xa8, 0x44, 0x83, 0x08, 0xec, 0x78, 0x88, 0x36, 0x33, 0x28, 0xbf, 0x91, 0xb8, 0x06, 0x2f, 0xe2,
+	0xe7, 0x0b, 0xe4, 0xbf, 0x6b, 0x60, 0xa4, 0x73, 0x12, 0x65, 0xf8, 0x3a, 0xbb, 0x9d, 0xa8, 0x01,
+	0xd5, 0x7e, 0x9c, 0x7a, 0x31, 0xa4, 0x93, 0x31, 0x6a, 0x65, 0xa0, 0xfe, 0xaa, 0x24, 0x79, 0x1a,
+	0x29, 0xb0, 0x5d, 0x4e, 0xe4, 0x69, 0x7e, 0xa0, 0x81, 0x91, 0x4e, 0x3d, 0x94, 0xa0, 0xa6, 0xa1,
+	0xd0, 0x14, 0x50, 0xe8, 0x1c, 0x14, 0x79, 0xec, 0x47, 0x84, 0xa2, 0xa8, 0x82, 0xa2, 0x74, 0x96,
+	0x5b, 0x63, 0x01, 0x66, 0x0f, 0x4f, 0x5c, 0x57, 0xe1, 0xc2, 0xef, 0x66, 0xd2, 0xb7, 0xd7, 0x22,
+	0x25, 0xf9, 0x1b, 0xaf, 0x5a, 0xf2, 0xf6, 0xbe, 0x06, 0xcb, 0x03, 0x09, 0x5f, 0xda, 0xc6, 0x7b,
+	0x19, 0x1b, 0xdf, 0xc9, 0xa8, 0x7f, 0x95, 0x2d, 0xfc, 0x27, 0x1d, 0x16, 0x99, 0x63, 0x6e, 0x06,
+	0x81, 0xd5, 0x3e, 0xee, 0xe0, 0xee, 0xf8, 0x20, 0xbc, 0x0e, 0x33, 0x36, 0x79, 0x40, 0xda, 0x96,
+	0xcb, 0x98, 0x50, 0x2b, 0x56, 0x4d, 0x9e, 0x18, 0x06, 0xef, 0xce, 0x89, 0x1b, 0x38, 0x87, 0x56,
+	0x70, 0x4c, 0x15, 0xac, 0x9a, 0x03, 0x02, 0xba, 0x03, 0xd5, 0x63, 0xe2, 0x07, 0xad, 0xee, 0x53,
+	0x42, 0x15, 0x9c, 0xda, 0x98, 0x8b, 0x80, 0xdc, 0x8f, 0xc8, 0x66, 0x32, 0x01, 0xed, 0x66, 0x82,
+	0xcc, 0x9b, 0x5c, 0x90, 0xe1, 0x75, 0x39, 0x87, 0x98, 0x7e, 0x1b, 0x66, 0x37, 0xdb, 0x6d, 0xec,
+	0xfb, 0x87, 0xe1, 0x53, 0xdb, 0xc4, 0x8d, 0xf2, 0x61, 0x81, 0x1a, 0xc7, 0x7e, 0x38, 0x87, 0xd8,
+	0xff, 0x0f, 0x0d, 0x16, 0x59, 0x1c, 0x99, 0xc0, 0x72, 0x69, 0xd4, 0xf5, 0x71, 0x50, 0x2f, 0x72,
+	0xa8, 0xcb, 0xe4, 0x18, 0x11, 0xf5, 0x92, 0x0a, 0xf5, 0xf2, 0x30, 0xd4, 0x2b, 0x32, 0xd4, 0x27,
+	0xc3, 0xf5, 0x8f, 0x3a, 0xac, 0xb0, 0x55, 0x14, 0xfb, 0xed, 0x10, 0x7c, 0x87, 0xbd, 0x16, 0x5c,
+	0xb8, 0x77, 0x1c, 0x64, 0xbc, 0x83, 0xaf, 0x5f, 0xc8, 0xf5, 0xba, 0x3c, 0x2f, 0x99, 0xcc, 0x5e,
+	0xff, 0xd6, 0x60, 0x85, 0xad, 0xbf, 0x33, 0xb2, 0xd7, 0x58, 0x3e, 0x71, 0x90, 0xf1, 0x89, 0x77,
+	0x38, 0x9f, 0x98, 0x08, 0xeb, 0x2b, 0xe7, 0x1b, 0xbf, 0x2c, 0x40, 0x35, 0x06, 0x81, 0x26, 0xea,
+	0xae, 0x15, 0x3c, 0x25, 0x5e, 0x27, 0xba, 0x3b, 0x19, 0x87, 0x15, 0x04, 0xe2, 0x3f, 0x3e, 0xed,
+	0xc5, 0x3c, 0xa2, 0x51, 0x98, 0x6f, 0x86, 0xd0, 0x45, 0xdb, 0x3c, 0xfd, 0x4f, 0xed, 0xd3, 0x8b,
+	0x76, 0x3c, 0xcd, 0xe9, 0x85, 0x9e, 0xe0, 0x74, 0x9d, 0xc0, 0xb1, 0x02, 0xe2, 0x45, 0x10, 0x0c,
+	0x08, 0xcd, 0xbb, 0xa0, 0x3f, 0x22, 0x7e, 0x08, 0x44, 0xc7, 0x7a, 0xd1, 0x7a, 0x78, 0x78, 0x44,
+	0x9f, 0xad, 0x9b, 0xf1, 0x30, 0x7c, 0x74, 0xc7, 0x7a, 0xb1, 0xf5, 0x93, 0xa3, 0xe8, 0xad, 0x22,
+	0x1a, 0x35, 0xfb, 0x00, 0x6c, 0x3b, 0xa6, 0xb5, 0xcb, 0xaf, 0x41, 0x91, 0xda, 0xac, 0x40, 0x6d,
+	0xf6, 0x4a, 0x64, 0xb3, 0xc1, 0x84, 0xf5, 0x41, 0xf5, 0x93, 0x4e, 0x6c, 0xdc, 0x85, 0xda, 0x4b,
+	0x55, 0xd3, 0x9a, 0xbf, 0xaf, 0xc1, 0x12, 0xf3, 0xbb, 0x54, 0x79, 0x6e, 0xe4, 0x04, 0x5d, 0x48,
+	0xc6, 0xf5, 0x6c, 0x32, 0xbe, 0x06, 0x73, 0x3d, 0xcf, 0xe9, 0x58, 0xde, 0x69, 0x52, 0x0e, 0x65,
+	0x58, 0x8a, 0x64, 0x5a, 0x65, 0xc5, 0x6d, 0xd2, 0xb5, 0xd3, 0x73, 0x19, 0xc0, 0xd9, 0x0b, 0x97,
+	0x5c, 0x4e, 0xfa, 0x55, 0x01, 0x56, 0x22, 0xf9, 0xa5, 0x55, 0x4d, 0x63, 0x8a, 0x1a, 0xee, 0x7b,
+	0x5c, 0x60, 0x13, 0x00, 0x5e, 0x3f, 0x54, 0x30, 0x60, 0xb6, 0x55, 0x3e, 0x03, 0x7d, 0x50, 0x80,
+	0x5b, 0x09, 0x30, 0x72, 0x31, 0xa6, 0xa9, 0x18, 0xdf, 0x57, 0x8a, 0x71, 0xa4, 0x64, 0xc1, 0x04,
+	0x19, 0xf2, 0x9c, 0x10, 0xc3, 0xf0, 0xd0, 0xa5, 0x65, 0x1b, 0x33, 0x0c, 0x43, 0x36, 0x12, 0x02,
+	0xc6, 0xac, 0x2a, 0x60, 0xcc, 0x89, 0xaf, 0xa5, 0x35, 0xc7, 0x8f, 0x10, 0x8a, 0x6a, 0xed, 0x03,
+	0x02, 0xba, 0x9f, 0x8a, 0x6b, 0xf3, 0x54, 0xc7, 0xb7, 0x94, 0x3a, 0xe6, 0x05, 0xb4, 0x6f, 0xc3,
+	0x6c, 0x3f, 0x71, 0xaa, 0x07, 0x8e, 0x1f, 0x18, 0x88, 0x72, 0x9b, 0xcf, 0x78, 0x9c, 0x29, 0x4c,
+	0x0c, 0x17, 0x76, 0xea, 0x24, 0xe1, 0x80, 0xd8, 0xd8, 0x58, 0x60, 0x0b, 0x5b, 0x20, 0x87, 0x0b,
+	0x3b, 0x25, 0xcf, 0x21, 0xf6, 0x1c, 0x62, 0xd3, 0x1a, 0xbb, 0x6e, 0x66, 0x2f, 0xa0, 0x0d, 0x58,
+	0x4c, 0x11, 0xb7, 0xac, 0xae, 0xfd, 0x9e, 0x63, 0x07, 0xc7, 0xc6, 0x12, 0xbd, 0x41, 0x7a, 0xad,
+	0xf1, 0x10, 0xbe, 0x32, 0x74, 0x31, 0x8d, 0x55, 0xde, 0x7a, 0x04, 0xaf, 0x8d, 0xb0, 0x2c, 0xc6,
+	0x62, 0x39, 0x51, 0x64, 0xff, 0xb8, 0x02, 0x4b, 0x6c, 0xc7, 0xba, 0x8e, 0x52, 0xe7, 0x16, 0xa5,
+	0xa4, 0x00, 0x5f, 0x7c, 0x94, 0x92, 0x8b, 0x71, 0x35, 0xa3, 0x54, 0x3a, 0x0e, 0xd5, 0xb9, 0x38,
+	0x24, 0xd7, 0x22, 0x2f, 0x0e, 0x71, 0xd1, 0x6e, 0x5e, 0x88, 0x76, 0x5f, 0x0c, 0xf7, 0xde, 0xed,
+	0x5a, 0x4f, 0xdc, 0x6b, 0xf7, 0x3e, 0x3f, 0xf7, 0x96, 0x02, 0x7c, 0xf1, 0xee, 0x2d, 0x17, 0xe3,
+	0xf3, 0xe6, 0xde, 0x72, 0x2d, 0xae, 0xdd, 0x5b, 0xea, 0xde, 0xff, 0xaa, 0xc0, 0xf2, 0x8e, 0xe3,
+	0x5f, 0xfb, 0xf7, 0x78, 0xfe, 0xfd, 0xeb, 0xd1, 0xfc, 0xfb, 0xdd, 0x78, 0xc7, 0x71, 0xfc, 0xf3,
+	0x70, 0xf0, 0xdf, 0x8c, 0xea, 0xe0, 0x9b, 0x6a, 0x39, 0xae, 0xa6, 0x87, 0xef, 0x65, 0x3c, 0xfc,
+	0x8e, 0x5a, 0x8d, 0x6b, 0x17, 0x97, 0xba, 0xf8, 0x47, 0x55, 0xb8, 0x79, 0xdf, 0x72, 0x5c, 0xd2,
+	0xc7, 0xde, 0xb5, 0x8f, 0x8f, 0xee, 0xe3, 0xef, 0x8f, 0xe6, 0xe3, 0xf1, 0xe6, 0x99, 0x03, 0xf1,
+	0xc4, 0x4e, 0xfe, 0xdb, 0x51, 0x9d, 0x7c, 0x6b, 0x88, 0x20, 0x57, 0xd3, 0xcb, 0xbf, 0x0e, 0x0b,
+	0x96, 0xeb, 0x92, 0xf7, 0x58, 0x99, 0x13, 0x47, 0xdd, 0x3e, 0x51, 0x59, 0x41, 0x76, 0x09, 0xad,
+	0x03, 0x4a, 0xa4, 0xdc, 0xb2, 0xda, 0xcf, 0x71, 0xd7, 0x4e, 0x1a, 0xf9, 0x24, 0x57, 0xd0, 0x7e,
+	0x2a, 0x8e, 0xb0, 0x12, 0xc2, 0xdb, 0x43, 0x90, 0x1a, 0x29, 0x90, 0x2c, 0x7c, 0xe1, 0x02, 0xc9,
+	0xef, 0xb4, 0xb8, 0x1e, 0xc9, 0x2c, 0xb1, 0xe7, 0x91, 0x93, 0xde, 0xc8, 0x61, 0x64, 0xd8, 0x69,
+	0xed, 0xf0, 0xe6, 0x01, 0x59, 0x38, 0x28, 0xe5, 0x84, 0x83, 0x5b, 0x00, 0x96, 0x1d, 0xad, 0x18,
+	0x9f, 0x9e, 0x65, 0xd4, 0xcc, 0x14, 0x85, 0x75, 0xa0, 0x76, 0x48, 0x1f, 0xc7, 0x53, 0x2a, 0x74,
+	0x0a, 0x4f, 0xcc, 0x0d, 0x1b, 0xa9, 0xe5, 0x5c, 0xe3, 0x96, 0x73, 0xf3, 0x0f, 0x05, 0x58, 0xfa,
+	0x51, 0xcf, 0x1e, 0x01, 0x23, 0x1e, 0x0f, 0x2d, 0x83, 0x07, 0xaf, 0x81, 0x3e, 0x5c, 0x83, 0xa2,
+	0x4c, 0x83, 0xdc, 0xc3, 0xdf, 0xa6, 0x15, 0x97, 0x6d, 0x26, 0x15, 0x34, 0xf5, 0x08, 0x9d, 0x7f,
+	0xc4, 0x7f, 0x0b, 0x70, 0x93, 0x2d, 0x18, 0xca, 0x7d, 0xec, 0x1e, 0x93, 0xe1, 0x3b, 0x8f, 0x01,
+	0x95, 0x67, 0x21, 0xeb, 0x64, 0xc7, 0x89, 0x87, 0xe8, 0x1e, 0xd4, 0xe2, 0x93, 0x19, 0x3f, 0xea,
+	0x91, 0xfb, 0xf2, 0x90, 0x76, 0x12, 0x73, 0x70, 0x87, 0xa0, 0x74, 0x59, 0xa5, 0x74, 0x85, 0x57,
+	0xfa, 0x2f, 0x05, 0xb8, 0xc9, 0x80, 0x1d, 0xae, 0x74, 0x4a, 0x7c, 0x4d, 0x21, 0xbe, 0xce, 0x89,
+	0x9f, 0xd7, 0x02, 0x92, 0x2f, 0xfe, 0x18, 0x3d, 0x01, 0xcd, 0x8f, 0x74, 0x58, 0x60, 0x00, 0xdd,
+	0x77, 0x5c, 0x7c, 0x74, 0x6c, 0x79, 0xf8, 0x0c, 0xed, 0x15, 0x77, 0x0d, 0x15, 0x53, 0x5d, 0x43,
+	0xe3, 0xb8, 0x35, 0xb7, 0xcb, 0x97, 0xf3, 0x77, 0xf9, 0x4a, 0xee, 0x2e, 0x5f, 0x15, 0x76, 0xf9,
+	0xf0, 0x1a, 0x7f, 0xc6, 0x98, 0x8c, 0xd1, 0x4e, 0xa6, 0x33, 0x75, 0x8d, 0x5b, 0x42, 0x1c, 0x42,
+	0x57, 0xad, 0x39, 0xf5, 0x93, 0x02, 0x2c, 0xb0, 0xa5, 0xa2, 0x36, 0xe4, 0x4e, 0xa6, 0x0d, 0x67,
+	0x8d, 0x5b, 0x68, 0x2f, 0xa3, 0xe4, 0x85, 0xf5, 0xe2, 0x7c, 0xa8, 0xc1, 0x02, 0xeb, 0x12, 0x1e,
+	0xba, 0x5a, 0xc7, 0x6a, 0x17, 0xdc, 0xc9, 0x1c, 0xce, 0xae, 0x71, 0x3d, 0xc9, 0x2f, 0x03, 0xca,
+	0x18, 0x67, 0xb2, 0x13, 0x96, 0xec, 0x34, 0x58, 0x16, 0x16, 0xe8, 0x66, 0xdb, 0x95, 0xe2, 0xb2,
+	0x0a, 0x53, 0x4f, 0xe3, 0x39, 0x49, 0x10, 0x4a, 0x93, 0x42, 0xe4, 0x82, 0xd3, 0x5e, 0x8c, 0x10,
+	0xfd, 0x1f, 0x22, 0x67, 0xd1, 0xb3, 0xe1, 0xc7, 0x24, 0x6e, 0x4d, 0x8e, 0xc7, 0x21, 0x47, 0xf6,
+	0xff, 0x01, 0xee, 0x63, 0x37, 0x52, 0x3a, 0x4d, 0xe2, 0xde, 0xeb, 0xca, 0xdc, 0x7b, 0x9d, 0x5c,
+	0xe8, 0xab, 0xd6, 0xe5, 0xf7, 0x67, 0x0d, 0x96, 0x05, 0xd7, 0xb8, 0x38, 0x78, 0xf7, 0x32, 0x9d,
+	0xdf, 0x77, 0xe4, 0xde, 0x3a, 0x1e, 0x78, 0x63, 0x6c, 0x70, 0x93, 0x81, 0xf7, 0x69, 0x01, 0xea,
+	0x2c, 0x9f, 0x4f, 0x75, 0x06, 0xde, 0x86, 0x59, 0x8b, 0xef, 0x40, 0x60, 0xbc, 0x04, 0x6a, 0x38,
+	0xaf, 0x4d, 0xba, 0x5d, 0xdc, 0xa6, 0x69, 0x30, 0x0b, 0x58, 0x74, 0x1e, 0x4f, 0xe5, 0x9a, 0x76,
+	0x75, 0xae, 0x69, 0x57, 0x7c, 0x74, 0x2e, 0x3c, 0xe7, 0x14, 0xaf, 0x3e, 0xa5, 0xdf, 0xb5, 0x5c,
+	0x9a, 0xfa, 0x3b, 0xf8, 0x72, 0xd5, 0x0f, 0x60, 0x76, 0x9b, 0xf4, 0x4e, 0x53, 0xba, 0x1b, 0x50,
+	0xf1, 0xbd, 0x36, 0x6d, 0x5d, 0x62, 0x1c, 0xe2, 0x61, 0xe8, 0x05, 0x36, 0xf6, 0x03, 0x7a, 0x89,
+	0x31, 0x4a, 0xc6, 0xd2, 0xe6, 0xe3, 0x5c, 0x91, 0x9b, 0xff, 0x29, 0xc0, 0xdc, 0x1e, 0xee, 0x62,
+	0xcf, 0x69, 0x9b, 0xd8, 0xef, 0x91, 0xae, 0x8f, 0xd1, 0x5d, 0x28, 0x7b, 0xd8, 0x3f, 0x71, 0x03,
+	0xfa, 0xd8, 0xa9, 0x8d, 0x57, 0x23, 0x84, 0x84, 0x79, 0xeb, 0x26, 0x9d, 0xb4, 0x7f, 0xc3, 0x8c,
+	0xa6, 0xa3, 0x6f, 0x42, 0x09, 0x7b, 0x1e, 0xf1, 0xa8, 0x4c, 0x53, 0x1b, 0x2b, 0x39, 0xf7, 0xed,
+	0x86, 0x73, 0xf6, 0x6f, 0x98, 0x6c, 0x72, 0xa3, 0x09, 0x65, 0xc6, 0x29, 0x14, 0xb3, 0x83, 0x7d,
+	0xdf, 0x7a, 0x86, 0x63, 0x85, 0xa3, 0x61, 0xe3, 0x1e, 0x94, 0xe8, 0x5d, 0xa1, 0x76, 0x6d, 0x62,
+	0xc7, 0xd7, 0xe9, 0x7f, 0x31, 0xb5, 0xd2, 0x32, 0xa9, 0xd5, 0x56, 0x05, 0x4a, 0xe1, 0xe9, 0xf6,
+	0xe9, 0xc6, 0xff, 0xe7, 0x60, 0xe6, 0xd0, 0x23, 0x7d, 0xc7, 0x0f, 0x17, 0x04, 0x69, 0x3f, 0x47,
+	0x9b, 0x30, 0x9d, 0xce, 0x79, 0xd1, 0xcd, 0x9c, 0x8f, 0xd2, 0x1a, 0xcb, 0x72, 0x6d, 0x9a, 0x37,
+	0x42, 0x16, 0xe9, 0xbc, 0x33, 0x61, 0x21, 0x7e, 0x69, 0xa5, 0x66, 0x91, 0xfe, 0xa0, 0x27, 0x61,
+	0x21, 0x7e, 0xe5, 0xa3, 0x60, 0xd1, 0x82, 0xba, 0xf8, 0x45, 0x0a, 0x7a, 0x45, 0xf1, 0xa9, 0x8a,
+	0x82, 0xd5, 0x36, 0xcc, 0x70, 0xdf, 0x49, 0x20, 0x23, 0xef, 0xeb, 0x09, 0xb5, 0x4a, 0xe9, 0xcf,
+	0x00, 0x12, 0x95, 0xc4, 0x6f, 0x03, 0x14, 0x2c, 0x1e, 0xc5, 0x5d, 0xb4, 0x7c, 0x42, 0x8f, 0x86,
+	0xbd, 0xac, 0xa8, 0x59, 0xca, 0xde, 0x11, 0xd0, 0xb0, 0x17, 0x08, 0x05, 0xcb, 0x7b, 0x00, 0x83,
+	0xae, 0x64, 0xb4, 0x24, 0xed, 0xd3, 0x56, 0xdc, 0x7e, 0x00, 0x28, 0xdb, 0xd4, 0x8c, 0x5e, 0x55,
+	0xf6, 0x3b, 0xab, 0x97, 0x81, 0xd8, 0xad, 0x9b, 0x2c, 0x03, 0x59, 0x1b, 0xaf, 0x9a, 0x95, 0xd8,
+	0x82, 0x9a, 0xb0, 0x92, 0xf5, 0xa6, 0x2a, 0x58, 0xfd, 0x00, 0xe6, 0x33, 0x1d, 0x2e, 0x68, 0x45,
+	0xd5, 0xfb, 0xa2, 0x66, 0x96, 0x39, 0xa6, 0x4e, 0x98, 0x49, 0x0f, 0xb0, 0xd5, 0xcc, 0x32, 0x87,
+	0x62, 0x09, 0x33, 0xe9, 0x71, 0x99, 0xda, 0x96, 0xd9, 0xfa, 0x7b, 0x62, 0x4b, 0x79, 0x69, 0x5e,
+	0xc1, 0xee, 0x21, 0x2c, 0x48, 0xca, 0x70, 0xe8, 0x96, 0xba, 0x44, 0x37, 0x8a, 0x19, 0x52, 0x75,
+	0x0d, 0xc1, 0x0c, 0x42, 0xc5, 0x43, 0xcd, 0x2c, 0x53, 0xcd, 0x49, 0x98, 0x49, 0xeb, 0x3c, 0xa3,
+	0xd8, 0x54, 0xc6, 0x4c, 0x5a, 0x8b, 0x51, 0xe3, 0x26, 0x29, 0xad, 0x24, 0xb8, 0xe5, 0x94, 0x5d,
+	0xd4, 0x0c, 0x25, 0x65, 0x8b, 0x84, 0x61, 0x4e, 0x49, 0x43, 0xc1, 0x70, 0x0f, 0xe6, 0x84, 0x84,
+	0x1e, 0x35, 0xf2, 0x5f, 0x9f, 0xd5, 0x8c, 0x84, 0xe4, 0x36, 0x61, 0x24, 0x79, 0x45, 0x55, 0x33,
+	0x12, 0x5e, 0xdf, 0x12, 0x46, 0x92, 0xd7, 0x3a, 0xb5, 0x0f, 0x64, 0xdf, 0x55, 0x12, 0x1f, 0x90,
+	0xbf, 0xc6, 0x0c, 0x71, 0xa9, 0x4c, 0xf6, 0x3e, 0x70, 0x29, 0x69, 0x62, 0x9f, 0xcf, 0x6e, 0xe3,
+	0x6f, 0x05, 0x00, 0x16, 0xb5, 0xe2, 0xdd, 0x3f, 0x9d, 0xf6, 0x26, 0x9b, 0x94, 0x98, 0x0b, 0x0f,
+	0xdb, 0xfd, 0x25, 0x2c, 0x76, 0xf0, 0xc8, 0x2c, 0xee, 0x01, 0x0c, 0x52, 0xbf, 0x64, 0x07, 0xe1,
+	0xb3, 0xc1, 0xfc, 0xdb, 0x9f, 0x94, 0xe9, 0x85, 0x6f, 0x7c, 0x36, 0x00, 0x16, 0x7c, 0x4b, 0x2d,
+	0x41, 0x42, 0x00, 0x00,
 }
@@ -48,6 +48,7 @@ import (
 	log "github.com/golang/glog"
 	"github.com/opensds/opensds/pkg/dock"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
@@ -138,6 +139,61 @@ func (ds *dockServer) ExtendVolume(ctx context.Context, opt *pb.ExtendVolumeOpts
 	return &res, nil
 }
 
+// RevertToSnapshot implements pb.DockServer.RevertToSnapshot
+func (ds *dockServer) RevertToSnapshot(ctx context.Context, opt *pb.RevertToSnapshotOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive revert volume to snapshot request, vr =", opt)
+
+	if err := dock.Brain.RevertToSnapshot(opt); err != nil {
+		log.Error("When revert volume to snapshot in dock module:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult("")
+	return &res, nil
+}
+
+// MigrateVolume implements pb.DockServer.MigrateVolume
+func (ds *dockServer) MigrateVolume(ctx context.Context, opt *pb.MigrateVolumeOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive migrate volume request, vr =", opt)
+
+	vol, err := dock.Brain.MigrateVolume(opt)
+	if err != nil {
+		log.Error("When migrate volume in dock module:", err)
+
+		res.Reply = GenericResponseError(migrateErrorCode(err), fmt.Sprint(err))
+		// The error is carried by the response instead, so that the driver
+		// which doesn't support migration can be told apart from the others.
+		return &res, nil
+	}
+
+	res.Reply = GenericResponseResult(vol)
+	return &res, nil
+}
+
+// RetypeVolume implements pb.DockServer.RetypeVolume
+func (ds *dockServer) RetypeVolume(ctx context.Context, opt *pb.RetypeVolumeOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive retype volume request, vr =", opt)
+
+	vol, err := dock.Brain.RetypeVolume(opt)
+	if err != nil {
+		log.Error("When retype volume in dock module:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult(vol)
+	return &res, nil
+}
+
 // CreateAttachment implements pb.DockServer.CreateAttachment
 func (ds *dockServer) CreateAttachment(ctx context.Context, opt *pb.CreateAttachmentOpts) (*pb.GenericResponse, error) {
 	var res pb.GenericResponse
@@ -207,6 +263,60 @@ func (ds *dockServer) DeleteVolumeSnapshot(ctx context.Context, opt *pb.DeleteVo
 	return &res, nil
 }
 
+// PullVolume implements pb.DockServer.PullVolume
+func (ds *dockServer) PullVolume(ctx context.Context, opt *pb.PullVolumeOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive pull volume request, vr =", opt)
+
+	vol, err := dock.Brain.PullVolume(opt)
+	if err != nil {
+		log.Error("Error occurred in dock module when pull volume:", err)
+
+		res.Reply = GenericResponseError(pullErrorCode(err), fmt.Sprint(err))
+		// The error is carried by the response instead, so that the missing
+		// volume can be told apart from the failures of transport.
+		return &res, nil
+	}
+
+	res.Reply = GenericResponseResult(vol)
+	return &res, nil
+}
+
+// PullVolumeSnapshot implements pb.DockServer.PullVolumeSnapshot
+func (ds *dockServer) PullVolumeSnapshot(ctx context.Context, opt *pb.PullVolumeSnapshotOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive pull volume snapshot request, vr =", opt)
+
+	snp, err := dock.Brain.PullSnapshot(opt)
+	if err != nil {
+		log.Error("Error occurred in dock module when pull snapshot:", err)
+
+		res.Reply = GenericResponseError(pullErrorCode(err), fmt.Sprint(err))
+		return &res, nil
+	}
+
+	res.Reply = GenericResponseResult(snp)
+	return &res, nil
+}
+
+// migrateErrorCode returns 501 if the driver doesn't support migration.
+func migrateErrorCode(err error) string {
+	if _, ok := err.(*model.NotImplementError); ok {
+		return "501"
+	}
+	return "400"
+}
+
+// pullErrorCode returns 404 if the resource is missing in the backend.
+func pullErrorCode(err error) string {
+	if _, ok := err.(*model.NotFoundError); ok {
+		return "404"
+	}
+	return "400"
+}
+
 // AttachVolume implements pb.DockServer.AttachVolume
 func (ds *dockServer) AttachVolume(ctx context.Context, opt *pb.AttachVolumeOpts) (*pb.GenericResponse, error) {
 	var res pb.GenericResponse
@@ -242,6 +352,23 @@ func (ds *dockServer) DetachVolume(ctx context.Context, opt *pb.DetachVolumeOpts
 	return &res, nil
 }
 
+// CopyVolume implements pb.DockServer.CopyVolume
+func (ds *dockServer) CopyVolume(ctx context.Context, opt *pb.CopyVolumeOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive copy volume request, vr =", opt)
+
+	if err := dock.Brain.CopyVolume(opt); err != nil {
+		log.Error("Error occurred in dock module when copy volume:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult("")
+	return &res, nil
+}
+
 // CreateReplication implements opensds.DockServer
 func (ds *dockServer) CreateReplication(ctx context.Context, opt *pb.CreateReplicationOpts) (*pb.GenericResponse, error) {
 	var res pb.GenericResponse
@@ -373,6 +500,41 @@ func (ds *dockServer) DeleteVolumeGroup(ctx context.Context, opt *pb.DeleteVolum
 	return &res, nil
 }
 
+// CreateGroupSnapshot implements pb.DockServer.CreateGroupSnapshot
+func (ds *dockServer) CreateGroupSnapshot(ctx context.Context, opt *pb.CreateGroupSnapshotOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive create group snapshot request, vr =", opt)
+
+	snps, err := dock.Brain.CreateGroupSnapshot(opt)
+	if err != nil {
+		log.Error("Error occurred in dock module when create group snapshot:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult(snps)
+	return &res, nil
+}
+
+// DeleteGroupSnapshot implements pb.DockServer.DeleteGroupSnapshot
+func (ds *dockServer) DeleteGroupSnapshot(ctx context.Context, opt *pb.DeleteGroupSnapshotOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive delete group snapshot request, vr =", opt)
+
+	if err := dock.Brain.DeleteGroupSnapshot(opt); err != nil {
+		log.Error("Error occurred in dock module when delete group snapshot:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult("")
+	return &res, nil
+}
+
 func GenericResponseResult(message interface{}) *pb.GenericResponse_Result_ {
 	var msg string
 	switch message.(type) {
@@ -398,3 +560,91 @@ func GenericResponseError(code, description string) *pb.GenericResponse_Error_ {
 		},
 	}
 }
+
+// CreateFileShare implements pb.DockServer.CreateFileShare
+func (ds *dockServer) CreateFileShare(ctx context.Context, opt *pb.CreateFileShareOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive create file share request, vr =", opt)
+
+	share, err := dock.Brain.CreateFileShare(opt)
+	if err != nil {
+		log.Error("Error occurred in dock module when create file share:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult(share)
+	return &res, nil
+}
+
+// DeleteFileShare implements pb.DockServer.DeleteFileShare
+func (ds *dockServer) DeleteFileShare(ctx context.Context, opt *pb.DeleteFileShareOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive delete file share request, vr =", opt)
+
+	if err := dock.Brain.DeleteFileShare(opt); err != nil {
+		log.Error("Error occurred in dock module when delete file share:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult("")
+	return &res, nil
+}
+
+// ExtendFileShare implements pb.DockServer.ExtendFileShare
+func (ds *dockServer) ExtendFileShare(ctx context.Context, opt *pb.ExtendFileShareOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive extend file share request, vr =", opt)
+
+	share, err := dock.Brain.ExtendFileShare(opt)
+	if err != nil {
+		log.Error("Error occurred in dock module when extend file share:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult(share)
+	return &res, nil
+}
+
+// CreateFileShareAcl implements pb.DockServer.CreateFileShareAcl
+func (ds *dockServer) CreateFileShareAcl(ctx context.Context, opt *pb.CreateFileShareAclOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive create file share acl request, vr =", opt)
+
+	acl, err := dock.Brain.CreateFileShareAcl(opt)
+	if err != nil {
+		log.Error("Error occurred in dock module when create file share acl:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult(acl)
+	return &res, nil
+}
+
+// DeleteFileShareAcl implements pb.DockServer.DeleteFileShareAcl
+func (ds *dockServer) DeleteFileShareAcl(ctx context.Context, opt *pb.DeleteFileShareAclOpts) (*pb.GenericResponse, error) {
+	var res pb.GenericResponse
+
+	log.Info("Dock server receive delete file share acl request, vr =", opt)
+
+	if err := dock.Brain.DeleteFileShareAcl(opt); err != nil {
+		log.Error("Error occurred in dock module when delete file share acl:", err)
+
+		res.Reply = GenericResponseError("400", fmt.Sprint(err))
+		return &res, err
+	}
+
+	res.Reply = GenericResponseResult("")
+	return &res, nil
+}
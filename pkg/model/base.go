@@ -35,6 +35,23 @@ type BaseModel struct {
 	// Now, it's represented as a time string in RFC8601 format.
 	// +readOnly
 	UpdatedAt string `json:"updatedAt"`
+
+	// ResourceVersion represents the version of the object in database, it's
+	// changed whenever the object is updated. If it's specified in the update
+	// request, the update fails with a conflict error when the object has been
+	// modified since that version.
+	// +readOnly
+	ResourceVersion int64 `json:"resourceVersion,omitempty"`
+}
+
+// PageSpec describes where a page of list result locates.
+type PageSpec struct {
+	// Next is the continuation token which should be passed as marker to get
+	// the next page, and it's empty if the page is the last one.
+	Next string `json:"next,omitempty"`
+
+	// Total is the number of resources which match the filter.
+	Total int `json:"total"`
 }
 
 // DataStorageLoS can be used to describe a service option covering storage
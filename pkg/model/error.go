@@ -36,6 +36,8 @@ const (
 	ErrorForbidden = 403
 	// ErrorNotFound
 	ErrorNotFound = 404
+	// ErrorConflict
+	ErrorConflict = 409
 	// ErrorInternalServer
 	ErrorInternalServer = 500
 	// ErrorNotImplemented
@@ -69,6 +71,11 @@ func ErrorNotFoundStatus(message string) []byte {
 	return errorStatus(ErrorNotFound, message)
 }
 
+// ErrorConflictStatus
+func ErrorConflictStatus(message string) []byte {
+	return errorStatus(ErrorConflict, message)
+}
+
 // ErrorInternalServerStatus
 func ErrorInternalServerStatus(message string) []byte {
 	return errorStatus(ErrorInternalServer, message)
@@ -122,3 +129,30 @@ func NewNotFoundError(msg string) error {
 func (e *NotFoundError) Error() string {
 	return e.S
 }
+
+type QuotaExceededError struct {
+	S string
+}
+
+func NewQuotaExceededError(msg string) error {
+	return &QuotaExceededError{S: msg}
+}
+
+func (e *QuotaExceededError) Error() string {
+	return e.S
+}
+
+// ConflictError means that the resource has been modified by others since the
+// version which the update is based on, or the resource to be created already
+// exists.
+type ConflictError struct {
+	S string
+}
+
+func NewConflictError(msg string) error {
+	return &ConflictError{S: msg}
+}
+
+func (e *ConflictError) Error() string {
+	return e.S
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+import (
+	"strings"
+)
+
+// The types of resources whose status transitions are recorded as events.
+const (
+	EventResourceVolume        = "volume"
+	EventResourceSnapshot      = "snapshot"
+	EventResourceAttachment    = "attachment"
+	EventResourceVolumeGroup   = "volumeGroup"
+	EventResourceGroupSnapshot = "groupSnapshot"
+	EventResourceFileShare     = "fileShare"
+	EventResourceFileShareAcl  = "fileShareAcl"
+	// The transitions of the policy status of volume.
+	EventResourceVolumePolicy = "volumePolicy"
+)
+
+// EventSpec is a record of the status transition of a resource. The
+// resourceVersion of event increases with the order in which events happen,
+// so it can be used to watch the events after it.
+type EventSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the resource belongs to.
+	// +readOnly
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The type of resource, such as volume and snapshot.
+	// +readOnly
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of resource.
+	// +readOnly
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The status of resource before the transition.
+	// +readOnly
+	OldStatus string `json:"oldStatus,omitempty"`
+
+	// The status of resource after the transition.
+	// +readOnly
+	NewStatus string `json:"newStatus,omitempty"`
+}
+
+// NewStatusEvent returns the event of status transition, or nil if the
+// status isn't changed.
+func NewStatusEvent(tenantId, resourceType, resourceId, oldStatus, newStatus string) *EventSpec {
+	if oldStatus == newStatus {
+		return nil
+	}
+	return &EventSpec{
+		BaseModel:    &BaseModel{},
+		TenantId:     tenantId,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		OldStatus:    oldStatus,
+		NewStatus:    newStatus,
+	}
+}
+
+// IsErrorStatus reports whether the status means that the last operation on
+// the resource failed, such as error and errorDeleting.
+func IsErrorStatus(status string) bool {
+	return strings.HasPrefix(status, "error")
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+// The access types and levels of file share access rules.
+const (
+	FileShareAccessTypeIP = "ip"
+
+	FileShareAccessLevelRO = "ro"
+	FileShareAccessLevelRW = "rw"
+)
+
+// FileShareSpec is a file system created by storage service, which is
+// exported to the clients through the file sharing protocol such as NFS.
+type FileShareSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the file share belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the file share belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The name of the file share.
+	Name string `json:"name,omitempty"`
+
+	// The description of the file share.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The size of the file share requested by the user.
+	// Default unit of file share Size is GB.
+	Size int64 `json:"size,omitempty"`
+
+	// The locality that file share belongs to.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// The status of the file share.
+	// One of: "creating", "available", "error", etc.
+	Status string `json:"status,omitempty"`
+
+	// The uuid of the pool which the file share belongs to.
+	// +readOnly
+	PoolId string `json:"poolId,omitempty"`
+
+	// The uuid of the profile which the file share belongs to.
+	ProfileId string `json:"profileId,omitempty"`
+
+	// The file sharing protocol of the file share, only "nfs" is supported
+	// by now.
+	Protocol string `json:"protocol,omitempty"`
+
+	// The paths through which the clients mount the file share, such as
+	// 192.168.0.10:/var/lib/opensds/shares/xxx.
+	// +readOnly
+	ExportLocations []string `json:"exportLocations,omitempty"`
+
+	// Metadata should be kept until the scemantics between opensds file share
+	// and backend storage resouce description are clear.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// FileShareAclSpec is an access rule of file share, which allows the clients
+// matching it to mount the file share.
+type FileShareAclSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the access rule belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the access rule belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The uuid of the file share which the access rule belongs to.
+	FileShareId string `json:"fileShareId,omitempty"`
+
+	// The type of the access rule, only "ip" is supported by now.
+	Type string `json:"type,omitempty"`
+
+	// The clients which are allowed to access the file share, such as
+	// 192.168.0.10 or 192.168.0.0/24 for the ip access rule.
+	AccessTo string `json:"accessTo,omitempty"`
+
+	// The access level of the clients.
+	// One of: "ro" or "rw".
+	AccessLevel string `json:"accessLevel,omitempty"`
+
+	// The status of the access rule.
+	// One of: "applying", "available", "error", etc.
+	Status string `json:"status,omitempty"`
+
+	// The description of the access rule.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ExtendFileShareSpec is a description of the request of extending a file
+// share.
+type ExtendFileShareSpec struct {
+	NewSize int64 `json:"newSize,omitempty"`
+}
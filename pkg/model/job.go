@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+// The types of jobs, which are named after the operations of controller.
+const (
+	JobCreateVolume        = "createVolume"
+	JobDeleteVolume        = "deleteVolume"
+	JobExtendVolume        = "extendVolume"
+	JobRevertVolume        = "revertVolume"
+	JobMigrateVolume       = "migrateVolume"
+	JobRetypeVolume        = "retypeVolume"
+	JobCreateAttachment    = "createAttachment"
+	JobDeleteAttachment    = "deleteAttachment"
+	JobCreateSnapshot      = "createSnapshot"
+	JobDeleteSnapshot      = "deleteSnapshot"
+	JobCreateGroupSnapshot = "createGroupSnapshot"
+	JobDeleteGroupSnapshot = "deleteGroupSnapshot"
+	JobCreateVolumeGroup   = "createVolumeGroup"
+	JobCreateFileShare     = "createFileShare"
+	JobDeleteFileShare     = "deleteFileShare"
+	JobExtendFileShare     = "extendFileShare"
+	JobCreateFileShareAcl  = "createFileShareAcl"
+	JobDeleteFileShareAcl  = "deleteFileShareAcl"
+)
+
+// The status of jobs. A failed attempt of job puts it back to pending until
+// the attempts are used up.
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// JobSpec is an operation on a resource which runs in the background of
+// controller. It's persisted so that the operation can be retried when it
+// fails and be reconciled when the controller restarts in the middle of it.
+type JobSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the job belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the job belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The type of the job, such as createVolume.
+	Type string `json:"type,omitempty"`
+
+	// The uuid of the resource which the job operates on.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The arguments of the operation besides the resource, such as the new
+	// size of volume when it's extended.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// The status of the job, which is pending, running, succeeded or failed.
+	// +readOnly
+	Status string `json:"status,omitempty"`
+
+	// The host name of the controller which the job is submitted to.
+	// +readOnly
+	Host string `json:"host,omitempty"`
+
+	// The number of attempts made so far.
+	// +readOnly
+	Attempts int64 `json:"attempts,omitempty"`
+
+	// The maximum number of attempts before the job fails.
+	// +readOnly
+	MaxAttempts int64 `json:"maxAttempts,omitempty"`
+
+	// The time when the pending job is retried, in RFC 3339 format.
+	// +readOnly
+	NextRunAt string `json:"nextRunAt,omitempty"`
+
+	// The error of the last failed attempt.
+	// +readOnly
+	Error string `json:"error,omitempty"`
+}
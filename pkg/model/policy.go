@@ -0,0 +1,43 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+// PolicySpec describes a policy which can be specified in the custom
+// properties of profile.
+type PolicySpec struct {
+	// The name of policy, which is the key in custom properties.
+	Name string `json:"name"`
+
+	// The type of policy, a feature is satisfied by the pool which the volume
+	// is placed in, while an operation is executed by the controller.
+	// One of: feature and operation.
+	Type string `json:"type"`
+
+	// The lifecycle point of volume at which the policy takes effect.
+	// One of: create, delete and extend.
+	Lifecycle string `json:"lifecycle,omitempty"`
+
+	// The description of policy.
+	Description string `json:"description,omitempty"`
+
+	// The value of policy in the profile, which is only returned along with
+	// the policies active on a volume.
+	Value interface{} `json:"value,omitempty"`
+}
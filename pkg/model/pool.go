@@ -24,6 +24,19 @@ const (
 	ReplicationTypeArray = "ArrayBased"
 )
 
+const (
+	ProvisioningPolicyFixed = "Fixed"
+	ProvisioningPolicyThin  = "Thin"
+)
+
+// The storage types of pools and profiles, a pool without storage type is
+// regarded as a block one.
+const (
+	StorageTypeBlock  = "block"
+	StorageTypeFile   = "file"
+	StorageTypeObject = "object"
+)
+
 // A pool is discoveried and updated by a dock service. Each pool can be regarded
 // as a physical storage pool or a virtual storage pool. It's a logical and
 // atomic pool and can be abstracted from any storage platform.
@@ -55,6 +68,19 @@ type StoragePoolSpec struct {
 	// Default unit of FreeCapacity is GB.
 	FreeCapacity int64 `json:"freeCapacity,omitempty"`
 
+	// The sum of the sizes of volumes created in the pool, which may exceed
+	// the total capacity of a thin provisioned pool.
+	// Default unit of ProvisionedCapacity is GB.
+	ProvisionedCapacity int64 `json:"provisionedCapacity,omitempty"`
+
+	// The capacity actually consumed by the data in the pool.
+	// Default unit of AllocatedCapacity is GB.
+	AllocatedCapacity int64 `json:"allocatedCapacity,omitempty"`
+
+	// The ratio of the provisioned capacity to the total capacity that a thin
+	// provisioned pool is allowed to reach, it's configured per backend.
+	MaxOverSubscriptionRatio float64 `json:"maxOverSubscriptionRatio,omitempty"`
+
 	// The storage type of the storage pool.
 	// One of: "block", "file" or "object".
 	StorageType string `json:"storageType,omitempty"`
@@ -71,6 +97,21 @@ type StoragePoolSpec struct {
 	ReplicationDriverName string `json:"replicationDriverName,omitempty"`
 }
 
+// VirtualFreeCapacity returns the capacity which can still be provisioned in
+// the pool. For a thin provisioned pool it's limited by the over-subscription
+// ratio instead of the physical free capacity.
+func (p *StoragePoolSpec) VirtualFreeCapacity() int64 {
+	if p.Extras.DataStorage.ProvisioningPolicy != ProvisioningPolicyThin ||
+		p.MaxOverSubscriptionRatio <= 0 {
+		return p.FreeCapacity
+	}
+	free := int64(float64(p.TotalCapacity)*p.MaxOverSubscriptionRatio) - p.ProvisionedCapacity
+	if free < 0 {
+		return 0
+	}
+	return free
+}
+
 type StoragePoolExtraSpec struct {
 	// DataStorage represents suggested some data storage capabilities.
 	DataStorage DataStorageLoS `json:"dataStorage,omitempty" yaml:"dataStorage,omitempty"`
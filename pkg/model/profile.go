@@ -126,7 +126,9 @@ type SnapshotPropertiesSpec struct {
 		// is represented as "2008-09-15T15:53:00".
 		Datetime string `json:"datetime,omitempty"`
 		// The value specifies the duration of executing a operation, which
-		// contains three options including Daily, Weekly and Monthly.
+		// contains three options including Daily, Weekly and Monthly. A cron
+		// expression such as "0 2 * * *" or an ISO 8601 repeating interval
+		// such as "R/PT6H" is also supported.
 		Occurrence string `json:"occurrence,omitempty"`
 	} `json:"schedule,omitempty"`
 	Retention struct {
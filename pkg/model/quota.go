@@ -0,0 +1,136 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+import (
+	"fmt"
+)
+
+// QuotaUnlimited means that there is no limit on the resource.
+const QuotaUnlimited = -1
+
+// QuotaSpec is a description of the resource limits of a tenant, along with
+// the resources which have been reserved by the tenant.
+type QuotaSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the quota belongs to.
+	// +readOnly
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The maximum number of volumes, -1 means unlimited.
+	Volumes int64 `json:"volumes"`
+
+	// The maximum total size of volumes in GB, -1 means unlimited.
+	Gigabytes int64 `json:"gigabytes"`
+
+	// The maximum number of volume snapshots, -1 means unlimited.
+	Snapshots int64 `json:"snapshots"`
+
+	// The maximum number of replications, -1 means unlimited.
+	Replications int64 `json:"replications"`
+
+	// The resources which have been reserved by the tenant.
+	// +readOnly
+	Usage QuotaUsageSpec `json:"usage"`
+}
+
+// QuotaUsageSpec is a description of the amount of resources.
+type QuotaUsageSpec struct {
+	Volumes      int64 `json:"volumes"`
+	Gigabytes    int64 `json:"gigabytes"`
+	Snapshots    int64 `json:"snapshots"`
+	Replications int64 `json:"replications"`
+}
+
+// NewDefaultQuota returns the quota of tenant which has never been set, all
+// of its resources are unlimited.
+func NewDefaultQuota(tenantId string) *QuotaSpec {
+	return &QuotaSpec{
+		BaseModel:    &BaseModel{Id: tenantId},
+		TenantId:     tenantId,
+		Volumes:      QuotaUnlimited,
+		Gigabytes:    QuotaUnlimited,
+		Snapshots:    QuotaUnlimited,
+		Replications: QuotaUnlimited,
+	}
+}
+
+// Validate checks the limits of quota, none of which can be negative except
+// QuotaUnlimited.
+func (q *QuotaSpec) Validate() error {
+	for _, item := range []struct {
+		name  string
+		limit int64
+	}{
+		{"volumes", q.Volumes},
+		{"gigabytes", q.Gigabytes},
+		{"snapshots", q.Snapshots},
+		{"replications", q.Replications},
+	} {
+		if item.limit < QuotaUnlimited {
+			return fmt.Errorf("invalid quota of %s %d, it must be %d or a non-negative number",
+				item.name, item.limit, QuotaUnlimited)
+		}
+	}
+	return nil
+}
+
+// Reserve adds the delta to the usage of quota, QuotaExceededError will be
+// returned if any limit is exceeded and the usage is not changed then.
+func (q *QuotaSpec) Reserve(delta *QuotaUsageSpec) error {
+	for _, item := range []struct {
+		name         string
+		limit, usage int64
+		delta        int64
+	}{
+		{"volumes", q.Volumes, q.Usage.Volumes, delta.Volumes},
+		{"gigabytes", q.Gigabytes, q.Usage.Gigabytes, delta.Gigabytes},
+		{"snapshots", q.Snapshots, q.Usage.Snapshots, delta.Snapshots},
+		{"replications", q.Replications, q.Usage.Replications, delta.Replications},
+	} {
+		if item.limit >= 0 && item.delta > 0 && item.usage+item.delta > item.limit {
+			return NewQuotaExceededError(fmt.Sprintf(
+				"quota of %s exceeded for tenant %s: requested %d, used %d, limit %d",
+				item.name, q.TenantId, item.delta, item.usage, item.limit))
+		}
+	}
+	q.add(delta, 1)
+	return nil
+}
+
+// Release subtracts the delta from the usage of quota.
+func (q *QuotaSpec) Release(delta *QuotaUsageSpec) {
+	q.add(delta, -1)
+}
+
+func (q *QuotaSpec) add(delta *QuotaUsageSpec, sign int64) {
+	q.Usage.Volumes = nonNegative(q.Usage.Volumes + sign*delta.Volumes)
+	q.Usage.Gigabytes = nonNegative(q.Usage.Gigabytes + sign*delta.Gigabytes)
+	q.Usage.Snapshots = nonNegative(q.Usage.Snapshots + sign*delta.Snapshots)
+	q.Usage.Replications = nonNegative(q.Usage.Replications + sign*delta.Replications)
+}
+
+func nonNegative(n int64) int64 {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
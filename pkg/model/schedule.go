@@ -0,0 +1,73 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+// SnapshotScheduleIdKey is the key of the metadata of the snapshots taken by
+// the snapshot schedule, the value of which is the id of the schedule.
+const SnapshotScheduleIdKey = "snapshotScheduleId"
+
+// SnapshotScheduleSpec is a schedule of taking snapshots of the volume and
+// pruning the old ones, which is derived from the snapshot properties of the
+// profile when the volume is created.
+type SnapshotScheduleSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the schedule belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the schedule belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The uuid of the volume which the snapshots are taken of.
+	VolumeId string `json:"volumeId,omitempty"`
+
+	// The uuid of the profile which the schedule is derived from.
+	ProfileId string `json:"profileId,omitempty"`
+
+	// The occurrence of taking snapshots, which is Daily, Weekly, Monthly,
+	// a cron expression or an ISO 8601 repeating interval.
+	Occurrence string `json:"occurrence,omitempty"`
+
+	// The ISO 8601 datetime which the occurrence starts from.
+	// +optional
+	Datetime string `json:"datetime,omitempty"`
+
+	// The maximum number of snapshots taken by the schedule to retain, zero
+	// means no limit.
+	// +optional
+	RetentionNumber int64 `json:"retentionNumber,omitempty"`
+
+	// The maximum age of snapshots taken by the schedule to retain, zero
+	// means no limit.
+	// +optional
+	// +units:day
+	RetentionDuration int64 `json:"retentionDuration,omitempty"`
+
+	// The time when the next snapshot is taken, in RFC 3339 format. It's
+	// persisted before the snapshot is taken, so that an occurrence is never
+	// executed twice even if the controller restarts.
+	// +readOnly
+	NextRunAt string `json:"nextRunAt,omitempty"`
+
+	// The time when the last snapshot was taken, in RFC 3339 format.
+	// +readOnly
+	LastRunAt string `json:"lastRunAt,omitempty"`
+}
@@ -24,6 +24,28 @@ const (
 	VolumeErrorDeleting  = "errorDeleting"
 	VolumeErrorExtending = "errorExtending"
 	VolumeExtending      = "extending"
+	VolumeReverting      = "reverting"
+	VolumeErrorReverting = "errorReverting"
+	VolumeMigrating      = "migrating"
+	VolumeErrorMigrating = "errorMigrating"
+	VolumeRetyping       = "retyping"
+	VolumeErrorRetyping  = "errorRetyping"
+)
+
+// volume migration status
+const (
+	VolumeMigrationStarting   = "starting"
+	VolumeMigrationCopying    = "copying"
+	VolumeMigrationCompleting = "completing"
+	VolumeMigrationSuccess    = "success"
+	VolumeMigrationPartial    = "partial"
+	VolumeMigrationError      = "error"
+)
+
+// volume policy status
+const (
+	VolumePolicyApplied = "applied"
+	VolumePolicyError   = "error"
 )
 
 // volume attach status
@@ -63,3 +85,50 @@ const (
 	VolumeGroupUpdating      = "updating"
 	VolumeGroupInUse         = "inUse"
 )
+
+// group snapshot status
+const (
+	GroupSnapCreating      = "creating"
+	GroupSnapAvailable     = "available"
+	GroupSnapDeleting      = "deleting"
+	GroupSnapError         = "error"
+	GroupSnapErrorDeleting = "errorDeleting"
+)
+
+// file share status
+const (
+	FileShareCreating       = "creating"
+	FileShareAvailable      = "available"
+	FileShareDeleting       = "deleting"
+	FileShareError          = "error"
+	FileShareErrorDeleting  = "errorDeleting"
+	FileShareExtending      = "extending"
+	FileShareErrorExtending = "errorExtending"
+)
+
+// file share access rule status
+const (
+	FileShareAclApplying      = "applying"
+	FileShareAclAvailable     = "available"
+	FileShareAclDeleting      = "deleting"
+	FileShareAclError         = "error"
+	FileShareAclErrorDeleting = "errorDeleting"
+)
+
+// dock status
+const (
+	DockAvailable   = "available"
+	DockUnavailable = "unavailable"
+)
+
+// pool status
+const (
+	PoolAvailable   = "available"
+	PoolUnavailable = "unavailable"
+)
+
+// ResetStatusSpec is a description of the request of resetting the status of
+// a resource, which is only allowed to admin.
+type ResetStatusSpec struct {
+	Status string `json:"status"`
+}
@@ -74,6 +74,10 @@ type VolumeSpec struct {
 	// Download Snapshot From Cloud
 	SnapshotFromCloud bool `json:"snapshotFromCloud,omitempty"`
 
+	// The uuid of the source volume which the volume is cloned from.
+	// +optional
+	SourceVolumeId string `json:"sourceVolumeId,omitempty"`
+
 	// The uuid of the replication which the volume belongs to.
 	ReplicationId string `json:"replicationId,omitempty"`
 
@@ -81,6 +85,34 @@ type VolumeSpec struct {
 	ReplicationDriverData map[string]string `json:"replicationDriverData,omitempty"`
 	// Attach status of the volume.
 	AttachStatus string
+
+	// The status of the latest migration of the volume.
+	// One of: "starting", "copying", "completing", "success", "partial",
+	// "error".
+	// +readOnly
+	MigrationStatus string `json:"migrationStatus,omitempty"`
+
+	// The status of applying the policies of profile to the volume when it
+	// is created. One of: "applied", "error".
+	// +readOnly
+	PolicyStatus string `json:"policyStatus,omitempty"`
+
+	// The progress of the latest migration of the volume in percent.
+	// +readOnly
+	MigrationProgress int64 `json:"migrationProgress,omitempty"`
+
+	// The qos limits which are enforced on the volume by the backend.
+	// +readOnly
+	Qos *QosSpec `json:"qos,omitempty"`
+}
+
+// QosSpec is a description of the io limits of a volume.
+type QosSpec struct {
+	// The maximum io operations per second, zero means no limit.
+	MaxIOPS int64 `json:"maxIOPS,omitempty"`
+
+	// The maximum bandwidth in MB per second, zero means no limit.
+	MaxBWS int64 `json:"maxBWS,omitempty"`
 }
 
 // VolumeAttachmentSpec is a description of volume attached resource.
@@ -174,6 +206,10 @@ type VolumeSnapshotSpec struct {
 	// The uuid of the volume which the snapshot belongs to.
 	VolumeId string `json:"volumeId,omitempty"`
 
+	// The uuid of the group snapshot which the snapshot is taken along with.
+	// +readOnly
+	GroupSnapshotId string `json:"groupSnapshotId,omitempty"`
+
 	// Metadata should be kept until the scemantics between opensds volume
 	// snapshot and backend storage resouce snapshot description are clear.
 	// +optional
@@ -185,6 +221,29 @@ type ExtendVolumeSpec struct {
 	NewSize int64 `json:"newSize,omitempty"`
 }
 
+// RevertVolumeSpec is a description of the request of reverting a volume to
+// one of its snapshots.
+type RevertVolumeSpec struct {
+	// The uuid of the snapshot which the volume is reverted to.
+	SnapshotId string `json:"snapshotId,omitempty"`
+}
+
+// MigrateVolumeSpec is a description of the request of migrating a volume to
+// another storage pool.
+type MigrateVolumeSpec struct {
+	// The uuid of the target pool. If it is empty, a suitable pool will be
+	// chosen by the scheduler.
+	// +optional
+	PoolId string `json:"poolId,omitempty"`
+}
+
+// RetypeVolumeSpec is a description of the request of changing the profile of
+// a volume.
+type RetypeVolumeSpec struct {
+	// The uuid of the new profile of volume.
+	ProfileId string `json:"profileId,omitempty"`
+}
+
 type VolumeGroupSpec struct {
 	*BaseModel
 	// The name of the volume group.
@@ -221,4 +280,41 @@ type VolumeGroupSpec struct {
 	PoolId string `json:"poolId,omitempty"`
 
 	GroupSnapshots []string `json:"groupSnapshots,omitempty"`
+
+	// The uuid of the group snapshot which the volume group is created from.
+	// The volumes of the group are created from the snapshots in it.
+	// +optional
+	GroupSnapshotId string `json:"groupSnapshotId,omitempty"`
+}
+
+// GroupSnapshotSpec is a description of the snapshots of all the volumes in a
+// volume group. Whether the snapshots are consistent with each other depends
+// on the backend.
+type GroupSnapshotSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the group snapshot belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the group snapshot belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The name of the group snapshot.
+	Name string `json:"name,omitempty"`
+
+	// The description of the group snapshot.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The status of the group snapshot.
+	// One of: "creating", "available", "error", etc.
+	Status string `json:"status,omitempty"`
+
+	// The uuid of the volume group which the snapshot belongs to.
+	GroupId string `json:"groupId,omitempty"`
+
+	// The uuids of the volume snapshots taken for the volumes in the group.
+	// +readOnly
+	Snapshots []string `json:"snapshots,omitempty"`
 }
@@ -19,17 +19,25 @@ import "time"
 type Default struct{}
 
 type OsdsLet struct {
-	ApiEndpoint         string        `conf:"api_endpoint,localhost:50040"`
-	Graceful            bool          `conf:"graceful,true"`
-	SocketOrder         string        `conf:"socket_order"`
-	AuthStrategy        string        `conf:"auth_strategy,noauth"`
-	Daemon              bool          `conf:"daemon,false"`
-	PolicyPath          string        `conf:"policy_path,/etc/opensds/policy.json"`
-	LogFlushFrequency   time.Duration `conf:"log_flush_frequency,5s"` // Default value is 5s
-	HTTPSEnabled        bool          `conf:"https_enabled,false"`
-	BeegoHTTPSCertFile  string        `conf:"beego_https_cert_file,/opt/opensds-security/opensds/opensds-cert.pem"`
-	BeegoHTTPSKeyFile   string        `conf:"beego_https_key_file,/opt/opensds-security/opensds/opensds-key.pem"`
-	PasswordDecryptTool string        `conf:"password_decrypt_tool,aes"`
+	ApiEndpoint              string        `conf:"api_endpoint,localhost:50040"`
+	Graceful                 bool          `conf:"graceful,true"`
+	SocketOrder              string        `conf:"socket_order"`
+	AuthStrategy             string        `conf:"auth_strategy,noauth"`
+	Daemon                   bool          `conf:"daemon,false"`
+	PolicyPath               string        `conf:"policy_path,/etc/opensds/policy.json"`
+	LogFlushFrequency        time.Duration `conf:"log_flush_frequency,5s"` // Default value is 5s
+	HTTPSEnabled             bool          `conf:"https_enabled,false"`
+	BeegoHTTPSCertFile       string        `conf:"beego_https_cert_file,/opt/opensds-security/opensds/opensds-cert.pem"`
+	BeegoHTTPSKeyFile        string        `conf:"beego_https_key_file,/opt/opensds-security/opensds/opensds-key.pem"`
+	PasswordDecryptTool      string        `conf:"password_decrypt_tool,aes"`
+	DockCheckInterval        time.Duration `conf:"dock_check_interval,10s"`
+	SnapshotScheduleInterval time.Duration `conf:"snapshot_schedule_interval,1m"`
+	PoolWeighers             []string      `conf:"pool_weighers,free_capacity:1"`
+	JobWorkers               int           `conf:"job_workers,4"`
+	JobMaxAttempts           int           `conf:"job_max_attempts,3"`
+	JobCheckInterval         time.Duration `conf:"job_check_interval,10s"`
+	StuckResourceTimeout     time.Duration `conf:"stuck_resource_timeout,30m"`
+	StuckCheckInterval       time.Duration `conf:"stuck_check_interval,5m"`
 }
 
 type OsdsDock struct {
@@ -40,6 +48,8 @@ type OsdsDock struct {
 	BindIp                     string        `conf:"bind_ip"` // Just used for attacher dock
 	HostBasedReplicationDriver string        `conf:"host_based_replication_driver,drbd"`
 	LogFlushFrequency          time.Duration `conf:"log_flush_frequency,5s"` // Default value is 5s
+	HeartbeatInterval          time.Duration `conf:"heartbeat_interval,10s"`
+	HeartbeatTTL               time.Duration `conf:"heartbeat_ttl,30s"`
 	Backends
 }
 
@@ -55,6 +65,9 @@ type BackendProperties struct {
 	DriverName         string `conf:"driver_name"`
 	ConfigPath         string `conf:"config_path"`
 	SupportReplication bool   `conf:"support_replication,false"`
+	// The ratio of provisioned capacity to total capacity that the thin
+	// provisioned pools of the backend are allowed to reach.
+	MaxOverSubscriptionRatio float64 `conf:"max_over_subscription_ratio,1"`
 }
 
 type Backends struct {
@@ -64,6 +77,7 @@ type Backends struct {
 	LVM                 BackendProperties `conf:"lvm"`
 	HuaweiDorado        BackendProperties `conf:"huawei_dorado"`
 	HuaweiFusionStorage BackendProperties `conf:"huawei_fusionstorage"`
+	NFS                 BackendProperties `conf:"nfs"`
 }
 
 type KeystoneAuthToken struct {
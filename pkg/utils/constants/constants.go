@@ -28,6 +28,11 @@ const (
 	AuthTokenHeader    = "X-Auth-Token"
 	SubjectTokenHeader = "X-Subject-Token"
 
+	// Paginated list response headers, the total number of resources which
+	// match the filter and the continuation token of the next page.
+	TotalCountHeader = "X-Total-Count"
+	NextMarkerHeader = "X-Next-Marker"
+
 	// OpenSDS current api version
 	APIVersion = "v1beta"
 
@@ -0,0 +1,295 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the parsing of the occurrences of schedules, which are
+the points in time at which the scheduled operations are executed.
+
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensds/opensds/pkg/utils/constants"
+)
+
+// Occurrence is a series of points in time.
+type Occurrence interface {
+	// Next returns the first point in time of the series after t, or the zero
+	// time if there is no more.
+	Next(t time.Time) time.Time
+}
+
+// Parse parses the occurrence of schedule, which is one of:
+//   - Daily, Weekly or Monthly, which repeats from the start;
+//   - a cron expression of five fields (minute, hour, day of month, month and
+//     day of week) such as "30 2 * * 1-5", which is evaluated in UTC;
+//   - an ISO 8601 repeating interval such as "R5/2018-09-15T15:53:00Z/P1D",
+//     in which the start may be omitted, or an ISO 8601 duration such as
+//     "PT6H", which repeats from the start.
+//
+// The start is an ISO 8601 datetime, and the occurrence repeats from the Unix
+// epoch if it's empty, so that the series is always the same however many
+// times it's parsed.
+func Parse(occurrence, start string) (Occurrence, error) {
+	occurrence = strings.TrimSpace(occurrence)
+	if occurrence == "" {
+		return nil, fmt.Errorf("occurrence is empty")
+	}
+	from, err := parseDatetime(start)
+	if err != nil {
+		return nil, err
+	}
+
+	var occ Occurrence
+	switch strings.ToLower(occurrence) {
+	case "daily":
+		occ = &periodic{start: from, days: 1, count: -1}
+	case "weekly":
+		occ = &periodic{start: from, days: 7, count: -1}
+	case "monthly":
+		occ = &periodic{start: from, months: 1, count: -1}
+	default:
+		switch {
+		case strings.HasPrefix(occurrence, "R"):
+			occ, err = parseRepeatingInterval(occurrence, from)
+		case strings.HasPrefix(occurrence, "P"):
+			occ, err = parseRepeatingInterval("R/"+occurrence, from)
+		default:
+			occ, err = parseCron(occurrence)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if occ.Next(from.Add(-time.Second)).IsZero() {
+		return nil, fmt.Errorf("occurrence %s never occurs", occurrence)
+	}
+	return occ, nil
+}
+
+// parseDatetime parses the ISO 8601 datetime in the format of RFC 3339 or
+// in the format without time zone, which is in UTC.
+func parseDatetime(datetime string) (time.Time, error) {
+	if datetime == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, datetime); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.ParseInLocation(constants.TimeFormat, datetime, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ISO 8601 datetime %s", datetime)
+	}
+	return t, nil
+}
+
+var durationRegexp = regexp.MustCompile(
+	`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseRepeatingInterval parses the ISO 8601 repeating interval in the format
+// of R[n]/[start/]duration, where n is the number of occurrences.
+func parseRepeatingInterval(interval string, from time.Time) (Occurrence, error) {
+	parts := strings.Split(interval, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid ISO 8601 repeating interval %s", interval)
+	}
+	var p = &periodic{start: from, count: -1}
+	if n := strings.TrimPrefix(parts[0], "R"); n != "" {
+		count, err := strconv.Atoi(n)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid repetitions of ISO 8601 repeating interval %s", interval)
+		}
+		p.count = count
+	}
+	if len(parts) == 3 {
+		start, err := parseDatetime(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		p.start = start
+	}
+
+	m := durationRegexp.FindStringSubmatch(parts[len(parts)-1])
+	if m == nil || parts[len(parts)-1] == "P" || strings.HasSuffix(parts[len(parts)-1], "T") {
+		return nil, fmt.Errorf("invalid ISO 8601 duration %s", parts[len(parts)-1])
+	}
+	var n [7]int
+	for i := range n {
+		if m[i+1] != "" {
+			n[i], _ = strconv.Atoi(m[i+1])
+		}
+	}
+	p.years, p.months, p.days = n[0], n[1], n[2]*7+n[3]
+	p.dur = time.Duration(n[4])*time.Hour + time.Duration(n[5])*time.Minute + time.Duration(n[6])*time.Second
+	if p.approximate() <= 0 {
+		return nil, fmt.Errorf("ISO 8601 duration of %s is zero", interval)
+	}
+	return p, nil
+}
+
+// periodic occurs at start + k * period for k from 0 to count - 1, and count
+// -1 means that it occurs infinitely. The calendar part of period is added
+// to start as a whole, so that monthly occurrences don't drift.
+type periodic struct {
+	start               time.Time
+	years, months, days int
+	dur                 time.Duration
+	count               int
+}
+
+func (p *periodic) approximate() time.Duration {
+	const day = 24 * time.Hour
+	return time.Duration(p.years)*365*day + time.Duration(p.months)*28*day +
+		time.Duration(p.days)*day + p.dur
+}
+
+func (p *periodic) at(k int) time.Time {
+	return p.start.AddDate(k*p.years, k*p.months, k*p.days).Add(time.Duration(k) * p.dur)
+}
+
+func (p *periodic) Next(t time.Time) time.Time {
+	var k int
+	if t.After(p.start) {
+		// Start from an estimate which is never beyond the answer, since the
+		// approximate period is no longer than the real one.
+		k = int(t.Sub(p.start) / p.approximate())
+		for k > 0 && p.at(k).After(t) {
+			k--
+		}
+	}
+	for ; !p.at(k).After(t); k++ {
+	}
+	if p.count >= 0 && k >= p.count {
+		return time.Time{}
+	}
+	return p.at(k)
+}
+
+// cron occurs at the minutes matching all the fields, except that the day
+// matches either the day of month or the day of week if both of them are
+// restricted.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+func parseCron(expr string) (Occurrence, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid occurrence %s, it should be Daily, Weekly, Monthly, "+
+			"a cron expression of five fields or an ISO 8601 repeating interval", expr)
+	}
+	var c = &cron{domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	for i, f := range []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&c.minute, 0, 59},
+		{&c.hour, 0, 23},
+		{&c.dom, 1, 31},
+		{&c.month, 1, 12},
+		{&c.dow, 0, 7},
+	} {
+		bits, err := parseCronField(fields[i], f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %s of cron expression %s: %v", fields[i], expr, err)
+		}
+		*f.bits = bits
+	}
+	// Both 0 and 7 are Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		var step = 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %s", part[i+1:])
+			}
+			step, part = s, part[:i]
+		}
+		var lo, hi = min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %s", bounds[0])
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %s", bounds[1])
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value out of range [%d, %d]", min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// The cron expression which never matches, such as "0 0 30 2 *", is
+	// given up after searching for a few years.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseTime(t *testing.T, s string) time.Time {
+	tm, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+func TestNext(t *testing.T) {
+	for _, c := range []struct {
+		occurrence, start, after string
+		expected                 []string
+	}{
+		{"Daily", "2018-09-15T15:53:00", "2018-10-01T16:00:00Z",
+			[]string{"2018-10-02T15:53:00Z", "2018-10-03T15:53:00Z"}},
+		{"weekly", "2018-09-15T15:53:00", "2018-09-15T15:53:00Z",
+			[]string{"2018-09-22T15:53:00Z", "2018-09-29T15:53:00Z"}},
+		// Monthly occurrences don't drift after the short months.
+		{"Monthly", "2018-01-15T00:00:00Z", "2018-02-20T00:00:00Z",
+			[]string{"2018-03-15T00:00:00Z", "2018-04-15T00:00:00Z"}},
+		// The occurrence before the start is the start itself.
+		{"Daily", "2018-09-15T15:53:00", "2018-01-01T00:00:00Z",
+			[]string{"2018-09-15T15:53:00Z", "2018-09-16T15:53:00Z"}},
+		{"PT6H", "", "2018-09-15T15:53:00Z",
+			[]string{"2018-09-15T18:00:00Z", "2018-09-16T00:00:00Z"}},
+		{"R/2018-09-15T15:53:00+08:00/P1DT12H", "", "2018-09-16T00:00:00Z",
+			[]string{"2018-09-16T19:53:00Z", "2018-09-18T07:53:00Z"}},
+		{"R2/P1W", "2018-09-15T00:00:00", "2018-01-01T00:00:00Z",
+			[]string{"2018-09-15T00:00:00Z", "2018-09-22T00:00:00Z", ""}},
+		{"30 2 * * *", "", "2018-09-15T02:30:00Z",
+			[]string{"2018-09-16T02:30:00Z", "2018-09-17T02:30:00Z"}},
+		{"*/15 9-10 * * 1-5", "", "2018-09-14T10:50:00Z",
+			[]string{"2018-09-17T09:00:00Z", "2018-09-17T09:15:00Z"}},
+		// The day matches either day of month or day of week.
+		{"0 0 1 * 0", "", "2018-09-15T00:00:00Z",
+			[]string{"2018-09-16T00:00:00Z", "2018-09-23T00:00:00Z", "2018-09-30T00:00:00Z",
+				"2018-10-01T00:00:00Z"}},
+		{"0 12 29 2 *", "", "2018-01-01T00:00:00Z",
+			[]string{"2020-02-29T12:00:00Z", "2024-02-29T12:00:00Z"}},
+	} {
+		occ, err := Parse(c.occurrence, c.start)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", c.occurrence, err)
+			continue
+		}
+		var next = mustParseTime(t, c.after)
+		for _, e := range c.expected {
+			next = occ.Next(next)
+			if e == "" {
+				if !next.IsZero() {
+					t.Errorf("Expected no more occurrence of %s, got %v", c.occurrence, next)
+				}
+				break
+			}
+			if !next.Equal(mustParseTime(t, e)) {
+				t.Errorf("Expected next occurrence of %s is %s, got %v", c.occurrence, e, next)
+				break
+			}
+		}
+	}
+}
+
+func TestParseWithError(t *testing.T) {
+	for _, c := range []struct{ occurrence, start string }{
+		{"", ""},
+		{"Yearly", ""},
+		{"Daily", "2018/09/15"},
+		{"P", ""},
+		{"PT", ""},
+		{"P0D", ""},
+		{"P1H", ""},
+		{"R0/P1D", ""},
+		{"Rx/P1D", ""},
+		{"R/2018-09-15/P1D", ""},
+		{"60 * * * *", ""},
+		{"* * 0 * *", ""},
+		{"*/0 * * * *", ""},
+		{"5-1 * * * *", ""},
+		{"* * * *", ""},
+		{"0 0 30 2 *", ""},
+	} {
+		if _, err := Parse(c.occurrence, c.start); err == nil {
+			t.Errorf("Expected error of parsing %q", c.occurrence)
+		}
+	}
+}
@@ -37,6 +37,10 @@ func GenerateProfileURL(urlType int, tenantId string, in ...string) string {
 	return generateURL("profiles", urlType, tenantId, in...)
 }
 
+func GeneratePolicyURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("policies", urlType, tenantId, in...)
+}
+
 func GenerateVolumeURL(urlType int, tenantId string, in ...string) string {
 	return generateURL("block/volumes", urlType, tenantId, in...)
 }
@@ -62,6 +66,42 @@ func GenerateVolumeGroupURL(urlType int, tenantId string, in ...string) string {
 	return generateURL("block/volumeGroups", urlType, tenantId, in...)
 }
 
+func GenerateGroupSnapshotURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("block/groupSnapshots", urlType, tenantId, in...)
+}
+
+func GenerateFileShareURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("file/shares", urlType, tenantId, in...)
+}
+
+func GenerateFileShareAclURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("file/acls", urlType, tenantId, in...)
+}
+
+func GenerateSnapshotScheduleURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("block/snapshotSchedules", urlType, tenantId, in...)
+}
+
+func GenerateJobURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("jobs", urlType, tenantId, in...)
+}
+
+func GenerateQuotaURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("quotas", urlType, tenantId, in...)
+}
+
+func GenerateEventURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("events", urlType, tenantId, in...)
+}
+
+func GenerateHeartbeatURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("heartbeats", urlType, tenantId, in...)
+}
+
+func GenerateLockURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("locks", urlType, tenantId, in...)
+}
+
 func generateURL(resource string, urlType int, tenantId string, in ...string) string {
 	// If project id is not specified, ignore it.
 	if tenantId == "" {
@@ -21,13 +21,14 @@ import (
 	"testing"
 
 	"github.com/opensds/opensds/pkg/controller/volume"
+	"github.com/opensds/opensds/pkg/dock/client"
 	pb "github.com/opensds/opensds/pkg/dock/proto"
 	"github.com/opensds/opensds/pkg/model"
 	. "github.com/opensds/opensds/testutils/collection"
 )
 
 var (
-	vc      = volume.NewController()
+	vc      = volume.NewController(client.NewPool(client.NewClient))
 	dckInfo = &model.DockSpec{
 		Endpoint:   "localhost:50050",
 		DriverName: "default",
@@ -35,9 +36,7 @@ var (
 )
 
 func TestControllerCreateVolume(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	vol, err := vc.CreateVolume(&pb.CreateVolumeOpts{})
+	vol, err := vc.CreateVolume(dckInfo, &pb.CreateVolumeOpts{})
 	if err != nil {
 		t.Error("create volume in controller failed:", err)
 		return
@@ -50,18 +49,14 @@ func TestControllerCreateVolume(t *testing.T) {
 }
 
 func TestControllerDeleteVolume(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	err := vc.DeleteVolume(&pb.DeleteVolumeOpts{})
+	err := vc.DeleteVolume(dckInfo, &pb.DeleteVolumeOpts{})
 	if err != nil {
 		t.Error("delete volume in controller failed:", err)
 	}
 }
 
 func TestControllerExtendVolume(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	vol, err := vc.ExtendVolume(&pb.ExtendVolumeOpts{})
+	vol, err := vc.ExtendVolume(dckInfo, &pb.ExtendVolumeOpts{})
 	if err != nil {
 		t.Error("extend volume in controller failed:", err)
 		return
@@ -74,9 +69,7 @@ func TestControllerExtendVolume(t *testing.T) {
 }
 
 func TestControllerCreateVolumeAttachment(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	atc, err := vc.CreateVolumeAttachment(&pb.CreateAttachmentOpts{})
+	atc, err := vc.CreateVolumeAttachment(dckInfo, &pb.CreateAttachmentOpts{})
 	if err != nil {
 		t.Error("create volume attachment in controller failed:", err)
 		return
@@ -92,18 +85,14 @@ func TestControllerCreateVolumeAttachment(t *testing.T) {
 }
 
 func TestControllerDeleteVolumeAttachment(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	err := vc.DeleteVolumeAttachment(&pb.DeleteAttachmentOpts{})
+	err := vc.DeleteVolumeAttachment(dckInfo, &pb.DeleteAttachmentOpts{})
 	if err != nil {
 		t.Error("delete volume attachment in controller failed:", err)
 	}
 }
 
 func TestControllerCreateVolumeSnapshot(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	snp, err := vc.CreateVolumeSnapshot(&pb.CreateVolumeSnapshotOpts{})
+	snp, err := vc.CreateVolumeSnapshot(dckInfo, &pb.CreateVolumeSnapshotOpts{})
 	if err != nil {
 		t.Error("create volume snapshot in controller failed:", err)
 		return
@@ -116,9 +105,7 @@ func TestControllerCreateVolumeSnapshot(t *testing.T) {
 }
 
 func TestControllerDeleteVolumeSnapshot(t *testing.T) {
-	vc.SetDock(dckInfo)
-
-	err := vc.DeleteVolumeSnapshot(&pb.DeleteVolumeSnapshotOpts{})
+	err := vc.DeleteVolumeSnapshot(dckInfo, &pb.DeleteVolumeSnapshotOpts{})
 	if err != nil {
 		t.Error("delete volume snapshot in controller failed:", err)
 	}
@@ -0,0 +1,296 @@
+// Copyright (c) 2018 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package integration
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	ctx "github.com/opensds/opensds/pkg/context"
+	"github.com/opensds/opensds/pkg/db/drivers/mysql"
+	"github.com/opensds/opensds/pkg/model"
+	"github.com/satori/go.uuid"
+)
+
+// The tests below run the schema and the statements of mysql driver against
+// a real server, the dsn of which is like username:password@tcp(ip:port)/dbname.
+const mysqlDsnEnv = "OPENSDS_TEST_MYSQL_DSN"
+
+func newMysqlClient(t *testing.T) *mysql.Client {
+	dsn := os.Getenv(mysqlDsnEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set, skip the tests of mysql", mysqlDsnEnv)
+	}
+	return mysql.Init("mysql", dsn)
+}
+
+func newTenantContext() *ctx.Context {
+	return &ctx.Context{TenantId: uuid.NewV4().String()}
+}
+
+func TestMysqlMigrate(t *testing.T) {
+	newMysqlClient(t)
+
+	// Migrating the database which is up to date changes nothing.
+	db, err := sql.Open("mysql", os.Getenv(mysqlDsnEnv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = mysql.Migrate(db); err != nil {
+		t.Errorf("Expected %v, got %v", nil, err)
+	}
+}
+
+func TestMysqlProfile(t *testing.T) {
+	fc := newMysqlClient(t)
+	admin := ctx.NewAdminContext()
+
+	prf, err := fc.CreateProfile(admin, &model.ProfileSpec{
+		BaseModel:        &model.BaseModel{},
+		Name:                         "silver-" + uuid.NewV4().String(),
+		Description:      "silver policy",
+		CustomProperties: model.CustomPropertiesSpec{"diskType": "SAS"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fc.DeleteProfile(admin, prf.Id)
+
+	result, err := fc.GetProfile(admin, prf.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != prf.Name || result.CustomProperties["diskType"] != "SAS" {
+		t.Errorf("Expected %+v, got %+v", prf, result)
+	}
+
+	_, err = fc.AddCustomProperty(admin, prf.Id, model.CustomPropertiesSpec{"iops": "1000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = fc.RemoveCustomProperty(admin, prf.Id, "diskType"); err != nil {
+		t.Fatal(err)
+	}
+	props, err := fc.ListCustomProperties(admin, prf.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*props) != 1 || (*props)["iops"] != "1000" {
+		t.Errorf("Expected custom properties %v, got %v", map[string]string{"iops": "1000"}, *props)
+	}
+
+	prfs, err := fc.ListProfilesWithFilter(admin, map[string][]string{"name": {prf.Name}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prfs) != 1 || prfs[0].Id != prf.Id {
+		t.Errorf("Expected profile %s listed, got %v", prf.Id, prfs)
+	}
+}
+
+func TestMysqlVolume(t *testing.T) {
+	fc := newMysqlClient(t)
+	admin := ctx.NewAdminContext()
+	tenant := newTenantContext()
+
+	prf, err := fc.CreateProfile(admin, &model.ProfileSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "gold-" + uuid.NewV4().String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fc.DeleteProfile(admin, prf.Id)
+
+	var vol = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: uuid.NewV4().String()},
+		Name:      "sample-volume",
+		Size:      1,
+		Status:    model.VolumeCreating,
+		PoolId:    uuid.NewV4().String(),
+		ProfileId: prf.Id,
+		Metadata:  map[string]string{"key": "value"},
+	}
+	if _, err = fc.CreateVolume(tenant, vol); err != nil {
+		t.Fatal(err)
+	}
+	defer fc.DeleteVolume(admin, vol.Id)
+
+	// Creating the volume with an existing id doesn't overwrite it.
+	var dup = *vol
+	dup.Name = "duplicate-volume"
+	if _, err = fc.CreateVolume(tenant, &dup); err == nil {
+		t.Fatal("Expected error of creating volume with an existing id")
+	} else if _, ok := err.(*model.ConflictError); !ok {
+		t.Errorf("Expected ConflictError, got %v", err)
+	}
+
+	result, err := fc.GetVolume(tenant, vol.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != vol.Name || result.TenantId != tenant.TenantId || result.Metadata["key"] != "value" {
+		t.Errorf("Expected %+v, got %+v", vol, result)
+	}
+
+	// The volume of tenant is invisible to the others.
+	if _, err = fc.GetVolume(newTenantContext(), vol.Id); err == nil {
+		t.Error("Expected error of getting the volume of another tenant")
+	}
+
+	vols, err := fc.ListVolumesWithFilter(tenant, map[string][]string{"poolId": {vol.PoolId}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vols) != 1 || vols[0].Id != vol.Id {
+		t.Errorf("Expected volume %s listed, got %v", vol.Id, vols)
+	}
+
+	if err = fc.UpdateStatus(tenant, result, model.VolumeAvailable); err != nil {
+		t.Fatal(err)
+	}
+	result, err = fc.UpdateVolume(tenant, &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: vol.Id},
+		Name:      "updated-volume",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "updated-volume" || result.Status != model.VolumeAvailable {
+		t.Errorf("Expected volume updated, got %+v", result)
+	}
+
+	// The update based on a stale version of volume is rejected, while the
+	// status is applied to the latest version of it.
+	_, err = fc.UpdateVolume(tenant, &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: vol.Id, ResourceVersion: result.ResourceVersion - 1},
+		Name:      "stale-volume",
+	})
+	if _, ok := err.(*model.ConflictError); !ok {
+		t.Errorf("Expected ConflictError, got %v", err)
+	}
+	var stale = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: vol.Id, ResourceVersion: result.ResourceVersion - 1},
+		Name:      "sample-volume",
+	}
+	if err = fc.UpdateStatus(tenant, stale, model.VolumeInUse); err != nil {
+		t.Fatal(err)
+	}
+	if result, err = fc.GetVolume(tenant, vol.Id); err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "updated-volume" || result.Status != model.VolumeInUse {
+		t.Errorf("Expected status of volume updated only, got %+v", result)
+	}
+
+	var snp = &model.VolumeSnapshotSpec{
+		BaseModel: &model.BaseModel{Id: uuid.NewV4().String()},
+		Name:      "sample-snapshot",
+		Size:      1,
+		Status:    model.VolumeSnapAvailable,
+		VolumeId:  vol.Id,
+	}
+	if _, err = fc.CreateVolumeSnapshot(tenant, snp); err != nil {
+		t.Fatal(err)
+	}
+	snps, err := fc.ListSnapshotsByVolumeId(tenant, vol.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snps) != 1 || snps[0].Id != snp.Id {
+		t.Errorf("Expected snapshot %s listed, got %v", snp.Id, snps)
+	}
+	if err = fc.DeleteVolumeSnapshot(tenant, snp.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = fc.DeleteVolume(tenant, vol.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = fc.GetVolume(tenant, vol.Id); err == nil {
+		t.Error("Expected error of getting the deleted volume")
+	} else if _, ok := err.(*model.NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestMysqlQuota(t *testing.T) {
+	fc := newMysqlClient(t)
+	admin := ctx.NewAdminContext()
+	tenantId := uuid.NewV4().String()
+
+	quota := model.NewDefaultQuota(tenantId)
+	quota.Volumes = 1
+	if _, err := fc.UpdateQuota(admin, quota); err != nil {
+		t.Fatal(err)
+	}
+
+	var delta = &model.QuotaUsageSpec{Volumes: 1, Gigabytes: 1}
+	if err := fc.ReserveQuota(admin, tenantId, delta); err != nil {
+		t.Fatal(err)
+	}
+	if err := fc.ReserveQuota(admin, tenantId, delta); err == nil {
+		t.Error("Expected error of reserving the quota exceeded")
+	} else if _, ok := err.(*model.QuotaExceededError); !ok {
+		t.Errorf("Expected QuotaExceededError, got %v", err)
+	}
+	if err := fc.ReleaseQuota(admin, tenantId, delta); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := fc.GetQuota(admin, tenantId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Volumes != 1 || result.Usage.Volumes != 0 || result.Usage.Gigabytes != 0 {
+		t.Errorf("Expected quota %+v, got %+v", quota, result)
+	}
+}
+
+func TestMysqlJob(t *testing.T) {
+	fc := newMysqlClient(t)
+	tenant := newTenantContext()
+
+	var job = &model.JobSpec{
+		BaseModel:   &model.BaseModel{Id: uuid.NewV4().String()},
+		Type:        "createVolume",
+		ResourceId:  uuid.NewV4().String(),
+		Status:      "pending",
+		MaxAttempts: 3,
+	}
+	if _, err := fc.CreateJob(tenant, job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fc.CreateJob(tenant, job); err == nil {
+		t.Error("Expected error of creating job with an existing id")
+	}
+
+	job.Status = "running"
+	job.Attempts = 1
+	if _, err := fc.UpdateJob(tenant, job); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := fc.ListJobsWithFilter(tenant, map[string][]string{"status": {"running"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Id != job.Id || jobs[0].Attempts != 1 {
+		t.Errorf("Expected job %+v listed, got %v", job, jobs)
+	}
+}
@@ -213,6 +213,52 @@ var (
 		},
 	}
 
+	SampleGroupSnapshots = []model.GroupSnapshotSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50",
+			},
+			Name:        "sample-group-snapshot-01",
+			Description: "This is the first sample group snapshot for testing",
+			Status:      "available",
+			GroupId:     "3769855c-a102-11e7-b772-17b880d2f555",
+			Snapshots: []string{
+				"3769855c-a102-11e7-b772-17b880d2f537",
+				"3bfaf2cc-a102-11e7-8ecb-63aea739d755",
+			},
+		},
+	}
+
+	SampleFileShares = []model.FileShareSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			},
+			Name:             "sample-fileshare-01",
+			Description:      "This is the first sample file share for testing",
+			Size:             int64(1),
+			Status:           "available",
+			AvailabilityZone: "default",
+			PoolId:           "084bf71e-a102-11e7-88a8-e31fe6d52248",
+			ProfileId:        "1106b972-66ef-11e7-b172-db03f3689c9c",
+			Protocol:         "nfs",
+			ExportLocations:  []string{"192.168.56.105:/var/lib/opensds/shares/opensds-d2975ebe-d82c-430f-b28e-f373746a71ca"},
+		},
+	}
+
+	SampleFileShareAcls = []model.FileShareAclSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "6ad25d59-a160-45b2-8920-211be282e2df",
+			},
+			FileShareId: "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			Type:        "ip",
+			AccessTo:    "192.168.56.0/24",
+			AccessLevel: "rw",
+			Status:      "available",
+		},
+	}
+
 	SampleReplications = []model.ReplicationSpec{
 		{
 			BaseModel: &model.BaseModel{
@@ -237,6 +283,51 @@ var (
 			ProfileId:         "1106b972-66ef-11e7-b172-db03f3689c9c",
 		},
 	}
+
+	SampleQuotas = []model.QuotaSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+			},
+			TenantId:     "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+			Volumes:      10,
+			Gigabytes:    100,
+			Snapshots:    model.QuotaUnlimited,
+			Replications: model.QuotaUnlimited,
+			Usage: model.QuotaUsageSpec{
+				Volumes:   2,
+				Gigabytes: 3,
+			},
+		},
+	}
+
+	SampleEvents = []model.EventSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:              "e3f6a5d4-0c1b-4f3e-8c6b-5b0b3b2f1a01",
+				CreatedAt:       "2018-08-10T14:36:58",
+				ResourceVersion: 7,
+			},
+			ResourceType: "volume",
+			ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			OldStatus:    "creating",
+			NewStatus:    "available",
+		},
+	}
+
+	SampleJobs = []model.JobSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d",
+				CreatedAt: "2018-08-10T14:36:58",
+			},
+			Type:        "createVolume",
+			ResourceId:  "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			Status:      "succeeded",
+			Attempts:    1,
+			MaxAttempts: 3,
+		},
+	}
 )
 
 // The Byte*** variable here is designed for unit test in client package.
@@ -479,6 +570,106 @@ var (
 		}
 	]`
 
+	ByteGroupSnapshot = `{
+		"id": "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50",
+		"name": "sample-group-snapshot-01",
+		"description": "This is the first sample group snapshot for testing",
+		"status": "available",
+		"groupId": "3769855c-a102-11e7-b772-17b880d2f555",
+		"snapshots": [
+			"3769855c-a102-11e7-b772-17b880d2f537",
+			"3bfaf2cc-a102-11e7-8ecb-63aea739d755"
+		]
+	}`
+
+	ByteGroupSnapshots = `[
+		{
+			"id": "8b2f6c3e-2b4d-4a3e-9f0e-6a1c2d3e4f50",
+			"name": "sample-group-snapshot-01",
+			"description": "This is the first sample group snapshot for testing",
+			"status": "available",
+			"groupId": "3769855c-a102-11e7-b772-17b880d2f555",
+			"snapshots": [
+				"3769855c-a102-11e7-b772-17b880d2f537",
+				"3bfaf2cc-a102-11e7-8ecb-63aea739d755"
+			]
+		}
+	]`
+
+	ByteFileShare = `{
+		"id": "d2975ebe-d82c-430f-b28e-f373746a71ca",
+		"name": "sample-fileshare-01",
+		"description": "This is the first sample file share for testing",
+		"size": 1,
+		"status": "available",
+		"availabilityZone": "default",
+		"poolId": "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		"profileId": "1106b972-66ef-11e7-b172-db03f3689c9c",
+		"protocol": "nfs",
+		"exportLocations": [
+			"192.168.56.105:/var/lib/opensds/shares/opensds-d2975ebe-d82c-430f-b28e-f373746a71ca"
+		]
+	}`
+
+	ByteFileShares = `[
+		{
+			"id": "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			"name": "sample-fileshare-01",
+			"description": "This is the first sample file share for testing",
+			"size": 1,
+			"status": "available",
+			"availabilityZone": "default",
+			"poolId": "084bf71e-a102-11e7-88a8-e31fe6d52248",
+			"profileId": "1106b972-66ef-11e7-b172-db03f3689c9c",
+			"protocol": "nfs",
+			"exportLocations": [
+				"192.168.56.105:/var/lib/opensds/shares/opensds-d2975ebe-d82c-430f-b28e-f373746a71ca"
+			]
+		}
+	]`
+
+	ByteFileShareAcl = `{
+		"id": "6ad25d59-a160-45b2-8920-211be282e2df",
+		"fileShareId": "d2975ebe-d82c-430f-b28e-f373746a71ca",
+		"type": "ip",
+		"accessTo": "192.168.56.0/24",
+		"accessLevel": "rw",
+		"status": "available"
+	}`
+
+	ByteFileShareAcls = `[
+		{
+			"id": "6ad25d59-a160-45b2-8920-211be282e2df",
+			"fileShareId": "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			"type": "ip",
+			"accessTo": "192.168.56.0/24",
+			"accessLevel": "rw",
+			"status": "available"
+		}
+	]`
+
+	ByteJob = `{
+		"id": "0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d",
+		"createdAt": "2018-08-10T14:36:58",
+		"type": "createVolume",
+		"resourceId": "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		"status": "succeeded",
+		"attempts": 1,
+		"maxAttempts": 3
+	}`
+
+	ByteJobs = `[
+		{
+			"id": "0b9a2b62-5a6e-4f2e-9f0a-3a7c1c3e4b6d",
+			"createdAt": "2018-08-10T14:36:58",
+			"type": "createVolume",
+			"resourceId": "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			"status": "succeeded",
+			"attempts": 1,
+			"maxAttempts": 3
+		}
+	]`
+
 	ByteReplication = `{
 			"id": "c299a978-4f3e-11e8-8a5c-977218a83359",
 			"PrimaryVolumeId": "bd5b12a8-a101-11e7-941e-d77981b584d8",
@@ -510,6 +701,43 @@ var (
 		}
 	]`
 
+	ByteQuota = `{
+		"id": "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+		"tenantId": "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+		"volumes": 10,
+		"gigabytes": 100,
+		"snapshots": -1,
+		"replications": -1,
+		"usage": {
+			"volumes": 2,
+			"gigabytes": 3,
+			"snapshots": 0,
+			"replications": 0
+		}
+	}`
+
+	ByteEvents = `[
+		{
+			"id": "e3f6a5d4-0c1b-4f3e-8c6b-5b0b3b2f1a01",
+			"createdAt": "2018-08-10T14:36:58",
+			"resourceVersion": 7,
+			"resourceType": "volume",
+			"resourceId": "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			"oldStatus": "creating",
+			"newStatus": "available"
+		}
+	]`
+
+	BytePolicies = `[
+		{
+			"name": "intervalSnapshot",
+			"type": "operation",
+			"lifecycle": "create",
+			"description": "Take a few snapshots of the volume every interval such as 12h after it's created.",
+			"value": "12h"
+		}
+	]`
+
 	ByteVersion = `{
 		"name": "v1beta",
 		"status": "SUPPORTED",
@@ -661,4 +889,38 @@ var (
 			"profileId":         "1106b972-66ef-11e7-b172-db03f3689c9c"
 		}`,
 	}
+
+	StringSliceQuotas = []string{
+		`{
+			"id":           "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+			"tenantId":     "ef305038-cd12-4f3b-90bd-0612f83e14ee",
+			"volumes":      10,
+			"gigabytes":    100,
+			"snapshots":    -1,
+			"replications": -1,
+			"usage": {
+				"volumes":   2,
+				"gigabytes": 3
+			}
+		}`,
+	}
+
+	StringSliceEvents = []string{
+		`{
+			"id":           "e3f6a5d4-0c1b-4f3e-8c6b-5b0b3b2f1a01",
+			"createdAt":    "2018-08-10T14:36:58",
+			"resourceType": "volume",
+			"resourceId":   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			"oldStatus":    "creating",
+			"newStatus":    "available"
+		}`,
+		`{
+			"id":           "8a1d36f2-7c0e-4bb5-9a3f-0f7c2d3e4b02",
+			"createdAt":    "2018-08-10T14:37:02",
+			"resourceType": "snapshot",
+			"resourceId":   "3769855c-a102-11e7-b772-17b880d2f537",
+			"oldStatus":    "creating",
+			"newStatus":    "available"
+		}`,
+	}
 )
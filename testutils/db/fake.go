@@ -16,6 +16,7 @@ package db
 
 import (
 	"errors"
+	"time"
 
 	c "github.com/opensds/opensds/pkg/context"
 	"github.com/opensds/opensds/pkg/model"
@@ -69,6 +70,12 @@ func (fc *FakeDbClient) ListDocksWithFilter(ctx *c.Context, m map[string][]strin
 	}
 	return dcks, nil
 }
+
+// ListDocksWithPage
+func (fc *FakeDbClient) ListDocksWithPage(ctx *c.Context, m map[string][]string) ([]*model.DockSpec, *model.PageSpec, error) {
+	dcks, _ := fc.ListDocksWithFilter(ctx, m)
+	return dcks, &model.PageSpec{Total: len(dcks)}, nil
+}
 func (fc *FakeDbClient) ListDocks(ctx *c.Context) ([]*model.DockSpec, error) {
 	var dcks []*model.DockSpec
 
@@ -122,6 +129,12 @@ func (fc *FakeDbClient) ListPoolsWithFilter(ctx *c.Context, m map[string][]strin
 	}
 	return pols, nil
 }
+
+// ListPoolsWithPage
+func (fc *FakeDbClient) ListPoolsWithPage(ctx *c.Context, m map[string][]string) ([]*model.StoragePoolSpec, *model.PageSpec, error) {
+	pols, _ := fc.ListPoolsWithFilter(ctx, m)
+	return pols, &model.PageSpec{Total: len(pols)}, nil
+}
 func (fc *FakeDbClient) ListPools(ctx *c.Context) ([]*model.StoragePoolSpec, error) {
 	var pols []*model.StoragePoolSpec
 
@@ -141,6 +154,25 @@ func (fc *FakeDbClient) DeletePool(ctx *c.Context, polID string) error {
 	return nil
 }
 
+// Heartbeat
+func (fc *FakeDbClient) Heartbeat(ctx *c.Context, dckID string, ttl time.Duration) error {
+	return nil
+}
+
+// ListHeartbeats
+func (fc *FakeDbClient) ListHeartbeats(ctx *c.Context) ([]string, error) {
+	var ids []string
+	for _, dock := range SampleDocks {
+		ids = append(ids, dock.Id)
+	}
+	return ids, nil
+}
+
+// AcquireLock
+func (fc *FakeDbClient) AcquireLock(ctx *c.Context, name, holder string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
 // CreateProfile
 func (fc *FakeDbClient) CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.ProfileSpec, error) {
 	return &SampleProfiles[0], nil
@@ -177,6 +209,12 @@ func (fc *FakeDbClient) ListProfilesWithFilter(ctx *c.Context, m map[string][]st
 	}
 	return prfs, nil
 }
+
+// ListProfilesWithPage
+func (fc *FakeDbClient) ListProfilesWithPage(ctx *c.Context, m map[string][]string) ([]*model.ProfileSpec, *model.PageSpec, error) {
+	prfs, _ := fc.ListProfilesWithFilter(ctx, m)
+	return prfs, &model.PageSpec{Total: len(prfs)}, nil
+}
 func (fc *FakeDbClient) ListProfiles(ctx *c.Context) ([]*model.ProfileSpec, error) {
 	var prfs []*model.ProfileSpec
 
@@ -233,6 +271,13 @@ func (fc *FakeDbClient) ListVolumesWithFilter(ctx *c.Context, m map[string][]str
 	}
 	return vols, nil
 }
+
+// ListVolumesWithPage
+func (fc *FakeDbClient) ListVolumesWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSpec, *model.PageSpec, error) {
+	vols, _ := fc.ListVolumesWithFilter(ctx, m)
+	return vols, &model.PageSpec{Total: len(vols)}, nil
+}
+
 func (fc *FakeDbClient) ListVolumes(ctx *c.Context) ([]*model.VolumeSpec, error) {
 	var vols []*model.VolumeSpec
 
@@ -317,6 +362,13 @@ func (fc *FakeDbClient) ListVolumeSnapshotsWithFilter(ctx *c.Context, m map[stri
 	}
 	return snps, nil
 }
+
+// ListVolumeSnapshotsWithPage
+func (fc *FakeDbClient) ListVolumeSnapshotsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, *model.PageSpec, error) {
+	snps, _ := fc.ListVolumeSnapshotsWithFilter(ctx, m)
+	return snps, &model.PageSpec{Total: len(snps)}, nil
+}
+
 func (fc *FakeDbClient) ListVolumeSnapshots(ctx *c.Context) ([]*model.VolumeSnapshotSpec, error) {
 	var snps []*model.VolumeSnapshotSpec
 
@@ -362,6 +414,12 @@ func (fc *FakeDbClient) ListReplicationWithFilter(ctx *c.Context, m map[string][
 	return replications, nil
 }
 
+// ListReplicationWithPage
+func (fc *FakeDbClient) ListReplicationWithPage(ctx *c.Context, m map[string][]string) ([]*model.ReplicationSpec, *model.PageSpec, error) {
+	replications, _ := fc.ListReplicationWithFilter(ctx, m)
+	return replications, &model.PageSpec{Total: len(replications)}, nil
+}
+
 func (fc *FakeDbClient) DeleteReplication(ctx *c.Context, replicationId string) error {
 	return nil
 }
@@ -407,10 +465,194 @@ func (fc *FakeDbClient) ListVolumeGroupsWithFilter(ctx *c.Context, m map[string]
 	return nil, nil
 }
 
+// ListVolumeGroupsWithPage
+func (fc *FakeDbClient) ListVolumeGroupsWithPage(ctx *c.Context, m map[string][]string) ([]*model.VolumeGroupSpec, *model.PageSpec, error) {
+	vgs, _ := fc.ListVolumeGroupsWithFilter(ctx, m)
+	return vgs, &model.PageSpec{Total: len(vgs)}, nil
+}
+
 func (fc *FakeDbClient) ListVolumeGroups(ctx *c.Context) ([]*model.VolumeGroupSpec, error) {
 	return nil, nil
 }
 
+func (fc *FakeDbClient) CreateGroupSnapshot(ctx *c.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	return &SampleGroupSnapshots[0], nil
+}
+
+func (fc *FakeDbClient) GetGroupSnapshot(ctx *c.Context, gsId string) (*model.GroupSnapshotSpec, error) {
+	gs := SampleGroupSnapshots[0]
+	return &gs, nil
+}
+
+func (fc *FakeDbClient) ListGroupSnapshots(ctx *c.Context) ([]*model.GroupSnapshotSpec, error) {
+	var gss []*model.GroupSnapshotSpec
+	for i := range SampleGroupSnapshots {
+		gss = append(gss, &SampleGroupSnapshots[i])
+	}
+	return gss, nil
+}
+
+func (fc *FakeDbClient) ListGroupSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.GroupSnapshotSpec, error) {
+	return fc.ListGroupSnapshots(ctx)
+}
+
+func (fc *FakeDbClient) UpdateGroupSnapshot(ctx *c.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	return &SampleGroupSnapshots[0], nil
+}
+
+func (fc *FakeDbClient) DeleteGroupSnapshot(ctx *c.Context, gsId string) error {
+	return nil
+}
+
+func (fc *FakeDbClient) CreateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error) {
+	return &SampleFileShares[0], nil
+}
+
+func (fc *FakeDbClient) GetFileShare(ctx *c.Context, fshareId string) (*model.FileShareSpec, error) {
+	fshare := SampleFileShares[0]
+	return &fshare, nil
+}
+
+func (fc *FakeDbClient) ListFileShares(ctx *c.Context) ([]*model.FileShareSpec, error) {
+	var fshares []*model.FileShareSpec
+	for i := range SampleFileShares {
+		fshares = append(fshares, &SampleFileShares[i])
+	}
+	return fshares, nil
+}
+
+func (fc *FakeDbClient) ListFileSharesWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, error) {
+	return fc.ListFileShares(ctx)
+}
+
+// ListFileSharesWithPage
+func (fc *FakeDbClient) ListFileSharesWithPage(ctx *c.Context, m map[string][]string) ([]*model.FileShareSpec, *model.PageSpec, error) {
+	fshares, _ := fc.ListFileSharesWithFilter(ctx, m)
+	return fshares, &model.PageSpec{Total: len(fshares)}, nil
+}
+
+func (fc *FakeDbClient) UpdateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error) {
+	return &SampleFileShares[0], nil
+}
+
+func (fc *FakeDbClient) DeleteFileShare(ctx *c.Context, fshareId string) error {
+	return nil
+}
+
+func (fc *FakeDbClient) CreateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	return &SampleFileShareAcls[0], nil
+}
+
+func (fc *FakeDbClient) GetFileShareAcl(ctx *c.Context, aclId string) (*model.FileShareAclSpec, error) {
+	acl := SampleFileShareAcls[0]
+	return &acl, nil
+}
+
+func (fc *FakeDbClient) ListFileShareAcls(ctx *c.Context) ([]*model.FileShareAclSpec, error) {
+	var acls []*model.FileShareAclSpec
+	for i := range SampleFileShareAcls {
+		acls = append(acls, &SampleFileShareAcls[i])
+	}
+	return acls, nil
+}
+
+func (fc *FakeDbClient) ListFileShareAclsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareAclSpec, error) {
+	return fc.ListFileShareAcls(ctx)
+}
+
+func (fc *FakeDbClient) UpdateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	return &SampleFileShareAcls[0], nil
+}
+
+func (fc *FakeDbClient) DeleteFileShareAcl(ctx *c.Context, aclId string) error {
+	return nil
+}
+
 func (fc *FakeDbClient) VolumesToUpdate(ctx *c.Context, volumeList []*model.VolumeSpec) ([]*model.VolumeSpec, error) {
 	return nil, nil
 }
+
+func (fc *FakeDbClient) GetQuota(ctx *c.Context, tenantId string) (*model.QuotaSpec, error) {
+	return model.NewDefaultQuota(tenantId), nil
+}
+
+func (fc *FakeDbClient) UpdateQuota(ctx *c.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error) {
+	return quota, nil
+}
+
+func (fc *FakeDbClient) ReserveQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	return nil
+}
+
+func (fc *FakeDbClient) ReleaseQuota(ctx *c.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	return nil
+}
+
+// ListEventsWithFilter
+func (fc *FakeDbClient) ListEventsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.EventSpec, error) {
+	var evts []*model.EventSpec
+
+	for i := range SampleEvents {
+		evts = append(evts, &SampleEvents[i])
+	}
+	return evts, nil
+}
+
+// WatchEvents
+func (fc *FakeDbClient) WatchEvents(ctx *c.Context, m map[string][]string, timeout time.Duration) ([]*model.EventSpec, error) {
+	return fc.ListEventsWithFilter(ctx, m)
+}
+
+// CreateSnapshotSchedule
+func (fc *FakeDbClient) CreateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	return sched, nil
+}
+
+// GetSnapshotSchedule
+func (fc *FakeDbClient) GetSnapshotSchedule(ctx *c.Context, schedId string) (*model.SnapshotScheduleSpec, error) {
+	return nil, nil
+}
+
+// ListSnapshotSchedules
+func (fc *FakeDbClient) ListSnapshotSchedules(ctx *c.Context) ([]*model.SnapshotScheduleSpec, error) {
+	return nil, nil
+}
+
+// UpdateSnapshotSchedule
+func (fc *FakeDbClient) UpdateSnapshotSchedule(ctx *c.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	return sched, nil
+}
+
+// DeleteSnapshotSchedule
+func (fc *FakeDbClient) DeleteSnapshotSchedule(ctx *c.Context, schedId string) error {
+	return nil
+}
+
+// CreateJob
+func (fc *FakeDbClient) CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	return job, nil
+}
+
+// GetJob
+func (fc *FakeDbClient) GetJob(ctx *c.Context, jobId string) (*model.JobSpec, error) {
+	return &SampleJobs[0], nil
+}
+
+// ListJobs
+func (fc *FakeDbClient) ListJobs(ctx *c.Context) ([]*model.JobSpec, error) {
+	var jobs []*model.JobSpec
+	for i := range SampleJobs {
+		jobs = append(jobs, &SampleJobs[i])
+	}
+	return jobs, nil
+}
+
+// ListJobsWithFilter
+func (fc *FakeDbClient) ListJobsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.JobSpec, error) {
+	return fc.ListJobs(ctx)
+}
+
+// UpdateJob
+func (fc *FakeDbClient) UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	return job, nil
+}
@@ -5,12 +5,34 @@ import context "github.com/opensds/opensds/pkg/context"
 
 import mock "github.com/stretchr/testify/mock"
 import model "github.com/opensds/opensds/pkg/model"
+import time "time"
 
 // Client is an autogenerated mock type for the Client type
 type Client struct {
 	mock.Mock
 }
 
+// AcquireLock provides a mock function with given fields: ctx, name, holder, ttl
+func (_m *Client) AcquireLock(ctx *context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, name, holder, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, name, holder, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, holder, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddCustomProperty provides a mock function with given fields: ctx, prfID, custom
 func (_m *Client) AddCustomProperty(ctx *context.Context, prfID string, custom model.CustomPropertiesSpec) (*model.CustomPropertiesSpec, error) {
 	ret := _m.Called(ctx, prfID, custom)
@@ -57,6 +79,98 @@ func (_m *Client) CreateDock(ctx *context.Context, dck *model.DockSpec) (*model.
 	return r0, r1
 }
 
+// CreateFileShare provides a mock function with given fields: ctx, fshare
+func (_m *Client) CreateFileShare(ctx *context.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error) {
+	ret := _m.Called(ctx, fshare)
+
+	var r0 *model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.FileShareSpec) *model.FileShareSpec); ok {
+		r0 = rf(ctx, fshare)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.FileShareSpec) error); ok {
+		r1 = rf(ctx, fshare)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFileShareAcl provides a mock function with given fields: ctx, acl
+func (_m *Client) CreateFileShareAcl(ctx *context.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	ret := _m.Called(ctx, acl)
+
+	var r0 *model.FileShareAclSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.FileShareAclSpec) *model.FileShareAclSpec); ok {
+		r0 = rf(ctx, acl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareAclSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.FileShareAclSpec) error); ok {
+		r1 = rf(ctx, acl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupSnapshot provides a mock function with given fields: ctx, gs
+func (_m *Client) CreateGroupSnapshot(ctx *context.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	ret := _m.Called(ctx, gs)
+
+	var r0 *model.GroupSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.GroupSnapshotSpec) *model.GroupSnapshotSpec); ok {
+		r0 = rf(ctx, gs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GroupSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.GroupSnapshotSpec) error); ok {
+		r1 = rf(ctx, gs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJob provides a mock function with given fields: ctx, job
+func (_m *Client) CreateJob(ctx *context.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	ret := _m.Called(ctx, job)
+
+	var r0 *model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.JobSpec) *model.JobSpec); ok {
+		r0 = rf(ctx, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.JobSpec) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePool provides a mock function with given fields: ctx, pol
 func (_m *Client) CreatePool(ctx *context.Context, pol *model.StoragePoolSpec) (*model.StoragePoolSpec, error) {
 	ret := _m.Called(ctx, pol)
@@ -126,6 +240,29 @@ func (_m *Client) CreateReplication(ctx *context.Context, replication *model.Rep
 	return r0, r1
 }
 
+// CreateSnapshotSchedule provides a mock function with given fields: ctx, sched
+func (_m *Client) CreateSnapshotSchedule(ctx *context.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	ret := _m.Called(ctx, sched)
+
+	var r0 *model.SnapshotScheduleSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.SnapshotScheduleSpec) *model.SnapshotScheduleSpec); ok {
+		r0 = rf(ctx, sched)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SnapshotScheduleSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.SnapshotScheduleSpec) error); ok {
+		r1 = rf(ctx, sched)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateVolume provides a mock function with given fields: ctx, vol
 func (_m *Client) CreateVolume(ctx *context.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error) {
 	ret := _m.Called(ctx, vol)
@@ -232,6 +369,48 @@ func (_m *Client) DeleteDock(ctx *context.Context, dckID string) error {
 	return r0
 }
 
+// DeleteFileShare provides a mock function with given fields: ctx, fshareId
+func (_m *Client) DeleteFileShare(ctx *context.Context, fshareId string) error {
+	ret := _m.Called(ctx, fshareId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, fshareId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFileShareAcl provides a mock function with given fields: ctx, aclId
+func (_m *Client) DeleteFileShareAcl(ctx *context.Context, aclId string) error {
+	ret := _m.Called(ctx, aclId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, aclId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGroupSnapshot provides a mock function with given fields: ctx, gsId
+func (_m *Client) DeleteGroupSnapshot(ctx *context.Context, gsId string) error {
+	ret := _m.Called(ctx, gsId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, gsId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePool provides a mock function with given fields: ctx, polID
 func (_m *Client) DeletePool(ctx *context.Context, polID string) error {
 	ret := _m.Called(ctx, polID)
@@ -274,6 +453,20 @@ func (_m *Client) DeleteReplication(ctx *context.Context, replicationId string)
 	return r0
 }
 
+// DeleteSnapshotSchedule provides a mock function with given fields: ctx, schedId
+func (_m *Client) DeleteSnapshotSchedule(ctx *context.Context, schedId string) error {
+	ret := _m.Called(ctx, schedId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, schedId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteVolume provides a mock function with given fields: ctx, volID
 func (_m *Client) DeleteVolume(ctx *context.Context, volID string) error {
 	ret := _m.Called(ctx, volID)
@@ -422,6 +615,98 @@ func (_m *Client) GetDockByPoolId(ctx *context.Context, poolId string) (*model.D
 	return r0, r1
 }
 
+// GetFileShare provides a mock function with given fields: ctx, fshareId
+func (_m *Client) GetFileShare(ctx *context.Context, fshareId string) (*model.FileShareSpec, error) {
+	ret := _m.Called(ctx, fshareId)
+
+	var r0 *model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.FileShareSpec); ok {
+		r0 = rf(ctx, fshareId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, fshareId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFileShareAcl provides a mock function with given fields: ctx, aclId
+func (_m *Client) GetFileShareAcl(ctx *context.Context, aclId string) (*model.FileShareAclSpec, error) {
+	ret := _m.Called(ctx, aclId)
+
+	var r0 *model.FileShareAclSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.FileShareAclSpec); ok {
+		r0 = rf(ctx, aclId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareAclSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, aclId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupSnapshot provides a mock function with given fields: ctx, gsId
+func (_m *Client) GetGroupSnapshot(ctx *context.Context, gsId string) (*model.GroupSnapshotSpec, error) {
+	ret := _m.Called(ctx, gsId)
+
+	var r0 *model.GroupSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.GroupSnapshotSpec); ok {
+		r0 = rf(ctx, gsId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GroupSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, gsId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, jobId
+func (_m *Client) GetJob(ctx *context.Context, jobId string) (*model.JobSpec, error) {
+	ret := _m.Called(ctx, jobId)
+
+	var r0 *model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.JobSpec); ok {
+		r0 = rf(ctx, jobId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, jobId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPool provides a mock function with given fields: ctx, polID
 func (_m *Client) GetPool(ctx *context.Context, polID string) (*model.StoragePoolSpec, error) {
 	ret := _m.Called(ctx, polID)
@@ -468,6 +753,29 @@ func (_m *Client) GetProfile(ctx *context.Context, prfID string) (*model.Profile
 	return r0, r1
 }
 
+// GetQuota provides a mock function with given fields: ctx, tenantId
+func (_m *Client) GetQuota(ctx *context.Context, tenantId string) (*model.QuotaSpec, error) {
+	ret := _m.Called(ctx, tenantId)
+
+	var r0 *model.QuotaSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.QuotaSpec); ok {
+		r0 = rf(ctx, tenantId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QuotaSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, tenantId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReplication provides a mock function with given fields: ctx, replicationId
 func (_m *Client) GetReplication(ctx *context.Context, replicationId string) (*model.ReplicationSpec, error) {
 	ret := _m.Called(ctx, replicationId)
@@ -514,6 +822,29 @@ func (_m *Client) GetReplicationByVolumeId(ctx *context.Context, volumeId string
 	return r0, r1
 }
 
+// GetSnapshotSchedule provides a mock function with given fields: ctx, schedId
+func (_m *Client) GetSnapshotSchedule(ctx *context.Context, schedId string) (*model.SnapshotScheduleSpec, error) {
+	ret := _m.Called(ctx, schedId)
+
+	var r0 *model.SnapshotScheduleSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.SnapshotScheduleSpec); ok {
+		r0 = rf(ctx, schedId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SnapshotScheduleSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, schedId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVolume provides a mock function with given fields: ctx, volID
 func (_m *Client) GetVolume(ctx *context.Context, volID string) (*model.VolumeSpec, error) {
 	ret := _m.Called(ctx, volID)
@@ -606,6 +937,20 @@ func (_m *Client) GetVolumeSnapshot(ctx *context.Context, snapshotID string) (*m
 	return r0, r1
 }
 
+// Heartbeat provides a mock function with given fields: ctx, dckID, ttl
+func (_m *Client) Heartbeat(ctx *context.Context, dckID string, ttl time.Duration) error {
+	ret := _m.Called(ctx, dckID, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, dckID, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ListAvailabilityZones provides a mock function with given fields: ctx
 func (_m *Client) ListAvailabilityZones(ctx *context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
@@ -698,39 +1043,48 @@ func (_m *Client) ListDocksWithFilter(ctx *context.Context, m map[string][]strin
 	return r0, r1
 }
 
-// ListPools provides a mock function with given fields: ctx
-func (_m *Client) ListPools(ctx *context.Context) ([]*model.StoragePoolSpec, error) {
-	ret := _m.Called(ctx)
+// ListDocksWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListDocksWithPage(ctx *context.Context, m map[string][]string) ([]*model.DockSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
 
-	var r0 []*model.StoragePoolSpec
-	if rf, ok := ret.Get(0).(func(*context.Context) []*model.StoragePoolSpec); ok {
-		r0 = rf(ctx)
+	var r0 []*model.DockSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.DockSpec); ok {
+		r0 = rf(ctx, m)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.StoragePoolSpec)
+			r0 = ret.Get(0).([]*model.DockSpec)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
-		r1 = rf(ctx)
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// ListPoolsWithFilter provides a mock function with given fields: ctx, m
-func (_m *Client) ListPoolsWithFilter(ctx *context.Context, m map[string][]string) ([]*model.StoragePoolSpec, error) {
+// ListEventsWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListEventsWithFilter(ctx *context.Context, m map[string][]string) ([]*model.EventSpec, error) {
 	ret := _m.Called(ctx, m)
 
-	var r0 []*model.StoragePoolSpec
-	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.StoragePoolSpec); ok {
+	var r0 []*model.EventSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.EventSpec); ok {
 		r0 = rf(ctx, m)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.StoragePoolSpec)
+			r0 = ret.Get(0).([]*model.EventSpec)
 		}
 	}
 
@@ -744,16 +1098,16 @@ func (_m *Client) ListPoolsWithFilter(ctx *context.Context, m map[string][]strin
 	return r0, r1
 }
 
-// ListProfiles provides a mock function with given fields: ctx
-func (_m *Client) ListProfiles(ctx *context.Context) ([]*model.ProfileSpec, error) {
+// ListFileShareAcls provides a mock function with given fields: ctx
+func (_m *Client) ListFileShareAcls(ctx *context.Context) ([]*model.FileShareAclSpec, error) {
 	ret := _m.Called(ctx)
 
-	var r0 []*model.ProfileSpec
-	if rf, ok := ret.Get(0).(func(*context.Context) []*model.ProfileSpec); ok {
+	var r0 []*model.FileShareAclSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.FileShareAclSpec); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.ProfileSpec)
+			r0 = ret.Get(0).([]*model.FileShareAclSpec)
 		}
 	}
 
@@ -767,11 +1121,328 @@ func (_m *Client) ListProfiles(ctx *context.Context) ([]*model.ProfileSpec, erro
 	return r0, r1
 }
 
-// ListProfilesWithFilter provides a mock function with given fields: ctx, m
-func (_m *Client) ListProfilesWithFilter(ctx *context.Context, m map[string][]string) ([]*model.ProfileSpec, error) {
+// ListFileShareAclsWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListFileShareAclsWithFilter(ctx *context.Context, m map[string][]string) ([]*model.FileShareAclSpec, error) {
 	ret := _m.Called(ctx, m)
 
-	var r0 []*model.ProfileSpec
+	var r0 []*model.FileShareAclSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.FileShareAclSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareAclSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) error); ok {
+		r1 = rf(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFileShares provides a mock function with given fields: ctx
+func (_m *Client) ListFileShares(ctx *context.Context) ([]*model.FileShareSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.FileShareSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFileSharesWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListFileSharesWithFilter(ctx *context.Context, m map[string][]string) ([]*model.FileShareSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.FileShareSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) error); ok {
+		r1 = rf(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFileSharesWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListFileSharesWithPage(ctx *context.Context, m map[string][]string) ([]*model.FileShareSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.FileShareSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListGroupSnapshots provides a mock function with given fields: ctx
+func (_m *Client) ListGroupSnapshots(ctx *context.Context) ([]*model.GroupSnapshotSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.GroupSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.GroupSnapshotSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GroupSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroupSnapshotsWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListGroupSnapshotsWithFilter(ctx *context.Context, m map[string][]string) ([]*model.GroupSnapshotSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.GroupSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.GroupSnapshotSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GroupSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) error); ok {
+		r1 = rf(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHeartbeats provides a mock function with given fields: ctx
+func (_m *Client) ListHeartbeats(ctx *context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(*context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobs provides a mock function with given fields: ctx
+func (_m *Client) ListJobs(ctx *context.Context) ([]*model.JobSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.JobSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobsWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListJobsWithFilter(ctx *context.Context, m map[string][]string) ([]*model.JobSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.JobSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) error); ok {
+		r1 = rf(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPools provides a mock function with given fields: ctx
+func (_m *Client) ListPools(ctx *context.Context) ([]*model.StoragePoolSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.StoragePoolSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.StoragePoolSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StoragePoolSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPoolsWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListPoolsWithFilter(ctx *context.Context, m map[string][]string) ([]*model.StoragePoolSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.StoragePoolSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.StoragePoolSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StoragePoolSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) error); ok {
+		r1 = rf(ctx, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPoolsWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListPoolsWithPage(ctx *context.Context, m map[string][]string) ([]*model.StoragePoolSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.StoragePoolSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.StoragePoolSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StoragePoolSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListProfiles provides a mock function with given fields: ctx
+func (_m *Client) ListProfiles(ctx *context.Context) ([]*model.ProfileSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.ProfileSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.ProfileSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ProfileSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProfilesWithFilter provides a mock function with given fields: ctx, m
+func (_m *Client) ListProfilesWithFilter(ctx *context.Context, m map[string][]string) ([]*model.ProfileSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.ProfileSpec
 	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.ProfileSpec); ok {
 		r0 = rf(ctx, m)
 	} else {
@@ -790,6 +1461,38 @@ func (_m *Client) ListProfilesWithFilter(ctx *context.Context, m map[string][]st
 	return r0, r1
 }
 
+// ListProfilesWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListProfilesWithPage(ctx *context.Context, m map[string][]string) ([]*model.ProfileSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.ProfileSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.ProfileSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ProfileSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListReplication provides a mock function with given fields: ctx
 func (_m *Client) ListReplication(ctx *context.Context) ([]*model.ReplicationSpec, error) {
 	ret := _m.Called(ctx)
@@ -836,6 +1539,61 @@ func (_m *Client) ListReplicationWithFilter(ctx *context.Context, m map[string][
 	return r0, r1
 }
 
+// ListReplicationWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListReplicationWithPage(ctx *context.Context, m map[string][]string) ([]*model.ReplicationSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.ReplicationSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.ReplicationSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ReplicationSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSnapshotSchedules provides a mock function with given fields: ctx
+func (_m *Client) ListSnapshotSchedules(ctx *context.Context) ([]*model.SnapshotScheduleSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.SnapshotScheduleSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.SnapshotScheduleSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SnapshotScheduleSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSnapshotsByVolumeId provides a mock function with given fields: ctx, volId
 func (_m *Client) ListSnapshotsByVolumeId(ctx *context.Context, volId string) ([]*model.VolumeSnapshotSpec, error) {
 	ret := _m.Called(ctx, volId)
@@ -951,6 +1709,38 @@ func (_m *Client) ListVolumeGroupsWithFilter(ctx *context.Context, m map[string]
 	return r0, r1
 }
 
+// ListVolumeGroupsWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListVolumeGroupsWithPage(ctx *context.Context, m map[string][]string) ([]*model.VolumeGroupSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.VolumeGroupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.VolumeGroupSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.VolumeGroupSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListVolumeSnapshots provides a mock function with given fields: ctx
 func (_m *Client) ListVolumeSnapshots(ctx *context.Context) ([]*model.VolumeSnapshotSpec, error) {
 	ret := _m.Called(ctx)
@@ -997,6 +1787,38 @@ func (_m *Client) ListVolumeSnapshotsWithFilter(ctx *context.Context, m map[stri
 	return r0, r1
 }
 
+// ListVolumeSnapshotsWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListVolumeSnapshotsWithPage(ctx *context.Context, m map[string][]string) ([]*model.VolumeSnapshotSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.VolumeSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.VolumeSnapshotSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.VolumeSnapshotSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListVolumes provides a mock function with given fields: ctx
 func (_m *Client) ListVolumes(ctx *context.Context) ([]*model.VolumeSpec, error) {
 	ret := _m.Called(ctx)
@@ -1066,6 +1888,52 @@ func (_m *Client) ListVolumesWithFilter(ctx *context.Context, m map[string][]str
 	return r0, r1
 }
 
+// ListVolumesWithPage provides a mock function with given fields: ctx, m
+func (_m *Client) ListVolumesWithPage(ctx *context.Context, m map[string][]string) ([]*model.VolumeSpec, *model.PageSpec, error) {
+	ret := _m.Called(ctx, m)
+
+	var r0 []*model.VolumeSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string) []*model.VolumeSpec); ok {
+		r0 = rf(ctx, m)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.VolumeSpec)
+		}
+	}
+
+	var r1 *model.PageSpec
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string) *model.PageSpec); ok {
+		r1 = rf(ctx, m)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.PageSpec)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*context.Context, map[string][]string) error); ok {
+		r2 = rf(ctx, m)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReleaseQuota provides a mock function with given fields: ctx, tenantId, delta
+func (_m *Client) ReleaseQuota(ctx *context.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	ret := _m.Called(ctx, tenantId, delta)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.QuotaUsageSpec) error); ok {
+		r0 = rf(ctx, tenantId, delta)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveCustomProperty provides a mock function with given fields: ctx, prfID, customKey
 func (_m *Client) RemoveCustomProperty(ctx *context.Context, prfID string, customKey string) error {
 	ret := _m.Called(ctx, prfID, customKey)
@@ -1080,6 +1948,20 @@ func (_m *Client) RemoveCustomProperty(ctx *context.Context, prfID string, custo
 	return r0
 }
 
+// ReserveQuota provides a mock function with given fields: ctx, tenantId, delta
+func (_m *Client) ReserveQuota(ctx *context.Context, tenantId string, delta *model.QuotaUsageSpec) error {
+	ret := _m.Called(ctx, tenantId, delta)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.QuotaUsageSpec) error); ok {
+		r0 = rf(ctx, tenantId, delta)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDock provides a mock function with given fields: ctx, dckID, name, desp
 func (_m *Client) UpdateDock(ctx *context.Context, dckID string, name string, desp string) (*model.DockSpec, error) {
 	ret := _m.Called(ctx, dckID, name, desp)
@@ -1103,6 +1985,98 @@ func (_m *Client) UpdateDock(ctx *context.Context, dckID string, name string, de
 	return r0, r1
 }
 
+// UpdateFileShare provides a mock function with given fields: ctx, fshare
+func (_m *Client) UpdateFileShare(ctx *context.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error) {
+	ret := _m.Called(ctx, fshare)
+
+	var r0 *model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.FileShareSpec) *model.FileShareSpec); ok {
+		r0 = rf(ctx, fshare)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.FileShareSpec) error); ok {
+		r1 = rf(ctx, fshare)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateFileShareAcl provides a mock function with given fields: ctx, acl
+func (_m *Client) UpdateFileShareAcl(ctx *context.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
+	ret := _m.Called(ctx, acl)
+
+	var r0 *model.FileShareAclSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.FileShareAclSpec) *model.FileShareAclSpec); ok {
+		r0 = rf(ctx, acl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareAclSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.FileShareAclSpec) error); ok {
+		r1 = rf(ctx, acl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroupSnapshot provides a mock function with given fields: ctx, gs
+func (_m *Client) UpdateGroupSnapshot(ctx *context.Context, gs *model.GroupSnapshotSpec) (*model.GroupSnapshotSpec, error) {
+	ret := _m.Called(ctx, gs)
+
+	var r0 *model.GroupSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.GroupSnapshotSpec) *model.GroupSnapshotSpec); ok {
+		r0 = rf(ctx, gs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GroupSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.GroupSnapshotSpec) error); ok {
+		r1 = rf(ctx, gs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateJob provides a mock function with given fields: ctx, job
+func (_m *Client) UpdateJob(ctx *context.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	ret := _m.Called(ctx, job)
+
+	var r0 *model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.JobSpec) *model.JobSpec); ok {
+		r0 = rf(ctx, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.JobSpec) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePool provides a mock function with given fields: ctx, polID, name, desp, usedCapacity, used
 func (_m *Client) UpdatePool(ctx *context.Context, polID string, name string, desp string, usedCapacity int64, used bool) (*model.StoragePoolSpec, error) {
 	ret := _m.Called(ctx, polID, name, desp, usedCapacity, used)
@@ -1149,6 +2123,29 @@ func (_m *Client) UpdateProfile(ctx *context.Context, prfID string, input *model
 	return r0, r1
 }
 
+// UpdateQuota provides a mock function with given fields: ctx, quota
+func (_m *Client) UpdateQuota(ctx *context.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error) {
+	ret := _m.Called(ctx, quota)
+
+	var r0 *model.QuotaSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.QuotaSpec) *model.QuotaSpec); ok {
+		r0 = rf(ctx, quota)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QuotaSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.QuotaSpec) error); ok {
+		r1 = rf(ctx, quota)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateReplication provides a mock function with given fields: ctx, replicationId, input
 func (_m *Client) UpdateReplication(ctx *context.Context, replicationId string, input *model.ReplicationSpec) (*model.ReplicationSpec, error) {
 	ret := _m.Called(ctx, replicationId, input)
@@ -1172,6 +2169,29 @@ func (_m *Client) UpdateReplication(ctx *context.Context, replicationId string,
 	return r0, r1
 }
 
+// UpdateSnapshotSchedule provides a mock function with given fields: ctx, sched
+func (_m *Client) UpdateSnapshotSchedule(ctx *context.Context, sched *model.SnapshotScheduleSpec) (*model.SnapshotScheduleSpec, error) {
+	ret := _m.Called(ctx, sched)
+
+	var r0 *model.SnapshotScheduleSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.SnapshotScheduleSpec) *model.SnapshotScheduleSpec); ok {
+		r0 = rf(ctx, sched)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SnapshotScheduleSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.SnapshotScheduleSpec) error); ok {
+		r1 = rf(ctx, sched)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStatus provides a mock function with given fields: ctx, object, status
 func (_m *Client) UpdateStatus(ctx *context.Context, object interface{}, status string) error {
 	ret := _m.Called(ctx, object, status)
@@ -1300,3 +2320,26 @@ func (_m *Client) VolumesToUpdate(ctx *context.Context, volumeList []*model.Volu
 
 	return r0, r1
 }
+
+// WatchEvents provides a mock function with given fields: ctx, m, timeout
+func (_m *Client) WatchEvents(ctx *context.Context, m map[string][]string, timeout time.Duration) ([]*model.EventSpec, error) {
+	ret := _m.Called(ctx, m, timeout)
+
+	var r0 []*model.EventSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, map[string][]string, time.Duration) []*model.EventSpec); ok {
+		r0 = rf(ctx, m, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EventSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, map[string][]string, time.Duration) error); ok {
+		r1 = rf(ctx, m, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Huawei Technologies Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a sample driver for OpenSDS. This driver will handle all
+operations of file share and return a fake value.
+
+*/
+
+package sample
+
+import (
+	pb "github.com/opensds/opensds/pkg/dock/proto"
+	"github.com/opensds/opensds/pkg/model"
+	. "github.com/opensds/opensds/testutils/collection"
+)
+
+// FileShareDriver
+type FileShareDriver struct{}
+
+// Setup
+func (*FileShareDriver) Setup() error { return nil }
+
+// Unset
+func (*FileShareDriver) Unset() error { return nil }
+
+// CreateFileShare
+func (*FileShareDriver) CreateFileShare(opt *pb.CreateFileShareOpts) (*model.FileShareSpec, error) {
+	return &SampleFileShares[0], nil
+}
+
+// DeleteFileShare
+func (*FileShareDriver) DeleteFileShare(opt *pb.DeleteFileShareOpts) error {
+	return nil
+}
+
+// ExtendFileShare
+func (*FileShareDriver) ExtendFileShare(opt *pb.ExtendFileShareOpts) (*model.FileShareSpec, error) {
+	return &SampleFileShares[0], nil
+}
+
+// CreateFileShareAcl
+func (*FileShareDriver) CreateFileShareAcl(opt *pb.CreateFileShareAclOpts) (*model.FileShareAclSpec, error) {
+	return &SampleFileShareAcls[0], nil
+}
+
+// DeleteFileShareAcl
+func (*FileShareDriver) DeleteFileShareAcl(opt *pb.DeleteFileShareAclOpts) error {
+	return nil
+}
+
+// ListPools
+func (*FileShareDriver) ListPools() ([]*model.StoragePoolSpec, error) {
+	var pols []*model.StoragePoolSpec
+	for i := range SamplePools {
+		pols = append(pols, &SamplePools[i])
+	}
+	return pols, nil
+}
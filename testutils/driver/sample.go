@@ -53,6 +53,11 @@ func (*Driver) PullVolume(volIdentifier string) (*model.VolumeSpec, error) {
 	return nil, errors.New("Can't find volume " + volIdentifier)
 }
 
+// CloneVolume
+func (*Driver) CloneVolume(opt *pb.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
 // DeleteVolume
 func (*Driver) DeleteVolume(opt *pb.DeleteVolumeOpts) error {
 	return nil
@@ -63,6 +68,21 @@ func (*Driver) ExtendVolume(opt *pb.ExtendVolumeOpts) (*model.VolumeSpec, error)
 	return &SampleVolumes[0], nil
 }
 
+// RevertToSnapshot
+func (*Driver) RevertToSnapshot(opt *pb.RevertToSnapshotOpts) error {
+	return nil
+}
+
+// MigrateVolume
+func (*Driver) MigrateVolume(opt *pb.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
+// RetypeVolume
+func (*Driver) RetypeVolume(opt *pb.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	return &SampleVolumes[0], nil
+}
+
 // InitializeConnection
 func (*Driver) InitializeConnection(opt *pb.CreateAttachmentOpts) (*model.ConnectionInfo, error) {
 	return &SampleConnection, nil
@@ -77,14 +97,14 @@ func (*Driver) CreateSnapshot(opt *pb.CreateVolumeSnapshotOpts) (*model.VolumeSn
 }
 
 // PullSnapshot
-func (*Driver) PullSnapshot(snapIdentifier string) (*model.VolumeSnapshotSpec, error) {
+func (*Driver) PullSnapshot(opt *pb.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
 	for _, snapshot := range SampleSnapshots {
-		if snapIdentifier == snapshot.Id {
+		if opt.GetId() == snapshot.Id {
 			return &snapshot, nil
 		}
 	}
 
-	return nil, errors.New("Can't find snapshot " + snapIdentifier)
+	return nil, errors.New("Can't find snapshot " + opt.GetId())
 }
 
 // DeleteSnapshot
@@ -121,3 +141,27 @@ func (d *Driver) UpdateVolumeGroup(opt *pb.UpdateVolumeGroupOpts, vg *model.Volu
 func (d *Driver) DeleteVolumeGroup(opt *pb.DeleteVolumeGroupOpts, vg *model.VolumeGroupSpec, volumes []*model.VolumeSpec) (*model.VolumeGroupSpec, []*model.VolumeSpec, error) {
 	return nil, nil, &model.NotImplementError{"Method UpdateVolumeGroup has not been implemented yet"}
 }
+
+// CreateGroupSnapshot
+func (*Driver) CreateGroupSnapshot(opt *pb.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	var snps []*model.VolumeSnapshotSpec
+	for _, snp := range opt.GetSnapshots() {
+		snps = append(snps, &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{
+				Id: snp.GetId(),
+			},
+			Name:        snp.GetName(),
+			Description: snp.GetDescription(),
+			Size:        snp.GetSize(),
+			VolumeId:    snp.GetVolumeId(),
+			Status:      model.VolumeSnapAvailable,
+			Metadata:    snp.GetMetadata(),
+		})
+	}
+	return snps, nil
+}
+
+// DeleteGroupSnapshot
+func (*Driver) DeleteGroupSnapshot(opt *pb.DeleteGroupSnapshotOpts) error {
+	return nil
+}
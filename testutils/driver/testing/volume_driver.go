@@ -10,6 +10,52 @@ type VolumeDriver struct {
 	mock.Mock
 }
 
+// CloneVolume provides a mock function with given fields: opt
+func (_m *VolumeDriver) CloneVolume(opt *proto.CreateVolumeOpts) (*model.VolumeSpec, error) {
+	ret := _m.Called(opt)
+
+	var r0 *model.VolumeSpec
+	if rf, ok := ret.Get(0).(func(*proto.CreateVolumeOpts) *model.VolumeSpec); ok {
+		r0 = rf(opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VolumeSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*proto.CreateVolumeOpts) error); ok {
+		r1 = rf(opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupSnapshot provides a mock function with given fields: opt
+func (_m *VolumeDriver) CreateGroupSnapshot(opt *proto.CreateGroupSnapshotOpts) ([]*model.VolumeSnapshotSpec, error) {
+	ret := _m.Called(opt)
+
+	var r0 []*model.VolumeSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*proto.CreateGroupSnapshotOpts) []*model.VolumeSnapshotSpec); ok {
+		r0 = rf(opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.VolumeSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*proto.CreateGroupSnapshotOpts) error); ok {
+		r1 = rf(opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateSnapshot provides a mock function with given fields: opt
 func (_m *VolumeDriver) CreateSnapshot(opt *proto.CreateVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
 	ret := _m.Called(opt)
@@ -79,6 +125,20 @@ func (_m *VolumeDriver) CreateVolumeGroup(opt *proto.CreateVolumeGroupOpts, vg *
 	return r0, r1
 }
 
+// DeleteGroupSnapshot provides a mock function with given fields: opt
+func (_m *VolumeDriver) DeleteGroupSnapshot(opt *proto.DeleteGroupSnapshotOpts) error {
+	ret := _m.Called(opt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*proto.DeleteGroupSnapshotOpts) error); ok {
+		r0 = rf(opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteSnapshot provides a mock function with given fields: opt
 func (_m *VolumeDriver) DeleteSnapshot(opt *proto.DeleteVolumeSnapshotOpts) error {
 	ret := _m.Called(opt)
@@ -208,13 +268,36 @@ func (_m *VolumeDriver) ListPools() ([]*model.StoragePoolSpec, error) {
 	return r0, r1
 }
 
-// PullSnapshot provides a mock function with given fields: snapIdentifier
-func (_m *VolumeDriver) PullSnapshot(snapIdentifier string) (*model.VolumeSnapshotSpec, error) {
-	ret := _m.Called(snapIdentifier)
+// MigrateVolume provides a mock function with given fields: opt
+func (_m *VolumeDriver) MigrateVolume(opt *proto.MigrateVolumeOpts) (*model.VolumeSpec, error) {
+	ret := _m.Called(opt)
+
+	var r0 *model.VolumeSpec
+	if rf, ok := ret.Get(0).(func(*proto.MigrateVolumeOpts) *model.VolumeSpec); ok {
+		r0 = rf(opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VolumeSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*proto.MigrateVolumeOpts) error); ok {
+		r1 = rf(opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PullSnapshot provides a mock function with given fields: opt
+func (_m *VolumeDriver) PullSnapshot(opt *proto.PullVolumeSnapshotOpts) (*model.VolumeSnapshotSpec, error) {
+	ret := _m.Called(opt)
 
 	var r0 *model.VolumeSnapshotSpec
-	if rf, ok := ret.Get(0).(func(string) *model.VolumeSnapshotSpec); ok {
-		r0 = rf(snapIdentifier)
+	if rf, ok := ret.Get(0).(func(*proto.PullVolumeSnapshotOpts) *model.VolumeSnapshotSpec); ok {
+		r0 = rf(opt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.VolumeSnapshotSpec)
@@ -222,8 +305,8 @@ func (_m *VolumeDriver) PullSnapshot(snapIdentifier string) (*model.VolumeSnapsh
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(snapIdentifier)
+	if rf, ok := ret.Get(1).(func(*proto.PullVolumeSnapshotOpts) error); ok {
+		r1 = rf(opt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -254,6 +337,43 @@ func (_m *VolumeDriver) PullVolume(volIdentifier string) (*model.VolumeSpec, err
 	return r0, r1
 }
 
+// RetypeVolume provides a mock function with given fields: opt
+func (_m *VolumeDriver) RetypeVolume(opt *proto.RetypeVolumeOpts) (*model.VolumeSpec, error) {
+	ret := _m.Called(opt)
+
+	var r0 *model.VolumeSpec
+	if rf, ok := ret.Get(0).(func(*proto.RetypeVolumeOpts) *model.VolumeSpec); ok {
+		r0 = rf(opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VolumeSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*proto.RetypeVolumeOpts) error); ok {
+		r1 = rf(opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevertToSnapshot provides a mock function with given fields: opt
+func (_m *VolumeDriver) RevertToSnapshot(opt *proto.RevertToSnapshotOpts) error {
+	ret := _m.Called(opt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*proto.RevertToSnapshotOpts) error); ok {
+		r0 = rf(opt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Setup provides a mock function with given fields:
 func (_m *VolumeDriver) Setup() error {
 	ret := _m.Called()